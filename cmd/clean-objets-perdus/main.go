@@ -8,10 +8,7 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
-
-	"entgo.io/ent/dialect"
-	"entgo.io/ent/dialect/sql"
-	_ "github.com/lib/pq"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/database"
 )
 
 func main() {
@@ -23,20 +20,8 @@ func main() {
 		log.Fatalf("❌ Erreur lors du chargement de la configuration: %v", err)
 	}
 
-	// Construire la chaîne de connexion
-	dsn := fmt.Sprintf("host=%s port=%d user=%s dbname=%s sslmode=disable",
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.User,
-		cfg.Database.DBName,
-	)
-
-	if cfg.Database.Password != "" {
-		dsn += fmt.Sprintf(" password=%s", cfg.Database.Password)
-	}
-
 	// Ouvrir la connexion
-	drv, err := sql.Open(dialect.Postgres, dsn)
+	drv, err := database.Open(cfg.Database)
 	if err != nil {
 		log.Fatalf("❌ Erreur lors de l'ouverture de la connexion: %v", err)
 	}
@@ -2,81 +2,179 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
+	"os"
+	"strconv"
 
 	"police-trafic-api-frontend-aligned/ent"
-	"police-trafic-api-frontend-aligned/ent/migrate"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/database"
 
 	"entgo.io/ent/dialect"
-	"entgo.io/ent/dialect/sql"
-	_ "github.com/lib/pq"
 )
 
-func main() {
-	fmt.Println("🔄 Démarrage des migrations...")
+const usage = `Usage: go run ./cmd/migrate [-dir migrations] <commande>
+
+Commandes:
+  status              état des migrations (appliquées, en attente)
+  up [N]              appliquer les N prochaines migrations (toutes par défaut)
+  down [N]            annuler les N dernières migrations (1 par défaut)
+  diff <nom>          générer la migration du schéma ent vers les fichiers de migrations,
+                      sur une base PostgreSQL vide (-dev-db)
+  baseline <version>  marquer comme appliquées, sans les exécuter, les migrations jusqu'à
+                      version (base créée avant les migrations versionnées)
+  hash                recalculer atlas.sum après une modification relue d'une migration
+`
 
+func main() {
 	// Charger la configuration
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		log.Fatalf("❌ Erreur de chargement de la configuration: %v", err)
 	}
 
-	// Construire la chaîne de connexion
-	dsn := fmt.Sprintf("host=%s port=%d user=%s dbname=%s sslmode=disable",
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.User,
-		cfg.Database.DBName,
-	)
-
-	// Ajouter le mot de passe si fourni
-	if cfg.Database.Password != "" {
-		dsn += fmt.Sprintf(" password=%s", cfg.Database.Password)
+	dir := flag.String("dir", cfg.Database.MigrationsDir, "répertoire des migrations")
+	devDB := flag.String("dev-db", cfg.Database.DBName+"_dev", "base PostgreSQL vide utilisée par diff")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+
+	command := flag.Arg(0)
+	if command == "" {
+		command = "up"
 	}
 
-	fmt.Printf("📡 Connexion à la base de données: %s:%d/%s\n", 
-		cfg.Database.Host, cfg.Database.Port, cfg.Database.DBName)
+	fmt.Printf("📡 Connexion à la base de données: %s\n", database.Location(cfg.Database))
 
 	// Ouvrir la connexion
-	drv, err := sql.Open(dialect.Postgres, dsn)
+	drv, err := database.Open(cfg.Database)
 	if err != nil {
 		log.Fatalf("❌ Erreur d'ouverture de la connexion: %v", err)
 	}
 	defer drv.Close()
 
-	// Créer le client Ent
-	client := ent.NewClient(ent.Driver(drv))
-	defer client.Close()
-
-	// Exécuter les migrations avec options de mise à jour du schéma
 	ctx := context.Background()
-	fmt.Println("📦 Exécution des migrations...")
-
-	// Utiliser DropColumn et DropIndex pour mettre à jour le schéma existant
-	if err := client.Schema.Create(
-		ctx,
-		migrate.WithDropColumn(true),
-		migrate.WithDropIndex(true),
-	); err != nil {
-		log.Fatalf("❌ Erreur lors de la migration: %v", err)
+
+	if drv.Dialect() != dialect.Postgres {
+		// La base SQLite de développement est créée depuis le schéma ent, sans migrations versionnées
+		if command != "up" {
+			log.Fatalf("❌ %s: les migrations versionnées ne concernent que PostgreSQL", command)
+		}
+		client := ent.NewClient(ent.Driver(drv))
+		if err := client.Schema.Create(ctx); err != nil {
+			log.Fatalf("❌ Erreur lors de la création du schéma: %v", err)
+		}
+		fmt.Println("✅ Schéma créé depuis les entités ent")
+		return
+	}
+
+	migrator, err := database.NewMigrator(drv.DB(), *dir)
+	if err != nil {
+		log.Fatalf("❌ %v", err)
+	}
+
+	switch command {
+	case "status":
+		migrations, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("❌ Erreur de lecture des migrations: %v", err)
+		}
+		if len(migrations) == 0 {
+			fmt.Printf("📭 Aucune migration dans %s\n", *dir)
+			return
+		}
+		pending := 0
+		fmt.Println("\n📋 Migrations:")
+		for _, m := range migrations {
+			if m.AppliedAt != nil {
+				fmt.Printf("   ✓ %s_%s (appliquée le %s)\n", m.Version, m.Name, m.AppliedAt.Format("02/01/2006 15:04"))
+			} else {
+				fmt.Printf("   … %s_%s (en attente)\n", m.Version, m.Name)
+				pending++
+			}
+		}
+		fmt.Printf("\n%d migration(s) en attente\n", pending)
+
+	case "up":
+		fmt.Println("📦 Exécution des migrations...")
+		applied, err := migrator.Up(ctx, count(1, 0))
+		for _, m := range applied {
+			fmt.Printf("   ✓ %s_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatalf("❌ Erreur lors de la migration: %v", err)
+		}
+		if len(applied) == 0 {
+			fmt.Println("✅ Base de données à jour")
+		} else {
+			fmt.Printf("✅ %d migration(s) appliquée(s)\n", len(applied))
+		}
+
+	case "down":
+		fmt.Println("⏪ Annulation des migrations...")
+		reverted, err := migrator.Down(ctx, count(1, 1))
+		for _, m := range reverted {
+			fmt.Printf("   ✓ %s_%s annulée\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatalf("❌ Erreur lors de l'annulation: %v", err)
+		}
+		fmt.Printf("✅ %d migration(s) annulée(s)\n", len(reverted))
+
+	case "diff":
+		name := flag.Arg(1)
+		if name == "" {
+			log.Fatalf("❌ Nom de la migration requis: go run ./cmd/migrate diff <nom>")
+		}
+		devCfg := cfg.Database
+		devCfg.DBName = *devDB
+		dev, err := database.Open(devCfg)
+		if err != nil {
+			log.Fatalf("❌ Erreur d'ouverture de la base %s: %v", *devDB, err)
+		}
+		defer dev.Close()
+
+		fmt.Printf("🔍 Comparaison du schéma ent aux migrations (base %s)...\n", *devDB)
+		if err := migrator.Diff(ctx, dev, name); err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		fmt.Printf("✅ Migration générée dans %s : relisez-la avant de la committer\n", *dir)
+
+	case "baseline":
+		version := flag.Arg(1)
+		if version == "" {
+			log.Fatalf("❌ Version requise: go run ./cmd/migrate baseline <version>")
+		}
+		marked, err := migrator.Baseline(ctx, version)
+		for _, m := range marked {
+			fmt.Printf("   ✓ %s_%s marquée comme appliquée\n", m.Version, m.Name)
+		}
+		if err != nil {
+			log.Fatalf("❌ %v", err)
+		}
+		fmt.Printf("✅ %d migration(s) marquée(s) comme appliquée(s)\n", len(marked))
+
+	case "hash":
+		if err := migrator.Hash(); err != nil {
+			log.Fatalf("❌ Erreur de calcul de atlas.sum: %v", err)
+		}
+		fmt.Println("✅ atlas.sum mis à jour")
+
+	default:
+		flag.Usage()
+		os.Exit(2)
 	}
+}
 
-	fmt.Println("✅ Migrations exécutées avec succès!")
-	
-	// Afficher les tables créées
-	fmt.Println("\n📋 Tables créées:")
-	tables := []string{
-		"users",
-		"infraction_types", 
-		"controles",
-		// Ajouter d'autres tables quand elles seront créées
+// count reads the optional number of migrations argument
+func count(arg, def int) int {
+	if flag.Arg(arg) == "" {
+		return def
 	}
-	
-	for _, table := range tables {
-		fmt.Printf("   ✓ %s\n", table)
+	n, err := strconv.Atoi(flag.Arg(arg))
+	if err != nil || n <= 0 {
+		log.Fatalf("❌ Nombre de migrations invalide: %s", flag.Arg(arg))
 	}
-	
-	fmt.Println("\n🎉 Migration terminée!")
-}
\ No newline at end of file
+	return n
+}
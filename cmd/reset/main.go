@@ -2,13 +2,13 @@ package main
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"log"
 
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/database"
 
-	_ "github.com/lib/pq"
+	"entgo.io/ent/dialect"
 )
 
 func main() {
@@ -20,31 +20,28 @@ func main() {
 		log.Fatalf("❌ Erreur de chargement de la configuration: %v", err)
 	}
 
-	// Construire la chaîne de connexion
-	dsn := fmt.Sprintf("host=%s port=%d user=%s dbname=%s sslmode=disable",
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.User,
-		cfg.Database.DBName,
-	)
-
-	// Ajouter le mot de passe si fourni
-	if cfg.Database.Password != "" {
-		dsn += fmt.Sprintf(" password=%s", cfg.Database.Password)
-	}
-
-	fmt.Printf("📡 Connexion à la base de données: %s:%d/%s\n",
-		cfg.Database.Host, cfg.Database.Port, cfg.Database.DBName)
+	fmt.Printf("📡 Connexion à la base de données: %s\n", database.Location(cfg.Database))
 
 	// Ouvrir la connexion directe
-	db, err := sql.Open("postgres", dsn)
+	drv, err := database.Open(cfg.Database)
 	if err != nil {
 		log.Fatalf("❌ Erreur d'ouverture de la connexion: %v", err)
 	}
-	defer db.Close()
+	defer drv.Close()
+	db := drv.DB()
 
 	ctx := context.Background()
 
+	drop := "DROP TABLE IF EXISTS %s CASCADE"
+	if drv.Dialect() == dialect.SQLite {
+		// SQLite ne connaît pas CASCADE : clés étrangères désactivées sur l'unique connexion utilisée
+		drop = "DROP TABLE IF EXISTS %s"
+		db.SetMaxOpenConns(1)
+		if _, err := db.ExecContext(ctx, "PRAGMA foreign_keys = OFF"); err != nil {
+			log.Fatalf("❌ Erreur de désactivation des clés étrangères: %v", err)
+		}
+	}
+
 	// Tables à supprimer dans l'ordre (pour respecter les contraintes de clé étrangère)
 	tables := []string{
 		// Many-to-many junction tables first
@@ -74,11 +71,13 @@ func main() {
 		"infraction_types",
 		"users",
 		"commissariats",
+		// Historique des migrations versionnées
+		"schema_migrations",
 	}
 
 	fmt.Println("\n📦 Suppression des tables...")
 	for _, table := range tables {
-		_, err := db.ExecContext(ctx, fmt.Sprintf("DROP TABLE IF EXISTS %s CASCADE", table))
+		_, err := db.ExecContext(ctx, fmt.Sprintf(drop, table))
 		if err != nil {
 			fmt.Printf("   ⚠️  Erreur pour %s: %v\n", table, err)
 		} else {
@@ -87,5 +86,5 @@ func main() {
 	}
 
 	fmt.Println("\n✅ Base de données réinitialisée!")
-	fmt.Println("Exécutez maintenant: go run cmd/migrate/main.go && go run cmd/seed/main.go")
+	fmt.Println("Exécutez maintenant: go run ./cmd/migrate up && go run ./cmd/seed")
 }
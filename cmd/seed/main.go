@@ -12,11 +12,9 @@ import (
 	"police-trafic-api-frontend-aligned/ent/controle"
 	"police-trafic-api-frontend-aligned/ent/inspection"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/database"
 
-	"entgo.io/ent/dialect"
-	"entgo.io/ent/dialect/sql"
 	"github.com/google/uuid"
-	_ "github.com/lib/pq"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -42,20 +40,8 @@ func main() {
 		log.Fatalf("❌ Erreur de chargement de la configuration: %v", err)
 	}
 
-	// Construire la chaîne de connexion
-	dsn := fmt.Sprintf("host=%s port=%d user=%s dbname=%s sslmode=disable",
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.User,
-		cfg.Database.DBName,
-	)
-
-	if cfg.Database.Password != "" {
-		dsn += fmt.Sprintf(" password=%s", cfg.Database.Password)
-	}
-
 	// Ouvrir la connexion
-	drv, err := sql.Open(dialect.Postgres, dsn)
+	drv, err := database.Open(cfg.Database)
 	if err != nil {
 		log.Fatalf("❌ Erreur d'ouverture de la connexion: %v", err)
 	}
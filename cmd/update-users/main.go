@@ -8,11 +8,9 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/database"
 
-	"entgo.io/ent/dialect"
-	"entgo.io/ent/dialect/sql"
 	"github.com/google/uuid"
-	_ "github.com/lib/pq"
 )
 
 // idMap stores the mapping between symbolic IDs and actual UUIDs
@@ -36,18 +34,7 @@ func main() {
 		log.Fatalf("❌ Erreur de chargement de la configuration: %v", err)
 	}
 
-	dsn := fmt.Sprintf("host=%s port=%d user=%s dbname=%s sslmode=disable",
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.User,
-		cfg.Database.DBName,
-	)
-
-	if cfg.Database.Password != "" {
-		dsn += fmt.Sprintf(" password=%s", cfg.Database.Password)
-	}
-
-	drv, err := sql.Open(dialect.Postgres, dsn)
+	drv, err := database.Open(cfg.Database)
 	if err != nil {
 		log.Fatalf("❌ Erreur d'ouverture de la connexion: %v", err)
 	}
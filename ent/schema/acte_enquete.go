@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// ActeEnquete holds the schema definition for the ActeEnquete entity.
+type ActeEnquete struct {
+	ent.Schema
+}
+
+// Fields of the ActeEnquete.
+func (ActeEnquete) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.UUID("plainte_id", uuid.UUID{}),
+		field.Enum("type").
+			Values("AUDITION", "PERQUISITION", "EXPERTISE", "GARDE_A_VUE", "CONFRONTATION", "RECONSTITUTION"),
+		field.Time("date"),
+		field.String("heure").
+			Optional(),
+		field.String("duree").
+			Optional(),
+		field.String("lieu").
+			Optional(),
+		field.String("officier_charge"),
+		field.Text("description"),
+		field.String("pv_numero").
+			Optional(),
+		field.String("mandat_numero").
+			Optional(),
+		field.Strings("personnes_presentes").
+			Optional(),
+		field.Strings("objets_saisis").
+			Optional(),
+		field.Text("conclusions").
+			Optional(),
+		field.Strings("documents_joints").
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now),
+	}
+}
+
+// Edges of the ActeEnquete.
+func (ActeEnquete) Edges() []ent.Edge {
+	return []ent.Edge{
+		// Un acte d'enquête appartient à une plainte
+		edge.From("plainte", Plainte.Type).
+			Ref("actes_enquete").
+			Field("plainte_id").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the ActeEnquete.
+func (ActeEnquete) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("plainte_id"),
+		index.Fields("date"),
+	}
+}
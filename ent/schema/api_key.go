@@ -0,0 +1,67 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// APIKey holds the schema definition for the APIKey entity.
+// Clé d'API d'un compte technique; seul son hash est conservé.
+type APIKey struct {
+	ent.Schema
+}
+
+// Fields of the APIKey.
+func (APIKey) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.UUID("service_account_id", uuid.UUID{}),
+		field.String("prefix").
+			NotEmpty().
+			Unique().
+			Comment("Partie publique de la clé, pour la retrouver et l'identifier dans les journaux"),
+		field.String("key_hash").
+			NotEmpty().
+			Sensitive().
+			Comment("SHA-256 de la clé complète"),
+		field.Time("expires_at").
+			Optional().
+			Nillable(),
+		field.Time("revoked_at").
+			Optional().
+			Nillable(),
+		field.Time("last_used_at").
+			Optional().
+			Nillable(),
+		field.String("last_used_ip").
+			Optional(),
+		field.String("created_by").
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now),
+	}
+}
+
+// Edges of the APIKey.
+func (APIKey) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("service_account", ServiceAccount.Type).
+			Ref("keys").
+			Field("service_account_id").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the APIKey.
+func (APIKey) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("service_account_id"),
+	}
+}
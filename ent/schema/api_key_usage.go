@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// APIKeyUsage holds the schema definition for the APIKeyUsage entity.
+// Trace de chaque requête authentifiée par une clé d'API.
+type APIKeyUsage struct {
+	ent.Schema
+}
+
+// Fields of the APIKeyUsage.
+func (APIKeyUsage) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.UUID("service_account_id", uuid.UUID{}),
+		field.UUID("api_key_id", uuid.UUID{}),
+		field.String("key_prefix"),
+		field.String("method"),
+		field.String("path"),
+		field.String("route").
+			Optional(),
+		field.Int("status_code"),
+		field.String("ip_address").
+			Optional(),
+		field.Int64("duration_ms").
+			Default(0),
+		field.Time("created_at").
+			Default(time.Now),
+	}
+}
+
+// Indexes of the APIKeyUsage.
+func (APIKeyUsage) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("service_account_id", "created_at"),
+		index.Fields("created_at"),
+	}
+}
@@ -0,0 +1,301 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Convocation holds the schema definition for the Convocation entity.
+type Convocation struct {
+	ent.Schema
+}
+
+// Mixin of the Convocation.
+func (Convocation) Mixin() []ent.Mixin {
+	return []ent.Mixin{
+		CodeMixin{},
+	}
+}
+
+// Fields of the Convocation.
+func (Convocation) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("numero").
+			Unique(),
+		// Informations générales
+		field.String("reference").
+			Optional().
+			Nillable(),
+		field.String("type_convocation"),
+		field.String("sous_type").
+			Optional().
+			Nillable(),
+		field.Enum("urgence").
+			Values("NORMALE", "URGENT", "TRES_URGENT").
+			Default("NORMALE"),
+		field.Enum("priorite").
+			Values("BASSE", "MOYENNE", "HAUTE", "CRITIQUE").
+			Default("MOYENNE"),
+		field.Enum("confidentialite").
+			Values("STANDARD", "CONFIDENTIEL", "TRES_CONFIDENTIEL", "SECRET_DEFENSE").
+			Default("STANDARD"),
+		// Affaire liée
+		field.String("affaire_id").
+			Optional().
+			Nillable(),
+		field.String("affaire_type").
+			Optional().
+			Nillable(),
+		field.String("affaire_numero").
+			Optional().
+			Nillable(),
+		field.String("affaire_titre").
+			Optional().
+			Nillable(),
+		field.String("affaire_liee").
+			Optional().
+			Nillable().
+			Comment("Alias de affaire_numero"),
+		field.String("section_judiciaire").
+			Optional().
+			Nillable(),
+		field.String("infraction").
+			Optional().
+			Nillable(),
+		field.String("qualification_legale").
+			Optional().
+			Nillable(),
+		// Personne convoquée
+		field.String("statut_personne"),
+		field.String("qualite_convoque").
+			Optional().
+			Comment("Alias de statut_personne"),
+		field.String("convoque_nom"),
+		field.String("convoque_prenom"),
+		field.String("date_naissance").
+			Optional().
+			Nillable(),
+		field.String("lieu_naissance").
+			Optional().
+			Nillable(),
+		field.String("nationalite").
+			Optional().
+			Nillable(),
+		// Pièce d'identité
+		field.String("type_piece"),
+		field.String("numero_piece"),
+		field.String("date_delivrance_piece").
+			Optional().
+			Nillable(),
+		field.String("lieu_delivrance_piece").
+			Optional().
+			Nillable(),
+		field.String("date_expiration_piece").
+			Optional().
+			Nillable(),
+		// Contact
+		field.String("convoque_telephone").
+			Optional(),
+		field.String("convoque_telephone2").
+			Optional().
+			Nillable(),
+		field.String("convoque_email").
+			Optional().
+			Nillable(),
+		field.String("convoque_adresse").
+			Optional().
+			Nillable().
+			Comment("Alias de adresse_residence"),
+		field.String("adresse_residence").
+			Optional().
+			Nillable(),
+		field.String("adresse_professionnelle").
+			Optional().
+			Nillable(),
+		field.String("dernier_lieu_connu").
+			Optional().
+			Nillable(),
+		// Informations complémentaires
+		field.String("profession").
+			Optional().
+			Nillable(),
+		field.String("situation_familiale").
+			Optional().
+			Nillable(),
+		field.String("nombre_enfants").
+			Optional().
+			Nillable(),
+		field.String("sexe").
+			Optional().
+			Nillable(),
+		field.String("taille").
+			Optional().
+			Nillable(),
+		field.String("poids").
+			Optional().
+			Nillable(),
+		field.Text("signes_particuliers").
+			Optional().
+			Nillable(),
+		field.Bool("photo_identite").
+			Default(false),
+		field.Bool("empreintes").
+			Default(false),
+		// Dates
+		field.Time("date_creation").
+			Default(time.Now),
+		field.String("heure_convocation").
+			Optional().
+			Nillable(),
+		field.Time("date_rdv").
+			Optional().
+			Nillable(),
+		field.String("heure_rdv").
+			Optional().
+			Nillable(),
+		field.Int("duree_estimee").
+			Optional().
+			Nillable(),
+		field.String("type_audience").
+			Optional(),
+		field.Time("date_envoi").
+			Optional().
+			Nillable(),
+		field.Time("date_honoration").
+			Optional().
+			Nillable(),
+		// Statut
+		field.Enum("statut").
+			Values("CRÉATION", "ENVOYÉ", "HONORÉ", "EN ATTENTE", "CONFIRMÉ", "NON HONORÉ", "ANNULÉ").
+			Default("CRÉATION"),
+		// Lieu
+		field.String("lieu_rdv").
+			Optional(),
+		field.String("bureau").
+			Optional().
+			Nillable(),
+		field.String("salle_audience").
+			Optional().
+			Nillable(),
+		field.String("point_rencontre").
+			Optional().
+			Nillable(),
+		field.Text("acces_specifique").
+			Optional().
+			Nillable(),
+		// Personnes présentes
+		field.String("convocateur_nom").
+			Optional(),
+		field.String("convocateur_prenom").
+			Optional(),
+		field.String("convocateur_matricule").
+			Optional().
+			Nillable(),
+		field.String("convocateur_fonction").
+			Optional().
+			Nillable(),
+		field.Text("agents_presents").
+			Optional().
+			Nillable(),
+		field.Bool("representant_parquet").
+			Default(false),
+		field.String("nom_parquetier").
+			Optional().
+			Nillable(),
+		field.Bool("expert_present").
+			Default(false),
+		field.String("type_expert").
+			Optional().
+			Nillable(),
+		field.Bool("interprete_necessaire").
+			Default(false),
+		field.String("langue_interpretation").
+			Optional().
+			Nillable(),
+		field.Bool("avocat_present").
+			Default(false),
+		field.String("nom_avocat").
+			Optional().
+			Nillable(),
+		field.String("barreau_avocat").
+			Optional().
+			Nillable(),
+		// Motif et observations
+		field.Text("motif").
+			Optional(),
+		field.Text("objet_precis").
+			Optional().
+			Nillable(),
+		field.Text("questions_preparatoires").
+			Optional().
+			Nillable(),
+		field.Text("pieces_a_apporter").
+			Optional().
+			Nillable(),
+		field.Text("documents_demandes").
+			Optional().
+			Nillable(),
+		field.Text("observations").
+			Optional().
+			Nillable(),
+		field.Text("resultat_audition").
+			Optional().
+			Nillable(),
+		// Mode d'envoi
+		field.String("mode_envoi").
+			Default("MANUEL"),
+		field.String("reference_envoi").
+			Optional(),
+		// Données complètes du formulaire et historique des actions
+		field.JSON("donnees_completes", map[string]interface{}{}).
+			Optional(),
+		field.JSON("historique", []map[string]interface{}{}).
+			Optional(),
+		// Version (ETag / If-Match)
+		field.Int("version").
+			Default(1).
+			Comment("Version envoyée en ETag, incrémentée par chaque modification"),
+		// Timestamps
+		field.Time("created_at").
+			Default(time.Now),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the Convocation.
+func (Convocation) Edges() []ent.Edge {
+	return []ent.Edge{
+		// Une convocation appartient à une plainte
+		edge.From("plainte", Plainte.Type).
+			Ref("convocations").
+			Unique(),
+		// Une convocation est créée par un agent
+		edge.From("agent", User.Type).
+			Ref("convocations_creees").
+			Unique(),
+		// Commissariat de rattachement
+		edge.From("commissariat", Commissariat.Type).
+			Ref("convocations").
+			Unique(),
+	}
+}
+
+// Indexes of the Convocation.
+func (Convocation) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("numero"),
+		index.Fields("type_convocation"),
+		index.Fields("statut"),
+		index.Fields("date_rdv"),
+		index.Fields("date_creation"),
+	}
+}
@@ -0,0 +1,65 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Decision holds the schema definition for the Decision entity.
+type Decision struct {
+	ent.Schema
+}
+
+// Fields of the Decision.
+func (Decision) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.UUID("plainte_id", uuid.UUID{}),
+		field.Enum("type").
+			Values("CLASSEMENT", "POURSUITE", "RENVOI", "ACQUITTEMENT", "CONDAMNATION", "NON_LIEU", "AUTRE"),
+		field.Time("date_decision"),
+		field.String("autorite"),
+		field.Text("description"),
+		field.Text("motivation").
+			Optional(),
+		field.Strings("dispositions").
+			Optional(),
+		field.Text("suites").
+			Optional(),
+		field.String("document_reference").
+			Optional(),
+		field.Bool("notifiee").
+			Default(false),
+		field.Time("date_notification").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now),
+	}
+}
+
+// Edges of the Decision.
+func (Decision) Edges() []ent.Edge {
+	return []ent.Edge{
+		// Une décision appartient à une plainte
+		edge.From("plainte", Plainte.Type).
+			Ref("decisions").
+			Field("plainte_id").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the Decision.
+func (Decision) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("plainte_id"),
+		index.Fields("date_decision"),
+	}
+}
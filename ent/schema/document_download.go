@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// DocumentDownload holds the schema definition for the DocumentDownload entity.
+// Téléchargement d'un document par un lien signé, sans authentification.
+type DocumentDownload struct {
+	ent.Schema
+}
+
+// Fields of the DocumentDownload.
+func (DocumentDownload) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.UUID("document_id", uuid.UUID{}),
+		field.String("nonce").
+			Optional().
+			Comment("Identifiant du lien ; vide pour les liens des documents publics"),
+		field.Bool("single_use").
+			Default(false),
+		field.Bool("public").
+			Default(false).
+			Comment("Lien d'un document public"),
+		field.Time("link_expires_at"),
+		field.String("ip_address").
+			Optional(),
+		field.String("user_agent").
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now),
+	}
+}
+
+// Edges of the DocumentDownload.
+func (DocumentDownload) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("document", Document.Type).
+			Ref("downloads").
+			Field("document_id").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the DocumentDownload.
+func (DocumentDownload) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("document_id", "created_at"),
+		// Un lien à usage unique ne sert qu'une fois
+		index.Fields("nonce").
+			Unique().
+			Annotations(entsql.IndexWhere("single_use")),
+	}
+}
@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Enquete holds the schema definition for the Enquete entity.
+type Enquete struct {
+	ent.Schema
+}
+
+// Fields of the Enquete.
+func (Enquete) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.UUID("plainte_id", uuid.UUID{}),
+		field.Enum("type").
+			Values("AUDITION", "PERQUISITION", "EXPERTISE", "SURVEILLANCE", "AUTRE"),
+		field.String("officier_charge"),
+		field.Time("date_debut"),
+		field.Time("date_fin").
+			Optional().
+			Nillable(),
+		field.String("lieu").
+			Optional(),
+		field.Text("description"),
+		field.Text("resultats").
+			Optional(),
+		field.Strings("personnes_interrogees").
+			Optional(),
+		field.Strings("preuves_collectees").
+			Optional(),
+		field.Text("conclusions").
+			Optional(),
+		field.Enum("statut").
+			Values("EN_COURS", "TERMINEE", "SUSPENDUE").
+			Default("EN_COURS"),
+		field.Strings("documents").
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now),
+	}
+}
+
+// Edges of the Enquete.
+func (Enquete) Edges() []ent.Edge {
+	return []ent.Edge{
+		// Une enquête appartient à une plainte
+		edge.From("plainte", Plainte.Type).
+			Ref("enquetes").
+			Field("plainte_id").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the Enquete.
+func (Enquete) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("plainte_id"),
+		index.Fields("statut"),
+	}
+}
@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// IdempotencyKey holds the schema definition for the IdempotencyKey entity.
+// Première réponse d'une requête envoyée avec l'en-tête Idempotency-Key, rejouée aux nouvelles tentatives.
+type IdempotencyKey struct {
+	ent.Schema
+}
+
+// Fields of the IdempotencyKey.
+func (IdempotencyKey) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("key").
+			NotEmpty().
+			MaxLen(255).
+			Comment("Valeur de l'en-tête Idempotency-Key"),
+		field.String("user_id").
+			NotEmpty().
+			Comment("Utilisateur ou compte de service qui a envoyé la requête"),
+		field.String("route").
+			NotEmpty().
+			Comment("Méthode et chemin de la requête: POST /api/v1/pv/:id/payer avec l'ID"),
+		field.String("request_hash").
+			NotEmpty().
+			Comment("SHA-256 du corps de la requête, pour refuser une clé réutilisée avec un autre corps"),
+		field.Int("status_code").
+			Optional().
+			Nillable().
+			Comment("Vide tant que la première requête est en cours"),
+		field.String("content_type").
+			Optional(),
+		field.Bytes("response_body").
+			Optional(),
+		field.Time("locked_until").
+			Optional().
+			Nillable().
+			Comment("Expiration du verrou de la requête en cours si l'instance s'arrête brutalement"),
+		field.Time("expires_at"),
+		field.Time("created_at").
+			Default(time.Now),
+		field.Time("completed_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Indexes of the IdempotencyKey.
+func (IdempotencyKey) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "route", "key").
+			Unique(),
+		index.Fields("expires_at"),
+	}
+}
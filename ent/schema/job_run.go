@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// JobRun holds the schema definition for the JobRun entity.
+type JobRun struct {
+	ent.Schema
+}
+
+// Fields of the JobRun.
+func (JobRun) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("job_name").
+			NotEmpty(),
+		field.String("trigger").
+			Default("SCHEDULED"), // SCHEDULED, MANUAL
+		field.String("triggered_by").
+			Optional().
+			Comment("Matricule de l'administrateur pour un déclenchement manuel"),
+		field.String("instance_id").
+			Optional(),
+		field.String("status").
+			Default("RUNNING"), // RUNNING, SUCCESS, FAILURE
+		field.Time("started_at").
+			Default(time.Now),
+		field.Time("finished_at").
+			Optional().
+			Nillable(),
+		field.Int64("duration_ms").
+			Optional(),
+		field.Text("result").
+			Optional(),
+		field.Text("error_message").
+			Optional(),
+	}
+}
+
+// Indexes of the JobRun.
+func (JobRun) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("job_name", "started_at"),
+		index.Fields("status"),
+	}
+}
@@ -0,0 +1,58 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// JobState holds the schema definition for the JobState entity.
+// Une ligne par tâche planifiée : elle sert de verrou partagé entre les instances du serveur.
+type JobState struct {
+	ent.Schema
+}
+
+// Fields of the JobState.
+func (JobState) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("name").
+			NotEmpty().
+			Unique().
+			Comment("Nom de la tâche: pv-marquer-en-retard, session-cleanup, etc."),
+		field.Bool("paused").
+			Default(false),
+		field.Time("next_run_at").
+			Optional().
+			Nillable().
+			Comment("Prochaine exécution planifiée"),
+		field.String("locked_by").
+			Optional().
+			Comment("Instance qui exécute actuellement la tâche"),
+		field.Time("locked_until").
+			Optional().
+			Nillable().
+			Comment("Expiration du verrou si l'instance s'arrête brutalement"),
+		field.Time("last_run_at").
+			Optional().
+			Nillable(),
+		field.String("last_status").
+			Optional(), // SUCCESS, FAILURE
+		field.Time("created_at").
+			Default(time.Now),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Indexes of the JobState.
+func (JobState) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("next_run_at"),
+	}
+}
@@ -0,0 +1,56 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// LoginThrottle holds the schema definition for the LoginThrottle entity.
+// Compteur d'échecs de connexion, par compte utilisateur ou par adresse IP.
+type LoginThrottle struct {
+	ent.Schema
+}
+
+// Fields of the LoginThrottle.
+func (LoginThrottle) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("kind").
+			NotEmpty(), // account, ip
+		field.String("key").
+			NotEmpty().
+			Comment("ID de l'utilisateur ou adresse IP"),
+		field.Int("failures").
+			Default(0).
+			Comment("Échecs consécutifs depuis la dernière connexion réussie"),
+		field.Time("last_failure_at").
+			Default(time.Now),
+		field.Time("next_attempt_at").
+			Optional().
+			Nillable().
+			Comment("Délai progressif : aucune tentative acceptée avant cette date"),
+		field.Time("locked_until").
+			Optional().
+			Nillable().
+			Comment("Verrouillage temporaire du compte, levé par un administrateur ou à expiration"),
+		field.Time("created_at").
+			Default(time.Now),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Indexes of the LoginThrottle.
+func (LoginThrottle) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("kind", "key").
+			Unique(),
+		index.Fields("last_failure_at"),
+	}
+}
@@ -0,0 +1,38 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// MFAVerification holds the schema definition for the MFAVerification entity.
+// Trace de la vérification du second facteur lors de l'ouverture d'une session.
+type MFAVerification struct {
+	ent.Schema
+}
+
+// Fields of the MFAVerification.
+func (MFAVerification) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.UUID("session_id", uuid.UUID{}).
+			Unique(),
+		field.UUID("user_id", uuid.UUID{}),
+		field.String("method").
+			NotEmpty(), // totp, recovery_code
+		field.Time("verified_at").
+			Default(time.Now),
+	}
+}
+
+// Indexes of the MFAVerification.
+func (MFAVerification) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id"),
+	}
+}
@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Notification holds the schema definition for the Notification entity.
+// Chaque envoi (SMS, email, push) est conservé avec son statut de livraison.
+type Notification struct {
+	ent.Schema
+}
+
+// Fields of the Notification.
+func (Notification) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("channel").
+			NotEmpty(), // SMS, EMAIL, PUSH
+		field.String("recipient").
+			NotEmpty().
+			Comment("Numéro de téléphone, adresse email ou identifiant utilisateur (push)"),
+		field.String("template").
+			Optional(),
+		field.String("subject").
+			Optional(),
+		field.Text("body"),
+		field.String("status").
+			Default("PENDING"), // PENDING, SENDING, SENT, FAILED
+		field.Int("attempts").
+			Default(0),
+		field.Int("max_attempts").
+			Default(5),
+		field.Time("next_attempt_at").
+			Default(time.Now).
+			Comment("Prochaine tentative, ou fin du verrou pendant l'envoi"),
+		field.Text("last_error").
+			Optional(),
+		field.String("provider").
+			Optional().
+			Comment("Adaptateur ayant livré le message: smtp, http, log"),
+		field.Time("sent_at").
+			Optional().
+			Nillable(),
+		field.String("resource_type").
+			Optional(), // Convocation, ProcesVerbal, AlerteSecuritaire
+		field.String("resource_id").
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Indexes of the Notification.
+func (Notification) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("status", "next_attempt_at"),
+		index.Fields("resource_type", "resource_id"),
+		index.Fields("recipient"),
+	}
+}
@@ -0,0 +1,46 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// NumberSequence holds the schema definition for the NumberSequence entity.
+// One row per document type, commissariat and year keeps the last number
+// issued; it is incremented in the transaction that creates the document.
+type NumberSequence struct {
+	ent.Schema
+}
+
+// Fields of the NumberSequence.
+func (NumberSequence) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("type").
+			NotEmpty(), // pv, recu_tresor, recours, alerte, convocation, plainte, objet_perdu, objet_retrouve
+		field.String("scope").
+			Default("").
+			Comment("ID du commissariat, vide pour une séquence nationale"),
+		field.Int("year"),
+		field.Int("last_value").
+			Default(0),
+		field.Time("created_at").
+			Default(time.Now),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Indexes of the NumberSequence.
+func (NumberSequence) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("type", "scope", "year").
+			Unique(),
+	}
+}
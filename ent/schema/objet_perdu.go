@@ -0,0 +1,95 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// ObjetPerdu holds the schema definition for the ObjetPerdu entity.
+type ObjetPerdu struct {
+	ent.Schema
+}
+
+// Fields of the ObjetPerdu.
+func (ObjetPerdu) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("numero").
+			Unique(),
+		// Description de l'objet
+		field.String("type_objet"),
+		field.Text("description"),
+		field.String("valeur_estimee").
+			Optional().
+			Nillable(),
+		field.String("couleur").
+			Optional().
+			Nillable(),
+		field.JSON("details_specifiques", map[string]interface{}{}).
+			Optional(),
+		// Mode contenant (sac, valise...) avec son inventaire
+		field.Bool("is_container").
+			Default(false),
+		field.JSON("container_details", map[string]interface{}{}).
+			Optional(),
+		// Déclarant
+		field.JSON("declarant", map[string]interface{}{}),
+		// Perte
+		field.String("lieu_perte"),
+		field.String("adresse_lieu").
+			Optional().
+			Nillable(),
+		field.Time("date_perte"),
+		field.String("heure_perte").
+			Optional().
+			Nillable(),
+		// Statut
+		field.Enum("statut").
+			Values("EN_RECHERCHE", "RETROUVÉ", "CLÔTURÉ").
+			Default("EN_RECHERCHE"),
+		field.Time("date_declaration").
+			Default(time.Now),
+		field.Time("date_retrouve").
+			Optional().
+			Nillable(),
+		field.Text("observations").
+			Optional().
+			Nillable(),
+		// Timestamps
+		field.Time("created_at").
+			Default(time.Now),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the ObjetPerdu.
+func (ObjetPerdu) Edges() []ent.Edge {
+	return []ent.Edge{
+		// Un objet perdu est déclaré dans un commissariat
+		edge.From("commissariat", Commissariat.Type).
+			Ref("objets_perdus").
+			Unique(),
+		// Un objet perdu est enregistré par un agent
+		edge.From("agent", User.Type).
+			Ref("objets_perdus").
+			Unique(),
+	}
+}
+
+// Indexes of the ObjetPerdu.
+func (ObjetPerdu) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("numero"),
+		index.Fields("type_objet"),
+		index.Fields("statut"),
+		index.Fields("date_declaration"),
+	}
+}
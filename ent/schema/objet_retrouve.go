@@ -0,0 +1,98 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// ObjetRetrouve holds the schema definition for the ObjetRetrouve entity.
+type ObjetRetrouve struct {
+	ent.Schema
+}
+
+// Fields of the ObjetRetrouve.
+func (ObjetRetrouve) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("numero").
+			Unique(),
+		// Description de l'objet
+		field.String("type_objet"),
+		field.Text("description"),
+		field.String("valeur_estimee").
+			Optional().
+			Nillable(),
+		field.String("couleur").
+			Optional().
+			Nillable(),
+		field.JSON("details_specifiques", map[string]interface{}{}).
+			Optional(),
+		// Mode contenant (sac, valise...) avec son inventaire
+		field.Bool("is_container").
+			Default(false),
+		field.JSON("container_details", map[string]interface{}{}).
+			Optional(),
+		// Déposant
+		field.JSON("deposant", map[string]interface{}{}),
+		// Trouvaille
+		field.String("lieu_trouvaille"),
+		field.String("adresse_lieu").
+			Optional().
+			Nillable(),
+		field.Time("date_trouvaille"),
+		field.String("heure_trouvaille").
+			Optional().
+			Nillable(),
+		// Statut
+		field.Enum("statut").
+			Values("DISPONIBLE", "RESTITUÉ", "NON_RÉCLAMÉ").
+			Default("DISPONIBLE"),
+		field.Time("date_depot").
+			Default(time.Now),
+		// Restitution
+		field.Time("date_restitution").
+			Optional().
+			Nillable(),
+		field.JSON("proprietaire", map[string]interface{}{}).
+			Optional(),
+		field.Text("observations").
+			Optional().
+			Nillable(),
+		// Timestamps
+		field.Time("created_at").
+			Default(time.Now),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the ObjetRetrouve.
+func (ObjetRetrouve) Edges() []ent.Edge {
+	return []ent.Edge{
+		// Un objet retrouvé est déposé dans un commissariat
+		edge.From("commissariat", Commissariat.Type).
+			Ref("objets_retrouves").
+			Unique(),
+		// Un objet retrouvé est enregistré par un agent
+		edge.From("agent", User.Type).
+			Ref("objets_retrouves").
+			Unique(),
+	}
+}
+
+// Indexes of the ObjetRetrouve.
+func (ObjetRetrouve) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("numero"),
+		index.Fields("type_objet"),
+		index.Fields("statut"),
+		index.Fields("date_depot"),
+	}
+}
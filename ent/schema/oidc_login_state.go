@@ -0,0 +1,49 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// OIDCLoginState holds the schema definition for the OIDCLoginState entity.
+// Connexion OpenID Connect en cours, entre la redirection vers le fournisseur et son retour.
+type OIDCLoginState struct {
+	ent.Schema
+}
+
+// Fields of the OIDCLoginState.
+func (OIDCLoginState) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("state_hash").
+			NotEmpty().
+			Sensitive().
+			Comment("SHA-256 du paramètre state"),
+		field.String("code_verifier").
+			NotEmpty().
+			Sensitive().
+			Comment("Secret PKCE, envoyé au fournisseur avec le code d'autorisation"),
+		field.String("nonce").
+			NotEmpty().
+			Sensitive(),
+		field.String("ip_address").
+			Optional(),
+		field.Time("expires_at"),
+		field.Time("created_at").
+			Default(time.Now),
+	}
+}
+
+// Indexes of the OIDCLoginState.
+func (OIDCLoginState) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("state_hash").
+			Unique(),
+		index.Fields("expires_at"),
+	}
+}
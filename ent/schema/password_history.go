@@ -0,0 +1,42 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// PasswordHistory holds the schema definition for the PasswordHistory entity.
+// Mots de passe successifs d'un utilisateur : la dernière entrée donne la date
+// du changement (expiration) et indique un mot de passe provisoire.
+type PasswordHistory struct {
+	ent.Schema
+}
+
+// Fields of the PasswordHistory.
+func (PasswordHistory) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.UUID("user_id", uuid.UUID{}),
+		field.String("password_hash").
+			NotEmpty().
+			Sensitive().
+			Comment("Hash bcrypt, pour refuser la réutilisation"),
+		field.Bool("temporary").
+			Default(false).
+			Comment("Défini par un administrateur : à changer à la première connexion"),
+		field.Time("created_at").
+			Default(time.Now),
+	}
+}
+
+// Indexes of the PasswordHistory.
+func (PasswordHistory) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("user_id", "created_at"),
+	}
+}
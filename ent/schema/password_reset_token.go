@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// PasswordResetToken holds the schema definition for the PasswordResetToken entity.
+// Jeton de réinitialisation du mot de passe, à usage unique et limité dans le temps.
+type PasswordResetToken struct {
+	ent.Schema
+}
+
+// Fields of the PasswordResetToken.
+func (PasswordResetToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.UUID("user_id", uuid.UUID{}),
+		field.String("token_hash").
+			NotEmpty().
+			Sensitive().
+			Comment("SHA-256 du jeton envoyé à l'utilisateur"),
+		field.Time("expires_at"),
+		field.Time("used_at").
+			Optional().
+			Nillable(),
+		field.String("ip_address").
+			Optional().
+			Comment("Adresse IP de la demande"),
+		field.Time("created_at").
+			Default(time.Now),
+	}
+}
+
+// Indexes of the PasswordResetToken.
+func (PasswordResetToken) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("token_hash").
+			Unique(),
+		index.Fields("user_id"),
+		index.Fields("expires_at"),
+	}
+}
@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// Permission holds the schema definition for the Permission entity.
+// Le catalogue est alimenté au démarrage à partir des permissions déclarées dans le code.
+type Permission struct {
+	ent.Schema
+}
+
+// Fields of the Permission.
+func (Permission) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("code").
+			NotEmpty().
+			Unique().
+			Comment("ressource:action, ex: plaintes:read"),
+		field.String("resource").
+			NotEmpty(),
+		field.Time("created_at").
+			Default(time.Now),
+	}
+}
+
+// Edges of the Permission.
+func (Permission) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("roles", Role.Type).
+			Ref("permissions"),
+	}
+}
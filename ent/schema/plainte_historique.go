@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// PlainteHistorique holds the schema definition for the PlainteHistorique entity.
+type PlainteHistorique struct {
+	ent.Schema
+}
+
+// Fields of the PlainteHistorique.
+func (PlainteHistorique) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.UUID("plainte_id", uuid.UUID{}),
+		field.UUID("user_id", uuid.UUID{}).
+			Optional().
+			Nillable(),
+		field.Enum("type_changement").
+			Values("STATUT", "ETAPE", "ASSIGNATION", "PRIORITE", "AUTRE"),
+		field.String("champ_modifie"),
+		field.String("ancienne_valeur").
+			Optional(),
+		field.String("nouvelle_valeur"),
+		field.Text("commentaire").
+			Optional(),
+		field.String("auteur_nom").
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now),
+	}
+}
+
+// Edges of the PlainteHistorique.
+func (PlainteHistorique) Edges() []ent.Edge {
+	return []ent.Edge{
+		// Une entrée d'historique appartient à une plainte
+		edge.From("plainte", Plainte.Type).
+			Ref("historiques").
+			Field("plainte_id").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the PlainteHistorique.
+func (PlainteHistorique) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("plainte_id"),
+		index.Fields("created_at"),
+	}
+}
@@ -0,0 +1,71 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// Preuve holds the schema definition for the Preuve entity.
+type Preuve struct {
+	ent.Schema
+}
+
+// Fields of the Preuve.
+func (Preuve) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.UUID("plainte_id", uuid.UUID{}),
+		field.String("numero_piece"),
+		field.Enum("type").
+			Values("MATERIELLE", "NUMERIQUE", "TESTIMONIALE", "DOCUMENTAIRE"),
+		field.Text("description"),
+		field.String("lieu_conservation").
+			Optional(),
+		field.Time("date_collecte").
+			Default(time.Now),
+		field.String("collecte_par").
+			Optional(),
+		field.Strings("photos").
+			Optional(),
+		field.String("hash_verification").
+			Optional(),
+		// Expertise
+		field.Bool("expertise_demandee").
+			Default(false),
+		field.String("expertise_type").
+			Optional(),
+		field.Text("expertise_resultat").
+			Optional(),
+		field.Enum("statut").
+			Values("COLLECTEE", "EN_ANALYSE", "ANALYSEE", "RETOURNEE").
+			Default("COLLECTEE"),
+		field.Time("created_at").
+			Default(time.Now),
+	}
+}
+
+// Edges of the Preuve.
+func (Preuve) Edges() []ent.Edge {
+	return []ent.Edge{
+		// Une preuve appartient à une plainte
+		edge.From("plainte", Plainte.Type).
+			Ref("preuves").
+			Field("plainte_id").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the Preuve.
+func (Preuve) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("plainte_id"),
+		index.Fields("numero_piece"),
+	}
+}
@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// Role holds the schema definition for the Role entity.
+// Rôle applicatif et ses permissions, administrables sans redéploiement.
+type Role struct {
+	ent.Schema
+}
+
+// Fields of the Role.
+func (Role) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("name").
+			NotEmpty().
+			Unique().
+			Comment("Identifiant du rôle, repris dans User.role: admin, supervisor, agent..."),
+		field.String("libelle").
+			Optional(),
+		field.Text("description").
+			Optional(),
+		field.Bool("system").
+			Default(false).
+			Comment("Rôle livré avec l'application: il ne peut pas être supprimé"),
+		field.Time("created_at").
+			Default(time.Now),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the Role.
+func (Role) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("permissions", Permission.Type),
+	}
+}
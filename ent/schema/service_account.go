@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// ServiceAccount holds the schema definition for the ServiceAccount entity.
+// Compte technique d'un système partenaire (Trésor, assureurs, tribunaux),
+// authentifié par clé d'API au lieu d'un matricule.
+type ServiceAccount struct {
+	ent.Schema
+}
+
+// Fields of the ServiceAccount.
+func (ServiceAccount) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("name").
+			NotEmpty().
+			Unique().
+			Comment("Identifiant du partenaire: tresor, assureur-xyz..."),
+		field.Text("description").
+			Optional(),
+		field.Strings("permissions").
+			Optional().
+			Comment("Permissions accordées, sous-ensemble de celles des rôles"),
+		field.Strings("allowed_ips").
+			Optional().
+			Comment("Adresses IP ou plages CIDR autorisées; vide: toutes"),
+		field.UUID("commissariat_id", uuid.UUID{}).
+			Optional().
+			Nillable().
+			Comment("Limite les données visibles à un commissariat; vide: national"),
+		field.Bool("active").
+			Default(true),
+		field.String("created_by").
+			Optional(),
+		field.Time("last_used_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the ServiceAccount.
+func (ServiceAccount) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.To("keys", APIKey.Type),
+	}
+}
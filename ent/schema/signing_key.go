@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// SigningKey holds the schema definition for the SigningKey entity.
+// Clé asymétrique de signature des jetons d'accès, publiée dans le JWKS.
+type SigningKey struct {
+	ent.Schema
+}
+
+// Fields of the SigningKey.
+func (SigningKey) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.String("kid").
+			NotEmpty().
+			Comment("Identifiant publié dans l'en-tête kid des jetons"),
+		field.String("algorithm").
+			NotEmpty().
+			Comment("RS256 ou EdDSA"),
+		field.Text("private_key").
+			NotEmpty().
+			Sensitive().
+			Comment("Clé privée PKCS#8 chiffrée (AES-GCM, clé dérivée de jwt.secret)"),
+		field.Time("created_at").
+			Default(time.Now),
+		field.Time("retired_at").
+			Optional().
+			Nillable().
+			Comment("Fin de la signature ; la clé vérifie encore les jetons jusqu'à expires_at"),
+		field.Time("expires_at").
+			Optional().
+			Nillable().
+			Comment("Retrait du JWKS ; les jetons signés par la clé sont alors refusés"),
+	}
+}
+
+// Indexes of the SigningKey.
+func (SigningKey) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("kid").
+			Unique(),
+		index.Fields("expires_at"),
+	}
+}
@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// StreamEvent holds the schema definition for the StreamEvent entity.
+// Events pushed on /api/v1/stream are kept for a while so that clients can
+// replay what they missed after a reconnect (SSE Last-Event-ID).
+type StreamEvent struct {
+	ent.Schema
+}
+
+// Fields of the StreamEvent. The auto-increment ID orders the events.
+func (StreamEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("type").
+			NotEmpty(), // alerte.created, alerte.broadcast, ...
+		field.String("resource_id").
+			Optional(),
+		field.Text("payload").
+			Optional(), // JSON envoyé au client
+		field.Bool("broadcast").
+			Default(false).
+			Comment("Diffusion générale: visible par tous les agents"),
+		field.Strings("commissariat_ids").
+			Optional(),
+		field.Strings("agent_ids").
+			Optional(),
+		field.String("instance_id").
+			Optional().
+			Comment("Instance ayant publié l'événement"),
+		field.Time("created_at").
+			Default(time.Now),
+	}
+}
+
+// Indexes of the StreamEvent.
+func (StreamEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("created_at"),
+	}
+}
@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// TimelineEvent holds the schema definition for the TimelineEvent entity.
+type TimelineEvent struct {
+	ent.Schema
+}
+
+// Fields of the TimelineEvent.
+func (TimelineEvent) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.UUID("plainte_id", uuid.UUID{}),
+		field.Time("date"),
+		field.String("heure").
+			Optional(),
+		field.Enum("type").
+			Values("DEPOT", "AUDITION", "PERQUISITION", "EXPERTISE", "CONVOCATION", "DECISION", "AUTRE"),
+		field.String("titre"),
+		field.Text("description"),
+		field.String("acteur").
+			Optional(),
+		field.String("statut").
+			Optional(),
+		field.Strings("documents").
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now),
+	}
+}
+
+// Edges of the TimelineEvent.
+func (TimelineEvent) Edges() []ent.Edge {
+	return []ent.Edge{
+		// Un événement appartient à la chronologie d'une plainte
+		edge.From("plainte", Plainte.Type).
+			Ref("timeline").
+			Field("plainte_id").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the TimelineEvent.
+func (TimelineEvent) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("plainte_id"),
+		index.Fields("date"),
+	}
+}
@@ -0,0 +1,47 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// UserIdentity holds the schema definition for the UserIdentity entity.
+// Compte d'un fournisseur d'identité externe (OpenID Connect) lié à un utilisateur local.
+type UserIdentity struct {
+	ent.Schema
+}
+
+// Fields of the UserIdentity.
+func (UserIdentity) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.UUID("user_id", uuid.UUID{}),
+		field.String("issuer").
+			NotEmpty().
+			Comment("Émetteur (iss) du fournisseur d'identité"),
+		field.String("subject").
+			NotEmpty().
+			Comment("Identifiant de l'utilisateur chez le fournisseur (sub)"),
+		field.String("email").
+			Optional(),
+		field.Time("created_at").
+			Default(time.Now),
+		field.Time("last_login_at").
+			Optional().
+			Nillable(),
+	}
+}
+
+// Indexes of the UserIdentity.
+func (UserIdentity) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("issuer", "subject").
+			Unique(),
+		index.Fields("user_id"),
+	}
+}
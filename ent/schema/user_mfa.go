@@ -0,0 +1,41 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"github.com/google/uuid"
+)
+
+// UserMFA holds the schema definition for the UserMFA entity.
+// Secret TOTP d'un utilisateur ; le second facteur n'est actif qu'une fois l'enrôlement confirmé.
+type UserMFA struct {
+	ent.Schema
+}
+
+// Fields of the UserMFA.
+func (UserMFA) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		field.UUID("user_id", uuid.UUID{}).
+			Unique(),
+		field.String("secret").
+			NotEmpty().
+			Sensitive().
+			Comment("Secret TOTP base32, chiffré avec une clé dérivée de jwt.secret"),
+		field.Time("confirmed_at").
+			Optional().
+			Nillable().
+			Comment("Vide tant que l'utilisateur n'a pas saisi un premier code valide"),
+		field.Int64("last_used_step").
+			Default(0).
+			Comment("Dernier pas de temps accepté : un code ne peut servir qu'une fois"),
+		field.Time("created_at").
+			Default(time.Now),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
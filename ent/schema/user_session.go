@@ -0,0 +1,82 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+	"github.com/google/uuid"
+)
+
+// UserSession holds the schema definition for the UserSession entity.
+type UserSession struct {
+	ent.Schema
+}
+
+// Fields of the UserSession.
+func (UserSession) Fields() []ent.Field {
+	return []ent.Field{
+		field.UUID("id", uuid.UUID{}).
+			Default(uuid.New),
+		// Appareil
+		field.String("device_id"),
+		field.String("device_name").
+			Optional(),
+		field.String("device_type").
+			Optional(), // ios, android, web
+		field.String("device_os").
+			Optional(),
+		field.String("app_version").
+			Optional(),
+		// Jeton de rafraîchissement (haché)
+		field.String("refresh_token_hash").
+			Unique().
+			Sensitive(),
+		field.Time("refresh_token_expires_at"),
+		// Activité
+		field.Time("session_started_at").
+			Default(time.Now),
+		field.Time("last_activity_at").
+			Default(time.Now),
+		field.String("last_ip_address").
+			Optional(),
+		// Statut
+		field.Bool("is_active").
+			Default(true),
+		field.Bool("is_revoked").
+			Default(false),
+		field.Time("revoked_at").
+			Optional().
+			Nillable(),
+		field.String("revoked_reason").
+			Optional(),
+		// Timestamps
+		field.Time("created_at").
+			Default(time.Now),
+		field.Time("updated_at").
+			Default(time.Now).
+			UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the UserSession.
+func (UserSession) Edges() []ent.Edge {
+	return []ent.Edge{
+		// Une session appartient à un utilisateur
+		edge.From("user", User.Type).
+			Ref("sessions").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the UserSession.
+func (UserSession) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("device_id"),
+		index.Fields("is_active", "is_revoked"),
+		index.Fields("last_activity_at"),
+	}
+}
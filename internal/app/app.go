@@ -6,16 +6,32 @@ import (
 	"police-trafic-api-frontend-aligned/internal/core/middleware"
 	"police-trafic-api-frontend-aligned/internal/core/router"
 	"police-trafic-api-frontend-aligned/internal/core/server"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/apikeys"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/audittrail"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/crypto"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/database"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/idempotency"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/jwt"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/logger"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/loginguard"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/mfa"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/notification"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/oidc"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/passwords"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/realtime"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/scheduler"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/session"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/storage"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tenant"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/versioning"
 	"police-trafic-api-frontend-aligned/internal/modules/admin"
 	"police-trafic-api-frontend-aligned/internal/modules/alertes"
+	"police-trafic-api-frontend-aligned/internal/modules/audit"
 	"police-trafic-api-frontend-aligned/internal/modules/auth"
 	"police-trafic-api-frontend-aligned/internal/modules/commissariat"
 	"police-trafic-api-frontend-aligned/internal/modules/competence"
@@ -26,6 +42,7 @@ import (
 	"police-trafic-api-frontend-aligned/internal/modules/equipe"
 	"police-trafic-api-frontend-aligned/internal/modules/infraction"
 	"police-trafic-api-frontend-aligned/internal/modules/inspection"
+	"police-trafic-api-frontend-aligned/internal/modules/jobs"
 	"police-trafic-api-frontend-aligned/internal/modules/mission"
 		"police-trafic-api-frontend-aligned/internal/modules/objectif"
 		"police-trafic-api-frontend-aligned/internal/modules/observation"
@@ -34,6 +51,10 @@ import (
 		"police-trafic-api-frontend-aligned/internal/modules/plainte"
 		"police-trafic-api-frontend-aligned/internal/modules/pv"
 		"police-trafic-api-frontend-aligned/internal/modules/recours"
+		"police-trafic-api-frontend-aligned/internal/modules/roles"
+		"police-trafic-api-frontend-aligned/internal/modules/search"
+		"police-trafic-api-frontend-aligned/internal/modules/serviceaccounts"
+		"police-trafic-api-frontend-aligned/internal/modules/stream"
 		"police-trafic-api-frontend-aligned/internal/modules/vehicule"
 		"police-trafic-api-frontend-aligned/internal/modules/verification"
 		"police-trafic-api-frontend-aligned/internal/modules/objets-perdus"
@@ -48,6 +69,7 @@ func BuildApp() fx.Option {
 		// Infrastructure
 		config.Module,
 		logger.Module,
+		tracing.Module,
 		database.Module,
 		jwt.Module,
 		crypto.Module,
@@ -55,10 +77,25 @@ func BuildApp() fx.Option {
 		middleware.Module,
 		repository.Module,
 		session.Module,
+		audittrail.Module,
+		scheduler.Module,
+		notification.Module,
+		realtime.Module,
+		numbering.Module,
+		tenant.Module,
+		loginguard.Module,
+		mfa.Module,
+		passwords.Module,
+		apikeys.Module,
+		oidc.Module,
+		idempotency.Module,
+		versioning.Module,
+		storage.Module,
 		
 		// Modules
 		admin.Module,
 		alertes.Module,
+		audit.Module,
 		auth.Module,
 		commissariat.Module,
 		competence.Module,
@@ -69,6 +106,7 @@ func BuildApp() fx.Option {
 		equipe.Module,
 		infraction.Module,
 		inspection.Module,
+		jobs.Module,
 		mission.Module,
 		objectif.Module,
 		observation.Module,
@@ -77,6 +115,10 @@ func BuildApp() fx.Option {
 		plainte.Module,
 		pv.Module,
 		recours.Module,
+		roles.Module,
+		search.Module,
+		serviceaccounts.Module,
+		stream.Module,
 		vehicule.Module,
 		verification.Module,
 		objetsperdus.Module,
@@ -86,7 +128,7 @@ func BuildApp() fx.Option {
 		fx.Provide(
 			fx.Annotate(
 				router.NewServer,
-				fx.ParamTags(``, ``, ``, `group:"controllers"`),
+				fx.ParamTags(``, ``, ``, ``, ``, ``, ``, ``, `group:"controllers"`),
 			),
 		),
 
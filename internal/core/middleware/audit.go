@@ -0,0 +1,229 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/audittrail"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/jwt"
+
+	"github.com/labstack/echo/v4"
+)
+
+// maxCapturedErrorBody limits how much of an error response is kept to extract its message
+const maxCapturedErrorBody = 4096
+
+// AuditMiddleware records every mutating API call in the audit log
+type AuditMiddleware struct {
+	auditService audittrail.Service
+}
+
+// NewAuditMiddleware creates a new audit middleware
+func NewAuditMiddleware(auditService audittrail.Service) *AuditMiddleware {
+	return &AuditMiddleware{
+		auditService: auditService,
+	}
+}
+
+// Record middleware that audits POST/PUT/PATCH/DELETE requests.
+// It must run after the authentication middleware so that the JWT claims are available,
+// and before the idempotency middleware: a replayed response is recorded, flagged.
+func (m *AuditMiddleware) Record() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			if !isMutatingMethod(req.Method) {
+				return next(c)
+			}
+
+			ctx, trail := audittrail.WithTrail(req.Context())
+			c.SetRequest(req.WithContext(ctx))
+
+			capture := &errorBodyCapture{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = capture
+
+			start := time.Now()
+			err := next(c)
+
+			route := c.Path()
+			resourceType, action := ResourceAndActionFromRoute(req.Method, route)
+
+			entry := &audittrail.Entry{
+				Action:       action,
+				ResourceType: resourceType,
+				ResourceID:   c.Param("id"),
+				IPAddress:    c.RealIP(),
+				UserAgent:    req.UserAgent(),
+				Method:       req.Method,
+				Path:         req.URL.Path,
+				Route:        route,
+				RequestID:    c.Response().Header().Get(echo.HeaderXRequestID),
+				StatusCode:   responseStatus(c, err),
+				Duration:     time.Since(start),
+				Trail:        trail,
+				Replayed:     c.Response().Header().Get(HeaderIdempotentReplayed) == "true",
+			}
+
+			if userID, ok := c.Get("user_id").(string); ok {
+				entry.UserID = userID
+			}
+			if matricule, ok := c.Get("matricule").(string); ok {
+				entry.Matricule = matricule
+			}
+			if role, ok := c.Get("user_role").(string); ok {
+				entry.Role = role
+			}
+			if commissariatID, ok := c.Get("commissariat_id").(string); ok {
+				entry.CommissariatID = commissariatID
+			}
+			if claims, ok := c.Get("jwt_claims").(*jwt.Claims); ok {
+				entry.SessionID = claims.SessionID
+			}
+			if serviceAccountID, ok := c.Get("service_account_id").(string); ok {
+				entry.ServiceAccountID = serviceAccountID
+			}
+
+			if entry.StatusCode >= http.StatusBadRequest {
+				entry.ErrorMessage = capture.message()
+				if entry.ErrorMessage == "" && err != nil {
+					entry.ErrorMessage = err.Error()
+				}
+			}
+
+			m.auditService.Record(c.Request().Context(), entry)
+
+			// L'erreur remonte aux middlewares englobants, puis au gestionnaire d'Echo
+			return err
+		}
+	}
+}
+
+// responseStatus returns the status code of the response, including the one
+// the Echo error handler will write for an error not yet turned into a response
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+	var he *echo.HTTPError
+	if errors.As(err, &he) {
+		return he.Code
+	}
+	return http.StatusInternalServerError
+}
+
+// ResourceAndActionFromRoute derives the audited resource and action from an Echo route.
+// Static segments before the first path parameter name the resource, static segments
+// after it name the action:
+//
+//	POST   /api/v1/pv                  -> pv, CREATE
+//	PUT    /api/v1/pv/:id              -> pv, UPDATE
+//	POST   /api/v1/pv/:id/annuler      -> pv, ANNULER
+//	DELETE /api/v1/admin/agents/:id    -> admin/agents, DELETE
+//	POST   /api/v1/auth/login          -> auth, LOGIN
+func ResourceAndActionFromRoute(method, route string) (string, string) {
+	route = strings.TrimPrefix(route, "/api/v1")
+	segments := strings.Split(strings.Trim(route, "/"), "/")
+
+	var resource, actionParts []string
+	afterParam := false
+	for _, segment := range segments {
+		if segment == "" {
+			continue
+		}
+		if strings.HasPrefix(segment, ":") || segment == "*" {
+			afterParam = true
+			continue
+		}
+		if afterParam {
+			actionParts = append(actionParts, segment)
+		} else {
+			resource = append(resource, segment)
+		}
+	}
+
+	// Les routes d'authentification n'ont pas de paramètre : le dernier segment est l'action
+	if len(resource) > 1 && resource[0] == "auth" {
+		actionParts = resource[1:]
+		resource = resource[:1]
+	}
+
+	action := methodAction(method)
+	if len(actionParts) > 0 {
+		action = strings.ToUpper(strings.ReplaceAll(strings.Join(actionParts, "_"), "-", "_"))
+		if method == http.MethodDelete {
+			action = "DELETE_" + action
+		}
+	}
+
+	return strings.Join(resource, "/"), action
+}
+
+func methodAction(method string) string {
+	switch method {
+	case http.MethodPost:
+		return "CREATE"
+	case http.MethodPut, http.MethodPatch:
+		return "UPDATE"
+	case http.MethodDelete:
+		return "DELETE"
+	default:
+		return method
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// errorBodyCapture keeps the beginning of error responses so the audit entry
+// can store the message returned to the client
+type errorBodyCapture struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *errorBodyCapture) WriteHeader(code int) {
+	w.status = code
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *errorBodyCapture) Write(b []byte) (int, error) {
+	if w.status >= http.StatusBadRequest && w.body.Len() < maxCapturedErrorBody {
+		remaining := maxCapturedErrorBody - w.body.Len()
+		if len(b) < remaining {
+			remaining = len(b)
+		}
+		w.body.Write(b[:remaining])
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *errorBodyCapture) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// message extracts the "message" field of a JSON error response
+func (w *errorBodyCapture) message() string {
+	if w.body.Len() == 0 {
+		return ""
+	}
+	var payload struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(w.body.Bytes(), &payload); err == nil && payload.Message != "" {
+		return payload.Message
+	}
+	return strings.TrimSpace(w.body.String())
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/audittrail"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordedAudit struct {
+	entries []*audittrail.Entry
+}
+
+func (r *recordedAudit) Record(_ context.Context, entry *audittrail.Entry) {
+	r.entries = append(r.entries, entry)
+}
+
+func TestResourceAndActionFromRoute(t *testing.T) {
+	tests := []struct {
+		name             string
+		method           string
+		route            string
+		expectedResource string
+		expectedAction   string
+	}{
+		{"create", http.MethodPost, "/api/v1/pv", "pv", "CREATE"},
+		{"update", http.MethodPut, "/api/v1/pv/:id", "pv", "UPDATE"},
+		{"patch", http.MethodPatch, "/api/v1/paiements/:id", "paiements", "UPDATE"},
+		{"delete", http.MethodDelete, "/api/v1/admin/agents/:id", "admin/agents", "DELETE"},
+		{"sub-action", http.MethodPost, "/api/v1/pv/:id/annuler", "pv", "ANNULER"},
+		{"dashed sub-action", http.MethodPost, "/api/v1/pv/:id/envoyer-rappel", "pv", "ENVOYER_RAPPEL"},
+		{"delete sub-resource", http.MethodDelete, "/api/v1/plaintes/:id/preuves", "plaintes", "DELETE_PREUVES"},
+		{"auth route", http.MethodPost, "/api/v1/auth/login", "auth", "LOGIN"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, action := ResourceAndActionFromRoute(tt.method, tt.route)
+			assert.Equal(t, tt.expectedResource, resource)
+			assert.Equal(t, tt.expectedAction, action)
+		})
+	}
+}
+
+func TestResponseStatus(t *testing.T) {
+	newContext := func() echo.Context {
+		req := httptest.NewRequest(http.MethodPut, "/api/plaintes/1", nil)
+		return echo.New().NewContext(req, httptest.NewRecorder())
+	}
+
+	c := newContext()
+	assert.NoError(t, c.NoContent(http.StatusNoContent))
+	assert.Equal(t, http.StatusNoContent, responseStatus(c, nil))
+
+	// Erreur pas encore transformée en réponse par Echo
+	assert.Equal(t, http.StatusConflict, responseStatus(newContext(), echo.NewHTTPError(http.StatusConflict)))
+	assert.Equal(t, http.StatusInternalServerError, responseStatus(newContext(), errors.New("boom")))
+
+	c = newContext()
+	assert.NoError(t, c.NoContent(http.StatusAccepted))
+	assert.Equal(t, http.StatusAccepted, responseStatus(c, errors.New("after commit")))
+}
+
+func TestAuditRecord_FlagsIdempotentReplay(t *testing.T) {
+	recorded := &recordedAudit{}
+	e := echo.New()
+	e.POST("/api/v1/pv", func(c echo.Context) error {
+		// Réponse rejouée par le middleware d'idempotence, le handler n'est pas appelé
+		c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+		return c.NoContent(http.StatusCreated)
+	}, NewAuditMiddleware(recorded).Record())
+	e.POST("/api/v1/controles", func(c echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	}, NewAuditMiddleware(recorded).Record())
+
+	for _, path := range []string{"/api/v1/pv", "/api/v1/controles"} {
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, path, nil))
+	}
+
+	require.Len(t, recorded.entries, 2)
+	assert.True(t, recorded.entries[0].Replayed)
+	assert.False(t, recorded.entries[1].Replayed)
+}
@@ -1,32 +1,41 @@
 package middleware
 
 import (
+	"errors"
 	"strings"
+	"time"
 
+	"police-trafic-api-frontend-aligned/internal/infrastructure/apikeys"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/jwt"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/session"
 	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
 )
 
-// AuthMiddleware provides JWT authentication middleware
+// AuthMiddleware provides JWT and API key authentication middleware
 type AuthMiddleware struct {
-	jwtService  jwt.Service
-	rbacService rbac.Service
-	userRepo    repository.UserRepository
-	logger      *zap.Logger
+	jwtService     jwt.Service
+	rbacService    rbac.Service
+	apiKeyService  apikeys.Service
+	userRepo       repository.UserRepository
+	sessionService session.Service
+	logger         *zap.Logger
 }
 
 // NewAuthMiddleware creates a new authentication middleware
-func NewAuthMiddleware(jwtService jwt.Service, rbacService rbac.Service, userRepo repository.UserRepository, logger *zap.Logger) *AuthMiddleware {
+func NewAuthMiddleware(jwtService jwt.Service, rbacService rbac.Service, apiKeyService apikeys.Service, userRepo repository.UserRepository, sessionService session.Service, logger *zap.Logger) *AuthMiddleware {
 	return &AuthMiddleware{
-		jwtService:  jwtService,
-		rbacService: rbacService,
-		userRepo:    userRepo,
-		logger:      logger,
+		jwtService:     jwtService,
+		rbacService:    rbacService,
+		apiKeyService:  apiKeyService,
+		userRepo:       userRepo,
+		sessionService: sessionService,
+		logger:         logger,
 	}
 }
 
@@ -35,7 +44,8 @@ func (m *AuthMiddleware) RequireAuth() echo.MiddlewareFunc {
 	return m.RequireAuthWithSkipper(nil)
 }
 
-// RequireAuthWithSkipper middleware that requires authentication with optional skip function
+// RequireAuthWithSkipper middleware that requires authentication with optional skip function.
+// Partner systems authenticate with an API key instead of a JWT (see extractAPIKey).
 func (m *AuthMiddleware) RequireAuthWithSkipper(skipper func(path string) bool) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
@@ -44,6 +54,10 @@ func (m *AuthMiddleware) RequireAuthWithSkipper(skipper func(path string) bool)
 				return next(c)
 			}
 
+			if key := m.extractAPIKey(c); key != "" {
+				return m.authenticateAPIKey(c, key, next)
+			}
+
 			token := m.extractToken(c)
 			if token == "" {
 				return responses.Unauthorized(c, "Authorization token required")
@@ -58,6 +72,20 @@ func (m *AuthMiddleware) RequireAuthWithSkipper(skipper func(path string) bool)
 				return responses.Unauthorized(c, "Invalid or expired token")
 			}
 
+			if !m.sessionValid(c, claims) {
+				return responses.Unauthorized(c, "Session expired or revoked")
+			}
+
+			// Jetons révoqués par un changement de mot de passe ou une déconnexion de tous les appareils
+			user, err := m.userRepo.GetByID(c.Request().Context(), claims.UserID)
+			if err == nil && user != nil && user.TokensRevokedAt != nil && issuedBefore(claims, *user.TokensRevokedAt) {
+				m.logger.Warn("Revoked token rejected",
+					zap.String("user_id", claims.UserID),
+					zap.Time("tokens_revoked_at", *user.TokensRevokedAt),
+				)
+				return responses.Unauthorized(c, "Session expired or revoked")
+			}
+
 			// Store user information in context for use in handlers
 			c.Set("user_id", claims.UserID)
 			c.Set("matricule", claims.Matricule)
@@ -66,7 +94,6 @@ func (m *AuthMiddleware) RequireAuthWithSkipper(skipper func(path string) bool)
 			c.Set("rbac_service", m.rbacService)
 
 			// Récupérer le commissariat de l'utilisateur
-			user, err := m.userRepo.GetByID(c.Request().Context(), claims.UserID)
 			if err == nil && user != nil && user.Edges.Commissariat != nil {
 				c.Set("commissariat_id", user.Edges.Commissariat.ID.String())
 			}
@@ -82,6 +109,41 @@ func (m *AuthMiddleware) RequireAuthWithSkipper(skipper func(path string) bool)
 	}
 }
 
+// sessionValid checks the session a token is bound to: revoked, expired or
+// used from another device, it no longer grants access
+func (m *AuthMiddleware) sessionValid(c echo.Context, claims *jwt.Claims) bool {
+	if claims.SessionID == "" {
+		return true
+	}
+
+	sessionID, err := uuid.Parse(claims.SessionID)
+	if err != nil {
+		return false
+	}
+	valid, err := m.sessionService.IsSessionValid(c.Request().Context(), sessionID, claims.DeviceID)
+	if err != nil {
+		m.logger.Error("Failed to check session", zap.String("session_id", claims.SessionID), zap.Error(err))
+		return false
+	}
+	if !valid {
+		m.logger.Warn("Session invalid or revoked",
+			zap.String("session_id", claims.SessionID),
+			zap.String("user_id", claims.UserID),
+		)
+	}
+	return valid
+}
+
+// issuedBefore reports whether a token was issued before the revocation.
+// iat is in seconds: a token issued during the second of the revocation,
+// such as the login that follows a password change, is accepted.
+func issuedBefore(claims *jwt.Claims, revokedAt time.Time) bool {
+	if claims.IssuedAt == nil {
+		return true
+	}
+	return claims.IssuedAt.Before(revokedAt.Truncate(time.Second))
+}
+
 // RequireRole middleware that requires specific role(s)
 func (m *AuthMiddleware) RequireRole(roles ...string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
@@ -96,6 +158,9 @@ func (m *AuthMiddleware) RequireRole(roles ...string) echo.MiddlewareFunc {
 			if err != nil {
 				return responses.Unauthorized(c, "Invalid or expired token")
 			}
+			if !m.sessionValid(c, claims) {
+				return responses.Unauthorized(c, "Session expired or revoked")
+			}
 
 			// Check if user has required role
 			userRole := claims.Role
@@ -135,7 +200,7 @@ func (m *AuthMiddleware) OptionalAuth() echo.MiddlewareFunc {
 			token := m.extractToken(c)
 			if token != "" {
 				claims, err := m.jwtService.ValidateToken(token)
-				if err == nil {
+				if err == nil && m.sessionValid(c, claims) {
 					// Store user information in context if token is valid
 					c.Set("user_id", claims.UserID)
 					c.Set("matricule", claims.Matricule)
@@ -150,6 +215,79 @@ func (m *AuthMiddleware) OptionalAuth() echo.MiddlewareFunc {
 	}
 }
 
+// authenticateAPIKey authenticates a service account and records the request
+// against it once the handler has answered
+func (m *AuthMiddleware) authenticateAPIKey(c echo.Context, key string, next echo.HandlerFunc) error {
+	req := c.Request()
+	ip := c.RealIP()
+
+	principal, err := m.apiKeyService.Authenticate(req.Context(), key, ip)
+	if err != nil {
+		switch {
+		case errors.Is(err, apikeys.ErrIPNotAllowed):
+			return responses.Forbidden(c, "IP address not allowed for this API key")
+		case errors.Is(err, apikeys.ErrInvalidKey):
+			m.logger.Warn("API key validation failed", zap.String("remote_addr", req.RemoteAddr))
+			return responses.Unauthorized(c, "Invalid or expired API key")
+		default:
+			m.logger.Error("Failed to authenticate API key", zap.Error(err))
+			return responses.InternalServerError(c, "Failed to authenticate API key")
+		}
+	}
+
+	role := string(rbac.RoleServiceAccount)
+	c.Set("user_id", principal.ServiceAccountID)
+	c.Set("matricule", principal.Name)
+	c.Set("user_role", role)
+	c.Set("jwt_claims", &jwt.Claims{
+		UserID:    principal.ServiceAccountID,
+		Matricule: principal.Name,
+		Role:      role,
+	})
+	c.Set("rbac_service", m.rbacService)
+	c.Set("service_account", principal)
+	c.Set("service_account_id", principal.ServiceAccountID)
+	if principal.CommissariatID != "" {
+		c.Set("commissariat_id", principal.CommissariatID)
+	}
+
+	m.logger.Debug("Service account authenticated successfully",
+		zap.String("service_account", principal.Name),
+		zap.String("key_prefix", principal.KeyPrefix),
+	)
+
+	start := time.Now()
+	if err := next(c); err != nil {
+		// Laisser Echo produire la réponse pour connaître le vrai code HTTP
+		c.Error(err)
+	}
+
+	m.apiKeyService.RecordUse(req.Context(), principal, &apikeys.Usage{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		Route:      c.Path(),
+		StatusCode: c.Response().Status,
+		IPAddress:  ip,
+		Duration:   time.Since(start),
+	})
+
+	// L'erreur a déjà été traitée par c.Error
+	return nil
+}
+
+// extractAPIKey returns the API key sent in the X-API-Key header, or as a
+// Bearer token recognisable by its prefix
+func (m *AuthMiddleware) extractAPIKey(c echo.Context) string {
+	if key := c.Request().Header.Get("X-API-Key"); key != "" {
+		return key
+	}
+
+	if token := m.extractToken(c); strings.HasPrefix(token, apikeys.KeyPrefix) {
+		return token
+	}
+	return ""
+}
+
 // extractToken extracts the JWT token from Authorization header
 func (m *AuthMiddleware) extractToken(c echo.Context) string {
 	auth := c.Request().Header.Get("Authorization")
@@ -165,65 +303,93 @@ func (m *AuthMiddleware) extractToken(c echo.Context) string {
 	return parts[1]
 }
 
-// RequirePermission middleware that requires specific permission based on endpoint
-func (m *AuthMiddleware) RequirePermission() echo.MiddlewareFunc {
+// QueryToken moves an "access_token" query parameter into the Authorization
+// header for the given path prefixes. Browsers cannot set headers on EventSource
+// connections; the parameter is removed so the token does not reach the logs.
+// Register it with Echo#Pre so it runs before the authentication middlewares.
+func QueryToken(prefixes ...string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			// First check authentication
-			token := m.extractToken(c)
-			if token == "" {
-				return responses.Unauthorized(c, "Authorization token required")
+			req := c.Request()
+			token := req.URL.Query().Get("access_token")
+			if token == "" || !hasAnyPrefix(req.URL.Path, prefixes) {
+				return next(c)
 			}
 
-			claims, err := m.jwtService.ValidateToken(token)
-			if err != nil {
-				return responses.Unauthorized(c, "Invalid or expired token")
+			if req.Header.Get("Authorization") == "" {
+				req.Header.Set("Authorization", "Bearer "+token)
 			}
 
-			// Get required permission from endpoint
-			permission, err := rbac.GetPermissionFromEndpoint(c.Request().Method, c.Request().URL.Path)
-			if err != nil {
-				// If we can't determine permission, log but allow through (for auth endpoints, etc.)
-				m.logger.Debug("Cannot determine permission for endpoint",
-					zap.String("method", c.Request().Method),
-					zap.String("path", c.Request().URL.Path),
-					zap.Error(err),
-				)
-				// Still store user info in context
-				c.Set("user_id", claims.UserID)
-				c.Set("matricule", claims.Matricule)
-				c.Set("user_role", claims.Role)
-				c.Set("jwt_claims", claims)
-				c.Set("rbac_service", m.rbacService)
+			query := req.URL.Query()
+			query.Del("access_token")
+			req.URL.RawQuery = query.Encode()
+			req.RequestURI = req.URL.RequestURI()
+
+			return next(c)
+		}
+	}
+}
+
+func hasAnyPrefix(path string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(path, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// RequirePermission enforces the permission declared by the matched route (see rbac.Group).
+// It must run after the authentication middleware. Authenticated requests on a route
+// without declaration are refused, so that a new route cannot be left open by mistake.
+func (m *AuthMiddleware) RequirePermission() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			method, route := c.Request().Method, c.Path()
+			permission, declared := rbac.RequiredPermission(method, route)
+
+			role, authenticated := c.Get("user_role").(string)
+			if !authenticated {
+				if declared {
+					return responses.Unauthorized(c, "Authorization token required")
+				}
+				// Public routes, skipped by the authentication middleware
 				return next(c)
 			}
 
-			// Check if user has required permission
-			if !m.rbacService.HasPermission(claims.Role, permission) {
+			if !declared {
+				if strings.HasSuffix(route, "/*") {
+					// Echo's catch-all route: let it answer 404
+					return next(c)
+				}
+				m.logger.Warn("Access denied - no permission declared for route",
+					zap.String("method", method),
+					zap.String("route", route),
+				)
+				return responses.Forbidden(c, "Insufficient permissions")
+			}
+
+			allowed := false
+			if principal, ok := c.Get("service_account").(*apikeys.Principal); ok {
+				// Les comptes de service n'ont que les permissions qui leur ont été accordées
+				allowed = principal.HasPermission(permission)
+			} else {
+				allowed = m.rbacService.HasPermission(role, permission)
+			}
+
+			if !allowed {
+				userID, _ := c.Get("user_id").(string)
 				m.logger.Warn("Access denied - insufficient permissions",
-					zap.String("user_id", claims.UserID),
-					zap.String("user_role", claims.Role),
+					zap.String("user_id", userID),
+					zap.String("user_role", role),
 					zap.String("required_permission", string(permission)),
-					zap.String("method", c.Request().Method),
-					zap.String("path", c.Request().URL.Path),
+					zap.String("method", method),
+					zap.String("route", route),
 				)
 				return responses.Forbidden(c, "Insufficient permissions")
 			}
 
-			// Store user information in context
-			c.Set("user_id", claims.UserID)
-			c.Set("matricule", claims.Matricule)
-			c.Set("user_role", claims.Role)
-			c.Set("jwt_claims", claims)
 			c.Set("required_permission", string(permission))
-			c.Set("rbac_service", m.rbacService)
-
-			m.logger.Debug("Access granted",
-				zap.String("user_id", claims.UserID),
-				zap.String("user_role", claims.Role),
-				zap.String("permission", string(permission)),
-			)
-
 			return next(c)
 		}
 	}
@@ -223,87 +223,47 @@ func TestAuthMiddleware_RequireRole(t *testing.T) {
 }
 
 func TestAuthMiddleware_RequirePermission(t *testing.T) {
-	authMiddleware, jwtService := setupTestAuthMiddleware()
-
-	// Generate tokens for different roles
-	adminToken, err := jwtService.GenerateToken("1", "67890", "admin")
-	require.NoError(t, err)
+	authMiddleware, _ := setupTestAuthMiddleware()
 
-	supervisorToken, err := jwtService.GenerateToken("2", "11111", "supervisor")
-	require.NoError(t, err)
-
-	agentToken, err := jwtService.GenerateToken("3", "12345", "agent")
-	require.NoError(t, err)
+	// Routes declare their permission when registered
+	e := echo.New()
+	noop := func(c echo.Context) error { return nil }
+	users := rbac.Guard(e.Group("/api/v1/users"))
+	users.GET("", noop, rbac.PermReadUsers)
+	users.DELETE("/:id", noop, rbac.PermDeleteUsers)
+	controles := rbac.Guard(e.Group("/api/v1/controles"))
+	controles.POST("", noop, rbac.PermCreateControles)
+	controles.DELETE("/:id", noop, rbac.PermDeleteControles)
 
 	tests := []struct {
 		name           string
-		token          string
+		role           string // empty: request skipped by the authentication middleware
 		method         string
-		path           string
-		expectedAccess bool
+		route          string
+		expectedStatus int
 	}{
-		{
-			name:           "admin can delete users",
-			token:          adminToken,
-			method:         "DELETE",
-			path:           "/users/123",
-			expectedAccess: true,
-		},
-		{
-			name:           "supervisor cannot delete users",
-			token:          supervisorToken,
-			method:         "DELETE",
-			path:           "/users/123",
-			expectedAccess: false,
-		},
-		{
-			name:           "agent cannot delete users",
-			token:          agentToken,
-			method:         "DELETE",
-			path:           "/users/123",
-			expectedAccess: false,
-		},
-		{
-			name:           "all roles can read users",
-			token:          agentToken,
-			method:         "GET",
-			path:           "/users",
-			expectedAccess: true,
-		},
-		{
-			name:           "agent can create controles",
-			token:          agentToken,
-			method:         "POST",
-			path:           "/controles",
-			expectedAccess: true,
-		},
-		{
-			name:           "agent cannot delete controles",
-			token:          agentToken,
-			method:         "DELETE",
-			path:           "/controles/123",
-			expectedAccess: false,
-		},
-		{
-			name:           "supervisor can delete controles",
-			token:          supervisorToken,
-			method:         "DELETE",
-			path:           "/controles/123",
-			expectedAccess: true,
-		},
-		{
-			name:           "auth endpoints allow through",
-			token:          agentToken,
-			method:         "POST",
-			path:           "/auth/login",
-			expectedAccess: true, // Auth endpoints allow through
-		},
+		{"admin can delete users", "admin", "DELETE", "/api/v1/users/:id", 200},
+		{"supervisor cannot delete users", "supervisor", "DELETE", "/api/v1/users/:id", 403},
+		{"agent cannot delete users", "agent", "DELETE", "/api/v1/users/:id", 403},
+		{"all roles can read users", "agent", "GET", "/api/v1/users", 200},
+		{"agent can create controles", "agent", "POST", "/api/v1/controles", 200},
+		{"agent cannot delete controles", "agent", "DELETE", "/api/v1/controles/:id", 403},
+		{"supervisor can delete controles", "supervisor", "DELETE", "/api/v1/controles/:id", 200},
+		{"unknown role is refused", "invalid_role", "GET", "/api/v1/users", 403},
+		{"undeclared route is refused", "admin", "GET", "/api/v1/undeclared", 403},
+		{"catch-all route allows through", "agent", "GET", "/api/v1/*", 200},
+		{"public route allows through", "", "POST", "/api/v1/auth/login", 200},
+		{"declared route requires authentication", "", "GET", "/api/v1/users", 401},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			c, rec := createTestEchoContext(tt.path, tt.method)
-			c.Request().Header.Set("Authorization", "Bearer "+tt.token)
+			c, rec := createTestEchoContext(tt.route, tt.method)
+			c.SetPath(tt.route)
+			if tt.role != "" {
+				c.Set("user_id", "1")
+				c.Set("user_role", tt.role)
+			}
 
 			middleware := authMiddleware.RequirePermission()
 			handler := middleware(func(c echo.Context) error {
@@ -311,16 +271,8 @@ func TestAuthMiddleware_RequirePermission(t *testing.T) {
 			})
 
 			err := handler(c)
-			
-			if tt.expectedAccess {
-				assert.NoError(t, err)
-				assert.Equal(t, 200, rec.Code)
-				assert.NotNil(t, c.Get("user_id"))
-			} else {
-				assert.Error(t, err)
-				// For forbidden access, we expect a specific error type
-				// but the exact implementation may vary
-			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, rec.Code)
 		})
 	}
 }
@@ -480,4 +432,50 @@ func TestAuthMiddleware_ExpiredToken(t *testing.T) {
 	err = handler(c)
 	assert.Error(t, err, "Expired token should be rejected")
 	assert.Nil(t, c.Get("user_id"), "No user context should be set for expired token")
-}
\ No newline at end of file
+}
+func TestQueryToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		url           string
+		header        string
+		expectedAuth  string
+		expectedQuery string
+	}{
+		{
+			name:          "token moved to header",
+			url:           "/api/v1/stream?access_token=abc&types=alerte.created",
+			expectedAuth:  "Bearer abc",
+			expectedQuery: "types=alerte.created",
+		},
+		{
+			name:          "existing header kept",
+			url:           "/api/v1/stream?access_token=abc",
+			header:        "Bearer xyz",
+			expectedAuth:  "Bearer xyz",
+			expectedQuery: "",
+		},
+		{
+			name:          "other paths untouched",
+			url:           "/api/v1/alertes?access_token=abc",
+			expectedAuth:  "",
+			expectedQuery: "access_token=abc",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := createTestEchoContext(tt.url, "GET")
+			if tt.header != "" {
+				c.Request().Header.Set("Authorization", tt.header)
+			}
+
+			handler := QueryToken("/api/v1/stream")(func(c echo.Context) error {
+				return nil
+			})
+			require.NoError(t, handler(c))
+
+			assert.Equal(t, tt.expectedAuth, c.Request().Header.Get("Authorization"))
+			assert.Equal(t, tt.expectedQuery, c.Request().URL.RawQuery)
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/versioning"
+	"police-trafic-api-frontend-aligned/internal/shared/responses"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// HeaderETag and HeaderIfMatch carry the version of a resource
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+)
+
+// versionedRoute is a resource route, "/api/v1/plaintes/:id", and the
+// resource type its versions are recorded under, "plaintes"
+type versionedRoute struct {
+	path         string
+	resourceType string
+}
+
+// ConcurrencyMiddleware prevents concurrent edits of the same resource from
+// silently overwriting each other (optimistic locking with ETag / If-Match)
+type ConcurrencyMiddleware struct {
+	service        versioning.Service
+	routes         []versionedRoute
+	requireIfMatch bool
+	logger         *zap.Logger
+}
+
+// NewConcurrencyMiddleware creates a new concurrency middleware for the
+// resources listed in concurrency.resources
+func NewConcurrencyMiddleware(service versioning.Service, cfg *config.Config, logger *zap.Logger) (*ConcurrencyMiddleware, error) {
+	routes := make([]versionedRoute, 0, len(cfg.Concurrency.Resources))
+	for _, path := range cfg.Concurrency.Resources {
+		segments := strings.Split(strings.Trim(path, "/"), "/")
+		if len(segments) < 2 || segments[len(segments)-1] != ":id" {
+			return nil, fmt.Errorf("invalid concurrency resource %q: expected /collection/:id", path)
+		}
+		routes = append(routes, versionedRoute{
+			path:         path,
+			resourceType: segments[len(segments)-2],
+		})
+	}
+
+	return &ConcurrencyMiddleware{
+		service:        service,
+		routes:         routes,
+		requireIfMatch: cfg.Concurrency.RequireIfMatch,
+		logger:         logger,
+	}, nil
+}
+
+// Handle middleware that sends the version of a resource as the ETag of its
+// GET response and checks If-Match on every write under the resource route
+// (the resource itself and its sub-routes: /statut, /notes...). The version
+// is taken by the ent hook when the handler modifies the resource, with the
+// write and before the response; a concurrent write that got there first
+// turns the response into 412.
+func (m *ConcurrencyMiddleware) Handle() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			route, exact := m.match(c.Path())
+			resourceID := c.Param("id")
+			if route == nil || resourceID == "" {
+				return next(c)
+			}
+
+			req := c.Request()
+			switch req.Method {
+			case http.MethodGet, http.MethodHead:
+				if !exact {
+					return next(c)
+				}
+				version, err := m.service.Version(req.Context(), route.resourceType, resourceID)
+				if err != nil {
+					m.logger.Warn("Failed to get resource version",
+						zap.String("resource_type", route.resourceType),
+						zap.String("resource_id", resourceID),
+						zap.Error(err),
+					)
+					return next(c)
+				}
+				c.Response().Header().Set(HeaderETag, versioning.ETag(version))
+				return next(c)
+
+			case http.MethodPut, http.MethodPatch, http.MethodPost, http.MethodDelete:
+				return m.write(c, next, route, resourceID)
+			}
+
+			return next(c)
+		}
+	}
+}
+
+func (m *ConcurrencyMiddleware) write(c echo.Context, next echo.HandlerFunc, route *versionedRoute, resourceID string) error {
+	req := c.Request()
+
+	var expected []int
+	if header := req.Header.Get(HeaderIfMatch); header != "" {
+		versions, wildcard := versioning.ParseIfMatch(header)
+		if !wildcard && len(versions) == 0 {
+			return responses.PreconditionFailed(c, "If-Match does not match the current version of the resource")
+		}
+		expected = versions
+	} else if m.requireIfMatch && (req.Method == http.MethodPut || req.Method == http.MethodPatch) {
+		return responses.PreconditionRequired(c, "If-Match header is required to modify this resource")
+	}
+
+	// Refus immédiat d'une version déjà dépassée ; le hook revérifie à l'écriture
+	if len(expected) > 0 {
+		version, err := m.service.Version(req.Context(), route.resourceType, resourceID)
+		if err != nil {
+			m.logger.Warn("Failed to get resource version",
+				zap.String("resource_type", route.resourceType),
+				zap.String("resource_id", resourceID),
+				zap.Error(err),
+			)
+		} else if !slices.Contains(expected, version) {
+			return m.preconditionFailed(c, version)
+		}
+	}
+
+	w := &versioning.Write{
+		ResourceType: route.resourceType,
+		ResourceID:   resourceID,
+		Expected:     expected,
+	}
+	c.SetRequest(req.WithContext(versioning.NewContext(req.Context(), w)))
+
+	res := c.Response()
+	res.Before(func() {
+		if current, refused := w.Conflict(); refused {
+			// Le handler répond avec l'erreur du hook : c'est un conflit de version
+			res.Status = http.StatusPreconditionFailed
+			if current > 0 {
+				res.Header().Set(HeaderETag, versioning.ETag(current))
+			}
+			return
+		}
+		// La nouvelle version n'est annoncée que si la modification est acceptée
+		if version := w.Version(); version > 0 && res.Status < http.StatusBadRequest {
+			res.Header().Set(HeaderETag, versioning.ETag(version))
+		}
+	})
+
+	err := next(c)
+	if errors.Is(err, versioning.ErrPreconditionFailed) && !res.Committed {
+		current, _ := w.Conflict()
+		return m.preconditionFailed(c, current)
+	}
+	return err
+}
+
+func (m *ConcurrencyMiddleware) preconditionFailed(c echo.Context, current int) error {
+	if current > 0 {
+		c.Response().Header().Set(HeaderETag, versioning.ETag(current))
+	}
+	return responses.PreconditionFailed(c, "The resource was modified by another user, reload it and try again")
+}
+
+// match returns the resource route of path, and whether path is the resource
+// itself rather than one of its sub-routes
+func (m *ConcurrencyMiddleware) match(path string) (*versionedRoute, bool) {
+	for i := range m.routes {
+		route := &m.routes[i]
+		if path == route.path {
+			return route, true
+		}
+		if strings.HasPrefix(path, route.path+"/") {
+			return route, false
+		}
+	}
+	return nil, false
+}
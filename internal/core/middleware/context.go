@@ -3,6 +3,7 @@ package middleware
 import (
 	"fmt"
 
+	"police-trafic-api-frontend-aligned/internal/infrastructure/apikeys"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/jwt"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
 
@@ -44,7 +45,9 @@ func GetUserFromContext(c echo.Context) (*UserContext, error) {
 	// Get RBAC service from context to check permissions
 	rbacService := c.Get("rbac_service")
 	var hasPermission func(rbac.Permission) bool
-	if rbacSvc, ok := rbacService.(rbac.Service); ok {
+	if principal, ok := c.Get("service_account").(*apikeys.Principal); ok {
+		hasPermission = principal.HasPermission
+	} else if rbacSvc, ok := rbacService.(rbac.Service); ok {
 		hasPermission = func(permission rbac.Permission) bool {
 			return rbacSvc.HasPermission(role, permission)
 		}
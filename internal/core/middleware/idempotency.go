@@ -0,0 +1,158 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/idempotency"
+	"police-trafic-api-frontend-aligned/internal/shared/responses"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// maxIdempotentBody limits the request and response bodies of an idempotent request
+const maxIdempotentBody = 1 << 20
+
+// HeaderIdempotentReplayed marks a response replayed from a previous request
+const HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+// IdempotencyMiddleware replays the first response of the requests retried
+// with the same Idempotency-Key header
+type IdempotencyMiddleware struct {
+	service idempotency.Service
+	routes  map[string]bool
+	logger  *zap.Logger
+}
+
+// NewIdempotencyMiddleware creates a new idempotency middleware for the routes
+// listed in idempotency.routes
+func NewIdempotencyMiddleware(service idempotency.Service, cfg *config.Config, logger *zap.Logger) *IdempotencyMiddleware {
+	routes := make(map[string]bool, len(cfg.Idempotency.Routes))
+	for _, route := range cfg.Idempotency.Routes {
+		routes[route] = true
+	}
+
+	return &IdempotencyMiddleware{
+		service: service,
+		routes:  routes,
+		logger:  logger,
+	}
+}
+
+// Handle middleware that stores the response of a request sent with an
+// Idempotency-Key and replays it on retries. Requests without the header are
+// left unchanged. It must run after the authentication middleware: keys are
+// scoped to the user.
+func (m *IdempotencyMiddleware) Handle() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			key := req.Header.Get(idempotency.HeaderKey)
+			if key == "" || !m.routes[req.Method+" "+c.Path()] {
+				return next(c)
+			}
+			userID, _ := c.Get("user_id").(string)
+			if userID == "" {
+				return next(c)
+			}
+			if len(key) > idempotency.MaxKeyLength {
+				return responses.BadRequest(c, "Idempotency-Key is too long")
+			}
+
+			body, err := io.ReadAll(io.LimitReader(req.Body, maxIdempotentBody+1))
+			if err != nil {
+				return responses.BadRequest(c, "Invalid request body")
+			}
+			if len(body) > maxIdempotentBody {
+				return responses.BadRequest(c, "Request body too large for an idempotent request")
+			}
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			route := req.Method + " " + req.URL.Path
+			reservation, err := m.service.Begin(req.Context(), &idempotency.Request{
+				Key:    key,
+				UserID: userID,
+				Route:  route,
+				Body:   body,
+			})
+			switch {
+			case errors.Is(err, idempotency.ErrKeyReused):
+				return responses.UnprocessableEntity(c, "Idempotency-Key already used for a different request")
+			case errors.Is(err, idempotency.ErrInProgress):
+				c.Response().Header().Set("Retry-After", "1")
+				return responses.Conflict(c, "A request with this Idempotency-Key is still in progress")
+			case err != nil:
+				m.logger.Error("Failed to check idempotency key", zap.String("route", route), zap.Error(err))
+				return responses.InternalServerError(c, "Failed to check Idempotency-Key")
+			}
+
+			if replay := reservation.Replay; replay != nil {
+				c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+				return c.Blob(replay.StatusCode, replay.ContentType, replay.Body)
+			}
+
+			capture := &responseCapture{ResponseWriter: c.Response().Writer}
+			c.Response().Writer = capture
+
+			if err := next(c); err != nil {
+				// Produire la réponse ici pour l'enregistrer
+				c.Error(err)
+			}
+
+			// La réponse est enregistrée même si le client s'est déconnecté : c'est lui qui réessaiera
+			storeCtx, cancel := context.WithTimeout(context.WithoutCancel(req.Context()), 5*time.Second)
+			defer cancel()
+
+			status := c.Response().Status
+			if status >= http.StatusInternalServerError || capture.overflow {
+				// Une erreur serveur peut être retentée avec la même clé
+				if err := m.service.Release(storeCtx, reservation.ID); err != nil {
+					m.logger.Error("Failed to release idempotency key", zap.String("route", route), zap.Error(err))
+				}
+				return nil
+			}
+
+			err = m.service.Complete(storeCtx, reservation.ID, &idempotency.Response{
+				StatusCode:  status,
+				ContentType: c.Response().Header().Get(echo.HeaderContentType),
+				Body:        capture.body.Bytes(),
+			})
+			if err != nil {
+				m.logger.Error("Failed to store idempotent response", zap.String("route", route), zap.Error(err))
+			}
+
+			return nil
+		}
+	}
+}
+
+// responseCapture keeps a copy of the response body to replay it
+type responseCapture struct {
+	http.ResponseWriter
+	body     bytes.Buffer
+	overflow bool
+}
+
+func (w *responseCapture) Write(b []byte) (int, error) {
+	if !w.overflow {
+		if w.body.Len()+len(b) > maxIdempotentBody {
+			w.overflow = true
+			w.body.Reset()
+		} else {
+			w.body.Write(b)
+		}
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseCapture) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
@@ -0,0 +1,255 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/idempotency"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// memoryIdempotencyRepository keeps the idempotency keys in memory
+type memoryIdempotencyRepository struct {
+	mu      sync.Mutex
+	records map[uuid.UUID]*ent.IdempotencyKey
+}
+
+func (r *memoryIdempotencyRepository) Reserve(ctx context.Context, input *repository.ReserveIdempotencyKeyInput) (*ent.IdempotencyKey, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, rec := range r.records {
+		if rec.UserID == input.UserID && rec.Route == input.Route && rec.Key == input.Key {
+			return nil, false, nil
+		}
+	}
+	rec := &ent.IdempotencyKey{
+		ID:          uuid.New(),
+		Key:         input.Key,
+		UserID:      input.UserID,
+		Route:       input.Route,
+		RequestHash: input.RequestHash,
+		LockedUntil: &input.LockedUntil,
+		ExpiresAt:   input.ExpiresAt,
+	}
+	r.records[rec.ID] = rec
+	return rec, true, nil
+}
+
+func (r *memoryIdempotencyRepository) Get(ctx context.Context, userID, route, key string) (*ent.IdempotencyKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, rec := range r.records {
+		if rec.UserID == userID && rec.Route == route && rec.Key == key {
+			return rec, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memoryIdempotencyRepository) TakeOver(ctx context.Context, id uuid.UUID, now, lockedUntil time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec, ok := r.records[id]
+	if !ok || rec.StatusCode != nil || rec.LockedUntil == nil || !rec.LockedUntil.Before(now) {
+		return false, nil
+	}
+	rec.LockedUntil = &lockedUntil
+	return true, nil
+}
+
+func (r *memoryIdempotencyRepository) Complete(ctx context.Context, id uuid.UUID, input *repository.CompleteIdempotencyKeyInput) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec := r.records[id]
+	rec.StatusCode = &input.StatusCode
+	rec.ContentType = input.ContentType
+	rec.ResponseBody = input.Body
+	rec.LockedUntil = nil
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.records, id)
+	return nil
+}
+
+func (r *memoryIdempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deleted := 0
+	for id, rec := range r.records {
+		if rec.ExpiresAt.Before(before) {
+			delete(r.records, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// newIdempotentEcho serves POST /api/v1/pv/:id/payer, counting the calls of
+// the handler; the handler fails with status when it is set
+func newIdempotentEcho(repo *memoryIdempotencyRepository, calls *int, status *int) *echo.Echo {
+	cfg := &config.Config{Idempotency: config.IdempotencyConfig{
+		TTL:         time.Hour,
+		LockTimeout: time.Minute,
+		Routes:      []string{"POST /api/v1/pv/:id/payer"},
+	}}
+	m := NewIdempotencyMiddleware(idempotency.NewService(repo, cfg, zap.NewNop()), cfg, zap.NewNop())
+
+	e := echo.New()
+	api := e.Group("/api/v1", func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Set("user_id", c.Request().Header.Get("X-Test-User"))
+			return next(c)
+		}
+	}, m.Handle())
+	api.POST("/pv/:id/payer", func(c echo.Context) error {
+		*calls++
+		if *status != 0 {
+			return c.JSON(*status, map[string]string{"message": "failed"})
+		}
+		return c.JSON(http.StatusCreated, map[string]interface{}{"paiement": *calls})
+	})
+
+	return e
+}
+
+func sendIdempotent(e *echo.Echo, user, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/pv/42/payer", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Test-User", user)
+	if key != "" {
+		req.Header.Set(idempotency.HeaderKey, key)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestIdempotency_ReplaysFirstResponse(t *testing.T) {
+	repo := &memoryIdempotencyRepository{records: map[uuid.UUID]*ent.IdempotencyKey{}}
+	calls, status := 0, 0
+	e := newIdempotentEcho(repo, &calls, &status)
+
+	first := sendIdempotent(e, "user-1", "key-1", `{"montant":5000}`)
+	assert.Equal(t, http.StatusCreated, first.Code)
+
+	retry := sendIdempotent(e, "user-1", "key-1", `{"montant":5000}`)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get(HeaderIdempotentReplayed))
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, 1, calls)
+
+	// Même clé, autre utilisateur : requête distincte
+	other := sendIdempotent(e, "user-2", "key-1", `{"montant":5000}`)
+	assert.Equal(t, http.StatusCreated, other.Code)
+	assert.Empty(t, other.Header().Get(HeaderIdempotentReplayed))
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_RejectsDifferentBody(t *testing.T) {
+	repo := &memoryIdempotencyRepository{records: map[uuid.UUID]*ent.IdempotencyKey{}}
+	calls, status := 0, 0
+	e := newIdempotentEcho(repo, &calls, &status)
+
+	sendIdempotent(e, "user-1", "key-1", `{"montant":5000}`)
+	rec := sendIdempotent(e, "user-1", "key-1", `{"montant":9000}`)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	repo := &memoryIdempotencyRepository{records: map[uuid.UUID]*ent.IdempotencyKey{}}
+	calls, status := 0, 0
+	e := newIdempotentEcho(repo, &calls, &status)
+
+	// Une autre instance traite la première requête
+	other := idempotency.NewService(repo, &config.Config{}, zap.NewNop())
+	_, err := other.Begin(context.Background(), &idempotency.Request{
+		Key:    "key-1",
+		UserID: "user-1",
+		Route:  "POST /api/v1/pv/42/payer",
+		Body:   []byte(`{"montant":5000}`),
+	})
+	assert.NoError(t, err)
+
+	rec := sendIdempotent(e, "user-1", "key-1", `{"montant":5000}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Equal(t, 0, calls)
+
+	// Après l'expiration du verrou, la requête est reprise
+	past := time.Now().Add(-time.Second)
+	repo.records[firstID(repo)].LockedUntil = &past
+	rec = sendIdempotent(e, "user-1", "key-1", `{"montant":5000}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, 1, calls)
+}
+
+func TestIdempotency_ServerErrorCanBeRetried(t *testing.T) {
+	repo := &memoryIdempotencyRepository{records: map[uuid.UUID]*ent.IdempotencyKey{}}
+	calls, status := 0, http.StatusInternalServerError
+	e := newIdempotentEcho(repo, &calls, &status)
+
+	rec := sendIdempotent(e, "user-1", "key-1", `{"montant":5000}`)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Empty(t, repo.records)
+
+	status = 0
+	rec = sendIdempotent(e, "user-1", "key-1", `{"montant":5000}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_ExpiredKeyIsForgotten(t *testing.T) {
+	repo := &memoryIdempotencyRepository{records: map[uuid.UUID]*ent.IdempotencyKey{}}
+	calls, status := 0, 0
+	e := newIdempotentEcho(repo, &calls, &status)
+
+	sendIdempotent(e, "user-1", "key-1", `{"montant":5000}`)
+	repo.records[firstID(repo)].ExpiresAt = time.Now().Add(-time.Second)
+
+	rec := sendIdempotent(e, "user-1", "key-1", `{"montant":9000}`)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotency_WithoutHeader(t *testing.T) {
+	repo := &memoryIdempotencyRepository{records: map[uuid.UUID]*ent.IdempotencyKey{}}
+	calls, status := 0, 0
+	e := newIdempotentEcho(repo, &calls, &status)
+
+	sendIdempotent(e, "user-1", "", `{"montant":5000}`)
+	sendIdempotent(e, "user-1", "", `{"montant":5000}`)
+
+	assert.Equal(t, 2, calls)
+	assert.Empty(t, repo.records)
+}
+
+func firstID(repo *memoryIdempotencyRepository) uuid.UUID {
+	for id := range repo.records {
+		return id
+	}
+	return uuid.Nil
+}
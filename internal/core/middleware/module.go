@@ -5,4 +5,8 @@ import "go.uber.org/fx"
 // Module provides middleware dependencies
 var Module = fx.Module("middleware",
 	fx.Provide(NewAuthMiddleware),
+	fx.Provide(NewAuditMiddleware),
+	fx.Provide(NewTenantMiddleware),
+	fx.Provide(NewIdempotencyMiddleware),
+	fx.Provide(NewConcurrencyMiddleware),
 )
\ No newline at end of file
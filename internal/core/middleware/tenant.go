@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tenant"
+	"police-trafic-api-frontend-aligned/internal/shared/responses"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// TenantMiddleware attaches the caller's commissariat scope to the request context
+type TenantMiddleware struct {
+	resolver tenant.Resolver
+	logger   *zap.Logger
+}
+
+// NewTenantMiddleware creates a new tenant middleware
+func NewTenantMiddleware(resolver tenant.Resolver, logger *zap.Logger) *TenantMiddleware {
+	return &TenantMiddleware{
+		resolver: resolver,
+		logger:   logger,
+	}
+}
+
+// Scope middleware that restricts the data reachable by the request to the
+// user's commissariat (or region for supervisors).
+// It must run after the authentication middleware; unauthenticated requests are left unscoped.
+func (m *TenantMiddleware) Scope() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userID, ok := c.Get("user_id").(string)
+			if !ok || userID == "" {
+				return next(c)
+			}
+			role, _ := c.Get("user_role").(string)
+			commissariatID, _ := c.Get("commissariat_id").(string)
+
+			req := c.Request()
+			scope, err := m.resolver.Resolve(req.Context(), userID, role, commissariatID)
+			if err != nil {
+				m.logger.Error("Failed to resolve data scope",
+					zap.String("user_id", userID),
+					zap.Error(err),
+				)
+				return responses.InternalServerError(c, "Failed to resolve data scope")
+			}
+
+			c.SetRequest(req.WithContext(tenant.NewContext(req.Context(), scope)))
+			return next(c)
+		}
+	}
+}
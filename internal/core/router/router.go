@@ -5,6 +5,7 @@ import (
 	"police-trafic-api-frontend-aligned/internal/core/middleware"
 	"police-trafic-api-frontend-aligned/internal/core/server"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/jwt"
 
 	"go.uber.org/zap"
 )
@@ -14,7 +15,12 @@ func NewServer(
 	cfg *config.Config,
 	logger *zap.Logger,
 	authMiddleware *middleware.AuthMiddleware,
+	auditMiddleware *middleware.AuditMiddleware,
+	tenantMiddleware *middleware.TenantMiddleware,
+	idempotencyMiddleware *middleware.IdempotencyMiddleware,
+	concurrencyMiddleware *middleware.ConcurrencyMiddleware,
+	jwtService jwt.Service,
 	controllers []interfaces.Controller,
 ) *server.Server {
-	return server.NewServer(cfg, logger, authMiddleware, controllers...)
+	return server.NewServer(cfg, logger, authMiddleware, auditMiddleware, tenantMiddleware, idempotencyMiddleware, concurrencyMiddleware, jwtService, controllers...)
 }
\ No newline at end of file
@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
 	"time"
@@ -10,6 +11,10 @@ import (
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
 	coremiddleware "police-trafic-api-frontend-aligned/internal/core/middleware"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/jwt"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/metrics"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
@@ -28,18 +33,30 @@ func (cv *CustomValidator) Validate(i interface{}) error {
 	return cv.validator.Struct(i)
 }
 
+// streamPath is the long-lived real-time endpoint (Server-Sent Events)
+const streamPath = "/api/v1/stream"
+
 type Server struct {
-	echo           *echo.Echo
-	config         *config.Config
-	logger         *zap.Logger
-	controllers    []interfaces.Controller
-	authMiddleware *coremiddleware.AuthMiddleware
+	echo            *echo.Echo
+	config          *config.Config
+	logger          *zap.Logger
+	controllers     []interfaces.Controller
+	authMiddleware        *coremiddleware.AuthMiddleware
+	auditMiddleware       *coremiddleware.AuditMiddleware
+	tenantMiddleware      *coremiddleware.TenantMiddleware
+	idempotencyMiddleware *coremiddleware.IdempotencyMiddleware
+	concurrencyMiddleware *coremiddleware.ConcurrencyMiddleware
 }
 
 func NewServer(
 	cfg *config.Config,
 	logger *zap.Logger,
 	authMiddleware *coremiddleware.AuthMiddleware,
+	auditMiddleware *coremiddleware.AuditMiddleware,
+	tenantMiddleware *coremiddleware.TenantMiddleware,
+	idempotencyMiddleware *coremiddleware.IdempotencyMiddleware,
+	concurrencyMiddleware *coremiddleware.ConcurrencyMiddleware,
+	jwtService jwt.Service,
 	controllers ...interfaces.Controller,
 ) *Server {
 	e := echo.New()
@@ -48,15 +65,40 @@ func NewServer(
 	e.HideBanner = true
 	e.HidePort = true
 
+	// Adresse du client utilisée par les limitations de connexion, les listes d'IP
+	// des clés d'API et l'audit : jamais reprise d'un en-tête que le client forge
+	e.IPExtractor = clientIPExtractor(cfg.Server.TrustedProxies, logger)
+
 	// Set up validator
 	e.Validator = &CustomValidator{validator: validator.New()}
 
+	// Curseurs de pagination signés avec une clé commune aux instances
+	pagination.SetCursorKey(cfg.JWT.Secret)
+
 	// Add middlewares
-	e.Use(middleware.Logger())
+	e.Pre(coremiddleware.QueryToken(streamPath))
+	if cfg.Metrics.Enabled {
+		// En premier : mesure aussi les réponses des middlewares suivants (401, 403, panics)
+		e.Use(metrics.Middleware())
+	}
+	if tracing.Enabled(cfg) {
+		// Span de chaque requête ; le journal d'accès passe alors par zap pour porter le trace_id
+		e.Use(tracing.Middleware(cfg.App.Name, streamPath, "/metrics", "/health"))
+		e.Use(tracing.RequestLogger(logger))
+	} else {
+		e.Use(middleware.Logger())
+	}
 	e.Use(middleware.Recover())
-	e.Use(middleware.CORS())
+	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+		// Version lue par le frontend pour la renvoyer dans If-Match
+		ExposeHeaders: []string{coremiddleware.HeaderETag},
+	}))
 	e.Use(middleware.RequestID())
 	e.Use(middleware.TimeoutWithConfig(middleware.TimeoutConfig{
+		// Le flux temps réel reste ouvert tant que le client est connecté
+		Skipper: func(c echo.Context) bool {
+			return strings.HasPrefix(c.Request().URL.Path, streamPath)
+		},
 		Timeout: 30 * time.Second,
 	}))
 
@@ -70,32 +112,96 @@ func NewServer(
 		})
 	})
 
+	// Clés publiques de vérification des jetons, pour les services partenaires (vide en HS256)
+	e.GET("/.well-known/jwks.json", func(c echo.Context) error {
+		c.Response().Header().Set("Cache-Control", "public, max-age=300")
+		return c.JSON(http.StatusOK, jwtService.JWKS())
+	})
+
+	// Métriques Prometheus
+	if cfg.Metrics.Enabled {
+		e.GET("/metrics", metrics.Handler(cfg.Metrics.Token))
+	}
+
 	// Swagger documentation
 	if cfg.App.Environment == "development" {
 		e.GET("/swagger/*", echoSwagger.WrapHandler)
 	}
 
 	return &Server{
-		echo:           e,
-		config:         cfg,
-		logger:         logger,
-		controllers:    controllers,
-		authMiddleware: authMiddleware,
+		echo:                  e,
+		config:                cfg,
+		logger:                logger,
+		controllers:           controllers,
+		authMiddleware:        authMiddleware,
+		auditMiddleware:       auditMiddleware,
+		tenantMiddleware:      tenantMiddleware,
+		idempotencyMiddleware: idempotencyMiddleware,
+		concurrencyMiddleware: concurrencyMiddleware,
 	}
 }
 
+// clientIPExtractor reads X-Forwarded-For only behind the configured proxies,
+// walking it back to the first address that is not one of them. Without
+// proxies the client is the peer of the connection.
+func clientIPExtractor(proxies []string, logger *zap.Logger) echo.IPExtractor {
+	if len(proxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, proxy := range proxies {
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			logger.Warn("Ignoring invalid trusted proxy", zap.String("proxy", proxy), zap.Error(err))
+			continue
+		}
+		options = append(options, echo.TrustIPRange(ipNet))
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
 func (s *Server) Start(ctx context.Context) error {
 	// Register all controller routes under /api/v1 prefix
 	// Apply JWT authentication middleware to /api/v1 group (except /api/v1/auth)
 	api := s.echo.Group("/api/v1", s.authMiddleware.RequireAuthWithSkipper(func(path string) bool {
 		// Skip authentication for all auth routes
-		skip := strings.HasPrefix(path, "/api/v1/auth")
+		skip := strings.HasPrefix(path, "/api/v1/auth") ||
+			// Téléchargements par lien signé : la signature tient lieu de jeton
+			strings.HasPrefix(path, "/api/v1/files/")
 		s.logger.Debug("Auth middleware check", 
 			zap.String("path", path),
 			zap.Bool("skip", skip))
 		return skip
 	}))
-	
+
+	// Cloisonnement des données par commissariat (région pour les superviseurs, national pour les admins)
+	api.Use(s.tenantMiddleware.Scope())
+
+	// Journal d'audit de toutes les requêtes POST/PUT/PATCH/DELETE (après l'authentification ;
+	// avant le rejeu d'Idempotency-Key, dont les réponses sont marquées idempotent_replay)
+	api.Use(s.auditMiddleware.Record())
+
+	// Contrôle centralisé de la permission déclarée par chaque route (rbac.Guard)
+	api.Use(s.authMiddleware.RequirePermission())
+
+	// Rejeu de la première réponse des requêtes retentées avec le même Idempotency-Key
+	api.Use(s.idempotencyMiddleware.Handle())
+
+	// Verrouillage optimiste des ressources modifiées à plusieurs (ETag / If-Match)
+	api.Use(s.concurrencyMiddleware.Handle())
+
 	s.logger.Info("Registering controllers", zap.Int("count", len(s.controllers)))
 	for _, controller := range s.controllers {
 		controller.RegisterRoutes(api)
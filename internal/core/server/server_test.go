@@ -0,0 +1,35 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestClientIPExtractor(t *testing.T) {
+	request := func(remoteAddr, xff string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", nil)
+		req.RemoteAddr = remoteAddr
+		if xff != "" {
+			req.Header.Set(echo.HeaderXForwardedFor, xff)
+		}
+		req.Header.Set(echo.HeaderXRealIP, "203.0.113.99")
+		return req
+	}
+
+	// Sans proxy de confiance, les en-têtes du client sont ignorés
+	direct := clientIPExtractor(nil, zap.NewNop())
+	assert.Equal(t, "198.51.100.7", direct(request("198.51.100.7:4321", "203.0.113.5")))
+
+	behindProxy := clientIPExtractor([]string{"10.0.0.0/8", "192.0.2.10", "not-an-ip"}, zap.NewNop())
+	assert.Equal(t, "203.0.113.5", behindProxy(request("10.1.2.3:4321", "203.0.113.5")))
+	assert.Equal(t, "203.0.113.5", behindProxy(request("192.0.2.10:4321", "203.0.113.5, 10.4.5.6")))
+	// Une adresse forgée en tête de X-Forwarded-For n'est pas reprise
+	assert.Equal(t, "203.0.113.5", behindProxy(request("10.1.2.3:4321", "1.2.3.4, 203.0.113.5")))
+	// Connexion directe, hors proxy : X-Forwarded-For est ignoré
+	assert.Equal(t, "198.51.100.7", behindProxy(request("198.51.100.7:4321", "203.0.113.5")))
+}
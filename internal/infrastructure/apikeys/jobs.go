@@ -0,0 +1,24 @@
+package apikeys
+
+import (
+	"context"
+	"fmt"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/scheduler"
+)
+
+// NewCleanupJob removes the API key usage entries older than the retention
+func NewCleanupJob(service Service) scheduler.Job {
+	return scheduler.Job{
+		Name:        "api-key-usage-cleanup",
+		Description: "Supprime le journal d'utilisation des clés d'API au-delà de la durée de conservation",
+		Schedule:    "30 3 * * *",
+		Run: func(ctx context.Context) (string, error) {
+			deleted, err := service.CleanupUsage(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d entrées supprimées", deleted), nil
+		},
+	}
+}
@@ -0,0 +1,111 @@
+package apikeys
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// KeyPrefix starts every API key, so that keys can be told apart from JWTs
+// and found by secret scanners
+const KeyPrefix = "ptk_"
+
+const (
+	prefixBytes = 4  // Public part, stored in clear to find the key
+	secretBytes = 32 // Secret part, only its hash is stored
+)
+
+// Generate creates a new key "ptk_<prefix>_<secret>". The prefix identifies the
+// key; the full key is returned once and only its hash is kept.
+func Generate() (key, prefix string, err error) {
+	p := make([]byte, prefixBytes)
+	if _, err := rand.Read(p); err != nil {
+		return "", "", err
+	}
+	secret := make([]byte, secretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+
+	prefix = hex.EncodeToString(p)
+	return KeyPrefix + prefix + "_" + hex.EncodeToString(secret), prefix, nil
+}
+
+// Parse returns the prefix of a key, or false if the key is malformed
+func Parse(key string) (string, bool) {
+	if !strings.HasPrefix(key, KeyPrefix) {
+		return "", false
+	}
+
+	prefix, secret, found := strings.Cut(strings.TrimPrefix(key, KeyPrefix), "_")
+	if !found || len(prefix) != 2*prefixBytes || len(secret) != 2*secretBytes {
+		return "", false
+	}
+	if _, err := hex.DecodeString(prefix); err != nil {
+		return "", false
+	}
+	if _, err := hex.DecodeString(secret); err != nil {
+		return "", false
+	}
+
+	return prefix, true
+}
+
+// Hash returns the hex SHA-256 of a key. Keys are random and long enough that
+// a slow password hash is not needed.
+func Hash(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// Matches compares a key with a stored hash in constant time
+func Matches(key, hash string) bool {
+	return subtle.ConstantTimeCompare([]byte(Hash(key)), []byte(hash)) == 1
+}
+
+// ValidateAllowedIPs checks that every entry is an IP address or a CIDR range
+func ValidateAllowedIPs(entries []string) error {
+	for _, entry := range entries {
+		if strings.Contains(entry, "/") {
+			if _, _, err := net.ParseCIDR(entry); err != nil {
+				return fmt.Errorf("invalid CIDR range %q", entry)
+			}
+			continue
+		}
+		if net.ParseIP(entry) == nil {
+			return fmt.Errorf("invalid IP address %q", entry)
+		}
+	}
+
+	return nil
+}
+
+// IPAllowed tells whether ip matches one of the allow-list entries. An empty
+// list allows every address.
+func IPAllowed(allowed []string, ip string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	for _, entry := range allowed {
+		if strings.Contains(entry, "/") {
+			if _, network, err := net.ParseCIDR(entry); err == nil && network.Contains(addr) {
+				return true
+			}
+			continue
+		}
+		if allowedIP := net.ParseIP(entry); allowedIP != nil && allowedIP.Equal(addr) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,96 @@
+package apikeys
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateAndParse(t *testing.T) {
+	key, prefix, err := Generate()
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if !strings.HasPrefix(key, KeyPrefix+prefix+"_") {
+		t.Fatalf("key %q does not start with %q", key, KeyPrefix+prefix+"_")
+	}
+
+	parsed, ok := Parse(key)
+	if !ok || parsed != prefix {
+		t.Fatalf("Parse(%q) = %q, %v, want %q, true", key, parsed, ok, prefix)
+	}
+
+	other, _, err := Generate()
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	if other == key {
+		t.Fatal("Generate returned the same key twice")
+	}
+}
+
+func TestParseRejectsMalformedKeys(t *testing.T) {
+	secret := strings.Repeat("ab", secretBytes)
+	for _, key := range []string{
+		"",
+		"eyJhbGciOiJIUzI1NiJ9.e30.sig",
+		"ptk_",
+		"ptk_0011aabb",
+		"ptk_0011aabb_" + secret[:10],
+		"ptk_0011aabz_" + secret,
+		"ptk_0011aabb_" + secret[:len(secret)-1] + "z",
+		"xyz_0011aabb_" + secret,
+	} {
+		if _, ok := Parse(key); ok {
+			t.Errorf("Parse(%q) accepted a malformed key", key)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	key, _, err := Generate()
+	if err != nil {
+		t.Fatalf("Generate: %v", err)
+	}
+	hash := Hash(key)
+
+	if !Matches(key, hash) {
+		t.Error("Matches rejected the key of the hash")
+	}
+	if Matches(key+"0", hash) {
+		t.Error("Matches accepted another key")
+	}
+}
+
+func TestIPAllowed(t *testing.T) {
+	allowed := []string{"10.0.0.0/8", "192.168.1.10", "2001:db8::/32"}
+
+	tests := map[string]bool{
+		"10.1.2.3":     true,
+		"192.168.1.10": true,
+		"192.168.1.11": false,
+		"2001:db8::1":  true,
+		"2001:db9::1":  false,
+		"not-an-ip":    false,
+	}
+	for ip, want := range tests {
+		if got := IPAllowed(allowed, ip); got != want {
+			t.Errorf("IPAllowed(%q) = %v, want %v", ip, got, want)
+		}
+	}
+
+	if !IPAllowed(nil, "203.0.113.7") {
+		t.Error("an empty allow-list must allow every address")
+	}
+}
+
+func TestValidateAllowedIPs(t *testing.T) {
+	if err := ValidateAllowedIPs([]string{"10.0.0.0/8", "192.168.1.10", "::1"}); err != nil {
+		t.Errorf("ValidateAllowedIPs rejected valid entries: %v", err)
+	}
+
+	for _, entry := range []string{"10.0.0.0/33", "192.168.1", "example.org"} {
+		if err := ValidateAllowedIPs([]string{entry}); err == nil {
+			t.Errorf("ValidateAllowedIPs accepted %q", entry)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package apikeys
+
+import "go.uber.org/fx"
+
+// Module provides the API key authentication service
+var Module = fx.Module("apikeys",
+	fx.Provide(NewService),
+	fx.Provide(
+		fx.Annotate(
+			NewCleanupJob,
+			fx.ResultTags(`group:"jobs"`),
+		),
+	),
+)
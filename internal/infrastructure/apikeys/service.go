@@ -0,0 +1,259 @@
+package apikeys
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultUsageRetention = 90 * 24 * time.Hour
+	// touchInterval limits the writes of the last use date of a key
+	touchInterval = time.Minute
+)
+
+var (
+	// ErrInvalidKey is returned for a malformed, unknown, revoked or expired key,
+	// or a key of a disabled service account
+	ErrInvalidKey = errors.New("invalid or expired API key")
+	// ErrIPNotAllowed is returned when the key is used from outside the allow-list
+	ErrIPNotAllowed = errors.New("IP address not allowed for this API key")
+)
+
+// Principal is the service account authenticated by an API key
+type Principal struct {
+	ServiceAccountID string
+	Name             string
+	KeyID            string
+	KeyPrefix        string
+	Permissions      []string
+	CommissariatID   string // Empty for a national service account
+}
+
+// HasPermission tells whether the service account was granted the permission
+func (p *Principal) HasPermission(permission rbac.Permission) bool {
+	for _, perm := range p.Permissions {
+		if perm == string(permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// Usage describes one request made with an API key
+type Usage struct {
+	Method     string
+	Path       string
+	Route      string
+	StatusCode int
+	IPAddress  string
+	Duration   time.Duration
+}
+
+// IssuedKey is a new key, returned in clear only once
+type IssuedKey struct {
+	Key    string
+	APIKey *ent.APIKey
+}
+
+// Service authenticates API keys and manages their lifecycle
+type Service interface {
+	// Authenticate checks a key and the address it is used from
+	Authenticate(ctx context.Context, key, ipAddress string) (*Principal, error)
+	// RecordUse logs a request made with a key. Failures are logged and never
+	// propagated.
+	RecordUse(ctx context.Context, principal *Principal, usage *Usage)
+	// Issue creates a key for a service account. A nil expiresAt gives the
+	// configured lifetime.
+	Issue(ctx context.Context, serviceAccountID uuid.UUID, expiresAt *time.Time, createdBy string) (*IssuedKey, error)
+	// Rotate issues a new key; the previous ones stay valid during the
+	// configured grace period
+	Rotate(ctx context.Context, serviceAccountID uuid.UUID, createdBy string) (*IssuedKey, error)
+	Revoke(ctx context.Context, serviceAccountID, keyID uuid.UUID) error
+	// CleanupUsage removes the usage entries older than the retention
+	CleanupUsage(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo   repository.ServiceAccountRepository
+	cfg    config.APIKeyConfig
+	logger *zap.Logger
+}
+
+// NewService creates a new API key service
+func NewService(repo repository.ServiceAccountRepository, cfg *config.Config, logger *zap.Logger) Service {
+	apiKeys := cfg.Auth.APIKeys
+	if apiKeys.UsageRetention <= 0 {
+		apiKeys.UsageRetention = defaultUsageRetention
+	}
+
+	return &service{
+		repo:   repo,
+		cfg:    apiKeys,
+		logger: logger,
+	}
+}
+
+func (s *service) Authenticate(ctx context.Context, key, ipAddress string) (*Principal, error) {
+	prefix, ok := Parse(key)
+	if !ok {
+		return nil, ErrInvalidKey
+	}
+
+	apiKey, err := s.repo.GetKeyByPrefix(ctx, prefix)
+	if err != nil {
+		return nil, err
+	}
+	if apiKey == nil || !Matches(key, apiKey.KeyHash) {
+		return nil, ErrInvalidKey
+	}
+
+	now := time.Now()
+	account := apiKey.Edges.ServiceAccount
+	switch {
+	case apiKey.RevokedAt != nil,
+		apiKey.ExpiresAt != nil && now.After(*apiKey.ExpiresAt),
+		account == nil || !account.Active:
+		s.logger.Warn("Refused API key",
+			zap.String("key_prefix", prefix),
+			zap.String("ip", ipAddress),
+		)
+		return nil, ErrInvalidKey
+	}
+
+	if !IPAllowed(account.AllowedIps, ipAddress) {
+		s.logger.Warn("API key used from a non allowed address",
+			zap.String("service_account", account.Name),
+			zap.String("key_prefix", prefix),
+			zap.String("ip", ipAddress),
+		)
+		return nil, ErrIPNotAllowed
+	}
+
+	if apiKey.LastUsedAt == nil || now.Sub(*apiKey.LastUsedAt) >= touchInterval {
+		if err := s.repo.TouchKey(ctx, apiKey, ipAddress, now); err != nil {
+			s.logger.Error("Failed to record API key last use", zap.String("key_prefix", prefix), zap.Error(err))
+		}
+	}
+
+	principal := &Principal{
+		ServiceAccountID: account.ID.String(),
+		Name:             account.Name,
+		KeyID:            apiKey.ID.String(),
+		KeyPrefix:        prefix,
+		Permissions:      account.Permissions,
+	}
+	if account.CommissariatID != nil {
+		principal.CommissariatID = account.CommissariatID.String()
+	}
+
+	return principal, nil
+}
+
+func (s *service) RecordUse(ctx context.Context, principal *Principal, usage *Usage) {
+	serviceAccountID, err := uuid.Parse(principal.ServiceAccountID)
+	if err != nil {
+		return
+	}
+	keyID, err := uuid.Parse(principal.KeyID)
+	if err != nil {
+		return
+	}
+
+	// Comme pour l'audit, l'utilisation est enregistrée même si le client s'est déconnecté
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	err = s.repo.RecordUsage(writeCtx, &repository.APIKeyUsageInput{
+		ServiceAccountID: serviceAccountID,
+		APIKeyID:         keyID,
+		KeyPrefix:        principal.KeyPrefix,
+		Method:           usage.Method,
+		Path:             usage.Path,
+		Route:            usage.Route,
+		StatusCode:       usage.StatusCode,
+		IPAddress:        usage.IPAddress,
+		Duration:         usage.Duration,
+	})
+	if err != nil {
+		s.logger.Error("Failed to record API key usage",
+			zap.String("service_account", principal.Name),
+			zap.String("key_prefix", principal.KeyPrefix),
+			zap.Error(err),
+		)
+	}
+}
+
+func (s *service) Issue(ctx context.Context, serviceAccountID uuid.UUID, expiresAt *time.Time, createdBy string) (*IssuedKey, error) {
+	key, prefix, err := Generate()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate API key: %w", err)
+	}
+
+	if expiresAt == nil && s.cfg.DefaultTTL > 0 {
+		defaultExpiry := time.Now().Add(s.cfg.DefaultTTL)
+		expiresAt = &defaultExpiry
+	}
+
+	apiKey, err := s.repo.CreateKey(ctx, &repository.CreateAPIKeyInput{
+		ServiceAccountID: serviceAccountID,
+		Prefix:           prefix,
+		KeyHash:          Hash(key),
+		ExpiresAt:        expiresAt,
+		CreatedBy:        createdBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("API key issued",
+		zap.String("service_account_id", serviceAccountID.String()),
+		zap.String("key_prefix", prefix),
+		zap.String("created_by", createdBy),
+	)
+	return &IssuedKey{Key: key, APIKey: apiKey}, nil
+}
+
+func (s *service) Rotate(ctx context.Context, serviceAccountID uuid.UUID, createdBy string) (*IssuedKey, error) {
+	issued, err := s.Issue(ctx, serviceAccountID, nil, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	// Les anciennes clés restent valides le temps que le partenaire déploie la nouvelle
+	graceEnd := time.Now().Add(s.cfg.RotationGrace)
+	if err := s.repo.ExpireKeys(ctx, serviceAccountID, issued.APIKey.ID, graceEnd); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("API keys rotated",
+		zap.String("service_account_id", serviceAccountID.String()),
+		zap.Time("previous_keys_expire_at", graceEnd),
+	)
+	return issued, nil
+}
+
+func (s *service) Revoke(ctx context.Context, serviceAccountID, keyID uuid.UUID) error {
+	if err := s.repo.RevokeKey(ctx, serviceAccountID, keyID); err != nil {
+		return err
+	}
+
+	s.logger.Info("API key revoked",
+		zap.String("service_account_id", serviceAccountID.String()),
+		zap.String("key_id", keyID.String()),
+	)
+	return nil
+}
+
+func (s *service) CleanupUsage(ctx context.Context) (int, error) {
+	return s.repo.DeleteUsageBefore(ctx, time.Now().Add(-s.cfg.UsageRetention))
+}
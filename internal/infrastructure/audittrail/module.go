@@ -0,0 +1,8 @@
+package audittrail
+
+import "go.uber.org/fx"
+
+// Module provides audit trail service dependency
+var Module = fx.Module("audittrail",
+	fx.Provide(NewService),
+)
@@ -0,0 +1,156 @@
+package audittrail
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+
+	"go.uber.org/zap"
+)
+
+// Statuts d'une entrée d'audit
+const (
+	StatusSuccess = "SUCCESS"
+	StatusFailure = "FAILURE" // refus métier ou requête invalide (4xx)
+	StatusError   = "ERROR"   // erreur serveur (5xx)
+)
+
+// Entry describes one mutating API call as seen by the HTTP layer
+type Entry struct {
+	Action         string
+	ResourceType   string
+	ResourceID     string
+	UserID         string
+	Matricule      string
+	Role           string
+	CommissariatID string
+	SessionID      string
+	IPAddress      string
+	UserAgent      string
+	Method         string
+	Path           string
+	Route          string
+	RequestID      string
+	StatusCode     int
+	Duration       time.Duration
+	ErrorMessage   string
+	Trail          *Trail
+	// Replayed is set for a retry answered with the stored response of an
+	// Idempotency-Key: the operation itself ran once, on the first request
+	Replayed bool
+
+	// ServiceAccountID is set for the requests authenticated with an API key;
+	// UserID then holds the same value and is not linked to a user
+	ServiceAccountID string
+}
+
+// Service records audit entries
+type Service interface {
+	Record(ctx context.Context, entry *Entry)
+}
+
+type service struct {
+	auditRepo repository.AuditLogRepository
+	logger    *zap.Logger
+}
+
+// NewService creates a new audit trail service
+func NewService(auditRepo repository.AuditLogRepository, logger *zap.Logger) Service {
+	return &service{
+		auditRepo: auditRepo,
+		logger:    logger,
+	}
+}
+
+// Record persists an entry. Failures are logged and never propagated: an audit
+// problem must not turn a successful business operation into an error.
+func (s *service) Record(ctx context.Context, entry *Entry) {
+	input := &repository.CreateAuditLogInput{
+		Timestamp:    time.Now(),
+		Action:       entry.Action,
+		ResourceType: entry.ResourceType,
+		ResourceID:   entry.ResourceID,
+		UserID:       entry.UserID,
+		UserAgent:    entry.UserAgent,
+		IPAddress:    entry.IPAddress,
+		SessionID:    entry.SessionID,
+		Status:       statusFromCode(entry.StatusCode),
+		ErrorMessage: entry.ErrorMessage,
+	}
+
+	// Les informations du service métier priment sur celles déduites de la route
+	if entry.Trail != nil {
+		action, resourceType, resourceID, oldValues, newValues := entry.Trail.values()
+		if action != "" {
+			input.Action = action
+		}
+		if resourceType != "" {
+			input.ResourceType = resourceType
+		}
+		if resourceID != "" {
+			input.ResourceID = resourceID
+		}
+		input.OldValues = s.toJSON(oldValues)
+		input.NewValues = s.toJSON(newValues)
+	}
+
+	details := map[string]interface{}{
+		"method":          entry.Method,
+		"path":            entry.Path,
+		"route":           entry.Route,
+		"status_code":     entry.StatusCode,
+		"duration_ms":     entry.Duration.Milliseconds(),
+		"matricule":       entry.Matricule,
+		"role":            entry.Role,
+		"commissariat_id": entry.CommissariatID,
+		"request_id":      entry.RequestID,
+	}
+	if entry.Replayed {
+		details["idempotent_replay"] = true
+	}
+	if entry.ServiceAccountID != "" {
+		// Un compte de service n'est pas un utilisateur : il est identifié dans les détails
+		input.UserID = ""
+		details["service_account_id"] = entry.ServiceAccountID
+	}
+	input.Details = s.toJSON(details)
+
+	// La requête peut être annulée (client déconnecté) : l'audit doit quand même être écrit
+	writeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+
+	if _, err := s.auditRepo.Create(writeCtx, input); err != nil {
+		s.logger.Error("Failed to record audit entry",
+			zap.String("action", input.Action),
+			zap.String("resource_type", input.ResourceType),
+			zap.String("resource_id", input.ResourceID),
+			zap.String("user_id", entry.UserID),
+			zap.Error(err),
+		)
+	}
+}
+
+func (s *service) toJSON(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		s.logger.Warn("Failed to marshal audit values", zap.Error(err))
+		return ""
+	}
+	return string(data)
+}
+
+func statusFromCode(code int) string {
+	switch {
+	case code >= 500:
+		return StatusError
+	case code >= 400:
+		return StatusFailure
+	default:
+		return StatusSuccess
+	}
+}
@@ -0,0 +1,72 @@
+package audittrail
+
+import (
+	"context"
+	"sync"
+)
+
+type trailKey struct{}
+
+// Trail collects what the service layer knows about the mutation in progress.
+// The audit middleware attaches an empty Trail to the request context; services
+// fill it through Snapshot so the recorded entry carries before/after values.
+type Trail struct {
+	mu           sync.Mutex
+	action       string
+	resourceType string
+	resourceID   string
+	oldValues    interface{}
+	newValues    interface{}
+}
+
+// WithTrail returns a context carrying a fresh Trail
+func WithTrail(ctx context.Context) (context.Context, *Trail) {
+	trail := &Trail{}
+	return context.WithValue(ctx, trailKey{}, trail), trail
+}
+
+// FromContext returns the Trail attached to the context, or nil
+func FromContext(ctx context.Context) *Trail {
+	trail, _ := ctx.Value(trailKey{}).(*Trail)
+	return trail
+}
+
+// Snapshot records the state of a resource before and after a mutation.
+// It is a no-op when the context carries no Trail (jobs, CLI tools, tests).
+// Either value may be nil (creation has no before, deletion has no after).
+func Snapshot(ctx context.Context, resourceType, resourceID string, before, after interface{}) {
+	trail := FromContext(ctx)
+	if trail == nil {
+		return
+	}
+
+	trail.mu.Lock()
+	defer trail.mu.Unlock()
+
+	trail.resourceType = resourceType
+	trail.resourceID = resourceID
+	// Conserver le tout premier "before" si le service en prend plusieurs
+	if trail.oldValues == nil {
+		trail.oldValues = before
+	}
+	trail.newValues = after
+}
+
+// SetAction overrides the action derived from the HTTP route (e.g. "ANNULER")
+func SetAction(ctx context.Context, action string) {
+	trail := FromContext(ctx)
+	if trail == nil {
+		return
+	}
+
+	trail.mu.Lock()
+	defer trail.mu.Unlock()
+	trail.action = action
+}
+
+// values returns a consistent copy of the trail fields
+func (t *Trail) values() (action, resourceType, resourceID string, oldValues, newValues interface{}) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.action, t.resourceType, t.resourceID, t.oldValues, t.newValues
+}
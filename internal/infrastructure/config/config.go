@@ -2,17 +2,30 @@ package config
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server"`
-	Database DatabaseConfig `mapstructure:"database"`
-	JWT      JWTConfig      `mapstructure:"jwt"`
-	App      AppConfig      `mapstructure:"app"`
-	OpenAI   *OpenAIConfig  `mapstructure:"openai"`
+	Server       ServerConfig       `mapstructure:"server"`
+	Database     DatabaseConfig     `mapstructure:"database"`
+	JWT          JWTConfig          `mapstructure:"jwt"`
+	App          AppConfig          `mapstructure:"app"`
+	OpenAI       *OpenAIConfig      `mapstructure:"openai"`
+	Scheduler    SchedulerConfig    `mapstructure:"scheduler"`
+	Notification NotificationConfig `mapstructure:"notification"`
+	Stream       StreamConfig       `mapstructure:"stream"`
+	Numbering    NumberingConfig    `mapstructure:"numbering"`
+	Auth         AuthConfig         `mapstructure:"auth"`
+	Idempotency  IdempotencyConfig  `mapstructure:"idempotency"`
+	Concurrency  ConcurrencyConfig  `mapstructure:"concurrency"`
+	Metrics      MetricsConfig      `mapstructure:"metrics"`
+	Tracing      TracingConfig      `mapstructure:"tracing"`
+	Storage      StorageConfig      `mapstructure:"storage"`
+	Uploads      UploadConfig       `mapstructure:"uploads"`
+	PV           PVConfig           `mapstructure:"pv"`
 }
 
 type ServerConfig struct {
@@ -20,9 +33,13 @@ type ServerConfig struct {
 	ReadTimeout     time.Duration `mapstructure:"read_timeout"`
 	WriteTimeout    time.Duration `mapstructure:"write_timeout"`
 	ShutdownTimeout time.Duration `mapstructure:"shutdown_timeout"`
+	TrustedProxies  []string      `mapstructure:"trusted_proxies"` // IPs or CIDRs of the reverse proxies whose X-Forwarded-For is believed
 }
 
 type DatabaseConfig struct {
+	Driver          string        `mapstructure:"driver"` // postgres, or sqlite for an embedded database without server
+	Path            string        `mapstructure:"path"`   // SQLite file; ":memory:" for a temporary database removed on close
+	MigrationsDir   string        `mapstructure:"migrations_dir"`
 	Host            string        `mapstructure:"host"`
 	Port            int           `mapstructure:"port"`
 	User            string        `mapstructure:"user"`
@@ -41,6 +58,9 @@ type JWTConfig struct {
 	MaxSessionDuration time.Duration `mapstructure:"max_session_duration"` // Max time a session can be active (even with refresh)
 	MaxDevicesPerUser  int           `mapstructure:"max_devices_per_user"` // Max concurrent devices per user
 	InactivityTimeout  time.Duration `mapstructure:"inactivity_timeout"`   // Logout after X time of inactivity
+	Algorithm          string        `mapstructure:"algorithm"`            // HS256 (shared secret), RS256 or EdDSA
+	KeyRotation        time.Duration `mapstructure:"key_rotation"`         // Age of the asymmetric signing key before it is replaced; 0 disables rotation
+	KeyGrace           time.Duration `mapstructure:"key_grace"`            // How long a replaced key still verifies tokens and stays in the JWKS
 }
 
 type AppConfig struct {
@@ -50,6 +70,179 @@ type AppConfig struct {
 	LogLevel    string `mapstructure:"log_level"`
 }
 
+type SchedulerConfig struct {
+	Enabled      bool              `mapstructure:"enabled"`
+	PollInterval time.Duration     `mapstructure:"poll_interval"` // How often due jobs are checked
+	InstanceID   string            `mapstructure:"instance_id"`   // Defaults to hostname-pid
+	Schedules    map[string]string `mapstructure:"schedules"`     // Per-job cron overrides, keyed by job name
+}
+
+type StreamConfig struct {
+	PollInterval time.Duration `mapstructure:"poll_interval"` // How often events published by other instances are fetched
+	Heartbeat    time.Duration `mapstructure:"heartbeat"`     // Keep-alive comment sent to idle clients
+	Retention    time.Duration `mapstructure:"retention"`     // How long events can be replayed after a reconnect
+}
+
+type NumberingConfig struct {
+	Formats map[string]string `mapstructure:"formats"` // Per-type number format overrides, keyed by sequence type
+}
+
+type IdempotencyConfig struct {
+	TTL         time.Duration `mapstructure:"ttl"`          // How long a key and its response are replayed
+	LockTimeout time.Duration `mapstructure:"lock_timeout"` // A first request still running after this is considered lost and can be retried
+	Routes      []string      `mapstructure:"routes"`       // "METHOD /api/v1/route/:param" accepting the Idempotency-Key header
+}
+
+type ConcurrencyConfig struct {
+	RequireIfMatch bool     `mapstructure:"require_if_match"` // Refuse PUT/PATCH without If-Match (428) instead of overwriting
+	Resources      []string `mapstructure:"resources"`        // "/api/v1/route/:id" whose GET sends an ETag and writes check If-Match
+}
+
+type MetricsConfig struct {
+	Enabled bool   `mapstructure:"enabled"` // Expose /metrics in the Prometheus text format
+	Token   string `mapstructure:"token"`   // Bearer token required from scrapers; /metrics is public if empty
+}
+
+type TracingConfig struct {
+	Exporter    string            `mapstructure:"exporter"`     // none, stdout (local runs) or otlp
+	Endpoint    string            `mapstructure:"endpoint"`     // OTLP/HTTP collector host:port; OTEL_EXPORTER_OTLP_ENDPOINT if empty
+	Insecure    bool              `mapstructure:"insecure"`     // Plain HTTP to the collector
+	Headers     map[string]string `mapstructure:"headers"`      // Sent with each export (collector authentication)
+	SampleRatio float64           `mapstructure:"sample_ratio"` // Share of the traces started here that are kept, from 0 to 1
+}
+
+type StorageConfig struct {
+	Backend       string          `mapstructure:"backend"`        // local, or s3 for any S3-compatible service (AWS, MinIO, ...)
+	LocalDir      string          `mapstructure:"local_dir"`      // Root of the local backend; UPLOAD_DIR is still honoured
+	PresignExpiry time.Duration   `mapstructure:"presign_expiry"` // Lifetime of the download redirections to the backend
+	S3            S3Config        `mapstructure:"s3"`
+	SignedURLs    SignedURLConfig `mapstructure:"signed_urls"`
+}
+
+type SignedURLConfig struct {
+	Key       string        `mapstructure:"key"`        // HMAC key of the download links; derived from jwt.secret if empty
+	TTL       time.Duration `mapstructure:"ttl"`        // Default lifetime of the links to private documents
+	MaxTTL    time.Duration `mapstructure:"max_ttl"`    // Longest lifetime a caller may request for a private document
+	PublicTTL time.Duration `mapstructure:"public_ttl"` // Lifetime of the links to public documents, included in their responses
+}
+
+type UploadConfig struct {
+	MaxSizeMB     int                         `mapstructure:"max_size_mb"`    // Size limit of the types without their own
+	ThumbnailSize int                         `mapstructure:"thumbnail_size"` // Longest side of the image thumbnails, in pixels
+	MaxMegapixels int                         `mapstructure:"max_megapixels"` // Images larger once decoded are refused
+	Types         map[string]UploadTypeConfig `mapstructure:"types"`          // By type_document (PHOTO, PV, ...)
+}
+
+type PVConfig struct {
+	MajorationDelay time.Duration `mapstructure:"majoration_delay"` // Time after the payment deadline before an overdue PV is increased
+	MajorationRate  float64       `mapstructure:"majoration_rate"`  // Share of the total amount added by the increase
+}
+
+type UploadTypeConfig struct {
+	MaxSizeMB int      `mapstructure:"max_size_mb"`
+	Allowed   []string `mapstructure:"allowed"` // Detected MIME types accepted; built-in list if empty
+}
+
+type S3Config struct {
+	Endpoint  string `mapstructure:"endpoint"` // e.g. https://s3.eu-west-3.amazonaws.com, http://localhost:9000 for MinIO
+	Region    string `mapstructure:"region"`
+	Bucket    string `mapstructure:"bucket"`
+	AccessKey string `mapstructure:"access_key"`
+	SecretKey string `mapstructure:"secret_key"`
+	PathStyle bool   `mapstructure:"path_style"` // Bucket in the path rather than in the host name (MinIO)
+}
+
+type AuthConfig struct {
+	MockUsers bool           `mapstructure:"mock_users"` // Demo accounts for unknown matricules; ignored outside development
+	Lockout   LockoutConfig  `mapstructure:"lockout"`
+	MFA       MFAConfig      `mapstructure:"mfa"`
+	Password  PasswordConfig `mapstructure:"password"`
+	APIKeys   APIKeyConfig   `mapstructure:"api_keys"`
+	OIDC      OIDCConfig     `mapstructure:"oidc"`
+}
+
+type APIKeyConfig struct {
+	DefaultTTL     time.Duration `mapstructure:"default_ttl"`     // Lifetime of a new key; 0 creates keys without expiry
+	RotationGrace  time.Duration `mapstructure:"rotation_grace"`  // How long the previous keys stay valid after a rotation
+	UsageRetention time.Duration `mapstructure:"usage_retention"` // How long the key usage log is kept
+}
+
+type OIDCConfig struct {
+	Enabled      bool              `mapstructure:"enabled"`
+	ProviderName string            `mapstructure:"provider_name"` // Label of the login button
+	Issuer       string            `mapstructure:"issuer"`        // Discovery at {issuer}/.well-known/openid-configuration
+	ClientID     string            `mapstructure:"client_id"`
+	ClientSecret string            `mapstructure:"client_secret"` // Empty for a public client, PKCE only
+	RedirectURL  string            `mapstructure:"redirect_url"`  // Frontend page receiving the code and the state
+	Scopes       []string          `mapstructure:"scopes"`
+	StateTTL     time.Duration     `mapstructure:"state_ttl"`   // Time allowed to come back from the provider
+	AutoCreate   bool              `mapstructure:"auto_create"` // Create the local user of an unknown matricule
+	Claims       OIDCClaimsConfig  `mapstructure:"claims"`
+	RoleMapping  map[string]string `mapstructure:"role_mapping"` // Provider role -> local role
+	DefaultRole  string            `mapstructure:"default_role"` // Role of a created user without a mapped role
+	MFAMethods   []string          `mapstructure:"mfa_methods"`  // amr values proving a second factor at the provider
+	MFAACR       []string          `mapstructure:"mfa_acr"`      // acr values proving a second factor at the provider
+}
+
+// OIDCClaimsConfig names the ID token claims holding the local user fields
+type OIDCClaimsConfig struct {
+	Matricule    string `mapstructure:"matricule"`
+	Role         string `mapstructure:"role"`
+	Commissariat string `mapstructure:"commissariat"` // Code of the commissariat
+}
+
+type PasswordConfig struct {
+	MinLength     int           `mapstructure:"min_length"`
+	RequireUpper  bool          `mapstructure:"require_upper"`
+	RequireLower  bool          `mapstructure:"require_lower"`
+	RequireDigit  bool          `mapstructure:"require_digit"`
+	RequireSymbol bool          `mapstructure:"require_symbol"`
+	HistorySize   int           `mapstructure:"history_size"` // Previous passwords that cannot be reused
+	ExpiryDays    int           `mapstructure:"expiry_days"`  // Password to change after this many days; 0 disables expiry
+	ResetTokenTTL time.Duration `mapstructure:"reset_token_ttl"`
+	ResetURL      string        `mapstructure:"reset_url"` // Frontend page receiving the reset token; the token alone is sent if empty
+}
+
+type MFAConfig struct {
+	Issuer        string   `mapstructure:"issuer"`         // Account name prefix shown by authenticator applications
+	RequiredRoles []string `mapstructure:"required_roles"` // Roles that cannot log in without a second factor
+}
+
+type LockoutConfig struct {
+	FreeAttempts    int           `mapstructure:"free_attempts"` // Failures accepted before progressive delays start
+	BaseDelay       time.Duration `mapstructure:"base_delay"`    // First delay, doubled after each further failure
+	MaxDelay        time.Duration `mapstructure:"max_delay"`
+	MaxAttempts     int           `mapstructure:"max_attempts"`    // Failures before the account is locked
+	Duration        time.Duration `mapstructure:"duration"`        // Account lock duration
+	IPMaxAttempts   int           `mapstructure:"ip_max_attempts"` // Failures from one IP address before it is blocked
+	IPBlockDuration time.Duration `mapstructure:"ip_block_duration"`
+	Window          time.Duration `mapstructure:"window"` // Failures older than this are forgotten
+}
+
+type NotificationConfig struct {
+	SMTP        SMTPConfig        `mapstructure:"smtp"`
+	SMS         HTTPChannelConfig `mapstructure:"sms"`
+	Push        HTTPChannelConfig `mapstructure:"push"`
+	LogFile     string            `mapstructure:"log_file"`     // Dev stand-in: messages of unconfigured channels are appended here
+	MaxAttempts int               `mapstructure:"max_attempts"` // Delivery attempts before a notification is marked FAILED
+	Workers     int               `mapstructure:"workers"`
+}
+
+type SMTPConfig struct {
+	Host     string `mapstructure:"host"`
+	Port     int    `mapstructure:"port"`
+	Username string `mapstructure:"username"`
+	Password string `mapstructure:"password"`
+	From     string `mapstructure:"from"`
+}
+
+type HTTPChannelConfig struct {
+	URL     string        `mapstructure:"url"`
+	Token   string        `mapstructure:"token"`
+	Sender  string        `mapstructure:"sender"`
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
 type OpenAIConfig struct {
 	APIKey string `mapstructure:"api_key"`
 	Model  string `mapstructure:"model"`
@@ -63,15 +256,95 @@ func LoadConfig() (*Config, error) {
 
 	// Set default values
 	viper.SetDefault("server.port", "8080")
+	viper.SetDefault("database.driver", "postgres")
+	viper.SetDefault("database.path", "data/police_traffic.db")
+	viper.SetDefault("database.migrations_dir", "migrations")
+	viper.SetDefault("jwt.algorithm", "HS256")
+	viper.SetDefault("jwt.key_rotation", "720h")
+	viper.SetDefault("jwt.key_grace", "168h")
 	viper.SetDefault("server.read_timeout", "10s")
 	viper.SetDefault("server.write_timeout", "10s")
 	viper.SetDefault("server.shutdown_timeout", "10s")
 	viper.SetDefault("app.environment", "development")
 	viper.SetDefault("app.debug", true)
 	viper.SetDefault("app.log_level", "info")
+	viper.SetDefault("scheduler.enabled", true)
+	viper.SetDefault("scheduler.poll_interval", "30s")
+	viper.SetDefault("notification.max_attempts", 5)
+	viper.SetDefault("notification.workers", 2)
+	viper.SetDefault("notification.smtp.port", 587)
+	viper.SetDefault("stream.poll_interval", "1s")
+	viper.SetDefault("stream.heartbeat", "25s")
+	viper.SetDefault("stream.retention", "24h")
+	viper.SetDefault("idempotency.ttl", "24h")
+	viper.SetDefault("idempotency.lock_timeout", "1m")
+	viper.SetDefault("idempotency.routes", []string{
+		"POST /api/v1/paiements",
+		"POST /api/v1/pv/:id/payer",
+		"POST /api/v1/infractions/:id/payment",
+		"POST /api/v1/controles/:id/pv",
+	})
+	viper.SetDefault("concurrency.require_if_match", false)
+	viper.SetDefault("concurrency.resources", []string{
+		"/api/v1/plaintes/:id",
+		"/api/v1/alertes/:id",
+		"/api/v1/convocations/:id",
+	})
+	viper.SetDefault("metrics.enabled", true)
+	viper.SetDefault("tracing.exporter", "none")
+	viper.SetDefault("tracing.sample_ratio", 1.0)
+	viper.SetDefault("storage.backend", "local")
+	viper.SetDefault("storage.local_dir", "./uploads")
+	viper.SetDefault("storage.presign_expiry", "5m")
+	viper.SetDefault("storage.s3.region", "us-east-1")
+	viper.SetDefault("storage.s3.path_style", true)
+	viper.SetDefault("storage.signed_urls.ttl", "5m")
+	viper.SetDefault("storage.signed_urls.max_ttl", "1h")
+	viper.SetDefault("storage.signed_urls.public_ttl", "24h")
+	viper.SetDefault("uploads.max_size_mb", 10)
+	viper.SetDefault("uploads.thumbnail_size", 320)
+	viper.SetDefault("uploads.max_megapixels", 50)
+	viper.SetDefault("pv.majoration_delay", "720h")
+	viper.SetDefault("pv.majoration_rate", 0.5)
+	viper.SetDefault("auth.mock_users", true)
+	viper.SetDefault("auth.lockout.free_attempts", 3)
+	viper.SetDefault("auth.lockout.base_delay", "1s")
+	viper.SetDefault("auth.lockout.max_delay", "1m")
+	viper.SetDefault("auth.lockout.max_attempts", 10)
+	viper.SetDefault("auth.lockout.duration", "15m")
+	viper.SetDefault("auth.lockout.ip_max_attempts", 50)
+	viper.SetDefault("auth.lockout.ip_block_duration", "15m")
+	viper.SetDefault("auth.lockout.window", "1h")
+	viper.SetDefault("auth.mfa.issuer", "Police Trafic")
+	viper.SetDefault("auth.mfa.required_roles", []string{"admin", "supervisor"})
+	viper.SetDefault("auth.password.min_length", 10)
+	viper.SetDefault("auth.password.require_upper", true)
+	viper.SetDefault("auth.password.require_lower", true)
+	viper.SetDefault("auth.password.require_digit", true)
+	viper.SetDefault("auth.password.require_symbol", false)
+	viper.SetDefault("auth.password.history_size", 5)
+	viper.SetDefault("auth.password.expiry_days", 90)
+	viper.SetDefault("auth.password.reset_token_ttl", "30m")
+	viper.SetDefault("auth.api_keys.default_ttl", "8760h")
+	viper.SetDefault("auth.api_keys.rotation_grace", "24h")
+	viper.SetDefault("auth.api_keys.usage_retention", "2160h")
+	viper.SetDefault("auth.oidc.enabled", false)
+	viper.SetDefault("auth.oidc.provider_name", "SSO")
+	viper.SetDefault("auth.oidc.scopes", []string{"openid", "profile", "email"})
+	viper.SetDefault("auth.oidc.state_ttl", "10m")
+	viper.SetDefault("auth.oidc.auto_create", false)
+	viper.SetDefault("auth.oidc.claims.matricule", "matricule")
+	viper.SetDefault("auth.oidc.claims.role", "role")
+	viper.SetDefault("auth.oidc.claims.commissariat", "commissariat")
+	viper.SetDefault("auth.oidc.default_role", "agent")
+	viper.SetDefault("auth.oidc.mfa_methods", []string{"mfa"})
+	viper.SetDefault("auth.oidc.mfa_acr", []string{})
 
-	// Enable environment variables
+	// Enable environment variables (DATABASE_DRIVER for database.driver)
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv()
+	// UPLOAD_DIR: former variable of the document directory
+	viper.BindEnv("storage.local_dir", "STORAGE_LOCAL_DIR", "UPLOAD_DIR")
 
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
@@ -85,4 +358,3 @@ func LoadConfig() (*Config, error) {
 	return &config, nil
 }
 
-
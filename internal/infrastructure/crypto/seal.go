@@ -0,0 +1,64 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+)
+
+// ErrUnsealFailed is returned by Open for data sealed with another secret or
+// purpose, or altered
+var ErrUnsealFailed = errors.New("failed to decrypt sealed data")
+
+// Seal encrypts data stored at rest with AES-GCM, with a key derived from the
+// configured secret and the purpose of the data: a value sealed for one
+// purpose cannot be opened for another
+func Seal(plaintext []byte, secret, purpose string) (string, error) {
+	gcm, err := newGCM(secret, purpose)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, plaintext, nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// Open decrypts data encrypted by Seal with the same secret and purpose
+func Open(data, secret, purpose string) ([]byte, error) {
+	sealed, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode sealed data: %w", err)
+	}
+
+	gcm, err := newGCM(secret, purpose)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, errors.New("sealed data too short")
+	}
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, ErrUnsealFailed
+	}
+
+	return plaintext, nil
+}
+
+func newGCM(secret, purpose string) (cipher.AEAD, error) {
+	key := sha256.Sum256([]byte(purpose + ":" + secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
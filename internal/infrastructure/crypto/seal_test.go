@@ -0,0 +1,29 @@
+package crypto
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeal(t *testing.T) {
+	sealed, err := Seal([]byte("JBSWY3DPEHPK3PXP"), "secret", "totp-secrets")
+	require.NoError(t, err)
+	assert.NotContains(t, sealed, "JBSWY3DPEHPK3PXP")
+
+	opened, err := Open(sealed, "secret", "totp-secrets")
+	require.NoError(t, err)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", string(opened))
+
+	_, err = Open(sealed, "another-secret", "totp-secrets")
+	assert.ErrorIs(t, err, ErrUnsealFailed)
+
+	// La clé dépend de l'usage : un secret TOTP ne s'ouvre pas comme une clé de signature
+	_, err = Open(sealed, "secret", "signing-keys")
+	assert.ErrorIs(t, err, ErrUnsealFailed)
+
+	// Un secret enregistré en clair n'est pas une donnée chiffrée
+	_, err = Open("JBSWY3DPEHPK3PXP", "secret", "totp-secrets")
+	assert.Error(t, err)
+}
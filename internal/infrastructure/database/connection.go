@@ -2,82 +2,83 @@ package database
 
 import (
 	"context"
+	stdsql "database/sql"
 	"fmt"
 	"time"
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
 
 	"entgo.io/ent/dialect"
 	"entgo.io/ent/dialect/sql"
-	_ "github.com/lib/pq"
 	"go.uber.org/zap"
 )
 
 // DB represents a database connection using Ent
 type DB struct {
 	Client *ent.Client
+	pool   *stdsql.DB
 	logger *zap.Logger
 	config *config.DatabaseConfig
 }
 
 // NewDB creates a new Ent database connection
 func NewDB(cfg *config.Config, logger *zap.Logger) (*DB, error) {
-	logger.Info("Initializing PostgreSQL Database",
-		zap.String("host", cfg.Database.Host),
-		zap.Int("port", cfg.Database.Port),
-		zap.String("dbname", cfg.Database.DBName),
-	)
-
-	// Build connection string
-	dsn := fmt.Sprintf("host=%s port=%d user=%s dbname=%s sslmode=disable",
-		cfg.Database.Host,
-		cfg.Database.Port,
-		cfg.Database.User,
-		cfg.Database.DBName,
-	)
+	logger.Info("Initializing Database", zap.String("database", Location(cfg.Database)))
 
-	// Add password if provided
-	if cfg.Database.Password != "" {
-		dsn += fmt.Sprintf(" password=%s", cfg.Database.Password)
-	}
-
-	logger.Debug("Connecting to database", zap.String("dsn", dsn))
-
-	// Open database connection
-	drv, err := sql.Open(dialect.Postgres, dsn)
+	// Open database connection, with its pool settings
+	drv, err := Open(cfg.Database)
 	if err != nil {
-		return nil, fmt.Errorf("failed opening connection to postgres: %w", err)
+		return nil, err
 	}
-
-	// Configure connection pool
 	db := drv.DB()
-	db.SetMaxOpenConns(cfg.Database.MaxOpenConns)
-	db.SetMaxIdleConns(cfg.Database.MaxIdleConns)
-	db.SetConnMaxLifetime(cfg.Database.ConnMaxLifetime)
 
-	// Create Ent client
-	client := ent.NewClient(ent.Driver(drv))
+	// Create Ent client, each query traced in a span
+	client := ent.NewClient(ent.Driver(tracing.Driver(drv)))
 
 	// Test connection with timeout
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	
-	if err := client.Schema.Create(ctx); err != nil {
-		logger.Warn("Failed to create schema", zap.Error(err))
+	if err := checkSchema(ctx, cfg.Database, drv, client); err != nil {
+		logger.Warn("Database schema is not up to date", zap.Error(err))
 		client.Close()
 		return nil, fmt.Errorf("database connection failed: %w", err)
 	}
 
-	logger.Info("PostgreSQL Database connected successfully")
+	logger.Info("Database connected successfully", zap.String("driver", drv.Dialect()))
 
 	return &DB{
 		Client: client,
+		pool:   db,
 		logger: logger,
 		config: &cfg.Database,
 	}, nil
 }
 
+// checkSchema refuses a PostgreSQL database with pending migrations, or
+// without migrations to apply: the server never changes the schema,
+// cmd/migrate does. The SQLite development
+// database is created from the ent schema instead.
+func checkSchema(ctx context.Context, cfg config.DatabaseConfig, drv *sql.Driver, client *ent.Client) error {
+	if drv.Dialect() != dialect.Postgres {
+		return client.Schema.Create(ctx)
+	}
+	migrator, err := NewMigrator(drv.DB(), cfg.MigrationsDir)
+	if err != nil {
+		return err
+	}
+	pending, err := migrator.Pending(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("%d pending migrations, from %s_%s: run go run ./cmd/migrate up", len(pending), pending[0].Version, pending[0].Name)
+	}
+	return nil
+}
+
 // MockDB represents a mock database connection for development
 type MockDB struct {
 	logger *zap.Logger
@@ -103,8 +104,14 @@ func NewMockDB(cfg *config.Config, logger *zap.Logger) (*MockDB, error) {
 
 // Ping tests the database connection
 func (db *DB) Ping() error {
-	ctx := context.Background()
-	return db.Client.Schema.Create(ctx)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return db.pool.PingContext(ctx)
+}
+
+// Stats returns the statistics of the connection pool
+func (db *DB) Stats() stdsql.DBStats {
+	return db.pool.Stats()
 }
 
 // Close closes the database connection
@@ -116,7 +123,7 @@ func (db *DB) Close() error {
 // GetConnectionInfo returns connection information
 func (db *DB) GetConnectionInfo() map[string]interface{} {
 	return map[string]interface{}{
-		"driver":        "postgres",
+		"driver":        db.config.Driver,
 		"host":          db.config.Host,
 		"port":          db.config.Port,
 		"database":      db.config.DBName,
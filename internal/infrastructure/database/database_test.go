@@ -1,12 +1,19 @@
 package database
 
 import (
+	"context"
+	"os"
 	"strconv"
 	"testing"
+	"time"
 
+	"police-trafic-api-frontend-aligned/ent"
 	cfg "police-trafic-api-frontend-aligned/internal/infrastructure/config"
 
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
@@ -262,4 +269,117 @@ func buildConnectionString(config cfg.DatabaseConfig) string {
 		"user=" + config.User + " " +
 		"password=" + config.Password + " " +
 		"sslmode=" + config.SSLMode
-}
\ No newline at end of file
+}
+func TestDataSource(t *testing.T) {
+	t.Run("postgres", func(t *testing.T) {
+		name, dsn, err := DataSource(cfg.DatabaseConfig{
+			Driver: DriverPostgres, Host: "localhost", Port: 5432, DBName: "test", User: "user", Password: "pass",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "postgres", name)
+		assert.Equal(t, "host=localhost port=5432 user=user dbname=test sslmode=disable password=pass", dsn)
+	})
+
+	t.Run("sqlite file", func(t *testing.T) {
+		name, dsn, err := DataSource(cfg.DatabaseConfig{Driver: DriverSQLite, Path: "data/dev.db"})
+		assert.NoError(t, err)
+		assert.Equal(t, "sqlite3", name)
+		assert.Equal(t, "file:data/dev.db?_fk=1&_journal_mode=WAL&_busy_timeout=5000", dsn)
+	})
+
+	t.Run("sqlite in memory", func(t *testing.T) {
+		// Base temporaire créée par Open
+		_, _, err := DataSource(cfg.DatabaseConfig{Driver: DriverSQLite, Path: ":memory:"})
+		assert.Error(t, err)
+	})
+
+	t.Run("unknown driver", func(t *testing.T) {
+		_, _, err := DataSource(cfg.DatabaseConfig{Driver: "mysql"})
+		assert.Error(t, err)
+	})
+}
+
+func TestNewDB_SQLite(t *testing.T) {
+	db, err := NewDB(&cfg.Config{
+		Database: cfg.DatabaseConfig{Driver: DriverSQLite, Path: ":memory:", MaxOpenConns: 5, MaxIdleConns: 1},
+	}, zap.NewNop())
+	require.NoError(t, err, "the whole schema should be created on SQLite")
+	defer db.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	assert.Equal(t, DriverSQLite, db.GetConnectionInfo()["driver"])
+	_, err = db.Client.Commissariat.Query().Count(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, db.Stats().MaxOpenConnections)
+
+	// Une autre connexion attend la fin de la transaction au lieu d'échouer
+	tx, err := db.pool.BeginTx(ctx, nil)
+	require.NoError(t, err)
+	_, err = tx.ExecContext(ctx, "CREATE TABLE locked (id INTEGER)")
+	require.NoError(t, err)
+	done := make(chan error, 1)
+	go func() {
+		_, err := db.pool.ExecContext(ctx, "CREATE TABLE beside (id INTEGER)")
+		done <- err
+	}()
+	time.Sleep(100 * time.Millisecond)
+	require.NoError(t, tx.Commit())
+	assert.NoError(t, <-done)
+}
+
+func TestOpen_SQLiteMemoryRemovedOnClose(t *testing.T) {
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+
+	drv, err := Open(cfg.DatabaseConfig{Driver: DriverSQLite, Path: ":memory:", MaxOpenConns: 2})
+	require.NoError(t, err)
+	_, err = drv.DB().Exec("CREATE TABLE t (id INTEGER)")
+	require.NoError(t, err)
+	entries, _ := os.ReadDir(tmp)
+	assert.Len(t, entries, 1, "the database is a temporary file")
+
+	require.NoError(t, drv.Close())
+	entries, _ = os.ReadDir(tmp)
+	assert.Empty(t, entries)
+}
+
+// TestMigrator_UpOnEmptyPostgres applies the migrations directory to an empty
+// PostgreSQL database, in order: a migration using a table created by a later
+// one fails here. TEST_DATABASE_URL is a DSN to a disposable database.
+func TestMigrator_UpOnEmptyPostgres(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	drv, err := sql.Open(dialect.Postgres, dsn)
+	require.NoError(t, err)
+	defer drv.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	migrator, err := NewMigrator(drv.DB(), "../../../migrations")
+	require.NoError(t, err)
+
+	status, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, mig := range status {
+		require.Nil(t, mig.AppliedAt, "the database must be empty, %s is applied", mig.Version)
+	}
+
+	applied, err := migrator.Up(ctx, 0)
+	require.NoError(t, err)
+	assert.Len(t, applied, len(status))
+
+	pending, err := migrator.Pending(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	// Le schéma migré est celui du client ent, recherche comprise
+	client := ent.NewClient(ent.Driver(drv))
+	_, err = client.Document.Query().Count(ctx)
+	assert.NoError(t, err)
+	_, err = client.DocumentDownload.Query().Count(ctx)
+	assert.NoError(t, err)
+	assert.NoError(t, checkSchema(ctx, cfg.DatabaseConfig{MigrationsDir: "../../../migrations"}, drv, client))
+}
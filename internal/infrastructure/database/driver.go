@@ -0,0 +1,131 @@
+package database
+
+import (
+	"context"
+	stdsql "database/sql"
+	"database/sql/driver"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	_ "github.com/lib/pq"
+	"github.com/mattn/go-sqlite3"
+)
+
+// Values of database.driver
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+// memoryPath is the database.path of an in-memory SQLite database
+const memoryPath = ":memory:"
+
+// DataSource returns the ent dialect and the data source name of the
+// configured database. The in-memory SQLite database has none: Open creates
+// it in a temporary file.
+func DataSource(cfg config.DatabaseConfig) (string, string, error) {
+	switch cfg.Driver {
+	case "", DriverPostgres:
+		dsn := fmt.Sprintf("host=%s port=%d user=%s dbname=%s sslmode=disable",
+			cfg.Host,
+			cfg.Port,
+			cfg.User,
+			cfg.DBName,
+		)
+		if cfg.Password != "" {
+			dsn += fmt.Sprintf(" password=%s", cfg.Password)
+		}
+		return dialect.Postgres, dsn, nil
+	case DriverSQLite:
+		if isMemory(cfg) {
+			return "", "", fmt.Errorf("the in-memory SQLite database is opened on a temporary file")
+		}
+		return dialect.SQLite, sqliteDSN(cfg.Path), nil
+	default:
+		return "", "", fmt.Errorf("unsupported database driver %q (postgres or sqlite)", cfg.Driver)
+	}
+}
+
+// Open opens the configured database, PostgreSQL or SQLite, with its
+// connection pool settings. The in-memory SQLite database is a temporary
+// file, removed when the pool is closed: like a file database, its
+// connections wait for each other's locks.
+func Open(cfg config.DatabaseConfig) (*sql.Driver, error) {
+	var drv *sql.Driver
+	if isMemory(cfg) {
+		dir, err := os.MkdirTemp("", "police_traffic-")
+		if err != nil {
+			return nil, fmt.Errorf("failed creating the temporary SQLite database: %w", err)
+		}
+		db := stdsql.OpenDB(&tempConnector{dsn: sqliteDSN(filepath.Join(dir, "police_traffic.db")), dir: dir})
+		drv = sql.OpenDB(dialect.SQLite, db)
+	} else {
+		name, dsn, err := DataSource(cfg)
+		if err != nil {
+			return nil, err
+		}
+		if name == dialect.SQLite {
+			if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o755); err != nil {
+				return nil, fmt.Errorf("failed creating the directory of %s: %w", cfg.Path, err)
+			}
+		}
+
+		drv, err = sql.Open(name, dsn)
+		if err != nil {
+			return nil, fmt.Errorf("failed opening connection to %s: %w", name, err)
+		}
+	}
+
+	db := drv.DB()
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	return drv, nil
+}
+
+// isMemory reports whether the configured database is the in-memory SQLite one
+func isMemory(cfg config.DatabaseConfig) bool {
+	return cfg.Driver == DriverSQLite && (cfg.Path == "" || cfg.Path == memoryPath)
+}
+
+// sqliteDSN returns the data source name of a SQLite file
+func sqliteDSN(path string) string {
+	// WAL : lectures concurrentes pendant une écriture ; attente au lieu d'une erreur si la base est verrouillée
+	return "file:" + path + "?_fk=1&_journal_mode=WAL&_busy_timeout=5000"
+}
+
+// tempConnector opens the connections of the temporary SQLite database and
+// removes its files when the pool is closed
+type tempConnector struct {
+	dsn string
+	dir string
+}
+
+func (c *tempConnector) Connect(context.Context) (driver.Conn, error) {
+	return c.Driver().Open(c.dsn)
+}
+
+func (c *tempConnector) Driver() driver.Driver {
+	return &sqlite3.SQLiteDriver{}
+}
+
+// Close is called by sql.DB.Close, after its connections are closed
+func (c *tempConnector) Close() error {
+	return os.RemoveAll(c.dir)
+}
+
+// Location describes the configured database for the logs
+func Location(cfg config.DatabaseConfig) string {
+	if cfg.Driver == DriverSQLite {
+		if cfg.Path == "" {
+			return "sqlite " + memoryPath
+		}
+		return "sqlite " + cfg.Path
+	}
+	return fmt.Sprintf("postgres %s:%d/%s", cfg.Host, cfg.Port, cfg.DBName)
+}
@@ -0,0 +1,303 @@
+package database
+
+import (
+	"context"
+	stdsql "database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strings"
+	"time"
+
+	entmigrate "police-trafic-api-frontend-aligned/ent/migrate"
+
+	"ariga.io/atlas/sql/migrate"
+	"ariga.io/atlas/sql/sqltool"
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/schema"
+)
+
+// historyTable records the applied migrations
+const historyTable = "schema_migrations"
+
+// ErrNoMigrations is returned for a migrations directory without migrations:
+// applying it would leave the database without a schema
+var ErrNoMigrations = errors.New("no migrations found")
+
+// Migration is a versioned migration of the migrations directory: a
+// {version}_{name}.up.sql file generated from the ent schema diff
+// (cmd/migrate diff), and its .down.sql file
+type Migration struct {
+	Version   string
+	Name      string
+	AppliedAt *time.Time // nil while pending
+	file      migrate.File
+}
+
+// Migrator applies the versioned migrations of the PostgreSQL database and
+// records them in the history table
+type Migrator struct {
+	db   *stdsql.DB
+	dir  *sqltool.GolangMigrateDir
+	path string
+}
+
+// NewMigrator opens the migrations directory
+func NewMigrator(db *stdsql.DB, path string) (*Migrator, error) {
+	dir, err := sqltool.NewGolangMigrateDir(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed opening migrations directory: %w", err)
+	}
+	return &Migrator{db: db, dir: dir, path: path}, nil
+}
+
+// hasHistory reports whether the history table exists: it is only created
+// when migrations are applied, never by the startup check
+func (m *Migrator) hasHistory(ctx context.Context) (bool, error) {
+	var exists bool
+	err := m.db.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM information_schema.tables
+WHERE table_schema = current_schema() AND table_name = $1)`, historyTable).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed reading %s: %w", historyTable, err)
+	}
+	return exists, nil
+}
+
+// ensureHistory creates the history table
+func (m *Migrator) ensureHistory(ctx context.Context) error {
+	_, err := m.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+historyTable+` (
+	version VARCHAR(64) PRIMARY KEY,
+	name VARCHAR(255) NOT NULL,
+	applied_at TIMESTAMP NOT NULL
+)`)
+	if err != nil {
+		return fmt.Errorf("failed creating %s: %w", historyTable, err)
+	}
+	return nil
+}
+
+// Status returns the migrations of the directory in version order, with the
+// date they were applied. The files must not have been modified since they
+// were generated (atlas.sum).
+func (m *Migrator) Status(ctx context.Context) ([]*Migration, error) {
+	if err := migrate.Validate(m.dir); err != nil {
+		return nil, fmt.Errorf("migration files do not match atlas.sum (after a reviewed edit, run cmd/migrate hash): %w", err)
+	}
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := m.dir.Files()
+	if err != nil {
+		return nil, fmt.Errorf("failed reading migrations: %w", err)
+	}
+	migrations := make([]*Migration, len(files))
+	for i, f := range files {
+		migrations[i] = &Migration{Version: f.Version(), Name: f.Desc(), file: f}
+		if at, ok := applied[f.Version()]; ok {
+			migrations[i].AppliedAt = &at
+			delete(applied, f.Version())
+		}
+	}
+	if len(applied) > 0 {
+		// Migration appliquée puis supprimée du dépôt : la base est en avance sur le code
+		versions := make([]string, 0, len(applied))
+		for version := range applied {
+			versions = append(versions, version)
+		}
+		return nil, fmt.Errorf("applied migrations missing from the directory: %s", strings.Join(versions, ", "))
+	}
+	return migrations, nil
+}
+
+// applied returns the applied versions of the history table
+func (m *Migrator) applied(ctx context.Context) (map[string]time.Time, error) {
+	applied := make(map[string]time.Time)
+	exists, err := m.hasHistory(ctx)
+	if err != nil || !exists {
+		return applied, err
+	}
+	rows, err := m.db.QueryContext(ctx, `SELECT version, applied_at FROM `+historyTable)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading %s: %w", historyTable, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var version string
+		var at time.Time
+		if err := rows.Scan(&version, &at); err != nil {
+			return nil, fmt.Errorf("failed reading %s: %w", historyTable, err)
+		}
+		applied[version] = at
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed reading %s: %w", historyTable, err)
+	}
+	return applied, nil
+}
+
+// Pending returns the migrations not applied yet. An empty directory is an
+// error, not a database up to date.
+func (m *Migrator) Pending(ctx context.Context) ([]*Migration, error) {
+	migrations, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(migrations) == 0 {
+		return nil, fmt.Errorf("%w in %s", ErrNoMigrations, m.path)
+	}
+	var pending []*Migration
+	for _, mig := range migrations {
+		if mig.AppliedAt == nil {
+			pending = append(pending, mig)
+		} else if len(pending) > 0 {
+			return nil, fmt.Errorf("migration %s is pending but %s, generated after it, is applied", pending[0].Version, mig.Version)
+		}
+	}
+	return pending, nil
+}
+
+// Up applies the next n pending migrations, all of them if n <= 0. Each
+// migration runs in its own transaction with its history row.
+func (m *Migrator) Up(ctx context.Context, n int) ([]*Migration, error) {
+	if err := m.ensureHistory(ctx); err != nil {
+		return nil, err
+	}
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if n > 0 && n < len(pending) {
+		pending = pending[:n]
+	}
+	for i, mig := range pending {
+		stmts, err := mig.file.Stmts()
+		if err != nil {
+			return pending[:i], fmt.Errorf("migration %s: %w", mig.Version, err)
+		}
+		err = m.inTx(ctx, stmts, `INSERT INTO `+historyTable+` (version, name, applied_at) VALUES ($1, $2, $3)`,
+			mig.Version, mig.Name, time.Now().UTC())
+		if err != nil {
+			return pending[:i], fmt.Errorf("migration %s: %w", mig.Version, err)
+		}
+	}
+	return pending, nil
+}
+
+// Down reverts the last n applied migrations with their .down.sql file
+func (m *Migrator) Down(ctx context.Context, n int) ([]*Migration, error) {
+	migrations, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var reverted []*Migration
+	for i := len(migrations) - 1; i >= 0 && len(reverted) < n; i-- {
+		mig := migrations[i]
+		if mig.AppliedAt == nil {
+			continue
+		}
+		name := strings.TrimSuffix(mig.file.Name(), ".up.sql") + ".down.sql"
+		b, err := fs.ReadFile(m.dir, name)
+		if err != nil {
+			return reverted, fmt.Errorf("migration %s cannot be reverted: %w", mig.Version, err)
+		}
+		stmts, err := migrate.NewLocalFile(name, b).Stmts()
+		if err != nil {
+			return reverted, fmt.Errorf("migration %s: %w", mig.Version, err)
+		}
+		if err := m.inTx(ctx, stmts, `DELETE FROM `+historyTable+` WHERE version = $1`, mig.Version); err != nil {
+			return reverted, fmt.Errorf("migration %s: %w", mig.Version, err)
+		}
+		reverted = append(reverted, mig)
+	}
+	return reverted, nil
+}
+
+// Baseline records the migrations up to version as applied without running
+// them, for a database created before the versioned migrations
+func (m *Migrator) Baseline(ctx context.Context, version string) ([]*Migration, error) {
+	if err := m.ensureHistory(ctx); err != nil {
+		return nil, err
+	}
+	migrations, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.ContainsFunc(migrations, func(mig *Migration) bool { return mig.Version == version }) {
+		return nil, fmt.Errorf("unknown migration version %s", version)
+	}
+	var marked []*Migration
+	for _, mig := range migrations {
+		if mig.Version > version {
+			break
+		}
+		if mig.AppliedAt != nil {
+			continue
+		}
+		err := m.inTx(ctx, nil, `INSERT INTO `+historyTable+` (version, name, applied_at) VALUES ($1, $2, $3)`,
+			mig.Version, mig.Name, time.Now().UTC())
+		if err != nil {
+			return marked, fmt.Errorf("migration %s: %w", mig.Version, err)
+		}
+		marked = append(marked, mig)
+	}
+	return marked, nil
+}
+
+// Diff writes the migration from the schema of the migrations directory to
+// the ent schema. The directory is replayed on dev, an empty PostgreSQL
+// database, to compute the difference; the generated files are then
+// reviewed and committed with the schema change.
+func (m *Migrator) Diff(ctx context.Context, dev *sql.Driver, name string) error {
+	if dev.Dialect() != dialect.Postgres {
+		return fmt.Errorf("migrations are generated against PostgreSQL, not %s", dev.Dialect())
+	}
+	atlas, err := schema.NewMigrate(dev,
+		schema.WithDir(m.dir),
+		schema.WithMigrationMode(schema.ModeReplay),
+		schema.WithDialect(dialect.Postgres),
+		schema.WithFormatter(sqltool.GolangMigrateFormatter),
+		schema.WithDropColumn(true),
+		schema.WithDropIndex(true),
+	)
+	if err != nil {
+		return fmt.Errorf("failed creating migration engine: %w", err)
+	}
+	if err := atlas.NamedDiff(ctx, name, entmigrate.Tables...); err != nil {
+		return fmt.Errorf("failed generating migration: %w", err)
+	}
+	return nil
+}
+
+// Hash recomputes atlas.sum after a reviewed manual edit of the migration
+// files
+func (m *Migrator) Hash() error {
+	sum, err := m.dir.Checksum()
+	if err != nil {
+		return err
+	}
+	return migrate.WriteSumFile(m.dir, sum)
+}
+
+// inTx runs the statements of a migration and updates the history in one
+// transaction
+func (m *Migrator) inTx(ctx context.Context, stmts []string, history string, args ...any) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, stmt := range stmts {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			tx.Rollback()
+			return fmt.Errorf("%w\n%s", err, stmt)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, history, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
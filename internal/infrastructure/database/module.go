@@ -6,6 +6,7 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/metrics"
 
 	"go.uber.org/fx"
 	"go.uber.org/zap"
@@ -33,6 +34,7 @@ var Module = fx.Module("database",
 		return db.Client
 	}),
 	fx.Invoke(func(lc fx.Lifecycle, db *DB) {
+		metrics.RegisterDB(db.Stats)
 		lc.Append(fx.Hook{
 			OnStart: func(ctx context.Context) error {
 				return db.Ping()
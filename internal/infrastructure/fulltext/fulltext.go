@@ -0,0 +1,206 @@
+// Package fulltext implements the global search on PostgreSQL. Each searched
+// table declares its identifier columns (plates, numbers, phones), compared
+// without separators, and its name and text columns, compared without case
+// nor accents. Identifiers and names are matched by substring or trigram
+// similarity (pg_trgm), names and texts by full-text search, all backed by
+// expression indexes created at startup.
+//
+// On SQLite (development database) the search falls back to substring
+// matching, without accent folding nor fuzzy matching; the results are still
+// ranked by Score.
+package fulltext
+
+import (
+	"strings"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+)
+
+// Query is a search text in the forms compared with the columns
+type Query struct {
+	Text    string // Fold form
+	Compact string // Compact form
+	Limit   int
+}
+
+// NewQuery prepares a search text
+func NewQuery(text string, limit int) *Query {
+	return &Query{Text: Fold(text), Compact: Compact(text), Limit: limit}
+}
+
+// Spec declares the searched columns of a table
+type Spec struct {
+	Table       string
+	Identifiers []string // compared on their letters and digits
+	Names       []string // fuzzy and full-text matched
+	Texts       []string // full-text matched only
+}
+
+// normalized is the accent and case insensitive form of a column
+func normalized(column string) string {
+	return "f_unaccent(lower(" + column + "))"
+}
+
+// compacted is the form of an identifier column, see Compact
+func compacted(column string) string {
+	return "regexp_replace(" + normalized(column) + ", '[^a-z0-9]+', '', 'g')"
+}
+
+// document is the full-text document of the name and text columns
+func document(columns []string) string {
+	parts := make([]string, len(columns))
+	for i, column := range columns {
+		parts[i] = "coalesce(" + column + ", '')"
+	}
+	return "to_tsvector('simple', f_unaccent(" + strings.Join(parts, " || ' ' || ") + "))"
+}
+
+// separators are the characters removed from the identifiers on SQLite, which
+// has no regexp_replace
+var separators = []string{" ", "-", ".", "/", "+", "_"}
+
+// portableCompacted is the form of an identifier column on SQLite
+func portableCompacted(column string) string {
+	expr := "lower(" + column + ")"
+	for _, sep := range separators {
+		expr = "replace(" + expr + ", '" + sep + "', '')"
+	}
+	return expr
+}
+
+// escapeLike escapes the wildcards of a LIKE pattern
+func escapeLike(text string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(text)
+}
+
+// columns qualifies columns with the table of the selector
+func columns(s *sql.Selector, names []string) []string {
+	qualified := make([]string, len(names))
+	for i, name := range names {
+		qualified[i] = s.C(name)
+	}
+	return qualified
+}
+
+// Where returns the ent predicate keeping the rows matching the query, to
+// convert to the predicate type of the query: predicate.Vehicule(spec.Where(q))
+func (sp *Spec) Where(q *Query) func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		if s.Dialect() == dialect.SQLite {
+			sp.portableWhere(s, q)
+			return
+		}
+		var preds []*sql.Predicate
+		if len(q.Compact) >= MinLength {
+			for _, column := range columns(s, sp.Identifiers) {
+				preds = append(preds, sql.P(func(b *sql.Builder) {
+					b.WriteString(compacted(column) + " LIKE ").Arg("%" + q.Compact + "%")
+				}))
+			}
+		}
+		for _, column := range columns(s, sp.Names) {
+			preds = append(preds, sql.P(func(b *sql.Builder) {
+				b.WriteString(normalized(column) + " LIKE ").Arg("%" + escapeLike(q.Text) + "%")
+			}), sql.P(func(b *sql.Builder) {
+				// Opérateur de similarité de pg_trgm (seuil pg_trgm.similarity_threshold)
+				b.WriteString(normalized(column) + " % ").Arg(q.Text)
+			}))
+		}
+		if all := append(columns(s, sp.Names), columns(s, sp.Texts)...); len(all) > 0 {
+			preds = append(preds, sql.P(func(b *sql.Builder) {
+				b.WriteString(document(all) + " @@ plainto_tsquery('simple', ").Arg(q.Text).WriteString(")")
+			}))
+		}
+		if len(preds) == 0 {
+			s.Where(sql.False())
+			return
+		}
+		s.Where(sql.Or(preds...))
+	}
+}
+
+// portableWhere is the substring search of Where on SQLite
+func (sp *Spec) portableWhere(s *sql.Selector, q *Query) {
+	var preds []*sql.Predicate
+	if len(q.Compact) >= MinLength {
+		for _, column := range columns(s, sp.Identifiers) {
+			preds = append(preds, sql.P(func(b *sql.Builder) {
+				b.WriteString(portableCompacted(column) + " LIKE ").Arg("%" + q.Compact + "%")
+			}))
+		}
+	}
+	for _, column := range append(columns(s, sp.Names), columns(s, sp.Texts)...) {
+		preds = append(preds, sql.P(func(b *sql.Builder) {
+			b.WriteString("lower(" + column + ") LIKE ").Arg("%" + escapeLike(q.Text) + "%").WriteString(` ESCAPE '\'`)
+		}))
+	}
+	if len(preds) == 0 {
+		s.Where(sql.False())
+		return
+	}
+	s.Where(sql.Or(preds...))
+}
+
+// Order returns the ent order option ranking the rows on their best
+// similarity with the query. SQLite has no similarity: the rows are left in
+// their order and ranked by the caller.
+func (sp *Spec) Order(q *Query) func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		if s.Dialect() == dialect.SQLite {
+			return
+		}
+		s.OrderExpr(sql.ExprFunc(func(b *sql.Builder) {
+			b.WriteString("GREATEST(")
+			for i, column := range columns(s, sp.Identifiers) {
+				if i > 0 {
+					b.Comma()
+				}
+				b.WriteString("similarity(" + compacted(column) + ", ").Arg(q.Compact).WriteString(")")
+			}
+			for i, column := range columns(s, sp.Names) {
+				if i > 0 || len(sp.Identifiers) > 0 {
+					b.Comma()
+				}
+				b.WriteString("similarity(" + normalized(column) + ", ").Arg(q.Text).WriteString(")")
+			}
+			if len(sp.Identifiers)+len(sp.Names) == 0 {
+				b.WriteString("0")
+			}
+			b.WriteString(") DESC")
+		}))
+	}
+}
+
+// Statements returns the DDL of the search: extensions, the immutable
+// unaccent wrapper usable in indexes, and the expression indexes of the
+// tables. The queries need them, so they are applied by the versioned
+// migrations (*_search_indexes.up.sql); a new searched column needs a
+// migration with its index. Every statement is idempotent, for the databases
+// where they were created before the migrations.
+func Statements(specs ...*Spec) []string {
+	stmts := []string{
+		"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+		"CREATE EXTENSION IF NOT EXISTS unaccent",
+		// unaccent() n'est pas IMMUTABLE (dictionnaire modifiable) : l'enveloppe
+		// fixe le dictionnaire pour pouvoir l'indexer
+		`CREATE OR REPLACE FUNCTION f_unaccent(text) RETURNS text AS
+$$ SELECT public.unaccent('public.unaccent', $1) $$
+LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT`,
+	}
+	for _, sp := range specs {
+		for _, column := range sp.Identifiers {
+			stmts = append(stmts, "CREATE INDEX IF NOT EXISTS "+sp.Table+"_"+column+"_search_idx ON "+sp.Table+
+				" USING gin ("+compacted(column)+" gin_trgm_ops)")
+		}
+		for _, column := range sp.Names {
+			stmts = append(stmts, "CREATE INDEX IF NOT EXISTS "+sp.Table+"_"+column+"_trgm_idx ON "+sp.Table+
+				" USING gin ("+normalized(column)+" gin_trgm_ops)")
+		}
+		if all := append(append([]string{}, sp.Names...), sp.Texts...); len(all) > 0 {
+			stmts = append(stmts, "CREATE INDEX IF NOT EXISTS "+sp.Table+"_fts_idx ON "+sp.Table+
+				" USING gin ("+document(all)+")")
+		}
+	}
+	return stmts
+}
@@ -0,0 +1,93 @@
+package fulltext
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFold(t *testing.T) {
+	assert.Equal(t, "kouame n'guessan", Fold(" Kouamé N'Guessan "))
+	assert.Equal(t, "coeur de lion", Fold("Cœur de Lion"))
+	assert.Equal(t, "ab123cd", Compact("AB-123-CD"))
+	assert.Equal(t, "2250707070707", Compact("+225 07 07 07 07 07"))
+}
+
+func TestScore(t *testing.T) {
+	assert.Equal(t, 1.0, Score("ab 123 cd", "AB-123-CD"))
+	assert.Equal(t, 1.0, Score("Kouame", "KOUAMÉ"))
+	assert.Equal(t, 0.9, Score("kouam", "Kouamé"))
+	assert.Equal(t, 0.75, Score("123", "AB-123-CD"))
+	assert.Zero(t, Score("kouame", ""))
+
+	// Faute de frappe : proche, mais derrière les correspondances exactes
+	typo := Score("kouassi", "Kouasi")
+	assert.Greater(t, typo, 0.0)
+	assert.Less(t, typo, 0.75)
+	assert.Greater(t, typo, Score("kouassi", "Traoré"))
+}
+
+func TestSimilarity(t *testing.T) {
+	// pg_trgm : similarity('word', 'two words') = 0.363636
+	assert.InDelta(t, 0.363636, Similarity("word", "two words"), 1e-6)
+	assert.Equal(t, 1.0, Similarity("Éloïse", "eloise"))
+}
+
+func TestSpec_Where(t *testing.T) {
+	spec := &Spec{
+		Table:       "conducteurs",
+		Identifiers: []string{"numero_permis"},
+		Names:       []string{"nom"},
+	}
+	s := sql.Dialect(dialect.Postgres).Select("*").From(sql.Table("conducteurs"))
+	q := NewQuery("Kouamé 50%", 5)
+	spec.Where(q)(s)
+	spec.Order(q)(s)
+
+	query, args := s.Query()
+	assert.Contains(t, query, `regexp_replace(f_unaccent(lower("conducteurs"."numero_permis")), '[^a-z0-9]+', '', 'g') LIKE $1`)
+	assert.Contains(t, query, `f_unaccent(lower("conducteurs"."nom")) % $3`)
+	assert.Contains(t, query, `to_tsvector('simple', f_unaccent(coalesce("conducteurs"."nom", ''))) @@ plainto_tsquery('simple', $4)`)
+	assert.Contains(t, query, `ORDER BY GREATEST(similarity(`)
+	assert.Equal(t, []interface{}{"%kouame50%", `%kouame 50\%%`, "kouame 50%", "kouame 50%", "kouame50", "kouame 50%"}, args)
+}
+
+func TestSpec_Where_SQLite(t *testing.T) {
+	spec := &Spec{
+		Table:       "vehicules",
+		Identifiers: []string{"immatriculation"},
+		Texts:       []string{"marque"},
+	}
+	s := sql.Dialect(dialect.SQLite).Select("*").From(sql.Table("vehicules"))
+	q := NewQuery("AB-123", 5)
+	spec.Where(q)(s)
+	spec.Order(q)(s)
+
+	query, args := s.Query()
+	assert.Contains(t, query, `replace(replace(replace(replace(replace(replace(lower(`+"`vehicules`.`immatriculation`"+`), ' ', ''), '-', ''), '.', ''), '/', ''), '+', ''), '_', '') LIKE ?`)
+	assert.Contains(t, query, "lower(`vehicules`.`marque`) LIKE ? ESCAPE '\\'")
+	assert.NotContains(t, query, "ORDER BY")
+	assert.Equal(t, []interface{}{"%ab123%", "%ab-123%"}, args)
+}
+
+func TestStatements_InMigrations(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "..", "migrations", "*.up.sql"))
+	require.NoError(t, err)
+	var migrations strings.Builder
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		require.NoError(t, err)
+		migrations.Write(b)
+	}
+
+	// Un index de recherche sans migration ne serait jamais créé
+	for _, stmt := range Statements(All...) {
+		assert.Contains(t, migrations.String(), stmt+";")
+	}
+}
@@ -0,0 +1,64 @@
+package fulltext
+
+import (
+	"police-trafic-api-frontend-aligned/ent/alertesecuritaire"
+	"police-trafic-api-frontend-aligned/ent/conducteur"
+	"police-trafic-api-frontend-aligned/ent/convocation"
+	"police-trafic-api-frontend-aligned/ent/objetperdu"
+	"police-trafic-api-frontend-aligned/ent/objetretrouve"
+	"police-trafic-api-frontend-aligned/ent/plainte"
+	"police-trafic-api-frontend-aligned/ent/procesverbal"
+	"police-trafic-api-frontend-aligned/ent/vehicule"
+)
+
+// Searched tables
+var (
+	Vehicules = &Spec{
+		Table:       vehicule.Table,
+		Identifiers: []string{vehicule.FieldImmatriculation, vehicule.FieldNumeroChassis},
+		Names:       []string{vehicule.FieldProprietaireNom, vehicule.FieldProprietairePrenom},
+		Texts:       []string{vehicule.FieldMarque, vehicule.FieldModele},
+	}
+	Conducteurs = &Spec{
+		Table:       conducteur.Table,
+		Identifiers: []string{conducteur.FieldNumeroPermis, conducteur.FieldTelephone},
+		Names:       []string{conducteur.FieldNom, conducteur.FieldPrenom},
+	}
+	ProcesVerbaux = &Spec{
+		Table:       procesverbal.Table,
+		Identifiers: []string{procesverbal.FieldNumeroPv},
+	}
+	Plaintes = &Spec{
+		Table:       plainte.Table,
+		Identifiers: []string{plainte.FieldNumero, plainte.FieldPlaignantTelephone},
+		Names:       []string{plainte.FieldPlaignantNom, plainte.FieldPlaignantPrenom},
+	}
+	Convocations = &Spec{
+		Table:       convocation.Table,
+		Identifiers: []string{convocation.FieldNumero, convocation.FieldConvoqueTelephone},
+		Names:       []string{convocation.FieldConvoqueNom, convocation.FieldConvoquePrenom},
+	}
+	Alertes = &Spec{
+		Table:       alertesecuritaire.Table,
+		Identifiers: []string{alertesecuritaire.FieldNumero},
+		Names:       []string{alertesecuritaire.FieldTitre},
+		Texts:       []string{alertesecuritaire.FieldDescription},
+	}
+	ObjetsPerdus = &Spec{
+		Table:       objetperdu.Table,
+		Identifiers: []string{objetperdu.FieldNumero},
+		Names:       []string{objetperdu.FieldTypeObjet},
+		Texts:       []string{objetperdu.FieldDescription},
+	}
+	ObjetsRetrouves = &Spec{
+		Table:       objetretrouve.Table,
+		Identifiers: []string{objetretrouve.FieldNumero},
+		Names:       []string{objetretrouve.FieldTypeObjet},
+		Texts:       []string{objetretrouve.FieldDescription, objetretrouve.FieldLieuTrouvaille},
+	}
+)
+
+// All lists the searched tables
+var All = []*Spec{
+	Vehicules, Conducteurs, ProcesVerbaux, Plaintes, Convocations, Alertes, ObjetsPerdus, ObjetsRetrouves,
+}
@@ -0,0 +1,103 @@
+package fulltext
+
+import (
+	"strings"
+	"unicode"
+)
+
+// MinLength is the minimum number of letters and digits of a search text:
+// trigram indexes cannot serve shorter patterns
+const MinLength = 3
+
+// accents maps the accented letters of French (and the neighbouring Latin
+// alphabets) to their base letters, like the unaccent extension
+var accents = map[rune]string{
+	'à': "a", 'â': "a", 'ä': "a", 'á': "a", 'ã': "a", 'å': "a",
+	'ç': "c",
+	'é': "e", 'è': "e", 'ê': "e", 'ë': "e",
+	'î': "i", 'ï': "i", 'í': "i", 'ì': "i",
+	'ô': "o", 'ö': "o", 'ó': "o", 'ò': "o", 'õ': "o",
+	'ù': "u", 'û': "u", 'ü': "u", 'ú': "u",
+	'ÿ': "y", 'ý': "y",
+	'ñ': "n",
+	'œ': "oe", 'æ': "ae",
+}
+
+// Fold returns the lower case form of a text without accents, the form of the
+// normalized columns
+func Fold(text string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(text) {
+		if base, ok := accents[r]; ok {
+			b.WriteString(base)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// Compact returns the letters and digits of a folded text, the form under
+// which plates, numbers and phones are compared: "AB-123-CD" and "ab 123 cd"
+// are the same plate
+func Compact(text string) string {
+	var b strings.Builder
+	for _, r := range Fold(text) {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// Score rates how well a value matches a search text, from 0 to 1: equal
+// values first, then values starting with the text, containing it, and last
+// values only close to it (pg_trgm similarity)
+func Score(text, value string) float64 {
+	text, value = Fold(text), Fold(value)
+	if text == "" || value == "" {
+		return 0
+	}
+	compactText, compactValue := Compact(text), Compact(value)
+	switch {
+	case text == value || (compactText != "" && compactText == compactValue):
+		return 1
+	case strings.HasPrefix(value, text) || (compactText != "" && strings.HasPrefix(compactValue, compactText)):
+		return 0.9
+	case strings.Contains(value, text) || (compactText != "" && strings.Contains(compactValue, compactText)):
+		return 0.75
+	}
+	return 0.7 * Similarity(text, value)
+}
+
+// Similarity is the trigram similarity of pg_trgm: the share of the trigrams
+// of the words of both texts that they have in common
+func Similarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range ta {
+		if tb[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+// trigrams returns the trigrams of the words of a text, each word padded with
+// two spaces before and one after as pg_trgm does
+func trigrams(text string) map[string]bool {
+	set := make(map[string]bool)
+	words := strings.FieldsFunc(Fold(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for _, word := range words {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
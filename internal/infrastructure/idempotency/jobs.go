@@ -0,0 +1,24 @@
+package idempotency
+
+import (
+	"context"
+	"fmt"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/scheduler"
+)
+
+// NewCleanupJob removes the expired idempotency keys
+func NewCleanupJob(service Service) scheduler.Job {
+	return scheduler.Job{
+		Name:        "idempotency-key-cleanup",
+		Description: "Supprime les clés d'idempotence expirées et les réponses enregistrées",
+		Schedule:    "0 * * * *",
+		Run: func(ctx context.Context) (string, error) {
+			deleted, err := service.Cleanup(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d clés supprimées", deleted), nil
+		},
+	}
+}
@@ -0,0 +1,14 @@
+package idempotency
+
+import "go.uber.org/fx"
+
+// Module provides the idempotency key service
+var Module = fx.Module("idempotency",
+	fx.Provide(NewService),
+	fx.Provide(
+		fx.Annotate(
+			NewCleanupJob,
+			fx.ResultTags(`group:"jobs"`),
+		),
+	),
+)
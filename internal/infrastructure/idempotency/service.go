@@ -0,0 +1,184 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// HeaderKey is the request header holding the idempotency key
+const HeaderKey = "Idempotency-Key"
+
+// MaxKeyLength is the longest key accepted
+const MaxKeyLength = 255
+
+const (
+	defaultTTL         = 24 * time.Hour
+	defaultLockTimeout = time.Minute
+)
+
+var (
+	// ErrKeyReused is returned when a key is sent again with another body
+	ErrKeyReused = errors.New("idempotency key already used for a different request")
+	// ErrInProgress is returned while the first request with the key is still running
+	ErrInProgress = errors.New("a request with this idempotency key is still in progress")
+)
+
+// Request identifies a request sent with an idempotency key
+type Request struct {
+	Key    string
+	UserID string
+	Route  string // Method and path, with the parameter values
+	Body   []byte
+}
+
+// Response is the first response returned for a key
+type Response struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// Reservation is the outcome of Begin: either the request runs and its
+// response is stored with Complete, or the stored response is replayed
+type Reservation struct {
+	ID     uuid.UUID
+	Replay *Response
+}
+
+// Service stores the first response of each key, shared by all the server
+// instances through the database
+type Service interface {
+	// Begin reserves the key of a request, or returns the response to replay
+	Begin(ctx context.Context, req *Request) (*Reservation, error)
+	// Complete stores the response of a reserved key
+	Complete(ctx context.Context, id uuid.UUID, resp *Response) error
+	// Release forgets a reserved key whose request failed, so that it can be retried
+	Release(ctx context.Context, id uuid.UUID) error
+	// Cleanup removes the expired keys
+	Cleanup(ctx context.Context) (int, error)
+}
+
+type service struct {
+	repo   repository.IdempotencyRepository
+	cfg    config.IdempotencyConfig
+	logger *zap.Logger
+}
+
+// NewService creates a new idempotency service
+func NewService(repo repository.IdempotencyRepository, cfg *config.Config, logger *zap.Logger) Service {
+	idempotency := cfg.Idempotency
+	if idempotency.TTL <= 0 {
+		idempotency.TTL = defaultTTL
+	}
+	if idempotency.LockTimeout <= 0 {
+		idempotency.LockTimeout = defaultLockTimeout
+	}
+
+	return &service{
+		repo:   repo,
+		cfg:    idempotency,
+		logger: logger,
+	}
+}
+
+func (s *service) Begin(ctx context.Context, req *Request) (*Reservation, error) {
+	hash := hashBody(req.Body)
+
+	// Deux passages au plus : la clé expirée trouvée au premier est supprimée
+	for attempt := 0; attempt < 2; attempt++ {
+		now := time.Now()
+		record, reserved, err := s.repo.Reserve(ctx, &repository.ReserveIdempotencyKeyInput{
+			Key:         req.Key,
+			UserID:      req.UserID,
+			Route:       req.Route,
+			RequestHash: hash,
+			LockedUntil: now.Add(s.cfg.LockTimeout),
+			ExpiresAt:   now.Add(s.cfg.TTL),
+		})
+		if err != nil {
+			return nil, err
+		}
+		if reserved {
+			return &Reservation{ID: record.ID}, nil
+		}
+
+		existing, err := s.repo.Get(ctx, req.UserID, req.Route, req.Key)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			// Supprimée entre-temps par une autre instance
+			continue
+		}
+		if existing.ExpiresAt.Before(now) {
+			if err := s.repo.Delete(ctx, existing.ID); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		if existing.RequestHash != hash {
+			return nil, ErrKeyReused
+		}
+
+		if existing.StatusCode != nil {
+			return &Reservation{
+				ID: existing.ID,
+				Replay: &Response{
+					StatusCode:  *existing.StatusCode,
+					ContentType: existing.ContentType,
+					Body:        existing.ResponseBody,
+				},
+			}, nil
+		}
+
+		// L'instance qui traitait la première requête s'est arrêtée sans réponse
+		if existing.LockedUntil != nil && existing.LockedUntil.Before(now) {
+			taken, err := s.repo.TakeOver(ctx, existing.ID, now, now.Add(s.cfg.LockTimeout))
+			if err != nil {
+				return nil, err
+			}
+			if taken {
+				s.logger.Warn("Idempotency key taken over after lock timeout",
+					zap.String("route", req.Route),
+					zap.String("user_id", req.UserID),
+				)
+				return &Reservation{ID: existing.ID}, nil
+			}
+		}
+
+		return nil, ErrInProgress
+	}
+
+	return nil, ErrInProgress
+}
+
+func (s *service) Complete(ctx context.Context, id uuid.UUID, resp *Response) error {
+	return s.repo.Complete(ctx, id, &repository.CompleteIdempotencyKeyInput{
+		StatusCode:  resp.StatusCode,
+		ContentType: resp.ContentType,
+		Body:        resp.Body,
+	})
+}
+
+func (s *service) Release(ctx context.Context, id uuid.UUID) error {
+	return s.repo.Delete(ctx, id)
+}
+
+func (s *service) Cleanup(ctx context.Context) (int, error) {
+	return s.repo.DeleteExpired(ctx, time.Now())
+}
+
+func hashBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
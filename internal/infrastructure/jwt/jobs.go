@@ -0,0 +1,33 @@
+package jwt
+
+import (
+	"context"
+	"fmt"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/scheduler"
+)
+
+// NewKeyRotationJob replaces the asymmetric signing key when it reaches
+// jwt.key_rotation and removes the keys past their grace period
+func NewKeyRotationJob(service Service) scheduler.Job {
+	return scheduler.Job{
+		Name:        "jwt-key-rotation",
+		Description: "Renouvelle la clé de signature des jetons et supprime les clés expirées",
+		Schedule:    "15 * * * *",
+		Run: func(ctx context.Context) (string, error) {
+			rotated, err := service.RotateKeys(ctx, false)
+			if err != nil {
+				return "", err
+			}
+			deleted, err := service.CleanupKeys(ctx)
+			if err != nil {
+				return "", err
+			}
+
+			if rotated {
+				return fmt.Sprintf("clé renouvelée, %d clés expirées supprimées", deleted), nil
+			}
+			return fmt.Sprintf("aucune rotation nécessaire, %d clés expirées supprimées", deleted), nil
+		},
+	}
+}
@@ -1,10 +1,15 @@
 package jwt
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sort"
+	"sync"
 	"time"
 
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
 
 	"github.com/golang-jwt/jwt/v5"
 	"go.uber.org/zap"
@@ -17,30 +22,110 @@ type Claims struct {
 	Role      string `json:"role"`
 	SessionID string `json:"session_id,omitempty"` // Session ID for revocation check
 	DeviceID  string `json:"device_id,omitempty"`  // Device ID for device binding
+	Purpose   string `json:"purpose,omitempty"`    // Set on tokens that are not access tokens
+	Federated bool   `json:"federated,omitempty"`  // MFA token of a login through the identity provider
 	jwt.RegisteredClaims
 }
 
+// Purposes of the short-lived tokens issued during a login, each only accepted
+// by the matching step
+const (
+	// PurposeMFA marks the token issued after the password step, for the second factor
+	PurposeMFA = "mfa"
+	// PurposePasswordChange marks the token issued when the password is temporary
+	// or expired, for the password change
+	PurposePasswordChange = "password_change"
+)
+
+const (
+	mfaTokenExpiration            = 5 * time.Minute
+	passwordChangeTokenExpiration = 10 * time.Minute
+
+	defaultKeyGrace = 7 * 24 * time.Hour
+	// unknownKeyReloadInterval limits the reloads triggered by tokens signed
+	// with a key this instance does not know yet
+	unknownKeyReloadInterval = 30 * time.Second
+)
+
+// errNoSigningKey is returned when no asymmetric key was loaded or created yet
+var errNoSigningKey = errors.New("no active signing key")
+
+// ErrSessionRequired is returned by RefreshToken for a token bound to no
+// session, which could not be revoked
+var ErrSessionRequired = errors.New("token is not bound to a session")
+
 // Service defines JWT service interface
 type Service interface {
 	GenerateToken(userID, matricule, role string) (string, error)
 	GenerateTokenWithSession(userID, matricule, role, sessionID, deviceID string) (string, error)
 	ValidateToken(tokenString string) (*Claims, error)
 	ValidateTokenIgnoreExpiry(tokenString string) (*Claims, error)
+	// RefreshToken issues a new token bound to the session of an existing one,
+	// possibly expired; a token without session is refused
 	RefreshToken(tokenString string) (string, error)
+	// GenerateMFAToken is issued after the first step of a login, the password
+	// or the identity provider when federated is set
+	GenerateMFAToken(userID, matricule, role string, federated bool) (string, error)
+	ValidateMFAToken(tokenString string) (*Claims, error)
+	GeneratePasswordChangeToken(userID, matricule, role string) (string, error)
+	ValidatePasswordChangeToken(tokenString string) (*Claims, error)
+	// JWKS returns the public keys verifying the tokens; it is empty with HS256
+	JWKS() *JWKSet
+	// Reload loads the signing keys from the database
+	Reload(ctx context.Context) error
+	// RotateKeys creates a new signing key when there is none, when the active
+	// one is older than jwt.key_rotation, or when force is set. The previous
+	// keys keep verifying tokens during jwt.key_grace.
+	RotateKeys(ctx context.Context, force bool) (bool, error)
+	// EnsureKey loads the keys and creates the first one when there is none.
+	// Replacing an old key is left to the jwt-key-rotation job, which runs
+	// on a single instance.
+	EnsureKey(ctx context.Context) error
+	// CleanupKeys removes the keys past their grace period
+	CleanupKeys(ctx context.Context) (int, error)
 }
 
 // service implements JWT service
 type service struct {
-	config *config.JWTConfig
-	logger *zap.Logger
+	config    *config.JWTConfig
+	algorithm string
+	keyRepo   repository.SigningKeyRepository // nil with HS256
+	logger    *zap.Logger
+
+	mu         sync.RWMutex
+	keys       map[string]*signingKey
+	active     *signingKey
+	lastReload time.Time
 }
 
-// NewJWTService creates a new JWT service
+// NewJWTService creates a new JWT service signing with HS256 and the shared secret
 func NewJWTService(cfg *config.Config, logger *zap.Logger) Service {
 	return &service{
-		config: &cfg.JWT,
-		logger: logger,
+		config:    &cfg.JWT,
+		algorithm: AlgorithmHS256,
+		logger:    logger,
+	}
+}
+
+// NewDatabaseJWTService creates a new JWT service using the algorithm set by
+// jwt.algorithm. Asymmetric keys are stored in the database, shared by all the
+// instances; none is usable until the first Reload.
+func NewDatabaseJWTService(cfg *config.Config, keyRepo repository.SigningKeyRepository, logger *zap.Logger) (Service, error) {
+	algorithm := cfg.JWT.Algorithm
+	if algorithm == "" || algorithm == AlgorithmHS256 {
+		return NewJWTService(cfg, logger), nil
+	}
+	if _, err := signingMethod(algorithm); err != nil {
+		return nil, err
 	}
+
+	return &service{
+		config:    &cfg.JWT,
+		algorithm: algorithm,
+		keyRepo:   keyRepo,
+		logger:    logger,
+		keys:      make(map[string]*signingKey),
+	}, nil
 }
 
 // GenerateToken generates a JWT token for a user (without session binding - for backwards compatibility)
@@ -66,8 +151,7 @@ func (s *service) GenerateTokenWithSession(userID, matricule, role, sessionID, d
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(s.config.Secret))
+	tokenString, err := s.sign(claims)
 	if err != nil {
 		s.logger.Error("Failed to generate token", zap.Error(err))
 		return "", fmt.Errorf("failed to generate token: %w", err)
@@ -86,13 +170,7 @@ func (s *service) GenerateTokenWithSession(userID, matricule, role, sessionID, d
 
 // ValidateToken validates and parses a JWT token
 func (s *service) ValidateToken(tokenString string) (*Claims, error) {
-	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		// Validate signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.config.Secret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, s.keyFunc)
 
 	if err != nil {
 		s.logger.Warn("Token validation failed", zap.Error(err))
@@ -105,6 +183,12 @@ func (s *service) ValidateToken(tokenString string) (*Claims, error) {
 		return nil, fmt.Errorf("invalid token claims")
 	}
 
+	// Un jeton de second facteur n'est pas un jeton d'accès
+	if claims.Purpose != "" {
+		s.logger.Warn("Token purpose not accepted", zap.String("purpose", claims.Purpose))
+		return nil, fmt.Errorf("invalid token purpose")
+	}
+
 	// Check if token is expired
 	if claims.ExpiresAt != nil && claims.ExpiresAt.Before(time.Now()) {
 		s.logger.Warn("Token expired", 
@@ -129,13 +213,7 @@ func (s *service) ValidateTokenIgnoreExpiry(tokenString string) (*Claims, error)
 	// Parse with options to skip expiration check
 	parser := jwt.NewParser(jwt.WithoutClaimsValidation())
 
-	token, err := parser.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
-		// Validate signing method
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.config.Secret), nil
-	})
+	token, err := parser.ParseWithClaims(tokenString, &Claims{}, s.keyFunc)
 
 	if err != nil {
 		s.logger.Warn("Token parsing failed (ignore expiry)", zap.Error(err))
@@ -148,14 +226,14 @@ func (s *service) ValidateTokenIgnoreExpiry(tokenString string) (*Claims, error)
 		return nil, fmt.Errorf("invalid token claims")
 	}
 
+	if claims.Purpose != "" {
+		s.logger.Warn("Token purpose not accepted (ignore expiry)", zap.String("purpose", claims.Purpose))
+		return nil, fmt.Errorf("invalid token purpose")
+	}
+
 	// Verify signature is valid (token.Valid checks signature)
 	// We need to re-verify the signature since we skipped validation
-	_, err = jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return []byte(s.config.Secret), nil
-	}, jwt.WithoutClaimsValidation())
+	_, err = jwt.Parse(tokenString, s.keyFunc, jwt.WithoutClaimsValidation())
 
 	if err != nil {
 		s.logger.Warn("Token signature invalid", zap.Error(err))
@@ -179,6 +257,311 @@ func (s *service) RefreshToken(tokenString string) (string, error) {
 		return "", fmt.Errorf("cannot refresh invalid token: %w", err)
 	}
 
-	// Generate new token with same user info but new expiration
-	return s.GenerateToken(claims.UserID, claims.Matricule, claims.Role)
-}
\ No newline at end of file
+	// Sans session, rien ne permettrait de révoquer le jeton renouvelé
+	if claims.SessionID == "" {
+		s.logger.Warn("Sessionless token refresh refused", zap.String("user_id", claims.UserID))
+		return "", ErrSessionRequired
+	}
+
+	// Generate new token with same user info and session but new expiration
+	return s.GenerateTokenWithSession(claims.UserID, claims.Matricule, claims.Role, claims.SessionID, claims.DeviceID)
+}
+
+// GenerateMFAToken generates the token proving the first step of a login.
+// It is rejected by ValidateToken and only grants access to the second step.
+func (s *service) GenerateMFAToken(userID, matricule, role string, federated bool) (string, error) {
+	return s.generatePurposeToken(Claims{
+		UserID:    userID,
+		Matricule: matricule,
+		Role:      role,
+		Purpose:   PurposeMFA,
+		Federated: federated,
+	}, mfaTokenExpiration)
+}
+
+// ValidateMFAToken validates a token issued by GenerateMFAToken
+func (s *service) ValidateMFAToken(tokenString string) (*Claims, error) {
+	return s.validatePurposeToken(tokenString, PurposeMFA)
+}
+
+// GeneratePasswordChangeToken generates the token returned by a login whose
+// password must be changed. It only grants access to the password change.
+func (s *service) GeneratePasswordChangeToken(userID, matricule, role string) (string, error) {
+	return s.generatePurposeToken(Claims{
+		UserID:    userID,
+		Matricule: matricule,
+		Role:      role,
+		Purpose:   PurposePasswordChange,
+	}, passwordChangeTokenExpiration)
+}
+
+// ValidatePasswordChangeToken validates a token issued by GeneratePasswordChangeToken
+func (s *service) ValidatePasswordChangeToken(tokenString string) (*Claims, error) {
+	return s.validatePurposeToken(tokenString, PurposePasswordChange)
+}
+
+func (s *service) generatePurposeToken(claims Claims, expiration time.Duration) (string, error) {
+	now := time.Now()
+	claims.RegisteredClaims = jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(now.Add(expiration)),
+		IssuedAt:  jwt.NewNumericDate(now),
+		NotBefore: jwt.NewNumericDate(now),
+		Issuer:    "police-traffic-api",
+		Subject:   claims.UserID,
+		Audience:  []string{"police-traffic-frontend"},
+	}
+
+	tokenString, err := s.sign(claims)
+	if err != nil {
+		s.logger.Error("Failed to generate token", zap.String("purpose", claims.Purpose), zap.Error(err))
+		return "", fmt.Errorf("failed to generate %s token: %w", claims.Purpose, err)
+	}
+
+	return tokenString, nil
+}
+
+func (s *service) validatePurposeToken(tokenString, purpose string) (*Claims, error) {
+	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, s.keyFunc)
+	if err != nil {
+		s.logger.Warn("Token validation failed", zap.String("purpose", purpose), zap.Error(err))
+		return nil, fmt.Errorf("invalid token: %w", err)
+	}
+
+	claims, ok := token.Claims.(*Claims)
+	if !ok || !token.Valid || claims.Purpose != purpose {
+		return nil, fmt.Errorf("invalid %s token", purpose)
+	}
+
+	return claims, nil
+}
+
+// sign signs the claims with the shared secret, or with the active key whose
+// kid is set in the header
+func (s *service) sign(claims Claims) (string, error) {
+	if s.keyRepo == nil {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(s.config.Secret))
+	}
+
+	s.mu.RLock()
+	key := s.active
+	s.mu.RUnlock()
+	if key == nil {
+		return "", errNoSigningKey
+	}
+
+	token := jwt.NewWithClaims(key.method, claims)
+	token.Header["kid"] = key.id
+	return token.SignedString(key.private)
+}
+
+// keyFunc returns the key verifying a token: the shared secret with HS256,
+// otherwise the public key designated by the kid header
+func (s *service) keyFunc(token *jwt.Token) (interface{}, error) {
+	if s.keyRepo == nil {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return []byte(s.config.Secret), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key := s.verificationKey(kid)
+	if key == nil {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.private.Public(), nil
+}
+
+// verificationKey looks up a key by kid. An unknown kid may come from a key
+// just created by another instance: the keys are then reloaded.
+func (s *service) verificationKey(kid string) *signingKey {
+	if kid == "" {
+		return nil
+	}
+
+	s.mu.RLock()
+	key, found := s.keys[kid]
+	lastReload := s.lastReload
+	s.mu.RUnlock()
+	if found || time.Since(lastReload) < unknownKeyReloadInterval {
+		return key
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.Reload(ctx); err != nil {
+		s.logger.Warn("Failed to reload signing keys", zap.Error(err))
+		return nil
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.keys[kid]
+}
+
+// JWKS returns the public keys, the active one first
+func (s *service) JWKS() *JWKSet {
+	set := &JWKSet{Keys: []JWK{}}
+	if s.keyRepo == nil {
+		return set
+	}
+
+	s.mu.RLock()
+	keys := make([]*signingKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].createdAt.After(keys[j].createdAt)
+	})
+	for _, key := range keys {
+		jwk, err := toJWK(key)
+		if err != nil {
+			s.logger.Error("Failed to publish signing key", zap.String("kid", key.id), zap.Error(err))
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+
+	return set
+}
+
+func (s *service) Reload(ctx context.Context) error {
+	if s.keyRepo == nil {
+		return nil
+	}
+
+	stored, err := s.keyRepo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load signing keys: %w", err)
+	}
+
+	keys := make(map[string]*signingKey, len(stored))
+	var active *signingKey
+	for _, k := range stored {
+		method, err := signingMethod(k.Algorithm)
+		if err != nil {
+			s.logger.Warn("Ignoring signing key", zap.String("kid", k.Kid), zap.Error(err))
+			continue
+		}
+		private, err := decryptPrivateKey(k.PrivateKey, s.config.Secret)
+		if err != nil {
+			s.logger.Error("Ignoring signing key", zap.String("kid", k.Kid), zap.Error(err))
+			continue
+		}
+
+		key := &signingKey{
+			id:        k.Kid,
+			method:    method,
+			private:   private,
+			createdAt: k.CreatedAt,
+			retired:   k.RetiredAt != nil,
+		}
+		keys[key.id] = key
+
+		// Clés triées de la plus récente à la plus ancienne
+		if active == nil && !key.retired && k.Algorithm == s.algorithm {
+			active = key
+		}
+	}
+
+	s.mu.Lock()
+	s.keys = keys
+	s.active = active
+	s.lastReload = time.Now()
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *service) RotateKeys(ctx context.Context, force bool) (bool, error) {
+	if s.keyRepo == nil {
+		return false, nil
+	}
+
+	if err := s.Reload(ctx); err != nil {
+		return false, err
+	}
+
+	s.mu.RLock()
+	active := s.active
+	s.mu.RUnlock()
+	if !force && active != nil && (s.config.KeyRotation <= 0 || time.Since(active.createdAt) < s.config.KeyRotation) {
+		return false, nil
+	}
+
+	now := time.Now()
+	private, err := generateKeyPair(s.algorithm)
+	if err != nil {
+		return false, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	encrypted, err := encryptPrivateKey(private, s.config.Secret)
+	if err != nil {
+		return false, err
+	}
+	kid, err := newKeyID(now)
+	if err != nil {
+		return false, fmt.Errorf("failed to generate key ID: %w", err)
+	}
+
+	created, err := s.keyRepo.Create(ctx, &repository.CreateSigningKeyInput{
+		Kid:        kid,
+		Algorithm:  s.algorithm,
+		PrivateKey: encrypted,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	// Les jetons signés par les anciennes clés restent valides jusqu'à leur expiration
+	grace := s.config.KeyGrace
+	if grace <= 0 {
+		grace = defaultKeyGrace
+	}
+	if err := s.keyRepo.Retire(ctx, created, now, now.Add(grace)); err != nil {
+		return false, err
+	}
+
+	if err := s.Reload(ctx); err != nil {
+		return false, err
+	}
+
+	s.logger.Info("Signing key rotated",
+		zap.String("kid", kid),
+		zap.String("algorithm", s.algorithm),
+		zap.Duration("grace", grace),
+	)
+	return true, nil
+}
+
+func (s *service) EnsureKey(ctx context.Context) error {
+	if s.keyRepo == nil {
+		return nil
+	}
+
+	if err := s.Reload(ctx); err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	active := s.active
+	s.mu.RUnlock()
+	if active != nil {
+		return nil
+	}
+
+	_, err := s.RotateKeys(ctx, false)
+	return err
+}
+
+func (s *service) CleanupKeys(ctx context.Context) (int, error) {
+	if s.keyRepo == nil {
+		return 0, nil
+	}
+	return s.keyRepo.DeleteExpired(ctx, time.Now())
+}
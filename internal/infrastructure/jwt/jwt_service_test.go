@@ -163,7 +163,9 @@ func TestJWTService_RefreshToken(t *testing.T) {
 	userID := "123"
 	matricule := "12345"
 	role := "admin"
-	originalToken, err := service.GenerateToken(userID, matricule, role)
+	originalToken, err := service.GenerateTokenWithSession(userID, matricule, role, "session-1", "device-1")
+	require.NoError(t, err)
+	sessionlessToken, err := service.GenerateToken(userID, matricule, role)
 	require.NoError(t, err)
 
 	tests := []struct {
@@ -188,6 +190,17 @@ func TestJWTService_RefreshToken(t *testing.T) {
 				assert.Equal(t, userID, claims.UserID)
 				assert.Equal(t, matricule, claims.Matricule)
 				assert.Equal(t, role, claims.Role)
+				assert.Equal(t, "session-1", claims.SessionID)
+				assert.Equal(t, "device-1", claims.DeviceID)
+			},
+		},
+		{
+			name:    "sessionless token refresh",
+			token:   sessionlessToken,
+			wantErr: true,
+			checkFunc: func(t *testing.T, newToken string, err error) {
+				assert.ErrorIs(t, err, ErrSessionRequired)
+				assert.Empty(t, newToken)
 			},
 		},
 		{
@@ -245,4 +258,74 @@ func generateTokenWithWrongSecret(t *testing.T) string {
 	token, err := wrongService.GenerateToken("123", "12345", "admin")
 	require.NoError(t, err)
 	return token
-}
\ No newline at end of file
+}
+func TestJWTService_MFAToken(t *testing.T) {
+	logger := zap.NewNop()
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:           "test-secret-key-for-jwt-token",
+			AccessExpiration: 15 * time.Minute,
+		},
+	}
+
+	service := NewJWTService(cfg, logger)
+
+	mfaToken, err := service.GenerateMFAToken("123", "12345", "admin", false)
+	require.NoError(t, err)
+
+	claims, err := service.ValidateMFAToken(mfaToken)
+	require.NoError(t, err)
+	assert.Equal(t, "123", claims.UserID)
+	assert.Equal(t, PurposeMFA, claims.Purpose)
+	assert.False(t, claims.Federated)
+
+	// Premier facteur vérifié par le fournisseur d'identité
+	federatedToken, err := service.GenerateMFAToken("123", "12345", "admin", true)
+	require.NoError(t, err)
+	claims, err = service.ValidateMFAToken(federatedToken)
+	require.NoError(t, err)
+	assert.True(t, claims.Federated)
+
+	// Le jeton du premier facteur ne donne pas accès à l'API
+	_, err = service.ValidateToken(mfaToken)
+	assert.Error(t, err)
+	_, err = service.RefreshToken(mfaToken)
+	assert.Error(t, err)
+
+	// Et un jeton d'accès ne remplace pas le jeton du premier facteur
+	accessToken, err := service.GenerateToken("123", "12345", "admin")
+	require.NoError(t, err)
+	_, err = service.ValidateMFAToken(accessToken)
+	assert.Error(t, err)
+}
+
+func TestJWTService_PasswordChangeToken(t *testing.T) {
+	logger := zap.NewNop()
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:           "test-secret-key-for-jwt-token",
+			AccessExpiration: 15 * time.Minute,
+		},
+	}
+
+	service := NewJWTService(cfg, logger)
+
+	token, err := service.GeneratePasswordChangeToken("123", "12345", "agent")
+	require.NoError(t, err)
+
+	claims, err := service.ValidatePasswordChangeToken(token)
+	require.NoError(t, err)
+	assert.Equal(t, "123", claims.UserID)
+	assert.Equal(t, PurposePasswordChange, claims.Purpose)
+
+	// Ni jeton d'accès, ni jeton de second facteur
+	_, err = service.ValidateToken(token)
+	assert.Error(t, err)
+	_, err = service.ValidateMFAToken(token)
+	assert.Error(t, err)
+
+	mfaToken, err := service.GenerateMFAToken("123", "12345", "agent", false)
+	require.NoError(t, err)
+	_, err = service.ValidatePasswordChangeToken(mfaToken)
+	assert.Error(t, err)
+}
@@ -0,0 +1,260 @@
+package jwt
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+
+	gojwt "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// memoryKeyRepository keeps the signing keys in memory
+type memoryKeyRepository struct {
+	mu   sync.Mutex
+	keys []*ent.SigningKey
+}
+
+func (r *memoryKeyRepository) List(ctx context.Context) ([]*ent.SigningKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var keys []*ent.SigningKey
+	for i := len(r.keys) - 1; i >= 0; i-- {
+		if k := r.keys[i]; k.ExpiresAt == nil || k.ExpiresAt.After(time.Now()) {
+			keys = append(keys, k)
+		}
+	}
+	return keys, nil
+}
+
+func (r *memoryKeyRepository) Create(ctx context.Context, input *repository.CreateSigningKeyInput) (*ent.SigningKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	key := &ent.SigningKey{
+		Kid:        input.Kid,
+		Algorithm:  input.Algorithm,
+		PrivateKey: input.PrivateKey,
+		CreatedAt:  time.Now(),
+	}
+	r.keys = append(r.keys, key)
+	return key, nil
+}
+
+func (r *memoryKeyRepository) Retire(ctx context.Context, key *ent.SigningKey, retiredAt, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, k := range r.keys {
+		if k.Kid != key.Kid && k.CreatedAt.Before(key.CreatedAt) && k.RetiredAt == nil {
+			k.RetiredAt, k.ExpiresAt = &retiredAt, &expiresAt
+		}
+	}
+	return nil
+}
+
+func (r *memoryKeyRepository) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	kept := r.keys[:0]
+	for _, k := range r.keys {
+		if k.ExpiresAt == nil || !k.ExpiresAt.Before(before) {
+			kept = append(kept, k)
+		}
+	}
+	deleted := len(r.keys) - len(kept)
+	r.keys = kept
+	return deleted, nil
+}
+
+func newAsymmetricService(t *testing.T, algorithm string, repo repository.SigningKeyRepository) Service {
+	t.Helper()
+
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:           "test-secret-key-for-jwt-token",
+			AccessExpiration: 15 * time.Minute,
+			Algorithm:        algorithm,
+			KeyRotation:      720 * time.Hour,
+			KeyGrace:         time.Hour,
+		},
+	}
+
+	service, err := NewDatabaseJWTService(cfg, repo, zap.NewNop())
+	require.NoError(t, err)
+	return service
+}
+
+func TestJWTService_AsymmetricSigning(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmRS256, AlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			ctx := context.Background()
+			service := newAsymmetricService(t, algorithm, &memoryKeyRepository{})
+
+			// Aucune clé avant le premier démarrage
+			_, err := service.GenerateToken("123", "12345", "admin")
+			assert.Error(t, err)
+
+			rotated, err := service.RotateKeys(ctx, false)
+			require.NoError(t, err)
+			assert.True(t, rotated)
+
+			token, err := service.GenerateToken("123", "12345", "admin")
+			require.NoError(t, err)
+
+			parsed, _, err := gojwt.NewParser().ParseUnverified(token, &Claims{})
+			require.NoError(t, err)
+			assert.Equal(t, algorithm, parsed.Method.Alg())
+			kid, _ := parsed.Header["kid"].(string)
+			assert.NotEmpty(t, kid)
+
+			claims, err := service.ValidateToken(token)
+			require.NoError(t, err)
+			assert.Equal(t, "123", claims.UserID)
+
+			jwks := service.JWKS()
+			require.Len(t, jwks.Keys, 1)
+			assert.Equal(t, kid, jwks.Keys[0].KeyID)
+			assert.Equal(t, algorithm, jwks.Keys[0].Algorithm)
+
+			// Un jeton HS256 signé avec le secret n'est plus accepté
+			hsToken, err := NewJWTService(&config.Config{JWT: config.JWTConfig{
+				Secret:           "test-secret-key-for-jwt-token",
+				AccessExpiration: 15 * time.Minute,
+			}}, zap.NewNop()).GenerateToken("123", "12345", "admin")
+			require.NoError(t, err)
+			_, err = service.ValidateToken(hsToken)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestJWTService_KeyRotation(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryKeyRepository{}
+	service := newAsymmetricService(t, AlgorithmEdDSA, repo)
+
+	_, err := service.RotateKeys(ctx, false)
+	require.NoError(t, err)
+
+	// La clé active n'a pas atteint jwt.key_rotation
+	rotated, err := service.RotateKeys(ctx, false)
+	require.NoError(t, err)
+	assert.False(t, rotated)
+
+	oldToken, err := service.GenerateToken("123", "12345", "admin")
+	require.NoError(t, err)
+
+	rotated, err = service.RotateKeys(ctx, true)
+	require.NoError(t, err)
+	assert.True(t, rotated)
+
+	newToken, err := service.GenerateToken("123", "12345", "admin")
+	require.NoError(t, err)
+	assert.NotEqual(t, kidOf(t, oldToken), kidOf(t, newToken))
+
+	// Les deux clés sont publiées, la nouvelle en premier, et vérifient leurs jetons
+	jwks := service.JWKS()
+	require.Len(t, jwks.Keys, 2)
+	assert.Equal(t, kidOf(t, newToken), jwks.Keys[0].KeyID)
+
+	_, err = service.ValidateToken(oldToken)
+	assert.NoError(t, err)
+	_, err = service.ValidateToken(newToken)
+	assert.NoError(t, err)
+
+	// Une autre instance charge les clés depuis la base
+	other := newAsymmetricService(t, AlgorithmEdDSA, repo)
+	_, err = other.ValidateToken(newToken)
+	assert.NoError(t, err)
+
+	// Après la période de grâce, l'ancienne clé est retirée
+	_, err = repo.DeleteExpired(ctx, time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, service.Reload(ctx))
+	assert.Len(t, service.JWKS().Keys, 1)
+	_, err = service.ValidateToken(oldToken)
+	assert.Error(t, err)
+}
+
+func TestJWTService_EnsureKey(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryKeyRepository{}
+	service := newAsymmetricService(t, AlgorithmEdDSA, repo)
+
+	require.NoError(t, service.EnsureKey(ctx))
+	require.Len(t, repo.keys, 1)
+
+	// Au démarrage, une clé trop ancienne est gardée : sa rotation revient au job
+	repo.keys[0].CreatedAt = time.Now().Add(-1000 * time.Hour)
+	other := newAsymmetricService(t, AlgorithmEdDSA, repo)
+	require.NoError(t, other.EnsureKey(ctx))
+	assert.Len(t, repo.keys, 1)
+
+	rotated, err := other.RotateKeys(ctx, false)
+	require.NoError(t, err)
+	assert.True(t, rotated)
+	assert.Len(t, repo.keys, 2)
+}
+
+func TestJWTService_RotationKeepsNewerKeys(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryKeyRepository{}
+	first := newAsymmetricService(t, AlgorithmEdDSA, repo)
+	second := newAsymmetricService(t, AlgorithmEdDSA, repo)
+
+	_, err := first.RotateKeys(ctx, true)
+	require.NoError(t, err)
+	_, err = second.RotateKeys(ctx, true)
+	require.NoError(t, err)
+	newest := repo.keys[1]
+
+	// Une rotation qui retire après coup ne touche pas la clé créée entre-temps
+	require.NoError(t, repo.Retire(ctx, repo.keys[0], time.Now(), time.Now().Add(time.Hour)))
+	assert.Nil(t, newest.RetiredAt)
+
+	require.NoError(t, first.Reload(ctx))
+	token, err := first.GenerateToken("123", "12345", "admin")
+	require.NoError(t, err)
+	assert.Equal(t, newest.Kid, kidOf(t, token))
+}
+
+func TestNewDatabaseJWTService_UnknownAlgorithm(t *testing.T) {
+	cfg := &config.Config{JWT: config.JWTConfig{Algorithm: "none"}}
+
+	_, err := NewDatabaseJWTService(cfg, &memoryKeyRepository{}, zap.NewNop())
+	assert.Error(t, err)
+}
+
+func TestEncryptPrivateKey(t *testing.T) {
+	private, err := generateKeyPair(AlgorithmEdDSA)
+	require.NoError(t, err)
+
+	encrypted, err := encryptPrivateKey(private, "secret")
+	require.NoError(t, err)
+
+	decrypted, err := decryptPrivateKey(encrypted, "secret")
+	require.NoError(t, err)
+	assert.Equal(t, private.Public(), decrypted.Public())
+
+	_, err = decryptPrivateKey(encrypted, "another-secret")
+	assert.Error(t, err)
+}
+
+func kidOf(t *testing.T, token string) string {
+	t.Helper()
+
+	parsed, _, err := gojwt.NewParser().ParseUnverified(token, &Claims{})
+	require.NoError(t, err)
+	kid, _ := parsed.Header["kid"].(string)
+	return kid
+}
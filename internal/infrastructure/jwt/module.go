@@ -1,8 +1,61 @@
 package jwt
 
-import "go.uber.org/fx"
+import (
+	"context"
+	"time"
 
-// Module provides JWT service dependency
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// keyReloadInterval bounds how long a key rotated by another instance takes to
+// be used for signing here
+const keyReloadInterval = time.Minute
+
+// Module provides JWT service dependency. With an asymmetric algorithm, the
+// signing keys are loaded at startup (the first one is created if needed),
+// then reloaded periodically. Their rotation is a scheduled job, so that it
+// runs on one instance only.
 var Module = fx.Module("jwt",
-	fx.Provide(NewJWTService),
-)
\ No newline at end of file
+	fx.Provide(NewDatabaseJWTService),
+	fx.Provide(
+		fx.Annotate(
+			NewKeyRotationJob,
+			fx.ResultTags(`group:"jobs"`),
+		),
+	),
+	fx.Invoke(func(lc fx.Lifecycle, s Service, logger *zap.Logger) {
+		stop := make(chan struct{})
+		lc.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				if err := s.EnsureKey(ctx); err != nil {
+					return err
+				}
+				go reloadPeriodically(s, logger, stop)
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				close(stop)
+				return nil
+			},
+		})
+	}),
+)
+
+func reloadPeriodically(s Service, logger *zap.Logger, stop <-chan struct{}) {
+	ticker := time.NewTicker(keyReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := s.Reload(ctx); err != nil {
+				logger.Warn("Failed to reload signing keys", zap.Error(err))
+			}
+			cancel()
+		}
+	}
+}
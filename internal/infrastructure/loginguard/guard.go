@@ -0,0 +1,224 @@
+package loginguard
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/audittrail"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/shared/errors"
+
+	"go.uber.org/zap"
+)
+
+// Audit actions recorded by the guard
+const (
+	ActionAccountLocked = "ACCOUNT_LOCKED"
+	ActionIPBlocked     = "IP_BLOCKED"
+)
+
+// BlockedError is returned by Check while an account or an IP address has to
+// wait before the next login attempt
+type BlockedError struct {
+	Until   time.Time
+	Account bool // the account is blocked, rather than the IP address
+	Locked  bool // account locked, rather than a progressive delay
+}
+
+func (e *BlockedError) Error() string {
+	return fmt.Sprintf("%s until %s", e.Unwrap(), e.Until.Format(time.RFC3339))
+}
+
+// Unwrap maps the error to the shared authentication errors
+func (e *BlockedError) Unwrap() error {
+	if e.Locked {
+		return errors.ErrAccountLocked
+	}
+	return errors.ErrTooManyAttempts
+}
+
+// RetryAfter returns the number of seconds to wait, for the Retry-After header
+func (e *BlockedError) RetryAfter() int {
+	seconds := int(time.Until(e.Until).Seconds()) + 1
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
+// Service tracks failed login attempts per account and per IP address
+type Service interface {
+	// Check refuses the attempt with a *BlockedError while the account or the IP
+	// address is delayed or locked. userID may be empty for unknown accounts.
+	Check(ctx context.Context, userID, ip string) error
+	// Failure records a failed attempt and applies the delays and locks
+	Failure(ctx context.Context, userID, ip string)
+	// Success clears the failures of the account
+	Success(ctx context.Context, userID string)
+	// Unlock lifts the lock of an account
+	Unlock(ctx context.Context, userID string) error
+	// Cleanup removes the counters left untouched for longer than the window
+	Cleanup(ctx context.Context) (int, error)
+}
+
+type service struct {
+	throttleRepo repository.LoginThrottleRepository
+	auditService audittrail.Service
+	policy       config.LockoutConfig
+	logger       *zap.Logger
+}
+
+// NewService creates a new login guard
+func NewService(throttleRepo repository.LoginThrottleRepository, auditService audittrail.Service, cfg *config.Config, logger *zap.Logger) Service {
+	return &service{
+		throttleRepo: throttleRepo,
+		auditService: auditService,
+		policy:       cfg.Auth.Lockout,
+		logger:       logger,
+	}
+}
+
+func (s *service) Check(ctx context.Context, userID, ip string) error {
+	now := time.Now()
+
+	if ip != "" {
+		t, err := s.throttleRepo.Get(ctx, repository.ThrottleIP, ip)
+		if err != nil {
+			return err
+		}
+		if t != nil && t.NextAttemptAt != nil && now.Before(*t.NextAttemptAt) {
+			return &BlockedError{Until: *t.NextAttemptAt}
+		}
+	}
+
+	if userID != "" {
+		t, err := s.throttleRepo.Get(ctx, repository.ThrottleAccount, userID)
+		if err != nil {
+			return err
+		}
+		if t != nil && t.LockedUntil != nil && now.Before(*t.LockedUntil) {
+			return &BlockedError{Until: *t.LockedUntil, Account: true, Locked: true}
+		}
+		if t != nil && t.NextAttemptAt != nil && now.Before(*t.NextAttemptAt) {
+			return &BlockedError{Until: *t.NextAttemptAt, Account: true}
+		}
+	}
+
+	return nil
+}
+
+// Failure never returns an error: the login has failed anyway, and a counting
+// problem must not change the answer given to the client
+func (s *service) Failure(ctx context.Context, userID, ip string) {
+	now := time.Now()
+
+	if ip != "" {
+		t, err := s.throttleRepo.RecordFailure(ctx, repository.ThrottleIP, ip, now, s.policy.Window)
+		if err != nil {
+			s.logger.Error("Failed to record login failure", zap.String("ip", ip), zap.Error(err))
+		} else if s.policy.IPMaxAttempts > 0 && t.Failures >= s.policy.IPMaxAttempts {
+			until := now.Add(s.policy.IPBlockDuration)
+			s.block(ctx, repository.ThrottleIP, ip, until, nil)
+			if t.Failures == s.policy.IPMaxAttempts {
+				s.logger.Warn("IP address blocked after failed logins", zap.String("ip", ip), zap.Int("failures", t.Failures))
+				s.record(ctx, &audittrail.Entry{
+					Action:       ActionIPBlocked,
+					ResourceType: "ip",
+					ResourceID:   ip,
+					IPAddress:    ip,
+					ErrorMessage: fmt.Sprintf("%d échecs de connexion", t.Failures),
+				})
+			}
+		}
+	}
+
+	if userID == "" {
+		return
+	}
+
+	t, err := s.throttleRepo.RecordFailure(ctx, repository.ThrottleAccount, userID, now, s.policy.Window)
+	if err != nil {
+		s.logger.Error("Failed to record login failure", zap.String("user_id", userID), zap.Error(err))
+		return
+	}
+
+	if s.policy.MaxAttempts > 0 && t.Failures >= s.policy.MaxAttempts {
+		until := now.Add(s.policy.Duration)
+		s.block(ctx, repository.ThrottleAccount, userID, until, &until)
+		s.logger.Warn("Account locked after failed logins",
+			zap.String("user_id", userID),
+			zap.String("ip", ip),
+			zap.Int("failures", t.Failures),
+			zap.Time("locked_until", until),
+		)
+		s.record(ctx, &audittrail.Entry{
+			Action:       ActionAccountLocked,
+			ResourceType: "users",
+			ResourceID:   userID,
+			UserID:       userID,
+			IPAddress:    ip,
+			ErrorMessage: fmt.Sprintf("%d échecs de connexion, verrouillé jusqu'à %s", t.Failures, until.Format(time.RFC3339)),
+		})
+		return
+	}
+
+	if delay := delayAfter(s.policy, t.Failures); delay > 0 {
+		s.block(ctx, repository.ThrottleAccount, userID, now.Add(delay), nil)
+	}
+}
+
+func (s *service) Success(ctx context.Context, userID string) {
+	if err := s.throttleRepo.Reset(ctx, repository.ThrottleAccount, userID); err != nil {
+		s.logger.Error("Failed to reset login failures", zap.String("user_id", userID), zap.Error(err))
+	}
+}
+
+func (s *service) Unlock(ctx context.Context, userID string) error {
+	if err := s.throttleRepo.Reset(ctx, repository.ThrottleAccount, userID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Account unlocked", zap.String("user_id", userID))
+	return nil
+}
+
+func (s *service) Cleanup(ctx context.Context) (int, error) {
+	return s.throttleRepo.DeleteStale(ctx, time.Now().Add(-s.policy.Window))
+}
+
+func (s *service) block(ctx context.Context, kind, key string, nextAttemptAt time.Time, lockedUntil *time.Time) {
+	if err := s.throttleRepo.Block(ctx, kind, key, nextAttemptAt, lockedUntil); err != nil {
+		s.logger.Error("Failed to block login attempts", zap.String("kind", kind), zap.String("key", key), zap.Error(err))
+	}
+}
+
+func (s *service) record(ctx context.Context, entry *audittrail.Entry) {
+	entry.StatusCode = http.StatusLocked
+	s.auditService.Record(ctx, entry)
+}
+
+// delayAfter returns the wait imposed after the given number of consecutive
+// failures: nothing for the first FreeAttempts, then BaseDelay doubled at each
+// further failure, up to MaxDelay
+func delayAfter(policy config.LockoutConfig, failures int) time.Duration {
+	extra := failures - policy.FreeAttempts
+	if extra <= 0 || policy.BaseDelay <= 0 {
+		return 0
+	}
+
+	delay := policy.BaseDelay
+	for i := 1; i < extra; i++ {
+		delay *= 2
+		if policy.MaxDelay > 0 && delay >= policy.MaxDelay {
+			return policy.MaxDelay
+		}
+	}
+	if policy.MaxDelay > 0 && delay > policy.MaxDelay {
+		return policy.MaxDelay
+	}
+
+	return delay
+}
@@ -0,0 +1,61 @@
+package loginguard
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	sharederrors "police-trafic-api-frontend-aligned/internal/shared/errors"
+)
+
+func TestDelayAfter(t *testing.T) {
+	policy := config.LockoutConfig{
+		FreeAttempts: 3,
+		BaseDelay:    time.Second,
+		MaxDelay:     10 * time.Second,
+	}
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 1, want: 0},
+		{failures: 3, want: 0},
+		{failures: 4, want: time.Second},
+		{failures: 5, want: 2 * time.Second},
+		{failures: 7, want: 8 * time.Second},
+		{failures: 8, want: 10 * time.Second},
+		{failures: 100, want: 10 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := delayAfter(policy, tt.failures); got != tt.want {
+			t.Errorf("delayAfter(%d) = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestDelayAfter_Disabled(t *testing.T) {
+	if got := delayAfter(config.LockoutConfig{FreeAttempts: 3}, 10); got != 0 {
+		t.Errorf("delayAfter without base delay = %s, want 0", got)
+	}
+}
+
+func TestBlockedError(t *testing.T) {
+	locked := &BlockedError{Until: time.Now().Add(90 * time.Second), Account: true, Locked: true}
+	if !errors.Is(locked, sharederrors.ErrAccountLocked) {
+		t.Error("locked account should map to ErrAccountLocked")
+	}
+	if got := locked.RetryAfter(); got < 89 || got > 91 {
+		t.Errorf("RetryAfter() = %d, want about 90", got)
+	}
+
+	delayed := &BlockedError{Until: time.Now().Add(-time.Second)}
+	if !errors.Is(delayed, sharederrors.ErrTooManyAttempts) {
+		t.Error("delayed attempt should map to ErrTooManyAttempts")
+	}
+	if got := delayed.RetryAfter(); got != 1 {
+		t.Errorf("RetryAfter() of a past date = %d, want 1", got)
+	}
+}
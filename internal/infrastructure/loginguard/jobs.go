@@ -0,0 +1,24 @@
+package loginguard
+
+import (
+	"context"
+	"fmt"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/scheduler"
+)
+
+// NewCleanupJob removes the failed login counters that expired
+func NewCleanupJob(service Service) scheduler.Job {
+	return scheduler.Job{
+		Name:        "login-throttle-cleanup",
+		Description: "Supprime les compteurs d'échecs de connexion expirés",
+		Schedule:    "30 * * * *",
+		Run: func(ctx context.Context) (string, error) {
+			deleted, err := service.Cleanup(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d compteurs supprimés", deleted), nil
+		},
+	}
+}
@@ -0,0 +1,14 @@
+package loginguard
+
+import "go.uber.org/fx"
+
+// Module provides the login brute-force protection
+var Module = fx.Module("loginguard",
+	fx.Provide(NewService),
+	fx.Provide(
+		fx.Annotate(
+			NewCleanupJob,
+			fx.ResultTags(`group:"jobs"`),
+		),
+	),
+)
@@ -0,0 +1,224 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+)
+
+const (
+	publicQuality    = 90
+	thumbnailQuality = 80
+	// defaultMaxPixels bounds the decoded size of an image (decompression bombs)
+	defaultMaxPixels = 50_000_000
+)
+
+// Processed are the copies of an image, without any metadata: Go's encoders
+// only write the pixels
+type Processed struct {
+	Public     []byte // Re-encoded image: JPEG and PNG keep their format, GIF becomes PNG
+	PublicType string
+	Thumbnail  []byte // JPEG
+}
+
+// Process decodes an image, turns it upright according to its EXIF
+// orientation, and re-encodes it with a thumbnail whose longest side is
+// thumbnailSize pixels
+func Process(data []byte, mimeType string, thumbnailSize, maxPixels int) (*Processed, error) {
+	if maxPixels <= 0 {
+		maxPixels = defaultMaxPixels
+	}
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if cfg.Width*cfg.Height > maxPixels {
+		return nil, fmt.Errorf("%w: %dx%d pixels", ErrTooLarge, cfg.Width, cfg.Height)
+	}
+
+	var img image.Image
+	switch mimeType {
+	case TypeJPEG:
+		img, err = jpeg.Decode(bytes.NewReader(data))
+		if err == nil {
+			img = orient(img, orientation(data))
+		}
+	case TypePNG:
+		img, err = png.Decode(bytes.NewReader(data))
+	case TypeGIF:
+		img, err = gif.Decode(bytes.NewReader(data))
+	default:
+		return nil, fmt.Errorf("%w: %s is not a processed image", ErrTypeNotAllowed, mimeType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	processed := &Processed{PublicType: PublicType(mimeType)}
+	var public bytes.Buffer
+	if processed.PublicType == TypeJPEG {
+		err = jpeg.Encode(&public, img, &jpeg.Options{Quality: publicQuality})
+	} else {
+		err = png.Encode(&public, img)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode image: %w", err)
+	}
+	processed.Public = public.Bytes()
+
+	var thumbnail bytes.Buffer
+	if err := jpeg.Encode(&thumbnail, resize(img, thumbnailSize), &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	processed.Thumbnail = thumbnail.Bytes()
+	return processed, nil
+}
+
+// orientation reads the EXIF orientation of a JPEG image, 1 (upright) when
+// absent
+func orientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+	for i := 2; i+4 <= len(data); {
+		if data[i] != 0xFF {
+			return 1
+		}
+		marker := data[i+1]
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if marker == 0xDA || length < 2 || i+2+length > len(data) {
+			// Début des données de l'image : plus de métadonnées
+			return 1
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+// exifOrientation reads the orientation tag (0x0112) of the first IFD of a
+// TIFF structure
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	count := int(order.Uint16(tiff[ifd:]))
+	for n := 0; n < count; n++ {
+		entry := ifd + 2 + n*12
+		if entry+12 > len(tiff) {
+			return 1
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if o := int(order.Uint16(tiff[entry+8:])); o >= 1 && o <= 8 {
+				return o
+			}
+			return 1
+		}
+	}
+	return 1
+}
+
+// orient turns an image upright according to its EXIF orientation
+func orient(img image.Image, o int) image.Image {
+	if o <= 1 || o > 8 {
+		return img
+	}
+	src := toRGBA(img, nil)
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	dw, dh := w, h
+	if o >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		for x := 0; x < dw; x++ {
+			var sx, sy int
+			switch o {
+			case 2: // miroir horizontal
+				sx, sy = w-1-x, y
+			case 3: // 180°
+				sx, sy = w-1-x, h-1-y
+			case 4: // miroir vertical
+				sx, sy = x, h-1-y
+			case 5: // transposition
+				sx, sy = y, x
+			case 6: // 90° horaire
+				sx, sy = y, h-1-x
+			case 7: // transversale
+				sx, sy = w-1-y, h-1-x
+			case 8: // 90° anti-horaire
+				sx, sy = w-1-y, x
+			}
+			dst.SetRGBA(x, y, src.RGBAAt(sx, sy))
+		}
+	}
+	return dst
+}
+
+// toRGBA copies an image to an RGBA image starting at (0, 0), over a
+// background colour when set
+func toRGBA(img image.Image, background color.Color) *image.RGBA {
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	if background != nil {
+		draw.Draw(dst, dst.Rect, image.NewUniform(background), image.Point{}, draw.Src)
+	}
+	draw.Draw(dst, dst.Rect, img, b.Min, draw.Over)
+	return dst
+}
+
+// resize scales an image down so that its longest side is at most maxSide,
+// each pixel averaging the pixels it covers. Transparent areas become white.
+func resize(img image.Image, maxSide int) *image.RGBA {
+	src := toRGBA(img, color.White)
+	w, h := src.Rect.Dx(), src.Rect.Dy()
+	if maxSide <= 0 || (w <= maxSide && h <= maxSide) {
+		return src
+	}
+	tw, th := maxSide, max(1, h*maxSide/w)
+	if h > w {
+		tw, th = max(1, w*maxSide/h), maxSide
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		y0, y1 := y*h/th, max((y+1)*h/th, y*h/th+1)
+		for x := 0; x < tw; x++ {
+			x0, x1 := x*w/tw, max((x+1)*w/tw, x*w/tw+1)
+			var r, g, b, n uint32
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					c := src.RGBAAt(sx, sy)
+					r += uint32(c.R)
+					g += uint32(c.G)
+					b += uint32(c.B)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: 255})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,141 @@
+// Package media inspects uploaded files: their type is detected from the
+// content rather than trusted from the client, checked against the types
+// allowed per kind of document, and images are re-encoded without their
+// metadata (EXIF, GPS position) for public copies and thumbnails.
+package media
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+)
+
+// SniffLen is the number of leading bytes read to detect a type
+const SniffLen = 512
+
+// Detected types
+const (
+	TypeJPEG = "image/jpeg"
+	TypePNG  = "image/png"
+	TypeGIF  = "image/gif"
+	TypeWebP = "image/webp"
+	TypePDF  = "application/pdf"
+	TypeText = "text/plain"
+)
+
+var (
+	// ErrTooLarge is returned for a file over the size limit of its kind
+	ErrTooLarge = errors.New("file too large")
+	// ErrTypeNotAllowed is returned for content not accepted for its kind
+	ErrTypeNotAllowed = errors.New("file type not allowed")
+	// ErrInvalidImage is returned for an image that cannot be decoded
+	ErrInvalidImage = errors.New("invalid image")
+)
+
+// extensions are the file extensions of the detected types
+var extensions = map[string]string{
+	TypeJPEG: ".jpg",
+	TypePNG:  ".png",
+	TypeGIF:  ".gif",
+	TypeWebP: ".webp",
+	TypePDF:  ".pdf",
+	TypeText: ".txt",
+}
+
+// defaultAllowed are the types accepted per type_document when the
+// configuration does not list them
+var defaultAllowed = map[string][]string{
+	"PHOTO":       {TypeJPEG, TypePNG},
+	"PERMIS":      {TypeJPEG, TypePNG, TypePDF},
+	"CARTE_GRISE": {TypeJPEG, TypePNG, TypePDF},
+	"ASSURANCE":   {TypeJPEG, TypePNG, TypePDF},
+	"CONSTAT":     {TypeJPEG, TypePNG, TypePDF},
+	"PV":          {TypePDF},
+	"AUTRE":       {TypeJPEG, TypePNG, TypeGIF, TypePDF, TypeText},
+}
+
+// Detect returns the type of a file from its first bytes, without parameters
+// (text/plain rather than text/plain; charset=utf-8)
+func Detect(head []byte) string {
+	detected := http.DetectContentType(head)
+	if i := strings.IndexByte(detected, ';'); i >= 0 {
+		detected = detected[:i]
+	}
+	return strings.TrimSpace(detected)
+}
+
+// Extension returns the file extension of a detected type
+func Extension(mimeType string) string {
+	if ext, ok := extensions[mimeType]; ok {
+		return ext
+	}
+	return ".bin"
+}
+
+// IsImage tells whether a type is an image re-encoded by Process
+func IsImage(mimeType string) bool {
+	return mimeType == TypeJPEG || mimeType == TypePNG || mimeType == TypeGIF
+}
+
+// PublicType returns the type of the public copy of an image
+func PublicType(mimeType string) string {
+	if mimeType == TypeGIF {
+		return TypePNG
+	}
+	return mimeType
+}
+
+// Policy holds the types and sizes accepted per type_document
+type Policy struct {
+	maxSize int64
+	types   map[string]config.UploadTypeConfig
+}
+
+// NewPolicy creates the upload policy of the configuration
+func NewPolicy(cfg config.UploadConfig) *Policy {
+	p := &Policy{maxSize: megabytes(cfg.MaxSizeMB), types: make(map[string]config.UploadTypeConfig)}
+	if p.maxSize <= 0 {
+		p.maxSize = megabytes(10)
+	}
+	// Viper met les clés en minuscules
+	for kind, t := range cfg.Types {
+		p.types[strings.ToUpper(kind)] = t
+	}
+	return p
+}
+
+// MaxSize returns the size limit of a type_document, in bytes
+func (p *Policy) MaxSize(kind string) int64 {
+	if t, ok := p.types[kind]; ok && t.MaxSizeMB > 0 {
+		return megabytes(t.MaxSizeMB)
+	}
+	return p.maxSize
+}
+
+// Allowed returns the types accepted for a type_document
+func (p *Policy) Allowed(kind string) []string {
+	if t, ok := p.types[kind]; ok && len(t.Allowed) > 0 {
+		return t.Allowed
+	}
+	return defaultAllowed[kind]
+}
+
+// Check checks the size and detected type of a file of a type_document
+func (p *Policy) Check(kind string, size int64, mimeType string) error {
+	if limit := p.MaxSize(kind); size > limit {
+		return fmt.Errorf("%w: %d bytes, at most %d MB for %s", ErrTooLarge, size, limit>>20, kind)
+	}
+	for _, allowed := range p.Allowed(kind) {
+		if allowed == mimeType {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s for %s (accepted: %s)", ErrTypeNotAllowed, mimeType, kind, strings.Join(p.Allowed(kind), ", "))
+}
+
+func megabytes(n int) int64 {
+	return int64(n) << 20
+}
@@ -0,0 +1,132 @@
+package media
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetect(t *testing.T) {
+	assert.Equal(t, TypePDF, Detect([]byte("%PDF-1.7\n%\xe2\xe3\xcf\xd3")))
+	assert.Equal(t, TypeText, Detect([]byte("procès-verbal n° 12")))
+	assert.Equal(t, TypePNG, Detect(encodePNG(t, 4, 4)))
+	// Un exécutable annoncé comme image reste un exécutable
+	assert.Equal(t, "application/octet-stream", Detect([]byte("MZ\x90\x00\x03\x00\x00\x00\x04\x00")))
+	assert.Equal(t, ".pdf", Extension(TypePDF))
+	assert.Equal(t, ".bin", Extension("application/x-msdownload"))
+}
+
+func TestPolicy(t *testing.T) {
+	policy := NewPolicy(config.UploadConfig{
+		MaxSizeMB: 10,
+		Types: map[string]config.UploadTypeConfig{
+			"pv": {MaxSizeMB: 20},
+		},
+	})
+	assert.Equal(t, int64(20<<20), policy.MaxSize("PV"))
+	assert.Equal(t, int64(10<<20), policy.MaxSize("PHOTO"))
+
+	assert.NoError(t, policy.Check("PHOTO", 1024, TypeJPEG))
+	assert.ErrorIs(t, policy.Check("PHOTO", 1024, TypePDF), ErrTypeNotAllowed)
+	assert.ErrorIs(t, policy.Check("PHOTO", 11<<20, TypeJPEG), ErrTooLarge)
+	assert.NoError(t, policy.Check("PV", 15<<20, TypePDF))
+	assert.ErrorIs(t, policy.Check("INCONNU", 1024, TypePDF), ErrTypeNotAllowed)
+}
+
+func TestProcess_StripsEXIF(t *testing.T) {
+	// JPEG 40x20 pris téléphone tourné (orientation 6) avec une position GPS
+	original := withEXIF(t, encodeJPEG(t, 40, 20), 6)
+	require.Equal(t, 6, orientation(original))
+
+	processed, err := Process(original, TypeJPEG, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, TypeJPEG, processed.PublicType)
+	assert.NotContains(t, string(processed.Public), "Exif")
+	assert.NotContains(t, string(processed.Public), "GPS")
+	assert.Equal(t, 1, orientation(processed.Public))
+
+	// Image redressée : largeur et hauteur échangées
+	public, err := jpeg.DecodeConfig(bytes.NewReader(processed.Public))
+	require.NoError(t, err)
+	assert.Equal(t, 20, public.Width)
+	assert.Equal(t, 40, public.Height)
+
+	thumbnail, err := jpeg.DecodeConfig(bytes.NewReader(processed.Thumbnail))
+	require.NoError(t, err)
+	assert.Equal(t, 5, thumbnail.Width)
+	assert.Equal(t, 10, thumbnail.Height)
+}
+
+func TestProcess_Limits(t *testing.T) {
+	_, err := Process(encodePNG(t, 100, 100), TypePNG, 10, 5000)
+	assert.ErrorIs(t, err, ErrTooLarge)
+
+	_, err = Process([]byte("\x89PNG\r\n\x1a\nnot an image"), TypePNG, 10, 0)
+	assert.ErrorIs(t, err, ErrInvalidImage)
+
+	processed, err := Process(encodePNG(t, 8, 4), TypePNG, 320, 0)
+	require.NoError(t, err)
+	assert.Equal(t, TypePNG, processed.PublicType)
+	thumbnail, err := jpeg.DecodeConfig(bytes.NewReader(processed.Thumbnail))
+	require.NoError(t, err)
+	assert.Equal(t, 8, thumbnail.Width)
+}
+
+func TestOrient(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 1))
+	img.SetRGBA(0, 0, color.RGBA{R: 255, A: 255})
+	img.SetRGBA(1, 0, color.RGBA{B: 255, A: 255})
+
+	// 90° horaire : le pixel de gauche passe en haut
+	rotated := orient(img, 6).(*image.RGBA)
+	assert.Equal(t, image.Rect(0, 0, 1, 2), rotated.Rect)
+	assert.Equal(t, uint8(255), rotated.RGBAAt(0, 0).R)
+	assert.Equal(t, uint8(255), rotated.RGBAAt(0, 1).B)
+
+	// 90° anti-horaire : le pixel de droite passe en haut
+	rotated = orient(img, 8).(*image.RGBA)
+	assert.Equal(t, uint8(255), rotated.RGBAAt(0, 0).B)
+}
+
+func encodeJPEG(t *testing.T, w, h int) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, w, h)), nil))
+	return buf.Bytes()
+}
+
+func encodePNG(t *testing.T, w, h int) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, w, h))))
+	return buf.Bytes()
+}
+
+// withEXIF inserts after the SOI marker an APP1 segment holding an
+// orientation tag and a GPS marker
+func withEXIF(t *testing.T, data []byte, o uint16) []byte {
+	tiff := []byte("II*\x00\x08\x00\x00\x00")
+	tiff = binary.LittleEndian.AppendUint16(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint16(tiff, o)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+	tiff = append(tiff, "GPS 5.3364N 4.0267W"...)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1}
+	segment = binary.BigEndian.AppendUint16(segment, uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	require.Equal(t, []byte{0xFF, 0xD8}, data[:2])
+	out := append([]byte{0xFF, 0xD8}, segment...)
+	return append(out, data[2:]...)
+}
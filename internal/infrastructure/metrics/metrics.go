@@ -0,0 +1,148 @@
+// Package metrics exposes the application metrics in the Prometheus text
+// format on /metrics: HTTP requests per route, the database connection pool,
+// the Go runtime and domain counters incremented by the services.
+package metrics
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"net/http"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Default is the registry served on /metrics
+var Default = NewRegistry()
+
+// HTTP metrics, recorded by Middleware
+var (
+	httpRequests = Default.NewCounter("http_requests_total",
+		"HTTP requests by method, route and status code.", "method", "route", "status")
+	httpDuration = Default.NewHistogram("http_request_duration_seconds",
+		"HTTP request latency by method and route.", DefaultBuckets, "method", "route")
+	httpInFlight atomic.Int64
+)
+
+// Domain metrics
+var (
+	PVEmitted = Default.NewCounter("pv_emitted_total",
+		"Procès-verbaux emitted, by origin (pv, controle, infraction).", "source")
+	PaiementsValidated = Default.NewCounter("paiements_validated_total",
+		"Payments validated, by payment method.", "moyen_paiement")
+	AlertesBroadcast = Default.NewCounter("alertes_broadcast_total",
+		"Security alerts broadcast to agents.")
+	AIGenerations = Default.NewCounter("ai_generation_calls_total",
+		"Calls to the text generation API, by kind (description, rapport).", "kind")
+	AIGenerationFailures = Default.NewCounter("ai_generation_failures_total",
+		"Failed calls to the text generation API, by kind.", "kind")
+)
+
+func init() {
+	Default.NewGaugeFunc("http_requests_in_flight", "HTTP requests being served.", func() float64 {
+		return float64(httpInFlight.Load())
+	})
+	Default.NewGaugeFunc("go_goroutines", "Number of goroutines.", func() float64 {
+		return float64(runtime.NumGoroutine())
+	})
+	Default.NewGaugeFunc("go_memstats_heap_alloc_bytes", "Bytes of allocated heap objects.", func() float64 {
+		var m runtime.MemStats
+		runtime.ReadMemStats(&m)
+		return float64(m.HeapAlloc)
+	})
+	start := time.Now()
+	Default.NewGaugeFunc("process_start_time_seconds", "Start time of the process since the Unix epoch.", func() float64 {
+		return float64(start.Unix())
+	})
+}
+
+// ObserveAIGeneration counts a call to the text generation API and its failure
+func ObserveAIGeneration(kind string, err error) {
+	AIGenerations.Inc(kind)
+	if err != nil {
+		AIGenerationFailures.Inc(kind)
+	}
+}
+
+// RegisterDB exposes the statistics of a database connection pool
+func RegisterDB(stats func() sql.DBStats) {
+	gauges := map[string]struct {
+		help  string
+		value func(sql.DBStats) float64
+	}{
+		"db_connections_max_open": {"Maximum number of open connections to the database.", func(s sql.DBStats) float64 { return float64(s.MaxOpenConnections) }},
+		"db_connections_open":     {"Established connections, in use and idle.", func(s sql.DBStats) float64 { return float64(s.OpenConnections) }},
+		"db_connections_in_use":   {"Connections currently in use.", func(s sql.DBStats) float64 { return float64(s.InUse) }},
+		"db_connections_idle":     {"Idle connections.", func(s sql.DBStats) float64 { return float64(s.Idle) }},
+	}
+	for name, g := range gauges {
+		Default.NewGaugeFunc(name, g.help, func() float64 { return g.value(stats()) })
+	}
+
+	counters := map[string]struct {
+		help  string
+		value func(sql.DBStats) float64
+	}{
+		"db_wait_count_total":            {"Connections waited for.", func(s sql.DBStats) float64 { return float64(s.WaitCount) }},
+		"db_wait_duration_seconds_total": {"Time blocked waiting for a new connection.", func(s sql.DBStats) float64 { return s.WaitDuration.Seconds() }},
+		"db_max_idle_closed_total":       {"Connections closed due to SetMaxIdleConns.", func(s sql.DBStats) float64 { return float64(s.MaxIdleClosed) }},
+		"db_max_lifetime_closed_total":   {"Connections closed due to SetConnMaxLifetime.", func(s sql.DBStats) float64 { return float64(s.MaxLifetimeClosed) }},
+	}
+	for name, c := range counters {
+		Default.NewCounterFunc(name, c.help, func() float64 { return c.value(stats()) })
+	}
+}
+
+// Middleware records the count and latency of the requests per route. The
+// route is the registered pattern (/api/v1/pv/:id), never the raw path, so
+// that identifiers do not create series; unknown paths share one route.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			httpInFlight.Add(1)
+			defer httpInFlight.Add(-1)
+
+			start := time.Now()
+			err := next(c)
+
+			status := c.Response().Status
+			if err != nil {
+				// L'erreur n'est écrite qu'après le middleware, par le gestionnaire d'erreurs d'Echo
+				var he *echo.HTTPError
+				if errors.As(err, &he) {
+					status = he.Code
+				} else if !c.Response().Committed {
+					status = http.StatusInternalServerError
+				}
+			}
+			route := c.Path()
+			if route == "" || (status == http.StatusNotFound && route == "/*") {
+				route = "unmatched"
+			}
+			method := c.Request().Method
+
+			httpRequests.Inc(method, route, strconv.Itoa(status))
+			httpDuration.Observe(time.Since(start).Seconds(), method, route)
+			return err
+		}
+	}
+}
+
+// Handler serves the default registry. When token is set, scrapers must send
+// it as a bearer token.
+func Handler(token string) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if token != "" {
+			expected := "Bearer " + token
+			if subtle.ConstantTimeCompare([]byte(c.Request().Header.Get(echo.HeaderAuthorization)), []byte(expected)) != 1 {
+				return c.NoContent(http.StatusUnauthorized)
+			}
+		}
+		Default.ServeHTTP(c.Response(), c.Request())
+		return nil
+	}
+}
@@ -0,0 +1,274 @@
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultBuckets are the latency buckets of the histograms, in seconds
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// family is a metric and its series, written in the text exposition format
+type family interface {
+	name() string
+	write(w *bufio.Writer)
+}
+
+// Registry holds metrics and exposes them in the Prometheus text format
+type Registry struct {
+	mu       sync.RWMutex
+	families map[string]family
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]family)}
+}
+
+// register adds a metric, replacing the one with the same name (a collector
+// registered again after a restart of the application)
+func (r *Registry) register(f family) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.families[f.name()] = f
+}
+
+// Write writes every metric, sorted by name
+func (r *Registry) Write(w io.Writer) error {
+	r.mu.RLock()
+	names := make([]string, 0, len(r.families))
+	for name := range r.families {
+		names = append(names, name)
+	}
+	families := make([]family, len(names))
+	sort.Strings(names)
+	for i, name := range names {
+		families[i] = r.families[name]
+	}
+	r.mu.RUnlock()
+
+	bw := bufio.NewWriter(w)
+	for _, f := range families {
+		f.write(bw)
+	}
+	return bw.Flush()
+}
+
+// ServeHTTP serves the metrics to a Prometheus scraper
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = r.Write(w)
+}
+
+// desc describes a metric
+type desc struct {
+	Name   string
+	Help   string
+	Labels []string
+}
+
+func (d *desc) name() string {
+	return d.Name
+}
+
+func (d *desc) header(w *bufio.Writer, kind string) {
+	w.WriteString("# HELP " + d.Name + " " + strings.NewReplacer(`\`, `\\`, "\n", `\n`).Replace(d.Help) + "\n")
+	w.WriteString("# TYPE " + d.Name + " " + kind + "\n")
+}
+
+// series writes one sample: name{labels} value
+func (d *desc) series(w *bufio.Writer, name string, values []string, extra string, v float64) {
+	w.WriteString(name)
+	if len(values) > 0 || extra != "" {
+		w.WriteByte('{')
+		for i, label := range d.Labels {
+			if i > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(label + `="` + escapeLabel(values[i]) + `"`)
+		}
+		if extra != "" {
+			if len(values) > 0 {
+				w.WriteByte(',')
+			}
+			w.WriteString(extra)
+		}
+		w.WriteByte('}')
+	}
+	w.WriteString(" " + formatValue(v) + "\n")
+}
+
+// key identifies the series of label values, checking their number
+func (d *desc) key(values []string) string {
+	if len(values) != len(d.Labels) {
+		panic("metrics: " + d.Name + " expects " + strconv.Itoa(len(d.Labels)) + " label values")
+	}
+	return strings.Join(values, "\xff")
+}
+
+func escapeLabel(v string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(v)
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// sortedKeys returns the keys of the series in a stable order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Counter is a monotonically increasing metric, by label values
+type Counter struct {
+	desc
+	mu     sync.Mutex
+	values map[string]*sample
+}
+
+type sample struct {
+	labels []string
+	value  float64
+}
+
+// NewCounter registers a counter
+func (r *Registry) NewCounter(name, help string, labels ...string) *Counter {
+	c := &Counter{desc: desc{Name: name, Help: help, Labels: labels}, values: make(map[string]*sample)}
+	r.register(c)
+	return c
+}
+
+// Inc adds one to the series of the label values
+func (c *Counter) Inc(labels ...string) {
+	c.Add(1, labels...)
+}
+
+// Add adds a positive value to the series of the label values
+func (c *Counter) Add(v float64, labels ...string) {
+	if v < 0 {
+		return
+	}
+	key := c.key(labels)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s, ok := c.values[key]
+	if !ok {
+		s = &sample{labels: append([]string(nil), labels...)}
+		c.values[key] = s
+	}
+	s.value += v
+}
+
+func (c *Counter) write(w *bufio.Writer) {
+	c.header(w, "counter")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.Labels) == 0 && len(c.values) == 0 {
+		c.series(w, c.Name, nil, "", 0)
+	}
+	for _, key := range sortedKeys(c.values) {
+		s := c.values[key]
+		c.series(w, c.Name, s.labels, "", s.value)
+	}
+}
+
+// Histogram counts observations in buckets, by label values
+type Histogram struct {
+	desc
+	buckets []float64
+	mu      sync.Mutex
+	values  map[string]*distribution
+}
+
+type distribution struct {
+	labels []string
+	counts []uint64 // per bucket, not cumulative
+	count  uint64
+	sum    float64
+}
+
+// NewHistogram registers a histogram with the upper bounds of its buckets
+func (r *Registry) NewHistogram(name, help string, buckets []float64, labels ...string) *Histogram {
+	h := &Histogram{
+		desc:    desc{Name: name, Help: help, Labels: labels},
+		buckets: append([]float64(nil), buckets...),
+		values:  make(map[string]*distribution),
+	}
+	sort.Float64s(h.buckets)
+	r.register(h)
+	return h
+}
+
+// Observe adds an observation to the series of the label values
+func (h *Histogram) Observe(v float64, labels ...string) {
+	key := h.key(labels)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	d, ok := h.values[key]
+	if !ok {
+		d = &distribution{labels: append([]string(nil), labels...), counts: make([]uint64, len(h.buckets))}
+		h.values[key] = d
+	}
+	if i := sort.SearchFloat64s(h.buckets, v); i < len(h.buckets) {
+		d.counts[i]++
+	}
+	d.count++
+	d.sum += v
+}
+
+func (h *Histogram) write(w *bufio.Writer) {
+	h.header(w, "histogram")
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, key := range sortedKeys(h.values) {
+		d := h.values[key]
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += d.counts[i]
+			h.series(w, h.Name+"_bucket", d.labels, `le="`+formatValue(bound)+`"`, float64(cumulative))
+		}
+		h.series(w, h.Name+"_bucket", d.labels, `le="+Inf"`, float64(d.count))
+		h.series(w, h.Name+"_sum", d.labels, "", d.sum)
+		h.series(w, h.Name+"_count", d.labels, "", float64(d.count))
+	}
+}
+
+// funcMetric is a metric read when scraped
+type funcMetric struct {
+	desc
+	kind  string
+	value func() float64
+}
+
+// NewGaugeFunc registers a gauge whose value is read when scraped
+func (r *Registry) NewGaugeFunc(name, help string, value func() float64) {
+	r.register(&funcMetric{desc: desc{Name: name, Help: help}, kind: "gauge", value: value})
+}
+
+// NewCounterFunc registers a counter maintained elsewhere (database pool
+// statistics), read when scraped
+func (r *Registry) NewCounterFunc(name, help string, value func() float64) {
+	r.register(&funcMetric{desc: desc{Name: name, Help: help}, kind: "counter", value: value})
+}
+
+func (f *funcMetric) write(w *bufio.Writer) {
+	f.header(w, f.kind)
+	f.series(w, f.Name, nil, "", f.value())
+}
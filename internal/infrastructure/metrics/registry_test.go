@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry_Counter(t *testing.T) {
+	r := NewRegistry()
+	c := r.NewCounter("paiements_validated_total", "Payments validated.", "moyen_paiement")
+	c.Inc("ORANGE_MONEY")
+	c.Inc("ESPECES")
+	c.Add(2, "ORANGE_MONEY")
+	r.NewCounter("alertes_broadcast_total", "Alerts broadcast.")
+
+	var out strings.Builder
+	require.NoError(t, r.Write(&out))
+	assert.Equal(t, `# HELP alertes_broadcast_total Alerts broadcast.
+# TYPE alertes_broadcast_total counter
+alertes_broadcast_total 0
+# HELP paiements_validated_total Payments validated.
+# TYPE paiements_validated_total counter
+paiements_validated_total{moyen_paiement="ESPECES"} 1
+paiements_validated_total{moyen_paiement="ORANGE_MONEY"} 3
+`, out.String())
+
+	assert.Panics(t, func() { c.Inc() })
+}
+
+func TestRegistry_Histogram(t *testing.T) {
+	r := NewRegistry()
+	h := r.NewHistogram("http_request_duration_seconds", "Latency.", []float64{0.1, 1}, "route")
+	h.Observe(0.05, "/api/v1/pv/:id")
+	h.Observe(0.1, "/api/v1/pv/:id")
+	h.Observe(3, "/api/v1/pv/:id")
+
+	var out strings.Builder
+	require.NoError(t, r.Write(&out))
+	assert.Equal(t, `# HELP http_request_duration_seconds Latency.
+# TYPE http_request_duration_seconds histogram
+http_request_duration_seconds_bucket{route="/api/v1/pv/:id",le="0.1"} 2
+http_request_duration_seconds_bucket{route="/api/v1/pv/:id",le="1"} 2
+http_request_duration_seconds_bucket{route="/api/v1/pv/:id",le="+Inf"} 3
+http_request_duration_seconds_sum{route="/api/v1/pv/:id"} 3.15
+http_request_duration_seconds_count{route="/api/v1/pv/:id"} 3
+`, out.String())
+}
@@ -0,0 +1,8 @@
+package mfa
+
+import "go.uber.org/fx"
+
+// Module provides the TOTP second factor service
+var Module = fx.Module("mfa",
+	fx.Provide(NewService),
+)
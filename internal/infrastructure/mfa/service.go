@@ -0,0 +1,382 @@
+package mfa
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/crypto"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/totp"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Second factor methods, recorded on the sessions they opened
+const (
+	MethodTOTP         = "totp"
+	MethodRecoveryCode = "recovery_code"
+	MethodOIDC         = "oidc" // Verified by the OpenID Connect provider
+)
+
+const (
+	recoveryCodeCount    = 10
+	recoveryCodeLength   = 10
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789" // sans 0/o, 1/i/l
+
+	// secretPurpose derives the key encrypting the TOTP secrets from jwt.secret
+	secretPurpose = "totp-secrets"
+)
+
+var (
+	// ErrNotEnabled is returned when the user has no confirmed second factor
+	ErrNotEnabled = errors.New("two-factor authentication is not enabled")
+	// ErrAlreadyEnabled is returned by BeginEnrollment once the enrolment is confirmed
+	ErrAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	// ErrNoPendingEnrollment is returned by ConfirmEnrollment without BeginEnrollment
+	ErrNoPendingEnrollment = errors.New("no pending two-factor enrolment")
+	// ErrInvalidCode is returned for a wrong, expired or already used code
+	ErrInvalidCode = errors.New("invalid two-factor code")
+	// ErrRequired is returned by Disable for the roles that must use a second factor
+	ErrRequired = errors.New("two-factor authentication is mandatory for this role")
+)
+
+// Enrollment holds what the user needs to configure an authenticator application
+type Enrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"` // otpauth:// URI, to display as a QR code
+}
+
+// Status describes the second factor of a user
+type Status struct {
+	Enabled           bool       `json:"enabled"`
+	Pending           bool       `json:"pending"` // enrolment started but not confirmed
+	Required          bool       `json:"required"`
+	ConfirmedAt       *time.Time `json:"confirmed_at,omitempty"`
+	RecoveryCodesLeft int        `json:"recovery_codes_left"`
+}
+
+// Service manages TOTP enrolment and verification
+type Service interface {
+	// Required tells whether the role must use a second factor
+	Required(role string) bool
+	Status(ctx context.Context, userID uuid.UUID, role string) (*Status, error)
+	BeginEnrollment(ctx context.Context, userID uuid.UUID, account string) (*Enrollment, error)
+	// ConfirmEnrollment activates the second factor and returns the recovery codes,
+	// shown to the user only once
+	ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	// Verify checks a TOTP code or a recovery code and returns the method used
+	Verify(ctx context.Context, userID uuid.UUID, code, recoveryCode string) (string, error)
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	Disable(ctx context.Context, userID uuid.UUID, role, code string) error
+	// Reset removes the second factor without a code, for administrators
+	Reset(ctx context.Context, userID uuid.UUID) error
+}
+
+type service struct {
+	mfaRepo         repository.MFARepository
+	passwordService crypto.Service
+	sealKey         string // jwt.secret, encrypts the TOTP secrets at rest
+	issuer          string
+	requiredRoles   map[string]bool
+	logger          *zap.Logger
+}
+
+// NewService creates a new second factor service
+func NewService(mfaRepo repository.MFARepository, passwordService crypto.Service, cfg *config.Config, logger *zap.Logger) Service {
+	required := make(map[string]bool, len(cfg.Auth.MFA.RequiredRoles))
+	for _, role := range cfg.Auth.MFA.RequiredRoles {
+		required[role] = true
+	}
+
+	return &service{
+		mfaRepo:         mfaRepo,
+		passwordService: passwordService,
+		sealKey:         cfg.JWT.Secret,
+		issuer:          cfg.Auth.MFA.Issuer,
+		requiredRoles:   required,
+		logger:          logger,
+	}
+}
+
+func (s *service) Required(role string) bool {
+	return s.requiredRoles[role]
+}
+
+func (s *service) Status(ctx context.Context, userID uuid.UUID, role string) (*Status, error) {
+	status := &Status{Required: s.Required(role)}
+
+	m, err := s.mfaRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return status, nil
+	}
+
+	status.Enabled = m.ConfirmedAt != nil
+	status.Pending = m.ConfirmedAt == nil
+	status.ConfirmedAt = m.ConfirmedAt
+
+	if status.Enabled {
+		codes, err := s.mfaRepo.ListUnusedRecoveryCodes(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		status.RecoveryCodesLeft = len(codes)
+	}
+
+	return status, nil
+}
+
+func (s *service) BeginEnrollment(ctx context.Context, userID uuid.UUID, account string) (*Enrollment, error) {
+	m, err := s.mfaRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if m != nil && m.ConfirmedAt != nil {
+		return nil, ErrAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := crypto.Seal([]byte(secret), s.sealKey, secretPurpose)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt TOTP secret: %w", err)
+	}
+	if err := s.mfaRepo.SavePending(ctx, userID, sealed); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Two-factor enrolment started", zap.String("user_id", userID.String()))
+
+	return &Enrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(secret, s.issuer, account),
+	}, nil
+}
+
+func (s *service) ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	m, err := s.mfaRepo.Get(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if m == nil {
+		return nil, ErrNoPendingEnrollment
+	}
+	if m.ConfirmedAt != nil {
+		return nil, ErrAlreadyEnabled
+	}
+
+	secret, err := s.secret(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidCode
+	}
+
+	codes, hashes, err := s.newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.mfaRepo.Confirm(ctx, userID, step, hashes); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Two-factor authentication enabled", zap.String("user_id", userID.String()))
+	return codes, nil
+}
+
+func (s *service) Verify(ctx context.Context, userID uuid.UUID, code, recoveryCode string) (string, error) {
+	m, err := s.mfaRepo.Get(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if m == nil || m.ConfirmedAt == nil {
+		return "", ErrNotEnabled
+	}
+
+	if recoveryCode != "" {
+		if err := s.useRecoveryCode(ctx, userID, recoveryCode); err != nil {
+			return "", err
+		}
+		return MethodRecoveryCode, nil
+	}
+
+	secret, err := s.secret(ctx, m)
+	if err != nil {
+		return "", err
+	}
+	step, ok := totp.Validate(secret, code, time.Now())
+	if !ok {
+		return "", ErrInvalidCode
+	}
+
+	fresh, err := s.mfaRepo.UseStep(ctx, userID, step)
+	if err != nil {
+		return "", err
+	}
+	if !fresh {
+		s.logger.Warn("TOTP code replayed", zap.String("user_id", userID.String()))
+		return "", ErrInvalidCode
+	}
+
+	return MethodTOTP, nil
+}
+
+func (s *service) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	if _, err := s.Verify(ctx, userID, code, ""); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := s.newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.mfaRepo.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Recovery codes regenerated", zap.String("user_id", userID.String()))
+	return codes, nil
+}
+
+func (s *service) Disable(ctx context.Context, userID uuid.UUID, role, code string) error {
+	if s.Required(role) {
+		return ErrRequired
+	}
+	if _, err := s.Verify(ctx, userID, code, ""); err != nil {
+		return err
+	}
+
+	if err := s.mfaRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Two-factor authentication disabled", zap.String("user_id", userID.String()))
+	return nil
+}
+
+func (s *service) Reset(ctx context.Context, userID uuid.UUID) error {
+	if err := s.mfaRepo.Delete(ctx, userID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Two-factor authentication reset", zap.String("user_id", userID.String()))
+	return nil
+}
+
+func (s *service) useRecoveryCode(ctx context.Context, userID uuid.UUID, recoveryCode string) error {
+	normalized := normalizeRecoveryCode(recoveryCode)
+	if normalized == "" {
+		return ErrInvalidCode
+	}
+
+	codes, err := s.mfaRepo.ListUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range codes {
+		if s.passwordService.CheckPassword(normalized, c.CodeHash) != nil {
+			continue
+		}
+
+		used, err := s.mfaRepo.UseRecoveryCode(ctx, c.ID)
+		if err != nil {
+			return err
+		}
+		if !used {
+			break // utilisé par une connexion concurrente
+		}
+
+		s.logger.Info("Recovery code used",
+			zap.String("user_id", userID.String()),
+			zap.Int("remaining", len(codes)-1),
+		)
+		return nil
+	}
+
+	return ErrInvalidCode
+}
+
+// secret decrypts the TOTP secret of an enrolment. A secret stored in clear
+// before the secrets were encrypted is encrypted on first use.
+func (s *service) secret(ctx context.Context, m *ent.UserMFA) (string, error) {
+	secret, err := crypto.Open(m.Secret, s.sealKey, secretPurpose)
+	if err == nil {
+		return string(secret), nil
+	}
+
+	if _, codeErr := totp.Code(m.Secret, time.Now()); codeErr != nil {
+		return "", fmt.Errorf("failed to decrypt TOTP secret: %w", err)
+	}
+	sealed, err := crypto.Seal([]byte(m.Secret), s.sealKey, secretPurpose)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt TOTP secret: %w", err)
+	}
+	if err := s.mfaRepo.UpdateSecret(ctx, m.UserID, sealed); err != nil {
+		// Le secret reste utilisable, il sera chiffré à la prochaine vérification
+		s.logger.Warn("Failed to encrypt stored TOTP secret",
+			zap.String("user_id", m.UserID.String()),
+			zap.Error(err),
+		)
+	}
+	return m.Secret, nil
+}
+
+// newRecoveryCodes returns the codes to show and their hashes to store
+func (s *service) newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+
+	for i := range codes {
+		code, err := randomCode(recoveryCodeLength)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+
+		hash, err := s.passwordService.HashPassword(code)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		codes[i] = code[:recoveryCodeLength/2] + "-" + code[recoveryCodeLength/2:]
+		hashes[i] = hash
+	}
+
+	return codes, hashes, nil
+}
+
+func randomCode(length int) (string, error) {
+	max := big.NewInt(int64(len(recoveryCodeAlphabet)))
+
+	var b strings.Builder
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(recoveryCodeAlphabet[n.Int64()])
+	}
+
+	return b.String(), nil
+}
+
+// normalizeRecoveryCode accepts the code as displayed, in any case, with or
+// without separators
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
@@ -0,0 +1,61 @@
+package mfa
+
+import (
+	"strings"
+	"testing"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+
+	"go.uber.org/zap"
+)
+
+func TestNormalizeRecoveryCode(t *testing.T) {
+	tests := map[string]string{
+		"abcde-fghjk":   "abcdefghjk",
+		"ABCDE-FGHJK":   "abcdefghjk",
+		" abcde fghjk ": "abcdefghjk",
+		"abcdefghjk":    "abcdefghjk",
+		"-":             "",
+	}
+
+	for input, want := range tests {
+		if got := normalizeRecoveryCode(input); got != want {
+			t.Errorf("normalizeRecoveryCode(%q) = %q, want %q", input, got, want)
+		}
+	}
+}
+
+func TestRandomCode(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 50; i++ {
+		code, err := randomCode(recoveryCodeLength)
+		if err != nil {
+			t.Fatalf("randomCode: %v", err)
+		}
+		if len(code) != recoveryCodeLength {
+			t.Fatalf("code %q has length %d, want %d", code, len(code), recoveryCodeLength)
+		}
+		for _, r := range code {
+			if !strings.ContainsRune(recoveryCodeAlphabet, r) {
+				t.Fatalf("code %q contains %q, outside the alphabet", code, r)
+			}
+		}
+		if seen[code] {
+			t.Fatalf("code %q generated twice", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestRequired(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Auth.MFA.RequiredRoles = []string{"admin", "supervisor"}
+
+	s := NewService(nil, nil, cfg, zap.NewNop())
+
+	for role, want := range map[string]bool{"admin": true, "supervisor": true, "agent": false, "": false} {
+		if got := s.Required(role); got != want {
+			t.Errorf("Required(%q) = %v, want %v", role, got, want)
+		}
+	}
+}
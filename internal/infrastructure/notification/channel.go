@@ -0,0 +1,25 @@
+package notification
+
+import "context"
+
+// Channel types
+const (
+	ChannelSMS   = "SMS"
+	ChannelEmail = "EMAIL"
+	ChannelPush  = "PUSH"
+)
+
+// Message is a rendered notification ready to be delivered
+type Message struct {
+	Channel   string
+	Recipient string
+	Subject   string
+	Body      string
+}
+
+// Channel delivers messages through one provider (SMTP server, SMS gateway, ...)
+type Channel interface {
+	// Provider identifies the adapter in the delivery history
+	Provider() string
+	Send(ctx context.Context, msg *Message) error
+}
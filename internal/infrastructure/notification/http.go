@@ -0,0 +1,80 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+)
+
+const defaultHTTPTimeout = 10 * time.Second
+
+// httpChannel posts messages as JSON to an HTTP gateway (SMS aggregator, push service)
+type httpChannel struct {
+	config config.HTTPChannelConfig
+	client *http.Client
+}
+
+// httpPayload is the body sent to the gateway
+type httpPayload struct {
+	To      string `json:"to"`
+	From    string `json:"from,omitempty"`
+	Subject string `json:"subject,omitempty"`
+	Message string `json:"message"`
+}
+
+// NewHTTPChannel creates a channel backed by an HTTP gateway
+func NewHTTPChannel(cfg config.HTTPChannelConfig) Channel {
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeout
+	}
+	return &httpChannel{
+		config: cfg,
+		client: &http.Client{Timeout: timeout, Transport: tracing.Transport()},
+	}
+}
+
+func (c *httpChannel) Provider() string {
+	return "http"
+}
+
+func (c *httpChannel) Send(ctx context.Context, msg *Message) error {
+	payload, err := json.Marshal(httpPayload{
+		To:      msg.Recipient,
+		From:    c.config.Sender,
+		Subject: msg.Subject,
+		Message: msg.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.URL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("gateway request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("gateway returned %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+
+	return nil
+}
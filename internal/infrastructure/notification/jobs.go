@@ -0,0 +1,24 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/scheduler"
+)
+
+// NewRetryJob requeues notifications whose next delivery attempt is due
+func NewRetryJob(service Service) scheduler.Job {
+	return scheduler.Job{
+		Name:        "notification-retry",
+		Description: "Relance l'envoi des notifications en échec ou en attente",
+		Schedule:    "* * * * *",
+		Run: func(ctx context.Context) (string, error) {
+			queued, err := service.RetryDue(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d notifications remises en file", queued), nil
+		},
+	}
+}
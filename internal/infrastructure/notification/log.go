@@ -0,0 +1,74 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// logChannel is the development stand-in: messages are logged and, if a file
+// is configured, appended to it as JSON lines instead of being delivered
+type logChannel struct {
+	path   string
+	logger *zap.Logger
+	mu     sync.Mutex
+}
+
+// NewLogChannel creates the log stand-in channel
+func NewLogChannel(path string, logger *zap.Logger) Channel {
+	return &logChannel{
+		path:   path,
+		logger: logger,
+	}
+}
+
+func (c *logChannel) Provider() string {
+	return "log"
+}
+
+func (c *logChannel) Send(ctx context.Context, msg *Message) error {
+	c.logger.Info("Notification (stand-in)",
+		zap.String("channel", msg.Channel),
+		zap.String("recipient", msg.Recipient),
+		zap.String("subject", msg.Subject),
+		zap.String("body", msg.Body),
+	)
+
+	if c.path == "" {
+		return nil
+	}
+
+	line, err := json.Marshal(map[string]interface{}{
+		"date":      time.Now().Format(time.RFC3339),
+		"channel":   msg.Channel,
+		"recipient": msg.Recipient,
+		"subject":   msg.Subject,
+		"body":      msg.Body,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return fmt.Errorf("failed to create notification log directory: %w", err)
+	}
+	f, err := os.OpenFile(c.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open notification log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write notification log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package notification
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+)
+
+// Module provides the notification service and its retry job
+var Module = fx.Module("notification",
+	fx.Provide(
+		NewService,
+		fx.Annotate(
+			NewRetryJob,
+			fx.ResultTags(`group:"jobs"`),
+		),
+	),
+	fx.Invoke(func(lc fx.Lifecycle, s Service) {
+		lc.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				return s.Start(ctx)
+			},
+			OnStop: func(ctx context.Context) error {
+				return s.Stop(ctx)
+			},
+		})
+	}),
+)
@@ -0,0 +1,353 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultMaxAttempts = 5
+	defaultWorkers     = 2
+	queueSize          = 256
+	// sendTimeout bounds one delivery attempt; the claim lease is slightly longer
+	sendTimeout  = 30 * time.Second
+	claimLease   = 2 * time.Minute
+	retryBatch   = 100
+	storeTimeout = 10 * time.Second
+	// redacted replaces the secrets in the stored subject and body
+	redacted = "[non conservé]"
+)
+
+// retryDelays is the backoff between attempts; the last delay is reused
+var retryDelays = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	15 * time.Minute,
+	time.Hour,
+}
+
+// ErrNoRecipient is returned when a notification has no recipient address
+var ErrNoRecipient = errors.New("notification recipient is required")
+
+// errSecretLost ends a notification whose secrets are no longer held in memory
+var errSecretLost = errors.New("notification secrets not available on this instance, not resent")
+
+// SendRequest describes a notification to send from a template
+type SendRequest struct {
+	Channel      string
+	Recipient    string
+	Template     string
+	Data         map[string]interface{}
+	Secrets      map[string]interface{} // Template data sent but masked in the stored notification (tokens, links)
+	ResourceType string                 // Business resource the notification relates to (e.g. "pv")
+	ResourceID   string
+}
+
+// Service persists notifications and delivers them asynchronously. Every send
+// is stored first, so failed deliveries are retried by the "notification-retry"
+// job even after a restart. Notifications carrying secrets are the exception:
+// the secrets only live in the memory of the instance that created them.
+type Service interface {
+	Send(ctx context.Context, req *SendRequest) (*ent.Notification, error)
+	RetryDue(ctx context.Context) (int, error)
+	ListByResource(ctx context.Context, resourceType, resourceID string) ([]*ent.Notification, error)
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+type service struct {
+	repo        repository.NotificationRepository
+	channels    map[string]Channel
+	maxAttempts int
+	workers     int
+	logger      *zap.Logger
+
+	queue   chan *ent.Notification
+	secrets sync.Map // notification ID -> *Message rendered with its secrets
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewService creates the notification service. Channels without configuration
+// fall back to the log stand-in so development setups need no provider.
+func NewService(
+	repo repository.NotificationRepository,
+	cfg *config.Config,
+	logger *zap.Logger,
+) Service {
+	ncfg := cfg.Notification
+	fallback := NewLogChannel(ncfg.LogFile, logger)
+
+	channels := map[string]Channel{
+		ChannelEmail: fallback,
+		ChannelSMS:   fallback,
+		ChannelPush:  fallback,
+	}
+	if ncfg.SMTP.Host != "" {
+		channels[ChannelEmail] = NewSMTPChannel(ncfg.SMTP)
+	}
+	if ncfg.SMS.URL != "" {
+		channels[ChannelSMS] = NewHTTPChannel(ncfg.SMS)
+	}
+	if ncfg.Push.URL != "" {
+		channels[ChannelPush] = NewHTTPChannel(ncfg.Push)
+	}
+
+	maxAttempts := ncfg.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	workers := ncfg.Workers
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+
+	return &service{
+		repo:        repo,
+		channels:    channels,
+		maxAttempts: maxAttempts,
+		workers:     workers,
+		logger:      logger,
+		queue:       make(chan *ent.Notification, queueSize),
+	}
+}
+
+// Send renders the template, stores the notification and queues its delivery
+func (s *service) Send(ctx context.Context, req *SendRequest) (*ent.Notification, error) {
+	if req.Recipient == "" {
+		return nil, ErrNoRecipient
+	}
+	if _, ok := s.channels[req.Channel]; !ok {
+		return nil, fmt.Errorf("unsupported notification channel %q", req.Channel)
+	}
+
+	// Le message stocké (et visible dans l'historique) ne contient pas les secrets
+	subject, body, err := Render(req.Template, withSecrets(req.Data, req.Secrets, true))
+	if err != nil {
+		return nil, err
+	}
+
+	n, err := s.repo.Create(ctx, &repository.CreateNotificationInput{
+		Channel:      req.Channel,
+		Recipient:    req.Recipient,
+		Template:     req.Template,
+		Subject:      subject,
+		Body:         body,
+		MaxAttempts:  s.maxAttempts,
+		ResourceType: req.ResourceType,
+		ResourceID:   req.ResourceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(req.Secrets) > 0 {
+		subject, body, err := Render(req.Template, withSecrets(req.Data, req.Secrets, false))
+		if err != nil {
+			return nil, err
+		}
+		s.secrets.Store(n.ID, &Message{Subject: subject, Body: body})
+	}
+
+	s.enqueue(n)
+	return n, nil
+}
+
+// withSecrets merges the secrets into the template data, masked when the
+// result is stored. Empty values stay empty for the template conditions.
+func withSecrets(data, secrets map[string]interface{}, mask bool) map[string]interface{} {
+	if len(secrets) == 0 {
+		return data
+	}
+
+	merged := make(map[string]interface{}, len(data)+len(secrets))
+	for k, v := range data {
+		merged[k] = v
+	}
+	for k, v := range secrets {
+		if mask && v != nil && v != "" {
+			v = redacted
+		}
+		merged[k] = v
+	}
+	return merged
+}
+
+// enqueue hands a notification to the workers; when the queue is full the
+// retry job picks it up later
+func (s *service) enqueue(n *ent.Notification) {
+	select {
+	case s.queue <- n:
+	default:
+		s.logger.Warn("Notification queue full, delivery deferred", zap.String("id", n.ID.String()))
+	}
+}
+
+// RetryDue queues the notifications whose next attempt is due
+func (s *service) RetryDue(ctx context.Context) (int, error) {
+	due, err := s.repo.ListDue(ctx, time.Now(), retryBatch)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, n := range due {
+		s.enqueue(n)
+	}
+	return len(due), nil
+}
+
+// ListByResource lists the notifications sent for a business resource
+func (s *service) ListByResource(ctx context.Context, resourceType, resourceID string) ([]*ent.Notification, error) {
+	return s.repo.ListByResource(ctx, resourceType, resourceID)
+}
+
+// Start launches the delivery workers
+func (s *service) Start(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+
+	for i := 0; i < s.workers; i++ {
+		s.wg.Add(1)
+		go s.worker(runCtx)
+	}
+
+	s.logger.Info("Notification workers started", zap.Int("workers", s.workers))
+	return nil
+}
+
+// Stop waits for in-flight deliveries; queued ones stay PENDING in database
+func (s *service) Stop(ctx context.Context) error {
+	if s.cancel == nil {
+		return nil
+	}
+	s.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *service) worker(ctx context.Context) {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case n := <-s.queue:
+			s.deliver(ctx, n)
+		}
+	}
+}
+
+// deliver claims the notification, sends it and records the outcome
+func (s *service) deliver(ctx context.Context, n *ent.Notification) {
+	logger := s.logger.With(
+		zap.String("id", n.ID.String()),
+		zap.String("channel", n.Channel),
+	)
+
+	storeCtx, cancel := context.WithTimeout(context.Background(), storeTimeout)
+	defer cancel()
+
+	now := time.Now()
+	claimed, err := s.repo.Claim(storeCtx, n.ID, now, now.Add(claimLease))
+	if err != nil {
+		logger.Error("Failed to claim notification", zap.Error(err))
+		return
+	}
+	if !claimed {
+		// Déjà traitée ou prise par un autre worker/instance
+		return
+	}
+	attempt := n.Attempts + 1
+
+	channel := s.channels[n.Channel]
+	if channel == nil {
+		s.fail(storeCtx, logger, n, attempt, fmt.Errorf("unsupported notification channel %q", n.Channel))
+		return
+	}
+
+	msg := &Message{
+		Channel:   n.Channel,
+		Recipient: n.Recipient,
+		Subject:   n.Subject,
+		Body:      n.Body,
+	}
+	if v, ok := s.secrets.Load(n.ID); ok {
+		secret := v.(*Message)
+		msg.Subject, msg.Body = secret.Subject, secret.Body
+	} else if strings.Contains(n.Subject, redacted) || strings.Contains(n.Body, redacted) {
+		// Secrets perdus (redémarrage, autre instance): ne jamais envoyer le message masqué
+		logger.Warn("Notification secrets lost, delivery abandoned")
+		if err := s.repo.MarkFailed(storeCtx, n.ID, errSecretLost.Error(), nil); err != nil {
+			logger.Error("Failed to record notification failure", zap.Error(err))
+		}
+		return
+	}
+
+	sendCtx, cancelSend := context.WithTimeout(ctx, sendTimeout)
+	err = channel.Send(sendCtx, msg)
+	cancelSend()
+	if err != nil {
+		s.fail(storeCtx, logger, n, attempt, err)
+		return
+	}
+	s.secrets.Delete(n.ID)
+
+	if err := s.repo.MarkSent(storeCtx, n.ID, channel.Provider(), time.Now()); err != nil {
+		logger.Error("Failed to record notification delivery", zap.Error(err))
+		return
+	}
+	logger.Debug("Notification sent", zap.String("provider", channel.Provider()))
+}
+
+func (s *service) fail(ctx context.Context, logger *zap.Logger, n *ent.Notification, attempt int, sendErr error) {
+	var nextAttemptAt *time.Time
+	if attempt < n.MaxAttempts {
+		next := time.Now().Add(retryDelay(attempt))
+		nextAttemptAt = &next
+	} else {
+		s.secrets.Delete(n.ID)
+	}
+
+	logger.Warn("Notification delivery failed",
+		zap.Int("attempt", attempt),
+		zap.Bool("will_retry", nextAttemptAt != nil),
+		zap.Error(sendErr),
+	)
+
+	if err := s.repo.MarkFailed(ctx, n.ID, sendErr.Error(), nextAttemptAt); err != nil {
+		logger.Error("Failed to record notification failure", zap.Error(err))
+	}
+}
+
+// retryDelay returns the wait before the attempt following the given one
+func retryDelay(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	if attempt > len(retryDelays) {
+		return retryDelays[len(retryDelays)-1]
+	}
+	return retryDelays[attempt-1]
+}
@@ -0,0 +1,67 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+)
+
+// smtpChannel sends emails through an SMTP server (STARTTLS when offered)
+type smtpChannel struct {
+	config config.SMTPConfig
+}
+
+// NewSMTPChannel creates an email channel
+func NewSMTPChannel(cfg config.SMTPConfig) Channel {
+	return &smtpChannel{config: cfg}
+}
+
+func (c *smtpChannel) Provider() string {
+	return "smtp"
+}
+
+func (c *smtpChannel) Send(ctx context.Context, msg *Message) error {
+	addr := net.JoinHostPort(c.config.Host, strconv.Itoa(c.config.Port))
+
+	var auth smtp.Auth
+	if c.config.Username != "" {
+		auth = smtp.PlainAuth("", c.config.Username, c.config.Password, c.config.Host)
+	}
+
+	// net/smtp ne gère pas les contextes: on borne l'envoi dans une goroutine
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(addr, auth, c.config.From, []string{msg.Recipient}, c.buildMessage(msg))
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("smtp send failed: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("smtp send aborted: %w", ctx.Err())
+	}
+}
+
+func (c *smtpChannel) buildMessage(msg *Message) []byte {
+	var b strings.Builder
+	b.WriteString("From: " + c.config.From + "\r\n")
+	b.WriteString("To: " + msg.Recipient + "\r\n")
+	b.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", msg.Subject) + "\r\n")
+	b.WriteString("Date: " + time.Now().Format(time.RFC1123Z) + "\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+	return []byte(b.String())
+}
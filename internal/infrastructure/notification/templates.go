@@ -0,0 +1,76 @@
+package notification
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// Template names
+const (
+	TemplateConvocation     = "convocation_notification"
+	TemplatePVRappel        = "pv_rappel"
+	TemplateAlerteDiffusion = "alerte_diffusion"
+	TemplatePasswordReset   = "password_reset"
+)
+
+// messageTemplate holds the subject (emails, push) and the body of a message
+type messageTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+var templates = map[string]messageTemplate{
+	TemplateConvocation: mustTemplate(TemplateConvocation,
+		"Convocation {{.Numero}}",
+		`Bonjour {{.Nom}},
+Vous êtes convoqué(e) au {{.Lieu}}{{if .Date}} le {{.Date}}{{end}}{{if .Heure}} à {{.Heure}}{{end}} (convocation {{.Numero}}).
+{{if .Message}}{{.Message}}
+{{end}}Merci de vous présenter muni(e) d'une pièce d'identité.`),
+	TemplatePVRappel: mustTemplate(TemplatePVRappel,
+		"Rappel PV {{.Numero}}",
+		`Bonjour {{.Nom}},
+Rappel n°{{.NumeroRappel}} : le procès-verbal {{.Numero}} reste impayé. Montant dû : {{.Montant}} FCFA{{if .DateLimite}}, à régler avant le {{.DateLimite}}{{end}}.
+Sans paiement, le montant pourra être majoré.`),
+	TemplateAlerteDiffusion: mustTemplate(TemplateAlerteDiffusion,
+		"Alerte {{.Niveau}} - {{.Titre}}",
+		`ALERTE {{.Niveau}} {{.Numero}} : {{.Titre}}
+{{.Description}}{{if .Lieu}}
+Lieu : {{.Lieu}}{{end}}`),
+	TemplatePasswordReset: mustTemplate(TemplatePasswordReset,
+		"Réinitialisation de votre mot de passe",
+		`Bonjour {{.Nom}},
+Une réinitialisation du mot de passe du compte {{.Matricule}} a été demandée.
+{{if .Lien}}Pour choisir un nouveau mot de passe, ouvrez ce lien : {{.Lien}}{{else}}Code de réinitialisation : {{.Jeton}}{{end}}
+Valable {{.Validite}}, utilisable une seule fois. Si vous n'êtes pas à l'origine de cette demande, ignorez ce message.`),
+}
+
+func mustTemplate(name, subject, body string) messageTemplate {
+	return messageTemplate{
+		subject: template.Must(template.New(name + ".subject").Parse(subject)),
+		body:    template.Must(template.New(name + ".body").Parse(body)),
+	}
+}
+
+// Render renders a registered template with the given data
+func Render(name string, data map[string]interface{}) (subject, body string, err error) {
+	tpl, ok := templates[name]
+	if !ok {
+		return "", "", fmt.Errorf("unknown notification template %q", name)
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.subject.Execute(&buf, data); err != nil {
+		return "", "", fmt.Errorf("failed to render subject of %q: %w", name, err)
+	}
+	subject = strings.TrimSpace(buf.String())
+
+	buf.Reset()
+	if err := tpl.body.Execute(&buf, data); err != nil {
+		return "", "", fmt.Errorf("failed to render body of %q: %w", name, err)
+	}
+	body = strings.TrimSpace(buf.String())
+
+	return subject, body, nil
+}
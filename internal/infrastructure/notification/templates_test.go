@@ -0,0 +1,78 @@
+package notification
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	subject, body, err := Render(TemplatePVRappel, map[string]interface{}{
+		"Nom":          "KOUASSI Yao",
+		"Numero":       "PV-2024-0001",
+		"NumeroRappel": 2,
+		"Montant":      "15 000",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Rappel PV PV-2024-0001", subject)
+	assert.Contains(t, body, "Rappel n°2")
+	assert.Contains(t, body, "15 000 FCFA")
+	assert.NotContains(t, body, "à régler avant")
+
+	_, _, err = Render("inconnu", nil)
+	assert.Error(t, err)
+}
+
+func TestRenderPasswordReset(t *testing.T) {
+	_, body, err := Render(TemplatePasswordReset, map[string]interface{}{
+		"Nom":       "Yao KOUASSI",
+		"Matricule": "AG-1234",
+		"Lien":      "https://app.example/reset?token=abc",
+		"Jeton":     "abc",
+		"Validite":  "30 minutes",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, body, "https://app.example/reset?token=abc")
+	assert.Contains(t, body, "30 minutes")
+
+	// Sans lien configuré, le jeton est envoyé seul
+	_, body, err = Render(TemplatePasswordReset, map[string]interface{}{
+		"Nom":       "Yao KOUASSI",
+		"Matricule": "AG-1234",
+		"Lien":      "",
+		"Jeton":     "abc",
+		"Validite":  "30 minutes",
+	})
+	require.NoError(t, err)
+	assert.Contains(t, body, "Code de réinitialisation : abc")
+}
+
+func TestWithSecrets(t *testing.T) {
+	data := map[string]interface{}{
+		"Nom":       "Yao KOUASSI",
+		"Matricule": "AG-1234",
+		"Validite":  "30 minutes",
+	}
+	secrets := map[string]interface{}{"Lien": "", "Jeton": "abc"}
+
+	_, stored, err := Render(TemplatePasswordReset, withSecrets(data, secrets, true))
+	require.NoError(t, err)
+	assert.NotContains(t, stored, "abc")
+	assert.Contains(t, stored, "Code de réinitialisation : "+redacted)
+
+	_, sent, err := Render(TemplatePasswordReset, withSecrets(data, secrets, false))
+	require.NoError(t, err)
+	assert.Contains(t, sent, "Code de réinitialisation : abc")
+
+	// Les données d'origine ne sont pas modifiées
+	assert.NotContains(t, data, "Jeton")
+}
+
+func TestRetryDelay(t *testing.T) {
+	assert.Equal(t, time.Minute, retryDelay(1))
+	assert.Equal(t, 5*time.Minute, retryDelay(2))
+	assert.Equal(t, time.Hour, retryDelay(4))
+	assert.Equal(t, time.Hour, retryDelay(10))
+}
@@ -0,0 +1,115 @@
+package numbering
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// nationalScope replaces {COMM} and {VILLE} when no commissariat is known
+const nationalScope = "NAT"
+
+var placeholderPattern = regexp.MustCompile(`\{([A-Z]+)(?::(\d+))?\}`)
+
+// formatData holds the values available to a number format
+type formatData struct {
+	Date  time.Time
+	Code  string // Commissariat code
+	Ville string // Commissariat city
+	Seq   int
+}
+
+// render expands the placeholders of format:
+//
+//	{YYYY} {YY} {MM}  date of the document
+//	{COMM}            commissariat code
+//	{VILLE}           first 3 letters of the commissariat city
+//	{SEQ} {SEQ:n}     sequence value, zero padded to n digits
+func render(format string, data formatData) (string, error) {
+	var renderErr error
+
+	out := placeholderPattern.ReplaceAllStringFunc(format, func(match string) string {
+		parts := placeholderPattern.FindStringSubmatch(match)
+		name, width := parts[1], parts[2]
+
+		if width != "" && name != "SEQ" {
+			renderErr = fmt.Errorf("placeholder %s does not take a width", match)
+			return match
+		}
+
+		switch name {
+		case "YYYY":
+			return fmt.Sprintf("%04d", data.Date.Year())
+		case "YY":
+			return fmt.Sprintf("%02d", data.Date.Year()%100)
+		case "MM":
+			return fmt.Sprintf("%02d", int(data.Date.Month()))
+		case "COMM":
+			return codePrefix(data.Code)
+		case "VILLE":
+			return villePrefix(data.Ville)
+		case "SEQ":
+			if width == "" {
+				return strconv.Itoa(data.Seq)
+			}
+			n, _ := strconv.Atoi(width)
+			return fmt.Sprintf("%0*d", n, data.Seq)
+		default:
+			renderErr = fmt.Errorf("unknown placeholder %s", match)
+			return match
+		}
+	})
+	if renderErr != nil {
+		return "", renderErr
+	}
+
+	return out, nil
+}
+
+// validateFormat checks that format renders and contains a sequence
+func validateFormat(format string) error {
+	if !strings.Contains(format, "{SEQ") {
+		return fmt.Errorf("format %q has no {SEQ} placeholder", format)
+	}
+	_, err := render(format, formatData{Date: time.Now(), Seq: 1})
+	return err
+}
+
+// codePrefix normalizes a commissariat code for use in a number
+func codePrefix(code string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(code) {
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+	if b.Len() == 0 {
+		return nationalScope
+	}
+	return b.String()
+}
+
+// villePrefix returns the first 3 letters of the city in upper case, padded with X
+func villePrefix(ville string) string {
+	if ville == "" {
+		return nationalScope
+	}
+
+	letters := make([]rune, 0, 3)
+	for _, r := range strings.ToUpper(ville) {
+		if len(letters) == 3 {
+			break
+		}
+		if unicode.IsLetter(r) {
+			letters = append(letters, r)
+		}
+	}
+	for len(letters) < 3 {
+		letters = append(letters, 'X')
+	}
+
+	return string(letters)
+}
@@ -0,0 +1,73 @@
+package numbering
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRender(t *testing.T) {
+	date := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		format string
+		data   formatData
+		want   string
+	}{
+		{
+			name:   "pv",
+			format: defaultFormats[TypePV],
+			data:   formatData{Date: date, Code: "com-abj 01", Seq: 42},
+			want:   "PV-COM-ABJ01-2024-000042",
+		},
+		{
+			name:   "alerte",
+			format: defaultFormats[TypeAlerte],
+			data:   formatData{Date: date, Ville: "Abidjan", Seq: 7},
+			want:   "ALR-ABI-COM-2024-0007",
+		},
+		{
+			name:   "short city is padded",
+			format: "{VILLE}-{SEQ}",
+			data:   formatData{Date: date, Ville: "Bé", Seq: 3},
+			want:   "BÉX-3",
+		},
+		{
+			name:   "national scope",
+			format: defaultFormats[TypePlainte],
+			data:   formatData{Date: date, Seq: 1},
+			want:   "PLT-NAT-2024-00001",
+		},
+		{
+			name:   "date parts",
+			format: "X{YY}{MM}-{SEQ:3}",
+			data:   formatData{Date: date, Seq: 1234},
+			want:   "X2403-1234",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := render(tt.format, tt.data)
+			if err != nil {
+				t.Fatalf("render() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateFormat(t *testing.T) {
+	for format, valid := range map[string]bool{
+		"PV-{COMM}-{YYYY}-{SEQ:6}": true,
+		"PV-{COMM}-{YYYY}":         false,
+		"PV-{FOO}-{SEQ}":           false,
+		"PV-{YYYY:2}-{SEQ}":        false,
+	} {
+		if err := validateFormat(format); (err == nil) != valid {
+			t.Errorf("validateFormat(%q) error = %v, want valid %v", format, err, valid)
+		}
+	}
+}
@@ -0,0 +1,8 @@
+package numbering
+
+import "go.uber.org/fx"
+
+// Module provides the document numbering service
+var Module = fx.Module("numbering",
+	fx.Provide(NewService),
+)
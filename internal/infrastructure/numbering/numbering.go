@@ -0,0 +1,226 @@
+package numbering
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+
+	"go.uber.org/zap"
+)
+
+// Sequence types, also the keys of numbering.formats in the configuration
+const (
+	TypePV            = "pv"
+	TypeRecuTresor    = "recu_tresor"
+	TypeRecours       = "recours"
+	TypeAlerte        = "alerte"
+	TypeConvocation   = "convocation"
+	TypePlainte       = "plainte"
+	TypeObjetPerdu    = "objet_perdu"
+	TypeObjetRetrouve = "objet_retrouve"
+)
+
+var defaultFormats = map[string]string{
+	TypePV:            "PV-{COMM}-{YYYY}-{SEQ:6}",
+	TypeRecuTresor:    "RCU-TR-{COMM}-{YYYY}-{SEQ:6}",
+	TypeRecours:       "REC-{COMM}-{YYYY}-{SEQ:5}",
+	TypeAlerte:        "ALR-{VILLE}-COM-{YYYY}-{SEQ:4}",
+	TypeConvocation:   "CONV-{VILLE}-COM-{YYYY}-{SEQ:4}",
+	TypePlainte:       "PLT-{COMM}-{YYYY}-{SEQ:5}",
+	TypeObjetPerdu:    "OBP-{VILLE}-COM-{YYYY}-{SEQ:4}",
+	TypeObjetRetrouve: "OBR-{VILLE}-COM-{YYYY}-{SEQ:4}",
+}
+
+// Request describes the document to number. The sequence is scoped by year and
+// by commissariat: CommissariatID when set, otherwise the commissariat found
+// through ProcesVerbalID or the sources of a new PV. Without any, the national
+// sequence of the type is used.
+type Request struct {
+	Type           string
+	Date           time.Time // Defaults to now
+	CommissariatID string
+	ProcesVerbalID string
+	ControleID     *string
+	InspectionID   *string
+	InfractionIDs  []string
+}
+
+// Reservation holds a number taken inside an open transaction. The document
+// must be created with Context() or Client() and the reservation committed;
+// rolling back gives the number back, so numbers have no gaps.
+type Reservation struct {
+	Numero string
+
+	tx   *ent.Tx
+	ctx  context.Context
+	done bool
+}
+
+// Context returns a context carrying the transaction, for repository writes
+func (r *Reservation) Context() context.Context {
+	return r.ctx
+}
+
+// Client returns the transactional client, for direct ent builders
+func (r *Reservation) Client() *ent.Client {
+	return r.tx.Client()
+}
+
+// Commit commits the number together with the document created with it
+func (r *Reservation) Commit() error {
+	r.done = true
+	if err := r.tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit numbering transaction: %w", err)
+	}
+	return nil
+}
+
+// Rollback releases the number. It does nothing after Commit, so it can be deferred.
+func (r *Reservation) Rollback() {
+	if r.done {
+		return
+	}
+	r.done = true
+	_ = r.tx.Rollback()
+}
+
+// Service hands out official document numbers
+type Service interface {
+	Reserve(ctx context.Context, req *Request) (*Reservation, error)
+}
+
+type service struct {
+	client           *ent.Client
+	sequenceRepo     repository.NumberSequenceRepository
+	commissariatRepo repository.CommissariatRepository
+	formats          map[string]string
+	logger           *zap.Logger
+}
+
+// NewService creates the numbering service
+func NewService(
+	client *ent.Client,
+	sequenceRepo repository.NumberSequenceRepository,
+	commissariatRepo repository.CommissariatRepository,
+	cfg *config.Config,
+	logger *zap.Logger,
+) (Service, error) {
+	formats := make(map[string]string, len(defaultFormats))
+	for seqType, format := range defaultFormats {
+		formats[seqType] = format
+	}
+	for seqType, format := range cfg.Numbering.Formats {
+		seqType = strings.ToLower(seqType)
+		if _, ok := defaultFormats[seqType]; !ok {
+			return nil, fmt.Errorf("unknown numbering type %q", seqType)
+		}
+		if err := validateFormat(format); err != nil {
+			return nil, fmt.Errorf("invalid numbering format for %s: %w", seqType, err)
+		}
+		formats[seqType] = format
+	}
+
+	return &service{
+		client:           client,
+		sequenceRepo:     sequenceRepo,
+		commissariatRepo: commissariatRepo,
+		formats:          formats,
+		logger:           logger,
+	}, nil
+}
+
+// Reserve takes the next number of the sequence in a new transaction
+func (s *service) Reserve(ctx context.Context, req *Request) (*Reservation, error) {
+	format, ok := s.formats[req.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown numbering type %q", req.Type)
+	}
+
+	date := req.Date
+	if date.IsZero() {
+		date = time.Now()
+	}
+
+	scope, err := s.resolveScope(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	data := formatData{Date: date}
+	if scope != "" {
+		commissariat, err := s.commissariatRepo.GetByID(ctx, scope)
+		if err != nil {
+			return nil, fmt.Errorf("commissariat not found")
+		}
+		data.Code = commissariat.Code
+		data.Ville = commissariat.Ville
+	}
+
+	tx, txCtx, seq, err := s.increment(ctx, req.Type, scope, date.Year())
+	if err != nil {
+		return nil, err
+	}
+
+	data.Seq = seq
+	numero, err := render(format, data)
+	if err != nil {
+		_ = tx.Rollback()
+		return nil, fmt.Errorf("failed to format number: %w", err)
+	}
+
+	s.logger.Debug("Number reserved",
+		zap.String("type", req.Type),
+		zap.String("scope", scope),
+		zap.String("numero", numero),
+	)
+
+	return &Reservation{
+		Numero: numero,
+		tx:     tx,
+		ctx:    txCtx,
+	}, nil
+}
+
+// increment opens the transaction of the reservation and takes the next value
+// of the sequence in it. A missing sequence is created between two
+// transactions: a write beside the open transaction would wait for its lock on
+// SQLite.
+func (s *service) increment(ctx context.Context, seqType, scope string, year int) (*ent.Tx, context.Context, int, error) {
+	for created := false; ; created = true {
+		tx, err := s.client.Tx(ctx)
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("failed to start numbering transaction: %w", err)
+		}
+		txCtx := ent.NewTxContext(ctx, tx)
+
+		seq, err := s.sequenceRepo.Increment(txCtx, seqType, scope, year)
+		if err == nil {
+			return tx, txCtx, seq, nil
+		}
+		_ = tx.Rollback()
+		if created || !errors.Is(err, repository.ErrSequenceNotFound) {
+			return nil, nil, 0, err
+		}
+		if err := s.sequenceRepo.Ensure(ctx, seqType, scope, year); err != nil {
+			return nil, nil, 0, err
+		}
+	}
+}
+
+// resolveScope returns the commissariat the sequence is scoped to, "" for national
+func (s *service) resolveScope(ctx context.Context, req *Request) (string, error) {
+	switch {
+	case req.CommissariatID != "":
+		return req.CommissariatID, nil
+	case req.ProcesVerbalID != "":
+		return s.commissariatRepo.FindIDForPV(ctx, req.ProcesVerbalID)
+	default:
+		return s.commissariatRepo.FindIDForPVSources(ctx, req.ControleID, req.InspectionID, req.InfractionIDs)
+	}
+}
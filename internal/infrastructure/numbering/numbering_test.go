@@ -0,0 +1,45 @@
+package numbering
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/database"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestReserve_NewSequenceOnSQLite(t *testing.T) {
+	// Une seule connexion, prise par la transaction : la séquence est créée hors de celle-ci
+	drv, err := database.Open(config.DatabaseConfig{Driver: database.DriverSQLite, Path: ":memory:", MaxOpenConns: 1})
+	require.NoError(t, err)
+	client := ent.NewClient(ent.Driver(drv))
+	t.Cleanup(func() { client.Close() })
+	require.NoError(t, client.Schema.Create(context.Background()))
+
+	s, err := NewService(client,
+		repository.NewNumberSequenceRepository(client, zap.NewNop()),
+		repository.NewCommissariatRepository(client, zap.NewNop()),
+		&config.Config{}, zap.NewNop())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	date := time.Date(2024, time.March, 5, 10, 0, 0, 0, time.UTC)
+
+	first, err := s.Reserve(ctx, &Request{Type: TypePlainte, Date: date})
+	require.NoError(t, err, "the sequence must be created without a second connection")
+	require.NoError(t, first.Commit())
+	assert.Equal(t, "PLT-NAT-2024-00001", first.Numero)
+
+	second, err := s.Reserve(ctx, &Request{Type: TypePlainte, Date: date})
+	require.NoError(t, err)
+	second.Rollback()
+	assert.Equal(t, "PLT-NAT-2024-00002", second.Numero)
+}
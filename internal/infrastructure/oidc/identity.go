@@ -0,0 +1,106 @@
+package oidc
+
+import (
+	"fmt"
+	"strings"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Identity is the provider account described by a verified ID token
+type Identity struct {
+	Issuer           string
+	Subject          string
+	Email            string
+	Matricule        string
+	Nom              string
+	Prenom           string
+	Role             string // Local role, empty if the token carries no known role
+	CommissariatCode string
+	MFA              bool // The provider verified a second factor
+}
+
+// identityFromClaims maps the ID token claims onto the local user fields.
+// validRole tells whether a local role exists.
+func identityFromClaims(claims jwt.MapClaims, cfg config.OIDCConfig, validRole func(string) bool) (*Identity, error) {
+	identity := &Identity{
+		Issuer:           stringClaim(claims, "iss"),
+		Subject:          stringClaim(claims, "sub"),
+		Email:            stringClaim(claims, "email"),
+		Matricule:        stringClaim(claims, cfg.Claims.Matricule),
+		Nom:              stringClaim(claims, "family_name"),
+		Prenom:           stringClaim(claims, "given_name"),
+		CommissariatCode: stringClaim(claims, cfg.Claims.Commissariat),
+	}
+	if identity.Subject == "" {
+		return nil, fmt.Errorf("ID token has no sub claim")
+	}
+	if identity.Matricule == "" {
+		return nil, fmt.Errorf("ID token has no %q claim", cfg.Claims.Matricule)
+	}
+
+	// Méthodes d'authentification (RFC 8176) ou niveau d'assurance du fournisseur
+	identity.MFA = containsAny(stringsClaim(claims, "amr"), cfg.MFAMethods) ||
+		containsAny(stringsClaim(claims, "acr"), cfg.MFAACR)
+
+	for _, value := range stringsClaim(claims, cfg.Claims.Role) {
+		// Viper met les clés des maps en minuscules
+		role, ok := cfg.RoleMapping[strings.ToLower(value)]
+		if !ok {
+			role = value
+		}
+		if validRole(role) {
+			identity.Role = role
+			break
+		}
+	}
+
+	return identity, nil
+}
+
+func stringClaim(claims jwt.MapClaims, name string) string {
+	if name == "" {
+		return ""
+	}
+	switch v := claims[name].(type) {
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		// Matricule numérique dans le jeton
+		return fmt.Sprintf("%.0f", v)
+	default:
+		return ""
+	}
+}
+
+// containsAny tells whether one of the values is accepted
+func containsAny(values, accepted []string) bool {
+	for _, value := range values {
+		for _, a := range accepted {
+			if value == a {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stringsClaim reads a claim holding a string or an array of strings
+func stringsClaim(claims jwt.MapClaims, name string) []string {
+	switch v := claims[name].(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,24 @@
+package oidc
+
+import (
+	"context"
+	"fmt"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/scheduler"
+)
+
+// NewCleanupJob removes the OIDC logins never completed
+func NewCleanupJob(service Service) scheduler.Job {
+	return scheduler.Job{
+		Name:        "oidc-state-cleanup",
+		Description: "Supprime les connexions OpenID Connect expirées sans retour du fournisseur",
+		Schedule:    "*/30 * * * *",
+		Run: func(ctx context.Context) (string, error) {
+			deleted, err := service.Cleanup(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d connexions supprimées", deleted), nil
+		},
+	}
+}
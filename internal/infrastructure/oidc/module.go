@@ -0,0 +1,14 @@
+package oidc
+
+import "go.uber.org/fx"
+
+// Module provides the OpenID Connect login service
+var Module = fx.Module("oidc",
+	fx.Provide(NewService),
+	fx.Provide(
+		fx.Annotate(
+			NewCleanupJob,
+			fx.ResultTags(`group:"jobs"`),
+		),
+	),
+)
@@ -0,0 +1,288 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubIssuer is an in-process OpenID Connect provider: it authorizes every
+// request and signs the ID tokens with an RSA key
+type stubIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]stubAuthorization
+	// claims added to the next ID tokens
+	claims jwt.MapClaims
+}
+
+type stubAuthorization struct {
+	challenge string
+	nonce     string
+}
+
+func newStubIssuer(t *testing.T) *stubIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	s := &stubIssuer{key: key, codes: map[string]stubAuthorization{}, claims: jwt.MapClaims{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(discoveryDocument{
+			Issuer:                s.server.URL,
+			AuthorizationEndpoint: s.server.URL + "/authorize",
+			TokenEndpoint:         s.server.URL + "/token",
+			JWKSURI:               s.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []jsonWebKey{{
+				KeyType: "RSA",
+				KeyID:   "stub-key",
+				Use:     "sig",
+				N:       base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				E:       base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", s.token)
+	s.server = httptest.NewServer(mux)
+	t.Cleanup(s.server.Close)
+
+	return s
+}
+
+// authorize plays the user consenting at the authorization URL and returns
+// the code sent back to the redirect URL
+func (s *stubIssuer) authorize(t *testing.T, authURL string) string {
+	t.Helper()
+
+	u, err := url.Parse(authURL)
+	require.NoError(t, err)
+	q := u.Query()
+	require.Equal(t, "S256", q.Get("code_challenge_method"))
+
+	code, err := randomString()
+	require.NoError(t, err)
+
+	s.mu.Lock()
+	s.codes[code] = stubAuthorization{challenge: q.Get("code_challenge"), nonce: q.Get("nonce")}
+	s.mu.Unlock()
+	return code
+}
+
+func (s *stubIssuer) token(w http.ResponseWriter, r *http.Request) {
+	_ = r.ParseForm()
+
+	s.mu.Lock()
+	auth, ok := s.codes[r.PostForm.Get("code")]
+	delete(s.codes, r.PostForm.Get("code"))
+	extra := s.claims
+	s.mu.Unlock()
+
+	if !ok || codeChallenge(r.PostForm.Get("code_verifier")) != auth.challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	claims := jwt.MapClaims{
+		"iss":       s.server.URL,
+		"sub":       "user-42",
+		"aud":       "police-trafic",
+		"exp":       time.Now().Add(5 * time.Minute).Unix(),
+		"iat":       time.Now().Unix(),
+		"nonce":     auth.nonce,
+		"email":     "j.dupont@police.gouv.fr",
+		"matricule": "12345",
+	}
+	for k, v := range extra {
+		claims[k] = v
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "stub-key"
+	idToken, err := token.SignedString(s.key)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(map[string]string{"id_token": idToken, "token_type": "Bearer"})
+}
+
+func (s *stubIssuer) config() config.OIDCConfig {
+	return config.OIDCConfig{
+		Enabled:     true,
+		Issuer:      s.server.URL,
+		ClientID:    "police-trafic",
+		RedirectURL: "https://app.example.org/login/callback",
+		Scopes:      []string{"openid", "email"},
+		Claims:      config.OIDCClaimsConfig{Matricule: "matricule", Role: "role", Commissariat: "commissariat"},
+	}
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	issuer := newStubIssuer(t)
+	p := newProvider(issuer.config(), issuer.server.Client())
+	ctx := context.Background()
+
+	authURL, err := p.AuthorizationURL(ctx, "state", "nonce-1", "verifier-1")
+	require.NoError(t, err)
+	assert.Contains(t, authURL, issuer.server.URL+"/authorize?")
+	assert.Contains(t, authURL, "state=state")
+
+	code := issuer.authorize(t, authURL)
+	claims, err := p.Exchange(ctx, code, "verifier-1", "nonce-1")
+	require.NoError(t, err)
+	assert.Equal(t, "user-42", claims["sub"])
+	assert.Equal(t, "12345", claims["matricule"])
+
+	// Le code est à usage unique
+	_, err = p.Exchange(ctx, code, "verifier-1", "nonce-1")
+	assert.Error(t, err)
+}
+
+func TestProvider_ExchangeRefusesWrongVerifier(t *testing.T) {
+	issuer := newStubIssuer(t)
+	p := newProvider(issuer.config(), issuer.server.Client())
+	ctx := context.Background()
+
+	authURL, err := p.AuthorizationURL(ctx, "state", "nonce-1", "verifier-1")
+	require.NoError(t, err)
+
+	_, err = p.Exchange(ctx, issuer.authorize(t, authURL), "another-verifier", "nonce-1")
+	assert.Error(t, err)
+}
+
+func TestProvider_ExchangeRefusesWrongNonce(t *testing.T) {
+	issuer := newStubIssuer(t)
+	p := newProvider(issuer.config(), issuer.server.Client())
+	ctx := context.Background()
+
+	authURL, err := p.AuthorizationURL(ctx, "state", "nonce-1", "verifier-1")
+	require.NoError(t, err)
+
+	_, err = p.Exchange(ctx, issuer.authorize(t, authURL), "verifier-1", "nonce-2")
+	assert.ErrorContains(t, err, "nonce")
+}
+
+func TestProvider_ExchangeRefusesOtherAudience(t *testing.T) {
+	issuer := newStubIssuer(t)
+	cfg := issuer.config()
+	cfg.ClientID = "another-client"
+	p := newProvider(cfg, issuer.server.Client())
+	ctx := context.Background()
+
+	authURL, err := p.AuthorizationURL(ctx, "state", "nonce-1", "verifier-1")
+	require.NoError(t, err)
+
+	_, err = p.Exchange(ctx, issuer.authorize(t, authURL), "verifier-1", "nonce-1")
+	assert.Error(t, err)
+}
+
+func TestProvider_ExchangeRefusesExpiredToken(t *testing.T) {
+	issuer := newStubIssuer(t)
+	issuer.claims["exp"] = time.Now().Add(-time.Hour).Unix()
+	p := newProvider(issuer.config(), issuer.server.Client())
+	ctx := context.Background()
+
+	authURL, err := p.AuthorizationURL(ctx, "state", "nonce-1", "verifier-1")
+	require.NoError(t, err)
+
+	_, err = p.Exchange(ctx, issuer.authorize(t, authURL), "verifier-1", "nonce-1")
+	assert.Error(t, err)
+}
+
+func TestProvider_DiscoveryIssuerMismatch(t *testing.T) {
+	issuer := newStubIssuer(t)
+	cfg := issuer.config()
+	cfg.Issuer = issuer.server.URL + "/"
+	p := newProvider(cfg, issuer.server.Client())
+
+	_, err := p.AuthorizationURL(context.Background(), "state", "nonce", "verifier")
+	assert.ErrorContains(t, err, "does not match")
+}
+
+func TestIdentityFromClaims(t *testing.T) {
+	cfg := config.OIDCConfig{
+		Claims:      config.OIDCClaimsConfig{Matricule: "matricule", Role: "groups", Commissariat: "commissariat"},
+		RoleMapping: map[string]string{"police-chefs": "supervisor"},
+	}
+	validRole := func(role string) bool { return role == "agent" || role == "supervisor" }
+
+	identity, err := identityFromClaims(jwt.MapClaims{
+		"iss":          "https://sso.example.org",
+		"sub":          "user-42",
+		"matricule":    float64(12345),
+		"given_name":   "Jean",
+		"family_name":  "Dupont",
+		"groups":       []interface{}{"everyone", "Police-Chefs"},
+		"commissariat": "COM-07",
+	}, cfg, validRole)
+	require.NoError(t, err)
+	assert.Equal(t, "12345", identity.Matricule)
+	assert.Equal(t, "supervisor", identity.Role)
+	assert.Equal(t, "COM-07", identity.CommissariatCode)
+	assert.Equal(t, "Dupont", identity.Nom)
+	assert.False(t, identity.MFA)
+
+	// Un rôle inconnu n'est jamais recopié
+	identity, err = identityFromClaims(jwt.MapClaims{"sub": "user-42", "matricule": "12345", "groups": "superuser"}, cfg, validRole)
+	require.NoError(t, err)
+	assert.Empty(t, identity.Role)
+
+	_, err = identityFromClaims(jwt.MapClaims{"sub": "user-42"}, cfg, validRole)
+	assert.Error(t, err)
+}
+
+func TestIdentityFromClaims_MFA(t *testing.T) {
+	cfg := config.OIDCConfig{
+		Claims:     config.OIDCClaimsConfig{Matricule: "matricule"},
+		MFAMethods: []string{"mfa"},
+		MFAACR:     []string{"urn:example:loa:high"},
+	}
+	validRole := func(string) bool { return false }
+
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+		mfa    bool
+	}{
+		{"amr mfa", jwt.MapClaims{"amr": []interface{}{"pwd", "otp", "mfa"}}, true},
+		{"password only", jwt.MapClaims{"amr": []interface{}{"pwd"}}, false},
+		{"acr accepted", jwt.MapClaims{"acr": "urn:example:loa:high"}, true},
+		{"acr too low", jwt.MapClaims{"acr": "urn:example:loa:low"}, false},
+		{"no claim", jwt.MapClaims{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.claims["sub"] = "user-42"
+			tt.claims["matricule"] = "12345"
+			identity, err := identityFromClaims(tt.claims, cfg, validRole)
+			require.NoError(t, err)
+			assert.Equal(t, tt.mfa, identity.MFA)
+		})
+	}
+}
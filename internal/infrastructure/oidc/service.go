@@ -0,0 +1,325 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/crypto"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultStateTTL = 10 * time.Minute
+	httpTimeout     = 10 * time.Second
+)
+
+var (
+	// ErrDisabled is returned when no provider is configured
+	ErrDisabled = errors.New("OIDC login is disabled")
+	// ErrInvalidState is returned for an unknown, reused or expired state
+	ErrInvalidState = errors.New("invalid or expired OIDC login state")
+	// ErrAuthenticationFailed is returned when the provider refuses the code or
+	// returns an ID token that cannot be trusted
+	ErrAuthenticationFailed = errors.New("OIDC authentication failed")
+	// ErrUnknownUser is returned when the account matches no local user and
+	// auth.oidc.auto_create is off
+	ErrUnknownUser = errors.New("no local user for this OIDC account")
+)
+
+// Authorization is a started login: the browser is sent to URL and comes
+// back with the same state
+type Authorization struct {
+	URL       string
+	State     string
+	ExpiresAt time.Time
+}
+
+// Login is a completed provider login
+type Login struct {
+	User *ent.User
+	MFA  bool // The provider verified a second factor
+}
+
+// Service runs the authorization code flow with PKCE and maps the provider
+// accounts onto the local users
+type Service interface {
+	Enabled() bool
+	ProviderName() string
+	// Begin starts a login and returns the provider authorization URL
+	Begin(ctx context.Context, ipAddress string) (*Authorization, error)
+	// Complete exchanges the code returned with state and returns the local
+	// user, linked or created on the first login
+	Complete(ctx context.Context, code, state string) (*Login, error)
+	// Cleanup removes the logins never completed
+	Cleanup(ctx context.Context) (int, error)
+}
+
+type service struct {
+	cfg              config.OIDCConfig
+	provider         *provider
+	repo             repository.OIDCRepository
+	userRepo         repository.UserRepository
+	commissariatRepo repository.CommissariatRepository
+	cryptoService    crypto.Service
+	rbacService      rbac.Service
+	logger           *zap.Logger
+}
+
+// NewService creates a new OpenID Connect login service
+func NewService(
+	repo repository.OIDCRepository,
+	userRepo repository.UserRepository,
+	commissariatRepo repository.CommissariatRepository,
+	cryptoService crypto.Service,
+	rbacService rbac.Service,
+	cfg *config.Config,
+	logger *zap.Logger,
+) Service {
+	oidcCfg := cfg.Auth.OIDC
+	if oidcCfg.StateTTL <= 0 {
+		oidcCfg.StateTTL = defaultStateTTL
+	}
+	if len(oidcCfg.Scopes) == 0 {
+		oidcCfg.Scopes = []string{"openid"}
+	}
+
+	return &service{
+		cfg:              oidcCfg,
+		provider:         newProvider(oidcCfg, &http.Client{Timeout: httpTimeout, Transport: tracing.Transport()}),
+		repo:             repo,
+		userRepo:         userRepo,
+		commissariatRepo: commissariatRepo,
+		cryptoService:    cryptoService,
+		rbacService:      rbacService,
+		logger:           logger,
+	}
+}
+
+func (s *service) Enabled() bool {
+	return s.cfg.Enabled && s.cfg.Issuer != "" && s.cfg.ClientID != ""
+}
+
+func (s *service) ProviderName() string {
+	return s.cfg.ProviderName
+}
+
+func (s *service) Begin(ctx context.Context, ipAddress string) (*Authorization, error) {
+	if !s.Enabled() {
+		return nil, ErrDisabled
+	}
+
+	state, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	verifier, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+
+	authURL, err := s.provider.AuthorizationURL(ctx, state, nonce, verifier)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(s.cfg.StateTTL)
+	err = s.repo.CreateLoginState(ctx, &repository.CreateOIDCLoginStateInput{
+		StateHash:    hashState(state),
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+		IPAddress:    ipAddress,
+		ExpiresAt:    expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Authorization{URL: authURL, State: state, ExpiresAt: expiresAt}, nil
+}
+
+func (s *service) Complete(ctx context.Context, code, state string) (*Login, error) {
+	if !s.Enabled() {
+		return nil, ErrDisabled
+	}
+
+	loginState, err := s.repo.ConsumeLoginState(ctx, hashState(state))
+	if err != nil {
+		return nil, err
+	}
+	if loginState == nil || time.Now().After(loginState.ExpiresAt) {
+		return nil, ErrInvalidState
+	}
+
+	claims, err := s.provider.Exchange(ctx, code, loginState.CodeVerifier, loginState.Nonce)
+	if err != nil {
+		s.logger.Warn("OIDC code exchange failed", zap.Error(err))
+		return nil, ErrAuthenticationFailed
+	}
+
+	identity, err := identityFromClaims(claims, s.cfg, s.validRole)
+	if err != nil {
+		s.logger.Warn("OIDC ID token refused", zap.Error(err))
+		return nil, ErrAuthenticationFailed
+	}
+
+	user, err := s.resolveUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	return &Login{User: user, MFA: identity.MFA}, nil
+}
+
+func (s *service) Cleanup(ctx context.Context) (int, error) {
+	return s.repo.DeleteExpiredLoginStates(ctx, time.Now())
+}
+
+// resolveUser returns the user linked to the provider account. On the first
+// login, the account is linked to the user of the same matricule, or to a new
+// user, and the provider role and commissariat are applied.
+func (s *service) resolveUser(ctx context.Context, identity *Identity) (*ent.User, error) {
+	link, err := s.repo.GetIdentity(ctx, identity.Issuer, identity.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if link != nil {
+		user, err := s.userRepo.GetByID(ctx, link.UserID.String())
+		if err != nil {
+			s.logger.Warn("OIDC identity linked to a missing user", zap.String("user_id", link.UserID.String()))
+			return nil, ErrUnknownUser
+		}
+		if err := s.repo.TouchIdentity(ctx, link.ID, identity.Email); err != nil {
+			s.logger.Error("Failed to update OIDC identity", zap.String("matricule", user.Matricule), zap.Error(err))
+		}
+		return user, nil
+	}
+
+	user, err := s.userRepo.GetByMatricule(ctx, identity.Matricule)
+	if err != nil {
+		if !s.cfg.AutoCreate {
+			s.logger.Warn("OIDC login of an unknown matricule", zap.String("matricule", identity.Matricule))
+			return nil, ErrUnknownUser
+		}
+		user, err = s.createUser(ctx, identity)
+	} else {
+		user, err = s.applyClaims(ctx, user, identity)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.repo.CreateIdentity(ctx, &repository.CreateUserIdentityInput{
+		UserID:  user.ID,
+		Issuer:  identity.Issuer,
+		Subject: identity.Subject,
+		Email:   identity.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("OIDC identity linked",
+		zap.String("matricule", user.Matricule),
+		zap.String("issuer", identity.Issuer),
+	)
+	return user, nil
+}
+
+// createUser creates the local user of a provider account. Its password is
+// random: the user logs in through the provider until an administrator or
+// a reset sets one.
+func (s *service) createUser(ctx context.Context, identity *Identity) (*ent.User, error) {
+	if identity.Email == "" {
+		s.logger.Warn("OIDC user cannot be created without email", zap.String("matricule", identity.Matricule))
+		return nil, ErrUnknownUser
+	}
+
+	password, err := randomString()
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := s.cryptoService.HashPassword(password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	role := identity.Role
+	if role == "" {
+		role = s.cfg.DefaultRole
+	}
+
+	input := &repository.CreateUserInput{
+		ID:             uuid.New().String(),
+		Matricule:      identity.Matricule,
+		Nom:            identity.Nom,
+		Prenom:         identity.Prenom,
+		Email:          identity.Email,
+		Password:       hashedPassword,
+		Role:           role,
+		CommissariatID: s.commissariatID(ctx, identity),
+	}
+
+	user, err := s.userRepo.Create(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("User created from OIDC login",
+		zap.String("matricule", user.Matricule),
+		zap.String("role", user.Role),
+	)
+	return user, nil
+}
+
+// applyClaims copies the provider role and commissariat onto an existing user
+// on the first login; afterwards they are managed locally
+func (s *service) applyClaims(ctx context.Context, user *ent.User, identity *Identity) (*ent.User, error) {
+	input := &repository.UpdateUserInput{
+		CommissariatID: s.commissariatID(ctx, identity),
+	}
+	if identity.Role != "" && identity.Role != user.Role {
+		input.Role = &identity.Role
+	}
+	if input.Role == nil && input.CommissariatID == nil {
+		return user, nil
+	}
+
+	return s.userRepo.Update(ctx, user.ID.String(), input)
+}
+
+// commissariatID resolves the commissariat code of the token, or returns nil
+func (s *service) commissariatID(ctx context.Context, identity *Identity) *string {
+	if identity.CommissariatCode == "" {
+		return nil
+	}
+
+	comm, err := s.commissariatRepo.GetByCode(ctx, identity.CommissariatCode)
+	if err != nil {
+		s.logger.Warn("Unknown commissariat in OIDC ID token",
+			zap.String("matricule", identity.Matricule),
+			zap.String("code", identity.CommissariatCode),
+		)
+		return nil
+	}
+
+	id := comm.ID.String()
+	return &id
+}
+
+// validRole accepts the local roles of people, not the service account role
+func (s *service) validRole(role string) bool {
+	return role != string(rbac.RoleServiceAccount) && s.rbacService.ValidateRole(role)
+}
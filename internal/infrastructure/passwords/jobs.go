@@ -0,0 +1,24 @@
+package passwords
+
+import (
+	"context"
+	"fmt"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/scheduler"
+)
+
+// NewCleanupJob removes the expired password reset tokens
+func NewCleanupJob(service Service) scheduler.Job {
+	return scheduler.Job{
+		Name:        "password-reset-cleanup",
+		Description: "Supprime les jetons de réinitialisation de mot de passe expirés",
+		Schedule:    "45 * * * *",
+		Run: func(ctx context.Context) (string, error) {
+			deleted, err := service.Cleanup(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d jetons supprimés", deleted), nil
+		},
+	}
+}
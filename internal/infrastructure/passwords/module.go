@@ -0,0 +1,14 @@
+package passwords
+
+import "go.uber.org/fx"
+
+// Module provides the password policy and reset service
+var Module = fx.Module("passwords",
+	fx.Provide(NewService),
+	fx.Provide(
+		fx.Annotate(
+			NewCleanupJob,
+			fx.ResultTags(`group:"jobs"`),
+		),
+	),
+)
@@ -0,0 +1,68 @@
+package passwords
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+)
+
+// PolicyError lists the complexity rules a password breaks
+type PolicyError struct {
+	Violations []string
+}
+
+func (e *PolicyError) Error() string {
+	return "password does not meet the policy: " + strings.Join(e.Violations, ", ")
+}
+
+// CheckPolicy checks a password against the complexity rules. The password
+// must not contain any of the forbidden values (matricule, ...), whatever the
+// case.
+func CheckPolicy(policy config.PasswordConfig, password string, forbidden ...string) error {
+	var violations []string
+
+	if policy.MinLength > 0 && len([]rune(password)) < policy.MinLength {
+		violations = append(violations, fmt.Sprintf("at least %d characters", policy.MinLength))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	if policy.RequireUpper && !upper {
+		violations = append(violations, "an uppercase letter")
+	}
+	if policy.RequireLower && !lower {
+		violations = append(violations, "a lowercase letter")
+	}
+	if policy.RequireDigit && !digit {
+		violations = append(violations, "a digit")
+	}
+	if policy.RequireSymbol && !symbol {
+		violations = append(violations, "a symbol")
+	}
+
+	lowered := strings.ToLower(password)
+	for _, value := range forbidden {
+		if len(value) >= 3 && strings.Contains(lowered, strings.ToLower(value)) {
+			violations = append(violations, "no matricule or name")
+			break
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
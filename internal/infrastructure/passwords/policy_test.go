@@ -0,0 +1,69 @@
+package passwords
+
+import (
+	"errors"
+	"testing"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+)
+
+func TestCheckPolicy(t *testing.T) {
+	policy := config.PasswordConfig{
+		MinLength:    10,
+		RequireUpper: true,
+		RequireLower: true,
+		RequireDigit: true,
+	}
+
+	tests := []struct {
+		name       string
+		password   string
+		violations int
+	}{
+		{name: "valid", password: "Carrefour2024", violations: 0},
+		{name: "too short", password: "Abc12", violations: 1},
+		{name: "no uppercase", password: "carrefour2024", violations: 1},
+		{name: "no digit", password: "CarrefourNord", violations: 1},
+		{name: "letters only", password: "abc", violations: 3},
+		{name: "matricule", password: "Agent12345x", violations: 1},
+		{name: "matricule other case", password: "AGENT12345x", violations: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckPolicy(policy, tt.password, "agent12345")
+			if tt.violations == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var policyErr *PolicyError
+			if !errors.As(err, &policyErr) {
+				t.Fatalf("expected *PolicyError, got %v", err)
+			}
+			if len(policyErr.Violations) != tt.violations {
+				t.Errorf("violations = %v, want %d", policyErr.Violations, tt.violations)
+			}
+		})
+	}
+}
+
+func TestCheckPolicySymbol(t *testing.T) {
+	policy := config.PasswordConfig{RequireSymbol: true}
+
+	if err := CheckPolicy(policy, "Carrefour2024"); err == nil {
+		t.Error("expected an error without symbol")
+	}
+	if err := CheckPolicy(policy, "Carrefour-2024"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckPolicyIgnoresShortPersonalValues(t *testing.T) {
+	// Un nom de deux lettres ne doit pas interdire tous les mots de passe qui le contiennent
+	if err := CheckPolicy(config.PasswordConfig{}, "Koffi-2024", "Ko"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,340 @@
+package passwords
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/crypto"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/notification"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/session"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultResetTokenTTL = 30 * time.Minute
+	// resetRequestInterval limits the reset messages sent to one account
+	resetRequestInterval = time.Minute
+)
+
+var (
+	// ErrReused is returned when the new password is the current or a recent one
+	ErrReused = errors.New("password was used recently")
+	// ErrInvalidResetToken is returned for an unknown, expired or already used reset token
+	ErrInvalidResetToken = errors.New("invalid or expired password reset token")
+)
+
+// Status describes the lifecycle of the password of a user
+type Status struct {
+	MustChange bool       `json:"must_change"` // temporary password set by an administrator
+	Expired    bool       `json:"expired"`
+	ChangedAt  time.Time  `json:"changed_at"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+}
+
+// ChangeRequired tells whether the user has to choose a new password before
+// using the application
+func (s *Status) ChangeRequired() bool {
+	return s.MustChange || s.Expired
+}
+
+// Service applies the password policy: complexity, reuse, expiry and reset
+type Service interface {
+	// Validate checks the complexity rules. The password must not contain the
+	// personal values given (matricule, names).
+	Validate(password string, personal ...string) error
+	Status(ctx context.Context, user *ent.User) (*Status, error)
+	// Record stores the password set at the creation of an account. A temporary
+	// password must be changed at the first login.
+	Record(ctx context.Context, userID uuid.UUID, passwordHash string, temporary bool) error
+	// Change sets a new password chosen by the user and revokes all the sessions
+	Change(ctx context.Context, user *ent.User, newPassword string) error
+	// RequestReset sends a reset token to the user identified by matricule or
+	// email. Unknown accounts are ignored silently.
+	RequestReset(ctx context.Context, identifier, ipAddress string) error
+	// Reset sets a new password with a reset token and returns the user
+	Reset(ctx context.Context, token, newPassword string) (*ent.User, error)
+	// Cleanup removes the expired reset tokens
+	Cleanup(ctx context.Context) (int, error)
+}
+
+type service struct {
+	userRepo            repository.UserRepository
+	passwordRepo        repository.PasswordRepository
+	cryptoService       crypto.Service
+	sessionService      session.Service
+	notificationService notification.Service
+	policy              config.PasswordConfig
+	logger              *zap.Logger
+}
+
+// NewService creates a new password policy service
+func NewService(
+	userRepo repository.UserRepository,
+	passwordRepo repository.PasswordRepository,
+	cryptoService crypto.Service,
+	sessionService session.Service,
+	notificationService notification.Service,
+	cfg *config.Config,
+	logger *zap.Logger,
+) Service {
+	policy := cfg.Auth.Password
+	if policy.ResetTokenTTL <= 0 {
+		policy.ResetTokenTTL = defaultResetTokenTTL
+	}
+
+	return &service{
+		userRepo:            userRepo,
+		passwordRepo:        passwordRepo,
+		cryptoService:       cryptoService,
+		sessionService:      sessionService,
+		notificationService: notificationService,
+		policy:              policy,
+		logger:              logger,
+	}
+}
+
+func (s *service) Validate(password string, personal ...string) error {
+	return CheckPolicy(s.policy, password, personal...)
+}
+
+func (s *service) Status(ctx context.Context, user *ent.User) (*Status, error) {
+	latest, err := s.passwordRepo.LatestHistory(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Mot de passe antérieur à l'historique : daté de la création du compte
+	status := &Status{ChangedAt: user.CreatedAt}
+	if latest != nil {
+		status.ChangedAt = latest.CreatedAt
+		status.MustChange = latest.Temporary
+	}
+
+	if s.policy.ExpiryDays > 0 {
+		expiresAt := status.ChangedAt.AddDate(0, 0, s.policy.ExpiryDays)
+		status.ExpiresAt = &expiresAt
+		status.Expired = time.Now().After(expiresAt)
+	}
+
+	return status, nil
+}
+
+func (s *service) Record(ctx context.Context, userID uuid.UUID, passwordHash string, temporary bool) error {
+	return s.passwordRepo.AddHistory(ctx, userID, passwordHash, temporary, s.policy.HistorySize)
+}
+
+func (s *service) Change(ctx context.Context, user *ent.User, newPassword string) error {
+	if err := s.checkNew(ctx, user, newPassword); err != nil {
+		return err
+	}
+
+	return s.set(ctx, user, newPassword, "password_changed")
+}
+
+func (s *service) RequestReset(ctx context.Context, identifier, ipAddress string) error {
+	user, err := s.userRepo.GetByMatricule(ctx, identifier)
+	if err != nil {
+		user, err = s.userRepo.GetByEmail(ctx, identifier)
+	}
+	if err != nil || !user.Active {
+		s.logger.Info("Password reset requested for unknown or inactive account",
+			zap.String("identifier", identifier),
+			zap.String("ip", ipAddress),
+		)
+		return nil
+	}
+
+	latest, err := s.passwordRepo.LatestResetToken(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if latest != nil && latest.UsedAt == nil && time.Since(latest.CreatedAt) < resetRequestInterval {
+		s.logger.Info("Password reset already requested", zap.String("user_id", user.ID.String()))
+		return nil
+	}
+
+	channel, recipient := notification.ChannelEmail, user.Email
+	if recipient == "" {
+		channel, recipient = notification.ChannelSMS, user.Telephone
+	}
+	if recipient == "" {
+		s.logger.Warn("Password reset requested for an account without email or phone",
+			zap.String("user_id", user.ID.String()),
+		)
+		return nil
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return fmt.Errorf("failed to generate password reset token: %w", err)
+	}
+
+	// Un seul jeton valide à la fois
+	if err := s.passwordRepo.InvalidateResetTokens(ctx, user.ID); err != nil {
+		return err
+	}
+	expiresAt := time.Now().Add(s.policy.ResetTokenTTL)
+	if err := s.passwordRepo.CreateResetToken(ctx, user.ID, hashToken(token), expiresAt, ipAddress); err != nil {
+		return err
+	}
+
+	_, err = s.notificationService.Send(ctx, &notification.SendRequest{
+		Channel:   channel,
+		Recipient: recipient,
+		Template:  notification.TemplatePasswordReset,
+		Data: map[string]interface{}{
+			"Nom":       strings.TrimSpace(user.Prenom + " " + user.Nom),
+			"Matricule": user.Matricule,
+			"Validite":  fmt.Sprintf("%d minutes", int(s.policy.ResetTokenTTL.Minutes())),
+		},
+		// Le jeton n'est jamais écrit dans l'historique des notifications
+		Secrets: map[string]interface{}{
+			"Lien":  s.resetLink(token),
+			"Jeton": token,
+		},
+		ResourceType: "users",
+		ResourceID:   user.ID.String(),
+	})
+	if err != nil {
+		// La réponse ne doit pas révéler l'existence du compte
+		s.logger.Error("Failed to send password reset", zap.String("user_id", user.ID.String()), zap.Error(err))
+		return nil
+	}
+
+	s.logger.Info("Password reset requested",
+		zap.String("user_id", user.ID.String()),
+		zap.String("channel", channel),
+		zap.String("ip", ipAddress),
+	)
+	return nil
+}
+
+func (s *service) Reset(ctx context.Context, token, newPassword string) (*ent.User, error) {
+	t, err := s.passwordRepo.GetResetToken(ctx, hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if t == nil || t.UsedAt != nil || time.Now().After(t.ExpiresAt) {
+		return nil, ErrInvalidResetToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, t.UserID.String())
+	if err != nil || !user.Active {
+		return nil, ErrInvalidResetToken
+	}
+
+	// Le jeton n'est consommé que si le nouveau mot de passe est accepté
+	if err := s.checkNew(ctx, user, newPassword); err != nil {
+		return nil, err
+	}
+
+	used, err := s.passwordRepo.UseResetToken(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, ErrInvalidResetToken
+	}
+
+	if err := s.set(ctx, user, newPassword, "password_reset"); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *service) Cleanup(ctx context.Context) (int, error) {
+	return s.passwordRepo.DeleteExpiredResetTokens(ctx, time.Now())
+}
+
+// checkNew applies the complexity rules and refuses the current and the last
+// HistorySize passwords
+func (s *service) checkNew(ctx context.Context, user *ent.User, newPassword string) error {
+	if err := CheckPolicy(s.policy, newPassword, user.Matricule, user.Nom, user.Prenom); err != nil {
+		return err
+	}
+
+	if s.cryptoService.CheckPassword(newPassword, user.Password) == nil {
+		return ErrReused
+	}
+
+	if s.policy.HistorySize <= 0 {
+		return nil
+	}
+	history, err := s.passwordRepo.ListHistory(ctx, user.ID, s.policy.HistorySize)
+	if err != nil {
+		return err
+	}
+	for _, h := range history {
+		if s.cryptoService.CheckPassword(newPassword, h.PasswordHash) == nil {
+			return ErrReused
+		}
+	}
+
+	return nil
+}
+
+// set stores the new password, records it in the history and revokes the
+// sessions opened with the previous one
+func (s *service) set(ctx context.Context, user *ent.User, newPassword, reason string) error {
+	hash, err := s.cryptoService.HashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	if _, err := s.userRepo.Update(ctx, user.ID.String(), &repository.UpdateUserInput{Password: &hash}); err != nil {
+		return err
+	}
+	if err := s.passwordRepo.AddHistory(ctx, user.ID, hash, false, s.policy.HistorySize); err != nil {
+		return err
+	}
+	if err := s.passwordRepo.InvalidateResetTokens(ctx, user.ID); err != nil {
+		s.logger.Error("Failed to invalidate password reset tokens", zap.String("user_id", user.ID.String()), zap.Error(err))
+	}
+
+	if err := s.sessionService.RevokeAllUserSessions(ctx, user.ID, reason); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	s.logger.Info("Password changed", zap.String("user_id", user.ID.String()), zap.String("reason", reason))
+	return nil
+}
+
+// resetLink returns the frontend link carrying the token, or "" without
+// configured URL
+func (s *service) resetLink(token string) string {
+	if s.policy.ResetURL == "" {
+		return ""
+	}
+
+	sep := "?"
+	if strings.Contains(s.policy.ResetURL, "?") {
+		sep = "&"
+	}
+	return s.policy.ResetURL + sep + "token=" + url.QueryEscape(token)
+}
+
+func generateToken() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func hashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -1,8 +1,76 @@
 package rbac
 
-import "go.uber.org/fx"
+import (
+	"context"
+	"time"
 
-// Module provides RBAC service dependency
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// reloadInterval bounds how long a role change made on another instance takes to apply here
+const reloadInterval = time.Minute
+
+// Module provides RBAC service dependency. Roles are seeded and loaded from the
+// database at startup, then reloaded periodically.
 var Module = fx.Module("rbac",
-	fx.Provide(NewRBACService),
-)
\ No newline at end of file
+	fx.Provide(NewDatabaseRBACService),
+	fx.Invoke(func(lc fx.Lifecycle, s Service, roleRepo repository.RoleRepository, logger *zap.Logger) {
+		stop := make(chan struct{})
+		lc.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				if err := Seed(ctx, roleRepo); err != nil {
+					return err
+				}
+				if err := s.Reload(ctx); err != nil {
+					return err
+				}
+				go reloadPeriodically(s, logger, stop)
+				return nil
+			},
+			OnStop: func(ctx context.Context) error {
+				close(stop)
+				return nil
+			},
+		})
+	}),
+)
+
+// Seed stores the permission catalog and the built-in roles
+func Seed(ctx context.Context, roleRepo repository.RoleRepository) error {
+	permissions := make([]string, len(AllPermissions))
+	for i, p := range AllPermissions {
+		permissions[i] = string(p)
+	}
+
+	roles := make(map[string][]string, len(RolePermissions))
+	for role, perms := range RolePermissions {
+		codes := make([]string, len(perms))
+		for i, p := range perms {
+			codes[i] = string(p)
+		}
+		roles[string(role)] = codes
+	}
+
+	return roleRepo.SeedDefaults(ctx, permissions, roles)
+}
+
+func reloadPeriodically(s Service, logger *zap.Logger, stop <-chan struct{}) {
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			if err := s.Reload(ctx); err != nil {
+				logger.Warn("Failed to reload RBAC policy", zap.Error(err))
+			}
+			cancel()
+		}
+	}
+}
@@ -1,8 +1,12 @@
 package rbac
 
 import (
+	"context"
 	"fmt"
 	"strings"
+	"sync"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
 
 	"go.uber.org/zap"
 )
@@ -54,6 +58,92 @@ const (
 	PermManageSystem  Permission = "admin:system"
 	PermViewReports   Permission = "admin:reports"
 	PermManageConfig  Permission = "admin:config"
+
+	// Roles and permissions
+	PermReadRoles   Permission = "roles:read"
+	PermManageRoles Permission = "roles:manage"
+
+	// Audit log
+	PermReadAudit Permission = "audit:read"
+
+	// Inspections
+	PermReadInspections   Permission = "inspections:read"
+	PermCreateInspections Permission = "inspections:create"
+	PermUpdateInspections Permission = "inspections:update"
+	PermDeleteInspections Permission = "inspections:delete"
+
+	// Vehicles
+	PermReadVehicules   Permission = "vehicules:read"
+	PermCreateVehicules Permission = "vehicules:create"
+	PermUpdateVehicules Permission = "vehicules:update"
+	PermDeleteVehicules Permission = "vehicules:delete"
+
+	// Drivers
+	PermReadConducteurs   Permission = "conducteurs:read"
+	PermCreateConducteurs Permission = "conducteurs:create"
+	PermUpdateConducteurs Permission = "conducteurs:update"
+	PermDeleteConducteurs Permission = "conducteurs:delete"
+
+	// Documents
+	PermReadDocuments   Permission = "documents:read"
+	PermCreateDocuments Permission = "documents:create"
+	PermUpdateDocuments Permission = "documents:update"
+	PermDeleteDocuments Permission = "documents:delete"
+
+	// Payments
+	PermReadPaiements     Permission = "paiements:read"
+	PermCreatePaiements   Permission = "paiements:create"
+	PermUpdatePaiements   Permission = "paiements:update"
+	PermDeletePaiements   Permission = "paiements:delete"
+	PermValidatePaiements Permission = "paiements:validate"
+
+	// Appeals
+	PermReadRecours   Permission = "recours:read"
+	PermCreateRecours Permission = "recours:create"
+	PermUpdateRecours Permission = "recours:update"
+	PermDeleteRecours Permission = "recours:delete"
+	PermDecideRecours Permission = "recours:decide"
+
+	// Complaints
+	PermReadPlaintes   Permission = "plaintes:read"
+	PermCreatePlaintes Permission = "plaintes:create"
+	PermUpdatePlaintes Permission = "plaintes:update"
+	PermDeletePlaintes Permission = "plaintes:delete"
+
+	// Summons
+	PermReadConvocations   Permission = "convocations:read"
+	PermCreateConvocations Permission = "convocations:create"
+	PermUpdateConvocations Permission = "convocations:update"
+
+	// Lost and found items
+	PermReadObjetsPerdus      Permission = "objets-perdus:read"
+	PermCreateObjetsPerdus    Permission = "objets-perdus:create"
+	PermUpdateObjetsPerdus    Permission = "objets-perdus:update"
+	PermDeleteObjetsPerdus    Permission = "objets-perdus:delete"
+	PermReadObjetsRetrouves   Permission = "objets-retrouves:read"
+	PermCreateObjetsRetrouves Permission = "objets-retrouves:create"
+	PermUpdateObjetsRetrouves Permission = "objets-retrouves:update"
+	PermDeleteObjetsRetrouves Permission = "objets-retrouves:delete"
+
+	// Teams and missions
+	PermReadEquipes    Permission = "equipes:read"
+	PermManageEquipes  Permission = "equipes:manage"
+	PermReadMissions   Permission = "missions:read"
+	PermCreateMissions Permission = "missions:create"
+	PermUpdateMissions Permission = "missions:update"
+	PermDeleteMissions Permission = "missions:delete"
+
+	// Agent follow-up: skills, objectives, observations
+	PermReadCompetences    Permission = "competences:read"
+	PermManageCompetences  Permission = "competences:manage"
+	PermReadObjectifs      Permission = "objectifs:read"
+	PermManageObjectifs    Permission = "objectifs:manage"
+	PermReadObservations   Permission = "observations:read"
+	PermCreateObservations Permission = "observations:create"
+	PermManageObservations Permission = "observations:manage"
+
+	// Global search, restricted to the types the caller can read
+	PermSearch Permission = "search:read"
 )
 
 // Role represents a user role
@@ -63,20 +153,47 @@ const (
 	RoleAdmin      Role = "admin"
 	RoleSupervisor Role = "supervisor"
 	RoleAgent      Role = "agent"
+
+	// RoleServiceAccount is the role of the requests authenticated with an API
+	// key. It is not stored in the roles table: the permissions come from the
+	// service account itself.
+	RoleServiceAccount Role = "service_account"
 )
 
-// RolePermissions defines which permissions each role has
+// AllPermissions lists every permission known to the application. They are
+// stored in the database at startup so that roles can be granted any of them.
+var AllPermissions = []Permission{
+	PermReadUsers, PermCreateUsers, PermUpdateUsers, PermDeleteUsers,
+	PermReadControles, PermCreateControles, PermUpdateControles, PermDeleteControles,
+	PermReadInfractions, PermCreateInfractions, PermUpdateInfractions, PermDeleteInfractions,
+	PermReadPV, PermCreatePV, PermUpdatePV, PermDeletePV, PermApprovePV,
+	PermReadAlertes, PermCreateAlertes, PermUpdateAlertes, PermDeleteAlertes,
+	PermReadCommissariats, PermCreateCommissariats, PermUpdateCommissariats, PermDeleteCommissariats,
+	PermReadAdmin, PermManageSystem, PermViewReports, PermManageConfig,
+	PermReadRoles, PermManageRoles,
+	PermReadAudit,
+	PermReadInspections, PermCreateInspections, PermUpdateInspections, PermDeleteInspections,
+	PermReadVehicules, PermCreateVehicules, PermUpdateVehicules, PermDeleteVehicules,
+	PermReadConducteurs, PermCreateConducteurs, PermUpdateConducteurs, PermDeleteConducteurs,
+	PermReadDocuments, PermCreateDocuments, PermUpdateDocuments, PermDeleteDocuments,
+	PermReadPaiements, PermCreatePaiements, PermUpdatePaiements, PermDeletePaiements, PermValidatePaiements,
+	PermReadRecours, PermCreateRecours, PermUpdateRecours, PermDeleteRecours, PermDecideRecours,
+	PermReadPlaintes, PermCreatePlaintes, PermUpdatePlaintes, PermDeletePlaintes,
+	PermReadConvocations, PermCreateConvocations, PermUpdateConvocations,
+	PermReadObjetsPerdus, PermCreateObjetsPerdus, PermUpdateObjetsPerdus, PermDeleteObjetsPerdus,
+	PermReadObjetsRetrouves, PermCreateObjetsRetrouves, PermUpdateObjetsRetrouves, PermDeleteObjetsRetrouves,
+	PermReadEquipes, PermManageEquipes,
+	PermReadMissions, PermCreateMissions, PermUpdateMissions, PermDeleteMissions,
+	PermReadCompetences, PermManageCompetences,
+	PermReadObjectifs, PermManageObjectifs,
+	PermReadObservations, PermCreateObservations, PermManageObservations,
+	PermSearch,
+}
+
+// RolePermissions defines which permissions each built-in role has.
+// It seeds the roles table on first start; afterwards the database is authoritative.
 var RolePermissions = map[Role][]Permission{
-	RoleAdmin: {
-		// Full access to everything
-		PermReadUsers, PermCreateUsers, PermUpdateUsers, PermDeleteUsers,
-		PermReadControles, PermCreateControles, PermUpdateControles, PermDeleteControles,
-		PermReadInfractions, PermCreateInfractions, PermUpdateInfractions, PermDeleteInfractions,
-		PermReadPV, PermCreatePV, PermUpdatePV, PermDeletePV, PermApprovePV,
-		PermReadAlertes, PermCreateAlertes, PermUpdateAlertes, PermDeleteAlertes,
-		PermReadCommissariats, PermCreateCommissariats, PermUpdateCommissariats, PermDeleteCommissariats,
-		PermReadAdmin, PermManageSystem, PermViewReports, PermManageConfig,
-	},
+	RoleAdmin: AllPermissions, // Full access to everything
 	RoleSupervisor: {
 		// Can read users but not delete, approve PV
 		PermReadUsers, PermUpdateUsers,
@@ -86,6 +203,22 @@ var RolePermissions = map[Role][]Permission{
 		PermReadAlertes, PermCreateAlertes, PermUpdateAlertes, PermDeleteAlertes,
 		PermReadCommissariats, PermUpdateCommissariats,
 		PermViewReports,
+		PermReadInspections, PermCreateInspections, PermUpdateInspections, PermDeleteInspections,
+		PermReadVehicules, PermCreateVehicules, PermUpdateVehicules,
+		PermReadConducteurs, PermCreateConducteurs, PermUpdateConducteurs,
+		PermReadDocuments, PermCreateDocuments, PermUpdateDocuments, PermDeleteDocuments,
+		PermReadPaiements, PermCreatePaiements, PermUpdatePaiements, PermValidatePaiements,
+		PermReadRecours, PermCreateRecours, PermUpdateRecours, PermDecideRecours,
+		PermReadPlaintes, PermCreatePlaintes, PermUpdatePlaintes, PermDeletePlaintes,
+		PermReadConvocations, PermCreateConvocations, PermUpdateConvocations,
+		PermReadObjetsPerdus, PermCreateObjetsPerdus, PermUpdateObjetsPerdus, PermDeleteObjetsPerdus,
+		PermReadObjetsRetrouves, PermCreateObjetsRetrouves, PermUpdateObjetsRetrouves, PermDeleteObjetsRetrouves,
+		PermReadEquipes, PermManageEquipes,
+		PermReadMissions, PermCreateMissions, PermUpdateMissions, PermDeleteMissions,
+		PermReadCompetences, PermManageCompetences,
+		PermReadObjectifs, PermManageObjectifs,
+		PermReadObservations, PermCreateObservations, PermManageObservations,
+		PermSearch,
 	},
 	RoleAgent: {
 		// Basic operations, cannot delete or approve
@@ -95,6 +228,22 @@ var RolePermissions = map[Role][]Permission{
 		PermReadPV, PermCreatePV, PermUpdatePV,
 		PermReadAlertes, PermCreateAlertes, PermUpdateAlertes,
 		PermReadCommissariats,
+		PermReadInspections, PermCreateInspections, PermUpdateInspections,
+		PermReadVehicules, PermCreateVehicules, PermUpdateVehicules,
+		PermReadConducteurs, PermCreateConducteurs, PermUpdateConducteurs,
+		PermReadDocuments, PermCreateDocuments,
+		PermReadPaiements, PermCreatePaiements,
+		PermReadRecours, PermCreateRecours,
+		PermReadPlaintes, PermCreatePlaintes, PermUpdatePlaintes,
+		PermReadConvocations, PermCreateConvocations, PermUpdateConvocations,
+		PermReadObjetsPerdus, PermCreateObjetsPerdus, PermUpdateObjetsPerdus,
+		PermReadObjetsRetrouves, PermCreateObjetsRetrouves, PermUpdateObjetsRetrouves,
+		PermReadEquipes,
+		PermReadMissions,
+		PermReadCompetences,
+		PermReadObjectifs,
+		PermReadObservations,
+		PermSearch,
 	},
 }
 
@@ -104,23 +253,75 @@ type Service interface {
 	GetUserPermissions(role string) []Permission
 	CanAccessResource(role string, resource string, action string) bool
 	ValidateRole(role string) bool
+	// Reload refreshes the roles and their permissions from the database
+	Reload(ctx context.Context) error
 }
 
 type service struct {
-	logger *zap.Logger
+	roleRepo repository.RoleRepository // nil: built-in policy only
+	logger   *zap.Logger
+
+	mu     sync.RWMutex
+	policy map[Role][]Permission
 }
 
-// NewRBACService creates a new RBAC service
+// NewRBACService creates a new RBAC service using the built-in role policy
 func NewRBACService(logger *zap.Logger) Service {
 	return &service{
 		logger: logger,
+		policy: RolePermissions,
+	}
+}
+
+// NewDatabaseRBACService creates a new RBAC service whose roles are stored in
+// the database. The built-in policy applies until the first Reload.
+func NewDatabaseRBACService(roleRepo repository.RoleRepository, logger *zap.Logger) Service {
+	return &service{
+		roleRepo: roleRepo,
+		logger:   logger,
+		policy:   RolePermissions,
+	}
+}
+
+// Reload loads the roles and their permissions from the database
+func (s *service) Reload(ctx context.Context) error {
+	if s.roleRepo == nil {
+		return nil
 	}
+
+	roles, err := s.roleRepo.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load roles: %w", err)
+	}
+
+	policy := make(map[Role][]Permission, len(roles))
+	for _, r := range roles {
+		permissions := make([]Permission, 0, len(r.Edges.Permissions))
+		for _, p := range r.Edges.Permissions {
+			permissions = append(permissions, Permission(p.Code))
+		}
+		policy[Role(r.Name)] = permissions
+	}
+
+	s.mu.Lock()
+	s.policy = policy
+	s.mu.Unlock()
+
+	s.logger.Debug("RBAC policy loaded", zap.Int("roles", len(policy)))
+	return nil
+}
+
+// permissionsOf returns the permissions of a role in the current policy
+func (s *service) permissionsOf(role Role) ([]Permission, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	permissions, exists := s.policy[role]
+	return permissions, exists
 }
 
 // HasPermission checks if a role has a specific permission
 func (s *service) HasPermission(role string, permission Permission) bool {
-	roleEnum := Role(role)
-	permissions, exists := RolePermissions[roleEnum]
+	permissions, exists := s.permissionsOf(Role(role))
 	if !exists {
 		s.logger.Warn("Unknown role", zap.String("role", role))
 		return false
@@ -137,8 +338,7 @@ func (s *service) HasPermission(role string, permission Permission) bool {
 
 // GetUserPermissions returns all permissions for a user role
 func (s *service) GetUserPermissions(role string) []Permission {
-	roleEnum := Role(role)
-	permissions, exists := RolePermissions[roleEnum]
+	permissions, exists := s.permissionsOf(Role(role))
 	if !exists {
 		s.logger.Warn("Unknown role", zap.String("role", role))
 		return []Permission{}
@@ -157,8 +357,7 @@ func (s *service) CanAccessResource(role string, resource string, action string)
 
 // ValidateRole checks if a role is valid
 func (s *service) ValidateRole(role string) bool {
-	roleEnum := Role(role)
-	_, exists := RolePermissions[roleEnum]
+	_, exists := s.permissionsOf(Role(role))
 	return exists
 }
 
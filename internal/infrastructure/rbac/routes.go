@@ -0,0 +1,85 @@
+package rbac
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/labstack/echo/v4"
+)
+
+// routeTable holds the permission declared by each route, keyed by method and
+// route path ("GET /api/v1/plaintes/:id")
+type routeTable struct {
+	mu    sync.RWMutex
+	perms map[string]Permission
+}
+
+var routes = &routeTable{perms: make(map[string]Permission)}
+
+// RequiredPermission returns the permission declared for a route. The path is
+// the route pattern as returned by echo.Context#Path.
+func RequiredPermission(method, path string) (Permission, bool) {
+	routes.mu.RLock()
+	defer routes.mu.RUnlock()
+	perm, ok := routes.perms[method+" "+path]
+	return perm, ok
+}
+
+func declare(route *echo.Route, perm Permission) {
+	routes.mu.Lock()
+	defer routes.mu.Unlock()
+	routes.perms[route.Method+" "+route.Path] = perm
+}
+
+// Group registers routes on an echo group together with the permission each of
+// them requires. The permission is enforced centrally by the
+// AuthMiddleware#RequirePermission middleware.
+type Group struct {
+	group *echo.Group
+}
+
+// Guard wraps an echo group
+func Guard(g *echo.Group) *Group {
+	return &Group{group: g}
+}
+
+// Group creates a sub-group
+func (g *Group) Group(prefix string, m ...echo.MiddlewareFunc) *Group {
+	return Guard(g.group.Group(prefix, m...))
+}
+
+// Use adds middlewares to the group
+func (g *Group) Use(m ...echo.MiddlewareFunc) {
+	g.group.Use(m...)
+}
+
+// GET registers a GET route requiring the permission
+func (g *Group) GET(path string, h echo.HandlerFunc, perm Permission, m ...echo.MiddlewareFunc) *echo.Route {
+	return g.add(http.MethodGet, path, h, perm, m)
+}
+
+// POST registers a POST route requiring the permission
+func (g *Group) POST(path string, h echo.HandlerFunc, perm Permission, m ...echo.MiddlewareFunc) *echo.Route {
+	return g.add(http.MethodPost, path, h, perm, m)
+}
+
+// PUT registers a PUT route requiring the permission
+func (g *Group) PUT(path string, h echo.HandlerFunc, perm Permission, m ...echo.MiddlewareFunc) *echo.Route {
+	return g.add(http.MethodPut, path, h, perm, m)
+}
+
+// PATCH registers a PATCH route requiring the permission
+func (g *Group) PATCH(path string, h echo.HandlerFunc, perm Permission, m ...echo.MiddlewareFunc) *echo.Route {
+	return g.add(http.MethodPatch, path, h, perm, m)
+}
+
+// DELETE registers a DELETE route requiring the permission
+func (g *Group) DELETE(path string, h echo.HandlerFunc, perm Permission, m ...echo.MiddlewareFunc) *echo.Route {
+	return g.add(http.MethodDelete, path, h, perm, m)
+}
+
+func (g *Group) add(method, path string, h echo.HandlerFunc, perm Permission, m []echo.MiddlewareFunc) *echo.Route {
+	route := g.group.Add(method, path, h, m...)
+	declare(route, perm)
+	return route
+}
@@ -0,0 +1,57 @@
+package realtime
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Audience tells who may receive an event. An event reaches a subscriber when
+// it is general, targets the subscriber's commissariat or names the agent.
+type Audience struct {
+	All             bool
+	CommissariatIDs []string
+	AgentIDs        []string
+}
+
+// Event is a message pushed to the subscribed clients
+type Event struct {
+	ID         int
+	Type       string
+	ResourceID string
+	Data       json.RawMessage
+	Audience   Audience
+	CreatedAt  time.Time
+}
+
+// Subscriber describes a connected client and the events it wants
+type Subscriber struct {
+	UserID         string
+	CommissariatID string
+	// SeeAll lets administrators follow the events of every commissariat
+	SeeAll bool
+	// Types restricts the event types; empty means all
+	Types map[string]bool
+}
+
+// Accepts reports whether the event is visible to the subscriber
+func (s *Subscriber) Accepts(e *Event) bool {
+	if len(s.Types) > 0 && !s.Types[e.Type] {
+		return false
+	}
+	if s.SeeAll || e.Audience.All {
+		return true
+	}
+	if s.CommissariatID != "" && contains(e.Audience.CommissariatIDs, s.CommissariatID) {
+		return true
+	}
+	return s.UserID != "" && contains(e.Audience.AgentIDs, s.UserID)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+package realtime
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscriberAccepts(t *testing.T) {
+	agent := &Subscriber{UserID: "agent-1", CommissariatID: "comm-1"}
+
+	tests := []struct {
+		name     string
+		sub      *Subscriber
+		event    *Event
+		expected bool
+	}{
+		{
+			name:     "general broadcast",
+			sub:      agent,
+			event:    &Event{Type: "alerte.broadcast", Audience: Audience{All: true}},
+			expected: true,
+		},
+		{
+			name:     "own commissariat",
+			sub:      agent,
+			event:    &Event{Type: "alerte.created", Audience: Audience{CommissariatIDs: []string{"comm-2", "comm-1"}}},
+			expected: true,
+		},
+		{
+			name:     "named agent",
+			sub:      agent,
+			event:    &Event{Type: "alerte.assigned", Audience: Audience{CommissariatIDs: []string{"comm-2"}, AgentIDs: []string{"agent-1"}}},
+			expected: true,
+		},
+		{
+			name:     "other commissariat",
+			sub:      agent,
+			event:    &Event{Type: "alerte.created", Audience: Audience{CommissariatIDs: []string{"comm-2"}}},
+			expected: false,
+		},
+		{
+			name:     "admin sees everything",
+			sub:      &Subscriber{UserID: "admin", SeeAll: true},
+			event:    &Event{Type: "alerte.created", Audience: Audience{CommissariatIDs: []string{"comm-2"}}},
+			expected: true,
+		},
+		{
+			name:     "type filter",
+			sub:      &Subscriber{UserID: "agent-1", CommissariatID: "comm-1", Types: map[string]bool{"alerte.resolved": true}},
+			event:    &Event{Type: "alerte.created", Audience: Audience{All: true}},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.sub.Accepts(tt.event))
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPollInterval = time.Second
+	// subscriptionBuffer is the number of events a slow client may lag behind
+	// before it is disconnected (it then reconnects and replays)
+	subscriptionBuffer = 64
+	pollBatch          = 500
+	// maxReplay bounds the events sent back after a reconnect
+	maxReplay = 1000
+)
+
+// Hub fans events out to the connected clients. Events are stored first, so
+// that clients can replay them after a reconnect and so that every instance
+// of the API delivers them to its own clients.
+type Hub interface {
+	Publish(ctx context.Context, eventType, resourceID string, data interface{}, audience Audience) error
+	Subscribe(sub *Subscriber) *Subscription
+	Replay(ctx context.Context, sub *Subscriber, afterID int) (events []*Event, truncated bool, err error)
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+}
+
+// Subscription receives the events accepted by its subscriber
+type Subscription struct {
+	// Events delivers the live events
+	Events <-chan *Event
+	// Done is closed when the hub drops the subscription (slow client, shutdown)
+	Done <-chan struct{}
+
+	sub    *Subscriber
+	events chan *Event
+	done   chan struct{}
+	once   sync.Once
+	hub    *hub
+}
+
+// Close unregisters the subscription
+func (s *Subscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+func (s *Subscription) drop() {
+	s.once.Do(func() { close(s.done) })
+}
+
+type hub struct {
+	repo         repository.StreamEventRepository
+	instance     string
+	pollInterval time.Duration
+	logger       *zap.Logger
+
+	mu            sync.RWMutex
+	subscriptions map[*Subscription]struct{}
+
+	lastPolled int
+	cancel     context.CancelFunc
+	wg         sync.WaitGroup
+}
+
+// NewHub creates the event hub
+func NewHub(repo repository.StreamEventRepository, cfg *config.Config, logger *zap.Logger) Hub {
+	pollInterval := cfg.Stream.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultPollInterval
+	}
+
+	instance := cfg.Scheduler.InstanceID
+	if instance == "" {
+		hostname, _ := os.Hostname()
+		instance = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+
+	return &hub{
+		repo:          repo,
+		instance:      instance,
+		pollInterval:  pollInterval,
+		logger:        logger,
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
+
+// Publish stores the event and delivers it to the local subscribers; the other
+// instances pick it up on their next poll
+func (h *hub) Publish(ctx context.Context, eventType, resourceID string, data interface{}, audience Audience) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to encode event payload: %w", err)
+	}
+
+	stored, err := h.repo.Create(ctx, &repository.CreateStreamEventInput{
+		Type:            eventType,
+		ResourceID:      resourceID,
+		Payload:         string(payload),
+		Broadcast:       audience.All,
+		CommissariatIDs: audience.CommissariatIDs,
+		AgentIDs:        audience.AgentIDs,
+		InstanceID:      h.instance,
+	})
+	if err != nil {
+		return err
+	}
+
+	h.dispatch(toEvent(stored))
+	return nil
+}
+
+// Subscribe registers a client
+func (h *hub) Subscribe(sub *Subscriber) *Subscription {
+	events := make(chan *Event, subscriptionBuffer)
+	done := make(chan struct{})
+	s := &Subscription{
+		Events: events,
+		Done:   done,
+		sub:    sub,
+		events: events,
+		done:   done,
+		hub:    h,
+	}
+
+	h.mu.Lock()
+	h.subscriptions[s] = struct{}{}
+	h.mu.Unlock()
+
+	return s
+}
+
+func (h *hub) unsubscribe(s *Subscription) {
+	h.mu.Lock()
+	delete(h.subscriptions, s)
+	h.mu.Unlock()
+	s.drop()
+}
+
+// Replay returns the stored events after afterID visible to the subscriber.
+// truncated is set when more than maxReplay events were missed: the client
+// should then reload its state through the REST API.
+func (h *hub) Replay(ctx context.Context, sub *Subscriber, afterID int) (events []*Event, truncated bool, err error) {
+	for {
+		stored, err := h.repo.ListAfter(ctx, afterID, pollBatch)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, se := range stored {
+			afterID = se.ID
+			if e := toEvent(se); sub.Accepts(e) {
+				if len(events) == maxReplay {
+					return events, true, nil
+				}
+				events = append(events, e)
+			}
+		}
+		if len(stored) < pollBatch {
+			return events, false, nil
+		}
+	}
+}
+
+// dispatch delivers an event to the matching subscribers without blocking:
+// a client whose buffer is full is dropped and will replay on reconnect
+func (h *hub) dispatch(e *Event) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for s := range h.subscriptions {
+		if !s.sub.Accepts(e) {
+			continue
+		}
+		select {
+		case s.events <- e:
+		default:
+			h.logger.Warn("Stream client too slow, dropping subscription",
+				zap.String("user_id", s.sub.UserID))
+			s.drop()
+		}
+	}
+}
+
+// Start begins polling the events published by the other instances
+func (h *hub) Start(ctx context.Context) error {
+	lastID, err := h.repo.LastID(ctx)
+	if err != nil {
+		return err
+	}
+	h.lastPolled = lastID
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+
+	h.wg.Add(1)
+	go h.poll(runCtx)
+
+	h.logger.Info("Event stream hub started",
+		zap.String("instance", h.instance),
+		zap.Duration("poll_interval", h.pollInterval))
+	return nil
+}
+
+// Stop ends polling and disconnects the clients
+func (h *hub) Stop(ctx context.Context) error {
+	if h.cancel == nil {
+		return nil
+	}
+	h.cancel()
+	h.wg.Wait()
+
+	h.mu.Lock()
+	for s := range h.subscriptions {
+		s.drop()
+		delete(h.subscriptions, s)
+	}
+	h.mu.Unlock()
+
+	return nil
+}
+
+func (h *hub) poll(ctx context.Context) {
+	defer h.wg.Done()
+
+	ticker := time.NewTicker(h.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			h.pollOnce(ctx)
+		}
+	}
+}
+
+// pollOnce delivers the events stored by the other instances since the last poll.
+// An event whose insert commits after a higher ID was polled is not delivered
+// live; the window is a few milliseconds and the event is still replayed.
+func (h *hub) pollOnce(ctx context.Context) {
+	stored, err := h.repo.ListAfter(ctx, h.lastPolled, pollBatch)
+	if err != nil {
+		if ctx.Err() == nil {
+			h.logger.Error("Failed to poll stream events", zap.Error(err))
+		}
+		return
+	}
+
+	for _, se := range stored {
+		h.lastPolled = se.ID
+		if se.InstanceID == h.instance {
+			// Déjà distribué localement lors de la publication
+			continue
+		}
+		h.dispatch(toEvent(se))
+	}
+}
+
+func toEvent(se *ent.StreamEvent) *Event {
+	data := json.RawMessage(se.Payload)
+	if len(data) == 0 {
+		data = json.RawMessage("null")
+	}
+
+	return &Event{
+		ID:         se.ID,
+		Type:       se.Type,
+		ResourceID: se.ResourceID,
+		Data:       data,
+		Audience: Audience{
+			All:             se.Broadcast,
+			CommissariatIDs: se.CommissariatIds,
+			AgentIDs:        se.AgentIds,
+		},
+		CreatedAt: se.CreatedAt,
+	}
+}
@@ -0,0 +1,34 @@
+package realtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/scheduler"
+)
+
+const defaultRetention = 24 * time.Hour
+
+// NewCleanupJob purges the events that can no longer be replayed
+func NewCleanupJob(repo repository.StreamEventRepository, cfg *config.Config) scheduler.Job {
+	retention := cfg.Stream.Retention
+	if retention <= 0 {
+		retention = defaultRetention
+	}
+
+	return scheduler.Job{
+		Name:        "stream-events-cleanup",
+		Description: "Supprime les événements temps réel au-delà de la durée de rejeu",
+		Schedule:    "15 * * * *",
+		Run: func(ctx context.Context) (string, error) {
+			deleted, err := repo.DeleteBefore(ctx, time.Now().Add(-retention))
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d événements supprimés", deleted), nil
+		},
+	}
+}
@@ -0,0 +1,28 @@
+package realtime
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+)
+
+// Module provides the real-time event hub and its cleanup job
+var Module = fx.Module("realtime",
+	fx.Provide(
+		NewHub,
+		fx.Annotate(
+			NewCleanupJob,
+			fx.ResultTags(`group:"jobs"`),
+		),
+	),
+	fx.Invoke(func(lc fx.Lifecycle, h Hub) {
+		lc.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				return h.Start(ctx)
+			},
+			OnStop: func(ctx context.Context) error {
+				return h.Stop(ctx)
+			},
+		})
+	}),
+)
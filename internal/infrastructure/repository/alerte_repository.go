@@ -8,6 +8,8 @@ import (
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/alertesecuritaire"
 	"police-trafic-api-frontend-aligned/ent/commissariat"
+	"police-trafic-api-frontend-aligned/ent/predicate"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -75,6 +77,11 @@ type UpdateAlerteInput struct {
 	Documents                []map[string]interface{}
 	Photos                   []string
 	Suivis                   []map[string]interface{}
+	// Ajouts en fin de liste, faits dans la requête SQL sans relire la liste
+	AppendTemoins            []map[string]interface{}
+	AppendDocuments          []map[string]interface{}
+	AppendPhotos             []string
+	AppendSuivis             []map[string]interface{}
 	Diffusee                 *bool
 	DateDiffusion            *time.Time
 	DiffusionDestinataires   map[string]interface{}
@@ -95,6 +102,7 @@ type AlerteFilters struct {
 	Search         *string
 	Limit          int
 	Offset         int
+	Page           *pagination.Params // Replaces Limit and Offset when set
 }
 
 // alerteRepository implements AlerteRepository
@@ -119,7 +127,7 @@ func (r *alerteRepository) Create(ctx context.Context, input *CreateAlerteInput)
 	commID, _ := uuid.Parse(input.CommissariatID)
 	agentID, _ := uuid.Parse(input.AgentRecepteurID)
 
-	create := r.client.AlerteSecuritaire.Create().
+	create := ClientFromContext(ctx, r.client).AlerteSecuritaire.Create().
 		SetID(id).
 		SetNumero(input.Numero).
 		SetTitre(input.Titre).
@@ -205,6 +213,7 @@ func (r *alerteRepository) GetByID(ctx context.Context, id string) (*ent.AlerteS
 // List gets alertes with filters
 func (r *alerteRepository) List(ctx context.Context, filters *AlerteFilters) ([]*ent.AlerteSecuritaire, error) {
 	query := r.client.AlerteSecuritaire.Query()
+	order := ent.Desc(alertesecuritaire.FieldDateAlerte)
 
 	if filters != nil {
 		r.logger.Info("Applying filters to alertes query",
@@ -220,18 +229,26 @@ func (r *alerteRepository) List(ctx context.Context, filters *AlerteFilters) ([]
 
 		query = r.applyFilters(query, filters)
 
-		if filters.Limit > 0 {
-			query = query.Limit(filters.Limit)
-		}
-		if filters.Offset > 0 {
-			query = query.Offset(filters.Offset)
+		if page := filters.Page; page != nil {
+			// Les pages suivantes partent de la dernière alerte lue
+			query = query.Where(predicate.AlerteSecuritaire(page.Where())).
+				Offset(page.Offset()).
+				Limit(page.FetchLimit())
+			order = page.OrderBy()
+		} else {
+			if filters.Limit > 0 {
+				query = query.Limit(filters.Limit)
+			}
+			if filters.Offset > 0 {
+				query = query.Offset(filters.Offset)
+			}
 		}
 	}
 
 	alerteList, err := query.
 		WithCommissariat().
 		WithAgent().
-		Order(ent.Desc(alertesecuritaire.FieldDateAlerte)).
+		Order(order).
 		All(ctx)
 
 	if err != nil {
@@ -250,6 +267,7 @@ func (r *alerteRepository) Count(ctx context.Context, filters *AlerteFilters) (i
 
 	if filters != nil {
 		query = r.applyFilters(query, filters)
+		query = query.Where(predicate.AlerteSecuritaire(filters.Page.Filter()))
 	}
 
 	count, err := query.Count(ctx)
@@ -373,6 +391,18 @@ func (r *alerteRepository) Update(ctx context.Context, id string, input *UpdateA
 	if input.Suivis != nil {
 		update = update.SetSuivis(input.Suivis)
 	}
+	if len(input.AppendTemoins) > 0 {
+		update = update.AppendTemoins(input.AppendTemoins)
+	}
+	if len(input.AppendDocuments) > 0 {
+		update = update.AppendDocuments(input.AppendDocuments)
+	}
+	if len(input.AppendPhotos) > 0 {
+		update = update.AppendPhotos(input.AppendPhotos)
+	}
+	if len(input.AppendSuivis) > 0 {
+		update = update.AppendSuivis(input.AppendSuivis)
+	}
 
 	// Diffusion
 	if input.Diffusee != nil {
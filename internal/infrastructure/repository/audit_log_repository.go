@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/auditlog"
+	"police-trafic-api-frontend-aligned/ent/predicate"
+	"police-trafic-api-frontend-aligned/ent/user"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// AuditLogRepository defines audit log repository interface
+type AuditLogRepository interface {
+	Create(ctx context.Context, input *CreateAuditLogInput) (*ent.AuditLog, error)
+	GetByID(ctx context.Context, id string) (*ent.AuditLog, error)
+	List(ctx context.Context, filters *AuditLogFilters) ([]*ent.AuditLog, error)
+	Count(ctx context.Context, filters *AuditLogFilters) (int, error)
+}
+
+// CreateAuditLogInput represents input for creating an audit log entry
+type CreateAuditLogInput struct {
+	Timestamp    time.Time
+	Action       string
+	ResourceType string
+	ResourceID   string
+	UserID       string
+	UserAgent    string
+	IPAddress    string
+	Details      string
+	OldValues    string
+	NewValues    string
+	SessionID    string
+	Status       string
+	ErrorMessage string
+}
+
+// AuditLogFilters represents filters for listing audit logs
+type AuditLogFilters struct {
+	UserID       *string
+	Action       *string
+	ResourceType *string
+	ResourceID   *string
+	Status       *string
+	IPAddress    *string
+	DateDebut    *time.Time
+	DateFin      *time.Time
+	Limit        int
+	Offset       int
+	After        *AuditLogKey       // Keyset position, replaces Offset when set
+	Page         *pagination.Params // Replaces Limit and Offset when set
+}
+
+// AuditLogKey identifies an entry in the (timestamp, id) descending order of List
+type AuditLogKey struct {
+	Timestamp time.Time
+	ID        uuid.UUID
+}
+
+// auditLogRepository implements AuditLogRepository
+type auditLogRepository struct {
+	client *ent.Client
+	logger *zap.Logger
+}
+
+// NewAuditLogRepository creates a new audit log repository
+func NewAuditLogRepository(client *ent.Client, logger *zap.Logger) AuditLogRepository {
+	return &auditLogRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Create creates a new audit log entry
+func (r *auditLogRepository) Create(ctx context.Context, input *CreateAuditLogInput) (*ent.AuditLog, error) {
+	create := r.client.AuditLog.Create().
+		SetAction(input.Action).
+		SetResourceType(input.ResourceType)
+
+	if !input.Timestamp.IsZero() {
+		create = create.SetTimestamp(input.Timestamp)
+	}
+	if input.Status != "" {
+		create = create.SetStatus(input.Status)
+	}
+	if input.ResourceID != "" {
+		create = create.SetResourceID(input.ResourceID)
+	}
+	if input.UserAgent != "" {
+		create = create.SetUserAgent(input.UserAgent)
+	}
+	if input.IPAddress != "" {
+		create = create.SetIPAddress(input.IPAddress)
+	}
+	if input.Details != "" {
+		create = create.SetDetails(input.Details)
+	}
+	if input.OldValues != "" {
+		create = create.SetOldValues(input.OldValues)
+	}
+	if input.NewValues != "" {
+		create = create.SetNewValues(input.NewValues)
+	}
+	if input.SessionID != "" {
+		create = create.SetSessionID(input.SessionID)
+	}
+	if input.ErrorMessage != "" {
+		create = create.SetErrorMessage(input.ErrorMessage)
+	}
+	// Les utilisateurs mock n'ont pas d'UUID: on garde alors la trace dans details
+	if userID, err := uuid.Parse(input.UserID); err == nil {
+		create = create.SetUserID(userID)
+	}
+
+	entry, err := create.Save(ctx)
+	if err != nil {
+		r.logger.Error("Failed to create audit log", zap.Error(err))
+		return nil, fmt.Errorf("failed to create audit log: %w", err)
+	}
+
+	return entry, nil
+}
+
+// GetByID gets audit log entry by ID
+func (r *auditLogRepository) GetByID(ctx context.Context, id string) (*ent.AuditLog, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("audit log not found")
+	}
+
+	entry, err := r.client.AuditLog.
+		Query().
+		Where(auditlog.ID(uid)).
+		WithUser().
+		Only(ctx)
+
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("audit log not found")
+		}
+		r.logger.Error("Failed to get audit log by ID", zap.String("id", id), zap.Error(err))
+		return nil, fmt.Errorf("failed to get audit log: %w", err)
+	}
+
+	return entry, nil
+}
+
+// List gets audit log entries with filters, most recent first
+func (r *auditLogRepository) List(ctx context.Context, filters *AuditLogFilters) ([]*ent.AuditLog, error) {
+	query := r.client.AuditLog.Query()
+	order := ent.Desc(auditlog.FieldTimestamp, auditlog.FieldID)
+
+	if filters != nil {
+		query = r.applyFilters(query, filters)
+
+		if page := filters.Page; page != nil {
+			// Les pages suivantes partent de la dernière entrée lue
+			query = query.Where(predicate.AuditLog(page.Where())).
+				Offset(page.Offset()).
+				Limit(page.FetchLimit())
+			order = page.OrderBy()
+		} else {
+			if filters.Limit > 0 {
+				query = query.Limit(filters.Limit)
+			}
+			if after := filters.After; after != nil {
+				// Reprise après la dernière entrée lue, sans Offset à parcourir
+				query = query.Where(auditlog.Or(
+					auditlog.TimestampLT(after.Timestamp),
+					auditlog.And(auditlog.Timestamp(after.Timestamp), auditlog.IDLT(after.ID)),
+				))
+			} else if filters.Offset > 0 {
+				query = query.Offset(filters.Offset)
+			}
+		}
+	}
+
+	entries, err := query.
+		WithUser().
+		Order(order).
+		All(ctx)
+
+	if err != nil {
+		r.logger.Error("Failed to list audit logs", zap.Error(err))
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+
+	return entries, nil
+}
+
+// Count counts audit log entries with filters
+func (r *auditLogRepository) Count(ctx context.Context, filters *AuditLogFilters) (int, error) {
+	query := r.client.AuditLog.Query()
+
+	if filters != nil {
+		query = r.applyFilters(query, filters)
+		query = query.Where(predicate.AuditLog(filters.Page.Filter()))
+	}
+
+	count, err := query.Count(ctx)
+	if err != nil {
+		r.logger.Error("Failed to count audit logs", zap.Error(err))
+		return 0, fmt.Errorf("failed to count audit logs: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *auditLogRepository) applyFilters(query *ent.AuditLogQuery, filters *AuditLogFilters) *ent.AuditLogQuery {
+	if filters.UserID != nil && *filters.UserID != "" {
+		if userID, err := uuid.Parse(*filters.UserID); err == nil {
+			query = query.Where(auditlog.HasUserWith(user.ID(userID)))
+		} else {
+			// Identifiant non UUID: recherche par matricule
+			query = query.Where(auditlog.HasUserWith(user.Matricule(*filters.UserID)))
+		}
+	}
+	if filters.Action != nil && *filters.Action != "" {
+		query = query.Where(auditlog.Action(*filters.Action))
+	}
+	if filters.ResourceType != nil && *filters.ResourceType != "" {
+		query = query.Where(auditlog.ResourceType(*filters.ResourceType))
+	}
+	if filters.ResourceID != nil && *filters.ResourceID != "" {
+		query = query.Where(auditlog.ResourceID(*filters.ResourceID))
+	}
+	if filters.Status != nil && *filters.Status != "" {
+		query = query.Where(auditlog.Status(*filters.Status))
+	}
+	if filters.IPAddress != nil && *filters.IPAddress != "" {
+		query = query.Where(auditlog.IPAddress(*filters.IPAddress))
+	}
+	if filters.DateDebut != nil {
+		query = query.Where(auditlog.TimestampGTE(*filters.DateDebut))
+	}
+	if filters.DateFin != nil {
+		query = query.Where(auditlog.TimestampLTE(*filters.DateFin))
+	}
+
+	return query
+}
@@ -6,6 +6,12 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/commissariat"
+	"police-trafic-api-frontend-aligned/ent/controle"
+	"police-trafic-api-frontend-aligned/ent/infraction"
+	"police-trafic-api-frontend-aligned/ent/inspection"
+	"police-trafic-api-frontend-aligned/ent/predicate"
+	"police-trafic-api-frontend-aligned/ent/procesverbal"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -22,6 +28,8 @@ type CommissariatRepository interface {
 	Delete(ctx context.Context, id string) error
 	GetByRegion(ctx context.Context, region string) ([]*ent.Commissariat, error)
 	GetByVille(ctx context.Context, ville string) ([]*ent.Commissariat, error)
+	FindIDForPV(ctx context.Context, pvID string) (string, error)
+	FindIDForPVSources(ctx context.Context, controleID, inspectionID *string, infractionIDs []string) (string, error)
 }
 
 // CreateCommissariatInput represents input for creating commissariat
@@ -58,6 +66,7 @@ type CommissariatFilters struct {
 	Actif  *bool
 	Limit  int
 	Offset int
+	Page   *pagination.Params // Replaces Limit and Offset when set
 }
 
 // commissariatRepository implements CommissariatRepository
@@ -149,6 +158,7 @@ func (r *commissariatRepository) GetByCode(ctx context.Context, code string) (*e
 // List gets commissariats with filters
 func (r *commissariatRepository) List(ctx context.Context, filters *CommissariatFilters) ([]*ent.Commissariat, error) {
 	query := r.client.Commissariat.Query()
+	order := ent.Asc(commissariat.FieldNom)
 
 	if filters != nil {
 		if filters.Region != "" {
@@ -160,17 +170,25 @@ func (r *commissariatRepository) List(ctx context.Context, filters *Commissariat
 		if filters.Actif != nil {
 			query = query.Where(commissariat.Actif(*filters.Actif))
 		}
-		if filters.Limit > 0 {
-			query = query.Limit(filters.Limit)
-		}
-		if filters.Offset > 0 {
-			query = query.Offset(filters.Offset)
+		if page := filters.Page; page != nil {
+			// Les pages suivantes partent du dernier commissariat lu
+			query = query.Where(predicate.Commissariat(page.Where())).
+				Offset(page.Offset()).
+				Limit(page.FetchLimit())
+			order = page.OrderBy()
+		} else {
+			if filters.Limit > 0 {
+				query = query.Limit(filters.Limit)
+			}
+			if filters.Offset > 0 {
+				query = query.Offset(filters.Offset)
+			}
 		}
 	}
 
 	commList, err := query.
 		WithAgents().
-		Order(ent.Asc(commissariat.FieldNom)).
+		Order(order).
 		All(ctx)
 
 	if err != nil {
@@ -195,6 +213,7 @@ func (r *commissariatRepository) Count(ctx context.Context, filters *Commissaria
 		if filters.Actif != nil {
 			query = query.Where(commissariat.Actif(*filters.Actif))
 		}
+		query = query.Where(predicate.Commissariat(filters.Page.Filter()))
 	}
 
 	count, err := query.Count(ctx)
@@ -297,3 +316,66 @@ func (r *commissariatRepository) GetByVille(ctx context.Context, ville string) (
 
 	return commList, nil
 }
+
+// FindIDForPV returns the commissariat of a PV, through its control, its
+// inspection or the control of its infractions. It is empty when unknown.
+func (r *commissariatRepository) FindIDForPV(ctx context.Context, pvID string) (string, error) {
+	uid, err := uuid.Parse(pvID)
+	if err != nil {
+		return "", fmt.Errorf("invalid PV ID: %w", err)
+	}
+	pv := procesverbal.ID(uid)
+
+	return r.findID(ctx, commissariat.Or(
+		commissariat.HasControlesWith(controle.Or(
+			controle.HasProcesVerbalWith(pv),
+			controle.HasInfractionsWith(infraction.HasProcesVerbalWith(pv)),
+		)),
+		commissariat.HasInspectionsWith(inspection.HasProcesVerbalWith(pv)),
+	))
+}
+
+// FindIDForPVSources returns the commissariat of a PV about to be created
+// from a control, an inspection or infractions. It is empty when unknown.
+func (r *commissariatRepository) FindIDForPVSources(ctx context.Context, controleID, inspectionID *string, infractionIDs []string) (string, error) {
+	var preds []predicate.Commissariat
+
+	if controleID != nil {
+		if uid, err := uuid.Parse(*controleID); err == nil {
+			preds = append(preds, commissariat.HasControlesWith(controle.ID(uid)))
+		}
+	}
+	if inspectionID != nil {
+		if uid, err := uuid.Parse(*inspectionID); err == nil {
+			preds = append(preds, commissariat.HasInspectionsWith(inspection.ID(uid)))
+		}
+	}
+	if len(infractionIDs) > 0 {
+		uids := make([]uuid.UUID, 0, len(infractionIDs))
+		for _, id := range infractionIDs {
+			if uid, err := uuid.Parse(id); err == nil {
+				uids = append(uids, uid)
+			}
+		}
+		preds = append(preds, commissariat.HasControlesWith(controle.HasInfractionsWith(infraction.IDIn(uids...))))
+	}
+
+	if len(preds) == 0 {
+		return "", nil
+	}
+	return r.findID(ctx, commissariat.Or(preds...))
+}
+
+func (r *commissariatRepository) findID(ctx context.Context, pred predicate.Commissariat) (string, error) {
+	id, err := r.client.Commissariat.Query().
+		Where(pred).
+		FirstID(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to find commissariat: %w", err)
+	}
+
+	return id.String(), nil
+}
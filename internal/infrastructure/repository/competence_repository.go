@@ -7,6 +7,8 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/competence"
+	"police-trafic-api-frontend-aligned/ent/predicate"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -24,6 +26,7 @@ type CompetenceRepository interface {
 	RemoveFromAgent(ctx context.Context, competenceID, agentID string) error
 	GetByAgent(ctx context.Context, agentID string) ([]*ent.Competence, error)
 	GetExpiring(ctx context.Context, daysAhead int) ([]*ent.Competence, error)
+	DeactivateExpired(ctx context.Context) (int, error)
 }
 
 // CompetenceFilters represents filters for listing competences
@@ -32,6 +35,7 @@ type CompetenceFilters struct {
 	Active    *bool
 	Search    *string
 	Organisme *string
+	Page      *pagination.Params // Reads one page when set
 }
 
 // CreateCompetenceInput represents input for creating competence
@@ -161,7 +165,16 @@ func (r *competenceRepository) List(ctx context.Context, filters *CompetenceFilt
 		}
 	}
 
-	competences, err := query.Order(ent.Asc(competence.FieldNom)).All(ctx)
+	order := ent.Asc(competence.FieldNom)
+	if filters != nil && filters.Page != nil {
+		// Les pages suivantes partent de la dernière compétence lue
+		query = query.Where(predicate.Competence(filters.Page.Where())).
+			Offset(filters.Page.Offset()).
+			Limit(filters.Page.FetchLimit())
+		order = filters.Page.OrderBy()
+	}
+
+	competences, err := query.Order(order).All(ctx)
 	if err != nil {
 		r.logger.Error("Failed to list competences", zap.Error(err))
 		return nil, fmt.Errorf("failed to list competences: %w", err)
@@ -289,3 +302,21 @@ func (r *competenceRepository) GetExpiring(ctx context.Context, daysAhead int) (
 
 	return competences, nil
 }
+
+// DeactivateExpired deactivates active competences whose expiration date has passed
+func (r *competenceRepository) DeactivateExpired(ctx context.Context) (int, error) {
+	affected, err := r.client.Competence.Update().
+		Where(
+			competence.Active(true),
+			competence.DateExpirationLT(time.Now()),
+		).
+		SetActive(false).
+		Save(ctx)
+
+	if err != nil {
+		r.logger.Error("Failed to deactivate expired competences", zap.Error(err))
+		return 0, fmt.Errorf("failed to deactivate expired competences: %w", err)
+	}
+
+	return affected, nil
+}
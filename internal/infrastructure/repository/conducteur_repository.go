@@ -8,6 +8,8 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/conducteur"
+	"police-trafic-api-frontend-aligned/ent/predicate"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -75,6 +77,7 @@ type ConducteurFilters struct {
 	Active      *bool
 	Limit       int
 	Offset      int
+	Page        *pagination.Params // Replaces Limit and Offset when set
 }
 
 // conducteurRepository implements ConducteurRepository
@@ -190,6 +193,7 @@ func (r *conducteurRepository) GetByNumeroPermis(ctx context.Context, numeroPerm
 // List gets conducteurs with filters
 func (r *conducteurRepository) List(ctx context.Context, filters *ConducteurFilters) ([]*ent.Conducteur, error) {
 	query := r.client.Conducteur.Query()
+	order := ent.Desc(conducteur.FieldCreatedAt)
 
 	if filters != nil {
 		if filters.Nom != nil {
@@ -208,17 +212,25 @@ func (r *conducteurRepository) List(ctx context.Context, filters *ConducteurFilt
 			query = query.Where(conducteur.Active(*filters.Active))
 		}
 
-		if filters.Limit > 0 {
-			query = query.Limit(filters.Limit)
-		}
-		if filters.Offset > 0 {
-			query = query.Offset(filters.Offset)
+		if page := filters.Page; page != nil {
+			// Les pages suivantes partent du dernier conducteur lu
+			query = query.Where(predicate.Conducteur(page.Where())).
+				Offset(page.Offset()).
+				Limit(page.FetchLimit())
+			order = page.OrderBy()
+		} else {
+			if filters.Limit > 0 {
+				query = query.Limit(filters.Limit)
+			}
+			if filters.Offset > 0 {
+				query = query.Offset(filters.Offset)
+			}
 		}
 	}
 
 	conducteurs, err := query.
 		WithControles().
-		Order(ent.Desc(conducteur.FieldCreatedAt)).
+		Order(order).
 		All(ctx)
 
 	if err != nil {
@@ -9,8 +9,10 @@ import (
 	"police-trafic-api-frontend-aligned/ent/commissariat"
 	"police-trafic-api-frontend-aligned/ent/conducteur"
 	"police-trafic-api-frontend-aligned/ent/controle"
+	"police-trafic-api-frontend-aligned/ent/predicate"
 	"police-trafic-api-frontend-aligned/ent/user"
 	"police-trafic-api-frontend-aligned/ent/vehicule"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -99,6 +101,7 @@ type ControleFilters struct {
 	IsArchived              *bool // Filter by archive status
 	Limit                   int
 	Offset                  int
+	Page                    *pagination.Params // Replaces Limit and Offset when set
 }
 
 // ControleStatsFilters represents filters for statistics
@@ -246,6 +249,7 @@ func (r *controleRepository) GetByID(ctx context.Context, id string) (*ent.Contr
 // List gets controles with filters
 func (r *controleRepository) List(ctx context.Context, filters *ControleFilters) ([]*ent.Controle, error) {
 	query := r.client.Controle.Query()
+	order := ent.Desc(controle.FieldDateControle)
 
 	if filters != nil {
 		if filters.AgentID != nil {
@@ -286,11 +290,19 @@ func (r *controleRepository) List(ctx context.Context, filters *ControleFilters)
 			query = query.Where(controle.IsArchivedEQ(*filters.IsArchived))
 		}
 
-		if filters.Limit > 0 {
-			query = query.Limit(filters.Limit)
-		}
-		if filters.Offset > 0 {
-			query = query.Offset(filters.Offset)
+		if page := filters.Page; page != nil {
+			// Les pages suivantes partent du dernier contrôle lu, sans OFFSET
+			query = query.Where(predicate.Controle(page.Where())).
+				Offset(page.Offset()).
+				Limit(page.FetchLimit())
+			order = page.OrderBy()
+		} else {
+			if filters.Limit > 0 {
+				query = query.Limit(filters.Limit)
+			}
+			if filters.Offset > 0 {
+				query = query.Offset(filters.Offset)
+			}
 		}
 	}
 
@@ -300,7 +312,7 @@ func (r *controleRepository) List(ctx context.Context, filters *ControleFilters)
 		WithConducteur().
 		WithCommissariat().
 		WithInfractions().
-		Order(ent.Desc(controle.FieldDateControle)).
+		Order(order).
 		All(ctx)
 
 	if err != nil {
@@ -347,6 +359,7 @@ func (r *controleRepository) Count(ctx context.Context, filters *ControleFilters
 		if filters.IsArchived != nil {
 			query = query.Where(controle.IsArchivedEQ(*filters.IsArchived))
 		}
+		query = query.Where(predicate.Controle(filters.Page.Filter()))
 	}
 
 	count, err := query.Count(ctx)
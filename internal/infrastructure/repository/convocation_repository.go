@@ -8,7 +8,9 @@ import (
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/commissariat"
 	"police-trafic-api-frontend-aligned/ent/convocation"
+	"police-trafic-api-frontend-aligned/ent/predicate"
 	"police-trafic-api-frontend-aligned/ent/user"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -36,9 +38,9 @@ type ConvocationFilters struct {
 	DateDebut       *time.Time
 	DateFin         *time.Time
 	Search          *string
-	Page            int
 	Limit           int
 	Offset          int
+	Page            *pagination.Params // Replaces Limit and Offset when set
 }
 
 // convocationRepository implements ConvocationRepository
@@ -138,22 +140,31 @@ func (r *convocationRepository) GetByNumero(ctx context.Context, numero string)
 // List gets convocations with filters
 func (r *convocationRepository) List(ctx context.Context, filters *ConvocationFilters) ([]*ent.Convocation, error) {
 	query := r.client.Convocation.Query()
+	order := ent.Desc(convocation.FieldDateCreation)
 
 	if filters != nil {
 		query = r.applyFilters(query, filters)
 
-		if filters.Limit > 0 {
-			query = query.Limit(filters.Limit)
-		}
-		if filters.Offset > 0 {
-			query = query.Offset(filters.Offset)
+		if page := filters.Page; page != nil {
+			// Les pages suivantes partent de la dernière convocation lue
+			query = query.Where(predicate.Convocation(page.Where())).
+				Offset(page.Offset()).
+				Limit(page.FetchLimit())
+			order = page.OrderBy()
+		} else {
+			if filters.Limit > 0 {
+				query = query.Limit(filters.Limit)
+			}
+			if filters.Offset > 0 {
+				query = query.Offset(filters.Offset)
+			}
 		}
 	}
 
 	convs, err := query.
 		WithAgent().
 		WithCommissariat().
-		Order(ent.Desc(convocation.FieldDateCreation)).
+		Order(order).
 		All(ctx)
 
 	if err != nil {
@@ -170,6 +181,7 @@ func (r *convocationRepository) Count(ctx context.Context, filters *ConvocationF
 
 	if filters != nil {
 		query = r.applyFilters(query, filters)
+		query = query.Where(predicate.Convocation(filters.Page.Filter()))
 	}
 
 	count, err := query.Count(ctx)
@@ -2,21 +2,29 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/controle"
 	"police-trafic-api-frontend-aligned/ent/document"
+	"police-trafic-api-frontend-aligned/ent/documentdownload"
 	"police-trafic-api-frontend-aligned/ent/infraction"
+	"police-trafic-api-frontend-aligned/ent/predicate"
 	"police-trafic-api-frontend-aligned/ent/procesverbal"
 	"police-trafic-api-frontend-aligned/ent/recours"
 	"police-trafic-api-frontend-aligned/ent/user"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// ErrDownloadLinkUsed is returned by RecordDownload for a single-use link
+// already used
+var ErrDownloadLinkUsed = errors.New("single-use download link already used")
+
 // DocumentRepository defines document repository interface
 type DocumentRepository interface {
 	Create(ctx context.Context, input *CreateDocumentInput) (*ent.Document, error)
@@ -31,6 +39,24 @@ type DocumentRepository interface {
 	GetByRecours(ctx context.Context, recoursID string) ([]*ent.Document, error)
 	GetByUploader(ctx context.Context, userID string) ([]*ent.Document, error)
 	GetByType(ctx context.Context, typeDocument string) ([]*ent.Document, error)
+	// RecordDownload records a download through a signed link, or returns
+	// ErrDownloadLinkUsed if the link is single-use and was already used
+	RecordDownload(ctx context.Context, input *DocumentDownloadInput) (*ent.DocumentDownload, error)
+	// DeleteDownload removes a download that could not be served, freeing
+	// its single-use link
+	DeleteDownload(ctx context.Context, id uuid.UUID) error
+	ListDownloads(ctx context.Context, documentID string) ([]*ent.DocumentDownload, error)
+}
+
+// DocumentDownloadInput represents a download through a signed link
+type DocumentDownloadInput struct {
+	DocumentID    uuid.UUID
+	Nonce         string
+	SingleUse     bool
+	Public        bool
+	LinkExpiresAt time.Time
+	IPAddress     string
+	UserAgent     string
 }
 
 // CreateDocumentInput represents input for creating document
@@ -73,6 +99,7 @@ type DocumentFilters struct {
 	DateFin        *time.Time
 	Limit          int
 	Offset         int
+	Page           *pagination.Params // Replaces Limit and Offset when set
 }
 
 // documentRepository implements DocumentRepository
@@ -167,21 +194,30 @@ func (r *documentRepository) GetByID(ctx context.Context, id string) (*ent.Docum
 // List gets documents with filters
 func (r *documentRepository) List(ctx context.Context, filters *DocumentFilters) ([]*ent.Document, error) {
 	query := r.client.Document.Query()
+	order := ent.Desc(document.FieldCreatedAt)
 
 	if filters != nil {
 		query = r.applyFilters(query, filters)
 
-		if filters.Limit > 0 {
-			query = query.Limit(filters.Limit)
-		}
-		if filters.Offset > 0 {
-			query = query.Offset(filters.Offset)
+		if page := filters.Page; page != nil {
+			// Les pages suivantes partent du dernier document lu
+			query = query.Where(predicate.Document(page.Where())).
+				Offset(page.Offset()).
+				Limit(page.FetchLimit())
+			order = page.OrderBy()
+		} else {
+			if filters.Limit > 0 {
+				query = query.Limit(filters.Limit)
+			}
+			if filters.Offset > 0 {
+				query = query.Offset(filters.Offset)
+			}
 		}
 	}
 
 	documents, err := query.
 		WithUploadedBy().
-		Order(ent.Desc(document.FieldCreatedAt)).
+		Order(order).
 		All(ctx)
 
 	if err != nil {
@@ -198,6 +234,7 @@ func (r *documentRepository) Count(ctx context.Context, filters *DocumentFilters
 
 	if filters != nil {
 		query = r.applyFilters(query, filters)
+		query = query.Where(predicate.Document(filters.Page.Filter()))
 	}
 
 	count, err := query.Count(ctx)
@@ -395,3 +432,47 @@ func (r *documentRepository) GetByType(ctx context.Context, typeDocument string)
 
 	return documents, nil
 }
+
+// RecordDownload records a download through a signed link
+func (r *documentRepository) RecordDownload(ctx context.Context, input *DocumentDownloadInput) (*ent.DocumentDownload, error) {
+	download, err := r.client.DocumentDownload.Create().
+		SetDocumentID(input.DocumentID).
+		SetNonce(input.Nonce).
+		SetSingleUse(input.SingleUse).
+		SetPublic(input.Public).
+		SetLinkExpiresAt(input.LinkExpiresAt).
+		SetIPAddress(input.IPAddress).
+		SetUserAgent(input.UserAgent).
+		Save(ctx)
+	if err != nil {
+		if input.SingleUse && ent.IsConstraintError(err) {
+			return nil, ErrDownloadLinkUsed
+		}
+		return nil, fmt.Errorf("failed to record document download: %w", err)
+	}
+
+	return download, nil
+}
+
+// DeleteDownload removes a download through a signed link
+func (r *documentRepository) DeleteDownload(ctx context.Context, id uuid.UUID) error {
+	if err := r.client.DocumentDownload.DeleteOneID(id).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete document download: %w", err)
+	}
+
+	return nil
+}
+
+// ListDownloads lists the downloads of a document through signed links, newest first
+func (r *documentRepository) ListDownloads(ctx context.Context, documentID string) ([]*ent.DocumentDownload, error) {
+	id, _ := uuid.Parse(documentID)
+	downloads, err := r.client.DocumentDownload.Query().
+		Where(documentdownload.DocumentID(id)).
+		Order(ent.Desc(documentdownload.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list document downloads: %w", err)
+	}
+
+	return downloads, nil
+}
@@ -6,6 +6,8 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/equipe"
+	"police-trafic-api-frontend-aligned/ent/predicate"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -29,6 +31,7 @@ type EquipeFilters struct {
 	CommissariatID *string
 	Active         *bool
 	Search         *string
+	Page           *pagination.Params // Reads one page when set
 }
 
 // CreateEquipeInput represents input for creating equipe
@@ -163,7 +166,16 @@ func (r *equipeRepository) List(ctx context.Context, filters *EquipeFilters) ([]
 		}
 	}
 
-	equipes, err := query.Order(ent.Asc(equipe.FieldNom)).All(ctx)
+	order := ent.Asc(equipe.FieldNom)
+	if filters != nil && filters.Page != nil {
+		// Les pages suivantes partent de la dernière équipe lue
+		query = query.Where(predicate.Equipe(filters.Page.Where())).
+			Offset(filters.Page.Offset()).
+			Limit(filters.Page.FetchLimit())
+		order = filters.Page.OrderBy()
+	}
+
+	equipes, err := query.Order(order).All(ctx)
 	if err != nil {
 		r.logger.Error("Failed to list equipes", zap.Error(err))
 		return nil, fmt.Errorf("failed to list equipes: %w", err)
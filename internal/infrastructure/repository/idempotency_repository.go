@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/idempotencykey"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// IdempotencyRepository defines the idempotency key repository interface
+type IdempotencyRepository interface {
+	// Reserve records a new key, locked until input.LockedUntil. It returns
+	// false, without error, when the key is already recorded.
+	Reserve(ctx context.Context, input *ReserveIdempotencyKeyInput) (*ent.IdempotencyKey, bool, error)
+	Get(ctx context.Context, userID, route, key string) (*ent.IdempotencyKey, error)
+	// TakeOver locks again a key whose request never completed, after its
+	// lock expired; only one server instance wins
+	TakeOver(ctx context.Context, id uuid.UUID, now, lockedUntil time.Time) (bool, error)
+	Complete(ctx context.Context, id uuid.UUID, input *CompleteIdempotencyKeyInput) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	DeleteExpired(ctx context.Context, before time.Time) (int, error)
+}
+
+// ReserveIdempotencyKeyInput represents input for reserving an idempotency key
+type ReserveIdempotencyKeyInput struct {
+	Key         string
+	UserID      string
+	Route       string
+	RequestHash string
+	LockedUntil time.Time
+	ExpiresAt   time.Time
+}
+
+// CompleteIdempotencyKeyInput represents the response stored for a key
+type CompleteIdempotencyKeyInput struct {
+	StatusCode  int
+	ContentType string
+	Body        []byte
+}
+
+// idempotencyRepository implements IdempotencyRepository
+type idempotencyRepository struct {
+	client *ent.Client
+	logger *zap.Logger
+}
+
+// NewIdempotencyRepository creates a new idempotency key repository
+func NewIdempotencyRepository(client *ent.Client, logger *zap.Logger) IdempotencyRepository {
+	return &idempotencyRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Reserve relies on the unique (user_id, route, key) index so that only one
+// of concurrent requests, on any server instance, gets the key
+func (r *idempotencyRepository) Reserve(ctx context.Context, input *ReserveIdempotencyKeyInput) (*ent.IdempotencyKey, bool, error) {
+	record, err := r.client.IdempotencyKey.Create().
+		SetKey(input.Key).
+		SetUserID(input.UserID).
+		SetRoute(input.Route).
+		SetRequestHash(input.RequestHash).
+		SetLockedUntil(input.LockedUntil).
+		SetExpiresAt(input.ExpiresAt).
+		Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to reserve idempotency key: %w", err)
+	}
+
+	return record, true, nil
+}
+
+func (r *idempotencyRepository) Get(ctx context.Context, userID, route, key string) (*ent.IdempotencyKey, error) {
+	record, err := r.client.IdempotencyKey.Query().
+		Where(
+			idempotencykey.UserID(userID),
+			idempotencykey.Route(route),
+			idempotencykey.Key(key),
+		).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	return record, nil
+}
+
+func (r *idempotencyRepository) TakeOver(ctx context.Context, id uuid.UUID, now, lockedUntil time.Time) (bool, error) {
+	affected, err := r.client.IdempotencyKey.Update().
+		Where(
+			idempotencykey.ID(id),
+			idempotencykey.StatusCodeIsNil(),
+			idempotencykey.LockedUntilLT(now),
+		).
+		SetLockedUntil(lockedUntil).
+		Save(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to take over idempotency key: %w", err)
+	}
+
+	return affected == 1, nil
+}
+
+func (r *idempotencyRepository) Complete(ctx context.Context, id uuid.UUID, input *CompleteIdempotencyKeyInput) error {
+	err := r.client.IdempotencyKey.UpdateOneID(id).
+		SetStatusCode(input.StatusCode).
+		SetContentType(input.ContentType).
+		SetResponseBody(input.Body).
+		ClearLockedUntil().
+		SetCompletedAt(time.Now()).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *idempotencyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.client.IdempotencyKey.Delete().
+		Where(idempotencykey.ID(id)).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete idempotency key: %w", err)
+	}
+
+	return nil
+}
+
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	deleted, err := r.client.IdempotencyKey.Delete().
+		Where(idempotencykey.ExpiresAtLT(before)).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	return deleted, nil
+}
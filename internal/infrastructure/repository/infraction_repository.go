@@ -10,8 +10,10 @@ import (
 	"police-trafic-api-frontend-aligned/ent/controle"
 	"police-trafic-api-frontend-aligned/ent/infraction"
 	"police-trafic-api-frontend-aligned/ent/infractiontype"
+	"police-trafic-api-frontend-aligned/ent/predicate"
 	"police-trafic-api-frontend-aligned/ent/user"
 	"police-trafic-api-frontend-aligned/ent/vehicule"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -87,6 +89,7 @@ type InfractionFilters struct {
 	Accident         *bool
 	Limit            int
 	Offset           int
+	Page             *pagination.Params // Replaces Limit and Offset when set
 }
 
 // InfractionStatsFilters represents filters for statistics
@@ -243,6 +246,7 @@ func (r *infractionRepository) GetByNumeroPV(ctx context.Context, numeroPV strin
 // List gets infractions with filters
 func (r *infractionRepository) List(ctx context.Context, filters *InfractionFilters) ([]*ent.Infraction, error) {
 	query := r.client.Infraction.Query()
+	order := ent.Desc(infraction.FieldDateInfraction)
 
 	if filters != nil {
 		if filters.ControleID != nil {
@@ -280,11 +284,19 @@ func (r *infractionRepository) List(ctx context.Context, filters *InfractionFilt
 			query = query.Where(infraction.Accident(*filters.Accident))
 		}
 
-		if filters.Limit > 0 {
-			query = query.Limit(filters.Limit)
-		}
-		if filters.Offset > 0 {
-			query = query.Offset(filters.Offset)
+		if page := filters.Page; page != nil {
+			// Les pages suivantes partent de la dernière infraction lue, sans OFFSET
+			query = query.Where(predicate.Infraction(page.Where())).
+				Offset(page.Offset()).
+				Limit(page.FetchLimit())
+			order = page.OrderBy()
+		} else {
+			if filters.Limit > 0 {
+				query = query.Limit(filters.Limit)
+			}
+			if filters.Offset > 0 {
+				query = query.Offset(filters.Offset)
+			}
 		}
 	}
 
@@ -296,7 +308,7 @@ func (r *infractionRepository) List(ctx context.Context, filters *InfractionFilt
 		WithVehicule().
 		WithConducteur().
 		WithProcesVerbal().
-		Order(ent.Desc(infraction.FieldDateInfraction)).
+		Order(order).
 		All(ctx)
 
 	if err != nil {
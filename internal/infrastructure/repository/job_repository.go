@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/jobrun"
+	"police-trafic-api-frontend-aligned/ent/jobstate"
+	"police-trafic-api-frontend-aligned/ent/predicate"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// JobRepository defines scheduled job state and run history repository interface
+type JobRepository interface {
+	EnsureState(ctx context.Context, name string, nextRunAt time.Time) (*ent.JobState, error)
+	GetState(ctx context.Context, name string) (*ent.JobState, error)
+	ListStates(ctx context.Context) ([]*ent.JobState, error)
+	AcquireLease(ctx context.Context, input *AcquireJobLeaseInput) (bool, error)
+	ReleaseLease(ctx context.Context, name, owner, status string, finishedAt time.Time) error
+	SetPaused(ctx context.Context, name string, paused bool, nextRunAt *time.Time) error
+	CreateRun(ctx context.Context, input *CreateJobRunInput) (*ent.JobRun, error)
+	FinishRun(ctx context.Context, id uuid.UUID, input *FinishJobRunInput) (*ent.JobRun, error)
+	ListRuns(ctx context.Context, filters *JobRunFilters) ([]*ent.JobRun, error)
+	CountRuns(ctx context.Context, filters *JobRunFilters) (int, error)
+}
+
+// AcquireJobLeaseInput represents input for taking the lock on a job
+type AcquireJobLeaseInput struct {
+	Name       string
+	Owner      string
+	Now        time.Time
+	LeaseUntil time.Time
+	// NextRunAt is set for scheduled runs: the lease is only granted if the job
+	// is due and not paused, and the next occurrence is stored in the same update
+	NextRunAt *time.Time
+}
+
+// CreateJobRunInput represents input for creating a job run record
+type CreateJobRunInput struct {
+	JobName     string
+	Trigger     string
+	TriggeredBy string
+	InstanceID  string
+	StartedAt   time.Time
+}
+
+// FinishJobRunInput represents input for closing a job run record
+type FinishJobRunInput struct {
+	Status       string
+	FinishedAt   time.Time
+	Duration     time.Duration
+	Result       string
+	ErrorMessage string
+}
+
+// JobRunFilters represents filters for listing job runs
+type JobRunFilters struct {
+	JobName *string
+	Status  *string
+	Limit   int
+	Offset  int
+	Page    *pagination.Params // Replaces Limit and Offset when set
+}
+
+// jobRepository implements JobRepository
+type jobRepository struct {
+	client *ent.Client
+	logger *zap.Logger
+}
+
+// NewJobRepository creates a new job repository
+func NewJobRepository(client *ent.Client, logger *zap.Logger) JobRepository {
+	return &jobRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+// EnsureState creates the state row of a job if it does not exist yet.
+// An existing row keeps its next run unless the new schedule fires earlier.
+func (r *jobRepository) EnsureState(ctx context.Context, name string, nextRunAt time.Time) (*ent.JobState, error) {
+	state, err := r.GetState(ctx, name)
+	if err == nil {
+		if state.NextRunAt == nil || state.NextRunAt.After(nextRunAt) {
+			state, err = state.Update().SetNextRunAt(nextRunAt).Save(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to update job state: %w", err)
+			}
+		}
+		return state, nil
+	}
+
+	state, err = r.client.JobState.Create().
+		SetName(name).
+		SetNextRunAt(nextRunAt).
+		Save(ctx)
+	if err != nil {
+		// Une autre instance a pu créer la ligne entre-temps
+		if ent.IsConstraintError(err) {
+			return r.GetState(ctx, name)
+		}
+		r.logger.Error("Failed to create job state", zap.String("job", name), zap.Error(err))
+		return nil, fmt.Errorf("failed to create job state: %w", err)
+	}
+
+	return state, nil
+}
+
+// GetState gets the state row of a job
+func (r *jobRepository) GetState(ctx context.Context, name string) (*ent.JobState, error) {
+	state, err := r.client.JobState.Query().
+		Where(jobstate.Name(name)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("job state not found")
+		}
+		return nil, fmt.Errorf("failed to get job state: %w", err)
+	}
+
+	return state, nil
+}
+
+// ListStates lists the state rows of all jobs
+func (r *jobRepository) ListStates(ctx context.Context) ([]*ent.JobState, error) {
+	states, err := r.client.JobState.Query().
+		Order(ent.Asc(jobstate.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job states: %w", err)
+	}
+
+	return states, nil
+}
+
+// AcquireLease takes the lock on a job with a single conditional UPDATE,
+// so that only one server instance wins when several try at the same time
+func (r *jobRepository) AcquireLease(ctx context.Context, input *AcquireJobLeaseInput) (bool, error) {
+	update := r.client.JobState.Update().
+		Where(
+			jobstate.Name(input.Name),
+			jobstate.Or(
+				jobstate.LockedUntilIsNil(),
+				jobstate.LockedUntilLT(input.Now),
+			),
+		).
+		SetLockedBy(input.Owner).
+		SetLockedUntil(input.LeaseUntil)
+
+	if input.NextRunAt != nil {
+		update = update.
+			Where(
+				jobstate.Paused(false),
+				jobstate.NextRunAtLTE(input.Now),
+			).
+			SetNextRunAt(*input.NextRunAt)
+	}
+
+	affected, err := update.Save(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire job lease: %w", err)
+	}
+
+	return affected == 1, nil
+}
+
+// ReleaseLease releases the lock held by owner and records the outcome of the run
+func (r *jobRepository) ReleaseLease(ctx context.Context, name, owner, status string, finishedAt time.Time) error {
+	_, err := r.client.JobState.Update().
+		Where(
+			jobstate.Name(name),
+			jobstate.LockedBy(owner),
+		).
+		ClearLockedBy().
+		ClearLockedUntil().
+		SetLastRunAt(finishedAt).
+		SetLastStatus(status).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to release job lease: %w", err)
+	}
+
+	return nil
+}
+
+// SetPaused pauses or resumes a job
+func (r *jobRepository) SetPaused(ctx context.Context, name string, paused bool, nextRunAt *time.Time) error {
+	update := r.client.JobState.Update().
+		Where(jobstate.Name(name)).
+		SetPaused(paused)
+	if nextRunAt != nil {
+		update = update.SetNextRunAt(*nextRunAt)
+	}
+
+	affected, err := update.Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update job state: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("job state not found")
+	}
+
+	return nil
+}
+
+// CreateRun creates a new job run record
+func (r *jobRepository) CreateRun(ctx context.Context, input *CreateJobRunInput) (*ent.JobRun, error) {
+	create := r.client.JobRun.Create().
+		SetJobName(input.JobName).
+		SetTrigger(input.Trigger).
+		SetInstanceID(input.InstanceID).
+		SetStartedAt(input.StartedAt)
+
+	if input.TriggeredBy != "" {
+		create = create.SetTriggeredBy(input.TriggeredBy)
+	}
+
+	run, err := create.Save(ctx)
+	if err != nil {
+		r.logger.Error("Failed to create job run", zap.String("job", input.JobName), zap.Error(err))
+		return nil, fmt.Errorf("failed to create job run: %w", err)
+	}
+
+	return run, nil
+}
+
+// FinishRun closes a job run record
+func (r *jobRepository) FinishRun(ctx context.Context, id uuid.UUID, input *FinishJobRunInput) (*ent.JobRun, error) {
+	update := r.client.JobRun.UpdateOneID(id).
+		SetStatus(input.Status).
+		SetFinishedAt(input.FinishedAt).
+		SetDurationMs(input.Duration.Milliseconds())
+
+	if input.Result != "" {
+		update = update.SetResult(input.Result)
+	}
+	if input.ErrorMessage != "" {
+		update = update.SetErrorMessage(input.ErrorMessage)
+	}
+
+	run, err := update.Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("job run not found")
+		}
+		return nil, fmt.Errorf("failed to finish job run: %w", err)
+	}
+
+	return run, nil
+}
+
+// ListRuns lists job runs with filters, most recent first
+func (r *jobRepository) ListRuns(ctx context.Context, filters *JobRunFilters) ([]*ent.JobRun, error) {
+	query := r.client.JobRun.Query()
+	order := ent.Desc(jobrun.FieldStartedAt)
+
+	if filters != nil {
+		query = r.applyRunFilters(query, filters)
+		if page := filters.Page; page != nil {
+			// Les pages suivantes partent de la dernière exécution lue
+			query = query.Where(predicate.JobRun(page.Where())).
+				Offset(page.Offset()).
+				Limit(page.FetchLimit())
+			order = page.OrderBy()
+		} else {
+			if filters.Limit > 0 {
+				query = query.Limit(filters.Limit)
+			}
+			if filters.Offset > 0 {
+				query = query.Offset(filters.Offset)
+			}
+		}
+	}
+
+	runs, err := query.
+		Order(order).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list job runs: %w", err)
+	}
+
+	return runs, nil
+}
+
+// CountRuns counts job runs with filters
+func (r *jobRepository) CountRuns(ctx context.Context, filters *JobRunFilters) (int, error) {
+	query := r.client.JobRun.Query()
+
+	if filters != nil {
+		query = r.applyRunFilters(query, filters)
+		query = query.Where(predicate.JobRun(filters.Page.Filter()))
+	}
+
+	count, err := query.Count(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count job runs: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *jobRepository) applyRunFilters(query *ent.JobRunQuery, filters *JobRunFilters) *ent.JobRunQuery {
+	if filters.JobName != nil {
+		query = query.Where(jobrun.JobName(*filters.JobName))
+	}
+	if filters.Status != nil {
+		query = query.Where(jobrun.Status(*filters.Status))
+	}
+
+	return query
+}
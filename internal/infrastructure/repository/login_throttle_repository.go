@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/loginthrottle"
+
+	"go.uber.org/zap"
+)
+
+// Kinds of login throttles
+const (
+	ThrottleAccount = "account"
+	ThrottleIP      = "ip"
+)
+
+// LoginThrottleRepository defines login throttle repository interface
+type LoginThrottleRepository interface {
+	Get(ctx context.Context, kind, key string) (*ent.LoginThrottle, error)
+	RecordFailure(ctx context.Context, kind, key string, now time.Time, window time.Duration) (*ent.LoginThrottle, error)
+	Block(ctx context.Context, kind, key string, nextAttemptAt time.Time, lockedUntil *time.Time) error
+	Reset(ctx context.Context, kind, key string) error
+	DeleteStale(ctx context.Context, before time.Time) (int, error)
+}
+
+// loginThrottleRepository implements LoginThrottleRepository
+type loginThrottleRepository struct {
+	client *ent.Client
+	logger *zap.Logger
+}
+
+// NewLoginThrottleRepository creates a new login throttle repository
+func NewLoginThrottleRepository(client *ent.Client, logger *zap.Logger) LoginThrottleRepository {
+	return &loginThrottleRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Get returns the throttle of a key, or nil if it has no recorded failure
+func (r *loginThrottleRepository) Get(ctx context.Context, kind, key string) (*ent.LoginThrottle, error) {
+	t, err := r.client.LoginThrottle.Query().
+		Where(loginthrottle.Kind(kind), loginthrottle.Key(key)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get login throttle: %w", err)
+	}
+
+	return t, nil
+}
+
+// RecordFailure counts a failed attempt. Failures older than the window are
+// forgotten first. The counter is incremented with an UPDATE so concurrent
+// attempts on several instances are all counted.
+func (r *loginThrottleRepository) RecordFailure(ctx context.Context, kind, key string, now time.Time, window time.Duration) (*ent.LoginThrottle, error) {
+	err := r.client.LoginThrottle.Create().
+		SetKind(kind).
+		SetKey(key).
+		SetLastFailureAt(now).
+		Exec(ctx)
+	if err != nil && !ent.IsConstraintError(err) {
+		return nil, fmt.Errorf("failed to create login throttle: %w", err)
+	}
+
+	if window > 0 {
+		err = r.client.LoginThrottle.Update().
+			Where(
+				loginthrottle.Kind(kind),
+				loginthrottle.Key(key),
+				loginthrottle.LastFailureAtLT(now.Add(-window)),
+			).
+			SetFailures(0).
+			Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reset login throttle: %w", err)
+		}
+	}
+
+	err = r.client.LoginThrottle.Update().
+		Where(loginthrottle.Kind(kind), loginthrottle.Key(key)).
+		AddFailures(1).
+		SetLastFailureAt(now).
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record login failure: %w", err)
+	}
+
+	return r.Get(ctx, kind, key)
+}
+
+// Block sets the date of the next accepted attempt and, optionally, locks the key
+func (r *loginThrottleRepository) Block(ctx context.Context, kind, key string, nextAttemptAt time.Time, lockedUntil *time.Time) error {
+	update := r.client.LoginThrottle.Update().
+		Where(loginthrottle.Kind(kind), loginthrottle.Key(key)).
+		SetNextAttemptAt(nextAttemptAt)
+	if lockedUntil != nil {
+		update = update.SetLockedUntil(*lockedUntil)
+	}
+
+	if err := update.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to block login throttle: %w", err)
+	}
+
+	return nil
+}
+
+// Reset forgets the failures of a key and lifts its lock
+func (r *loginThrottleRepository) Reset(ctx context.Context, kind, key string) error {
+	_, err := r.client.LoginThrottle.Delete().
+		Where(loginthrottle.Kind(kind), loginthrottle.Key(key)).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to reset login throttle: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteStale removes the throttles without failure since the given date
+// that are no longer locked
+func (r *loginThrottleRepository) DeleteStale(ctx context.Context, before time.Time) (int, error) {
+	deleted, err := r.client.LoginThrottle.Delete().
+		Where(
+			loginthrottle.LastFailureAtLT(before),
+			loginthrottle.Or(
+				loginthrottle.LockedUntilIsNil(),
+				loginthrottle.LockedUntilLT(time.Now()),
+			),
+		).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete stale login throttles: %w", err)
+	}
+
+	return deleted, nil
+}
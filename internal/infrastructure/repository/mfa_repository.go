@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/recoverycode"
+	"police-trafic-api-frontend-aligned/ent/usermfa"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// MFARepository defines the second factor repository interface
+type MFARepository interface {
+	// Get returns the TOTP enrolment of a user, or nil if there is none
+	Get(ctx context.Context, userID uuid.UUID) (*ent.UserMFA, error)
+	// SavePending stores a new secret waiting for confirmation, replacing any previous one
+	SavePending(ctx context.Context, userID uuid.UUID, secret string) error
+	// UpdateSecret replaces the stored secret without changing the enrolment
+	UpdateSecret(ctx context.Context, userID uuid.UUID, secret string) error
+	// Confirm activates the enrolment and replaces the recovery codes
+	Confirm(ctx context.Context, userID uuid.UUID, step int64, codeHashes []string) error
+	// UseStep records an accepted code; it returns false if the step, or a later
+	// one, was already used
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	// Delete removes the enrolment and the recovery codes
+	Delete(ctx context.Context, userID uuid.UUID) error
+
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+	ListUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]*ent.RecoveryCode, error)
+	// UseRecoveryCode marks a code as used; it returns false if it already was
+	UseRecoveryCode(ctx context.Context, id uuid.UUID) (bool, error)
+}
+
+// mfaRepository implements MFARepository
+type mfaRepository struct {
+	client *ent.Client
+	logger *zap.Logger
+}
+
+// NewMFARepository creates a new second factor repository
+func NewMFARepository(client *ent.Client, logger *zap.Logger) MFARepository {
+	return &mfaRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (r *mfaRepository) Get(ctx context.Context, userID uuid.UUID) (*ent.UserMFA, error) {
+	m, err := r.client.UserMFA.Query().
+		Where(usermfa.UserID(userID)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get MFA enrolment: %w", err)
+	}
+
+	return m, nil
+}
+
+func (r *mfaRepository) SavePending(ctx context.Context, userID uuid.UUID, secret string) error {
+	return r.inTx(ctx, func(client *ent.Client) error {
+		if _, err := client.UserMFA.Delete().Where(usermfa.UserID(userID)).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to delete MFA enrolment: %w", err)
+		}
+
+		err := client.UserMFA.Create().
+			SetUserID(userID).
+			SetSecret(secret).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create MFA enrolment: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *mfaRepository) UpdateSecret(ctx context.Context, userID uuid.UUID, secret string) error {
+	err := r.client.UserMFA.Update().
+		Where(usermfa.UserID(userID)).
+		SetSecret(secret).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update MFA secret: %w", err)
+	}
+
+	return nil
+}
+
+func (r *mfaRepository) Confirm(ctx context.Context, userID uuid.UUID, step int64, codeHashes []string) error {
+	return r.inTx(ctx, func(client *ent.Client) error {
+		err := client.UserMFA.Update().
+			Where(usermfa.UserID(userID)).
+			SetConfirmedAt(time.Now()).
+			SetLastUsedStep(step).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to confirm MFA enrolment: %w", err)
+		}
+
+		return replaceRecoveryCodes(ctx, client, userID, codeHashes)
+	})
+}
+
+// UseStep relies on a conditional UPDATE so that two concurrent logins with
+// the same code cannot both succeed
+func (r *mfaRepository) UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	affected, err := r.client.UserMFA.Update().
+		Where(
+			usermfa.UserID(userID),
+			usermfa.LastUsedStepLT(step),
+		).
+		SetLastUsedStep(step).
+		Save(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to record TOTP step: %w", err)
+	}
+
+	return affected == 1, nil
+}
+
+func (r *mfaRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	return r.inTx(ctx, func(client *ent.Client) error {
+		if _, err := client.RecoveryCode.Delete().Where(recoverycode.UserID(userID)).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to delete recovery codes: %w", err)
+		}
+		if _, err := client.UserMFA.Delete().Where(usermfa.UserID(userID)).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to delete MFA enrolment: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (r *mfaRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	return r.inTx(ctx, func(client *ent.Client) error {
+		return replaceRecoveryCodes(ctx, client, userID, codeHashes)
+	})
+}
+
+func (r *mfaRepository) ListUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]*ent.RecoveryCode, error) {
+	codes, err := r.client.RecoveryCode.Query().
+		Where(
+			recoverycode.UserID(userID),
+			recoverycode.UsedAtIsNil(),
+		).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recovery codes: %w", err)
+	}
+
+	return codes, nil
+}
+
+func (r *mfaRepository) UseRecoveryCode(ctx context.Context, id uuid.UUID) (bool, error) {
+	affected, err := r.client.RecoveryCode.Update().
+		Where(
+			recoverycode.ID(id),
+			recoverycode.UsedAtIsNil(),
+		).
+		SetUsedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to use recovery code: %w", err)
+	}
+
+	return affected == 1, nil
+}
+
+// inTx runs fn in a transaction, rolled back if fn fails
+func (r *mfaRepository) inTx(ctx context.Context, fn func(client *ent.Client) error) error {
+	tx, err := r.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	if err := fn(tx.Client()); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func replaceRecoveryCodes(ctx context.Context, client *ent.Client, userID uuid.UUID, codeHashes []string) error {
+	if _, err := client.RecoveryCode.Delete().Where(recoverycode.UserID(userID)).Exec(ctx); err != nil {
+		return fmt.Errorf("failed to delete recovery codes: %w", err)
+	}
+
+	builders := make([]*ent.RecoveryCodeCreate, len(codeHashes))
+	for i, hash := range codeHashes {
+		builders[i] = client.RecoveryCode.Create().
+			SetUserID(userID).
+			SetCodeHash(hash)
+	}
+	if _, err := client.RecoveryCode.CreateBulk(builders...).Save(ctx); err != nil {
+		return fmt.Errorf("failed to create recovery codes: %w", err)
+	}
+
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/mission"
+	"police-trafic-api-frontend-aligned/ent/predicate"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -37,6 +39,7 @@ type MissionFilters struct {
 	Type           *string
 	DateDebut      *time.Time
 	DateFin        *time.Time
+	Page           *pagination.Params // Reads one page when set
 }
 
 // CreateMissionInput represents input for creating mission
@@ -173,7 +176,16 @@ func (r *missionRepository) List(ctx context.Context, filters *MissionFilters) (
 		}
 	}
 
-	missions, err := query.Order(ent.Desc(mission.FieldDateDebut)).All(ctx)
+	order := ent.Desc(mission.FieldDateDebut)
+	if filters != nil && filters.Page != nil {
+		// Les pages suivantes partent de la dernière mission lue
+		query = query.Where(predicate.Mission(filters.Page.Where())).
+			Offset(filters.Page.Offset()).
+			Limit(filters.Page.FetchLimit())
+		order = filters.Page.OrderBy()
+	}
+
+	missions, err := query.Order(order).All(ctx)
 	if err != nil {
 		r.logger.Error("Failed to list missions", zap.Error(err))
 		return nil, fmt.Errorf("failed to list missions: %w", err)
@@ -22,5 +22,19 @@ var Module = fx.Module("repository",
 		NewVerificationRepository,
 		NewObjetPerduRepository,
 		NewObjetRetrouveRepository,
+		NewAuditLogRepository,
+		NewJobRepository,
+		NewNotificationRepository,
+		NewStreamEventRepository,
+		NewNumberSequenceRepository,
+		NewRoleRepository,
+		NewLoginThrottleRepository,
+		NewMFARepository,
+		NewPasswordRepository,
+		NewServiceAccountRepository,
+		NewSigningKeyRepository,
+		NewOIDCRepository,
+		NewIdempotencyRepository,
+		NewSearchRepository,
 	),
 )
\ No newline at end of file
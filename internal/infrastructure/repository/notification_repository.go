@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/notification"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// NotificationRepository defines notification repository interface
+type NotificationRepository interface {
+	Create(ctx context.Context, input *CreateNotificationInput) (*ent.Notification, error)
+	GetByID(ctx context.Context, id string) (*ent.Notification, error)
+	Claim(ctx context.Context, id uuid.UUID, now, leaseUntil time.Time) (bool, error)
+	MarkSent(ctx context.Context, id uuid.UUID, provider string, sentAt time.Time) error
+	MarkFailed(ctx context.Context, id uuid.UUID, errorMessage string, nextAttemptAt *time.Time) error
+	ListDue(ctx context.Context, now time.Time, limit int) ([]*ent.Notification, error)
+	ListByResource(ctx context.Context, resourceType, resourceID string) ([]*ent.Notification, error)
+}
+
+// CreateNotificationInput represents input for creating a notification
+type CreateNotificationInput struct {
+	Channel      string
+	Recipient    string
+	Template     string
+	Subject      string
+	Body         string
+	MaxAttempts  int
+	ResourceType string
+	ResourceID   string
+}
+
+// notificationRepository implements NotificationRepository
+type notificationRepository struct {
+	client *ent.Client
+	logger *zap.Logger
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(client *ent.Client, logger *zap.Logger) NotificationRepository {
+	return &notificationRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Create creates a new pending notification
+func (r *notificationRepository) Create(ctx context.Context, input *CreateNotificationInput) (*ent.Notification, error) {
+	create := r.client.Notification.Create().
+		SetChannel(input.Channel).
+		SetRecipient(input.Recipient).
+		SetBody(input.Body)
+
+	if input.Template != "" {
+		create = create.SetTemplate(input.Template)
+	}
+	if input.Subject != "" {
+		create = create.SetSubject(input.Subject)
+	}
+	if input.MaxAttempts > 0 {
+		create = create.SetMaxAttempts(input.MaxAttempts)
+	}
+	if input.ResourceType != "" {
+		create = create.SetResourceType(input.ResourceType)
+	}
+	if input.ResourceID != "" {
+		create = create.SetResourceID(input.ResourceID)
+	}
+
+	n, err := create.Save(ctx)
+	if err != nil {
+		r.logger.Error("Failed to create notification", zap.Error(err))
+		return nil, fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return n, nil
+}
+
+// GetByID gets notification by ID
+func (r *notificationRepository) GetByID(ctx context.Context, id string) (*ent.Notification, error) {
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid notification ID: %w", err)
+	}
+
+	n, err := r.client.Notification.Get(ctx, uid)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, fmt.Errorf("notification not found")
+		}
+		return nil, fmt.Errorf("failed to get notification: %w", err)
+	}
+
+	return n, nil
+}
+
+// Claim marks a due notification as being sent. The conditional UPDATE guarantees
+// that a single worker (on any instance) sends it; leaseUntil lets another worker
+// take over if the sender dies before recording the outcome.
+func (r *notificationRepository) Claim(ctx context.Context, id uuid.UUID, now, leaseUntil time.Time) (bool, error) {
+	affected, err := r.client.Notification.Update().
+		Where(
+			notification.ID(id),
+			notification.StatusIn("PENDING", "SENDING"),
+			notification.NextAttemptAtLTE(now),
+		).
+		SetStatus("SENDING").
+		AddAttempts(1).
+		SetNextAttemptAt(leaseUntil).
+		Save(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim notification: %w", err)
+	}
+
+	return affected == 1, nil
+}
+
+// MarkSent records a successful delivery
+func (r *notificationRepository) MarkSent(ctx context.Context, id uuid.UUID, provider string, sentAt time.Time) error {
+	err := r.client.Notification.UpdateOneID(id).
+		SetStatus("SENT").
+		SetProvider(provider).
+		SetSentAt(sentAt).
+		ClearLastError().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification as sent: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed records a failed attempt. With a next attempt date the notification
+// goes back to PENDING, otherwise it is definitively FAILED.
+func (r *notificationRepository) MarkFailed(ctx context.Context, id uuid.UUID, errorMessage string, nextAttemptAt *time.Time) error {
+	update := r.client.Notification.UpdateOneID(id).
+		SetLastError(errorMessage)
+
+	if nextAttemptAt != nil {
+		update = update.
+			SetStatus("PENDING").
+			SetNextAttemptAt(*nextAttemptAt)
+	} else {
+		update = update.SetStatus("FAILED")
+	}
+
+	if err := update.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to mark notification as failed: %w", err)
+	}
+
+	return nil
+}
+
+// ListDue lists notifications waiting for a (re)try, oldest first
+func (r *notificationRepository) ListDue(ctx context.Context, now time.Time, limit int) ([]*ent.Notification, error) {
+	notifications, err := r.client.Notification.Query().
+		Where(
+			notification.StatusIn("PENDING", "SENDING"),
+			notification.NextAttemptAtLTE(now),
+		).
+		Order(ent.Asc(notification.FieldNextAttemptAt)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due notifications: %w", err)
+	}
+
+	return notifications, nil
+}
+
+// ListByResource lists the notifications sent for a business resource
+func (r *notificationRepository) ListByResource(ctx context.Context, resourceType, resourceID string) ([]*ent.Notification, error) {
+	notifications, err := r.client.Notification.Query().
+		Where(
+			notification.ResourceType(resourceType),
+			notification.ResourceID(resourceID),
+		).
+		Order(ent.Desc(notification.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list notifications: %w", err)
+	}
+
+	return notifications, nil
+}
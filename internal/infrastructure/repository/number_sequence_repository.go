@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/numbersequence"
+
+	"go.uber.org/zap"
+)
+
+// ErrSequenceNotFound is returned by Increment when the sequence row does not exist yet
+var ErrSequenceNotFound = errors.New("number sequence not found")
+
+// NumberSequenceRepository defines number sequence repository interface
+type NumberSequenceRepository interface {
+	Ensure(ctx context.Context, seqType, scope string, year int) error
+	Increment(ctx context.Context, seqType, scope string, year int) (int, error)
+}
+
+// numberSequenceRepository implements NumberSequenceRepository
+type numberSequenceRepository struct {
+	client *ent.Client
+	logger *zap.Logger
+}
+
+// NewNumberSequenceRepository creates a new number sequence repository
+func NewNumberSequenceRepository(client *ent.Client, logger *zap.Logger) NumberSequenceRepository {
+	return &numberSequenceRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Ensure creates the sequence row if needed. It always runs outside the caller's
+// transaction: a unique violation would abort a PostgreSQL transaction.
+func (r *numberSequenceRepository) Ensure(ctx context.Context, seqType, scope string, year int) error {
+	err := r.client.NumberSequence.Create().
+		SetType(seqType).
+		SetScope(scope).
+		SetYear(year).
+		Exec(ctx)
+	if err != nil && !ent.IsConstraintError(err) {
+		return fmt.Errorf("failed to create number sequence: %w", err)
+	}
+
+	return nil
+}
+
+// Increment takes the next value of the sequence. Called inside a transaction,
+// the UPDATE locks the row until commit, so concurrent callers are serialized
+// and a rolled back transaction gives its number back.
+func (r *numberSequenceRepository) Increment(ctx context.Context, seqType, scope string, year int) (int, error) {
+	client := ClientFromContext(ctx, r.client)
+
+	affected, err := client.NumberSequence.Update().
+		Where(
+			numbersequence.Type(seqType),
+			numbersequence.Scope(scope),
+			numbersequence.Year(year),
+		).
+		AddLastValue(1).
+		Save(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment number sequence: %w", err)
+	}
+	if affected == 0 {
+		return 0, ErrSequenceNotFound
+	}
+
+	seq, err := client.NumberSequence.Query().
+		Where(
+			numbersequence.Type(seqType),
+			numbersequence.Scope(scope),
+			numbersequence.Year(year),
+		).
+		Only(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read number sequence: %w", err)
+	}
+
+	return seq.LastValue, nil
+}
@@ -7,6 +7,8 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/objectif"
+	"police-trafic-api-frontend-aligned/ent/predicate"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -30,6 +32,7 @@ type ObjectifFilters struct {
 	Statut    *string
 	DateDebut *time.Time
 	DateFin   *time.Time
+	Page      *pagination.Params // Reads one page when set
 }
 
 // CreateObjectifInput represents input for creating objectif
@@ -147,7 +150,16 @@ func (r *objectifRepository) List(ctx context.Context, filters *ObjectifFilters)
 		}
 	}
 
-	objectifs, err := query.Order(ent.Desc(objectif.FieldCreatedAt)).All(ctx)
+	order := ent.Desc(objectif.FieldCreatedAt)
+	if filters != nil && filters.Page != nil {
+		// Les pages suivantes partent du dernier objectif lu
+		query = query.Where(predicate.Objectif(filters.Page.Where())).
+			Offset(filters.Page.Offset()).
+			Limit(filters.Page.FetchLimit())
+		order = filters.Page.OrderBy()
+	}
+
+	objectifs, err := query.Order(order).All(ctx)
 	if err != nil {
 		r.logger.Error("Failed to list objectifs", zap.Error(err))
 		return nil, fmt.Errorf("failed to list objectifs: %w", err)
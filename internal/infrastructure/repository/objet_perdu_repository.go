@@ -8,7 +8,9 @@ import (
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/commissariat"
 	"police-trafic-api-frontend-aligned/ent/objetperdu"
+	"police-trafic-api-frontend-aligned/ent/predicate"
 	"police-trafic-api-frontend-aligned/ent/user"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -87,6 +89,7 @@ type ObjetPerduFilters struct {
 	Search         *string
 	Limit          int
 	Offset         int
+	Page           *pagination.Params // Replaces Limit and Offset when set
 }
 
 // objetPerduRepository implements ObjetPerduRepository
@@ -105,7 +108,7 @@ func NewObjetPerduRepository(client *ent.Client, logger *zap.Logger) ObjetPerduR
 
 // Create creates a new objet perdu
 func (r *objetPerduRepository) Create(ctx context.Context, input *CreateObjetPerduInput) (*ent.ObjetPerdu, error) {
-	query := r.client.ObjetPerdu.Create().
+	query := ClientFromContext(ctx, r.client).ObjetPerdu.Create().
 		SetID(uuid.MustParse(input.ID)).
 		SetNumero(input.Numero).
 		SetTypeObjet(input.TypeObjet).
@@ -236,14 +239,23 @@ func (r *objetPerduRepository) List(ctx context.Context, filters *ObjetPerduFilt
 		)
 	}
 
-	if filters.Limit > 0 {
-		query = query.Limit(filters.Limit)
-	}
-	if filters.Offset > 0 {
-		query = query.Offset(filters.Offset)
+	order := ent.Desc(objetperdu.FieldDateDeclaration)
+	if page := filters.Page; page != nil {
+		// Les pages suivantes partent du dernier objet lu
+		query = query.Where(predicate.ObjetPerdu(page.Where())).
+			Offset(page.Offset()).
+			Limit(page.FetchLimit())
+		order = page.OrderBy()
+	} else {
+		if filters.Limit > 0 {
+			query = query.Limit(filters.Limit)
+		}
+		if filters.Offset > 0 {
+			query = query.Offset(filters.Offset)
+		}
 	}
 
-	query = query.Order(ent.Desc(objetperdu.FieldDateDeclaration))
+	query = query.Order(order)
 
 	objets, err := query.All(ctx)
 	if err != nil {
@@ -295,6 +307,8 @@ func (r *objetPerduRepository) Count(ctx context.Context, filters *ObjetPerduFil
 		)
 	}
 
+	query = query.Where(predicate.ObjetPerdu(filters.Page.Filter()))
+
 	count, err := query.Count(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count objets perdus: %w", err)
@@ -9,7 +9,9 @@ import (
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/commissariat"
 	"police-trafic-api-frontend-aligned/ent/objetretrouve"
+	"police-trafic-api-frontend-aligned/ent/predicate"
 	"police-trafic-api-frontend-aligned/ent/user"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -83,6 +85,7 @@ type ObjetRetrouveFilters struct {
 	Search         *string
 	Limit          int
 	Offset         int
+	Page           *pagination.Params // Replaces Limit and Offset when set
 }
 
 // objetRetrouveRepository implements ObjetRetrouveRepository
@@ -101,7 +104,7 @@ func NewObjetRetrouveRepository(client *ent.Client, logger *zap.Logger) ObjetRet
 
 // Create creates a new objet retrouve
 func (r *objetRetrouveRepository) Create(ctx context.Context, input *CreateObjetRetrouveInput) (*ent.ObjetRetrouve, error) {
-	query := r.client.ObjetRetrouve.Create().
+	query := ClientFromContext(ctx, r.client).ObjetRetrouve.Create().
 		SetID(uuid.MustParse(input.ID)).
 		SetNumero(input.Numero).
 		SetTypeObjet(input.TypeObjet).
@@ -281,17 +284,27 @@ func (r *objetRetrouveRepository) List(ctx context.Context, filters *ObjetRetrou
 	}
 
 	// Pagination
-	if filters.Limit > 0 {
-		query = query.Limit(filters.Limit)
-		r.logger.Info("Applied pagination: Limit", zap.Int("limit", filters.Limit))
-	}
-	if filters.Offset > 0 {
-		query = query.Offset(filters.Offset)
-		r.logger.Info("Applied pagination: Offset", zap.Int("offset", filters.Offset))
+	order := ent.Desc(objetretrouve.FieldDateDepot)
+	if page := filters.Page; page != nil {
+		// Les pages suivantes partent du dernier objet lu
+		query = query.Where(predicate.ObjetRetrouve(page.Where())).
+			Offset(page.Offset()).
+			Limit(page.FetchLimit())
+		order = page.OrderBy()
+		r.logger.Info("Applied pagination", zap.String("sort", page.Sort), zap.Int("limit", page.Limit))
+	} else {
+		if filters.Limit > 0 {
+			query = query.Limit(filters.Limit)
+			r.logger.Info("Applied pagination: Limit", zap.Int("limit", filters.Limit))
+		}
+		if filters.Offset > 0 {
+			query = query.Offset(filters.Offset)
+			r.logger.Info("Applied pagination: Offset", zap.Int("offset", filters.Offset))
+		}
 	}
 
-	// Tri par date de dépôt décroissant
-	query = query.Order(ent.Desc(objetretrouve.FieldDateDepot))
+	// Tri par date de dépôt décroissant, sauf tri demandé
+	query = query.Order(order)
 
 	// Exécuter la requête
 	objets, err := query.All(ctx)
@@ -382,6 +395,8 @@ func (r *objetRetrouveRepository) Count(ctx context.Context, filters *ObjetRetro
 		)
 	}
 
+	query = query.Where(predicate.ObjetRetrouve(filters.Page.Filter()))
+
 	count, err := query.Count(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("failed to count objets retrouves: %w", err)
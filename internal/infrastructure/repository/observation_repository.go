@@ -6,6 +6,8 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/observation"
+	"police-trafic-api-frontend-aligned/ent/predicate"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -29,6 +31,7 @@ type ObservationFilters struct {
 	Type         *string
 	Categorie    *string
 	VisibleAgent *bool
+	Page         *pagination.Params // Reads one page when set
 }
 
 // CreateObservationInput represents input for creating observation
@@ -142,7 +145,16 @@ func (r *observationRepository) List(ctx context.Context, filters *ObservationFi
 		}
 	}
 
-	observations, err := query.Order(ent.Desc(observation.FieldCreatedAt)).All(ctx)
+	order := ent.Desc(observation.FieldCreatedAt)
+	if filters != nil && filters.Page != nil {
+		// Les pages suivantes partent de la dernière observation lue
+		query = query.Where(predicate.Observation(filters.Page.Where())).
+			Offset(filters.Page.Offset()).
+			Limit(filters.Page.FetchLimit())
+		order = filters.Page.OrderBy()
+	}
+
+	observations, err := query.Order(order).All(ctx)
 	if err != nil {
 		r.logger.Error("Failed to list observations", zap.Error(err))
 		return nil, fmt.Errorf("failed to list observations: %w", err)
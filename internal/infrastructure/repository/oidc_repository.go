@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/oidcloginstate"
+	"police-trafic-api-frontend-aligned/ent/useridentity"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// OIDCRepository defines the OpenID Connect identity and login state repository interface
+type OIDCRepository interface {
+	// GetIdentity returns the identity linked to a provider account, or nil
+	GetIdentity(ctx context.Context, issuer, subject string) (*ent.UserIdentity, error)
+	CreateIdentity(ctx context.Context, input *CreateUserIdentityInput) (*ent.UserIdentity, error)
+	TouchIdentity(ctx context.Context, id uuid.UUID, email string) error
+
+	CreateLoginState(ctx context.Context, input *CreateOIDCLoginStateInput) error
+	// ConsumeLoginState deletes and returns the login state of a state hash; it
+	// returns nil if the state is unknown or was already consumed
+	ConsumeLoginState(ctx context.Context, stateHash string) (*ent.OIDCLoginState, error)
+	DeleteExpiredLoginStates(ctx context.Context, before time.Time) (int, error)
+}
+
+// CreateUserIdentityInput represents input for linking a provider account
+type CreateUserIdentityInput struct {
+	UserID  uuid.UUID
+	Issuer  string
+	Subject string
+	Email   string
+}
+
+// CreateOIDCLoginStateInput represents input for starting an OpenID Connect login
+type CreateOIDCLoginStateInput struct {
+	StateHash    string
+	CodeVerifier string
+	Nonce        string
+	IPAddress    string
+	ExpiresAt    time.Time
+}
+
+// oidcRepository implements OIDCRepository
+type oidcRepository struct {
+	client *ent.Client
+	logger *zap.Logger
+}
+
+// NewOIDCRepository creates a new OpenID Connect repository
+func NewOIDCRepository(client *ent.Client, logger *zap.Logger) OIDCRepository {
+	return &oidcRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (r *oidcRepository) GetIdentity(ctx context.Context, issuer, subject string) (*ent.UserIdentity, error) {
+	identity, err := r.client.UserIdentity.Query().
+		Where(
+			useridentity.Issuer(issuer),
+			useridentity.Subject(subject),
+		).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get user identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+func (r *oidcRepository) CreateIdentity(ctx context.Context, input *CreateUserIdentityInput) (*ent.UserIdentity, error) {
+	create := r.client.UserIdentity.Create().
+		SetUserID(input.UserID).
+		SetIssuer(input.Issuer).
+		SetSubject(input.Subject).
+		SetLastLoginAt(time.Now())
+	if input.Email != "" {
+		create = create.SetEmail(input.Email)
+	}
+
+	identity, err := create.Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create user identity: %w", err)
+	}
+
+	return identity, nil
+}
+
+func (r *oidcRepository) TouchIdentity(ctx context.Context, id uuid.UUID, email string) error {
+	update := r.client.UserIdentity.UpdateOneID(id).
+		SetLastLoginAt(time.Now())
+	if email != "" {
+		update = update.SetEmail(email)
+	}
+
+	if err := update.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to update user identity: %w", err)
+	}
+
+	return nil
+}
+
+func (r *oidcRepository) CreateLoginState(ctx context.Context, input *CreateOIDCLoginStateInput) error {
+	err := r.client.OIDCLoginState.Create().
+		SetStateHash(input.StateHash).
+		SetCodeVerifier(input.CodeVerifier).
+		SetNonce(input.Nonce).
+		SetIPAddress(input.IPAddress).
+		SetExpiresAt(input.ExpiresAt).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create OIDC login state: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeLoginState relies on the DELETE affecting one row so that a state
+// cannot be used twice by concurrent requests
+func (r *oidcRepository) ConsumeLoginState(ctx context.Context, stateHash string) (*ent.OIDCLoginState, error) {
+	state, err := r.client.OIDCLoginState.Query().
+		Where(oidcloginstate.StateHash(stateHash)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get OIDC login state: %w", err)
+	}
+
+	deleted, err := r.client.OIDCLoginState.Delete().
+		Where(oidcloginstate.ID(state.ID)).
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to consume OIDC login state: %w", err)
+	}
+	if deleted != 1 {
+		return nil, nil
+	}
+
+	return state, nil
+}
+
+func (r *oidcRepository) DeleteExpiredLoginStates(ctx context.Context, before time.Time) (int, error) {
+	deleted, err := r.client.OIDCLoginState.Delete().
+		Where(oidcloginstate.ExpiresAtLT(before)).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired OIDC login states: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -7,7 +7,9 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/paiement"
+	"police-trafic-api-frontend-aligned/ent/predicate"
 	"police-trafic-api-frontend-aligned/ent/procesverbal"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -63,6 +65,7 @@ type PaiementFilters struct {
 	MontantMax     *float64
 	Limit          int
 	Offset         int
+	Page           *pagination.Params // Replaces Limit and Offset when set
 }
 
 // PaiementStatistics represents statistics for paiements
@@ -176,21 +179,30 @@ func (r *paiementRepository) GetByNumeroTransaction(ctx context.Context, numero
 // List gets paiements with filters
 func (r *paiementRepository) List(ctx context.Context, filters *PaiementFilters) ([]*ent.Paiement, error) {
 	query := r.client.Paiement.Query()
+	order := ent.Desc(paiement.FieldDatePaiement)
 
 	if filters != nil {
 		query = r.applyFilters(query, filters)
 
-		if filters.Limit > 0 {
-			query = query.Limit(filters.Limit)
-		}
-		if filters.Offset > 0 {
-			query = query.Offset(filters.Offset)
+		if page := filters.Page; page != nil {
+			// Les pages suivantes partent du dernier paiement lu
+			query = query.Where(predicate.Paiement(page.Where())).
+				Offset(page.Offset()).
+				Limit(page.FetchLimit())
+			order = page.OrderBy()
+		} else {
+			if filters.Limit > 0 {
+				query = query.Limit(filters.Limit)
+			}
+			if filters.Offset > 0 {
+				query = query.Offset(filters.Offset)
+			}
 		}
 	}
 
 	paiements, err := query.
 		WithProcesVerbal().
-		Order(ent.Desc(paiement.FieldDatePaiement)).
+		Order(order).
 		All(ctx)
 
 	if err != nil {
@@ -207,6 +219,7 @@ func (r *paiementRepository) Count(ctx context.Context, filters *PaiementFilters
 
 	if filters != nil {
 		query = r.applyFilters(query, filters)
+		query = query.Where(predicate.Paiement(filters.Page.Filter()))
 	}
 
 	count, err := query.Count(ctx)
@@ -250,7 +263,7 @@ func (r *paiementRepository) Update(ctx context.Context, id string, input *Updat
 	r.logger.Info("Updating paiement", zap.String("id", id))
 
 	uid, _ := uuid.Parse(id)
-	update := r.client.Paiement.UpdateOneID(uid)
+	update := ClientFromContext(ctx, r.client).Paiement.UpdateOneID(uid)
 
 	if input.Statut != nil {
 		update = update.SetStatut(*input.Statut)
@@ -0,0 +1,196 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/passwordhistory"
+	"police-trafic-api-frontend-aligned/ent/passwordresettoken"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// PasswordRepository defines the password history and reset token repository interface
+type PasswordRepository interface {
+	// LatestHistory returns the current password entry of a user, or nil if the
+	// password was set before the history existed
+	LatestHistory(ctx context.Context, userID uuid.UUID) (*ent.PasswordHistory, error)
+	// ListHistory returns the last entries, newest first
+	ListHistory(ctx context.Context, userID uuid.UUID, limit int) ([]*ent.PasswordHistory, error)
+	// AddHistory records a new password and keeps only the last keep entries
+	AddHistory(ctx context.Context, userID uuid.UUID, passwordHash string, temporary bool, keep int) error
+
+	CreateResetToken(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time, ipAddress string) error
+	GetResetToken(ctx context.Context, tokenHash string) (*ent.PasswordResetToken, error)
+	// LatestResetToken returns the last token requested by a user, or nil
+	LatestResetToken(ctx context.Context, userID uuid.UUID) (*ent.PasswordResetToken, error)
+	// UseResetToken marks a token as used; it returns false if it already was
+	UseResetToken(ctx context.Context, id uuid.UUID) (bool, error)
+	// InvalidateResetTokens marks every unused token of a user as used
+	InvalidateResetTokens(ctx context.Context, userID uuid.UUID) error
+	DeleteExpiredResetTokens(ctx context.Context, before time.Time) (int, error)
+}
+
+// passwordRepository implements PasswordRepository
+type passwordRepository struct {
+	client *ent.Client
+	logger *zap.Logger
+}
+
+// NewPasswordRepository creates a new password repository
+func NewPasswordRepository(client *ent.Client, logger *zap.Logger) PasswordRepository {
+	return &passwordRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (r *passwordRepository) LatestHistory(ctx context.Context, userID uuid.UUID) (*ent.PasswordHistory, error) {
+	h, err := r.client.PasswordHistory.Query().
+		Where(passwordhistory.UserID(userID)).
+		Order(ent.Desc(passwordhistory.FieldCreatedAt)).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get password history: %w", err)
+	}
+
+	return h, nil
+}
+
+func (r *passwordRepository) ListHistory(ctx context.Context, userID uuid.UUID, limit int) ([]*ent.PasswordHistory, error) {
+	history, err := r.client.PasswordHistory.Query().
+		Where(passwordhistory.UserID(userID)).
+		Order(ent.Desc(passwordhistory.FieldCreatedAt)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list password history: %w", err)
+	}
+
+	return history, nil
+}
+
+func (r *passwordRepository) AddHistory(ctx context.Context, userID uuid.UUID, passwordHash string, temporary bool, keep int) error {
+	client := ClientFromContext(ctx, r.client)
+
+	err := client.PasswordHistory.Create().
+		SetUserID(userID).
+		SetPasswordHash(passwordHash).
+		SetTemporary(temporary).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to add password history: %w", err)
+	}
+
+	// La dernière entrée est toujours conservée : elle date le mot de passe actuel
+	if keep < 1 {
+		keep = 1
+	}
+	stale, err := client.PasswordHistory.Query().
+		Where(passwordhistory.UserID(userID)).
+		Order(ent.Desc(passwordhistory.FieldCreatedAt)).
+		Offset(keep).
+		IDs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list stale password history: %w", err)
+	}
+	if len(stale) > 0 {
+		if _, err := client.PasswordHistory.Delete().Where(passwordhistory.IDIn(stale...)).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to prune password history: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *passwordRepository) CreateResetToken(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time, ipAddress string) error {
+	err := r.client.PasswordResetToken.Create().
+		SetUserID(userID).
+		SetTokenHash(tokenHash).
+		SetExpiresAt(expiresAt).
+		SetIPAddress(ipAddress).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create password reset token: %w", err)
+	}
+
+	return nil
+}
+
+func (r *passwordRepository) GetResetToken(ctx context.Context, tokenHash string) (*ent.PasswordResetToken, error) {
+	t, err := r.client.PasswordResetToken.Query().
+		Where(passwordresettoken.TokenHash(tokenHash)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get password reset token: %w", err)
+	}
+
+	return t, nil
+}
+
+func (r *passwordRepository) LatestResetToken(ctx context.Context, userID uuid.UUID) (*ent.PasswordResetToken, error) {
+	t, err := r.client.PasswordResetToken.Query().
+		Where(passwordresettoken.UserID(userID)).
+		Order(ent.Desc(passwordresettoken.FieldCreatedAt)).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get password reset token: %w", err)
+	}
+
+	return t, nil
+}
+
+// UseResetToken relies on a conditional UPDATE so that a token cannot be
+// used twice by concurrent requests
+func (r *passwordRepository) UseResetToken(ctx context.Context, id uuid.UUID) (bool, error) {
+	affected, err := ClientFromContext(ctx, r.client).PasswordResetToken.Update().
+		Where(
+			passwordresettoken.ID(id),
+			passwordresettoken.UsedAtIsNil(),
+		).
+		SetUsedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to use password reset token: %w", err)
+	}
+
+	return affected == 1, nil
+}
+
+func (r *passwordRepository) InvalidateResetTokens(ctx context.Context, userID uuid.UUID) error {
+	_, err := ClientFromContext(ctx, r.client).PasswordResetToken.Update().
+		Where(
+			passwordresettoken.UserID(userID),
+			passwordresettoken.UsedAtIsNil(),
+		).
+		SetUsedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to invalidate password reset tokens: %w", err)
+	}
+
+	return nil
+}
+
+func (r *passwordRepository) DeleteExpiredResetTokens(ctx context.Context, before time.Time) (int, error) {
+	deleted, err := r.client.PasswordResetToken.Delete().
+		Where(passwordresettoken.ExpiresAtLT(before)).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired password reset tokens: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -8,8 +8,10 @@ import (
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/controle"
 	"police-trafic-api-frontend-aligned/ent/infraction"
+	"police-trafic-api-frontend-aligned/ent/predicate"
 	"police-trafic-api-frontend-aligned/ent/procesverbal"
 	"police-trafic-api-frontend-aligned/ent/user"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -76,6 +78,7 @@ type PVFilters struct {
 	Expired      *bool
 	Limit        int
 	Offset       int
+	Page         *pagination.Params // Replaces Limit and Offset when set
 }
 
 // PVStatistics represents statistics for PVs
@@ -111,7 +114,7 @@ func (r *pvRepository) Create(ctx context.Context, input *CreatePVInput) (*ent.P
 		zap.Float64("montant", input.MontantTotal))
 
 	id, _ := uuid.Parse(input.ID)
-	create := r.client.ProcesVerbal.Create().
+	create := ClientFromContext(ctx, r.client).ProcesVerbal.Create().
 		SetID(id).
 		SetNumeroPv(input.NumeroPV).
 		SetDateEmission(input.DateEmission).
@@ -168,7 +171,9 @@ func (r *pvRepository) GetByID(ctx context.Context, id string) (*ent.ProcesVerba
 		WithInfractions(func(q *ent.InfractionQuery) {
 			q.WithTypeInfraction().WithControle()
 		}).
-		WithControle().
+		WithControle(func(q *ent.ControleQuery) {
+			q.WithConducteur()
+		}).
 		WithInspection().
 		WithPaiements().
 		WithRecours().
@@ -214,15 +219,24 @@ func (r *pvRepository) GetByNumeroPV(ctx context.Context, numero string) (*ent.P
 // List gets PVs with filters
 func (r *pvRepository) List(ctx context.Context, filters *PVFilters) ([]*ent.ProcesVerbal, error) {
 	query := r.client.ProcesVerbal.Query()
+	order := ent.Desc(procesverbal.FieldDateEmission)
 
 	if filters != nil {
 		query = r.applyFilters(query, filters)
 
-		if filters.Limit > 0 {
-			query = query.Limit(filters.Limit)
-		}
-		if filters.Offset > 0 {
-			query = query.Offset(filters.Offset)
+		if page := filters.Page; page != nil {
+			// Les pages suivantes partent du dernier PV lu
+			query = query.Where(predicate.ProcesVerbal(page.Where())).
+				Offset(page.Offset()).
+				Limit(page.FetchLimit())
+			order = page.OrderBy()
+		} else {
+			if filters.Limit > 0 {
+				query = query.Limit(filters.Limit)
+			}
+			if filters.Offset > 0 {
+				query = query.Offset(filters.Offset)
+			}
 		}
 	}
 
@@ -233,7 +247,7 @@ func (r *pvRepository) List(ctx context.Context, filters *PVFilters) ([]*ent.Pro
 		WithControle().
 		WithInspection().
 		WithPaiements().
-		Order(ent.Desc(procesverbal.FieldDateEmission)).
+		Order(order).
 		All(ctx)
 
 	if err != nil {
@@ -250,6 +264,7 @@ func (r *pvRepository) Count(ctx context.Context, filters *PVFilters) (int, erro
 
 	if filters != nil {
 		query = r.applyFilters(query, filters)
+		query = query.Where(predicate.ProcesVerbal(filters.Page.Filter()))
 	}
 
 	count, err := query.Count(ctx)
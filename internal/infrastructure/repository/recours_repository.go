@@ -6,9 +6,11 @@ import (
 	"time"
 
 	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/predicate"
 	"police-trafic-api-frontend-aligned/ent/procesverbal"
 	"police-trafic-api-frontend-aligned/ent/recours"
 	"police-trafic-api-frontend-aligned/ent/user"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -71,6 +73,7 @@ type RecoursFilters struct {
 	DateFin        *time.Time
 	Limit          int
 	Offset         int
+	Page           *pagination.Params // Replaces Limit and Offset when set
 }
 
 // RecoursStatistics represents statistics for recours
@@ -105,7 +108,7 @@ func (r *recoursRepository) Create(ctx context.Context, input *CreateRecoursInpu
 
 	id, _ := uuid.Parse(input.ID)
 	pvID, _ := uuid.Parse(input.ProcesVerbalID)
-	create := r.client.Recours.Create().
+	create := ClientFromContext(ctx, r.client).Recours.Create().
 		SetID(id).
 		SetNumeroRecours(input.NumeroRecours).
 		SetDateRecours(input.DateRecours).
@@ -184,22 +187,31 @@ func (r *recoursRepository) GetByNumeroRecours(ctx context.Context, numero strin
 // List gets recours with filters
 func (r *recoursRepository) List(ctx context.Context, filters *RecoursFilters) ([]*ent.Recours, error) {
 	query := r.client.Recours.Query()
+	order := ent.Desc(recours.FieldDateRecours)
 
 	if filters != nil {
 		query = r.applyFilters(query, filters)
 
-		if filters.Limit > 0 {
-			query = query.Limit(filters.Limit)
-		}
-		if filters.Offset > 0 {
-			query = query.Offset(filters.Offset)
+		if page := filters.Page; page != nil {
+			// Les pages suivantes partent du dernier recours lu
+			query = query.Where(predicate.Recours(page.Where())).
+				Offset(page.Offset()).
+				Limit(page.FetchLimit())
+			order = page.OrderBy()
+		} else {
+			if filters.Limit > 0 {
+				query = query.Limit(filters.Limit)
+			}
+			if filters.Offset > 0 {
+				query = query.Offset(filters.Offset)
+			}
 		}
 	}
 
 	recoursList, err := query.
 		WithProcesVerbal().
 		WithTraitePar().
-		Order(ent.Desc(recours.FieldDateRecours)).
+		Order(order).
 		All(ctx)
 
 	if err != nil {
@@ -216,6 +228,7 @@ func (r *recoursRepository) Count(ctx context.Context, filters *RecoursFilters)
 
 	if filters != nil {
 		query = r.applyFilters(query, filters)
+		query = query.Where(predicate.Recours(filters.Page.Filter()))
 	}
 
 	count, err := query.Count(ctx)
@@ -0,0 +1,308 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/permission"
+	"police-trafic-api-frontend-aligned/ent/role"
+	"police-trafic-api-frontend-aligned/ent/user"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrRoleNotFound is returned when no role has the given name
+	ErrRoleNotFound = errors.New("role not found")
+	// ErrRoleExists is returned by Create when the name is already taken
+	ErrRoleExists = errors.New("role already exists")
+	// ErrSystemRole is returned by Delete for the roles shipped with the application
+	ErrSystemRole = errors.New("built-in role cannot be deleted")
+	// ErrRoleInUse is returned by Delete while users still have the role
+	ErrRoleInUse = errors.New("role is assigned to users")
+	// ErrUnknownPermission is returned when a permission code is not in the catalog
+	ErrUnknownPermission = errors.New("unknown permission")
+)
+
+// RoleRepository defines role repository interface
+type RoleRepository interface {
+	List(ctx context.Context) ([]*ent.Role, error)
+	GetByName(ctx context.Context, name string) (*ent.Role, error)
+	Create(ctx context.Context, input *CreateRoleInput) (*ent.Role, error)
+	Update(ctx context.Context, name string, input *UpdateRoleInput) (*ent.Role, error)
+	Delete(ctx context.Context, name string) error
+	ListPermissions(ctx context.Context) ([]*ent.Permission, error)
+	SeedDefaults(ctx context.Context, permissions []string, roles map[string][]string) error
+}
+
+// CreateRoleInput represents input for creating a role
+type CreateRoleInput struct {
+	Name        string
+	Libelle     string
+	Description string
+	Permissions []string
+}
+
+// UpdateRoleInput represents input for updating a role.
+// A nil Permissions leaves the permissions unchanged; a non-nil one replaces them.
+type UpdateRoleInput struct {
+	Libelle     *string
+	Description *string
+	Permissions []string
+}
+
+// roleRepository implements RoleRepository
+type roleRepository struct {
+	client *ent.Client
+	logger *zap.Logger
+}
+
+// NewRoleRepository creates a new role repository
+func NewRoleRepository(client *ent.Client, logger *zap.Logger) RoleRepository {
+	return &roleRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+// List returns every role with its permissions
+func (r *roleRepository) List(ctx context.Context) ([]*ent.Role, error) {
+	roles, err := r.client.Role.Query().
+		WithPermissions().
+		Order(ent.Asc(role.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+// GetByName gets a role with its permissions
+func (r *roleRepository) GetByName(ctx context.Context, name string) (*ent.Role, error) {
+	ro, err := r.client.Role.Query().
+		Where(role.Name(name)).
+		WithPermissions().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+
+	return ro, nil
+}
+
+// Create creates a role with the given permissions
+func (r *roleRepository) Create(ctx context.Context, input *CreateRoleInput) (*ent.Role, error) {
+	r.logger.Info("Creating role", zap.String("name", input.Name))
+
+	permissionIDs, err := r.permissionIDs(ctx, input.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	create := r.client.Role.Create().
+		SetName(input.Name).
+		AddPermissionIDs(permissionIDs...)
+	if input.Libelle != "" {
+		create = create.SetLibelle(input.Libelle)
+	}
+	if input.Description != "" {
+		create = create.SetDescription(input.Description)
+	}
+
+	if _, err := create.Save(ctx); err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, ErrRoleExists
+		}
+		r.logger.Error("Failed to create role", zap.Error(err))
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+
+	return r.GetByName(ctx, input.Name)
+}
+
+// Update updates a role and optionally replaces its permissions
+func (r *roleRepository) Update(ctx context.Context, name string, input *UpdateRoleInput) (*ent.Role, error) {
+	r.logger.Info("Updating role", zap.String("name", name))
+
+	ro, err := r.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	update := r.client.Role.UpdateOneID(ro.ID)
+	if input.Libelle != nil {
+		update = update.SetLibelle(*input.Libelle)
+	}
+	if input.Description != nil {
+		update = update.SetDescription(*input.Description)
+	}
+	if input.Permissions != nil {
+		permissionIDs, err := r.permissionIDs(ctx, input.Permissions)
+		if err != nil {
+			return nil, err
+		}
+		update = update.ClearPermissions().AddPermissionIDs(permissionIDs...)
+	}
+
+	if _, err := update.Save(ctx); err != nil {
+		r.logger.Error("Failed to update role", zap.String("name", name), zap.Error(err))
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+
+	return r.GetByName(ctx, name)
+}
+
+// Delete deletes a custom role that no user has anymore
+func (r *roleRepository) Delete(ctx context.Context, name string) error {
+	ro, err := r.GetByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	if ro.System {
+		return ErrSystemRole
+	}
+
+	users, err := r.client.User.Query().Where(user.Role(name)).Count(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count role users: %w", err)
+	}
+	if users > 0 {
+		return ErrRoleInUse
+	}
+
+	if err := r.client.Role.DeleteOneID(ro.ID).Exec(ctx); err != nil {
+		r.logger.Error("Failed to delete role", zap.String("name", name), zap.Error(err))
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+
+	return nil
+}
+
+// ListPermissions returns the permission catalog
+func (r *roleRepository) ListPermissions(ctx context.Context) ([]*ent.Permission, error) {
+	permissions, err := r.client.Permission.Query().
+		Order(ent.Asc(permission.FieldCode)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list permissions: %w", err)
+	}
+
+	return permissions, nil
+}
+
+// SeedDefaults stores the permissions missing from the catalog and creates the
+// missing built-in roles. A permission added to the catalog is also granted to
+// the existing built-in roles that have it by default; other changes made by an
+// administrator are kept. Concurrent instances may seed at the same time, so
+// unique violations are ignored.
+func (r *roleRepository) SeedDefaults(ctx context.Context, permissions []string, roles map[string][]string) error {
+	known, err := r.client.Permission.Query().
+		Select(permission.FieldCode).
+		Strings(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list permissions: %w", err)
+	}
+
+	existing := make(map[string]bool, len(known))
+	for _, code := range known {
+		existing[code] = true
+	}
+
+	added := make(map[string]bool)
+	for _, code := range permissions {
+		if existing[code] {
+			continue
+		}
+		err := r.client.Permission.Create().
+			SetCode(code).
+			SetResource(strings.SplitN(code, ":", 2)[0]).
+			Exec(ctx)
+		if err != nil && !ent.IsConstraintError(err) {
+			return fmt.Errorf("failed to create permission %s: %w", code, err)
+		}
+		added[code] = true
+	}
+
+	for name, codes := range roles {
+		ro, err := r.client.Role.Query().Where(role.Name(name)).Only(ctx)
+		if err != nil && !ent.IsNotFound(err) {
+			return fmt.Errorf("failed to get role %s: %w", name, err)
+		}
+
+		if ro == nil {
+			permissionIDs, err := r.permissionIDs(ctx, codes)
+			if err != nil {
+				return err
+			}
+			err = r.client.Role.Create().
+				SetName(name).
+				SetSystem(true).
+				AddPermissionIDs(permissionIDs...).
+				Exec(ctx)
+			if err != nil && !ent.IsConstraintError(err) {
+				return fmt.Errorf("failed to create role %s: %w", name, err)
+			}
+			r.logger.Info("Built-in role created", zap.String("name", name), zap.Int("permissions", len(codes)))
+			continue
+		}
+
+		var granted []string
+		for _, code := range codes {
+			if added[code] {
+				granted = append(granted, code)
+			}
+		}
+		if len(granted) == 0 {
+			continue
+		}
+		permissionIDs, err := r.permissionIDs(ctx, granted)
+		if err != nil {
+			return err
+		}
+		if err := r.client.Role.UpdateOneID(ro.ID).AddPermissionIDs(permissionIDs...).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to update role %s: %w", name, err)
+		}
+		r.logger.Info("New permissions granted to built-in role", zap.String("name", name), zap.Strings("permissions", granted))
+	}
+
+	return nil
+}
+
+// permissionIDs resolves permission codes, failing with ErrUnknownPermission
+// if one of them is not in the catalog
+func (r *roleRepository) permissionIDs(ctx context.Context, codes []string) ([]uuid.UUID, error) {
+	if len(codes) == 0 {
+		return nil, nil
+	}
+
+	perms, err := r.client.Permission.Query().
+		Where(permission.CodeIn(codes...)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get permissions: %w", err)
+	}
+
+	found := make(map[string]uuid.UUID, len(perms))
+	for _, p := range perms {
+		found[p.Code] = p.ID
+	}
+
+	ids := make([]uuid.UUID, 0, len(codes))
+	for _, code := range codes {
+		id, ok := found[code]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownPermission, code)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/predicate"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/fulltext"
+
+	"go.uber.org/zap"
+)
+
+// SearchRepository runs the queries of the global search. Each method returns
+// the rows of one entity matching the query, best first; like any ent query
+// they are restricted to the caller's commissariats by the tenant interceptor.
+type SearchRepository interface {
+	Vehicules(ctx context.Context, q *fulltext.Query) ([]*ent.Vehicule, error)
+	Conducteurs(ctx context.Context, q *fulltext.Query) ([]*ent.Conducteur, error)
+	ProcesVerbaux(ctx context.Context, q *fulltext.Query) ([]*ent.ProcesVerbal, error)
+	Plaintes(ctx context.Context, q *fulltext.Query) ([]*ent.Plainte, error)
+	Convocations(ctx context.Context, q *fulltext.Query) ([]*ent.Convocation, error)
+	Alertes(ctx context.Context, q *fulltext.Query) ([]*ent.AlerteSecuritaire, error)
+	ObjetsPerdus(ctx context.Context, q *fulltext.Query) ([]*ent.ObjetPerdu, error)
+	ObjetsRetrouves(ctx context.Context, q *fulltext.Query) ([]*ent.ObjetRetrouve, error)
+}
+
+// searchRepository implements SearchRepository
+type searchRepository struct {
+	client *ent.Client
+	logger *zap.Logger
+}
+
+// NewSearchRepository creates a new search repository
+func NewSearchRepository(client *ent.Client, logger *zap.Logger) SearchRepository {
+	return &searchRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (r *searchRepository) failed(entity string, q *fulltext.Query, err error) error {
+	r.logger.Error("Failed to search "+entity, zap.String("query", q.Text), zap.Error(err))
+	return fmt.Errorf("failed to search %s: %w", entity, err)
+}
+
+// Vehicules searches vehicules by plate, chassis number, owner, make and model
+func (r *searchRepository) Vehicules(ctx context.Context, q *fulltext.Query) ([]*ent.Vehicule, error) {
+	rows, err := r.client.Vehicule.Query().
+		Where(predicate.Vehicule(fulltext.Vehicules.Where(q))).
+		Order(fulltext.Vehicules.Order(q)).
+		Limit(q.Limit).
+		All(ctx)
+	if err != nil {
+		return nil, r.failed("vehicules", q, err)
+	}
+	return rows, nil
+}
+
+// Conducteurs searches conducteurs by name, permit number and phone
+func (r *searchRepository) Conducteurs(ctx context.Context, q *fulltext.Query) ([]*ent.Conducteur, error) {
+	rows, err := r.client.Conducteur.Query().
+		Where(predicate.Conducteur(fulltext.Conducteurs.Where(q))).
+		Order(fulltext.Conducteurs.Order(q)).
+		Limit(q.Limit).
+		All(ctx)
+	if err != nil {
+		return nil, r.failed("conducteurs", q, err)
+	}
+	return rows, nil
+}
+
+// ProcesVerbaux searches PV by number
+func (r *searchRepository) ProcesVerbaux(ctx context.Context, q *fulltext.Query) ([]*ent.ProcesVerbal, error) {
+	rows, err := r.client.ProcesVerbal.Query().
+		Where(predicate.ProcesVerbal(fulltext.ProcesVerbaux.Where(q))).
+		Order(fulltext.ProcesVerbaux.Order(q)).
+		Limit(q.Limit).
+		All(ctx)
+	if err != nil {
+		return nil, r.failed("PV", q, err)
+	}
+	return rows, nil
+}
+
+// Plaintes searches plaintes by number and plaignant
+func (r *searchRepository) Plaintes(ctx context.Context, q *fulltext.Query) ([]*ent.Plainte, error) {
+	rows, err := r.client.Plainte.Query().
+		Where(predicate.Plainte(fulltext.Plaintes.Where(q))).
+		Order(fulltext.Plaintes.Order(q)).
+		Limit(q.Limit).
+		All(ctx)
+	if err != nil {
+		return nil, r.failed("plaintes", q, err)
+	}
+	return rows, nil
+}
+
+// Convocations searches convocations by number and convoqué
+func (r *searchRepository) Convocations(ctx context.Context, q *fulltext.Query) ([]*ent.Convocation, error) {
+	rows, err := r.client.Convocation.Query().
+		Where(predicate.Convocation(fulltext.Convocations.Where(q))).
+		Order(fulltext.Convocations.Order(q)).
+		Limit(q.Limit).
+		All(ctx)
+	if err != nil {
+		return nil, r.failed("convocations", q, err)
+	}
+	return rows, nil
+}
+
+// Alertes searches alertes by number, title and description
+func (r *searchRepository) Alertes(ctx context.Context, q *fulltext.Query) ([]*ent.AlerteSecuritaire, error) {
+	rows, err := r.client.AlerteSecuritaire.Query().
+		Where(predicate.AlerteSecuritaire(fulltext.Alertes.Where(q))).
+		Order(fulltext.Alertes.Order(q)).
+		Limit(q.Limit).
+		All(ctx)
+	if err != nil {
+		return nil, r.failed("alertes", q, err)
+	}
+	return rows, nil
+}
+
+// ObjetsPerdus searches objets perdus by number, type and description
+func (r *searchRepository) ObjetsPerdus(ctx context.Context, q *fulltext.Query) ([]*ent.ObjetPerdu, error) {
+	rows, err := r.client.ObjetPerdu.Query().
+		Where(predicate.ObjetPerdu(fulltext.ObjetsPerdus.Where(q))).
+		Order(fulltext.ObjetsPerdus.Order(q)).
+		Limit(q.Limit).
+		All(ctx)
+	if err != nil {
+		return nil, r.failed("objets perdus", q, err)
+	}
+	return rows, nil
+}
+
+// ObjetsRetrouves searches objets retrouvés by number, type, description and
+// place
+func (r *searchRepository) ObjetsRetrouves(ctx context.Context, q *fulltext.Query) ([]*ent.ObjetRetrouve, error) {
+	rows, err := r.client.ObjetRetrouve.Query().
+		Where(predicate.ObjetRetrouve(fulltext.ObjetsRetrouves.Where(q))).
+		Order(fulltext.ObjetsRetrouves.Order(q)).
+		Limit(q.Limit).
+		All(ctx)
+	if err != nil {
+		return nil, r.failed("objets retrouvés", q, err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,368 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/apikey"
+	"police-trafic-api-frontend-aligned/ent/apikeyusage"
+	"police-trafic-api-frontend-aligned/ent/predicate"
+	"police-trafic-api-frontend-aligned/ent/serviceaccount"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrServiceAccountNotFound is returned when no service account has the given ID
+	ErrServiceAccountNotFound = errors.New("service account not found")
+	// ErrServiceAccountExists is returned by Create when the name is already taken
+	ErrServiceAccountExists = errors.New("service account already exists")
+	// ErrAPIKeyNotFound is returned when the key does not belong to the service account
+	ErrAPIKeyNotFound = errors.New("API key not found")
+)
+
+// ServiceAccountRepository defines service account and API key repository interface
+type ServiceAccountRepository interface {
+	List(ctx context.Context) ([]*ent.ServiceAccount, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*ent.ServiceAccount, error)
+	Create(ctx context.Context, input *CreateServiceAccountInput) (*ent.ServiceAccount, error)
+	Update(ctx context.Context, id uuid.UUID, input *UpdateServiceAccountInput) (*ent.ServiceAccount, error)
+	// Delete removes the service account and its keys; the usage history is kept
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	CreateKey(ctx context.Context, input *CreateAPIKeyInput) (*ent.APIKey, error)
+	// GetKeyByPrefix returns a key with its service account, or nil if none has the prefix
+	GetKeyByPrefix(ctx context.Context, prefix string) (*ent.APIKey, error)
+	ListKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]*ent.APIKey, error)
+	RevokeKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) error
+	// ExpireKeys brings forward to expiresAt the expiry of the usable keys of a
+	// service account, except the one given
+	ExpireKeys(ctx context.Context, serviceAccountID, exceptKeyID uuid.UUID, expiresAt time.Time) error
+	// TouchKey records the last use of a key and of its service account
+	TouchKey(ctx context.Context, key *ent.APIKey, ip string, at time.Time) error
+
+	RecordUsage(ctx context.Context, input *APIKeyUsageInput) error
+	ListUsage(ctx context.Context, serviceAccountID uuid.UUID, page *pagination.Params) ([]*ent.APIKeyUsage, int, error)
+	DeleteUsageBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// CreateServiceAccountInput represents input for creating a service account
+type CreateServiceAccountInput struct {
+	Name           string
+	Description    string
+	Permissions    []string
+	AllowedIPs     []string
+	CommissariatID *uuid.UUID
+	CreatedBy      string
+}
+
+// UpdateServiceAccountInput represents input for updating a service account.
+// Nil slices leave the values unchanged; non-nil ones replace them.
+type UpdateServiceAccountInput struct {
+	Description    *string
+	Permissions    []string
+	AllowedIPs     []string
+	CommissariatID *uuid.UUID
+	ClearScope     bool // Remove the commissariat restriction
+	Active         *bool
+}
+
+// CreateAPIKeyInput represents input for creating an API key
+type CreateAPIKeyInput struct {
+	ServiceAccountID uuid.UUID
+	Prefix           string
+	KeyHash          string
+	ExpiresAt        *time.Time
+	CreatedBy        string
+}
+
+// APIKeyUsageInput represents one request made with an API key
+type APIKeyUsageInput struct {
+	ServiceAccountID uuid.UUID
+	APIKeyID         uuid.UUID
+	KeyPrefix        string
+	Method           string
+	Path             string
+	Route            string
+	StatusCode       int
+	IPAddress        string
+	Duration         time.Duration
+}
+
+// serviceAccountRepository implements ServiceAccountRepository
+type serviceAccountRepository struct {
+	client *ent.Client
+	logger *zap.Logger
+}
+
+// NewServiceAccountRepository creates a new service account repository
+func NewServiceAccountRepository(client *ent.Client, logger *zap.Logger) ServiceAccountRepository {
+	return &serviceAccountRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (r *serviceAccountRepository) List(ctx context.Context) ([]*ent.ServiceAccount, error) {
+	accounts, err := r.client.ServiceAccount.Query().
+		Order(ent.Asc(serviceaccount.FieldName)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+func (r *serviceAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (*ent.ServiceAccount, error) {
+	sa, err := r.client.ServiceAccount.Get(ctx, id)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrServiceAccountNotFound
+		}
+		return nil, fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	return sa, nil
+}
+
+func (r *serviceAccountRepository) Create(ctx context.Context, input *CreateServiceAccountInput) (*ent.ServiceAccount, error) {
+	r.logger.Info("Creating service account", zap.String("name", input.Name))
+
+	create := r.client.ServiceAccount.Create().
+		SetName(input.Name).
+		SetPermissions(input.Permissions).
+		SetAllowedIps(input.AllowedIPs).
+		SetNillableCommissariatID(input.CommissariatID).
+		SetCreatedBy(input.CreatedBy)
+	if input.Description != "" {
+		create = create.SetDescription(input.Description)
+	}
+
+	sa, err := create.Save(ctx)
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			return nil, ErrServiceAccountExists
+		}
+		r.logger.Error("Failed to create service account", zap.Error(err))
+		return nil, fmt.Errorf("failed to create service account: %w", err)
+	}
+
+	return sa, nil
+}
+
+func (r *serviceAccountRepository) Update(ctx context.Context, id uuid.UUID, input *UpdateServiceAccountInput) (*ent.ServiceAccount, error) {
+	r.logger.Info("Updating service account", zap.String("id", id.String()))
+
+	update := r.client.ServiceAccount.UpdateOneID(id)
+	if input.Description != nil {
+		update = update.SetDescription(*input.Description)
+	}
+	if input.Permissions != nil {
+		update = update.SetPermissions(input.Permissions)
+	}
+	if input.AllowedIPs != nil {
+		update = update.SetAllowedIps(input.AllowedIPs)
+	}
+	if input.ClearScope {
+		update = update.ClearCommissariatID()
+	} else if input.CommissariatID != nil {
+		update = update.SetCommissariatID(*input.CommissariatID)
+	}
+	if input.Active != nil {
+		update = update.SetActive(*input.Active)
+	}
+
+	sa, err := update.Save(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, ErrServiceAccountNotFound
+		}
+		r.logger.Error("Failed to update service account", zap.String("id", id.String()), zap.Error(err))
+		return nil, fmt.Errorf("failed to update service account: %w", err)
+	}
+
+	return sa, nil
+}
+
+func (r *serviceAccountRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	tx, err := r.client.Tx(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to start transaction: %w", err)
+	}
+
+	if _, err := tx.APIKey.Delete().Where(apikey.ServiceAccountID(id)).Exec(ctx); err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to delete API keys: %w", err)
+	}
+	if err := tx.ServiceAccount.DeleteOneID(id).Exec(ctx); err != nil {
+		_ = tx.Rollback()
+		if ent.IsNotFound(err) {
+			return ErrServiceAccountNotFound
+		}
+		return fmt.Errorf("failed to delete service account: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *serviceAccountRepository) CreateKey(ctx context.Context, input *CreateAPIKeyInput) (*ent.APIKey, error) {
+	key, err := r.client.APIKey.Create().
+		SetServiceAccountID(input.ServiceAccountID).
+		SetPrefix(input.Prefix).
+		SetKeyHash(input.KeyHash).
+		SetNillableExpiresAt(input.ExpiresAt).
+		SetCreatedBy(input.CreatedBy).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *serviceAccountRepository) GetKeyByPrefix(ctx context.Context, prefix string) (*ent.APIKey, error) {
+	key, err := r.client.APIKey.Query().
+		Where(apikey.Prefix(prefix)).
+		WithServiceAccount().
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *serviceAccountRepository) ListKeys(ctx context.Context, serviceAccountID uuid.UUID) ([]*ent.APIKey, error) {
+	keys, err := r.client.APIKey.Query().
+		Where(apikey.ServiceAccountID(serviceAccountID)).
+		Order(ent.Desc(apikey.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *serviceAccountRepository) RevokeKey(ctx context.Context, serviceAccountID, keyID uuid.UUID) error {
+	affected, err := r.client.APIKey.Update().
+		Where(
+			apikey.ID(keyID),
+			apikey.ServiceAccountID(serviceAccountID),
+			apikey.RevokedAtIsNil(),
+		).
+		SetRevokedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+	if affected == 0 {
+		return ErrAPIKeyNotFound
+	}
+
+	return nil
+}
+
+func (r *serviceAccountRepository) ExpireKeys(ctx context.Context, serviceAccountID, exceptKeyID uuid.UUID, expiresAt time.Time) error {
+	_, err := r.client.APIKey.Update().
+		Where(
+			apikey.ServiceAccountID(serviceAccountID),
+			apikey.IDNEQ(exceptKeyID),
+			apikey.RevokedAtIsNil(),
+			apikey.Or(
+				apikey.ExpiresAtIsNil(),
+				apikey.ExpiresAtGT(expiresAt),
+			),
+		).
+		SetExpiresAt(expiresAt).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to expire API keys: %w", err)
+	}
+
+	return nil
+}
+
+func (r *serviceAccountRepository) TouchKey(ctx context.Context, key *ent.APIKey, ip string, at time.Time) error {
+	err := r.client.APIKey.UpdateOneID(key.ID).
+		SetLastUsedAt(at).
+		SetLastUsedIP(ip).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update API key last use: %w", err)
+	}
+
+	err = r.client.ServiceAccount.UpdateOneID(key.ServiceAccountID).
+		SetLastUsedAt(at).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update service account last use: %w", err)
+	}
+
+	return nil
+}
+
+func (r *serviceAccountRepository) RecordUsage(ctx context.Context, input *APIKeyUsageInput) error {
+	err := r.client.APIKeyUsage.Create().
+		SetServiceAccountID(input.ServiceAccountID).
+		SetAPIKeyID(input.APIKeyID).
+		SetKeyPrefix(input.KeyPrefix).
+		SetMethod(input.Method).
+		SetPath(input.Path).
+		SetRoute(input.Route).
+		SetStatusCode(input.StatusCode).
+		SetIPAddress(input.IPAddress).
+		SetDurationMs(input.Duration.Milliseconds()).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to record API key usage: %w", err)
+	}
+
+	return nil
+}
+
+func (r *serviceAccountRepository) ListUsage(ctx context.Context, serviceAccountID uuid.UUID, page *pagination.Params) ([]*ent.APIKeyUsage, int, error) {
+	query := r.client.APIKeyUsage.Query().
+		Where(apikeyusage.ServiceAccountID(serviceAccountID))
+
+	total, err := query.Clone().Where(predicate.APIKeyUsage(page.Filter())).Count(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count API key usage: %w", err)
+	}
+
+	usage, err := query.
+		Where(predicate.APIKeyUsage(page.Where())).
+		Order(page.OrderBy()).
+		Offset(page.Offset()).
+		Limit(page.FetchLimit()).
+		All(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list API key usage: %w", err)
+	}
+
+	return usage, total, nil
+}
+
+func (r *serviceAccountRepository) DeleteUsageBefore(ctx context.Context, before time.Time) (int, error) {
+	deleted, err := r.client.APIKeyUsage.Delete().
+		Where(apikeyusage.CreatedAtLT(before)).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete API key usage: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/signingkey"
+
+	"go.uber.org/zap"
+)
+
+// SigningKeyRepository defines the JWT signing key repository interface
+type SigningKeyRepository interface {
+	// List returns the keys not expired yet, newest first
+	List(ctx context.Context) ([]*ent.SigningKey, error)
+	Create(ctx context.Context, input *CreateSigningKeyInput) (*ent.SigningKey, error)
+	// Retire stops the signing with the keys created before key; they keep
+	// verifying tokens until expiresAt. A key created meanwhile by another
+	// instance is left active.
+	Retire(ctx context.Context, key *ent.SigningKey, retiredAt, expiresAt time.Time) error
+	DeleteExpired(ctx context.Context, before time.Time) (int, error)
+}
+
+// CreateSigningKeyInput represents input for storing a signing key
+type CreateSigningKeyInput struct {
+	Kid        string
+	Algorithm  string
+	PrivateKey string // Encrypted by the caller
+}
+
+// signingKeyRepository implements SigningKeyRepository
+type signingKeyRepository struct {
+	client *ent.Client
+	logger *zap.Logger
+}
+
+// NewSigningKeyRepository creates a new signing key repository
+func NewSigningKeyRepository(client *ent.Client, logger *zap.Logger) SigningKeyRepository {
+	return &signingKeyRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (r *signingKeyRepository) List(ctx context.Context) ([]*ent.SigningKey, error) {
+	keys, err := r.client.SigningKey.Query().
+		Where(signingkey.Or(
+			signingkey.ExpiresAtIsNil(),
+			signingkey.ExpiresAtGT(time.Now()),
+		)).
+		Order(ent.Desc(signingkey.FieldCreatedAt)).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (r *signingKeyRepository) Create(ctx context.Context, input *CreateSigningKeyInput) (*ent.SigningKey, error) {
+	key, err := r.client.SigningKey.Create().
+		SetKid(input.Kid).
+		SetAlgorithm(input.Algorithm).
+		SetPrivateKey(input.PrivateKey).
+		Save(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signing key: %w", err)
+	}
+
+	return key, nil
+}
+
+func (r *signingKeyRepository) Retire(ctx context.Context, key *ent.SigningKey, retiredAt, expiresAt time.Time) error {
+	_, err := r.client.SigningKey.Update().
+		Where(
+			signingkey.KidNEQ(key.Kid),
+			signingkey.CreatedAtLT(key.CreatedAt),
+			signingkey.RetiredAtIsNil(),
+		).
+		SetRetiredAt(retiredAt).
+		SetExpiresAt(expiresAt).
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to retire signing keys: %w", err)
+	}
+
+	return nil
+}
+
+func (r *signingKeyRepository) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	deleted, err := r.client.SigningKey.Delete().
+		Where(signingkey.ExpiresAtLT(before)).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired signing keys: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/streamevent"
+
+	"go.uber.org/zap"
+)
+
+// StreamEventRepository defines stream event repository interface
+type StreamEventRepository interface {
+	Create(ctx context.Context, input *CreateStreamEventInput) (*ent.StreamEvent, error)
+	ListAfter(ctx context.Context, afterID, limit int) ([]*ent.StreamEvent, error)
+	LastID(ctx context.Context) (int, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int, error)
+}
+
+// CreateStreamEventInput represents input for storing a stream event
+type CreateStreamEventInput struct {
+	Type            string
+	ResourceID      string
+	Payload         string
+	Broadcast       bool
+	CommissariatIDs []string
+	AgentIDs        []string
+	InstanceID      string
+}
+
+// streamEventRepository implements StreamEventRepository
+type streamEventRepository struct {
+	client *ent.Client
+	logger *zap.Logger
+}
+
+// NewStreamEventRepository creates a new stream event repository
+func NewStreamEventRepository(client *ent.Client, logger *zap.Logger) StreamEventRepository {
+	return &streamEventRepository{
+		client: client,
+		logger: logger,
+	}
+}
+
+// Create stores a new stream event
+func (r *streamEventRepository) Create(ctx context.Context, input *CreateStreamEventInput) (*ent.StreamEvent, error) {
+	create := r.client.StreamEvent.Create().
+		SetType(input.Type).
+		SetBroadcast(input.Broadcast).
+		SetInstanceID(input.InstanceID)
+
+	if input.ResourceID != "" {
+		create = create.SetResourceID(input.ResourceID)
+	}
+	if input.Payload != "" {
+		create = create.SetPayload(input.Payload)
+	}
+	if len(input.CommissariatIDs) > 0 {
+		create = create.SetCommissariatIds(input.CommissariatIDs)
+	}
+	if len(input.AgentIDs) > 0 {
+		create = create.SetAgentIds(input.AgentIDs)
+	}
+
+	event, err := create.Save(ctx)
+	if err != nil {
+		r.logger.Error("Failed to create stream event", zap.String("type", input.Type), zap.Error(err))
+		return nil, fmt.Errorf("failed to create stream event: %w", err)
+	}
+
+	return event, nil
+}
+
+// ListAfter lists the events stored after the given ID, in order
+func (r *streamEventRepository) ListAfter(ctx context.Context, afterID, limit int) ([]*ent.StreamEvent, error) {
+	events, err := r.client.StreamEvent.Query().
+		Where(streamevent.IDGT(afterID)).
+		Order(ent.Asc(streamevent.FieldID)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stream events: %w", err)
+	}
+
+	return events, nil
+}
+
+// LastID returns the ID of the most recent event, 0 when there is none
+func (r *streamEventRepository) LastID(ctx context.Context) (int, error) {
+	event, err := r.client.StreamEvent.Query().
+		Order(ent.Desc(streamevent.FieldID)).
+		First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to get last stream event: %w", err)
+	}
+
+	return event.ID, nil
+}
+
+// DeleteBefore purges the events older than the given date
+func (r *streamEventRepository) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	deleted, err := r.client.StreamEvent.Delete().
+		Where(streamevent.CreatedAtLT(before)).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete stream events: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"police-trafic-api-frontend-aligned/ent"
+)
+
+// ClientFromContext returns the client of the transaction carried by ctx
+// (ent.NewTxContext) or the given client. Repository writes use it so that a
+// service can run them inside its own transaction.
+func ClientFromContext(ctx context.Context, client *ent.Client) *ent.Client {
+	if tx := ent.TxFromContext(ctx); tx != nil {
+		return tx.Client()
+	}
+	return client
+}
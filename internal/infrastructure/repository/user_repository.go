@@ -6,7 +6,9 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/commissariat"
+	"police-trafic-api-frontend-aligned/ent/predicate"
 	"police-trafic-api-frontend-aligned/ent/user"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -32,6 +34,7 @@ type UserFilters struct {
 	StatutService  *string
 	Active         *bool
 	Search         *string
+	Page           *pagination.Params // Pages and sorts the list when set
 }
 
 // CreateUserInput represents input for creating user
@@ -205,6 +208,7 @@ func (r *userRepository) List(ctx context.Context) ([]*ent.User, error) {
 // ListWithFilters gets users with filters and commissariat
 func (r *userRepository) ListWithFilters(ctx context.Context, filters *UserFilters) ([]*ent.User, error) {
 	query := r.client.User.Query().WithCommissariat()
+	order := ent.Desc(user.FieldCreatedAt)
 
 	if filters != nil {
 		if filters.Active != nil {
@@ -231,9 +235,16 @@ func (r *userRepository) ListWithFilters(ctx context.Context, filters *UserFilte
 				),
 			)
 		}
+		if page := filters.Page; page != nil {
+			// Les pages suivantes partent du dernier utilisateur lu
+			query = query.Where(predicate.User(page.Where())).
+				Offset(page.Offset()).
+				Limit(page.FetchLimit())
+			order = page.OrderBy()
+		}
 	}
 
-	users, err := query.Order(ent.Desc(user.FieldCreatedAt)).All(ctx)
+	users, err := query.Order(order).All(ctx)
 	if err != nil {
 		r.logger.Error("Failed to list users with filters", zap.Error(err))
 		return nil, fmt.Errorf("failed to list users: %w", err)
@@ -256,6 +267,11 @@ func (r *userRepository) Count(ctx context.Context, filters *UserFilters) (int,
 		if filters.StatutService != nil && *filters.StatutService != "" {
 			query = query.Where(user.StatutService(*filters.StatutService))
 		}
+		if filters.CommissariatID != nil && *filters.CommissariatID != "" {
+			commID, _ := uuid.Parse(*filters.CommissariatID)
+			query = query.Where(user.HasCommissariatWith(commissariat.ID(commID)))
+		}
+		query = query.Where(predicate.User(filters.Page.Filter()))
 	}
 
 	count, err := query.Count(ctx)
@@ -6,7 +6,9 @@ import (
 	"strings"
 
 	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/predicate"
 	"police-trafic-api-frontend-aligned/ent/vehicule"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -72,6 +74,7 @@ type VehiculeFilters struct {
 	ProprietaireNom *string
 	Limit           int
 	Offset          int
+	Page            *pagination.Params // Replaces Limit and Offset when set
 }
 
 // vehiculeRepository implements VehiculeRepository
@@ -179,6 +182,7 @@ func (r *vehiculeRepository) GetByImmatriculation(ctx context.Context, immatricu
 // List gets vehicules with filters
 func (r *vehiculeRepository) List(ctx context.Context, filters *VehiculeFilters) ([]*ent.Vehicule, error) {
 	query := r.client.Vehicule.Query()
+	order := ent.Desc(vehicule.FieldCreatedAt)
 
 	if filters != nil {
 		if filters.Marque != nil {
@@ -197,17 +201,25 @@ func (r *vehiculeRepository) List(ctx context.Context, filters *VehiculeFilters)
 			query = query.Where(vehicule.ProprietaireNomContains(*filters.ProprietaireNom))
 		}
 
-		if filters.Limit > 0 {
-			query = query.Limit(filters.Limit)
-		}
-		if filters.Offset > 0 {
-			query = query.Offset(filters.Offset)
+		if page := filters.Page; page != nil {
+			// Les pages suivantes partent du dernier véhicule lu
+			query = query.Where(predicate.Vehicule(page.Where())).
+				Offset(page.Offset()).
+				Limit(page.FetchLimit())
+			order = page.OrderBy()
+		} else {
+			if filters.Limit > 0 {
+				query = query.Limit(filters.Limit)
+			}
+			if filters.Offset > 0 {
+				query = query.Offset(filters.Offset)
+			}
 		}
 	}
 
 	vehicules, err := query.
 		WithControles().
-		Order(ent.Desc(vehicule.FieldCreatedAt)).
+		Order(order).
 		All(ctx)
 
 	if err != nil {
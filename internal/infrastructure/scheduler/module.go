@@ -0,0 +1,27 @@
+package scheduler
+
+import (
+	"context"
+
+	"go.uber.org/fx"
+)
+
+// Module provides the job scheduler. Jobs are collected from the fx group "jobs".
+var Module = fx.Module("scheduler",
+	fx.Provide(
+		fx.Annotate(
+			NewScheduler,
+			fx.ParamTags(``, ``, ``, `group:"jobs"`),
+		),
+	),
+	fx.Invoke(func(lc fx.Lifecycle, s Scheduler) {
+		lc.Append(fx.Hook{
+			OnStart: func(ctx context.Context) error {
+				return s.Start(ctx)
+			},
+			OnStop: func(ctx context.Context) error {
+				return s.Stop(ctx)
+			},
+		})
+	}),
+)
@@ -0,0 +1,469 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Run statuses and triggers stored in the job history
+const (
+	StatusRunning = "RUNNING"
+	StatusSuccess = "SUCCESS"
+	StatusFailure = "FAILURE"
+
+	TriggerScheduled = "SCHEDULED"
+	TriggerManual    = "MANUAL"
+)
+
+const (
+	defaultJobTimeout   = 10 * time.Minute
+	defaultPollInterval = 30 * time.Second
+	// leaseMargin keeps the lock a little longer than the job timeout
+	leaseMargin = time.Minute
+	// bookkeepingTimeout bounds the database writes made around a run
+	bookkeepingTimeout = 10 * time.Second
+)
+
+var (
+	// ErrJobNotFound is returned for an unknown job name
+	ErrJobNotFound = errors.New("job not found")
+	// ErrJobRunning is returned when the job is already running on some instance
+	ErrJobRunning = errors.New("job already running")
+)
+
+// Job is a periodic background task. Modules register jobs in the fx group "jobs".
+type Job struct {
+	Name        string
+	Description string
+	Schedule    string        // Cron expression, can be overridden by scheduler.schedules.<name>
+	Timeout     time.Duration // Defaults to 10 minutes
+	// Run executes the job and returns a short summary stored in the run history
+	Run func(ctx context.Context) (string, error)
+}
+
+// JobInfo describes a registered job and its shared state
+type JobInfo struct {
+	Name        string
+	Description string
+	Schedule    string
+	Paused      bool
+	Running     bool
+	LockedBy    string
+	NextRunAt   *time.Time
+	LastRunAt   *time.Time
+	LastStatus  string
+}
+
+// Scheduler runs registered jobs on their schedule. Several server instances
+// can run it at once: each run is guarded by a lease in the job_states table.
+type Scheduler interface {
+	Start(ctx context.Context) error
+	Stop(ctx context.Context) error
+	Jobs(ctx context.Context) ([]*JobInfo, error)
+	Runs(ctx context.Context, name string, page *pagination.Params) ([]*ent.JobRun, int, error)
+	Trigger(ctx context.Context, name, triggeredBy string) (*ent.JobRun, error)
+	Pause(ctx context.Context, name string) error
+	Resume(ctx context.Context, name string) error
+}
+
+type registeredJob struct {
+	Job
+	schedule Schedule
+}
+
+type scheduler struct {
+	jobs     map[string]*registeredJob
+	names    []string
+	jobRepo  repository.JobRepository
+	config   *config.SchedulerConfig
+	instance string
+	logger   *zap.Logger
+
+	mu     sync.Mutex
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewScheduler creates a new scheduler with the jobs provided by the modules
+func NewScheduler(jobRepo repository.JobRepository, cfg *config.Config, logger *zap.Logger, jobs []Job) (Scheduler, error) {
+	s := &scheduler{
+		jobs:     make(map[string]*registeredJob, len(jobs)),
+		jobRepo:  jobRepo,
+		config:   &cfg.Scheduler,
+		instance: cfg.Scheduler.InstanceID,
+		logger:   logger,
+	}
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+
+	if s.instance == "" {
+		hostname, _ := os.Hostname()
+		s.instance = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+	}
+
+	for _, job := range jobs {
+		if err := s.register(job); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+func (s *scheduler) register(job Job) error {
+	if job.Name == "" || job.Run == nil {
+		return fmt.Errorf("invalid job: name and run function are required")
+	}
+	if _, exists := s.jobs[job.Name]; exists {
+		return fmt.Errorf("job %s registered twice", job.Name)
+	}
+
+	if override, ok := s.config.Schedules[job.Name]; ok && override != "" {
+		job.Schedule = override
+	}
+	schedule, err := ParseSchedule(job.Schedule)
+	if err != nil {
+		return fmt.Errorf("invalid schedule for job %s: %w", job.Name, err)
+	}
+	if job.Timeout <= 0 {
+		job.Timeout = defaultJobTimeout
+	}
+
+	s.jobs[job.Name] = &registeredJob{Job: job, schedule: schedule}
+	s.names = append(s.names, job.Name)
+	return nil
+}
+
+// Start registers the job states and starts the polling loop
+func (s *scheduler) Start(ctx context.Context) error {
+	if !s.config.Enabled {
+		s.logger.Info("Scheduler disabled, jobs can still be triggered manually")
+		return nil
+	}
+
+	now := time.Now()
+	for _, name := range s.names {
+		job := s.jobs[name]
+		if _, err := s.jobRepo.EnsureState(ctx, name, job.schedule.Next(now)); err != nil {
+			return fmt.Errorf("failed to register job %s: %w", name, err)
+		}
+	}
+
+	interval := s.config.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	s.wg.Add(1)
+	go s.loop(interval)
+
+	s.logger.Info("Scheduler started",
+		zap.String("instance", s.instance),
+		zap.Int("jobs", len(s.names)),
+		zap.Duration("poll_interval", interval),
+	)
+	return nil
+}
+
+// Stop stops the polling loop and waits for running jobs to finish
+func (s *scheduler) Stop(ctx context.Context) error {
+	// Sous verrou: aucun job ne peut démarrer après l'annulation
+	s.mu.Lock()
+	s.cancel()
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("Scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("scheduler stop: %w", ctx.Err())
+	}
+}
+
+func (s *scheduler) loop(interval time.Duration) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	s.tick()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.tick()
+		}
+	}
+}
+
+// tick starts every due job whose lease this instance manages to take
+func (s *scheduler) tick() {
+	ctx, cancel := context.WithTimeout(s.ctx, bookkeepingTimeout)
+	defer cancel()
+
+	states, err := s.jobRepo.ListStates(ctx)
+	if err != nil {
+		s.logger.Error("Failed to load job states", zap.Error(err))
+		return
+	}
+
+	now := time.Now()
+	for _, state := range states {
+		job, ok := s.jobs[state.Name]
+		if !ok || state.Paused || state.NextRunAt == nil || state.NextRunAt.After(now) {
+			continue
+		}
+
+		next := job.schedule.Next(now)
+		acquired, err := s.jobRepo.AcquireLease(ctx, &repository.AcquireJobLeaseInput{
+			Name:       job.Name,
+			Owner:      s.instance,
+			Now:        now,
+			LeaseUntil: now.Add(job.Timeout + leaseMargin),
+			NextRunAt:  &next,
+		})
+		if err != nil {
+			s.logger.Error("Failed to acquire job lease", zap.String("job", job.Name), zap.Error(err))
+			continue
+		}
+		if !acquired {
+			// Une autre instance s'en charge
+			continue
+		}
+
+		if _, err := s.start(ctx, job, TriggerScheduled, ""); err != nil {
+			s.logger.Error("Failed to start job", zap.String("job", job.Name), zap.Error(err))
+		}
+	}
+}
+
+// Trigger runs a job immediately, even if it is paused
+func (s *scheduler) Trigger(ctx context.Context, name, triggeredBy string) (*ent.JobRun, error) {
+	job, ok := s.jobs[name]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+
+	now := time.Now()
+	if _, err := s.jobRepo.EnsureState(ctx, name, job.schedule.Next(now)); err != nil {
+		return nil, err
+	}
+
+	acquired, err := s.jobRepo.AcquireLease(ctx, &repository.AcquireJobLeaseInput{
+		Name:       name,
+		Owner:      s.instance,
+		Now:        now,
+		LeaseUntil: now.Add(job.Timeout + leaseMargin),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !acquired {
+		return nil, ErrJobRunning
+	}
+
+	return s.start(ctx, job, TriggerManual, triggeredBy)
+}
+
+// start records the run and executes the job in the background. The lease must be held.
+func (s *scheduler) start(ctx context.Context, job *registeredJob, trigger, triggeredBy string) (*ent.JobRun, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx.Err() != nil {
+		s.release(job.Name, StatusFailure)
+		return nil, fmt.Errorf("scheduler is stopping")
+	}
+
+	run, err := s.jobRepo.CreateRun(ctx, &repository.CreateJobRunInput{
+		JobName:     job.Name,
+		Trigger:     trigger,
+		TriggeredBy: triggeredBy,
+		InstanceID:  s.instance,
+		StartedAt:   time.Now(),
+	})
+	if err != nil {
+		s.release(job.Name, StatusFailure)
+		return nil, err
+	}
+
+	s.wg.Add(1)
+	go s.execute(job, run.ID)
+
+	return run, nil
+}
+
+func (s *scheduler) execute(job *registeredJob, runID uuid.UUID) {
+	defer s.wg.Done()
+
+	ctx, cancel := context.WithTimeout(s.ctx, job.Timeout)
+	defer cancel()
+
+	s.logger.Info("Job started", zap.String("job", job.Name), zap.String("run_id", runID.String()))
+
+	start := time.Now()
+	result, err := s.safeRun(ctx, job)
+	duration := time.Since(start)
+
+	status := StatusSuccess
+	errorMessage := ""
+	if err != nil {
+		status = StatusFailure
+		errorMessage = err.Error()
+		s.logger.Error("Job failed",
+			zap.String("job", job.Name),
+			zap.Duration("duration", duration),
+			zap.Error(err),
+		)
+	} else {
+		s.logger.Info("Job completed",
+			zap.String("job", job.Name),
+			zap.Duration("duration", duration),
+			zap.String("result", result),
+		)
+	}
+
+	// Le contexte du job peut avoir expiré: l'historique est écrit avec un contexte dédié
+	writeCtx, writeCancel := context.WithTimeout(context.Background(), bookkeepingTimeout)
+	defer writeCancel()
+
+	if _, err := s.jobRepo.FinishRun(writeCtx, runID, &repository.FinishJobRunInput{
+		Status:       status,
+		FinishedAt:   time.Now(),
+		Duration:     duration,
+		Result:       result,
+		ErrorMessage: errorMessage,
+	}); err != nil {
+		s.logger.Error("Failed to record job run", zap.String("job", job.Name), zap.Error(err))
+	}
+
+	s.release(job.Name, status)
+}
+
+// safeRun turns a panic inside a job into an error so the lease is always released
+func (s *scheduler) safeRun(ctx context.Context, job *registeredJob) (result string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panicked: %v", r)
+		}
+	}()
+	return job.Run(ctx)
+}
+
+func (s *scheduler) release(name, status string) {
+	ctx, cancel := context.WithTimeout(context.Background(), bookkeepingTimeout)
+	defer cancel()
+
+	if err := s.jobRepo.ReleaseLease(ctx, name, s.instance, status, time.Now()); err != nil {
+		s.logger.Error("Failed to release job lease", zap.String("job", name), zap.Error(err))
+	}
+}
+
+// Jobs lists the registered jobs with their shared state
+func (s *scheduler) Jobs(ctx context.Context) ([]*JobInfo, error) {
+	states, err := s.jobRepo.ListStates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*ent.JobState, len(states))
+	for _, state := range states {
+		byName[state.Name] = state
+	}
+
+	now := time.Now()
+	result := make([]*JobInfo, 0, len(s.names))
+	for _, name := range s.names {
+		job := s.jobs[name]
+		info := &JobInfo{
+			Name:        job.Name,
+			Description: job.Description,
+			Schedule:    job.Schedule,
+		}
+		if state, ok := byName[name]; ok {
+			info.Paused = state.Paused
+			info.Running = state.LockedUntil != nil && state.LockedUntil.After(now)
+			info.LockedBy = state.LockedBy
+			info.NextRunAt = state.NextRunAt
+			info.LastRunAt = state.LastRunAt
+			info.LastStatus = state.LastStatus
+		}
+		result = append(result, info)
+	}
+
+	return result, nil
+}
+
+// Runs returns the run history of a job, most recent first
+func (s *scheduler) Runs(ctx context.Context, name string, page *pagination.Params) ([]*ent.JobRun, int, error) {
+	if _, ok := s.jobs[name]; !ok {
+		return nil, 0, ErrJobNotFound
+	}
+
+	filters := &repository.JobRunFilters{
+		JobName: &name,
+		Page:    page,
+	}
+
+	runs, err := s.jobRepo.ListRuns(ctx, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := s.jobRepo.CountRuns(ctx, filters)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return runs, total, nil
+}
+
+// Pause stops scheduled runs of a job on every instance
+func (s *scheduler) Pause(ctx context.Context, name string) error {
+	job, ok := s.jobs[name]
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	if _, err := s.jobRepo.EnsureState(ctx, name, job.schedule.Next(time.Now())); err != nil {
+		return err
+	}
+
+	return s.jobRepo.SetPaused(ctx, name, true, nil)
+}
+
+// Resume re-enables scheduled runs of a job, starting from the next occurrence
+func (s *scheduler) Resume(ctx context.Context, name string) error {
+	job, ok := s.jobs[name]
+	if !ok {
+		return ErrJobNotFound
+	}
+
+	next := job.schedule.Next(time.Now())
+	if _, err := s.jobRepo.EnsureState(ctx, name, next); err != nil {
+		return err
+	}
+
+	// Les occurrences manquées pendant la pause ne sont pas rattrapées
+	return s.jobRepo.SetPaused(ctx, name, false, &next)
+}
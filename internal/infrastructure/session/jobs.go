@@ -0,0 +1,24 @@
+package session
+
+import (
+	"context"
+	"fmt"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/scheduler"
+)
+
+// NewCleanupJob removes expired and revoked sessions
+func NewCleanupJob(service Service) scheduler.Job {
+	return scheduler.Job{
+		Name:        "session-cleanup",
+		Description: "Supprime les sessions expirées ou révoquées",
+		Schedule:    "0 * * * *",
+		Run: func(ctx context.Context) (string, error) {
+			deleted, err := service.CleanupExpiredSessions(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d sessions supprimées", deleted), nil
+		},
+	}
+}
@@ -5,4 +5,10 @@ import "go.uber.org/fx"
 // Module provides session service for dependency injection
 var Module = fx.Module("session",
 	fx.Provide(NewService),
+	fx.Provide(
+		fx.Annotate(
+			NewCleanupJob,
+			fx.ResultTags(`group:"jobs"`),
+		),
+	),
 )
@@ -9,6 +9,8 @@ import (
 	"time"
 
 	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/mfaverification"
+	"police-trafic-api-frontend-aligned/ent/user"
 	"police-trafic-api-frontend-aligned/ent/usersession"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
 
@@ -34,8 +36,9 @@ type SessionInfo struct {
 
 // Service defines the session management interface
 type Service interface {
-	// CreateSession creates a new session for a user on a device
-	CreateSession(ctx context.Context, userID uuid.UUID, device DeviceInfo, ipAddress string) (*SessionInfo, error)
+	// CreateSession creates a new session for a user on a device. mfaMethod is the
+	// second factor verified during the login (mfa.MethodTOTP...), empty if none.
+	CreateSession(ctx context.Context, userID uuid.UUID, device DeviceInfo, ipAddress string, mfaMethod string) (*SessionInfo, error)
 
 	// ValidateRefreshToken validates a refresh token and returns the session
 	ValidateRefreshToken(ctx context.Context, refreshToken string, deviceID string) (*ent.UserSession, error)
@@ -95,11 +98,11 @@ func NewService(client *ent.Client, cfg *config.Config, logger *zap.Logger) Serv
 }
 
 // CreateSession creates a new session for a user
-func (s *service) CreateSession(ctx context.Context, userID uuid.UUID, device DeviceInfo, ipAddress string) (*SessionInfo, error) {
+func (s *service) CreateSession(ctx context.Context, userID uuid.UUID, device DeviceInfo, ipAddress string, mfaMethod string) (*SessionInfo, error) {
 	// Check if user already has a session on this device
 	existingSession, err := s.client.UserSession.Query().
 		Where(
-			usersession.HasUserWith(),
+			usersession.HasUserWith(user.ID(userID)),
 			usersession.DeviceID(device.DeviceID),
 			usersession.IsActive(true),
 			usersession.IsRevoked(false),
@@ -120,7 +123,7 @@ func (s *service) CreateSession(ctx context.Context, userID uuid.UUID, device De
 	// Check max devices limit
 	activeSessions, err := s.client.UserSession.Query().
 		Where(
-			usersession.HasUserWith(),
+			usersession.HasUserWith(user.ID(userID)),
 			usersession.IsActive(true),
 			usersession.IsRevoked(false),
 		).
@@ -132,7 +135,7 @@ func (s *service) CreateSession(ctx context.Context, userID uuid.UUID, device De
 		// Revoke oldest session
 		oldestSession, err := s.client.UserSession.Query().
 			Where(
-				usersession.HasUserWith(),
+				usersession.HasUserWith(user.ID(userID)),
 				usersession.IsActive(true),
 				usersession.IsRevoked(false),
 			).
@@ -178,10 +181,22 @@ func (s *service) CreateSession(ctx context.Context, userID uuid.UUID, device De
 		return nil, fmt.Errorf("failed to create session: %w", err)
 	}
 
+	if mfaMethod != "" {
+		err := s.client.MFAVerification.Create().
+			SetSessionID(session.ID).
+			SetUserID(userID).
+			SetMethod(mfaMethod).
+			Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record session second factor: %w", err)
+		}
+	}
+
 	s.logger.Info("Session created",
 		zap.String("session_id", session.ID.String()),
 		zap.String("user_id", userID.String()),
 		zap.String("device_id", device.DeviceID),
+		zap.String("mfa_method", mfaMethod),
 	)
 
 	return &SessionInfo{
@@ -297,7 +312,7 @@ func (s *service) RevokeAllUserSessions(ctx context.Context, userID uuid.UUID, r
 	now := time.Now()
 	_, err := s.client.UserSession.Update().
 		Where(
-			usersession.HasUserWith(),
+			usersession.HasUserWith(user.ID(userID)),
 			usersession.IsActive(true),
 		).
 		SetIsActive(false).
@@ -310,6 +325,11 @@ func (s *service) RevokeAllUserSessions(ctx context.Context, userID uuid.UUID, r
 		return fmt.Errorf("failed to revoke all sessions: %w", err)
 	}
 
+	// Les jetons émis sans session (connexion web) sont refusés eux aussi
+	if err := s.client.User.UpdateOneID(userID).SetTokensRevokedAt(now).Exec(ctx); err != nil && !ent.IsNotFound(err) {
+		return fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+
 	s.logger.Info("All user sessions revoked",
 		zap.String("user_id", userID.String()),
 		zap.String("reason", reason),
@@ -323,7 +343,7 @@ func (s *service) RevokeOtherSessions(ctx context.Context, userID uuid.UUID, cur
 	now := time.Now()
 	_, err := s.client.UserSession.Update().
 		Where(
-			usersession.HasUserWith(),
+			usersession.HasUserWith(user.ID(userID)),
 			usersession.IsActive(true),
 			usersession.IDNEQ(currentSessionID),
 		).
@@ -350,7 +370,7 @@ func (s *service) RevokeOtherSessions(ctx context.Context, userID uuid.UUID, cur
 func (s *service) GetUserSessions(ctx context.Context, userID uuid.UUID) ([]*ent.UserSession, error) {
 	sessions, err := s.client.UserSession.Query().
 		Where(
-			usersession.HasUserWith(),
+			usersession.HasUserWith(user.ID(userID)),
 			usersession.IsActive(true),
 			usersession.IsRevoked(false),
 		).
@@ -383,6 +403,13 @@ func (s *service) CleanupExpiredSessions(ctx context.Context) (int, error) {
 		return 0, fmt.Errorf("failed to cleanup expired sessions: %w", err)
 	}
 
+	// Aucune session ne dure plus que MaxSessionDuration : ses traces de second facteur non plus
+	if _, err := s.client.MFAVerification.Delete().
+		Where(mfaverification.VerifiedAtLT(maxAge)).
+		Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to cleanup session second factors: %w", err)
+	}
+
 	if deleted > 0 {
 		s.logger.Info("Cleaned up expired sessions", zap.Int("count", deleted))
 	}
@@ -0,0 +1,136 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"time"
+)
+
+// localBackend stores the files below a directory. It only suits a single
+// API instance, or a directory shared between the instances.
+type localBackend struct {
+	root string
+}
+
+// NewLocal creates a backend storing the files below root
+func NewLocal(root string) (Backend, error) {
+	if root == "" {
+		root = "./uploads"
+	}
+	if err := os.MkdirAll(root, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create upload directory: %w", err)
+	}
+	return &localBackend{root: root}, nil
+}
+
+func (b *localBackend) Name() string {
+	return BackendLocal
+}
+
+func (b *localBackend) path(key string) (string, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(b.root, filepath.FromSlash(key)), nil
+}
+
+func (b *localBackend) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	fullPath, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	// Écriture dans un fichier temporaire renommé à la fin : jamais de fichier tronqué sous la clé
+	tmp, err := os.CreateTemp(filepath.Dir(fullPath), ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create destination file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, r)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save file: %w", err)
+	}
+	if size >= 0 && written != size {
+		return fmt.Errorf("failed to save file: %d bytes written, %d expected", written, size)
+	}
+	if err := os.Rename(tmp.Name(), fullPath); err != nil {
+		return fmt.Errorf("failed to save file: %w", err)
+	}
+	return nil
+}
+
+func (b *localBackend) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	fullPath, err := b.path(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	f, err := os.Open(fullPath)
+	if err != nil {
+		return nil, nil, localError(err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+	return f, localObject(key, info), nil
+}
+
+func (b *localBackend) Stat(ctx context.Context, key string) (*Object, error) {
+	fullPath, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(fullPath)
+	if err != nil {
+		return nil, localError(err)
+	}
+	return localObject(key, info), nil
+}
+
+func (b *localBackend) Delete(ctx context.Context, key string) error {
+	fullPath, err := b.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(fullPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (b *localBackend) Presign(ctx context.Context, key string, expires time.Duration, filename string) (string, error) {
+	return "", ErrPresignUnsupported
+}
+
+// localObject describes a file; its type is guessed from the extension, the
+// filesystem keeping none
+func localObject(key string, info fs.FileInfo) *Object {
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	return &Object{Key: key, Size: info.Size(), ContentType: contentType, ModTime: info.ModTime()}
+}
+
+func localError(err error) error {
+	if errors.Is(err, fs.ErrNotExist) {
+		return ErrNotFound
+	}
+	return err
+}
@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalBackend(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	backend, err := NewLocal(root)
+	require.NoError(t, err)
+
+	key := "2024/01/rapport.pdf"
+	require.NoError(t, backend.Put(ctx, key, strings.NewReader("%PDF-1.4"), 8, "application/pdf"))
+	assert.FileExists(t, filepath.Join(root, "2024", "01", "rapport.pdf"))
+
+	obj, err := backend.Stat(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), obj.Size)
+	assert.Equal(t, "application/pdf", obj.ContentType)
+
+	r, _, err := backend.Get(ctx, key)
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	r.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "%PDF-1.4", string(content))
+
+	_, err = backend.Presign(ctx, key, 0, "")
+	assert.ErrorIs(t, err, ErrPresignUnsupported)
+
+	require.NoError(t, backend.Delete(ctx, key))
+	require.NoError(t, backend.Delete(ctx, key))
+	_, err = backend.Stat(ctx, key)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestLocalBackend_Put_Truncated(t *testing.T) {
+	ctx := context.Background()
+	root := t.TempDir()
+	backend, err := NewLocal(root)
+	require.NoError(t, err)
+
+	// Envoi interrompu : aucun fichier partiel sous la clé
+	assert.Error(t, backend.Put(ctx, "a.jpg", strings.NewReader("abc"), 10, "image/jpeg"))
+	_, err = backend.Stat(ctx, "a.jpg")
+	assert.ErrorIs(t, err, ErrNotFound)
+	entries, err := os.ReadDir(root)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestCleanKey(t *testing.T) {
+	for _, key := range []string{"../etc/passwd", "a/../../b", "/abs", "", "a//b"} {
+		_, err := cleanKey(key)
+		assert.Error(t, err, key)
+	}
+	key, err := cleanKey("2024/01/photo.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, "2024/01/photo.jpg", key)
+}
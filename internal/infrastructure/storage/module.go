@@ -0,0 +1,8 @@
+package storage
+
+import "go.uber.org/fx"
+
+// Module provides the configured file storage backend
+var Module = fx.Module("storage",
+	fx.Provide(New),
+)
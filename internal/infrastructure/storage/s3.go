@@ -0,0 +1,195 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+)
+
+// maxPresignExpiry is the longest validity of a presigned URL accepted by S3
+const maxPresignExpiry = 7 * 24 * time.Hour
+
+// s3Backend stores the files in a bucket of an S3-compatible service, with
+// requests signed with AWS Signature Version 4
+type s3Backend struct {
+	endpoint  *url.URL
+	bucket    string
+	pathStyle bool
+	signer    *signer
+	client    *http.Client
+	now       func() time.Time
+}
+
+// NewS3 creates a backend storing the files in a bucket
+func NewS3(cfg config.S3Config) (Backend, error) {
+	if cfg.Endpoint == "" || cfg.Bucket == "" {
+		return nil, fmt.Errorf("storage.s3.endpoint and storage.s3.bucket are required")
+	}
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid storage.s3.endpoint %q", cfg.Endpoint)
+	}
+	region := cfg.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+	return &s3Backend{
+		endpoint:  endpoint,
+		bucket:    cfg.Bucket,
+		pathStyle: cfg.PathStyle,
+		signer:    &signer{accessKey: cfg.AccessKey, secretKey: cfg.SecretKey, region: region},
+		client:    &http.Client{Transport: tracing.Transport()},
+		now:       time.Now,
+	}, nil
+}
+
+func (b *s3Backend) Name() string {
+	return BackendS3
+}
+
+// objectURL returns the URL of an object: the bucket is a path segment for
+// MinIO and most self-hosted services, a subdomain for AWS
+func (b *s3Backend) objectURL(key string) (*url.URL, error) {
+	key, err := cleanKey(key)
+	if err != nil {
+		return nil, err
+	}
+	u := *b.endpoint
+	base := strings.TrimSuffix(u.Path, "/")
+	if b.pathStyle {
+		u.Path = base + "/" + b.bucket + "/" + key
+	} else {
+		u.Host = b.bucket + "." + u.Host
+		u.Path = base + "/" + key
+	}
+	// Chemin envoyé tel qu'il est signé
+	u.RawPath = canonicalPath(&u)
+	return &u, nil
+}
+
+func (b *s3Backend) request(ctx context.Context, method, key string, body io.Reader) (*http.Request, error) {
+	u, err := b.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+	return http.NewRequestWithContext(ctx, method, u.String(), body)
+}
+
+func (b *s3Backend) do(req *http.Request) (*http.Response, error) {
+	b.signer.sign(req, unsignedPayload, b.now())
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("storage request failed: %w", err)
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return nil, ErrNotFound
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("storage returned %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	return resp, nil
+}
+
+func (b *s3Backend) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if size < 0 {
+		return fmt.Errorf("storage: the size of %s is required", key)
+	}
+	req, err := b.request(ctx, http.MethodPut, key, r)
+	if err != nil {
+		return err
+	}
+	// Corps transmis tel quel, sans lecture préalable pour le signer
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := b.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (b *s3Backend) Get(ctx context.Context, key string) (io.ReadCloser, *Object, error) {
+	req, err := b.request(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := b.do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.Body, s3Object(key, resp), nil
+}
+
+func (b *s3Backend) Stat(ctx context.Context, key string) (*Object, error) {
+	req, err := b.request(ctx, http.MethodHead, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := b.do(req)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+	return s3Object(key, resp), nil
+}
+
+func (b *s3Backend) Delete(ctx context.Context, key string) error {
+	req, err := b.request(ctx, http.MethodDelete, key, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := b.do(req)
+	if err == ErrNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func (b *s3Backend) Presign(ctx context.Context, key string, expires time.Duration, filename string) (string, error) {
+	if expires <= 0 || expires > maxPresignExpiry {
+		return "", fmt.Errorf("storage: presigned URL lifetime must be between 1s and %s", maxPresignExpiry)
+	}
+	u, err := b.objectURL(key)
+	if err != nil {
+		return "", err
+	}
+	if filename != "" {
+		query := u.Query()
+		query.Set("response-content-disposition", fmt.Sprintf("attachment; filename=%q", filename))
+		u.RawQuery = query.Encode()
+	}
+	return b.signer.presign(http.MethodGet, u, expires, b.now()), nil
+}
+
+func s3Object(key string, resp *http.Response) *Object {
+	obj := &Object{Key: key, Size: resp.ContentLength, ContentType: resp.Header.Get("Content-Type")}
+	if size, err := strconv.ParseInt(resp.Header.Get("Content-Length"), 10, 64); err == nil {
+		obj.Size = size
+	}
+	if modTime, err := http.ParseTime(resp.Header.Get("Last-Modified")); err == nil {
+		obj.ModTime = modTime
+	}
+	return obj
+}
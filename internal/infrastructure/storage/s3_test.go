@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeS3 stands in for MinIO: objects kept in memory, addressed by path
+// (/bucket/key), every request required to be signed
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]fakeObject
+}
+
+type fakeObject struct {
+	body        []byte
+	contentType string
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=minio/") ||
+		r.Header.Get("X-Amz-Content-Sha256") != unsignedPayload {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = fakeObject{body: body, contentType: r.Header.Get("Content-Type")}
+	case http.MethodGet, http.MethodHead:
+		obj, ok := f.objects[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", obj.contentType)
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		w.Write(obj.body)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestS3Backend(t *testing.T) {
+	ctx := context.Background()
+	fake := &fakeS3{objects: make(map[string]fakeObject)}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	backend, err := NewS3(config.S3Config{
+		Endpoint:  server.URL,
+		Bucket:    "documents",
+		AccessKey: "minio",
+		SecretKey: "minio123",
+		PathStyle: true,
+	})
+	require.NoError(t, err)
+
+	key := "2024/01/photo accident.jpg"
+	require.NoError(t, backend.Put(ctx, key, strings.NewReader("jpeg-data"), 9, "image/jpeg"))
+	assert.Contains(t, fake.objects, "/documents/2024/01/photo accident.jpg")
+
+	obj, err := backend.Stat(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, int64(9), obj.Size)
+	assert.Equal(t, "image/jpeg", obj.ContentType)
+
+	r, _, err := backend.Get(ctx, key)
+	require.NoError(t, err)
+	content, err := io.ReadAll(r)
+	r.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "jpeg-data", string(content))
+
+	presigned, err := backend.Presign(ctx, key, 5*time.Minute, "photo.jpg")
+	require.NoError(t, err)
+	u, err := url.Parse(presigned)
+	require.NoError(t, err)
+	assert.Equal(t, "/documents/2024/01/photo%20accident.jpg", u.EscapedPath())
+	assert.Equal(t, "300", u.Query().Get("X-Amz-Expires"))
+	assert.Equal(t, `attachment; filename="photo.jpg"`, u.Query().Get("response-content-disposition"))
+	assert.NotEmpty(t, u.Query().Get("X-Amz-Signature"))
+
+	require.NoError(t, backend.Delete(ctx, key))
+	_, _, err = backend.Get(ctx, key)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestS3Backend_ObjectURL(t *testing.T) {
+	backend, err := NewS3(config.S3Config{Endpoint: "https://s3.eu-west-3.amazonaws.com", Bucket: "documents"})
+	require.NoError(t, err)
+
+	u, err := backend.(*s3Backend).objectURL("2024/01/a.pdf")
+	require.NoError(t, err)
+	assert.Equal(t, "https://documents.s3.eu-west-3.amazonaws.com/2024/01/a.pdf", u.String())
+}
@@ -0,0 +1,76 @@
+// Package storage keeps the uploaded files outside the API instances: on the
+// local filesystem for a single instance, or in an S3-compatible bucket (AWS,
+// MinIO, ...) shared by all of them. Files are addressed by a key, the
+// relative path recorded in the database (chemin_stockage).
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+)
+
+// Values of storage.backend
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+var (
+	// ErrNotFound is returned for a key without object
+	ErrNotFound = errors.New("storage: object not found")
+	// ErrPresignUnsupported is returned by backends that cannot hand out
+	// direct URLs: their objects are streamed through the API
+	ErrPresignUnsupported = errors.New("storage: presigned URLs not supported")
+)
+
+// Object describes a stored file
+type Object struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// Backend stores files by key
+type Backend interface {
+	// Name identifies the backend in logs
+	Name() string
+	// Put streams size bytes of r to key, replacing any previous object
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the object; the caller closes the reader
+	Get(ctx context.Context, key string) (io.ReadCloser, *Object, error)
+	Stat(ctx context.Context, key string) (*Object, error)
+	// Delete removes the object; a missing object is not an error
+	Delete(ctx context.Context, key string) error
+	// Presign returns a URL downloading the object without credentials until
+	// it expires, as an attachment named filename when set
+	Presign(ctx context.Context, key string, expires time.Duration, filename string) (string, error)
+}
+
+// New creates the configured backend
+func New(cfg *config.Config) (Backend, error) {
+	switch cfg.Storage.Backend {
+	case "", BackendLocal:
+		return NewLocal(cfg.Storage.LocalDir)
+	case BackendS3:
+		return NewS3(cfg.Storage.S3)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Storage.Backend)
+	}
+}
+
+// cleanKey checks that a key stays below the root of the backend
+func cleanKey(key string) (string, error) {
+	cleaned := path.Clean("/" + strings.ReplaceAll(key, "\\", "/"))[1:]
+	if cleaned == "" || cleaned != strings.ReplaceAll(key, "\\", "/") {
+		return "", fmt.Errorf("storage: invalid key %q", key)
+	}
+	return cleaned, nil
+}
@@ -0,0 +1,305 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/alertesecuritaire"
+	"police-trafic-api-frontend-aligned/ent/conducteur"
+	"police-trafic-api-frontend-aligned/ent/controle"
+	"police-trafic-api-frontend-aligned/ent/convocation"
+	"police-trafic-api-frontend-aligned/ent/document"
+	"police-trafic-api-frontend-aligned/ent/equipe"
+	"police-trafic-api-frontend-aligned/ent/infraction"
+	"police-trafic-api-frontend-aligned/ent/inspection"
+	"police-trafic-api-frontend-aligned/ent/mission"
+	"police-trafic-api-frontend-aligned/ent/objetperdu"
+	"police-trafic-api-frontend-aligned/ent/objetretrouve"
+	"police-trafic-api-frontend-aligned/ent/paiement"
+	"police-trafic-api-frontend-aligned/ent/plainte"
+	"police-trafic-api-frontend-aligned/ent/procesverbal"
+	"police-trafic-api-frontend-aligned/ent/recours"
+	"police-trafic-api-frontend-aligned/ent/vehicule"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ErrOutOfScope is returned when a write touches another commissariat's data
+var ErrOutOfScope = errors.New("resource belongs to another commissariat")
+
+// Hook rejects the writes on scoped entities outside the caller's commissariats:
+// updates and deletes of rows the caller cannot read, and links to a commissariat
+// or a parent record (control, PV...) out of scope. Rejections are logged.
+func Hook(logger *zap.Logger) ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			scope := FromContext(ctx)
+			if !scope.restricted() {
+				return next.Mutate(ctx, m)
+			}
+
+			g := &guard{scope: scope, p: newPredicates(scope), logger: logger}
+			if err := g.check(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+}
+
+// lookupFunc returns a query on one row, scoped by the interceptor
+type lookupFunc func(id uuid.UUID) existQuery
+
+// target describes how a mutation of a scoped entity is checked
+type target struct {
+	lookup   lookupFunc
+	restrict func() // Adds the scope filter to a bulk update or delete
+	refs     []ref
+}
+
+// ref is a row the mutation links to. A nil lookup means a commissariat,
+// checked against the scope directly.
+type ref struct {
+	entity string
+	id     uuid.UUID
+	lookup lookupFunc
+}
+
+func (t *target) commissariat(id uuid.UUID, ok bool) {
+	if ok {
+		t.refs = append(t.refs, ref{entity: "Commissariat", id: id})
+	}
+}
+
+func (t *target) link(entity string, id uuid.UUID, lookup lookupFunc) {
+	t.refs = append(t.refs, ref{entity: entity, id: id, lookup: lookup})
+}
+
+type guard struct {
+	scope  *Scope
+	p      *predicates
+	logger *zap.Logger
+}
+
+func (g *guard) check(ctx context.Context, m ent.Mutation) error {
+	t := g.target(m)
+	if t == nil {
+		return nil
+	}
+
+	op := m.Op()
+	switch {
+	case op.Is(ent.OpUpdateOne | ent.OpDeleteOne):
+		if im, ok := m.(interface{ ID() (uuid.UUID, bool) }); ok {
+			if id, exists := im.ID(); exists {
+				if err := g.require(ctx, op, m.Type(), id, t.lookup); err != nil {
+					return err
+				}
+			}
+		}
+	case op.Is(ent.OpUpdate | ent.OpDelete):
+		t.restrict()
+	}
+
+	if op.Is(ent.OpDelete | ent.OpDeleteOne) {
+		return nil
+	}
+	for _, r := range t.refs {
+		if err := g.require(ctx, op, r.entity, r.id, r.lookup); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// require fails with ErrOutOfScope when the row is not visible to the caller
+func (g *guard) require(ctx context.Context, op ent.Op, entity string, id uuid.UUID, lookup lookupFunc) error {
+	visible := g.scope.Allows(id.String())
+	if lookup != nil {
+		var err error
+		visible, err = lookup(id).Exist(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to check commissariat scope: %w", err)
+		}
+	}
+	if visible {
+		return nil
+	}
+
+	g.logger.Warn("Cross-commissariat write denied",
+		zap.String("user_id", g.scope.UserID),
+		zap.String("role", g.scope.Role),
+		zap.String("op", op.String()),
+		zap.String("entity", entity),
+		zap.String("id", id.String()),
+		zap.Strings("commissariat_ids", g.scope.CommissariatIDs),
+	)
+	return ErrOutOfScope
+}
+
+// target returns the checks of the mutation, nil for entities that are not scoped
+func (g *guard) target(m ent.Mutation) *target {
+	switch m := m.(type) {
+	case *ent.ControleMutation:
+		t := &target{lookup: controleLookup(m.Client()), restrict: func() { m.Where(g.p.controle()) }}
+		t.commissariat(m.CommissariatID())
+		return t
+	case *ent.InspectionMutation:
+		t := &target{lookup: inspectionLookup(m.Client()), restrict: func() { m.Where(g.p.inspection()) }}
+		t.commissariat(m.CommissariatID())
+		return t
+	case *ent.PlainteMutation:
+		t := &target{lookup: plainteLookup(m.Client()), restrict: func() { m.Where(g.p.plainte()) }}
+		t.commissariat(m.CommissariatID())
+		return t
+	case *ent.ConvocationMutation:
+		t := &target{lookup: convocationLookup(m.Client()), restrict: func() { m.Where(g.p.convocation()) }}
+		t.commissariat(m.CommissariatID())
+		return t
+	case *ent.AlerteSecuritaireMutation:
+		t := &target{lookup: alerteLookup(m.Client()), restrict: func() { m.Where(g.p.alerte()) }}
+		t.commissariat(m.CommissariatID())
+		return t
+	case *ent.ObjetPerduMutation:
+		t := &target{lookup: objetPerduLookup(m.Client()), restrict: func() { m.Where(g.p.objetPerdu()) }}
+		t.commissariat(m.CommissariatID())
+		return t
+	case *ent.ObjetRetrouveMutation:
+		t := &target{lookup: objetRetrouveLookup(m.Client()), restrict: func() { m.Where(g.p.objetRetrouve()) }}
+		t.commissariat(m.CommissariatID())
+		return t
+	case *ent.EquipeMutation:
+		t := &target{lookup: equipeLookup(m.Client()), restrict: func() { m.Where(g.p.equipe()) }}
+		t.commissariat(m.CommissariatID())
+		return t
+	case *ent.MissionMutation:
+		t := &target{lookup: missionLookup(m.Client()), restrict: func() { m.Where(g.p.mission()) }}
+		t.commissariat(m.CommissariatID())
+		return t
+
+	case *ent.ProcesVerbalMutation:
+		c := m.Client()
+		t := &target{lookup: procesVerbalLookup(c), restrict: func() { m.Where(g.p.procesVerbal()) }}
+		if id, ok := m.ControleID(); ok {
+			t.link("Controle", id, controleLookup(c))
+		}
+		if id, ok := m.InspectionID(); ok {
+			t.link("Inspection", id, inspectionLookup(c))
+		}
+		for _, id := range m.InfractionsIDs() {
+			t.link("Infraction", id, infractionLookup(c))
+		}
+		return t
+	case *ent.InfractionMutation:
+		c := m.Client()
+		t := &target{lookup: infractionLookup(c), restrict: func() { m.Where(g.p.infraction()) }}
+		if id, ok := m.ControleID(); ok {
+			t.link("Controle", id, controleLookup(c))
+		}
+		return t
+	case *ent.PaiementMutation:
+		c := m.Client()
+		t := &target{lookup: paiementLookup(c), restrict: func() { m.Where(g.p.paiement()) }}
+		if id, ok := m.ProcesVerbalID(); ok {
+			t.link("ProcesVerbal", id, procesVerbalLookup(c))
+		}
+		return t
+	case *ent.RecoursMutation:
+		c := m.Client()
+		t := &target{lookup: recoursLookup(c), restrict: func() { m.Where(g.p.recours()) }}
+		if id, ok := m.ProcesVerbalID(); ok {
+			t.link("ProcesVerbal", id, procesVerbalLookup(c))
+		}
+		return t
+	case *ent.DocumentMutation:
+		c := m.Client()
+		t := &target{lookup: documentLookup(c), restrict: func() { m.Where(g.p.document()) }}
+		if id, ok := m.ControleID(); ok {
+			t.link("Controle", id, controleLookup(c))
+		}
+		if id, ok := m.InfractionID(); ok {
+			t.link("Infraction", id, infractionLookup(c))
+		}
+		if id, ok := m.ProcesVerbalID(); ok {
+			t.link("ProcesVerbal", id, procesVerbalLookup(c))
+		}
+		if id, ok := m.RecoursID(); ok {
+			t.link("Recours", id, recoursLookup(c))
+		}
+		return t
+	case *ent.VehiculeMutation:
+		return &target{lookup: vehiculeLookup(m.Client()), restrict: func() { m.Where(g.p.vehicule()) }}
+	case *ent.ConducteurMutation:
+		return &target{lookup: conducteurLookup(m.Client()), restrict: func() { m.Where(g.p.conducteur()) }}
+	}
+
+	return nil
+}
+
+func controleLookup(c *ent.Client) lookupFunc {
+	return func(id uuid.UUID) existQuery { return c.Controle.Query().Where(controle.ID(id)) }
+}
+
+func inspectionLookup(c *ent.Client) lookupFunc {
+	return func(id uuid.UUID) existQuery { return c.Inspection.Query().Where(inspection.ID(id)) }
+}
+
+func plainteLookup(c *ent.Client) lookupFunc {
+	return func(id uuid.UUID) existQuery { return c.Plainte.Query().Where(plainte.ID(id)) }
+}
+
+func convocationLookup(c *ent.Client) lookupFunc {
+	return func(id uuid.UUID) existQuery { return c.Convocation.Query().Where(convocation.ID(id)) }
+}
+
+func alerteLookup(c *ent.Client) lookupFunc {
+	return func(id uuid.UUID) existQuery { return c.AlerteSecuritaire.Query().Where(alertesecuritaire.ID(id)) }
+}
+
+func objetPerduLookup(c *ent.Client) lookupFunc {
+	return func(id uuid.UUID) existQuery { return c.ObjetPerdu.Query().Where(objetperdu.ID(id)) }
+}
+
+func objetRetrouveLookup(c *ent.Client) lookupFunc {
+	return func(id uuid.UUID) existQuery { return c.ObjetRetrouve.Query().Where(objetretrouve.ID(id)) }
+}
+
+func equipeLookup(c *ent.Client) lookupFunc {
+	return func(id uuid.UUID) existQuery { return c.Equipe.Query().Where(equipe.ID(id)) }
+}
+
+func missionLookup(c *ent.Client) lookupFunc {
+	return func(id uuid.UUID) existQuery { return c.Mission.Query().Where(mission.ID(id)) }
+}
+
+func procesVerbalLookup(c *ent.Client) lookupFunc {
+	return func(id uuid.UUID) existQuery { return c.ProcesVerbal.Query().Where(procesverbal.ID(id)) }
+}
+
+func infractionLookup(c *ent.Client) lookupFunc {
+	return func(id uuid.UUID) existQuery { return c.Infraction.Query().Where(infraction.ID(id)) }
+}
+
+func paiementLookup(c *ent.Client) lookupFunc {
+	return func(id uuid.UUID) existQuery { return c.Paiement.Query().Where(paiement.ID(id)) }
+}
+
+func recoursLookup(c *ent.Client) lookupFunc {
+	return func(id uuid.UUID) existQuery { return c.Recours.Query().Where(recours.ID(id)) }
+}
+
+func documentLookup(c *ent.Client) lookupFunc {
+	return func(id uuid.UUID) existQuery { return c.Document.Query().Where(document.ID(id)) }
+}
+
+func vehiculeLookup(c *ent.Client) lookupFunc {
+	return func(id uuid.UUID) existQuery { return c.Vehicule.Query().Where(vehicule.ID(id)) }
+}
+
+func conducteurLookup(c *ent.Client) lookupFunc {
+	return func(id uuid.UUID) existQuery { return c.Conducteur.Query().Where(conducteur.ID(id)) }
+}
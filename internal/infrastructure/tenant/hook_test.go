@@ -0,0 +1,90 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestHook_DeniesCrossCommissariatWrites(t *testing.T) {
+	client := newTestClient(t, zap.NewNop())
+	abidjan := createCommissariat(t, client, "ABJ", "Lagunes")
+	bouake := createCommissariat(t, client, "BKE", "Gbeke")
+	propre := createPlainte(t, client, "PLT-ABJ-1", abidjan.ID)
+	autre := createPlainte(t, client, "PLT-BKE-1", bouake.ID)
+
+	ctx := scoped("agent", abidjan)
+
+	_, err := client.Plainte.UpdateOneID(autre.ID).SetDescription("modifiée").Save(ctx)
+	assert.ErrorIs(t, err, ErrOutOfScope)
+
+	err = client.Plainte.DeleteOneID(autre.ID).Exec(ctx)
+	assert.ErrorIs(t, err, ErrOutOfScope)
+
+	// Rattacher une plainte, nouvelle ou existante, à un autre commissariat
+	_, err = client.Plainte.Create().
+		SetNumero("PLT-BKE-2").
+		SetTypePlainte("VOL").
+		SetPlaignantNom("Kouassi").
+		SetPlaignantPrenom("Awa").
+		SetCommissariatID(bouake.ID).
+		Save(ctx)
+	assert.ErrorIs(t, err, ErrOutOfScope)
+
+	_, err = client.Plainte.UpdateOneID(propre.ID).SetCommissariatID(bouake.ID).Save(ctx)
+	assert.ErrorIs(t, err, ErrOutOfScope)
+
+	updated, err := client.Plainte.UpdateOneID(propre.ID).SetDescription("complétée").Save(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "complétée", updated.Description)
+
+	unchanged, err := client.Plainte.Get(context.Background(), autre.ID)
+	require.NoError(t, err)
+	assert.Empty(t, unchanged.Description)
+}
+
+func TestHook_RestrictsBulkWrites(t *testing.T) {
+	client := newTestClient(t, zap.NewNop())
+	plateau := createCommissariat(t, client, "PLT", "Lagunes")
+	cocody := createCommissariat(t, client, "CCD", "Lagunes")
+	bouake := createCommissariat(t, client, "BKE", "Gbeke")
+	createPlainte(t, client, "PLT-PLT-1", plateau.ID)
+	createPlainte(t, client, "PLT-CCD-1", cocody.ID)
+	autre := createPlainte(t, client, "PLT-BKE-1", bouake.ID)
+
+	ctx := scoped("supervisor", plateau, cocody)
+
+	n, err := client.Plainte.Update().SetObservations("revue régionale").Save(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	unchanged, err := client.Plainte.Get(context.Background(), autre.ID)
+	require.NoError(t, err)
+	assert.Empty(t, unchanged.Observations)
+
+	n, err = client.Plainte.Delete().Exec(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+
+	count, err := client.Plainte.Query().Count(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+}
+
+func TestHook_AdminBypass(t *testing.T) {
+	client := newTestClient(t, zap.NewNop())
+	abidjan := createCommissariat(t, client, "ABJ", "Lagunes")
+	bouake := createCommissariat(t, client, "BKE", "Gbeke")
+	plainte := createPlainte(t, client, "PLT-ABJ-1", abidjan.ID)
+
+	ctx := NewContext(context.Background(), &Scope{UserID: "admin", Role: "admin", National: true})
+
+	// Transfert d'une plainte vers un autre commissariat
+	_, err := client.Plainte.UpdateOneID(plainte.ID).SetCommissariatID(bouake.ID).Save(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, client.Plainte.DeleteOneID(plainte.ID).Exec(ctx))
+}
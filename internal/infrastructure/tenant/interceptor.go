@@ -0,0 +1,129 @@
+package tenant
+
+import (
+	"context"
+	"reflect"
+
+	"police-trafic-api-frontend-aligned/ent"
+
+	entgo "entgo.io/ent"
+	"go.uber.org/zap"
+)
+
+// existQuery is implemented by every generated query
+type existQuery interface {
+	Exist(ctx context.Context) (bool, error)
+}
+
+// Interceptor restricts the queries on scoped entities to the rows of the
+// caller's commissariats. A single-row lookup that only misses because of the
+// scope is logged as a cross-commissariat access attempt.
+func Interceptor(logger *zap.Logger) ent.Interceptor {
+	return ent.InterceptFunc(func(next ent.Querier) ent.Querier {
+		return ent.QuerierFunc(func(ctx context.Context, q ent.Query) (ent.Value, error) {
+			scope := FromContext(ctx)
+			if !scope.restricted() {
+				return next.Query(ctx, q)
+			}
+
+			qc := entgo.QueryFromContext(ctx)
+			unscoped := restrict(q, newPredicates(scope), isSingleLookup(qc))
+
+			v, err := next.Query(ctx, q)
+			if err == nil && unscoped != nil && isEmpty(v) {
+				if exists, existErr := unscoped.Exist(withoutScope(ctx)); existErr == nil && exists {
+					logger.Warn("Cross-commissariat read denied",
+						zap.String("user_id", scope.UserID),
+						zap.String("role", scope.Role),
+						zap.String("entity", qc.Type),
+						zap.Strings("commissariat_ids", scope.CommissariatIDs),
+					)
+				}
+			}
+			return v, err
+		})
+	})
+}
+
+// restrict adds the scope filter to q. For single-row lookups it also returns
+// a copy of the query taken before filtering.
+func restrict(q ent.Query, p *predicates, single bool) existQuery {
+	var before existQuery
+	switch q := q.(type) {
+	case *ent.ControleQuery:
+		before = cloneIf(single, q.Clone)
+		q.Where(p.controle())
+	case *ent.InspectionQuery:
+		before = cloneIf(single, q.Clone)
+		q.Where(p.inspection())
+	case *ent.ProcesVerbalQuery:
+		before = cloneIf(single, q.Clone)
+		q.Where(p.procesVerbal())
+	case *ent.InfractionQuery:
+		before = cloneIf(single, q.Clone)
+		q.Where(p.infraction())
+	case *ent.PaiementQuery:
+		before = cloneIf(single, q.Clone)
+		q.Where(p.paiement())
+	case *ent.RecoursQuery:
+		before = cloneIf(single, q.Clone)
+		q.Where(p.recours())
+	case *ent.DocumentQuery:
+		before = cloneIf(single, q.Clone)
+		q.Where(p.document())
+	case *ent.VehiculeQuery:
+		before = cloneIf(single, q.Clone)
+		q.Where(p.vehicule())
+	case *ent.ConducteurQuery:
+		before = cloneIf(single, q.Clone)
+		q.Where(p.conducteur())
+	case *ent.AlerteSecuritaireQuery:
+		before = cloneIf(single, q.Clone)
+		q.Where(p.alerte())
+	case *ent.PlainteQuery:
+		before = cloneIf(single, q.Clone)
+		q.Where(p.plainte())
+	case *ent.ConvocationQuery:
+		before = cloneIf(single, q.Clone)
+		q.Where(p.convocation())
+	case *ent.ObjetPerduQuery:
+		before = cloneIf(single, q.Clone)
+		q.Where(p.objetPerdu())
+	case *ent.ObjetRetrouveQuery:
+		before = cloneIf(single, q.Clone)
+		q.Where(p.objetRetrouve())
+	case *ent.EquipeQuery:
+		before = cloneIf(single, q.Clone)
+		q.Where(p.equipe())
+	case *ent.MissionQuery:
+		before = cloneIf(single, q.Clone)
+		q.Where(p.mission())
+	}
+
+	return before
+}
+
+func cloneIf[Q existQuery](single bool, clone func() Q) existQuery {
+	if !single {
+		return nil
+	}
+	return clone()
+}
+
+// isSingleLookup reports whether the query fetches one row (GetByID and the like)
+func isSingleLookup(qc *entgo.QueryContext) bool {
+	if qc == nil {
+		return false
+	}
+	switch qc.Op {
+	case entgo.OpQueryOnly, entgo.OpQueryOnlyID, entgo.OpQueryFirst, entgo.OpQueryFirstID:
+		return true
+	}
+	return false
+}
+
+// isEmpty reports whether a query result is an empty list
+func isEmpty(v ent.Value) bool {
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Slice && rv.Len() == 0
+}
@@ -0,0 +1,143 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/enttest"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newTestClient opens an in-memory database with the scoping interceptor and hook installed
+func newTestClient(t *testing.T, logger *zap.Logger) *ent.Client {
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	t.Cleanup(func() { client.Close() })
+
+	client.Intercept(Interceptor(logger))
+	client.Use(Hook(logger))
+	return client
+}
+
+func createCommissariat(t *testing.T, client *ent.Client, code, region string) *ent.Commissariat {
+	c, err := client.Commissariat.Create().
+		SetID(uuid.New()).
+		SetNom("Commissariat " + code).
+		SetCode(code).
+		SetAdresse("Boulevard principal").
+		SetVille(code).
+		SetRegion(region).
+		SetTelephone("0102030405").
+		Save(context.Background())
+	require.NoError(t, err)
+	return c
+}
+
+func createPlainte(t *testing.T, client *ent.Client, numero string, commissariatID uuid.UUID) *ent.Plainte {
+	p, err := client.Plainte.Create().
+		SetID(uuid.New()).
+		SetNumero(numero).
+		SetTypePlainte("VOL").
+		SetPlaignantNom("Kouassi").
+		SetPlaignantPrenom("Awa").
+		SetDateDepot(time.Now()).
+		SetCommissariatID(commissariatID).
+		Save(context.Background())
+	require.NoError(t, err)
+	return p
+}
+
+func scoped(role string, commissariats ...*ent.Commissariat) context.Context {
+	scope := &Scope{UserID: "u1", Role: role}
+	for _, c := range commissariats {
+		scope.CommissariatIDs = append(scope.CommissariatIDs, c.ID.String())
+	}
+	return NewContext(context.Background(), scope)
+}
+
+func numeros(t *testing.T, ctx context.Context, client *ent.Client) []string {
+	plaintes, err := client.Plainte.Query().All(ctx)
+	require.NoError(t, err)
+	result := make([]string, len(plaintes))
+	for i, p := range plaintes {
+		result[i] = p.Numero
+	}
+	return result
+}
+
+func TestInterceptor_RestrictsReadsToScope(t *testing.T) {
+	client := newTestClient(t, zap.NewNop())
+	abidjan := createCommissariat(t, client, "ABJ", "Lagunes")
+	bouake := createCommissariat(t, client, "BKE", "Gbeke")
+	createPlainte(t, client, "PLT-ABJ-1", abidjan.ID)
+	autre := createPlainte(t, client, "PLT-BKE-1", bouake.ID)
+
+	ctx := scoped("agent", abidjan)
+	assert.ElementsMatch(t, []string{"PLT-ABJ-1"}, numeros(t, ctx, client))
+
+	count, err := client.Plainte.Query().Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	// Une plainte d'un autre commissariat se comporte comme une plainte inexistante
+	_, err = client.Plainte.Get(ctx, autre.ID)
+	assert.True(t, ent.IsNotFound(err))
+}
+
+func TestInterceptor_SupervisorRegionScope(t *testing.T) {
+	client := newTestClient(t, zap.NewNop())
+	plateau := createCommissariat(t, client, "PLT", "Lagunes")
+	cocody := createCommissariat(t, client, "CCD", "Lagunes")
+	bouake := createCommissariat(t, client, "BKE", "Gbeke")
+	createPlainte(t, client, "PLT-PLT-1", plateau.ID)
+	createPlainte(t, client, "PLT-CCD-1", cocody.ID)
+	createPlainte(t, client, "PLT-BKE-1", bouake.ID)
+
+	ctx := scoped("supervisor", plateau, cocody)
+	assert.ElementsMatch(t, []string{"PLT-PLT-1", "PLT-CCD-1"}, numeros(t, ctx, client))
+}
+
+func TestInterceptor_UnrestrictedScopes(t *testing.T) {
+	client := newTestClient(t, zap.NewNop())
+	abidjan := createCommissariat(t, client, "ABJ", "Lagunes")
+	bouake := createCommissariat(t, client, "BKE", "Gbeke")
+	createPlainte(t, client, "PLT-ABJ-1", abidjan.ID)
+	createPlainte(t, client, "PLT-BKE-1", bouake.ID)
+	all := []string{"PLT-ABJ-1", "PLT-BKE-1"}
+
+	admin := NewContext(context.Background(), &Scope{UserID: "admin", Role: "admin", National: true})
+	assert.ElementsMatch(t, all, numeros(t, admin, client))
+
+	// Les jobs et le démarrage s'exécutent sans scope
+	assert.ElementsMatch(t, all, numeros(t, context.Background(), client))
+
+	// Sans commissariat, aucune donnée rattachée n'est visible
+	assert.Empty(t, numeros(t, scoped("agent"), client))
+}
+
+func TestInterceptor_LogsCrossCommissariatLookup(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	client := newTestClient(t, zap.New(core))
+	abidjan := createCommissariat(t, client, "ABJ", "Lagunes")
+	bouake := createCommissariat(t, client, "BKE", "Gbeke")
+	autre := createPlainte(t, client, "PLT-BKE-1", bouake.ID)
+
+	ctx := scoped("agent", abidjan)
+	_, err := client.Plainte.Get(ctx, uuid.New())
+	assert.True(t, ent.IsNotFound(err))
+	assert.Zero(t, logs.Len(), "a missing row is not an access attempt")
+
+	_, err = client.Plainte.Get(ctx, autre.ID)
+	assert.True(t, ent.IsNotFound(err))
+	require.Equal(t, 1, logs.FilterMessage("Cross-commissariat read denied").Len())
+	assert.Equal(t, "Plainte", logs.All()[0].ContextMap()["entity"])
+}
@@ -0,0 +1,18 @@
+package tenant
+
+import (
+	"police-trafic-api-frontend-aligned/ent"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module provides the scope resolver and installs the scoping interceptor and
+// hook on the ent client
+var Module = fx.Module("tenant",
+	fx.Provide(NewResolver),
+	fx.Invoke(func(client *ent.Client, logger *zap.Logger) {
+		client.Intercept(Interceptor(logger))
+		client.Use(Hook(logger))
+	}),
+)
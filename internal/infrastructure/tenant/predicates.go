@@ -0,0 +1,155 @@
+package tenant
+
+import (
+	"police-trafic-api-frontend-aligned/ent/alertesecuritaire"
+	"police-trafic-api-frontend-aligned/ent/commissariat"
+	"police-trafic-api-frontend-aligned/ent/conducteur"
+	"police-trafic-api-frontend-aligned/ent/controle"
+	"police-trafic-api-frontend-aligned/ent/convocation"
+	"police-trafic-api-frontend-aligned/ent/document"
+	"police-trafic-api-frontend-aligned/ent/equipe"
+	"police-trafic-api-frontend-aligned/ent/infraction"
+	"police-trafic-api-frontend-aligned/ent/inspection"
+	"police-trafic-api-frontend-aligned/ent/mission"
+	"police-trafic-api-frontend-aligned/ent/objetperdu"
+	"police-trafic-api-frontend-aligned/ent/objetretrouve"
+	"police-trafic-api-frontend-aligned/ent/paiement"
+	"police-trafic-api-frontend-aligned/ent/plainte"
+	"police-trafic-api-frontend-aligned/ent/predicate"
+	"police-trafic-api-frontend-aligned/ent/procesverbal"
+	"police-trafic-api-frontend-aligned/ent/recours"
+	"police-trafic-api-frontend-aligned/ent/user"
+	"police-trafic-api-frontend-aligned/ent/vehicule"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqljson"
+	"github.com/google/uuid"
+)
+
+// predicates builds, for each scoped entity, the filter that keeps the rows
+// linked to one of the scope's commissariats
+type predicates struct {
+	scope *Scope
+	ids   []uuid.UUID
+}
+
+func newPredicates(scope *Scope) *predicates {
+	return &predicates{scope: scope, ids: scope.uuids()}
+}
+
+func (p *predicates) commissariat() predicate.Commissariat {
+	return commissariat.IDIn(p.ids...)
+}
+
+func (p *predicates) controle() predicate.Controle {
+	return controle.HasCommissariatWith(p.commissariat())
+}
+
+func (p *predicates) inspection() predicate.Inspection {
+	return inspection.HasCommissariatWith(p.commissariat())
+}
+
+func (p *predicates) plainte() predicate.Plainte {
+	return plainte.HasCommissariatWith(p.commissariat())
+}
+
+func (p *predicates) convocation() predicate.Convocation {
+	return convocation.HasCommissariatWith(p.commissariat())
+}
+
+func (p *predicates) equipe() predicate.Equipe {
+	return equipe.HasCommissariatWith(p.commissariat())
+}
+
+func (p *predicates) mission() predicate.Mission {
+	return mission.HasCommissariatWith(p.commissariat())
+}
+
+func (p *predicates) objetPerdu() predicate.ObjetPerdu {
+	return objetperdu.HasCommissariatWith(p.commissariat())
+}
+
+func (p *predicates) objetRetrouve() predicate.ObjetRetrouve {
+	return objetretrouve.HasCommissariatWith(p.commissariat())
+}
+
+// alerte also keeps the alerts broadcast to everyone or assigned to one of
+// the scope's commissariats: alerts are shared between commissariats by design
+func (p *predicates) alerte() predicate.AlerteSecuritaire {
+	preds := []predicate.AlerteSecuritaire{
+		alertesecuritaire.HasCommissariatWith(p.commissariat()),
+		alertesecuritaire.Diffusee(true),
+	}
+	for _, id := range p.scope.CommissariatIDs {
+		id := id
+		preds = append(preds, func(s *sql.Selector) {
+			s.Where(sqljson.HasKey(s.C(alertesecuritaire.FieldAssignationDestinataires), sqljson.Path(id)))
+		})
+	}
+	return alertesecuritaire.Or(preds...)
+}
+
+// procesVerbalDirect covers PVs issued from a control or an inspection
+func (p *predicates) procesVerbalDirect() predicate.ProcesVerbal {
+	return procesverbal.Or(
+		procesverbal.HasControleWith(p.controle()),
+		procesverbal.HasInspectionWith(p.inspection()),
+	)
+}
+
+func (p *predicates) procesVerbal() predicate.ProcesVerbal {
+	return procesverbal.Or(
+		p.procesVerbalDirect(),
+		procesverbal.HasInfractionsWith(infraction.HasControleWith(p.controle())),
+	)
+}
+
+func (p *predicates) infraction() predicate.Infraction {
+	return infraction.Or(
+		infraction.HasControleWith(p.controle()),
+		infraction.HasProcesVerbalWith(p.procesVerbalDirect()),
+	)
+}
+
+func (p *predicates) paiement() predicate.Paiement {
+	return paiement.HasProcesVerbalWith(p.procesVerbal())
+}
+
+func (p *predicates) recours() predicate.Recours {
+	return recours.HasProcesVerbalWith(p.procesVerbal())
+}
+
+func (p *predicates) document() predicate.Document {
+	return document.Or(
+		document.HasControleWith(p.controle()),
+		document.HasInfractionWith(p.infraction()),
+		document.HasProcesVerbalWith(p.procesVerbal()),
+		document.HasRecoursWith(p.recours()),
+		document.HasUploadedByWith(user.HasCommissariatWith(p.commissariat())),
+	)
+}
+
+// vehicule keeps the vehicles seen by the scope's commissariats and the ones
+// not seen by anyone yet, so that a new registration stays reachable
+func (p *predicates) vehicule() predicate.Vehicule {
+	return vehicule.Or(
+		vehicule.HasControlesWith(p.controle()),
+		vehicule.HasInspectionsWith(p.inspection()),
+		vehicule.And(
+			vehicule.Not(vehicule.HasControles()),
+			vehicule.Not(vehicule.HasInspections()),
+		),
+	)
+}
+
+// conducteur follows the same rule as vehicule
+func (p *predicates) conducteur() predicate.Conducteur {
+	return conducteur.Or(
+		conducteur.HasControlesWith(p.controle()),
+		conducteur.HasInfractionsWith(p.infraction()),
+		conducteur.And(
+			conducteur.Not(conducteur.HasControles()),
+			conducteur.Not(conducteur.HasInfractions()),
+		),
+	)
+}
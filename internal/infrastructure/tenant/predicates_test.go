@@ -0,0 +1,90 @@
+package tenant
+
+import (
+	"testing"
+
+	"police-trafic-api-frontend-aligned/ent/alertesecuritaire"
+	"police-trafic-api-frontend-aligned/ent/conducteur"
+	"police-trafic-api-frontend-aligned/ent/controle"
+	"police-trafic-api-frontend-aligned/ent/convocation"
+	"police-trafic-api-frontend-aligned/ent/document"
+	"police-trafic-api-frontend-aligned/ent/equipe"
+	"police-trafic-api-frontend-aligned/ent/infraction"
+	"police-trafic-api-frontend-aligned/ent/inspection"
+	"police-trafic-api-frontend-aligned/ent/mission"
+	"police-trafic-api-frontend-aligned/ent/objetperdu"
+	"police-trafic-api-frontend-aligned/ent/objetretrouve"
+	"police-trafic-api-frontend-aligned/ent/paiement"
+	"police-trafic-api-frontend-aligned/ent/plainte"
+	"police-trafic-api-frontend-aligned/ent/procesverbal"
+	"police-trafic-api-frontend-aligned/ent/recours"
+	"police-trafic-api-frontend-aligned/ent/vehicule"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// render applies a predicate to a SELECT on the table and returns the SQL and its arguments
+func render(table string, pred func(*sql.Selector)) (string, []interface{}) {
+	s := sql.Dialect(dialect.Postgres).Select("*").From(sql.Table(table))
+	pred(s)
+	return s.Query()
+}
+
+func TestPredicates_FilterEveryEntityOnTheScope(t *testing.T) {
+	abidjan, cocody := uuid.New(), uuid.New()
+	p := newPredicates(&Scope{Role: "supervisor", CommissariatIDs: []string{abidjan.String(), cocody.String()}})
+
+	tests := []struct {
+		table string
+		pred  func(*sql.Selector)
+	}{
+		{controle.Table, p.controle()},
+		{inspection.Table, p.inspection()},
+		{plainte.Table, p.plainte()},
+		{convocation.Table, p.convocation()},
+		{equipe.Table, p.equipe()},
+		{mission.Table, p.mission()},
+		{objetperdu.Table, p.objetPerdu()},
+		{objetretrouve.Table, p.objetRetrouve()},
+		{alertesecuritaire.Table, p.alerte()},
+		{procesverbal.Table, p.procesVerbal()},
+		{infraction.Table, p.infraction()},
+		{paiement.Table, p.paiement()},
+		{recours.Table, p.recours()},
+		{document.Table, p.document()},
+		{vehicule.Table, p.vehicule()},
+		{conducteur.Table, p.conducteur()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.table, func(t *testing.T) {
+			query, args := render(tt.table, tt.pred)
+			assert.Contains(t, query, "WHERE")
+			// Chaque filtre finit par comparer aux commissariats du scope
+			assert.Contains(t, args, abidjan)
+			assert.Contains(t, args, cocody)
+		})
+	}
+}
+
+func TestPredicates_AlerteKeepsSharedAlerts(t *testing.T) {
+	abidjan := uuid.New()
+	p := newPredicates(&Scope{Role: "agent", CommissariatIDs: []string{abidjan.String()}})
+
+	query, args := render(alertesecuritaire.Table, p.alerte())
+	assert.Contains(t, args, abidjan)
+	// Les alertes diffusées ou assignées au commissariat restent visibles
+	assert.Contains(t, query, `"`+alertesecuritaire.FieldDiffusee+`"`)
+	assert.Contains(t, query, `"`+alertesecuritaire.FieldAssignationDestinataires+`"->'`+abidjan.String()+`'`)
+}
+
+func TestPredicates_NoCommissariatMatchesNothing(t *testing.T) {
+	p := newPredicates(&Scope{Role: "agent"})
+
+	query, _ := render(plainte.Table, p.plainte())
+	// Un IN vide est rendu comme une condition toujours fausse
+	assert.Contains(t, query, "FALSE")
+}
@@ -0,0 +1,79 @@
+package tenant
+
+import (
+	"context"
+	"fmt"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+
+	"go.uber.org/zap"
+)
+
+// Resolver computes the scope of an authenticated user
+type Resolver interface {
+	Resolve(ctx context.Context, userID, role, commissariatID string) (*Scope, error)
+}
+
+type resolver struct {
+	commissariatRepo repository.CommissariatRepository
+	logger           *zap.Logger
+}
+
+// NewResolver creates the scope resolver
+func NewResolver(commissariatRepo repository.CommissariatRepository, logger *zap.Logger) Resolver {
+	return &resolver{
+		commissariatRepo: commissariatRepo,
+		logger:           logger,
+	}
+}
+
+// Resolve gives admins national access, supervisors the commissariats of their
+// region and everyone else their own commissariat. Service accounts not
+// restricted to a commissariat have national access.
+func (r *resolver) Resolve(ctx context.Context, userID, role, commissariatID string) (*Scope, error) {
+	scope := &Scope{
+		UserID: userID,
+		Role:   role,
+	}
+
+	if rbac.Role(role) == rbac.RoleAdmin {
+		scope.National = true
+		return scope, nil
+	}
+
+	if rbac.Role(role) == rbac.RoleServiceAccount && commissariatID == "" {
+		scope.National = true
+		return scope, nil
+	}
+
+	if commissariatID == "" {
+		r.logger.Debug("User without commissariat, no scoped data visible", zap.String("user_id", userID))
+		return scope, nil
+	}
+	scope.CommissariatIDs = []string{commissariatID}
+
+	if rbac.Role(role) != rbac.RoleSupervisor {
+		return scope, nil
+	}
+
+	commissariat, err := r.commissariatRepo.GetByID(ctx, commissariatID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve scope: %w", err)
+	}
+	if commissariat.Region == "" {
+		return scope, nil
+	}
+
+	regionaux, err := r.commissariatRepo.GetByRegion(ctx, commissariat.Region)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve scope: %w", err)
+	}
+	for _, c := range regionaux {
+		if id := c.ID.String(); id != commissariatID {
+			scope.CommissariatIDs = append(scope.CommissariatIDs, id)
+		}
+	}
+
+	return scope, nil
+}
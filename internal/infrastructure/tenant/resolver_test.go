@@ -0,0 +1,74 @@
+package tenant
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeCommissariats serves the lookups of the resolver from memory
+type fakeCommissariats struct {
+	repository.CommissariatRepository
+	all []*ent.Commissariat
+}
+
+func (f *fakeCommissariats) GetByID(_ context.Context, id string) (*ent.Commissariat, error) {
+	for _, c := range f.all {
+		if c.ID.String() == id {
+			return c, nil
+		}
+	}
+	return nil, errors.New("commissariat not found")
+}
+
+func (f *fakeCommissariats) GetByRegion(_ context.Context, region string) ([]*ent.Commissariat, error) {
+	var result []*ent.Commissariat
+	for _, c := range f.all {
+		if c.Region == region {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	plateau := &ent.Commissariat{ID: uuid.New(), Region: "Lagunes"}
+	cocody := &ent.Commissariat{ID: uuid.New(), Region: "Lagunes"}
+	bouake := &ent.Commissariat{ID: uuid.New(), Region: "Gbeke"}
+	r := NewResolver(&fakeCommissariats{all: []*ent.Commissariat{plateau, cocody, bouake}}, zap.NewNop())
+	ctx := context.Background()
+
+	admin, err := r.Resolve(ctx, "u1", "admin", plateau.ID.String())
+	require.NoError(t, err)
+	assert.True(t, admin.National)
+
+	agent, err := r.Resolve(ctx, "u2", "agent", plateau.ID.String())
+	require.NoError(t, err)
+	assert.False(t, agent.National)
+	assert.Equal(t, []string{plateau.ID.String()}, agent.CommissariatIDs)
+
+	supervisor, err := r.Resolve(ctx, "u3", "supervisor", plateau.ID.String())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{plateau.ID.String(), cocody.ID.String()}, supervisor.CommissariatIDs)
+	assert.False(t, supervisor.Allows(bouake.ID.String()))
+
+	sansCommissariat, err := r.Resolve(ctx, "u4", "agent", "")
+	require.NoError(t, err)
+	assert.Empty(t, sansCommissariat.CommissariatIDs)
+	assert.True(t, sansCommissariat.restricted())
+
+	serviceAccount, err := r.Resolve(ctx, "sa1", "service_account", "")
+	require.NoError(t, err)
+	assert.True(t, serviceAccount.National)
+
+	_, err = r.Resolve(ctx, "u5", "supervisor", uuid.NewString())
+	assert.Error(t, err)
+}
@@ -0,0 +1,70 @@
+package tenant
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Scope is the set of commissariats whose data a caller may read and write
+type Scope struct {
+	UserID string
+	Role   string
+	// National gives access to every commissariat (admins)
+	National bool
+	// CommissariatIDs is the caller's commissariat, plus the other commissariats
+	// of the region for supervisors. Empty means no scoped data is visible.
+	CommissariatIDs []string
+}
+
+type scopeKey struct{}
+
+type unscopedKey struct{}
+
+// NewContext returns a context carrying the scope
+func NewContext(ctx context.Context, scope *Scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+// FromContext returns the scope of the context. It is nil for work that does not
+// run on behalf of a caller (jobs, startup), which is not restricted.
+func FromContext(ctx context.Context) *Scope {
+	if unscoped, _ := ctx.Value(unscopedKey{}).(bool); unscoped {
+		return nil
+	}
+	scope, _ := ctx.Value(scopeKey{}).(*Scope)
+	return scope
+}
+
+// withoutScope lifts the restriction, to tell a missing row from a hidden one
+func withoutScope(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unscopedKey{}, true)
+}
+
+// Allows reports whether the commissariat is within the scope
+func (s *Scope) Allows(commissariatID string) bool {
+	if s == nil || s.National {
+		return true
+	}
+	for _, id := range s.CommissariatIDs {
+		if id == commissariatID {
+			return true
+		}
+	}
+	return false
+}
+
+// restricted reports whether queries must be filtered
+func (s *Scope) restricted() bool {
+	return s != nil && !s.National
+}
+
+func (s *Scope) uuids() []uuid.UUID {
+	ids := make([]uuid.UUID, 0, len(s.CommissariatIDs))
+	for _, id := range s.CommissariatIDs {
+		if uid, err := uuid.Parse(id); err == nil {
+			ids = append(ids, uid)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,44 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScope_Allows(t *testing.T) {
+	var nilScope *Scope
+	assert.True(t, nilScope.Allows("c1"))
+
+	national := &Scope{Role: "admin", National: true}
+	assert.True(t, national.Allows("c1"))
+	assert.False(t, national.restricted())
+
+	regional := &Scope{Role: "supervisor", CommissariatIDs: []string{"c1", "c2"}}
+	assert.True(t, regional.Allows("c2"))
+	assert.False(t, regional.Allows("c3"))
+	assert.True(t, regional.restricted())
+
+	none := &Scope{Role: "agent"}
+	assert.False(t, none.Allows("c1"))
+	assert.True(t, none.restricted())
+}
+
+func TestScope_Context(t *testing.T) {
+	ctx := context.Background()
+	assert.Nil(t, FromContext(ctx))
+
+	scope := &Scope{UserID: "u1", CommissariatIDs: []string{"c1"}}
+	ctx = NewContext(ctx, scope)
+	assert.Same(t, scope, FromContext(ctx))
+
+	assert.Nil(t, FromContext(withoutScope(ctx)))
+}
+
+func TestScope_UUIDsSkipsInvalidIDs(t *testing.T) {
+	scope := &Scope{CommissariatIDs: []string{"6f1c2a4e-3b7d-4d8e-9a1f-2c3b4d5e6f70", "not-a-uuid"}}
+	ids := scope.uuids()
+	assert.Len(t, ids, 1)
+	assert.Equal(t, "6f1c2a4e-3b7d-4d8e-9a1f-2c3b4d5e6f70", ids[0].String())
+}
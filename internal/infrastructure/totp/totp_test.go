@@ -0,0 +1,76 @@
+package totp
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// Test vectors of RFC 6238 appendix B (SHA1, 8 digits)
+func TestGenerate_RFC6238(t *testing.T) {
+	key := []byte("12345678901234567890")
+
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "94287082"},
+		{unix: 1111111109, want: "07081804"},
+		{unix: 1111111111, want: "14050471"},
+		{unix: 1234567890, want: "89005924"},
+		{unix: 2000000000, want: "69279037"},
+		{unix: 20000000000, want: "65353130"},
+	}
+
+	for _, tt := range tests {
+		if got := generate(key, Step(time.Unix(tt.unix, 0)), 8); got != tt.want {
+			t.Errorf("generate(%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("GenerateSecret() error = %v", err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	code, err := Code(secret, now)
+	if err != nil {
+		t.Fatalf("Code() error = %v", err)
+	}
+
+	step, ok := Validate(secret, code, now)
+	if !ok || step != Step(now) {
+		t.Errorf("Validate() = %d, %v, want %d, true", step, ok, Step(now))
+	}
+
+	// Dérive d'horloge d'un pas tolérée
+	if _, ok := Validate(secret, code, now.Add(Period)); !ok {
+		t.Error("code of the previous step should be accepted")
+	}
+	if _, ok := Validate(secret, code, now.Add(3*Period)); ok {
+		t.Error("code older than the skew should be refused")
+	}
+
+	if _, ok := Validate(secret, "12345", now); ok {
+		t.Error("code with a wrong length should be refused")
+	}
+	if _, ok := Validate("not base32!", code, now); ok {
+		t.Error("invalid secret should be refused")
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("JBSWY3DPEHPK3PXP", "Police Trafic", "AG-001")
+
+	if !strings.HasPrefix(uri, "otpauth://totp/Police%20Trafic:AG-001?") {
+		t.Errorf("unexpected label in %s", uri)
+	}
+	for _, part := range []string{"secret=JBSWY3DPEHPK3PXP", "issuer=Police+Trafic", "digits=6", "period=30"} {
+		if !strings.Contains(uri, part) {
+			t.Errorf("%s missing from %s", part, uri)
+		}
+	}
+}
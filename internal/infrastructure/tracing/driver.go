@@ -0,0 +1,114 @@
+package tracing
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"entgo.io/ent/dialect"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Driver wraps an ent driver to trace each query in a span, child of the span
+// of the service method running it. The arguments are not recorded: they
+// hold personal data (names, plates, phones).
+func Driver(drv dialect.Driver) dialect.Driver {
+	return &driver{Driver: drv, system: dbSystem(drv.Dialect())}
+}
+
+type driver struct {
+	dialect.Driver
+	system string
+}
+
+func (d *driver) Exec(ctx context.Context, query string, args, v any) error {
+	ctx, span := startQuery(ctx, d.system, query)
+	err := d.Driver.Exec(ctx, query, args, v)
+	endQuery(span, err)
+	return err
+}
+
+func (d *driver) Query(ctx context.Context, query string, args, v any) error {
+	ctx, span := startQuery(ctx, d.system, query)
+	err := d.Driver.Query(ctx, query, args, v)
+	endQuery(span, err)
+	return err
+}
+
+func (d *driver) Tx(ctx context.Context) (dialect.Tx, error) {
+	tx, err := d.Driver.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &transaction{Tx: tx, system: d.system}, nil
+}
+
+// BeginTx is used by ent for the transactions with options
+func (d *driver) BeginTx(ctx context.Context, opts *sql.TxOptions) (dialect.Tx, error) {
+	drv, ok := d.Driver.(interface {
+		BeginTx(context.Context, *sql.TxOptions) (dialect.Tx, error)
+	})
+	if !ok {
+		return nil, fmt.Errorf("driver %T does not support BeginTx", d.Driver)
+	}
+	tx, err := drv.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &transaction{Tx: tx, system: d.system}, nil
+}
+
+type transaction struct {
+	dialect.Tx
+	system string
+}
+
+func (t *transaction) Exec(ctx context.Context, query string, args, v any) error {
+	ctx, span := startQuery(ctx, t.system, query)
+	err := t.Tx.Exec(ctx, query, args, v)
+	endQuery(span, err)
+	return err
+}
+
+func (t *transaction) Query(ctx context.Context, query string, args, v any) error {
+	ctx, span := startQuery(ctx, t.system, query)
+	err := t.Tx.Query(ctx, query, args, v)
+	endQuery(span, err)
+	return err
+}
+
+// startQuery starts the span of a query, named after its operation (SELECT,
+// INSERT...)
+func startQuery(ctx context.Context, system, query string) (context.Context, trace.Span) {
+	operation := system
+	if fields := strings.Fields(query); len(fields) > 0 {
+		operation = strings.ToUpper(fields[0])
+	}
+	return Start(ctx, operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(
+		attribute.String("db.system.name", system),
+		attribute.String("db.operation.name", operation),
+		attribute.String("db.query.text", query),
+	))
+}
+
+func endQuery(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// dbSystem returns the OpenTelemetry name of the database of an ent dialect
+func dbSystem(name string) string {
+	switch name {
+	case dialect.Postgres:
+		return "postgresql"
+	case dialect.SQLite:
+		return "sqlite"
+	}
+	return name
+}
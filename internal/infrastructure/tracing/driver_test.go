@@ -0,0 +1,54 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"entgo.io/ent/dialect"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// fakeDriver fails the queries on the "broken" table
+type fakeDriver struct {
+	dialect.Driver
+}
+
+func (fakeDriver) Dialect() string { return dialect.Postgres }
+
+func (fakeDriver) Query(_ context.Context, query string, _, _ any) error {
+	if query == `SELECT * FROM "broken"` {
+		return errors.New("relation does not exist")
+	}
+	return nil
+}
+
+func TestDriver(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx, parent := Start(context.Background(), "pv.GetByID")
+	drv := Driver(fakeDriver{})
+	require.NoError(t, drv.Query(ctx, `select "id" FROM "proces_verbals" WHERE "id" = $1`, []any{"pv-1"}, nil))
+	require.Error(t, drv.Query(ctx, `SELECT * FROM "broken"`, nil, nil))
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 3)
+
+	query := spans[0]
+	assert.Equal(t, "SELECT", query.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), query.Parent().SpanID())
+	assert.Contains(t, query.Attributes(), attribute.String("db.system.name", "postgresql"))
+	assert.Contains(t, query.Attributes(), attribute.String("db.query.text", `select "id" FROM "proces_verbals" WHERE "id" = $1`))
+	assert.Equal(t, codes.Unset, query.Status().Code)
+
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "pv.GetByID", spans[2].Name())
+}
@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.uber.org/zap"
+)
+
+// Middleware starts the span of each request, named after its route. The
+// paths with the given prefixes are not traced (health checks, scrapes and
+// long-lived streams).
+func Middleware(service string, skipPrefixes ...string) echo.MiddlewareFunc {
+	return otelecho.Middleware(service, otelecho.WithSkipper(func(c echo.Context) bool {
+		for _, prefix := range skipPrefixes {
+			if strings.HasPrefix(c.Request().URL.Path, prefix) {
+				return true
+			}
+		}
+		return false
+	}))
+}
+
+// RequestLogger logs each request through zap with the trace ID of its span,
+// in place of the Echo access log which cannot carry it
+func RequestLogger(logger *zap.Logger) echo.MiddlewareFunc {
+	return middleware.RequestLoggerWithConfig(middleware.RequestLoggerConfig{
+		LogMethod:    true,
+		LogURI:       true,
+		LogStatus:    true,
+		LogLatency:   true,
+		LogRemoteIP:  true,
+		LogRequestID: true,
+		LogError:     true,
+		LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
+			fields := []zap.Field{
+				zap.String("method", v.Method),
+				zap.String("uri", v.URI),
+				zap.Int("status", v.Status),
+				zap.Duration("latency", v.Latency),
+				zap.String("remote_ip", v.RemoteIP),
+				zap.String("request_id", v.RequestID),
+			}
+			log := Logger(c.Request().Context(), logger)
+			if v.Error != nil {
+				log.Warn("request", append(fields, zap.Error(v.Error))...)
+			} else {
+				log.Info("request", fields...)
+			}
+			return nil
+		},
+	})
+}
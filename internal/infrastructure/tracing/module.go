@@ -0,0 +1,15 @@
+package tracing
+
+import (
+	"go.uber.org/fx"
+)
+
+// Module provides the tracer provider and flushes the spans on shutdown
+var Module = fx.Module("tracing",
+	fx.Provide(NewProvider),
+	fx.Invoke(func(lc fx.Lifecycle, p *Provider) {
+		lc.Append(fx.Hook{
+			OnStop: p.Shutdown,
+		})
+	}),
+)
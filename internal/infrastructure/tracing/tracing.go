@@ -0,0 +1,44 @@
+// Package tracing instruments the application with OpenTelemetry: a span per
+// HTTP request (in the server), per service method, per ent query and per
+// outbound HTTP call, exported to an OTLP collector or printed on stdout.
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// instrumentation names the tracer of the spans started by the application
+const instrumentation = "police-trafic-api-frontend-aligned"
+
+// Start starts the span of a service method, named "module.Method":
+//
+//	ctx, span := tracing.Start(ctx, "alertes.GetDashboard")
+//	defer span.End()
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentation).Start(ctx, name, opts...)
+}
+
+// Logger adds the trace and span IDs of the context to the fields of a
+// logger, to find the trace of a logged request or error
+func Logger(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return logger
+	}
+	return logger.With(
+		zap.String("trace_id", sc.TraceID().String()),
+		zap.String("span_id", sc.SpanID().String()),
+	)
+}
+
+// Transport returns an HTTP transport tracing the outbound calls and
+// propagating the trace context to the called service
+func Transport() http.RoundTripper {
+	return otelhttp.NewTransport(http.DefaultTransport)
+}
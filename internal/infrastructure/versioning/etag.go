@@ -0,0 +1,32 @@
+package versioning
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ETag formats a version as a strong entity tag
+func ETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ParseIfMatch returns the versions listed in an If-Match header, or wildcard
+// when it is "*". Weak and foreign tags never match (strong comparison, RFC
+// 9110), so a header listing only those gives no version and no wildcard.
+func ParseIfMatch(header string) (versions []int, wildcard bool) {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil, true
+		}
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		version, err := strconv.Atoi(tag[1 : len(tag)-1])
+		if err != nil || version < 1 {
+			continue
+		}
+		versions = append(versions, version)
+	}
+	return versions, false
+}
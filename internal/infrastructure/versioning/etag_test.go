@@ -0,0 +1,30 @@
+package versioning
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		header   string
+		versions []int
+		wildcard bool
+	}{
+		{header: `"3"`, versions: []int{3}},
+		{header: ETag(12), versions: []int{12}},
+		{header: `"3", "4"`, versions: []int{3, 4}},
+		{header: `*`, wildcard: true},
+		// Comparaison forte : les étiquettes faibles ne correspondent jamais
+		{header: `W/"3"`},
+		{header: `"abc", "0", 3`},
+		{header: `W/"3", "5"`, versions: []int{5}},
+	}
+
+	for _, tt := range tests {
+		versions, wildcard := ParseIfMatch(tt.header)
+		assert.Equal(t, tt.versions, versions, tt.header)
+		assert.Equal(t, tt.wildcard, wildcard, tt.header)
+	}
+}
@@ -0,0 +1,130 @@
+package versioning
+
+import (
+	"context"
+	"slices"
+
+	"police-trafic-api-frontend-aligned/ent"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// fieldVersion is the version column of the versioned entities
+const fieldVersion = "version"
+
+// resourceTypes are the versioned entities and the resource type their
+// versions are recorded under, the collection of their route
+var resourceTypes = map[string]string{
+	ent.TypePlainte:           "plaintes",
+	ent.TypeAlerteSecuritaire: "alertes",
+	ent.TypeConvocation:       "convocations",
+}
+
+// Hook moves the versioned entities to their next version on every update,
+// from a request or a job. The version column is incremented by the UPDATE of
+// the write itself, so both are committed or lost together. The resource of
+// the request write is only modified or deleted from a version its If-Match
+// accepts: the check is a condition of the same statement.
+func Hook(logger *zap.Logger) ent.Hook {
+	return func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			resourceType, ok := resourceTypes[m.Type()]
+			if !ok || !m.Op().Is(ent.OpUpdate|ent.OpUpdateOne|ent.OpDelete|ent.OpDeleteOne) {
+				return next.Mutate(ctx, m)
+			}
+
+			if m.Op().Is(ent.OpUpdate | ent.OpUpdateOne) {
+				if err := m.AddField(fieldVersion, 1); err != nil {
+					return nil, err
+				}
+			}
+
+			// If-Match ne porte que sur les écritures d'une seule ressource :
+			// une mise à jour en masse ne saute pas silencieusement la sienne
+			w := writeFromContext(ctx)
+			id, single := mutationID(m)
+			checked := single && w.matches(resourceType, id.String()) && len(w.Expected) > 0
+			if checked {
+				expected := w.Expected
+				if v := w.Version(); v > 0 {
+					// Les mutations suivantes de la requête partent de sa propre version
+					expected = append(slices.Clone(expected), v)
+				}
+				if wm, ok := m.(interface{ WhereP(...func(*sql.Selector)) }); ok {
+					wm.WhereP(sql.FieldIn(fieldVersion, expected...))
+				}
+			}
+
+			// Sans ligne modifiée, UpdateOne renvoie NotFound et DeleteOne 0 :
+			// la ressource a changé de version, ou n'existe pas
+			value, err := next.Mutate(ctx, m)
+			if checked && (ent.IsNotFound(err) || err == nil && value == 0) {
+				if refuse(ctx, m, w, resourceType, id, logger) {
+					return nil, ErrPreconditionFailed
+				}
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			if single && w.matches(resourceType, id.String()) {
+				if version := versionOf(value); version > 0 {
+					w.reach(version)
+				}
+			}
+			return value, nil
+		})
+	}
+}
+
+// mutationID returns the resource of an UpdateOne or DeleteOne mutation
+func mutationID(m ent.Mutation) (uuid.UUID, bool) {
+	if !m.Op().Is(ent.OpUpdateOne | ent.OpDeleteOne) {
+		return uuid.Nil, false
+	}
+	im, ok := m.(interface{ ID() (uuid.UUID, bool) })
+	if !ok {
+		return uuid.Nil, false
+	}
+	return im.ID()
+}
+
+// refuse tells a write skipped by its If-Match condition from a missing
+// resource, and records the current version of the first
+func refuse(ctx context.Context, m ent.Mutation, w *Write, resourceType string, id uuid.UUID, logger *zap.Logger) bool {
+	cm, ok := m.(interface{ Client() *ent.Client })
+	if !ok {
+		return false
+	}
+
+	// Lecture sur le client de la mutation : dans sa transaction s'il y en a une
+	current, err := currentVersion(ctx, cm.Client(), resourceType, id)
+	if err != nil {
+		if !ent.IsNotFound(err) {
+			logger.Warn("Failed to get resource version",
+				zap.String("resource_type", resourceType),
+				zap.String("resource_id", id.String()),
+				zap.Error(err),
+			)
+		}
+		return false
+	}
+
+	w.refuse(current)
+	return true
+}
+
+// versionOf returns the version of an updated entity, 0 for another value
+func versionOf(value ent.Value) int {
+	switch e := value.(type) {
+	case *ent.Plainte:
+		return e.Version
+	case *ent.AlerteSecuritaire:
+		return e.Version
+	case *ent.Convocation:
+		return e.Version
+	}
+	return 0
+}
@@ -0,0 +1,155 @@
+package versioning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/ent/enttest"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// newTestClient opens an in-memory database with the versioning hook installed
+func newTestClient(t *testing.T) (*ent.Client, Service) {
+	client := enttest.Open(t, "sqlite3", fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	t.Cleanup(func() { client.Close() })
+
+	client.Use(Hook(zap.NewNop()))
+	return client, NewService(client, zap.NewNop())
+}
+
+func createPlainte(t *testing.T, client *ent.Client) *ent.Plainte {
+	commissariat, err := client.Commissariat.Create().
+		SetID(uuid.New()).
+		SetNom("Commissariat du Plateau").
+		SetCode("PLT").
+		SetAdresse("Boulevard principal").
+		SetVille("Abidjan").
+		SetRegion("Lagunes").
+		SetTelephone("0102030405").
+		Save(context.Background())
+	require.NoError(t, err)
+
+	p, err := client.Plainte.Create().
+		SetID(uuid.New()).
+		SetNumero("PLT-001").
+		SetTypePlainte("VOL").
+		SetPlaignantNom("Kouassi").
+		SetPlaignantPrenom("Awa").
+		SetDateDepot(time.Now()).
+		SetCommissariatID(commissariat.ID).
+		Save(context.Background())
+	require.NoError(t, err)
+	return p
+}
+
+func version(t *testing.T, service Service, p *ent.Plainte) int {
+	v, err := service.Version(context.Background(), "plaintes", p.ID.String())
+	require.NoError(t, err)
+	return v
+}
+
+func TestHook_VersionsEveryWrite(t *testing.T) {
+	client, service := newTestClient(t)
+	p := createPlainte(t, client)
+	assert.Equal(t, 1, version(t, service, p))
+
+	// Écritures des jobs, sans requête
+	require.NoError(t, client.Plainte.UpdateOneID(p.ID).SetObservations("relance SLA").Exec(context.Background()))
+	assert.Equal(t, 2, version(t, service, p))
+
+	n, err := client.Plainte.Update().SetObservations("revue").Save(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, 3, version(t, service, p))
+}
+
+func TestHook_ChecksIfMatch(t *testing.T) {
+	client, service := newTestClient(t)
+	p := createPlainte(t, client)
+
+	w := &Write{ResourceType: "plaintes", ResourceID: p.ID.String(), Expected: []int{1}}
+	ctx := NewContext(context.Background(), w)
+	require.NoError(t, client.Plainte.UpdateOneID(p.ID).SetDescription("première").Exec(ctx))
+	// Les mutations suivantes de la requête partent de sa propre version
+	require.NoError(t, client.Plainte.UpdateOneID(p.ID).SetObservations("complément").Exec(ctx))
+	assert.Equal(t, 3, w.Version())
+	assert.Equal(t, 3, version(t, service, p))
+
+	stale := &Write{ResourceType: "plaintes", ResourceID: p.ID.String(), Expected: []int{1}}
+	err := client.Plainte.UpdateOneID(p.ID).SetDescription("écrasée").Exec(NewContext(context.Background(), stale))
+	assert.ErrorIs(t, err, ErrPreconditionFailed)
+	current, refused := stale.Conflict()
+	assert.True(t, refused)
+	assert.Equal(t, 3, current)
+
+	unchanged, err := client.Plainte.Get(context.Background(), p.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "première", unchanged.Description)
+}
+
+func TestHook_ChecksIfMatchOnDelete(t *testing.T) {
+	client, _ := newTestClient(t)
+	p := createPlainte(t, client)
+	require.NoError(t, client.Plainte.UpdateOneID(p.ID).SetObservations("relance").Exec(context.Background()))
+
+	stale := &Write{ResourceType: "plaintes", ResourceID: p.ID.String(), Expected: []int{1}}
+	err := client.Plainte.DeleteOneID(p.ID).Exec(NewContext(context.Background(), stale))
+	assert.ErrorIs(t, err, ErrPreconditionFailed)
+	current, _ := stale.Conflict()
+	assert.Equal(t, 2, current)
+
+	w := &Write{ResourceType: "plaintes", ResourceID: p.ID.String(), Expected: []int{2}}
+	require.NoError(t, client.Plainte.DeleteOneID(p.ID).Exec(NewContext(context.Background(), w)))
+
+	// Une ressource absente reste introuvable, ce n'est pas un conflit
+	err = client.Plainte.DeleteOneID(p.ID).Exec(NewContext(context.Background(), w))
+	assert.True(t, ent.IsNotFound(err))
+	_, refused := w.Conflict()
+	assert.False(t, refused)
+}
+
+func TestHook_HandlerTransaction(t *testing.T) {
+	client, service := newTestClient(t)
+	p := createPlainte(t, client)
+	ctx := NewContext(context.Background(), &Write{ResourceType: "plaintes", ResourceID: p.ID.String(), Expected: []int{1}})
+
+	// La version s'écrit dans la transaction, sans attendre un autre verrou
+	tx, err := client.Tx(ctx)
+	require.NoError(t, err)
+	require.NoError(t, tx.Plainte.UpdateOneID(p.ID).SetDescription("annulée").Exec(ctx))
+	require.NoError(t, tx.Rollback())
+	assert.Equal(t, 1, version(t, service, p))
+
+	tx, err = client.Tx(ctx)
+	require.NoError(t, err)
+	require.NoError(t, tx.Plainte.UpdateOneID(p.ID).SetDescription("validée").Exec(ctx))
+	require.NoError(t, tx.Commit())
+	assert.Equal(t, 2, version(t, service, p))
+}
+
+func TestHook_FailedWriteKeepsVersion(t *testing.T) {
+	client, service := newTestClient(t)
+	p := createPlainte(t, client)
+	require.NoError(t, client.Plainte.UpdateOneID(p.ID).SetObservations("relance").Exec(context.Background()))
+
+	failure := errors.New("write failed")
+	client.Plainte.Use(func(ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(context.Context, ent.Mutation) (ent.Value, error) {
+			return nil, failure
+		})
+	})
+
+	err := client.Plainte.UpdateOneID(p.ID).SetDescription("perdue").Exec(context.Background())
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, 2, version(t, service, p))
+}
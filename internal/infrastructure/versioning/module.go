@@ -0,0 +1,17 @@
+package versioning
+
+import (
+	"police-trafic-api-frontend-aligned/ent"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module provides the resource versioning service and installs the hook
+// taking the versions on the ent client
+var Module = fx.Module("versioning",
+	fx.Provide(NewService),
+	fx.Invoke(func(client *ent.Client, logger *zap.Logger) {
+		client.Use(Hook(logger))
+	}),
+)
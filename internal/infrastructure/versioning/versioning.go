@@ -0,0 +1,126 @@
+package versioning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"police-trafic-api-frontend-aligned/ent"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// ErrPreconditionFailed is returned when the resource was modified since the
+// version sent in If-Match
+var ErrPreconditionFailed = errors.New("resource was modified since it was read")
+
+// Write is the resource modified by a request and the versions its If-Match
+// accepts. The hook checks them and records the version reached.
+type Write struct {
+	ResourceType string
+	ResourceID   string
+	Expected     []int // Any version when empty
+
+	mu      sync.Mutex
+	version int  // Version reached, 0 while the resource is unchanged
+	refused bool // If-Match did not match the current version
+	current int  // Current version of a refused write, 0 if unknown
+}
+
+// Version returns the version reached by the write, 0 if the resource was not modified
+func (w *Write) Version() int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.version
+}
+
+// Conflict tells whether the write was refused because the resource changed,
+// and its current version, 0 if unknown
+func (w *Write) Conflict() (int, bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current, w.refused
+}
+
+func (w *Write) reach(version int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.version = version
+}
+
+func (w *Write) refuse(current int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.refused = true
+	w.current = current
+}
+
+func (w *Write) matches(resourceType, resourceID string) bool {
+	return w != nil && w.ResourceType == resourceType && w.ResourceID == resourceID
+}
+
+type writeKey struct{}
+
+// NewContext returns a context carrying the write of the request
+func NewContext(ctx context.Context, w *Write) context.Context {
+	return context.WithValue(ctx, writeKey{}, w)
+}
+
+func writeFromContext(ctx context.Context) *Write {
+	w, _ := ctx.Value(writeKey{}).(*Write)
+	return w
+}
+
+// Service tracks the version of the resources edited concurrently
+type Service interface {
+	// Version returns the current version of a resource
+	Version(ctx context.Context, resourceType, resourceID string) (int, error)
+}
+
+type service struct {
+	client *ent.Client
+	logger *zap.Logger
+}
+
+// NewService creates the resource versioning service
+func NewService(client *ent.Client, logger *zap.Logger) Service {
+	return &service{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (s *service) Version(ctx context.Context, resourceType, resourceID string) (int, error) {
+	id, err := uuid.Parse(resourceID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid resource id %q: %w", resourceID, err)
+	}
+	return currentVersion(ctx, s.client, resourceType, id)
+}
+
+// currentVersion reads the version column of a resource
+func currentVersion(ctx context.Context, client *ent.Client, resourceType string, id uuid.UUID) (int, error) {
+	switch resourceType {
+	case "plaintes":
+		p, err := client.Plainte.Get(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return p.Version, nil
+	case "alertes":
+		a, err := client.AlerteSecuritaire.Get(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return a.Version, nil
+	case "convocations":
+		c, err := client.Convocation.Get(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return c.Version, nil
+	}
+	return 0, fmt.Errorf("resource type %q is not versioned", resourceType)
+}
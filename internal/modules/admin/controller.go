@@ -1,8 +1,12 @@
 package admin
 
 import (
+	"errors"
 	"net/http"
 
+	"police-trafic-api-frontend-aligned/internal/infrastructure/passwords"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
@@ -22,33 +26,37 @@ func NewController(service Service) *Controller {
 
 // RegisterRoutes registers admin routes
 func (ctrl *Controller) RegisterRoutes(e *echo.Group) {
-	admin := e.Group("/admin")
+	admin := rbac.Guard(e).Group("/admin")
 
 	// Statistics
-	admin.GET("/statistiques", ctrl.GetStatistiquesNationales)
+	admin.GET("/statistiques", ctrl.GetStatistiquesNationales, rbac.PermViewReports)
 
 	// Dashboard
-	admin.GET("/agents/dashboard", ctrl.GetAgentsDashboard)
+	admin.GET("/agents/dashboard", ctrl.GetAgentsDashboard, rbac.PermViewReports)
 
 	// Commissariats
-	admin.GET("/commissariats", ctrl.GetCommissariats)
-	admin.GET("/commissariats/:id", ctrl.GetCommissariat)
-	admin.POST("/commissariats", ctrl.CreateCommissariat)
-	admin.PUT("/commissariats/:id", ctrl.UpdateCommissariat)
-	admin.DELETE("/commissariats/:id", ctrl.DeleteCommissariat)
+	admin.GET("/commissariats", ctrl.GetCommissariats, rbac.PermReadCommissariats)
+	admin.GET("/commissariats/:id", ctrl.GetCommissariat, rbac.PermReadCommissariats)
+	admin.POST("/commissariats", ctrl.CreateCommissariat, rbac.PermCreateCommissariats)
+	admin.PUT("/commissariats/:id", ctrl.UpdateCommissariat, rbac.PermUpdateCommissariats)
+	admin.DELETE("/commissariats/:id", ctrl.DeleteCommissariat, rbac.PermDeleteCommissariats)
 
 	// Agents
-	admin.GET("/agents", ctrl.GetAgents)
-	admin.GET("/agents/:id", ctrl.GetAgent)
-	admin.POST("/agents", ctrl.CreateAgent)
-	admin.PUT("/agents/:id", ctrl.UpdateAgent)
-	admin.DELETE("/agents/:id", ctrl.DeleteAgent)
-	admin.GET("/agents/:id/statistiques", ctrl.GetAgentStatistiques)
+	admin.GET("/agents", ctrl.GetAgents, rbac.PermReadUsers)
+	admin.GET("/agents/:id", ctrl.GetAgent, rbac.PermReadUsers)
+	admin.POST("/agents", ctrl.CreateAgent, rbac.PermCreateUsers)
+	admin.PUT("/agents/:id", ctrl.UpdateAgent, rbac.PermUpdateUsers)
+	admin.DELETE("/agents/:id", ctrl.DeleteAgent, rbac.PermDeleteUsers)
+	admin.GET("/agents/:id/statistiques", ctrl.GetAgentStatistiques, rbac.PermReadUsers)
 
 	// Session Management (Remote Logout)
-	admin.GET("/agents/:id/sessions", ctrl.GetAgentSessions)
-	admin.DELETE("/agents/:id/sessions/:sessionId", ctrl.RevokeAgentSession)
-	admin.DELETE("/agents/:id/sessions", ctrl.RevokeAllAgentSessions)
+	admin.GET("/agents/:id/sessions", ctrl.GetAgentSessions, rbac.PermReadUsers)
+	admin.DELETE("/agents/:id/sessions/:sessionId", ctrl.RevokeAgentSession, rbac.PermUpdateUsers)
+	admin.DELETE("/agents/:id/sessions", ctrl.RevokeAllAgentSessions, rbac.PermUpdateUsers)
+
+	// Login lockout
+	admin.POST("/agents/:id/unlock", ctrl.UnlockAgent, rbac.PermUpdateUsers)
+	admin.DELETE("/agents/:id/mfa", ctrl.ResetAgentMFA, rbac.PermUpdateUsers)
 }
 
 // GetStatistiquesNationales handles GET /admin/statistiques
@@ -82,11 +90,16 @@ func (ctrl *Controller) GetAgentsDashboard(c echo.Context) error {
 
 // GetCommissariats handles GET /admin/commissariats
 func (ctrl *Controller) GetCommissariats(c echo.Context) error {
-	commissariats, err := ctrl.service.GetCommissariats(c.Request().Context())
+	page, err := pagination.Parse(c, commissariatSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
+	commissariats, result, err := ctrl.service.GetCommissariats(c.Request().Context(), page)
 	if err != nil {
 		return responses.InternalServerError(c, err.Error())
 	}
-	return responses.Success(c, commissariats)
+	return responses.Paginated(c, commissariats, result)
 }
 
 // GetCommissariat handles GET /admin/commissariats/:id
@@ -170,11 +183,16 @@ func (ctrl *Controller) GetAgents(c echo.Context) error {
 		commissariatID = &id
 	}
 
-	agents, err := ctrl.service.GetAgents(c.Request().Context(), commissariatID)
+	page, err := pagination.Parse(c, agentSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
+	agents, result, err := ctrl.service.GetAgents(c.Request().Context(), commissariatID, page)
 	if err != nil {
 		return responses.InternalServerError(c, err.Error())
 	}
-	return responses.Success(c, agents)
+	return responses.Paginated(c, agents, result)
 }
 
 // GetAgent handles GET /admin/agents/:id
@@ -208,6 +226,9 @@ func (ctrl *Controller) UpdateAgent(c echo.Context) error {
 
 	agent, err := ctrl.service.UpdateAgent(c.Request().Context(), id, &req)
 	if err != nil {
+		if errors.Is(err, ErrUnknownRole) {
+			return responses.BadRequest(c, err.Error())
+		}
 		if err.Error() == "user not found" {
 			return responses.NotFound(c, "Agent not found")
 		}
@@ -229,6 +250,10 @@ func (ctrl *Controller) CreateAgent(c echo.Context) error {
 
 	agent, err := ctrl.service.CreateAgent(c.Request().Context(), &req)
 	if err != nil {
+		var policyErr *passwords.PolicyError
+		if errors.Is(err, ErrUnknownRole) || errors.As(err, &policyErr) {
+			return responses.BadRequest(c, err.Error())
+		}
 		return responses.InternalServerError(c, err.Error())
 	}
 	return responses.Created(c, agent)
@@ -285,6 +310,11 @@ func (ctrl *Controller) GetAgentSessions(c echo.Context) error {
 		return responses.BadRequest(c, "ID is required")
 	}
 
+	page, err := pagination.Parse(c, sessionSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
 	sessions, err := ctrl.service.GetAgentSessions(c.Request().Context(), id)
 	if err != nil {
 		if err.Error() == "agent not found" {
@@ -292,7 +322,9 @@ func (ctrl *Controller) GetAgentSessions(c echo.Context) error {
 		}
 		return responses.InternalServerError(c, err.Error())
 	}
-	return responses.Success(c, sessions)
+
+	sessions, result := pagination.Slice(page, sessions)
+	return responses.Paginated(c, sessions, result)
 }
 
 // RevokeAgentSession handles DELETE /admin/agents/:id/sessions/:sessionId
@@ -364,3 +396,55 @@ func (ctrl *Controller) RevokeAllAgentSessions(c echo.Context) error {
 	}
 	return responses.SuccessWithMessage(c, "All sessions revoked successfully", nil)
 }
+
+// UnlockAgent handles POST /admin/agents/:id/unlock
+// @Summary Unlock agent account
+// @Description Lift the lock set after repeated failed logins and reset the failure counter
+// @Tags admin
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Agent ID"
+// @Success 200 {object} responses.SuccessResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Router /admin/agents/{id}/unlock [post]
+func (ctrl *Controller) UnlockAgent(c echo.Context) error {
+	agentID := c.Param("id")
+	if agentID == "" {
+		return responses.BadRequest(c, "Agent ID is required")
+	}
+
+	if err := ctrl.service.UnlockAgent(c.Request().Context(), agentID); err != nil {
+		if err.Error() == "agent not found" {
+			return responses.NotFound(c, "Agent not found")
+		}
+		return responses.InternalServerError(c, err.Error())
+	}
+	return responses.SuccessWithMessage(c, "Agent account unlocked successfully", nil)
+}
+
+// ResetAgentMFA handles DELETE /admin/agents/:id/mfa
+// @Summary Reset agent second factor
+// @Description Remove the TOTP enrolment and recovery codes of an agent, e.g. after a lost phone
+// @Tags admin
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Agent ID"
+// @Success 200 {object} responses.SuccessResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Router /admin/agents/{id}/mfa [delete]
+func (ctrl *Controller) ResetAgentMFA(c echo.Context) error {
+	agentID := c.Param("id")
+	if agentID == "" {
+		return responses.BadRequest(c, "Agent ID is required")
+	}
+
+	if err := ctrl.service.ResetAgentMFA(c.Request().Context(), agentID); err != nil {
+		if err.Error() == "agent not found" {
+			return responses.NotFound(c, "Agent not found")
+		}
+		return responses.InternalServerError(c, err.Error())
+	}
+	return responses.SuccessWithMessage(c, "Agent second factor reset successfully", nil)
+}
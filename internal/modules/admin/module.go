@@ -3,6 +3,10 @@ package admin
 import (
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/crypto"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/loginguard"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/mfa"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/passwords"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/session"
 
@@ -32,9 +36,13 @@ func NewServiceProvider(
 	infractionRepo repository.InfractionRepository,
 	passwordService crypto.Service,
 	sessionService session.Service,
+	rbacService rbac.Service,
+	loginGuard loginguard.Service,
+	mfaService mfa.Service,
+	passwordPolicy passwords.Service,
 	logger *zap.Logger,
 ) Service {
-	return NewService(commissariatRepo, userRepo, controleRepo, pvRepo, alerteRepo, infractionRepo, passwordService, sessionService, logger)
+	return NewService(commissariatRepo, userRepo, controleRepo, pvRepo, alerteRepo, infractionRepo, passwordService, sessionService, rbacService, loginGuard, mfaService, passwordPolicy, logger)
 }
 
 // NewControllerProvider creates a new admin controller for DI
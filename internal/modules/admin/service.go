@@ -2,31 +2,42 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/audittrail"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/crypto"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/loginguard"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/mfa"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/passwords"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/session"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// ErrUnknownRole is returned when an agent is given a role that does not exist
+var ErrUnknownRole = errors.New("unknown role")
+
 // Service defines admin service interface
 type Service interface {
 	// Statistics
 	GetStatistiquesNationales(ctx context.Context) (*StatistiquesNationales, error)
 
 	// Commissariats
-	GetCommissariats(ctx context.Context) ([]*CommissariatResponse, error)
+	GetCommissariats(ctx context.Context, params *pagination.Params) ([]*CommissariatResponse, *pagination.Page, error)
 	GetCommissariat(ctx context.Context, id string) (*CommissariatResponse, error)
 	CreateCommissariat(ctx context.Context, req *CreateCommissariatRequest) (*CommissariatResponse, error)
 	UpdateCommissariat(ctx context.Context, id string, req *UpdateCommissariatRequest) (*CommissariatResponse, error)
 	DeleteCommissariat(ctx context.Context, id string) error
 
 	// Agents
-	GetAgents(ctx context.Context, commissariatID *string) ([]*AgentResponse, error)
+	GetAgents(ctx context.Context, commissariatID *string, params *pagination.Params) ([]*AgentResponse, *pagination.Page, error)
 	GetAgent(ctx context.Context, id string) (*AgentResponse, error)
 	CreateAgent(ctx context.Context, req *CreateAgentRequest) (*AgentResponse, error)
 	UpdateAgent(ctx context.Context, id string, req *UpdateAgentRequest) (*AgentResponse, error)
@@ -40,6 +51,12 @@ type Service interface {
 	GetAgentSessions(ctx context.Context, agentID string) ([]*AgentSessionResponse, error)
 	RevokeAgentSession(ctx context.Context, agentID string, sessionID string, reason string) error
 	RevokeAllAgentSessions(ctx context.Context, agentID string, reason string) error
+
+	// Login lockout
+	UnlockAgent(ctx context.Context, agentID string) error
+
+	// Second factor
+	ResetAgentMFA(ctx context.Context, agentID string) error
 }
 
 // service implements admin service
@@ -52,6 +69,10 @@ type service struct {
 	infractionRepo   repository.InfractionRepository
 	passwordService  crypto.Service
 	sessionService   session.Service
+	rbacService      rbac.Service
+	loginGuard       loginguard.Service
+	mfaService       mfa.Service
+	passwordPolicy   passwords.Service
 	logger           *zap.Logger
 }
 
@@ -65,6 +86,10 @@ func NewService(
 	infractionRepo repository.InfractionRepository,
 	passwordService crypto.Service,
 	sessionService session.Service,
+	rbacService rbac.Service,
+	loginGuard loginguard.Service,
+	mfaService mfa.Service,
+	passwordPolicy passwords.Service,
 	logger *zap.Logger,
 ) Service {
 	return &service{
@@ -76,12 +101,19 @@ func NewService(
 		infractionRepo:   infractionRepo,
 		passwordService:  passwordService,
 		sessionService:   sessionService,
+		rbacService:      rbacService,
+		loginGuard:       loginGuard,
+		mfaService:       mfaService,
+		passwordPolicy:   passwordPolicy,
 		logger:           logger,
 	}
 }
 
 // GetStatistiquesNationales returns national statistics
 func (s *service) GetStatistiquesNationales(ctx context.Context) (*StatistiquesNationales, error) {
+	ctx, span := tracing.Start(ctx, "admin.GetStatistiquesNationales")
+	defer span.End()
+
 	s.logger.Info("Getting national statistics")
 
 	// Get controles count
@@ -204,13 +236,28 @@ func (s *service) GetStatistiquesNationales(ctx context.Context) (*StatistiquesN
 	}, nil
 }
 
-// GetCommissariats returns all commissariats
-func (s *service) GetCommissariats(ctx context.Context) ([]*CommissariatResponse, error) {
+// GetCommissariats returns a page of commissariats
+func (s *service) GetCommissariats(ctx context.Context, params *pagination.Params) ([]*CommissariatResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "admin.GetCommissariats")
+	defer span.End()
+
 	s.logger.Info("Getting commissariats")
 
-	commList, err := s.commissariatRepo.List(ctx, nil)
+	filters := &repository.CommissariatFilters{Page: params}
+	commList, err := s.commissariatRepo.List(ctx, filters)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list commissariats: %w", err)
+		return nil, nil, fmt.Errorf("failed to list commissariats: %w", err)
+	}
+
+	commList, page, err := pagination.Trim(params, commList)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if total, err := s.commissariatRepo.Count(ctx, filters); err == nil {
+		page.SetTotal(total)
+	} else {
+		s.logger.Error("Failed to count commissariats", zap.Error(err))
 	}
 
 	responses := make([]*CommissariatResponse, len(commList))
@@ -218,11 +265,14 @@ func (s *service) GetCommissariats(ctx context.Context) ([]*CommissariatResponse
 		responses[i] = s.commissariatToResponse(comm)
 	}
 
-	return responses, nil
+	return responses, page, nil
 }
 
 // GetCommissariat returns a commissariat by ID
 func (s *service) GetCommissariat(ctx context.Context, id string) (*CommissariatResponse, error) {
+	ctx, span := tracing.Start(ctx, "admin.GetCommissariat")
+	defer span.End()
+
 	s.logger.Info("Getting commissariat", zap.String("id", id))
 
 	comm, err := s.commissariatRepo.GetByID(ctx, id)
@@ -235,6 +285,9 @@ func (s *service) GetCommissariat(ctx context.Context, id string) (*Commissariat
 
 // CreateCommissariat creates a new commissariat
 func (s *service) CreateCommissariat(ctx context.Context, req *CreateCommissariatRequest) (*CommissariatResponse, error) {
+	ctx, span := tracing.Start(ctx, "admin.CreateCommissariat")
+	defer span.End()
+
 	s.logger.Info("Creating commissariat", zap.String("code", req.Code))
 
 	input := &repository.CreateCommissariatInput{
@@ -260,6 +313,9 @@ func (s *service) CreateCommissariat(ctx context.Context, req *CreateCommissaria
 
 // UpdateCommissariat updates a commissariat
 func (s *service) UpdateCommissariat(ctx context.Context, id string, req *UpdateCommissariatRequest) (*CommissariatResponse, error) {
+	ctx, span := tracing.Start(ctx, "admin.UpdateCommissariat")
+	defer span.End()
+
 	s.logger.Info("Updating commissariat", zap.String("id", id))
 
 	input := &repository.UpdateCommissariatInput{
@@ -284,35 +340,55 @@ func (s *service) UpdateCommissariat(ctx context.Context, id string, req *Update
 
 // DeleteCommissariat deletes a commissariat
 func (s *service) DeleteCommissariat(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "admin.DeleteCommissariat")
+	defer span.End()
+
 	s.logger.Info("Deleting commissariat", zap.String("id", id))
 	return s.commissariatRepo.Delete(ctx, id)
 }
 
-// GetAgents returns agents, optionally filtered by commissariat
-func (s *service) GetAgents(ctx context.Context, commissariatID *string) ([]*AgentResponse, error) {
+// GetAgents returns a page of active agents, optionally filtered by commissariat
+func (s *service) GetAgents(ctx context.Context, commissariatID *string, params *pagination.Params) ([]*AgentResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "admin.GetAgents")
+	defer span.End()
+
 	s.logger.Info("Getting agents")
 
-	users, err := s.userRepo.List(ctx)
+	active := true
+	filters := &repository.UserFilters{
+		CommissariatID: commissariatID,
+		Active:         &active,
+		Page:           params,
+	}
+	users, err := s.userRepo.ListWithFilters(ctx, filters)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list agents: %w", err)
+		return nil, nil, fmt.Errorf("failed to list agents: %w", err)
 	}
 
-	responses := make([]*AgentResponse, 0, len(users))
-	for _, user := range users {
-		// Filter by commissariat if specified
-		if commissariatID != nil {
-			if user.Edges.Commissariat == nil || user.Edges.Commissariat.ID.String() != *commissariatID {
-				continue
-			}
-		}
-		responses = append(responses, s.userToAgentResponse(user))
+	users, page, err := pagination.Trim(params, users)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if total, err := s.userRepo.Count(ctx, filters); err == nil {
+		page.SetTotal(total)
+	} else {
+		s.logger.Error("Failed to count agents", zap.Error(err))
+	}
+
+	responses := make([]*AgentResponse, len(users))
+	for i, user := range users {
+		responses[i] = s.userToAgentResponse(user)
 	}
 
-	return responses, nil
+	return responses, page, nil
 }
 
 // GetAgent returns an agent by ID
 func (s *service) GetAgent(ctx context.Context, id string) (*AgentResponse, error) {
+	ctx, span := tracing.Start(ctx, "admin.GetAgent")
+	defer span.End()
+
 	s.logger.Info("Getting agent", zap.String("id", id))
 
 	user, err := s.userRepo.GetByID(ctx, id)
@@ -325,8 +401,20 @@ func (s *service) GetAgent(ctx context.Context, id string) (*AgentResponse, erro
 
 // UpdateAgent updates an agent
 func (s *service) UpdateAgent(ctx context.Context, id string, req *UpdateAgentRequest) (*AgentResponse, error) {
+	ctx, span := tracing.Start(ctx, "admin.UpdateAgent")
+	defer span.End()
+
 	s.logger.Info("Updating agent", zap.String("id", id))
 
+	var before *AgentResponse
+	if current, err := s.userRepo.GetByID(ctx, id); err == nil {
+		before = s.userToAgentResponse(current)
+	}
+
+	if req.Role != nil && !s.rbacService.ValidateRole(*req.Role) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRole, *req.Role)
+	}
+
 	input := &repository.UpdateUserInput{
 		Nom:            req.Nom,
 		Prenom:         req.Prenom,
@@ -346,13 +434,27 @@ func (s *service) UpdateAgent(ctx context.Context, id string, req *UpdateAgentRe
 		return nil, fmt.Errorf("failed to update agent: %w", err)
 	}
 
-	return s.userToAgentResponse(user), nil
+	response := s.userToAgentResponse(user)
+	audittrail.Snapshot(ctx, "User", id, before, response)
+	return response, nil
 }
 
-// CreateAgent creates a new agent with hashed password
+// CreateAgent creates a new agent with hashed password. The password is
+// temporary: the agent has to change it at the first login.
 func (s *service) CreateAgent(ctx context.Context, req *CreateAgentRequest) (*AgentResponse, error) {
+	ctx, span := tracing.Start(ctx, "admin.CreateAgent")
+	defer span.End()
+
 	s.logger.Info("Creating agent", zap.String("matricule", req.Matricule))
 
+	if !s.rbacService.ValidateRole(req.Role) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownRole, req.Role)
+	}
+
+	if err := s.passwordPolicy.Validate(req.Password, req.Matricule, req.Nom, req.Prenom); err != nil {
+		return nil, err
+	}
+
 	// Hash the password
 	hashedPassword, err := s.passwordService.HashPassword(req.Password)
 	if err != nil {
@@ -378,17 +480,27 @@ func (s *service) CreateAgent(ctx context.Context, req *CreateAgentRequest) (*Ag
 		return nil, fmt.Errorf("failed to create agent: %w", err)
 	}
 
+	if err := s.passwordPolicy.Record(ctx, user.ID, hashedPassword, true); err != nil {
+		return nil, fmt.Errorf("failed to record temporary password: %w", err)
+	}
+
 	return s.userToAgentResponse(user), nil
 }
 
 // DeleteAgent deletes an agent
 func (s *service) DeleteAgent(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "admin.DeleteAgent")
+	defer span.End()
+
 	s.logger.Info("Deleting agent", zap.String("id", id))
 	return s.userRepo.Delete(ctx, id)
 }
 
 // GetAgentStatistiques returns statistics for an agent
 func (s *service) GetAgentStatistiques(ctx context.Context, id string) (*AgentStatistiquesResponse, error) {
+	ctx, span := tracing.Start(ctx, "admin.GetAgentStatistiques")
+	defer span.End()
+
 	s.logger.Info("Getting agent statistics", zap.String("id", id))
 
 	// Verify agent exists
@@ -636,6 +748,9 @@ func (s *service) userToAgentResponse(user *ent.User) *AgentResponse {
 
 // GetAgentsDashboard returns comprehensive dashboard data for agents management
 func (s *service) GetAgentsDashboard(ctx context.Context, req *AgentDashboardRequest) (*AgentDashboardResponse, error) {
+	ctx, span := tracing.Start(ctx, "admin.GetAgentsDashboard")
+	defer span.End()
+
 	s.logger.Info("Getting agents dashboard", zap.String("periode", req.Periode))
 
 	// Get all users/agents
@@ -965,6 +1080,9 @@ func (s *service) buildCommissariatStats(ctx context.Context, commissariats []*e
 
 // GetAgentSessions returns all active sessions for an agent
 func (s *service) GetAgentSessions(ctx context.Context, agentID string) ([]*AgentSessionResponse, error) {
+	ctx, span := tracing.Start(ctx, "admin.GetAgentSessions")
+	defer span.End()
+
 	s.logger.Info("Getting agent sessions", zap.String("agent_id", agentID))
 
 	// Verify agent exists
@@ -1004,6 +1122,9 @@ func (s *service) GetAgentSessions(ctx context.Context, agentID string) ([]*Agen
 
 // RevokeAgentSession revokes a specific session for an agent
 func (s *service) RevokeAgentSession(ctx context.Context, agentID string, sessionID string, reason string) error {
+	ctx, span := tracing.Start(ctx, "admin.RevokeAgentSession")
+	defer span.End()
+
 	s.logger.Info("Revoking agent session",
 		zap.String("agent_id", agentID),
 		zap.String("session_id", sessionID),
@@ -1053,6 +1174,9 @@ func (s *service) RevokeAgentSession(ctx context.Context, agentID string, sessio
 
 // RevokeAllAgentSessions revokes all sessions for an agent
 func (s *service) RevokeAllAgentSessions(ctx context.Context, agentID string, reason string) error {
+	ctx, span := tracing.Start(ctx, "admin.RevokeAllAgentSessions")
+	defer span.End()
+
 	s.logger.Info("Revoking all agent sessions",
 		zap.String("agent_id", agentID),
 		zap.String("reason", reason),
@@ -1075,3 +1199,37 @@ func (s *service) RevokeAllAgentSessions(ctx context.Context, agentID string, re
 
 	return s.sessionService.RevokeAllUserSessions(ctx, userID, reason)
 }
+
+// UnlockAgent lifts the lock set after repeated failed logins
+func (s *service) UnlockAgent(ctx context.Context, agentID string) error {
+	ctx, span := tracing.Start(ctx, "admin.UnlockAgent")
+	defer span.End()
+
+	s.logger.Info("Unlocking agent account", zap.String("agent_id", agentID))
+
+	// Verify agent exists
+	user, err := s.userRepo.GetByID(ctx, agentID)
+	if err != nil {
+		return fmt.Errorf("agent not found")
+	}
+
+	return s.loginGuard.Unlock(ctx, user.ID.String())
+}
+
+// ResetAgentMFA removes the second factor of an agent who lost their device.
+// Active sessions are kept; the agent enrols again at the next login if the
+// role requires it.
+func (s *service) ResetAgentMFA(ctx context.Context, agentID string) error {
+	ctx, span := tracing.Start(ctx, "admin.ResetAgentMFA")
+	defer span.End()
+
+	s.logger.Info("Resetting agent second factor", zap.String("agent_id", agentID))
+
+	// Verify agent exists
+	user, err := s.userRepo.GetByID(ctx, agentID)
+	if err != nil {
+		return fmt.Errorf("agent not found")
+	}
+
+	return s.mfaService.Reset(ctx, user.ID)
+}
@@ -1,6 +1,11 @@
 package admin
 
-import "time"
+import (
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+)
 
 // StatistiquesNationales represents national statistics
 type StatistiquesNationales struct {
@@ -328,3 +333,54 @@ type AgentSessionResponse struct {
 type RevokeSessionRequest struct {
 	Reason string `json:"reason,omitempty"`
 }
+
+// commissariatSpec declares the sorts and fields accepted by GET /admin/commissariats
+var commissariatSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"nom":       "nom",
+		"ville":     "ville",
+		"region":    "region",
+		"createdAt": "created_at",
+	},
+	DefaultSort: "nom",
+	Fields:      pagination.JSONFields(CommissariatResponse{}),
+	Filters: filter.Fields{
+		"nom":       {Column: "nom", Type: filter.String},
+		"code":      {Column: "code", Type: filter.String},
+		"ville":     {Column: "ville", Type: filter.String},
+		"region":    {Column: "region", Type: filter.String},
+		"actif":     {Column: "actif", Type: filter.Bool},
+		"createdAt": {Column: "created_at", Type: filter.Time},
+	},
+}
+
+// agentSpec declares the sorts and fields accepted by GET /admin/agents
+var agentSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"nom":       "nom",
+		"matricule": "matricule",
+		"role":      "role",
+		"createdAt": "created_at",
+	},
+	DefaultSort: "-createdAt",
+	Fields:      pagination.JSONFields(AgentResponse{}),
+	Filters: filter.Fields{
+		"nom":           {Column: "nom", Type: filter.String},
+		"matricule":     {Column: "matricule", Type: filter.String},
+		"role":          {Column: "role", Type: filter.String},
+		"grade":         {Column: "grade", Type: filter.String},
+		"statutService": {Column: "statut_service", Type: filter.String},
+		"createdAt":     {Column: "created_at", Type: filter.Time},
+	},
+}
+
+// sessionSpec declares the sorts and fields accepted by GET /admin/agents/:id/sessions
+var sessionSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"last_activity_at": "last_activity_at",
+		"session_started":  "session_started",
+		"device_name":      "device_name",
+	},
+	DefaultSort: "-last_activity_at",
+	Fields:      pagination.JSONFields(AgentSessionResponse{}),
+}
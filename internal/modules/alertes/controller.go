@@ -2,10 +2,11 @@ package alertes
 
 import (
 	"net/http"
-	"strconv"
 	"time"
 
 	"police-trafic-api-frontend-aligned/internal/core/middleware"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
@@ -28,54 +29,51 @@ func NewController(service Service, authMiddleware *middleware.AuthMiddleware) *
 
 // RegisterRoutes registers alertes routes
 func (ctrl *Controller) RegisterRoutes(e *echo.Group) {
-	alertes := e.Group("/alertes")
-
-	// Appliquer le middleware d'authentification à toutes les routes alertes
-	alertes.Use(ctrl.authMiddleware.RequireAuth())
+	alertes := rbac.Guard(e).Group("/alertes")
 
 	// Routes principales
-	alertes.POST("", ctrl.Create)
-	alertes.GET("", ctrl.List)
+	alertes.POST("", ctrl.Create, rbac.PermCreateAlertes)
+	alertes.GET("", ctrl.List, rbac.PermReadAlertes)
 	
 	// Routes fixes (AVANT /:id pour éviter les conflits)
-	alertes.GET("/actives", ctrl.GetActives)
-	alertes.GET("/statistiques", ctrl.GetStatistiques)
-	alertes.GET("/dashboard", ctrl.GetDashboard)
-	alertes.POST("/generer-description", ctrl.GenererDescription)
-	alertes.POST("/:id/generer-rapport", ctrl.GenererRapport)
+	alertes.GET("/actives", ctrl.GetActives, rbac.PermReadAlertes)
+	alertes.GET("/statistiques", ctrl.GetStatistiques, rbac.PermReadAlertes)
+	alertes.GET("/dashboard", ctrl.GetDashboard, rbac.PermReadAlertes)
+	alertes.POST("/generer-description", ctrl.GenererDescription, rbac.PermCreateAlertes)
+	alertes.POST("/:id/generer-rapport", ctrl.GenererRapport, rbac.PermUpdateAlertes)
 	
 	// Route avec paramètre :id (APRÈS les routes fixes)
-	alertes.GET("/:id", ctrl.GetByID)
-	alertes.PATCH("/:id", ctrl.Update)
-	alertes.PUT("/:id", ctrl.Update)
-	alertes.DELETE("/:id", ctrl.Delete)
+	alertes.GET("/:id", ctrl.GetByID, rbac.PermReadAlertes)
+	alertes.PATCH("/:id", ctrl.Update, rbac.PermUpdateAlertes)
+	alertes.PUT("/:id", ctrl.Update, rbac.PermUpdateAlertes)
+	alertes.DELETE("/:id", ctrl.Delete, rbac.PermDeleteAlertes)
 
 	// Gestion du cycle de vie
-	alertes.POST("/:id/suivi", ctrl.AddSuivi)
-	alertes.POST("/:id/broadcast", ctrl.Broadcast)
-	alertes.POST("/:id/diffuser", ctrl.Broadcast) // Alias
-	alertes.POST("/:id/diffusion-interne", ctrl.DiffusionInterne)
-	alertes.POST("/:id/assign", ctrl.Assign)
-	alertes.PATCH("/:id/resolve", ctrl.Resolve)
-	alertes.POST("/:id/resoudre", ctrl.Resolve) // Alias
-	alertes.POST("/:id/archiver", ctrl.Archiver)
-	alertes.POST("/:id/cloturer", ctrl.Cloturer)
+	alertes.POST("/:id/suivi", ctrl.AddSuivi, rbac.PermUpdateAlertes)
+	alertes.POST("/:id/broadcast", ctrl.Broadcast, rbac.PermUpdateAlertes)
+	alertes.POST("/:id/diffuser", ctrl.Broadcast, rbac.PermUpdateAlertes) // Alias
+	alertes.POST("/:id/diffusion-interne", ctrl.DiffusionInterne, rbac.PermUpdateAlertes)
+	alertes.POST("/:id/assign", ctrl.Assign, rbac.PermUpdateAlertes)
+	alertes.PATCH("/:id/resolve", ctrl.Resolve, rbac.PermUpdateAlertes)
+	alertes.POST("/:id/resoudre", ctrl.Resolve, rbac.PermUpdateAlertes) // Alias
+	alertes.POST("/:id/archiver", ctrl.Archiver, rbac.PermUpdateAlertes)
+	alertes.POST("/:id/cloturer", ctrl.Cloturer, rbac.PermUpdateAlertes)
 
 	// Intervention
-	alertes.POST("/:id/intervention/deploy", ctrl.DeployIntervention)
-	alertes.PATCH("/:id/intervention", ctrl.UpdateIntervention)
+	alertes.POST("/:id/intervention/deploy", ctrl.DeployIntervention, rbac.PermUpdateAlertes)
+	alertes.PATCH("/:id/intervention", ctrl.UpdateIntervention, rbac.PermUpdateAlertes)
 
 	// Évaluation et rapport
-	alertes.POST("/:id/evaluation", ctrl.AddEvaluation)
-	alertes.POST("/:id/rapport", ctrl.AddRapport)
+	alertes.POST("/:id/evaluation", ctrl.AddEvaluation, rbac.PermUpdateAlertes)
+	alertes.POST("/:id/rapport", ctrl.AddRapport, rbac.PermUpdateAlertes)
 
 	// Témoins et documents
-	alertes.POST("/:id/temoin", ctrl.AddTemoin)
-	alertes.POST("/:id/document", ctrl.AddDocument)
-	alertes.POST("/:id/photos", ctrl.AddPhotos)
+	alertes.POST("/:id/temoin", ctrl.AddTemoin, rbac.PermUpdateAlertes)
+	alertes.POST("/:id/document", ctrl.AddDocument, rbac.PermUpdateAlertes)
+	alertes.POST("/:id/photos", ctrl.AddPhotos, rbac.PermUpdateAlertes)
 
 	// Actions
-	alertes.PATCH("/:id/actions", ctrl.UpdateActions)
+	alertes.PATCH("/:id/actions", ctrl.UpdateActions, rbac.PermUpdateAlertes)
 }
 
 // List handles GET /alertes
@@ -120,17 +118,12 @@ func (ctrl *Controller) List(c echo.Context) error {
 		}
 	}
 
-	// Parse pagination
-	if page := c.QueryParam("page"); page != "" {
-		if p, err := strconv.Atoi(page); err == nil && p > 0 {
-			filters.Page = p
-		}
-	}
-	if limit := c.QueryParam("limit"); limit != "" {
-		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
-			filters.Limit = l
-		}
+	// Pagination, tri et champs
+	page, err := pagination.Parse(c, listSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
 	}
+	filters.Page = page
 
 	// Get user context for filtering
 	userID := getUserIDFromContext(c)
@@ -152,22 +145,22 @@ func (ctrl *Controller) List(c echo.Context) error {
 			"niveau":         filters.Niveau,
 			"type":           filters.Type,
 			"search":         filters.Search,
-			"page":           filters.Page,
-			"limit":          filters.Limit,
+			"limit":          page.Limit,
+			"sort":           page.Sort,
 		}),
 	)
 
-	result, err := ctrl.service.List(c.Request().Context(), filters, roleStr, userID, commissariatFromContext)
+	alertes, result, err := ctrl.service.List(c.Request().Context(), filters, roleStr, userID, commissariatFromContext)
 	if err != nil {
 		return responses.InternalServerError(c, err.Error())
 	}
 
 	ctrl.service.(*service).logger.Info("List alertes response",
-		zap.Int("count", len(result.Alertes)),
-		zap.Int64("total", result.Total),
+		zap.Int("count", len(alertes)),
+		zap.Any("total", result.Total),
 	)
 
-	return responses.Success(c, result)
+	return responses.Paginated(c, alertes, result)
 }
 
 // GetByID handles GET /alertes/:id
@@ -312,12 +305,17 @@ func (ctrl *Controller) GetActives(c echo.Context) error {
 		commissariatID = &id
 	}
 
-	alertes, err := ctrl.service.GetActives(c.Request().Context(), commissariatID)
+	page, err := pagination.Parse(c, listSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
+	alertes, result, err := ctrl.service.GetActives(c.Request().Context(), commissariatID, page)
 	if err != nil {
 		return responses.InternalServerError(c, err.Error())
 	}
 
-	return responses.Success(c, alertes)
+	return responses.Paginated(c, alertes, result)
 }
 
 // GetStatistiques handles GET /alertes/statistiques
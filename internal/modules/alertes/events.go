@@ -0,0 +1,53 @@
+package alertes
+
+import (
+	"context"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/realtime"
+
+	"go.uber.org/zap"
+)
+
+// Événements temps réel publiés sur /api/v1/stream
+const (
+	EventAlerteCreated      = "alerte.created"
+	EventAlerteBroadcast    = "alerte.broadcast"
+	EventAlerteAssigned     = "alerte.assigned"
+	EventAlerteIntervention = "alerte.intervention"
+	EventAlerteResolved     = "alerte.resolved"
+)
+
+// audienceAlerte returns who follows an alert: its commissariat, the broadcast
+// recipients and the commissariats and agents it was assigned to
+func audienceAlerte(alerte *AlerteResponse) realtime.Audience {
+	audience := realtime.Audience{}
+	if alerte.CommissariatID != "" {
+		audience.CommissariatIDs = append(audience.CommissariatIDs, alerte.CommissariatID)
+	}
+
+	if diff := alerte.DiffusionDestinataires; diff != nil {
+		audience.All = diff.DiffusionGenerale != nil && *diff.DiffusionGenerale
+		audience.CommissariatIDs = append(audience.CommissariatIDs, diff.CommissariatsIds...)
+		audience.AgentIDs = append(audience.AgentIDs, diff.AgentsIds...)
+	}
+
+	for commissariatID, assignation := range alerte.AssignationDestinataires {
+		audience.CommissariatIDs = append(audience.CommissariatIDs, commissariatID)
+		if assignation != nil {
+			audience.AgentIDs = append(audience.AgentIDs, assignation.AgentsIds...)
+		}
+	}
+
+	return audience
+}
+
+// publier pushes the alert to the connected clients. A failure is only logged:
+// clients still get the alert through /alertes/actives.
+func (s *service) publier(ctx context.Context, eventType string, alerte *AlerteResponse) {
+	if err := s.hub.Publish(ctx, eventType, alerte.ID, alerte, audienceAlerte(alerte)); err != nil {
+		s.logger.Warn("Failed to publish alerte event",
+			zap.String("id", alerte.ID),
+			zap.String("event", eventType),
+			zap.Error(err))
+	}
+}
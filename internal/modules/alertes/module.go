@@ -4,6 +4,9 @@ import (
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
 	"police-trafic-api-frontend-aligned/internal/core/middleware"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/notification"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/realtime"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
 
 	"go.uber.org/fx"
@@ -27,10 +30,13 @@ func NewServiceProvider(
 	alerteRepo repository.AlerteRepository,
 	userRepo repository.UserRepository,
 	commissariatRepo repository.CommissariatRepository,
+	notificationService notification.Service,
+	numberingService numbering.Service,
+	hub realtime.Hub,
 	cfg *config.Config,
 	logger *zap.Logger,
 ) Service {
-	return NewService(alerteRepo, userRepo, commissariatRepo, cfg, logger)
+	return NewService(alerteRepo, userRepo, commissariatRepo, notificationService, numberingService, hub, cfg, logger)
 }
 
 // NewControllerProvider creates a new alertes controller for DI
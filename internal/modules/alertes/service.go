@@ -7,14 +7,20 @@ import (
 	"fmt"
 	"io"
 	"net/http"
-	"strconv"
 	"strings"
 	"time"
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/alertesecuritaire"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/audittrail"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/metrics"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/notification"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/realtime"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -26,7 +32,7 @@ type Service interface {
 	Create(ctx context.Context, req *CreateAlerteRequest, agentID string) (*AlerteResponse, error)
 	GetByID(ctx context.Context, id string) (*AlerteResponse, error)
 	GetByNumero(ctx context.Context, numero string) (*AlerteResponse, error)
-	List(ctx context.Context, filters *FilterAlertesRequest, role, userID, commissariatID string) (*ListAlertesResponse, error)
+	List(ctx context.Context, filters *FilterAlertesRequest, role, userID, commissariatID string) ([]AlerteResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, req *UpdateAlerteRequest) (*AlerteResponse, error)
 	Delete(ctx context.Context, id string) error
 	
@@ -56,20 +62,26 @@ type Service interface {
 	UpdateActions(ctx context.Context, id string, req *UpdateActionsRequest, agentID string) (*AlerteResponse, error)
 	
 	// Utilitaires
-	GetActives(ctx context.Context, commissariatID *string) ([]*AlerteResponse, error)
+	GetActives(ctx context.Context, commissariatID *string, params *pagination.Params) ([]*AlerteResponse, *pagination.Page, error)
 	GetStatistiques(ctx context.Context, commissariatID *string, dateDebut, dateFin, periode *string) (*StatistiquesAlertesResponse, error)
 	GetDashboard(ctx context.Context, commissariatID *string, dateDebut, dateFin, periode *string) (*DashboardResponse, error)
 	GenererDescription(ctx context.Context, req *GenerateDescriptionRequest) (*GenerateDescriptionResponse, error)
 	GenererRapport(ctx context.Context, alerteID string) (*GenerateRapportResponse, error)
 }
 
+// auditResourceType identifies alerts in the audit log
+const auditResourceType = "AlerteSecuritaire"
+
 // service implements alertes service
 type service struct {
-	alerteRepo       repository.AlerteRepository
-	userRepo         repository.UserRepository
-	commissariatRepo repository.CommissariatRepository
-	config           *config.Config
-	logger           *zap.Logger
+	alerteRepo          repository.AlerteRepository
+	userRepo            repository.UserRepository
+	commissariatRepo    repository.CommissariatRepository
+	notificationService notification.Service
+	numberingService    numbering.Service
+	hub                 realtime.Hub
+	config              *config.Config
+	logger              *zap.Logger
 }
 
 // NewService creates a new alertes service
@@ -77,101 +89,29 @@ func NewService(
 	alerteRepo repository.AlerteRepository,
 	userRepo repository.UserRepository,
 	commissariatRepo repository.CommissariatRepository,
+	notificationService notification.Service,
+	numberingService numbering.Service,
+	hub realtime.Hub,
 	cfg *config.Config,
 	logger *zap.Logger,
 ) Service {
 	return &service{
-		alerteRepo:       alerteRepo,
-		userRepo:         userRepo,
-		commissariatRepo: commissariatRepo,
-		config:           cfg,
-		logger:           logger,
+		alerteRepo:          alerteRepo,
+		userRepo:            userRepo,
+		commissariatRepo:    commissariatRepo,
+		notificationService: notificationService,
+		numberingService:    numberingService,
+		hub:                 hub,
+		config:              cfg,
+		logger:              logger,
 	}
 }
 
-// generateNumero génère un numéro unique pour l'alerte avec incrémentation
-func (s *service) generateNumero(ctx context.Context, commissariatID string) (string, error) {
-	// Récupérer le commissariat pour obtenir la ville
-	commissariat, err := s.commissariatRepo.GetByID(ctx, commissariatID)
-	if err != nil {
-		return "", fmt.Errorf("commissariat not found")
-	}
-
-	year := time.Now().Year()
-	
-	// Extraire les 3 premières lettres de la ville en majuscules
-	ville := strings.ToUpper(commissariat.Ville)
-	villePrefix := ville
-	if len(ville) > 3 {
-		villePrefix = ville[:3]
-	} else if len(ville) < 3 {
-		// Si la ville a moins de 3 lettres, compléter avec des X
-		villePrefix = ville + strings.Repeat("X", 3-len(ville))
-	}
-	
-	// Chercher la dernière alerte du commissariat pour cette année
-	startYear := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
-	endYear := time.Date(year, 12, 31, 23, 59, 59, 0, time.UTC)
-	
-	filters := &repository.AlerteFilters{
-		CommissariatID: &commissariatID,
-		DateDebut:      &startYear,
-		DateFin:        &endYear,
-		Limit:          1,
-		Offset:         0,
-	}
-	
-	alertes, err := s.alerteRepo.List(ctx, filters)
-	
-	nextNumber := 1
-	
-	// Si des alertes existent, extraire le dernier numéro
-	if err == nil && len(alertes) > 0 {
-		// Trouver le numéro max en parcourant toutes les alertes de l'année
-		// (car la liste peut ne pas être triée par numéro)
-		maxNum := 0
-		for _, alerte := range alertes {
-			// Format: ALR-VILLE-COM-YYYY-NNNN
-			parts := strings.Split(alerte.Numero, "-")
-			if len(parts) == 5 {
-				if num, err := strconv.Atoi(parts[4]); err == nil && num > maxNum {
-					maxNum = num
-				}
-			}
-		}
-		if maxNum > 0 {
-			nextNumber = maxNum + 1
-		}
-	}
-	
-	// Générer le numéro avec retry pour éviter les collisions
-	maxRetries := 10
-	for retry := 0; retry < maxRetries; retry++ {
-		numero := fmt.Sprintf("ALR-%s-COM-%d-%04d", villePrefix, year, nextNumber+retry)
-		
-		// Vérifier l'unicité
-		_, err := s.alerteRepo.GetByNumero(ctx, numero)
-		if err != nil {
-			// Numéro n'existe pas, c'est bon !
-			s.logger.Info("Generated unique numero", zap.String("numero", numero))
-			return numero, nil
-		}
-		
-		s.logger.Warn("Numero collision detected, retrying", 
-			zap.String("numero", numero), 
-			zap.Int("retry", retry))
-	}
-	
-	// En dernier recours, utiliser un timestamp
-	timestamp := time.Now().UnixNano() % 1000000
-	numero := fmt.Sprintf("ALR-%s-COM-%d-%06d", villePrefix, year, timestamp)
-	s.logger.Warn("Using timestamp-based numero after max retries", zap.String("numero", numero))
-	
-	return numero, nil
-}
-
 // Create creates a new alert
 func (s *service) Create(ctx context.Context, req *CreateAlerteRequest, agentID string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.Create")
+	defer span.End()
+
 	s.logger.Info("Creating alerte", zap.String("titre", req.Titre))
 
 	// Vérifier que l'agent existe
@@ -196,11 +136,15 @@ func (s *service) Create(ctx context.Context, req *CreateAlerteRequest, agentID
 		return nil, fmt.Errorf("commissariat not found")
 	}
 
-	// Générer le numéro unique
-	numero, err := s.generateNumero(ctx, commissariatID)
+	// Réserver le numéro dans la séquence du commissariat
+	resv, err := s.numberingService.Reserve(ctx, &numbering.Request{
+		Type:           numbering.TypeAlerte,
+		CommissariatID: commissariatID,
+	})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to reserve alerte number: %w", err)
 	}
+	defer resv.Rollback()
 
 	// Préparer les données JSONB
 	var personneConcernee, vehicule, suspect map[string]interface{}
@@ -228,7 +172,7 @@ func (s *service) Create(ctx context.Context, req *CreateAlerteRequest, agentID
 
 	input := &repository.CreateAlerteInput{
 		ID:                    uuid.New().String(),
-		Numero:                numero,
+		Numero:                resv.Numero,
 		Titre:                 req.Titre,
 		Description:           req.Description,
 		Contexte:              req.Contexte,
@@ -248,11 +192,15 @@ func (s *service) Create(ctx context.Context, req *CreateAlerteRequest, agentID
 		Observations:          req.Observations,
 	}
 
-	alerte, err := s.alerteRepo.Create(ctx, input)
+	alerte, err := s.alerteRepo.Create(resv.Context(), input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create alerte: %w", err)
 	}
 
+	if err := resv.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to create alerte: %w", err)
+	}
+
 	// Reload with edges
 	alerte, err = s.alerteRepo.GetByID(ctx, alerte.ID.String())
 	if err != nil {
@@ -275,11 +223,21 @@ func (s *service) Create(ctx context.Context, req *CreateAlerteRequest, agentID
 	}
 	alerte, _ = s.alerteRepo.Update(ctx, alerte.ID.String(), updateInput)
 
-	return s.alerteToResponse(alerte), nil
+	resp := s.alerteToResponse(alerte)
+	if resp.CommissariatID == "" {
+		resp.CommissariatID = commissariatID
+	}
+	s.publier(ctx, EventAlerteCreated, resp)
+
+	audittrail.Snapshot(ctx, auditResourceType, resp.ID, nil, resp)
+	return resp, nil
 }
 
 // GetByID gets alert by ID
 func (s *service) GetByID(ctx context.Context, id string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.GetByID")
+	defer span.End()
+
 	alerte, err := s.alerteRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -289,6 +247,9 @@ func (s *service) GetByID(ctx context.Context, id string) (*AlerteResponse, erro
 
 // GetByNumero gets alert by numero
 func (s *service) GetByNumero(ctx context.Context, numero string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.GetByNumero")
+	defer span.End()
+
 	alerte, err := s.alerteRepo.GetByNumero(ctx, numero)
 	if err != nil {
 		return nil, err
@@ -297,14 +258,9 @@ func (s *service) GetByNumero(ctx context.Context, numero string) (*AlerteRespon
 }
 
 // List lists alerts with filters and pagination
-func (s *service) List(ctx context.Context, filters *FilterAlertesRequest, role, userID, commissariatID string) (*ListAlertesResponse, error) {
-	// Set defaults
-	if filters.Limit <= 0 {
-		filters.Limit = 20
-	}
-	if filters.Page <= 0 {
-		filters.Page = 1
-	}
+func (s *service) List(ctx context.Context, filters *FilterAlertesRequest, role, userID, commissariatID string) ([]AlerteResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "alertes.List")
+	defer span.End()
 
 	// Adapter les filtres
 	var statut, typeAlerte, niveau *string
@@ -329,35 +285,40 @@ func (s *service) List(ctx context.Context, filters *FilterAlertesRequest, role,
 		DateDebut:      filters.DateDebut,
 		DateFin:        filters.DateFin,
 		Search:         filters.Search,
-		Limit:          filters.Limit,
-		Offset:         (filters.Page - 1) * filters.Limit,
+		Page:           filters.Page,
 	}
 
 	alertes, err := s.alerteRepo.List(ctx, repoFilters)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list alertes: %w", err)
+		return nil, nil, fmt.Errorf("failed to list alertes: %w", err)
+	}
+
+	alertes, page, err := pagination.Trim(filters.Page, alertes)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	total, err := s.alerteRepo.Count(ctx, repoFilters)
 	if err != nil {
-		return nil, fmt.Errorf("failed to count alertes: %w", err)
+		return nil, nil, fmt.Errorf("failed to count alertes: %w", err)
 	}
+	page.SetTotal(total)
 
 	responses := make([]AlerteResponse, len(alertes))
 	for i, alerte := range alertes {
 		responses[i] = *s.alerteToResponse(alerte)
 	}
 
-	return &ListAlertesResponse{
-		Alertes: responses,
-		Total:   int64(total),
-		Page:    filters.Page,
-		Limit:   filters.Limit,
-	}, nil
+	return responses, page, nil
 }
 
 // Update updates an alert
 func (s *service) Update(ctx context.Context, id string, req *UpdateAlerteRequest) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.Update")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	s.logger.Info("Updating alerte", zap.String("id", id))
 
 	// Préparer les données JSONB si présentes
@@ -457,17 +418,32 @@ func (s *service) Update(ctx context.Context, id string, req *UpdateAlerteReques
 		return nil, fmt.Errorf("failed to reload alerte: %w", err)
 	}
 
-	return s.alerteToResponse(alerte), nil
+	resp := s.alerteToResponse(alerte)
+	audittrail.Snapshot(ctx, auditResourceType, id, before, resp)
+	return resp, nil
 }
 
 // Delete deletes an alert
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "alertes.Delete")
+	defer span.End()
+
 	s.logger.Info("Deleting alerte", zap.String("id", id))
-	return s.alerteRepo.Delete(ctx, id)
+	before := s.snapshot(ctx, id)
+	if err := s.alerteRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+	audittrail.Snapshot(ctx, auditResourceType, id, before, nil)
+	return nil
 }
 
 // AddSuivi ajoute un suivi à une alerte
 func (s *service) AddSuivi(ctx context.Context, id string, req *AddSuiviRequest, agentID string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.AddSuivi")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	s.logger.Info("Adding suivi to alerte", zap.String("id", id))
 
 	// Récupérer l'alerte
@@ -482,12 +458,6 @@ func (s *service) AddSuivi(ctx context.Context, id string, req *AddSuiviRequest,
 		return nil, fmt.Errorf("agent not found")
 	}
 
-	// Récupérer les suivis existants ou initialiser
-	suivis := alerte.Suivis
-	if suivis == nil {
-		suivis = []map[string]interface{}{}
-	}
-
 	// Ajouter le nouveau suivi
 	nouveauSuivi := map[string]interface{}{
 		"date":    time.Now().Format("2006-01-02"),
@@ -497,11 +467,10 @@ func (s *service) AddSuivi(ctx context.Context, id string, req *AddSuiviRequest,
 		"action":  req.Action,
 		"statut":  req.Statut,
 	}
-	suivis = append(suivis, nouveauSuivi)
 
-	// Mettre à jour l'alerte
+	// Mettre à jour l'alerte sans écraser un suivi ajouté entre-temps
 	updateInput := &repository.UpdateAlerteInput{
-		Suivis: suivis,
+		AppendSuivis: []map[string]interface{}{nouveauSuivi},
 	}
 
 	alerte, err = s.alerteRepo.Update(ctx, id, updateInput)
@@ -511,11 +480,18 @@ func (s *service) AddSuivi(ctx context.Context, id string, req *AddSuiviRequest,
 
 	// Reload
 	alerte, _ = s.alerteRepo.GetByID(ctx, id)
-	return s.alerteToResponse(alerte), nil
+	resp := s.alerteToResponse(alerte)
+	audittrail.Snapshot(ctx, auditResourceType, id, before, resp)
+	return resp, nil
 }
 
 // Diffuser diffuse une alerte
 func (s *service) Diffuser(ctx context.Context, id string, req *BroadcastAlerteRequest, agentID string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.Diffuser")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	s.logger.Info("Broadcasting alerte", zap.String("id", id))
 
 	// Vérifier que l'alerte existe
@@ -549,6 +525,7 @@ func (s *service) Diffuser(ctx context.Context, id string, req *BroadcastAlerteR
 	if err != nil {
 		return nil, err
 	}
+	metrics.AlertesBroadcast.Inc()
 
 	// Ajouter un suivi
 	s.AddSuivi(ctx, id, &AddSuiviRequest{
@@ -556,13 +533,119 @@ func (s *service) Diffuser(ctx context.Context, id string, req *BroadcastAlerteR
 		Statut: string(alerte.Statut),
 	}, agentID)
 
+	s.notifierDiffusion(ctx, alerte, req)
+
 	// Reload
 	alerte, _ = s.alerteRepo.GetByID(ctx, id)
-	return s.alerteToResponse(alerte), nil
+	resp := s.alerteToResponse(alerte)
+	s.publier(ctx, EventAlerteBroadcast, resp)
+	audittrail.Snapshot(ctx, auditResourceType, id, before, resp)
+	return resp, nil
+}
+
+// destinatairesDiffusion resolves the active agents targeted by a broadcast
+func (s *service) destinatairesDiffusion(ctx context.Context, req *BroadcastAlerteRequest) ([]*ent.User, error) {
+	active := true
+	if req.DiffusionGenerale != nil && *req.DiffusionGenerale {
+		return s.userRepo.ListWithFilters(ctx, &repository.UserFilters{Active: &active})
+	}
+
+	vus := make(map[string]bool)
+	var agents []*ent.User
+	ajouter := func(u *ent.User) {
+		if u == nil || !u.Active || vus[u.ID.String()] {
+			return
+		}
+		vus[u.ID.String()] = true
+		agents = append(agents, u)
+	}
+
+	for _, commissariatID := range req.CommissariatsIds {
+		cid := commissariatID
+		users, err := s.userRepo.ListWithFilters(ctx, &repository.UserFilters{CommissariatID: &cid, Active: &active})
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range users {
+			ajouter(u)
+		}
+	}
+
+	for _, agentID := range req.AgentsIds {
+		u, err := s.userRepo.GetByID(ctx, agentID)
+		if err != nil {
+			s.logger.Warn("Broadcast recipient not found", zap.String("agent_id", agentID), zap.Error(err))
+			continue
+		}
+		ajouter(u)
+	}
+
+	return agents, nil
+}
+
+// notifierDiffusion pushes the alert to each targeted agent; high and critical
+// alerts are also sent by SMS
+func (s *service) notifierDiffusion(ctx context.Context, alerte *ent.AlerteSecuritaire, req *BroadcastAlerteRequest) {
+	agents, err := s.destinatairesDiffusion(ctx, req)
+	if err != nil {
+		s.logger.Error("Failed to resolve broadcast recipients", zap.String("id", alerte.ID.String()), zap.Error(err))
+		return
+	}
+
+	data := map[string]interface{}{
+		"Numero":      alerte.Numero,
+		"Titre":       alerte.Titre,
+		"Description": alerte.Description,
+		"Niveau":      string(alerte.Niveau),
+	}
+	if alerte.Lieu != nil {
+		data["Lieu"] = *alerte.Lieu
+	}
+	parSMS := alerte.Niveau == alertesecuritaire.NiveauELEVE || alerte.Niveau == alertesecuritaire.NiveauCRITIQUE
+
+	envoyees := 0
+	for _, agent := range agents {
+		requests := []*notification.SendRequest{{
+			Channel:   notification.ChannelPush,
+			Recipient: agent.ID.String(),
+		}}
+		if parSMS && agent.Telephone != "" {
+			requests = append(requests, &notification.SendRequest{
+				Channel:   notification.ChannelSMS,
+				Recipient: agent.Telephone,
+			})
+		}
+
+		for _, r := range requests {
+			r.Template = notification.TemplateAlerteDiffusion
+			r.Data = data
+			r.ResourceType = "AlerteSecuritaire"
+			r.ResourceID = alerte.ID.String()
+			if _, err := s.notificationService.Send(ctx, r); err != nil {
+				s.logger.Warn("Failed to send alerte notification",
+					zap.String("id", alerte.ID.String()),
+					zap.String("agent_id", agent.ID.String()),
+					zap.String("channel", r.Channel),
+					zap.Error(err))
+				continue
+			}
+			envoyees++
+		}
+	}
+
+	s.logger.Info("Alerte notifications queued",
+		zap.String("id", alerte.ID.String()),
+		zap.Int("agents", len(agents)),
+		zap.Int("notifications", envoyees))
 }
 
 // DiffusionInterne diffuse l'alerte aux agents du même commissariat
 func (s *service) DiffusionInterne(ctx context.Context, id string, req *AssignAlerteRequest, commissariatID, agentID string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.DiffusionInterne")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	s.logger.Info("Diffusion interne de l'alerte", zap.String("id", id), zap.String("commissariatID", commissariatID))
 
 	// Récupérer l'alerte
@@ -609,11 +692,19 @@ func (s *service) DiffusionInterne(ctx context.Context, id string, req *AssignAl
 		zap.Bool("generale", req.AssigneeGenerale != nil && *req.AssigneeGenerale),
 		zap.Int("nbAgents", len(req.AgentsIds)))
 	
-	return s.alerteToResponse(alerte), nil
+	resp := s.alerteToResponse(alerte)
+	s.publier(ctx, EventAlerteAssigned, resp)
+	audittrail.Snapshot(ctx, auditResourceType, id, before, resp)
+	return resp, nil
 }
 
 // Assigner assigne une alerte à des agents (commissariats destinataires)
 func (s *service) Assigner(ctx context.Context, id string, req *AssignAlerteRequest, commissariatID, agentID string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.Assigner")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	s.logger.Info("Assigning alerte", zap.String("id", id))
 
 	// Récupérer l'alerte
@@ -659,11 +750,19 @@ func (s *service) Assigner(ctx context.Context, id string, req *AssignAlerteRequ
 
 	// Reload
 	alerte, _ = s.alerteRepo.GetByID(ctx, id)
-	return s.alerteToResponse(alerte), nil
+	resp := s.alerteToResponse(alerte)
+	s.publier(ctx, EventAlerteAssigned, resp)
+	audittrail.Snapshot(ctx, auditResourceType, id, before, resp)
+	return resp, nil
 }
 
 // Resoudre marks an alert as resolved
 func (s *service) Resoudre(ctx context.Context, id string, agentID string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.Resoudre")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	s.logger.Info("Resolving alerte", zap.String("id", id))
 
 	now := time.Now()
@@ -687,11 +786,19 @@ func (s *service) Resoudre(ctx context.Context, id string, agentID string) (*Ale
 
 	// Reload
 	alerte, _ = s.alerteRepo.GetByID(ctx, id)
-	return s.alerteToResponse(alerte), nil
+	resp := s.alerteToResponse(alerte)
+	s.publier(ctx, EventAlerteResolved, resp)
+	audittrail.Snapshot(ctx, auditResourceType, id, before, resp)
+	return resp, nil
 }
 
 // Archiver archives an alert
 func (s *service) Archiver(ctx context.Context, id string, agentID string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.Archiver")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	s.logger.Info("Archiving alerte", zap.String("id", id))
 
 	statut := string(StatutAlerteArchivee)
@@ -713,11 +820,18 @@ func (s *service) Archiver(ctx context.Context, id string, agentID string) (*Ale
 
 	// Reload
 	alerte, _ = s.alerteRepo.GetByID(ctx, id)
-	return s.alerteToResponse(alerte), nil
+	resp := s.alerteToResponse(alerte)
+	audittrail.Snapshot(ctx, auditResourceType, id, before, resp)
+	return resp, nil
 }
 
 // Cloturer clôture une alerte (résolu + archivé)
 func (s *service) Cloturer(ctx context.Context, id string, agentID string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.Cloturer")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	s.logger.Info("Closing alerte", zap.String("id", id))
 
 	now := time.Now()
@@ -742,11 +856,19 @@ func (s *service) Cloturer(ctx context.Context, id string, agentID string) (*Ale
 
 	// Reload
 	alerte, _ = s.alerteRepo.GetByID(ctx, id)
-	return s.alerteToResponse(alerte), nil
+	resp := s.alerteToResponse(alerte)
+	s.publier(ctx, EventAlerteResolved, resp)
+	audittrail.Snapshot(ctx, auditResourceType, id, before, resp)
+	return resp, nil
 }
 
 // DeployIntervention déploie une intervention
 func (s *service) DeployIntervention(ctx context.Context, id string, req *DeployInterventionRequest, agentID string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.DeployIntervention")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	s.logger.Info("Deploying intervention", zap.String("id", id))
 
 	intervention := map[string]interface{}{
@@ -773,11 +895,19 @@ func (s *service) DeployIntervention(ctx context.Context, id string, req *Deploy
 
 	// Reload
 	alerte, _ = s.alerteRepo.GetByID(ctx, id)
-	return s.alerteToResponse(alerte), nil
+	resp := s.alerteToResponse(alerte)
+	s.publier(ctx, EventAlerteIntervention, resp)
+	audittrail.Snapshot(ctx, auditResourceType, id, before, resp)
+	return resp, nil
 }
 
 // UpdateIntervention met à jour une intervention
 func (s *service) UpdateIntervention(ctx context.Context, id string, req *UpdateInterventionRequest, agentID string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.UpdateIntervention")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	s.logger.Info("Updating intervention", zap.String("id", id))
 
 	// Récupérer l'alerte pour obtenir l'intervention existante
@@ -823,11 +953,19 @@ func (s *service) UpdateIntervention(ctx context.Context, id string, req *Update
 
 	// Reload
 	alerte, _ = s.alerteRepo.GetByID(ctx, id)
-	return s.alerteToResponse(alerte), nil
+	resp := s.alerteToResponse(alerte)
+	s.publier(ctx, EventAlerteIntervention, resp)
+	audittrail.Snapshot(ctx, auditResourceType, id, before, resp)
+	return resp, nil
 }
 
 // AddEvaluation ajoute une évaluation
 func (s *service) AddEvaluation(ctx context.Context, id string, req *AddEvaluationRequest, agentID string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.AddEvaluation")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	s.logger.Info("Adding evaluation", zap.String("id", id))
 
 	evaluation := structToMap(req)
@@ -849,11 +987,18 @@ func (s *service) AddEvaluation(ctx context.Context, id string, req *AddEvaluati
 
 	// Reload
 	alerte, _ = s.alerteRepo.GetByID(ctx, id)
-	return s.alerteToResponse(alerte), nil
+	resp := s.alerteToResponse(alerte)
+	audittrail.Snapshot(ctx, auditResourceType, id, before, resp)
+	return resp, nil
 }
 
 // AddRapport ajoute un rapport final
 func (s *service) AddRapport(ctx context.Context, id string, req *AddRapportRequest, agentID string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.AddRapport")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	s.logger.Info("Adding rapport", zap.String("id", id))
 
 	rapport := structToMap(req)
@@ -875,11 +1020,18 @@ func (s *service) AddRapport(ctx context.Context, id string, req *AddRapportRequ
 
 	// Reload
 	alerte, _ = s.alerteRepo.GetByID(ctx, id)
-	return s.alerteToResponse(alerte), nil
+	resp := s.alerteToResponse(alerte)
+	audittrail.Snapshot(ctx, auditResourceType, id, before, resp)
+	return resp, nil
 }
 
 // AddTemoin ajoute un témoin
 func (s *service) AddTemoin(ctx context.Context, id string, req *AddTemoinRequest, agentID string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.AddTemoin")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	s.logger.Info("Adding temoin", zap.String("id", id))
 
 	// Récupérer l'alerte
@@ -888,17 +1040,9 @@ func (s *service) AddTemoin(ctx context.Context, id string, req *AddTemoinReques
 		return nil, err
 	}
 
-	// Récupérer les témoins existants
-	temoins := alerte.Temoins
-	if temoins == nil {
-		temoins = []map[string]interface{}{}
-	}
-
 	// Ajouter le nouveau témoin
-	temoins = append(temoins, structToMap(req))
-
 	updateInput := &repository.UpdateAlerteInput{
-		Temoins: temoins,
+		AppendTemoins: []map[string]interface{}{structToMap(req)},
 	}
 
 	alerte, err = s.alerteRepo.Update(ctx, id, updateInput)
@@ -908,11 +1052,18 @@ func (s *service) AddTemoin(ctx context.Context, id string, req *AddTemoinReques
 
 	// Reload
 	alerte, _ = s.alerteRepo.GetByID(ctx, id)
-	return s.alerteToResponse(alerte), nil
+	resp := s.alerteToResponse(alerte)
+	audittrail.Snapshot(ctx, auditResourceType, id, before, resp)
+	return resp, nil
 }
 
 // AddDocument ajoute un document
 func (s *service) AddDocument(ctx context.Context, id string, req *AddDocumentRequest, agentID string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.AddDocument")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	s.logger.Info("Adding document", zap.String("id", id))
 
 	// Récupérer l'alerte
@@ -921,17 +1072,9 @@ func (s *service) AddDocument(ctx context.Context, id string, req *AddDocumentRe
 		return nil, err
 	}
 
-	// Récupérer les documents existants
-	documents := alerte.Documents
-	if documents == nil {
-		documents = []map[string]interface{}{}
-	}
-
 	// Ajouter le nouveau document
-	documents = append(documents, structToMap(req))
-
 	updateInput := &repository.UpdateAlerteInput{
-		Documents: documents,
+		AppendDocuments: []map[string]interface{}{structToMap(req)},
 	}
 
 	alerte, err = s.alerteRepo.Update(ctx, id, updateInput)
@@ -941,11 +1084,18 @@ func (s *service) AddDocument(ctx context.Context, id string, req *AddDocumentRe
 
 	// Reload
 	alerte, _ = s.alerteRepo.GetByID(ctx, id)
-	return s.alerteToResponse(alerte), nil
+	resp := s.alerteToResponse(alerte)
+	audittrail.Snapshot(ctx, auditResourceType, id, before, resp)
+	return resp, nil
 }
 
 // AddPhotos ajoute des photos
 func (s *service) AddPhotos(ctx context.Context, id string, photos []string, agentID string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.AddPhotos")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	s.logger.Info("Adding photos", zap.String("id", id))
 
 	// Récupérer l'alerte
@@ -954,17 +1104,9 @@ func (s *service) AddPhotos(ctx context.Context, id string, photos []string, age
 		return nil, err
 	}
 
-	// Récupérer les photos existantes
-	existingPhotos := alerte.Photos
-	if existingPhotos == nil {
-		existingPhotos = []string{}
-	}
-
 	// Ajouter les nouvelles photos
-	existingPhotos = append(existingPhotos, photos...)
-
 	updateInput := &repository.UpdateAlerteInput{
-		Photos: existingPhotos,
+		AppendPhotos: photos,
 	}
 
 	alerte, err = s.alerteRepo.Update(ctx, id, updateInput)
@@ -974,11 +1116,18 @@ func (s *service) AddPhotos(ctx context.Context, id string, photos []string, age
 
 	// Reload
 	alerte, _ = s.alerteRepo.GetByID(ctx, id)
-	return s.alerteToResponse(alerte), nil
+	resp := s.alerteToResponse(alerte)
+	audittrail.Snapshot(ctx, auditResourceType, id, before, resp)
+	return resp, nil
 }
 
 // UpdateActions met à jour les actions
 func (s *service) UpdateActions(ctx context.Context, id string, req *UpdateActionsRequest, agentID string) (*AlerteResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.UpdateActions")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	s.logger.Info("Updating actions", zap.String("id", id))
 
 	// Construire actions en s'assurant que tous les champs sont présents (même vides)
@@ -1010,46 +1159,52 @@ func (s *service) UpdateActions(ctx context.Context, id string, req *UpdateActio
 
 	// Reload
 	alerte, _ = s.alerteRepo.GetByID(ctx, id)
-	return s.alerteToResponse(alerte), nil
+	resp := s.alerteToResponse(alerte)
+	audittrail.Snapshot(ctx, auditResourceType, id, before, resp)
+	return resp, nil
 }
 
-// GetActives gets active alerts
-func (s *service) GetActives(ctx context.Context, commissariatID *string) ([]*AlerteResponse, error) {
-	var alertes []*ent.AlerteSecuritaire
-	var err error
+// GetActives gets a page of active alerts, optionally filtered by commissariat
+func (s *service) GetActives(ctx context.Context, commissariatID *string, params *pagination.Params) ([]*AlerteResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "alertes.GetActives")
+	defer span.End()
 
-	if commissariatID != nil {
-		// Filtrer par commissariat si spécifié
-		filters := &repository.AlerteFilters{
-			CommissariatID: commissariatID,
-		}
-		allAlertes, err := s.alerteRepo.List(ctx, filters)
+	statut := alertesecuritaire.StatutACTIVE.String()
+	repoFilters := &repository.AlerteFilters{
+		Statut:         &statut,
+		CommissariatID: commissariatID,
+		Page:           params,
+	}
+
+	alertes, err := s.alerteRepo.List(ctx, repoFilters)
 	if err != nil {
-			return nil, err
-		}
-		// Filtrer les actives
-		for _, a := range allAlertes {
-			if a.Statut == alertesecuritaire.StatutACTIVE {
-				alertes = append(alertes, a)
-			}
-		}
-	} else {
-		alertes, err = s.alerteRepo.GetActives(ctx)
+		return nil, nil, fmt.Errorf("failed to list active alertes: %w", err)
+	}
+
+	alertes, page, err := pagination.Trim(params, alertes)
 	if err != nil {
-			return nil, err
-		}
+		return nil, nil, err
 	}
 
+	total, err := s.alerteRepo.Count(ctx, repoFilters)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count active alertes: %w", err)
+	}
+	page.SetTotal(total)
+
 	responses := make([]*AlerteResponse, len(alertes))
 	for i, alerte := range alertes {
 		responses[i] = s.alerteToResponse(alerte)
 	}
 
-	return responses, nil
+	return responses, page, nil
 }
 
 // GetStatistiques gets alert statistics
 func (s *service) GetStatistiques(ctx context.Context, commissariatID *string, dateDebut, dateFin, periode *string) (*StatistiquesAlertesResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.GetStatistiques")
+	defer span.End()
+
 	var debut, fin *time.Time
 	
 	if dateDebut != nil {
@@ -1102,8 +1257,12 @@ func (s *service) GetStatistiques(ctx context.Context, commissariatID *string, d
 }
 
 // GenererDescription génère une description avec IA OpenAI
-func (s *service) GenererDescription(ctx context.Context, req *GenerateDescriptionRequest) (*GenerateDescriptionResponse, error) {
+func (s *service) GenererDescription(ctx context.Context, req *GenerateDescriptionRequest) (_ *GenerateDescriptionResponse, err error) {
+	ctx, span := tracing.Start(ctx, "alertes.GenererDescription")
+	defer span.End()
+
 	s.logger.Info("Generating description with AI", zap.String("type", string(req.Type)))
+	defer func() { metrics.ObserveAIGeneration("description", err) }()
 
 	// Vérifier la clé OpenAI
 	var openaiKey string
@@ -1153,7 +1312,7 @@ func (s *service) GenererDescription(ctx context.Context, req *GenerateDescripti
 	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", openaiKey))
 	httpReq.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := &http.Client{Timeout: 30 * time.Second, Transport: tracing.Transport()}
 	resp, err := client.Do(httpReq)
 	if err != nil {
 		s.logger.Error("Failed to call OpenAI API", zap.Error(err))
@@ -1235,8 +1394,12 @@ func (s *service) GenererDescription(ctx context.Context, req *GenerateDescripti
 }
 
 // GenererRapport génère un rapport complet avec IA OpenAI
-func (s *service) GenererRapport(ctx context.Context, alerteID string) (*GenerateRapportResponse, error) {
+func (s *service) GenererRapport(ctx context.Context, alerteID string) (_ *GenerateRapportResponse, err error) {
+	ctx, span := tracing.Start(ctx, "alertes.GenererRapport")
+	defer span.End()
+
 	s.logger.Info("Generating rapport with AI", zap.String("alerteId", alerteID))
+	defer func() { metrics.ObserveAIGeneration("rapport", err) }()
 
 	// Vérifier la clé OpenAI
 	var openaiKey string
@@ -1290,7 +1453,7 @@ func (s *service) GenererRapport(ctx context.Context, alerteID string) (*Generat
 	httpReq.Header.Set("Authorization", fmt.Sprintf("Bearer %s", openaiKey))
 	httpReq.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := &http.Client{Timeout: 30 * time.Second, Transport: tracing.Transport()}
 	resp, err := client.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("erreur lors de l'appel à l'API OpenAI: %v", err)
@@ -1693,6 +1856,16 @@ Sois professionnel, objectif et factuel. Utilise un ton officiel adapté à un r
 
 // Helper functions
 
+// snapshot returns the state of an alert before a mutation, for the audit
+// log; nil when it cannot be read, the mutation then reports the error
+func (s *service) snapshot(ctx context.Context, id string) *AlerteResponse {
+	alerte, err := s.alerteRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return s.alerteToResponse(alerte)
+}
+
 func (s *service) alerteToResponse(alerte *ent.AlerteSecuritaire) *AlerteResponse {
 	resp := &AlerteResponse{
 		ID:          alerte.ID.String(),
@@ -1881,6 +2054,9 @@ func (s *service) alerteToResponse(alerte *ent.AlerteSecuritaire) *AlerteRespons
 
 // GetDashboard gets dashboard data for alerts
 func (s *service) GetDashboard(ctx context.Context, commissariatID *string, dateDebut, dateFin, periode *string) (*DashboardResponse, error) {
+	ctx, span := tracing.Start(ctx, "alertes.GetDashboard")
+	defer span.End()
+
 	var debut, fin *time.Time
 	
 	if dateDebut != nil {
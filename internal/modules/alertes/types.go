@@ -1,6 +1,11 @@
 package alertes
 
-import "time"
+import (
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+)
 
 // TypeAlerte représente les types d'alertes
 type TypeAlerte string
@@ -226,8 +231,31 @@ type FilterAlertesRequest struct {
 	CommissariatID *string        `json:"commissariatId,omitempty" validate:"omitempty,uuid"`
 	Search         *string        `json:"search,omitempty"`
 	Diffusee       *bool          `json:"diffusee,omitempty"`
-	Page           int            `json:"page,omitempty" validate:"omitempty,min=1"`
-	Limit          int            `json:"limit,omitempty" validate:"omitempty,min=1"`
+	Page           *pagination.Params `json:"-"`
+}
+
+// listSpec declares the sorts and fields accepted by GET /alertes and
+// GET /alertes/actives
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"dateAlerte": "date_alerte",
+		"createdAt":  "created_at",
+		"niveau":     "niveau",
+		"statut":     "statut",
+	},
+	DefaultSort: "-dateAlerte",
+	Fields:      pagination.JSONFields(AlerteResponse{}),
+	Filters: filter.Fields{
+		"titre":          {Column: "titre", Type: filter.String},
+		"niveau":         {Column: "niveau", Type: filter.String},
+		"statut":         {Column: "statut", Type: filter.String},
+		"typeAlerte":     {Column: "type_alerte", Type: filter.String},
+		"localisation":   {Column: "localisation", Type: filter.String},
+		"diffusee":       {Column: "diffusee", Type: filter.Bool},
+		"dateAlerte":     {Column: "date_alerte", Type: filter.Time},
+		"dateResolution": {Column: "date_resolution", Type: filter.Time},
+		"createdAt":      {Column: "created_at", Type: filter.Time},
+	},
 }
 
 // AddSuiviRequest représente l'ajout d'un suivi
@@ -381,14 +409,6 @@ type AlerteResponse struct {
 	UpdatedAt                time.Time                           `json:"updatedAt"`
 }
 
-// ListAlertesResponse représente la réponse de liste avec pagination
-type ListAlertesResponse struct {
-	Alertes []AlerteResponse `json:"alertes"`
-	Total   int64            `json:"total"`
-	Page    int              `json:"page"`
-	Limit   int              `json:"limit"`
-}
-
 // StatistiquesAlertesResponse représente les statistiques
 type StatistiquesAlertesResponse struct {
 	Total              int64                   `json:"total"`
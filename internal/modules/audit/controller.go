@@ -0,0 +1,152 @@
+package audit
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+	"police-trafic-api-frontend-aligned/internal/shared/responses"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Controller handles audit log HTTP requests
+type Controller struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewController creates a new audit controller
+func NewController(service Service, logger *zap.Logger) *Controller {
+	return &Controller{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes registers audit routes
+func (ctrl *Controller) RegisterRoutes(e *echo.Group) {
+	audit := rbac.Guard(e).Group("/admin/audit")
+
+	audit.GET("", ctrl.List, rbac.PermReadAudit)
+	audit.GET("/export", ctrl.Export, rbac.PermReadAudit)
+	audit.GET("/:id", ctrl.GetByID, rbac.PermReadAudit)
+}
+
+// List handles GET /admin/audit
+func (ctrl *Controller) List(c echo.Context) error {
+	req, err := parseFilters(c)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
+	// Pagination, tri et champs
+	page, err := pagination.Parse(c, listSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+	req.Page = page
+
+	logs, result, err := ctrl.service.List(c.Request().Context(), req)
+	if err != nil {
+		return responses.InternalServerError(c, err.Error())
+	}
+
+	return responses.Paginated(c, logs, result)
+}
+
+// GetByID handles GET /admin/audit/:id
+func (ctrl *Controller) GetByID(c echo.Context) error {
+	id := c.Param("id")
+	if id == "" {
+		return responses.BadRequest(c, "ID is required")
+	}
+
+	entry, err := ctrl.service.GetByID(c.Request().Context(), id)
+	if err != nil {
+		if err.Error() == "audit log not found" {
+			return responses.NotFound(c, "Audit log not found")
+		}
+		return responses.InternalServerError(c, err.Error())
+	}
+
+	return responses.Success(c, entry)
+}
+
+// Export handles GET /admin/audit/export (CSV)
+func (ctrl *Controller) Export(c echo.Context) error {
+	req, err := parseFilters(c)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
+	filename := fmt.Sprintf("audit_%s.csv", time.Now().Format("20060102_150405"))
+	c.Response().Header().Set(echo.HeaderContentType, "text/csv; charset=utf-8")
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	c.Response().WriteHeader(http.StatusOK)
+
+	rows, err := ctrl.service.ExportCSV(c.Request().Context(), req, c.Response())
+	if err != nil {
+		// Les en-têtes sont déjà envoyés : on ne peut que journaliser
+		ctrl.logger.Error("Audit export failed", zap.Int("rows", rows), zap.Error(err))
+		return nil
+	}
+
+	ctrl.logger.Info("Audit export completed", zap.Int("rows", rows))
+	return nil
+}
+
+func parseFilters(c echo.Context) (*ListAuditLogsRequest, error) {
+	req := &ListAuditLogsRequest{}
+
+	if userID := c.QueryParam("userId"); userID != "" {
+		req.UserID = &userID
+	}
+	if action := c.QueryParam("action"); action != "" {
+		req.Action = &action
+	}
+	if resourceType := c.QueryParam("resourceType"); resourceType != "" {
+		req.ResourceType = &resourceType
+	}
+	if resourceID := c.QueryParam("resourceId"); resourceID != "" {
+		req.ResourceID = &resourceID
+	}
+	if status := c.QueryParam("status"); status != "" {
+		req.Status = &status
+	}
+	if ip := c.QueryParam("ipAddress"); ip != "" {
+		req.IPAddress = &ip
+	}
+
+	if dateDebut := c.QueryParam("dateDebut"); dateDebut != "" {
+		t, _, err := parseDate(dateDebut)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dateDebut: %s", dateDebut)
+		}
+		req.DateDebut = &t
+	}
+	if dateFin := c.QueryParam("dateFin"); dateFin != "" {
+		t, dateOnly, err := parseDate(dateFin)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dateFin: %s", dateFin)
+		}
+		// Une date sans heure inclut toute la journée
+		if dateOnly {
+			t = t.Add(24*time.Hour - time.Nanosecond)
+		}
+		req.DateFin = &t
+	}
+
+	return req, nil
+}
+
+func parseDate(value string) (time.Time, bool, error) {
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	return t, false, err
+}
@@ -0,0 +1,19 @@
+package audit
+
+import (
+	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+
+	"go.uber.org/fx"
+)
+
+// Module provides audit module dependencies
+var Module = fx.Module("audit",
+	fx.Provide(
+		NewService,
+		fx.Annotate(
+			NewController,
+			fx.As(new(interfaces.Controller)),
+			fx.ResultTags(`group:"controllers"`),
+		),
+	),
+)
@@ -0,0 +1,226 @@
+package audit
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+
+	"go.uber.org/zap"
+)
+
+const (
+	exportBatchSize = 500
+	maxExportRows   = 100000
+)
+
+// Service defines audit query service interface
+type Service interface {
+	List(ctx context.Context, req *ListAuditLogsRequest) ([]*AuditLogResponse, *pagination.Page, error)
+	GetByID(ctx context.Context, id string) (*AuditLogResponse, error)
+	ExportCSV(ctx context.Context, req *ListAuditLogsRequest, w io.Writer) (int, error)
+}
+
+type service struct {
+	auditRepo repository.AuditLogRepository
+	logger    *zap.Logger
+}
+
+// NewService creates a new audit query service
+func NewService(auditRepo repository.AuditLogRepository, logger *zap.Logger) Service {
+	return &service{
+		auditRepo: auditRepo,
+		logger:    logger,
+	}
+}
+
+// List returns a page of audit entries and the total matching count
+func (s *service) List(ctx context.Context, req *ListAuditLogsRequest) ([]*AuditLogResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "audit.List")
+	defer span.End()
+
+	filters := s.buildFilters(req)
+	filters.Page = req.Page
+
+	entries, err := s.auditRepo.List(ctx, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	entries, page, err := pagination.Trim(req.Page, entries)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	total, err := s.auditRepo.Count(ctx, filters)
+	if err != nil {
+		return nil, nil, err
+	}
+	page.SetTotal(total)
+
+	result := make([]*AuditLogResponse, len(entries))
+	for i, entry := range entries {
+		result[i] = toResponse(entry)
+	}
+
+	return result, page, nil
+}
+
+// GetByID returns a single audit entry
+func (s *service) GetByID(ctx context.Context, id string) (*AuditLogResponse, error) {
+	ctx, span := tracing.Start(ctx, "audit.GetByID")
+	defer span.End()
+
+	entry, err := s.auditRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return toResponse(entry), nil
+}
+
+// ExportCSV streams every matching entry as CSV and returns the number of rows written
+func (s *service) ExportCSV(ctx context.Context, req *ListAuditLogsRequest, w io.Writer) (int, error) {
+	ctx, span := tracing.Start(ctx, "audit.ExportCSV")
+	defer span.End()
+
+	writer := csv.NewWriter(w)
+	// Séparateur ";" pour une ouverture directe dans Excel (locale française)
+	writer.Comma = ';'
+
+	header := []string{
+		"id", "timestamp", "action", "resource_type", "resource_id",
+		"user_id", "matricule", "role", "ip_address", "session_id",
+		"status", "error_message", "old_values", "new_values",
+	}
+	if err := writer.Write(header); err != nil {
+		return 0, fmt.Errorf("failed to write csv header: %w", err)
+	}
+
+	filters := s.buildFilters(req)
+	filters.Limit = exportBatchSize
+
+	rows := 0
+	for rows < maxExportRows {
+		entries, err := s.auditRepo.List(ctx, filters)
+		if err != nil {
+			return rows, err
+		}
+
+		for _, entry := range entries {
+			r := toResponse(entry)
+			record := []string{
+				r.ID,
+				r.Timestamp.Format(time.RFC3339),
+				r.Action,
+				r.ResourceType,
+				r.ResourceID,
+				r.UserID,
+				r.Matricule,
+				r.Role,
+				r.IPAddress,
+				r.SessionID,
+				r.Status,
+				r.ErrorMessage,
+				string(r.OldValues),
+				string(r.NewValues),
+			}
+			for i, cell := range record {
+				record[i] = csvCell(cell)
+			}
+			if err := writer.Write(record); err != nil {
+				return rows, fmt.Errorf("failed to write csv row: %w", err)
+			}
+			rows++
+		}
+
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return rows, fmt.Errorf("failed to flush csv: %w", err)
+		}
+
+		if len(entries) < exportBatchSize {
+			break
+		}
+		last := entries[len(entries)-1]
+		filters.After = &repository.AuditLogKey{Timestamp: last.Timestamp, ID: last.ID}
+	}
+
+	if rows >= maxExportRows {
+		s.logger.Warn("Audit export truncated", zap.Int("rows", rows))
+	}
+
+	return rows, nil
+}
+
+// csvCell neutralises a value a spreadsheet would evaluate as a formula
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func (s *service) buildFilters(req *ListAuditLogsRequest) *repository.AuditLogFilters {
+	return &repository.AuditLogFilters{
+		UserID:       req.UserID,
+		Action:       req.Action,
+		ResourceType: req.ResourceType,
+		ResourceID:   req.ResourceID,
+		Status:       req.Status,
+		IPAddress:    req.IPAddress,
+		DateDebut:    req.DateDebut,
+		DateFin:      req.DateFin,
+	}
+}
+
+func toResponse(entry *ent.AuditLog) *AuditLogResponse {
+	response := &AuditLogResponse{
+		ID:           entry.ID.String(),
+		Timestamp:    entry.Timestamp,
+		Action:       entry.Action,
+		ResourceType: entry.ResourceType,
+		ResourceID:   entry.ResourceID,
+		IPAddress:    entry.IPAddress,
+		UserAgent:    entry.UserAgent,
+		SessionID:    entry.SessionID,
+		Status:       entry.Status,
+		ErrorMessage: entry.ErrorMessage,
+		Details:      rawJSON(entry.Details),
+		OldValues:    rawJSON(entry.OldValues),
+		NewValues:    rawJSON(entry.NewValues),
+	}
+
+	var details auditDetails
+	if entry.Details != "" && json.Unmarshal([]byte(entry.Details), &details) == nil {
+		response.Matricule = details.Matricule
+		response.Role = details.Role
+	}
+
+	if entry.Edges.User != nil {
+		response.UserID = entry.Edges.User.ID.String()
+		response.Matricule = entry.Edges.User.Matricule
+		response.NomComplet = entry.Edges.User.Prenom + " " + entry.Edges.User.Nom
+	}
+
+	return response
+}
+
+// rawJSON returns stored JSON as-is, or quotes it if the column holds plain text
+func rawJSON(value string) json.RawMessage {
+	if value == "" {
+		return nil
+	}
+	if json.Valid([]byte(value)) {
+		return json.RawMessage(value)
+	}
+	return json.RawMessage(strconv.Quote(value))
+}
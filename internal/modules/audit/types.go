@@ -0,0 +1,73 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+)
+
+// ListAuditLogsRequest represents filters for querying the audit log
+type ListAuditLogsRequest struct {
+	UserID       *string            `json:"userId,omitempty"` // UUID ou matricule
+	Action       *string            `json:"action,omitempty"`
+	ResourceType *string            `json:"resourceType,omitempty"`
+	ResourceID   *string            `json:"resourceId,omitempty"`
+	Status       *string            `json:"status,omitempty"`
+	IPAddress    *string            `json:"ipAddress,omitempty"`
+	DateDebut    *time.Time         `json:"dateDebut,omitempty"`
+	DateFin      *time.Time         `json:"dateFin,omitempty"`
+	Page         *pagination.Params `json:"-"`
+}
+
+// listSpec declares the sorts and fields accepted by GET /admin/audit
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"timestamp":    "timestamp",
+		"action":       "action",
+		"resourceType": "resource_type",
+		"status":       "status",
+	},
+	DefaultSort: "-timestamp",
+	Fields:      pagination.JSONFields(AuditLogResponse{}),
+	Filters: filter.Fields{
+		"action":       {Column: "action", Type: filter.String},
+		"resourceType": {Column: "resource_type", Type: filter.String},
+		"resourceId":   {Column: "resource_id", Type: filter.String},
+		"status":       {Column: "status", Type: filter.String},
+		"ipAddress":    {Column: "ip_address", Type: filter.String},
+		"timestamp":    {Column: "timestamp", Type: filter.Time},
+	},
+}
+
+// AuditLogResponse represents an audit log entry
+type AuditLogResponse struct {
+	ID           string          `json:"id"`
+	Timestamp    time.Time       `json:"timestamp"`
+	Action       string          `json:"action"`
+	ResourceType string          `json:"resourceType"`
+	ResourceID   string          `json:"resourceId,omitempty"`
+	UserID       string          `json:"userId,omitempty"`
+	Matricule    string          `json:"matricule,omitempty"`
+	NomComplet   string          `json:"nomComplet,omitempty"`
+	Role         string          `json:"role,omitempty"`
+	IPAddress    string          `json:"ipAddress,omitempty"`
+	UserAgent    string          `json:"userAgent,omitempty"`
+	SessionID    string          `json:"sessionId,omitempty"`
+	Status       string          `json:"status"`
+	ErrorMessage string          `json:"errorMessage,omitempty"`
+	Details      json.RawMessage `json:"details,omitempty"`
+	OldValues    json.RawMessage `json:"oldValues,omitempty"`
+	NewValues    json.RawMessage `json:"newValues,omitempty"`
+}
+
+// auditDetails mirrors the JSON written by the audit middleware in the details column
+type auditDetails struct {
+	Method         string `json:"method"`
+	Path           string `json:"path"`
+	StatusCode     int    `json:"status_code"`
+	Matricule      string `json:"matricule"`
+	Role           string `json:"role"`
+	CommissariatID string `json:"commissariat_id"`
+}
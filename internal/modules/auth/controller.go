@@ -1,8 +1,18 @@
 package auth
 
 import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"path"
+	"strconv"
 	"strings"
 
+	"police-trafic-api-frontend-aligned/internal/infrastructure/loginguard"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/mfa"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/oidc"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/passwords"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 	"police-trafic-api-frontend-aligned/internal/shared/responses"
 	"police-trafic-api-frontend-aligned/internal/shared/utils"
 
@@ -10,6 +20,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// oidcStateCookie binds an OpenID Connect login to the browser that started it
+const oidcStateCookie = "oidc_state"
+
 // Controller handles auth HTTP requests
 type Controller struct {
 	service Service
@@ -34,6 +47,24 @@ func (ctrl *Controller) RegisterRoutes(e *echo.Group) {
 	auth.GET("/me", ctrl.GetCurrentUser)
 	auth.GET("/sessions", ctrl.GetSessions)
 	auth.DELETE("/sessions/:id", ctrl.RevokeSession)
+
+	// Second factor (TOTP)
+	auth.GET("/mfa", ctrl.GetMFAStatus)
+	auth.POST("/mfa/verify", ctrl.VerifyMFA)
+	auth.POST("/mfa/enroll", ctrl.BeginMFAEnrollment)
+	auth.POST("/mfa/enroll/confirm", ctrl.ConfirmMFAEnrollment)
+	auth.POST("/mfa/recovery-codes", ctrl.RegenerateRecoveryCodes)
+	auth.POST("/mfa/disable", ctrl.DisableMFA)
+
+	// Password lifecycle
+	auth.POST("/password/change", ctrl.ChangePassword)
+	auth.POST("/password/forgot", ctrl.ForgotPassword)
+	auth.POST("/password/reset", ctrl.ResetPassword)
+
+	// OpenID Connect
+	auth.GET("/oidc", ctrl.GetOIDCConfig)
+	auth.POST("/oidc/authorize", ctrl.BeginOIDCLogin)
+	auth.POST("/oidc/callback", ctrl.OIDCCallback)
 }
 
 // Register handles user registration
@@ -64,7 +95,7 @@ func (ctrl *Controller) Register(c echo.Context) error {
 		if strings.Contains(err.Error(), "already exists") {
 			return responses.Conflict(c, err.Error())
 		}
-		return responses.Error(c, err)
+		return ctrl.authError(c, err)
 	}
 
 	return c.JSON(201, responses.SuccessResponse{
@@ -76,6 +107,8 @@ func (ctrl *Controller) Register(c echo.Context) error {
 // Login handles user login
 // @Summary User login
 // @Description Authenticate user with matricule and password. For mobile apps, include device info to get refresh token.
+// @Description If a second factor is needed, the response only holds an mfa_token for /auth/mfa/verify or /auth/mfa/enroll.
+// @Description If the password is temporary or expired, the response only holds a password_token for /auth/password/change.
 // @Tags auth
 // @Accept json
 // @Produce json
@@ -83,6 +116,7 @@ func (ctrl *Controller) Register(c echo.Context) error {
 // @Success 200 {object} LoginResponse
 // @Failure 400 {object} responses.ErrorResponse
 // @Failure 401 {object} responses.ErrorResponse
+// @Failure 429 {object} responses.ErrorResponse
 // @Router /auth/login [post]
 func (ctrl *Controller) Login(c echo.Context) error {
 	var req LoginRequest
@@ -107,7 +141,7 @@ func (ctrl *Controller) Login(c echo.Context) error {
 
 	response, err := ctrl.service.Login(req, ipAddress)
 	if err != nil {
-		return responses.Error(c, err)
+		return ctrl.authError(c, err)
 	}
 
 	return responses.Success(c, response)
@@ -218,12 +252,18 @@ func (ctrl *Controller) GetSessions(c echo.Context) error {
 		return responses.Unauthorized(c, "Authorization token required")
 	}
 
+	page, err := pagination.Parse(c, sessionSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
 	sessions, err := ctrl.service.GetUserSessions(token)
 	if err != nil {
 		return responses.Error(c, err)
 	}
 
-	return responses.Success(c, sessions)
+	sessions, result := pagination.Slice(page, sessions)
+	return responses.Paginated(c, sessions, result)
 }
 
 // RevokeSession revokes a specific session
@@ -259,6 +299,417 @@ func (ctrl *Controller) RevokeSession(c echo.Context) error {
 	return responses.SuccessWithMessage(c, "Session revoked", nil)
 }
 
+// GetMFAStatus handles GET /auth/mfa
+// @Summary Get second factor status
+// @Description Tell whether TOTP is enabled, required for the role, and how many recovery codes are left
+// @Tags auth
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} mfa.Status
+// @Failure 401 {object} responses.ErrorResponse
+// @Router /auth/mfa [get]
+func (ctrl *Controller) GetMFAStatus(c echo.Context) error {
+	token := ctrl.extractToken(c)
+	if token == "" {
+		return responses.Unauthorized(c, "Authorization token required")
+	}
+
+	status, err := ctrl.service.GetMFAStatus(token)
+	if err != nil {
+		return ctrl.authError(c, err)
+	}
+
+	return responses.Success(c, status)
+}
+
+// VerifyMFA handles the second login step
+// @Summary Verify second factor
+// @Description Complete a login with the TOTP code, or a single-use recovery code
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body MFAVerifyRequest true "MFA token returned by /auth/login and code"
+// @Success 200 {object} LoginResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 429 {object} responses.ErrorResponse
+// @Router /auth/mfa/verify [post]
+func (ctrl *Controller) VerifyMFA(c echo.Context) error {
+	var req MFAVerifyRequest
+	if err := c.Bind(&req); err != nil {
+		return responses.BadRequest(c, "Invalid request format")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return responses.BadRequest(c, "Validation failed: "+err.Error())
+	}
+	if req.Code == "" && req.RecoveryCode == "" {
+		return responses.BadRequest(c, "Code or recovery_code is required")
+	}
+
+	response, err := ctrl.service.VerifyMFA(req, c.RealIP())
+	if err != nil {
+		return ctrl.authError(c, err)
+	}
+
+	return responses.Success(c, response)
+}
+
+// BeginMFAEnrollment handles POST /auth/mfa/enroll
+// @Summary Start TOTP enrolment
+// @Description Generate a TOTP secret and its otpauth:// URI to display as a QR code. Use the access token, or the mfa_token of a login that requires the enrolment.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body MFAEnrollRequest false "MFA token during a login"
+// @Success 200 {object} mfa.Enrollment
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 409 {object} responses.ErrorResponse
+// @Router /auth/mfa/enroll [post]
+func (ctrl *Controller) BeginMFAEnrollment(c echo.Context) error {
+	var req MFAEnrollRequest
+	_ = c.Bind(&req)
+
+	token := req.MFAToken
+	if token == "" {
+		token = ctrl.extractToken(c)
+	}
+	if token == "" {
+		return responses.Unauthorized(c, "Authorization token or mfa_token required")
+	}
+
+	enrollment, err := ctrl.service.BeginMFAEnrollment(token)
+	if err != nil {
+		return ctrl.authError(c, err)
+	}
+
+	return responses.Success(c, enrollment)
+}
+
+// ConfirmMFAEnrollment handles POST /auth/mfa/enroll/confirm
+// @Summary Confirm TOTP enrolment
+// @Description Enable the second factor with a first code. Returns the recovery codes, shown only once, and completes the login when called with an mfa_token.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body MFAConfirmRequest true "First TOTP code"
+// @Success 200 {object} MFAConfirmResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Router /auth/mfa/enroll/confirm [post]
+func (ctrl *Controller) ConfirmMFAEnrollment(c echo.Context) error {
+	var req MFAConfirmRequest
+	if err := c.Bind(&req); err != nil {
+		return responses.BadRequest(c, "Invalid request format")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return responses.BadRequest(c, "Validation failed: "+err.Error())
+	}
+
+	token := req.MFAToken
+	if token == "" {
+		token = ctrl.extractToken(c)
+	}
+	if token == "" {
+		return responses.Unauthorized(c, "Authorization token or mfa_token required")
+	}
+
+	response, err := ctrl.service.ConfirmMFAEnrollment(req, token, c.RealIP())
+	if err != nil {
+		return ctrl.authError(c, err)
+	}
+
+	return responses.Success(c, response)
+}
+
+// RegenerateRecoveryCodes handles POST /auth/mfa/recovery-codes
+// @Summary Regenerate recovery codes
+// @Description Replace the recovery codes; the previous ones stop working
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body MFACodeRequest true "Current TOTP code"
+// @Success 200 {object} RecoveryCodesResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Router /auth/mfa/recovery-codes [post]
+func (ctrl *Controller) RegenerateRecoveryCodes(c echo.Context) error {
+	token := ctrl.extractToken(c)
+	if token == "" {
+		return responses.Unauthorized(c, "Authorization token required")
+	}
+
+	var req MFACodeRequest
+	if err := c.Bind(&req); err != nil {
+		return responses.BadRequest(c, "Invalid request format")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return responses.BadRequest(c, "Validation failed: "+err.Error())
+	}
+
+	codes, err := ctrl.service.RegenerateRecoveryCodes(token, req.Code, c.RealIP())
+	if err != nil {
+		return ctrl.authError(c, err)
+	}
+
+	return responses.Success(c, RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableMFA handles POST /auth/mfa/disable
+// @Summary Disable second factor
+// @Description Remove the TOTP enrolment and the recovery codes. Refused for the roles that require a second factor.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body MFACodeRequest true "Current TOTP code"
+// @Success 200 {object} responses.SuccessResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Router /auth/mfa/disable [post]
+func (ctrl *Controller) DisableMFA(c echo.Context) error {
+	token := ctrl.extractToken(c)
+	if token == "" {
+		return responses.Unauthorized(c, "Authorization token required")
+	}
+
+	var req MFACodeRequest
+	if err := c.Bind(&req); err != nil {
+		return responses.BadRequest(c, "Invalid request format")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return responses.BadRequest(c, "Validation failed: "+err.Error())
+	}
+
+	if err := ctrl.service.DisableMFA(token, req.Code, c.RealIP()); err != nil {
+		return ctrl.authError(c, err)
+	}
+
+	return responses.SuccessWithMessage(c, "Two-factor authentication disabled", nil)
+}
+
+// ChangePassword handles POST /auth/password/change
+// @Summary Change password
+// @Description Replace the password and revoke all the sessions. Use the access token, or the password_token of a login whose password is temporary or expired.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param request body ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} responses.SuccessResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 429 {object} responses.ErrorResponse
+// @Router /auth/password/change [post]
+func (ctrl *Controller) ChangePassword(c echo.Context) error {
+	var req ChangePasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return responses.BadRequest(c, "Invalid request format")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return responses.BadRequest(c, "Validation failed: "+err.Error())
+	}
+
+	token := req.PasswordToken
+	if token == "" {
+		token = ctrl.extractToken(c)
+	}
+	if token == "" {
+		return responses.Unauthorized(c, "Authorization token or password_token required")
+	}
+
+	if err := ctrl.service.ChangePassword(req, token, c.RealIP()); err != nil {
+		return ctrl.authError(c, err)
+	}
+
+	return responses.SuccessWithMessage(c, "Password changed, please log in again", nil)
+}
+
+// ForgotPassword handles POST /auth/password/forgot
+// @Summary Request password reset
+// @Description Send a single-use reset token to the email, or phone, of the account. The answer does not tell whether the account exists.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body ForgotPasswordRequest true "Matricule or email"
+// @Success 200 {object} responses.SuccessResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 429 {object} responses.ErrorResponse
+// @Router /auth/password/forgot [post]
+func (ctrl *Controller) ForgotPassword(c echo.Context) error {
+	var req ForgotPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return responses.BadRequest(c, "Invalid request format")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return responses.BadRequest(c, "Validation failed: "+err.Error())
+	}
+
+	if err := ctrl.service.ForgotPassword(req, c.RealIP()); err != nil {
+		return ctrl.authError(c, err)
+	}
+
+	return responses.SuccessWithMessage(c, "If the account exists, a reset link has been sent", nil)
+}
+
+// ResetPassword handles POST /auth/password/reset
+// @Summary Reset password
+// @Description Set a new password with the reset token and revoke all the sessions
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} responses.SuccessResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 429 {object} responses.ErrorResponse
+// @Router /auth/password/reset [post]
+func (ctrl *Controller) ResetPassword(c echo.Context) error {
+	var req ResetPasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return responses.BadRequest(c, "Invalid request format")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return responses.BadRequest(c, "Validation failed: "+err.Error())
+	}
+
+	if err := ctrl.service.ResetPassword(req, c.RealIP()); err != nil {
+		return ctrl.authError(c, err)
+	}
+
+	return responses.SuccessWithMessage(c, "Password reset, please log in", nil)
+}
+
+// authError maps the login, second factor and password errors to HTTP responses
+// GetOIDCConfig handles GET /auth/oidc
+// @Summary OpenID Connect configuration
+// @Description Tell whether the login page offers the identity provider, and its name
+// @Tags auth
+// @Produce json
+// @Success 200 {object} OIDCConfigResponse
+// @Router /auth/oidc [get]
+func (ctrl *Controller) GetOIDCConfig(c echo.Context) error {
+	return responses.Success(c, ctrl.service.GetOIDCConfig())
+}
+
+// BeginOIDCLogin handles POST /auth/oidc/authorize
+// @Summary Start OpenID Connect login
+// @Description Return the identity provider URL (authorization code flow with PKCE). The provider redirects back to the frontend with code and state, to send to /auth/oidc/callback from the same browser: the state is also set in an HttpOnly cookie.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} OIDCAuthorizeResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 429 {object} responses.ErrorResponse
+// @Router /auth/oidc/authorize [post]
+func (ctrl *Controller) BeginOIDCLogin(c echo.Context) error {
+	response, err := ctrl.service.BeginOIDCLogin(c.RealIP())
+	if err != nil {
+		return ctrl.authError(c, err)
+	}
+
+	c.SetCookie(&http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    response.State,
+		Path:     oidcCookiePath(c),
+		Expires:  response.ExpiresAt,
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		// Envoyé à l'appel du callback par le frontend, pas par un site tiers
+		SameSite: http.SameSiteLaxMode,
+	})
+	return responses.Success(c, response)
+}
+
+// OIDCCallback handles POST /auth/oidc/callback
+// @Summary Complete OpenID Connect login
+// @Description Exchange the code returned by the identity provider. The state must match the cookie set by /auth/oidc/authorize. The account is linked to the local user of the same matricule, or creates it, on the first login. The response is the same as /auth/login: the local second factor is asked unless the provider verified one.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body OIDCCallbackRequest true "Code and state returned by the provider, with optional device info"
+// @Success 200 {object} LoginResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 429 {object} responses.ErrorResponse
+// @Router /auth/oidc/callback [post]
+func (ctrl *Controller) OIDCCallback(c echo.Context) error {
+	var req OIDCCallbackRequest
+	if err := c.Bind(&req); err != nil {
+		return responses.BadRequest(c, "Invalid request format")
+	}
+
+	if err := utils.ValidateStruct(&req); err != nil {
+		return responses.BadRequest(c, "Validation failed: "+err.Error())
+	}
+
+	// Un state obtenu dans un autre navigateur ne connecte pas celui-ci (CSRF de connexion)
+	cookie, err := c.Cookie(oidcStateCookie)
+	if err != nil || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(req.State)) != 1 {
+		ctrl.logger.Warn("OIDC state not bound to this browser", zap.String("ip", c.RealIP()))
+		return ctrl.authError(c, oidc.ErrInvalidState)
+	}
+	c.SetCookie(&http.Cookie{
+		Name:     oidcStateCookie,
+		Path:     oidcCookiePath(c),
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   c.Scheme() == "https",
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	response, err := ctrl.service.LoginOIDC(req, c.RealIP())
+	if err != nil {
+		return ctrl.authError(c, err)
+	}
+
+	return responses.Success(c, response)
+}
+
+// oidcCookiePath limits the state cookie to the OpenID Connect routes
+func oidcCookiePath(c echo.Context) string {
+	return path.Dir(c.Path())
+}
+
+func (ctrl *Controller) authError(c echo.Context, err error) error {
+	var blocked *loginguard.BlockedError
+	switch {
+	case errors.As(err, &blocked):
+		c.Response().Header().Set("Retry-After", strconv.Itoa(blocked.RetryAfter()))
+		return responses.Error(c, err)
+	case errors.Is(err, mfa.ErrInvalidCode):
+		return responses.Unauthorized(c, "Invalid two-factor code")
+	case errors.Is(err, mfa.ErrNotEnabled), errors.Is(err, mfa.ErrNoPendingEnrollment):
+		return responses.BadRequest(c, err.Error())
+	case errors.Is(err, mfa.ErrAlreadyEnabled):
+		return responses.Conflict(c, err.Error())
+	case errors.Is(err, mfa.ErrRequired):
+		return responses.Forbidden(c, err.Error())
+	case errors.As(err, new(*passwords.PolicyError)),
+		errors.Is(err, passwords.ErrReused),
+		errors.Is(err, passwords.ErrInvalidResetToken):
+		return responses.BadRequest(c, err.Error())
+	case errors.Is(err, oidc.ErrDisabled):
+		return responses.NotFound(c, err.Error())
+	case errors.Is(err, oidc.ErrInvalidState):
+		return responses.BadRequest(c, err.Error())
+	case errors.Is(err, oidc.ErrAuthenticationFailed):
+		return responses.Unauthorized(c, err.Error())
+	case errors.Is(err, oidc.ErrUnknownUser):
+		return responses.Forbidden(c, err.Error())
+	default:
+		return responses.Error(c, err)
+	}
+}
+
 func (ctrl *Controller) extractToken(c echo.Context) string {
 	auth := c.Request().Header.Get("Authorization")
 	if auth == "" {
@@ -1,6 +1,10 @@
 package auth
 
-import "time"
+import (
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+)
 
 // DeviceInfo contains device information from the mobile app
 type DeviceInfo struct {
@@ -44,13 +48,104 @@ type RefreshTokenRequest struct {
 	DeviceID     string `json:"device_id" validate:"required"`
 }
 
-// LoginResponse represents a login response
+// LoginResponse represents a login response.
+// When a second factor is needed, only the MFA fields are set: the client then
+// calls /auth/mfa/verify, or enrols with /auth/mfa/enroll, with the MFA token.
+// When the password is temporary or expired, only the password fields are set:
+// the client changes it with /auth/password/change, then logs in again.
 type LoginResponse struct {
-	Token        string       `json:"token"`
-	RefreshToken string       `json:"refresh_token,omitempty"` // Only for mobile apps with session
-	SessionID    string       `json:"session_id,omitempty"`    // Session ID for mobile apps
-	ExpiresAt    *time.Time   `json:"expires_at,omitempty"`    // Token expiration
-	User         User         `json:"user"`
+	Token                  string     `json:"token"`
+	RefreshToken           string     `json:"refresh_token,omitempty"`            // Only for mobile apps with session
+	SessionID              string     `json:"session_id,omitempty"`               // Session ID for mobile apps
+	ExpiresAt              *time.Time `json:"expires_at,omitempty"`               // Token expiration
+	MFARequired            bool       `json:"mfa_required,omitempty"`             // TOTP code expected
+	MFAEnrollmentRequired  bool       `json:"mfa_enrollment_required,omitempty"`  // Role requires a second factor not configured yet
+	MFAToken               string     `json:"mfa_token,omitempty"`                // Short-lived token for the second step
+	PasswordChangeRequired bool       `json:"password_change_required,omitempty"` // Temporary or expired password
+	PasswordExpired        bool       `json:"password_expired,omitempty"`
+	PasswordToken          string     `json:"password_token,omitempty"`      // Short-lived token for the password change
+	PasswordExpiresAt      *time.Time `json:"password_expires_at,omitempty"` // When the password policy sets an expiry
+	User                   User       `json:"user"`
+}
+
+// ChangePasswordRequest changes the password of the current user. The password
+// token is only needed when the login asked for the change; otherwise the
+// access token is used.
+type ChangePasswordRequest struct {
+	PasswordToken   string `json:"password_token,omitempty"`
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+// ForgotPasswordRequest asks for a password reset token
+type ForgotPasswordRequest struct {
+	Identifier string `json:"identifier" validate:"required"` // Matricule or email
+}
+
+// ResetPasswordRequest sets a new password with a reset token
+type ResetPasswordRequest struct {
+	Token       string `json:"token" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+// MFAVerifyRequest represents the second login step
+type MFAVerifyRequest struct {
+	MFAToken     string      `json:"mfa_token" validate:"required"`
+	Code         string      `json:"code"`                    // TOTP code
+	RecoveryCode string      `json:"recovery_code,omitempty"` // Single-use recovery code, instead of the TOTP code
+	Device       *DeviceInfo `json:"device,omitempty"`
+}
+
+// MFAEnrollRequest starts a TOTP enrolment. The MFA token is only needed when
+// the enrolment is required to log in; otherwise the access token is used.
+type MFAEnrollRequest struct {
+	MFAToken string `json:"mfa_token,omitempty"`
+}
+
+// MFAConfirmRequest confirms a TOTP enrolment with a first code
+type MFAConfirmRequest struct {
+	MFAToken string      `json:"mfa_token,omitempty"`
+	Code     string      `json:"code" validate:"required"`
+	Device   *DeviceInfo `json:"device,omitempty"` // During a login, as in LoginRequest
+}
+
+// MFAConfirmResponse holds the recovery codes, shown only once, and the login
+// completed when the enrolment was done during a login
+type MFAConfirmResponse struct {
+	RecoveryCodes []string       `json:"recovery_codes"`
+	Login         *LoginResponse `json:"login,omitempty"`
+}
+
+// MFACodeRequest represents an operation confirmed with a TOTP code
+type MFACodeRequest struct {
+	Code string `json:"code" validate:"required"`
+}
+
+// RecoveryCodesResponse holds new recovery codes
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// OIDCConfigResponse tells whether the login page offers the OpenID Connect provider
+type OIDCConfigResponse struct {
+	Enabled      bool   `json:"enabled"`
+	ProviderName string `json:"provider_name,omitempty"`
+}
+
+// OIDCAuthorizeResponse holds the provider URL the browser is sent to. The
+// provider redirects back with the code and the same state, also set in the
+// oidc_state cookie.
+type OIDCAuthorizeResponse struct {
+	AuthorizationURL string    `json:"authorization_url"`
+	State            string    `json:"state"`
+	ExpiresAt        time.Time `json:"expires_at"`
+}
+
+// OIDCCallbackRequest completes an OpenID Connect login
+type OIDCCallbackRequest struct {
+	Code   string      `json:"code" validate:"required"`
+	State  string      `json:"state" validate:"required"`
+	Device *DeviceInfo `json:"device,omitempty"` // As in LoginRequest
 }
 
 // User represents a user
@@ -87,6 +182,17 @@ type SessionDTO struct {
 	CreatedAt      time.Time `json:"created_at"`
 }
 
+// sessionSpec declares the sorts and fields accepted by GET /auth/sessions
+var sessionSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"last_activity_at": "last_activity_at",
+		"created_at":       "created_at",
+		"device_name":      "device_name",
+	},
+	DefaultSort: "-last_activity_at",
+	Fields:      pagination.JSONFields(SessionDTO{}),
+}
+
 // LogoutRequest represents logout request with optional session specification
 type LogoutRequest struct {
 	SessionID  string `json:"session_id,omitempty"`  // Specific session to revoke
@@ -2,11 +2,18 @@ package auth
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"time"
 
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/crypto"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/jwt"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/loginguard"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/mfa"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/oidc"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/passwords"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/session"
 	"police-trafic-api-frontend-aligned/internal/shared/errors"
@@ -25,14 +32,37 @@ type Service interface {
 	Register(req RegisterRequest) (*User, error)
 	GetUserSessions(token string) ([]SessionDTO, error)
 	RevokeSession(token string, sessionID string) error
+
+	// Second factor (TOTP)
+	VerifyMFA(req MFAVerifyRequest, ipAddress string) (*LoginResponse, error)
+	GetMFAStatus(token string) (*mfa.Status, error)
+	BeginMFAEnrollment(token string) (*mfa.Enrollment, error)
+	ConfirmMFAEnrollment(req MFAConfirmRequest, token string, ipAddress string) (*MFAConfirmResponse, error)
+	RegenerateRecoveryCodes(token string, code string, ipAddress string) ([]string, error)
+	DisableMFA(token string, code string, ipAddress string) error
+
+	// Password lifecycle
+	ChangePassword(req ChangePasswordRequest, token string, ipAddress string) error
+	ForgotPassword(req ForgotPasswordRequest, ipAddress string) error
+	ResetPassword(req ResetPasswordRequest, ipAddress string) error
+
+	// OpenID Connect login, beside the local passwords
+	GetOIDCConfig() *OIDCConfigResponse
+	BeginOIDCLogin(ipAddress string) (*OIDCAuthorizeResponse, error)
+	LoginOIDC(req OIDCCallbackRequest, ipAddress string) (*LoginResponse, error)
 }
 
 type service struct {
-	logger         *zap.Logger
-	userRepo       repository.UserRepository
-	jwtService     jwt.Service
-	cryptoService  crypto.Service
-	sessionService session.Service
+	logger          *zap.Logger
+	userRepo        repository.UserRepository
+	jwtService      jwt.Service
+	cryptoService   crypto.Service
+	sessionService  session.Service
+	loginGuard      loginguard.Service
+	mfaService      mfa.Service
+	passwordService passwords.Service
+	oidcService     oidc.Service
+	mockUsers       bool
 }
 
 // NewService creates a new auth service
@@ -42,13 +72,24 @@ func NewService(
 	jwtService jwt.Service,
 	cryptoService crypto.Service,
 	sessionService session.Service,
+	loginGuard loginguard.Service,
+	mfaService mfa.Service,
+	passwordService passwords.Service,
+	oidcService oidc.Service,
+	cfg *config.Config,
 ) Service {
 	return &service{
-		logger:         logger,
-		userRepo:       userRepo,
-		jwtService:     jwtService,
-		cryptoService:  cryptoService,
-		sessionService: sessionService,
+		logger:          logger,
+		userRepo:        userRepo,
+		jwtService:      jwtService,
+		cryptoService:   cryptoService,
+		sessionService:  sessionService,
+		loginGuard:      loginGuard,
+		mfaService:      mfaService,
+		passwordService: passwordService,
+		oidcService:     oidcService,
+		// Les comptes de démonstration ne sont jamais disponibles hors développement
+		mockUsers: cfg.Auth.MockUsers && cfg.App.Environment == "development",
 	}
 }
 
@@ -60,8 +101,37 @@ func (s *service) Login(req LoginRequest, ipAddress string) (*LoginResponse, err
 	user, err := s.userRepo.GetByMatricule(ctx, identifier)
 	if err != nil {
 		s.logger.Warn("User not found", zap.String("matricule", identifier), zap.Error(err))
+		if err := s.loginGuard.Check(ctx, "", ipAddress); err != nil {
+			return nil, err
+		}
+
+		if !s.mockUsers {
+			s.loginGuard.Failure(ctx, "", ipAddress)
+			return nil, errors.ErrInvalidCredentials
+		}
+
 		// Fallback to mock data for testing
-		return s.loginWithMockData(identifier, req.Password, req.Device, ipAddress)
+		resp, err := s.loginWithMockData(identifier, req.Password, req.Device, ipAddress)
+		if err != nil {
+			s.loginGuard.Failure(ctx, "", ipAddress)
+		}
+		return resp, err
+	}
+
+	// Refuser avant de vérifier le mot de passe tant que le compte ou l'IP est bloqué
+	if err := s.loginGuard.Check(ctx, user.ID.String(), ipAddress); err != nil {
+		s.logger.Warn("Login attempt refused",
+			zap.String("matricule", identifier),
+			zap.String("ip", ipAddress),
+			zap.Error(err),
+		)
+		// Un compte bloqué répond comme un matricule inconnu, qui ne l'est jamais:
+		// la réponse ne doit pas révéler quels matricules existent
+		var blocked *loginguard.BlockedError
+		if stderrors.As(err, &blocked) && blocked.Account {
+			return nil, errors.ErrInvalidCredentials
+		}
+		return nil, err
 	}
 
 	// Verify password with bcrypt
@@ -70,6 +140,7 @@ func (s *service) Login(req LoginRequest, ipAddress string) (*LoginResponse, err
 			zap.String("matricule", identifier),
 			zap.Error(err),
 		)
+		s.loginGuard.Failure(ctx, user.ID.String(), ipAddress)
 		return nil, errors.ErrInvalidCredentials
 	}
 
@@ -78,6 +149,38 @@ func (s *service) Login(req LoginRequest, ipAddress string) (*LoginResponse, err
 		return nil, errors.ErrUnauthorized
 	}
 
+	// Le compteur d'échecs n'est remis à zéro qu'une fois le second facteur validé
+	if challenge, err := s.mfaChallenge(user, false); err != nil || challenge != nil {
+		return challenge, err
+	}
+
+	s.loginGuard.Success(ctx, user.ID.String())
+	return s.completeLogin(ctx, user, req.Device, ipAddress, "")
+}
+
+// completeLogin issues the tokens of an authenticated user, unless the password
+// has to be changed first. mfaMethod is the second factor verified, recorded on
+// the session.
+func (s *service) completeLogin(ctx context.Context, user *ent.User, device *DeviceInfo, ipAddress string, mfaMethod string) (*LoginResponse, error) {
+	passwordStatus, err := s.passwordService.Status(ctx, user)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get password status: %w", err)
+	}
+	if passwordStatus.ChangeRequired() {
+		return s.passwordChallenge(user, passwordStatus)
+	}
+
+	resp, err := s.issueTokens(ctx, user, device, ipAddress, mfaMethod)
+	if err != nil {
+		return nil, err
+	}
+	resp.PasswordExpiresAt = passwordStatus.ExpiresAt
+	return resp, nil
+}
+
+// issueTokens creates the session of a mobile app, or a plain access token
+// for the web app
+func (s *service) issueTokens(ctx context.Context, user *ent.User, device *DeviceInfo, ipAddress string, mfaMethod string) (*LoginResponse, error) {
 	// Build user response
 	userResp := User{
 		ID:        user.ID.String(),
@@ -101,8 +204,8 @@ func (s *service) Login(req LoginRequest, ipAddress string) (*LoginResponse, err
 	}
 
 	// If device info provided, create a session (mobile app)
-	if req.Device != nil && req.Device.DeviceID != "" {
-		return s.loginWithSession(ctx, user.ID, userResp, req.Device, ipAddress)
+	if device != nil && device.DeviceID != "" {
+		return s.loginWithSession(ctx, user.ID, userResp, device, ipAddress, mfaMethod)
 	}
 
 	// Standard login without session (web app - backwards compatible)
@@ -117,7 +220,7 @@ func (s *service) Login(req LoginRequest, ipAddress string) (*LoginResponse, err
 	}, nil
 }
 
-func (s *service) loginWithSession(ctx context.Context, userID uuid.UUID, userResp User, device *DeviceInfo, ipAddress string) (*LoginResponse, error) {
+func (s *service) loginWithSession(ctx context.Context, userID uuid.UUID, userResp User, device *DeviceInfo, ipAddress string, mfaMethod string) (*LoginResponse, error) {
 	// Create session
 	deviceInfo := session.DeviceInfo{
 		DeviceID:   device.DeviceID,
@@ -127,7 +230,7 @@ func (s *service) loginWithSession(ctx context.Context, userID uuid.UUID, userRe
 		AppVersion: device.AppVersion,
 	}
 
-	sessionInfo, err := s.sessionService.CreateSession(ctx, userID, deviceInfo, ipAddress)
+	sessionInfo, err := s.sessionService.CreateSession(ctx, userID, deviceInfo, ipAddress, mfaMethod)
 	if err != nil {
 		s.logger.Error("Failed to create session", zap.Error(err))
 		return nil, fmt.Errorf("failed to create session: %w", err)
@@ -164,7 +267,7 @@ func (s *service) loginWithMockData(matricule, password string, device *DeviceIn
 					// For mock users, generate a mock session
 					ctx := context.Background()
 					mockUserID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
-					return s.loginWithSession(ctx, mockUserID, user, device, ipAddress)
+					return s.loginWithSession(ctx, mockUserID, user, device, ipAddress, "")
 				}
 
 				token, err := s.jwtService.GenerateToken(user.ID, user.Matricule, user.Role)
@@ -361,7 +464,8 @@ func (s *service) RefreshTokenLegacy(token string) (*LoginResponse, error) {
 	// Use JWT service to refresh token
 	newToken, err := s.jwtService.RefreshToken(token)
 	if err != nil {
-		return nil, fmt.Errorf("failed to refresh token: %w", err)
+		// Jeton sans session ou invalide : nouvelle connexion nécessaire
+		return nil, errors.ErrInvalidToken
 	}
 
 	// Get user info from new token
@@ -450,6 +554,10 @@ func (s *service) RevokeSession(token string, sessionID string) error {
 func (s *service) Register(req RegisterRequest) (*User, error) {
 	s.logger.Info("User registration attempt", zap.String("matricule", req.Matricule))
 
+	if err := s.passwordService.Validate(req.Password, req.Matricule, req.Nom, req.Prenom); err != nil {
+		return nil, err
+	}
+
 	// Hash password
 	hashedPassword, err := s.cryptoService.HashPassword(req.Password)
 	if err != nil {
@@ -483,6 +591,10 @@ func (s *service) Register(req RegisterRequest) (*User, error) {
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
+	if err := s.passwordService.Record(ctx, user.ID, hashedPassword, false); err != nil {
+		s.logger.Error("Failed to record password history", zap.String("matricule", req.Matricule), zap.Error(err))
+	}
+
 	return &User{
 		ID:        user.ID.String(),
 		Matricule: user.Matricule,
@@ -496,6 +608,383 @@ func (s *service) Register(req RegisterRequest) (*User, error) {
 	}, nil
 }
 
+// mfaChallenge returns the response asking for the second factor, or nil when
+// the first step is enough for this user. federated marks a first step done
+// by the identity provider.
+func (s *service) mfaChallenge(user *ent.User, federated bool) (*LoginResponse, error) {
+	status, err := s.mfaService.Status(context.Background(), user.ID, user.Role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get MFA status: %w", err)
+	}
+	if !status.Enabled && !status.Required {
+		return nil, nil
+	}
+
+	mfaToken, err := s.jwtService.GenerateMFAToken(user.ID.String(), user.Matricule, user.Role, federated)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Second factor required",
+		zap.String("matricule", user.Matricule),
+		zap.Bool("enrollment_required", !status.Enabled),
+	)
+
+	return &LoginResponse{
+		MFARequired:           status.Enabled,
+		MFAEnrollmentRequired: !status.Enabled,
+		MFAToken:              mfaToken,
+	}, nil
+}
+
+// VerifyMFA completes a login with the TOTP code or a recovery code
+func (s *service) VerifyMFA(req MFAVerifyRequest, ipAddress string) (*LoginResponse, error) {
+	claims, err := s.jwtService.ValidateMFAToken(req.MFAToken)
+	if err != nil {
+		return nil, errors.ErrInvalidToken
+	}
+
+	ctx := context.Background()
+	if err := s.loginGuard.Check(ctx, claims.UserID, ipAddress); err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, errors.ErrInvalidToken
+	}
+
+	method, err := s.mfaService.Verify(ctx, userID, req.Code, req.RecoveryCode)
+	if err != nil {
+		if stderrors.Is(err, mfa.ErrInvalidCode) {
+			s.logger.Warn("Second factor verification failed", zap.String("matricule", claims.Matricule))
+			s.loginGuard.Failure(ctx, claims.UserID, ipAddress)
+		}
+		return nil, err
+	}
+
+	return s.finishMFALogin(ctx, claims, req.Device, ipAddress, method)
+}
+
+// GetMFAStatus returns the second factor status of the current user
+func (s *service) GetMFAStatus(token string) (*mfa.Status, error) {
+	claims, err := s.accessClaims(token)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, errors.ErrInvalidToken
+	}
+
+	return s.mfaService.Status(context.Background(), userID, claims.Role)
+}
+
+// BeginMFAEnrollment generates a new TOTP secret. The token is an access token,
+// or the MFA token of a login that requires the enrolment.
+func (s *service) BeginMFAEnrollment(token string) (*mfa.Enrollment, error) {
+	claims, err := s.enrollmentClaims(token)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, errors.ErrInvalidToken
+	}
+
+	return s.mfaService.BeginEnrollment(context.Background(), userID, claims.Matricule)
+}
+
+// ConfirmMFAEnrollment activates the second factor. During a login, it also
+// completes the login.
+func (s *service) ConfirmMFAEnrollment(req MFAConfirmRequest, token string, ipAddress string) (*MFAConfirmResponse, error) {
+	claims, err := s.enrollmentClaims(token)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	if err := s.loginGuard.Check(ctx, claims.UserID, ipAddress); err != nil {
+		return nil, err
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, errors.ErrInvalidToken
+	}
+
+	codes, err := s.mfaService.ConfirmEnrollment(ctx, userID, req.Code)
+	if err != nil {
+		if stderrors.Is(err, mfa.ErrInvalidCode) {
+			s.loginGuard.Failure(ctx, claims.UserID, ipAddress)
+		}
+		return nil, err
+	}
+
+	resp := &MFAConfirmResponse{RecoveryCodes: codes}
+	if claims.Purpose == jwt.PurposeMFA {
+		resp.Login, err = s.finishMFALogin(ctx, claims, req.Device, ipAddress, mfa.MethodTOTP)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the current user
+func (s *service) RegenerateRecoveryCodes(token string, code string, ipAddress string) ([]string, error) {
+	claims, err := s.accessClaims(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var codes []string
+	err = s.withCode(claims, ipAddress, func(ctx context.Context, userID uuid.UUID) error {
+		codes, err = s.mfaService.RegenerateRecoveryCodes(ctx, userID, code)
+		return err
+	})
+
+	return codes, err
+}
+
+// DisableMFA removes the second factor of the current user, if the role allows it
+func (s *service) DisableMFA(token string, code string, ipAddress string) error {
+	claims, err := s.accessClaims(token)
+	if err != nil {
+		return err
+	}
+
+	return s.withCode(claims, ipAddress, func(ctx context.Context, userID uuid.UUID) error {
+		return s.mfaService.Disable(ctx, userID, claims.Role, code)
+	})
+}
+
+// withCode runs an operation checking a TOTP code, with the same brute-force
+// protection as the login
+func (s *service) withCode(claims *jwt.Claims, ipAddress string, fn func(ctx context.Context, userID uuid.UUID) error) error {
+	ctx := context.Background()
+	if err := s.loginGuard.Check(ctx, claims.UserID, ipAddress); err != nil {
+		return err
+	}
+
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return errors.ErrInvalidToken
+	}
+
+	err = fn(ctx, userID)
+	if stderrors.Is(err, mfa.ErrInvalidCode) {
+		s.loginGuard.Failure(ctx, claims.UserID, ipAddress)
+	}
+
+	return err
+}
+
+// finishMFALogin issues the tokens once the second factor of the MFA token
+// claims is verified
+func (s *service) finishMFALogin(ctx context.Context, claims *jwt.Claims, device *DeviceInfo, ipAddress string, method string) (*LoginResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, errors.ErrUnauthorized
+	}
+	if !user.Active {
+		return nil, errors.ErrUnauthorized
+	}
+
+	s.loginGuard.Success(ctx, claims.UserID)
+	if claims.Federated {
+		// Le mot de passe local, aléatoire ou inutilisé, n'a pas servi à la connexion
+		return s.issueTokens(ctx, user, device, ipAddress, method)
+	}
+	return s.completeLogin(ctx, user, device, ipAddress, method)
+}
+
+// accessClaims validates an access token and the session it is bound to
+func (s *service) accessClaims(token string) (*jwt.Claims, error) {
+	claims, err := s.jwtService.ValidateToken(token)
+	if err != nil {
+		return nil, errors.ErrInvalidToken
+	}
+
+	if claims.SessionID != "" {
+		sessionID, err := uuid.Parse(claims.SessionID)
+		if err != nil {
+			return nil, errors.ErrInvalidToken
+		}
+		valid, _ := s.sessionService.IsSessionValid(context.Background(), sessionID, claims.DeviceID)
+		if !valid {
+			return nil, errors.ErrUnauthorized
+		}
+	}
+
+	return claims, nil
+}
+
+// passwordChallenge returns the response asking for a password change
+func (s *service) passwordChallenge(user *ent.User, status *passwords.Status) (*LoginResponse, error) {
+	passwordToken, err := s.jwtService.GeneratePasswordChangeToken(user.ID.String(), user.Matricule, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Password change required",
+		zap.String("matricule", user.Matricule),
+		zap.Bool("temporary", status.MustChange),
+		zap.Bool("expired", status.Expired),
+	)
+
+	return &LoginResponse{
+		PasswordChangeRequired: true,
+		PasswordExpired:        status.Expired,
+		PasswordToken:          passwordToken,
+	}, nil
+}
+
+// ChangePassword replaces the password of the current user, or of the user
+// whose login asked for a change. All the sessions are revoked.
+func (s *service) ChangePassword(req ChangePasswordRequest, token string, ipAddress string) error {
+	claims, err := s.passwordClaims(token)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if err := s.loginGuard.Check(ctx, claims.UserID, ipAddress); err != nil {
+		return err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, claims.UserID)
+	if err != nil {
+		return errors.ErrUnauthorized
+	}
+
+	if err := s.cryptoService.CheckPassword(req.CurrentPassword, user.Password); err != nil {
+		s.logger.Warn("Password change refused: wrong current password", zap.String("matricule", user.Matricule))
+		s.loginGuard.Failure(ctx, claims.UserID, ipAddress)
+		return errors.ErrInvalidCredentials
+	}
+
+	return s.passwordService.Change(ctx, user, req.NewPassword)
+}
+
+// ForgotPassword sends a reset token. The answer is the same whether the
+// account exists or not.
+func (s *service) ForgotPassword(req ForgotPasswordRequest, ipAddress string) error {
+	ctx := context.Background()
+	if err := s.loginGuard.Check(ctx, "", ipAddress); err != nil {
+		return err
+	}
+
+	return s.passwordService.RequestReset(ctx, req.Identifier, ipAddress)
+}
+
+// ResetPassword sets a new password with a reset token
+func (s *service) ResetPassword(req ResetPasswordRequest, ipAddress string) error {
+	ctx := context.Background()
+	if err := s.loginGuard.Check(ctx, "", ipAddress); err != nil {
+		return err
+	}
+
+	user, err := s.passwordService.Reset(ctx, req.Token, req.NewPassword)
+	if err != nil {
+		if stderrors.Is(err, passwords.ErrInvalidResetToken) {
+			s.loginGuard.Failure(ctx, "", ipAddress)
+		}
+		return err
+	}
+
+	// Le nouveau mot de passe lève aussi le verrouillage dû aux échecs
+	s.loginGuard.Success(ctx, user.ID.String())
+	return nil
+}
+
+// GetOIDCConfig tells the login page whether to show the provider button
+func (s *service) GetOIDCConfig() *OIDCConfigResponse {
+	resp := &OIDCConfigResponse{Enabled: s.oidcService.Enabled()}
+	if resp.Enabled {
+		resp.ProviderName = s.oidcService.ProviderName()
+	}
+	return resp
+}
+
+// BeginOIDCLogin returns the provider URL the browser is sent to
+func (s *service) BeginOIDCLogin(ipAddress string) (*OIDCAuthorizeResponse, error) {
+	ctx := context.Background()
+	if err := s.loginGuard.Check(ctx, "", ipAddress); err != nil {
+		return nil, err
+	}
+
+	authorization, err := s.oidcService.Begin(ctx, ipAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDCAuthorizeResponse{
+		AuthorizationURL: authorization.URL,
+		State:            authorization.State,
+		ExpiresAt:        authorization.ExpiresAt,
+	}, nil
+}
+
+// LoginOIDC completes a login with the code returned by the provider. The
+// provider replaces the password step: the local password policy does not
+// apply, and the local second factor is asked unless the ID token proves one.
+func (s *service) LoginOIDC(req OIDCCallbackRequest, ipAddress string) (*LoginResponse, error) {
+	ctx := context.Background()
+	if err := s.loginGuard.Check(ctx, "", ipAddress); err != nil {
+		return nil, err
+	}
+
+	login, err := s.oidcService.Complete(ctx, req.Code, req.State)
+	if err != nil {
+		if stderrors.Is(err, oidc.ErrInvalidState) || stderrors.Is(err, oidc.ErrAuthenticationFailed) {
+			s.loginGuard.Failure(ctx, "", ipAddress)
+		}
+		return nil, err
+	}
+	user := login.User
+
+	if err := s.loginGuard.Check(ctx, user.ID.String(), ipAddress); err != nil {
+		return nil, err
+	}
+	if !user.Active {
+		s.logger.Warn("User account inactive", zap.String("matricule", user.Matricule))
+		return nil, errors.ErrUnauthorized
+	}
+
+	s.logger.Info("OIDC login", zap.String("matricule", user.Matricule), zap.Bool("provider_mfa", login.MFA))
+	if login.MFA {
+		s.loginGuard.Success(ctx, user.ID.String())
+		return s.issueTokens(ctx, user, req.Device, ipAddress, mfa.MethodOIDC)
+	}
+
+	if challenge, err := s.mfaChallenge(user, true); err != nil || challenge != nil {
+		return challenge, err
+	}
+
+	s.loginGuard.Success(ctx, user.ID.String())
+	return s.issueTokens(ctx, user, req.Device, ipAddress, "")
+}
+
+// passwordClaims accepts an access token or a password change token
+func (s *service) passwordClaims(token string) (*jwt.Claims, error) {
+	if claims, err := s.jwtService.ValidatePasswordChangeToken(token); err == nil {
+		return claims, nil
+	}
+	return s.accessClaims(token)
+}
+
+// enrollmentClaims accepts an access token or an MFA token
+func (s *service) enrollmentClaims(token string) (*jwt.Claims, error) {
+	if claims, err := s.jwtService.ValidateMFAToken(token); err == nil {
+		return claims, nil
+	}
+	return s.accessClaims(token)
+}
+
 // getMockUsers returns mock users for testing
 func (s *service) getMockUsers() []User {
 	// Utiliser le commissariat du 7ème Arrondissement qui a des alertes
@@ -1,9 +1,10 @@
 package commissariat
 
 import (
-	"strconv"
 	"time"
 
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
@@ -21,15 +22,17 @@ func NewController(service Service) *Controller {
 
 // RegisterRoutes registers commissariat routes
 func (ctrl *Controller) RegisterRoutes(e *echo.Group) {
+	routes := rbac.Guard(e)
+
 	// Route pour lister tous les commissariats
-	e.GET("/commissariats", ctrl.List)
+	routes.GET("/commissariats", ctrl.List, rbac.PermReadCommissariats)
 
 	// Routes pour un commissariat spécifique
-	commissariat := e.Group("/commissariat")
-	commissariat.GET("/:id/dashboard", ctrl.GetDashboard)
-	commissariat.GET("/:id/agents", ctrl.GetAgents)
-	commissariat.GET("/:id/controles", ctrl.GetControles)
-	commissariat.GET("/:id/statistiques", ctrl.GetStatistiques)
+	commissariat := routes.Group("/commissariat")
+	commissariat.GET("/:id/dashboard", ctrl.GetDashboard, rbac.PermReadCommissariats)
+	commissariat.GET("/:id/agents", ctrl.GetAgents, rbac.PermReadCommissariats)
+	commissariat.GET("/:id/controles", ctrl.GetControles, rbac.PermReadCommissariats)
+	commissariat.GET("/:id/statistiques", ctrl.GetStatistiques, rbac.PermReadCommissariats)
 }
 
 // List handles GET /commissariats
@@ -41,26 +44,18 @@ func (ctrl *Controller) List(c echo.Context) error {
 		actif = &val
 	}
 
-	page := 1
-	if p := c.QueryParam("page"); p != "" {
-		if val, err := strconv.Atoi(p); err == nil && val > 0 {
-			page = val
-		}
-	}
-
-	limit := 20
-	if l := c.QueryParam("limit"); l != "" {
-		if val, err := strconv.Atoi(l); err == nil && val > 0 && val <= 100 {
-			limit = val
-		}
+	// Pagination, tri et champs
+	page, err := pagination.Parse(c, listSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
 	}
 
-	commissariats, err := ctrl.service.List(c.Request().Context(), actif, page, limit)
+	commissariats, result, err := ctrl.service.List(c.Request().Context(), actif, page)
 	if err != nil {
 		return responses.InternalServerError(c, err.Error())
 	}
 
-	return responses.Success(c, commissariats)
+	return responses.Paginated(c, commissariats, result)
 }
 
 // GetDashboard handles GET /commissariat/:id/dashboard
@@ -88,6 +83,11 @@ func (ctrl *Controller) GetAgents(c echo.Context) error {
 		return responses.BadRequest(c, "Commissariat ID is required")
 	}
 
+	page, err := pagination.Parse(c, agentSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
 	agents, err := ctrl.service.GetAgents(c.Request().Context(), id)
 	if err != nil {
 		if err.Error() == "commissariat not found" {
@@ -96,7 +96,9 @@ func (ctrl *Controller) GetAgents(c echo.Context) error {
 		return responses.InternalServerError(c, err.Error())
 	}
 
-	return responses.Success(c, agents)
+	// Agents chargés avec le commissariat : la page est découpée en mémoire
+	agents, result := pagination.Slice(page, agents)
+	return responses.Paginated(c, agents, result)
 }
 
 // GetControles handles GET /commissariat/:id/controles
@@ -106,21 +108,13 @@ func (ctrl *Controller) GetControles(c echo.Context) error {
 		return responses.BadRequest(c, "Commissariat ID is required")
 	}
 
-	// Parse pagination
-	page := 1
-	limit := 20
-	if p := c.QueryParam("page"); p != "" {
-		if val, err := strconv.Atoi(p); err == nil && val > 0 {
-			page = val
-		}
-	}
-	if l := c.QueryParam("limit"); l != "" {
-		if val, err := strconv.Atoi(l); err == nil && val > 0 {
-			limit = val
-		}
+	// Pagination, tri et champs
+	page, err := pagination.Parse(c, controleSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
 	}
 
-	controles, err := ctrl.service.GetControles(c.Request().Context(), id, page, limit)
+	controles, result, err := ctrl.service.GetControles(c.Request().Context(), id, page)
 	if err != nil {
 		if err.Error() == "commissariat not found" {
 			return responses.NotFound(c, "Commissariat not found")
@@ -128,7 +122,7 @@ func (ctrl *Controller) GetControles(c echo.Context) error {
 		return responses.InternalServerError(c, err.Error())
 	}
 
-	return responses.Success(c, controles)
+	return responses.Paginated(c, controles, result)
 }
 
 // GetStatistiques handles GET /commissariat/:id/statistiques
@@ -7,16 +7,18 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"go.uber.org/zap"
 )
 
 // Service defines commissariat service interface
 type Service interface {
-	List(ctx context.Context, actif *bool, page, limit int) (*ListCommissariatsResponse, error)
+	List(ctx context.Context, actif *bool, page *pagination.Params) ([]*CommissariatResponse, *pagination.Page, error)
 	GetDashboard(ctx context.Context, commissariatID string) (*DashboardResponse, error)
 	GetAgents(ctx context.Context, commissariatID string) ([]*AgentResponse, error)
-	GetControles(ctx context.Context, commissariatID string, page, limit int) (*ListControlesResponse, error)
+	GetControles(ctx context.Context, commissariatID string, page *pagination.Params) ([]*ControleResponse, *pagination.Page, error)
 	GetStatistiques(ctx context.Context, commissariatID string, dateDebut, dateFin *time.Time) (*StatistiquesResponse, error)
 }
 
@@ -50,28 +52,35 @@ func NewService(
 }
 
 // List returns paginated list of commissariats
-func (s *service) List(ctx context.Context, actif *bool, page, limit int) (*ListCommissariatsResponse, error) {
-	s.logger.Info("Listing commissariats", zap.Int("page", page), zap.Int("limit", limit))
+func (s *service) List(ctx context.Context, actif *bool, params *pagination.Params) ([]*CommissariatResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "commissariat.List")
+	defer span.End()
+
+	s.logger.Info("Listing commissariats", zap.String("sort", params.Sort), zap.Int("limit", params.Limit))
 
 	// Build filters
 	filters := &repository.CommissariatFilters{
-		Actif:  actif,
-		Limit:  limit,
-		Offset: (page - 1) * limit,
+		Actif: actif,
+		Page:  params,
 	}
 
 	// Get commissariats
 	commissariats, err := s.commissariatRepo.List(ctx, filters)
 	if err != nil {
 		s.logger.Error("Failed to list commissariats", zap.Error(err))
-		return nil, fmt.Errorf("failed to list commissariats: %w", err)
+		return nil, nil, fmt.Errorf("failed to list commissariats: %w", err)
 	}
 
-	// Get total count
-	total, err := s.commissariatRepo.Count(ctx, filters)
+	commissariats, page, err := pagination.Trim(params, commissariats)
 	if err != nil {
+		return nil, nil, err
+	}
+
+	// Get total count
+	if total, err := s.commissariatRepo.Count(ctx, filters); err == nil {
+		page.SetTotal(total)
+	} else {
 		s.logger.Error("Failed to count commissariats", zap.Error(err))
-		total = len(commissariats)
 	}
 
 	// Convert to response
@@ -98,22 +107,14 @@ func (s *service) List(ctx context.Context, actif *bool, page, limit int) (*List
 		}
 	}
 
-	// Calculate total pages
-	totalPages := (total + limit - 1) / limit
-
-	return &ListCommissariatsResponse{
-		Data: data,
-		Pagination: &Pagination{
-			Page:       page,
-			Limit:      limit,
-			Total:      total,
-			TotalPages: totalPages,
-		},
-	}, nil
+	return data, page, nil
 }
 
 // GetDashboard returns commissariat dashboard
 func (s *service) GetDashboard(ctx context.Context, commissariatID string) (*DashboardResponse, error) {
+	ctx, span := tracing.Start(ctx, "commissariat.GetDashboard")
+	defer span.End()
+
 	s.logger.Info("Getting dashboard for commissariat", zap.String("id", commissariatID))
 
 	// Get commissariat with agents
@@ -204,6 +205,9 @@ func (s *service) GetDashboard(ctx context.Context, commissariatID string) (*Das
 
 // GetAgents returns commissariat agents
 func (s *service) GetAgents(ctx context.Context, commissariatID string) ([]*AgentResponse, error) {
+	ctx, span := tracing.Start(ctx, "commissariat.GetAgents")
+	defer span.End()
+
 	s.logger.Info("Getting agents for commissariat", zap.String("id", commissariatID))
 
 	comm, err := s.commissariatRepo.GetByID(ctx, commissariatID)
@@ -231,55 +235,47 @@ func (s *service) GetAgents(ctx context.Context, commissariatID string) ([]*Agen
 }
 
 // GetControles returns commissariat controles
-func (s *service) GetControles(ctx context.Context, commissariatID string, page, limit int) (*ListControlesResponse, error) {
-	s.logger.Info("Getting controles for commissariat", zap.String("id", commissariatID))
+func (s *service) GetControles(ctx context.Context, commissariatID string, params *pagination.Params) ([]*ControleResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "commissariat.GetControles")
+	defer span.End()
 
-	if page <= 0 {
-		page = 1
-	}
-	if limit <= 0 {
-		limit = 20
-	}
+	s.logger.Info("Getting controles for commissariat", zap.String("id", commissariatID))
 
 	// Filter controles by commissariat
 	filters := &repository.ControleFilters{
-		Limit:  limit,
-		Offset: (page - 1) * limit,
+		CommissariatID: &commissariatID,
+		Page:           params,
 	}
 
-	controles, err := s.controleRepo.GetByCommissariat(ctx, commissariatID, filters)
+	controles, err := s.controleRepo.List(ctx, filters)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list controles: %w", err)
+		return nil, nil, fmt.Errorf("failed to list controles: %w", err)
 	}
 
-	total, err := s.controleRepo.Count(ctx, &repository.ControleFilters{CommissariatID: &commissariatID})
+	controles, page, err := pagination.Trim(params, controles)
 	if err != nil {
-		return nil, fmt.Errorf("failed to count controles: %w", err)
+		return nil, nil, err
 	}
 
-	responses := make([]*ControleResponse, len(controles))
-	for i, ctrl := range controles {
-		responses[i] = s.controleToResponse(ctrl)
+	total, err := s.controleRepo.Count(ctx, filters)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to count controles: %w", err)
 	}
+	page.SetTotal(total)
 
-	totalPages := total / limit
-	if total%limit > 0 {
-		totalPages++
+	data := make([]*ControleResponse, len(controles))
+	for i, ctrl := range controles {
+		data[i] = s.controleToResponse(ctrl)
 	}
 
-	return &ListControlesResponse{
-		Data: responses,
-		Pagination: &Pagination{
-			Page:       page,
-			Limit:      limit,
-			Total:      total,
-			TotalPages: totalPages,
-		},
-	}, nil
+	return data, page, nil
 }
 
 // GetStatistiques returns commissariat statistics
 func (s *service) GetStatistiques(ctx context.Context, commissariatID string, dateDebut, dateFin *time.Time) (*StatistiquesResponse, error) {
+	ctx, span := tracing.Start(ctx, "commissariat.GetStatistiques")
+	defer span.End()
+
 	s.logger.Info("Getting statistiques for commissariat", zap.String("id", commissariatID))
 
 	// Get controle statistics
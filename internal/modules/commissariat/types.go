@@ -1,6 +1,11 @@
 package commissariat
 
-import "time"
+import (
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+)
 
 // DashboardResponse represents commissariat dashboard
 type DashboardResponse struct {
@@ -119,20 +124,6 @@ type InfractionStats struct {
 	ParType map[string]int `json:"parType"`
 }
 
-// ListControlesResponse represents paginated controls
-type ListControlesResponse struct {
-	Data       []*ControleResponse `json:"data"`
-	Pagination *Pagination         `json:"pagination"`
-}
-
-// Pagination represents pagination info
-type Pagination struct {
-	Page       int `json:"page"`
-	Limit      int `json:"limit"`
-	Total      int `json:"total"`
-	TotalPages int `json:"totalPages"`
-}
-
 // CommissariatResponse represents commissariat in list
 type CommissariatResponse struct {
 	ID           string    `json:"id"`
@@ -148,8 +139,52 @@ type CommissariatResponse struct {
 	CreatedAt    time.Time `json:"createdAt"`
 }
 
-// ListCommissariatsResponse represents paginated commissariats
-type ListCommissariatsResponse struct {
-	Data       []*CommissariatResponse `json:"data"`
-	Pagination *Pagination             `json:"pagination"`
+// listSpec declares the sorts and fields accepted by GET /commissariats
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"nom":       "nom",
+		"ville":     "ville",
+		"createdAt": "created_at",
+	},
+	DefaultSort: "nom",
+	Fields:      pagination.JSONFields(CommissariatResponse{}),
+	Filters: filter.Fields{
+		"nom":       {Column: "nom", Type: filter.String},
+		"code":      {Column: "code", Type: filter.String},
+		"ville":     {Column: "ville", Type: filter.String},
+		"region":    {Column: "region", Type: filter.String},
+		"actif":     {Column: "actif", Type: filter.Bool},
+		"createdAt": {Column: "created_at", Type: filter.Time},
+	},
+}
+
+// agentSpec declares the sorts and fields accepted by GET /commissariat/:id/agents
+var agentSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"nom":       "nom",
+		"matricule": "matricule",
+		"role":      "role",
+		"createdAt": "createdAt",
+	},
+	DefaultSort: "nom",
+	Fields:      pagination.JSONFields(AgentResponse{}),
+}
+
+// controleSpec declares the sorts and fields accepted by GET /commissariat/:id/controles
+var controleSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"dateControle": "date_controle",
+		"statut":       "statut",
+		"typeControle": "type_controle",
+		"createdAt":    "created_at",
+	},
+	DefaultSort: "-dateControle",
+	Fields:      pagination.JSONFields(ControleResponse{}),
+	Filters: filter.Fields{
+		"statut":       {Column: "statut", Type: filter.String},
+		"typeControle": {Column: "type_controle", Type: filter.String},
+		"dateControle": {Column: "date_controle", Type: filter.Time},
+		"lieuControle": {Column: "lieu_controle", Type: filter.String},
+		"createdAt":    {Column: "created_at", Type: filter.Time},
+	},
 }
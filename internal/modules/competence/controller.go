@@ -5,6 +5,9 @@ import (
 	"strconv"
 
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
@@ -84,6 +87,7 @@ func (c *Controller) GetByID(ctx echo.Context) error {
 // @Param active query string false "Active status (true/false)"
 // @Param search query string false "Search term"
 // @Param organisme query string false "Organisme"
+// @Param filter query string false "Filter expression, e.g. type = FORMATION and dateExpiration < 2026-12-31"
 // @Success 200 {array} CompetenceResponse
 // @Router /api/competences [get]
 func (c *Controller) List(ctx echo.Context) error {
@@ -92,13 +96,19 @@ func (c *Controller) List(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid query parameters")
 	}
 
-	competences, err := c.service.List(ctx.Request().Context(), &filters)
+	page, err := pagination.Parse(ctx, listSpec)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	filters.Page = page
+
+	competences, result, err := c.service.List(ctx.Request().Context(), &filters)
 	if err != nil {
 		c.logger.Error("Failed to list competences", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusOK, competences)
+	return responses.Paginated(ctx, competences, result)
 }
 
 // Update updates a competence
@@ -257,16 +267,18 @@ func (c *Controller) GetExpiring(ctx echo.Context) error {
 
 // RegisterRoutes registers competence routes
 func (c *Controller) RegisterRoutes(g *echo.Group) {
-	competences := g.Group("/competences")
-	competences.POST("", c.Create)
-	competences.GET("", c.List)
-	competences.GET("/expiring", c.GetExpiring)
-	competences.GET("/:id", c.GetByID)
-	competences.PUT("/:id", c.Update)
-	competences.DELETE("/:id", c.Delete)
-	competences.POST("/:id/agents", c.AssignToAgent)
-	competences.DELETE("/:id/agents/:agentId", c.RemoveFromAgent)
+	routes := rbac.Guard(g)
+
+	competences := routes.Group("/competences")
+	competences.POST("", c.Create, rbac.PermManageCompetences)
+	competences.GET("", c.List, rbac.PermReadCompetences)
+	competences.GET("/expiring", c.GetExpiring, rbac.PermReadCompetences)
+	competences.GET("/:id", c.GetByID, rbac.PermReadCompetences)
+	competences.PUT("/:id", c.Update, rbac.PermManageCompetences)
+	competences.DELETE("/:id", c.Delete, rbac.PermManageCompetences)
+	competences.POST("/:id/agents", c.AssignToAgent, rbac.PermManageCompetences)
+	competences.DELETE("/:id/agents/:agentId", c.RemoveFromAgent, rbac.PermManageCompetences)
 
 	// Nested routes
-	g.GET("/agents/:agentId/competences", c.GetByAgent)
+	routes.GET("/agents/:agentId/competences", c.GetByAgent, rbac.PermReadCompetences)
 }
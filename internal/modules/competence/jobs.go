@@ -0,0 +1,44 @@
+package competence
+
+import (
+	"context"
+	"fmt"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/scheduler"
+
+	"go.uber.org/zap"
+)
+
+// expirationWarningDays is how far ahead expiring competences are reported
+const expirationWarningDays = 30
+
+// NewExpirationJob deactivates expired competences and reports the ones about to expire
+func NewExpirationJob(service Service, logger *zap.Logger) scheduler.Job {
+	return scheduler.Job{
+		Name:        "competence-expiration",
+		Description: "Désactive les compétences expirées et signale celles qui expirent sous 30 jours",
+		Schedule:    "0 6 * * *",
+		Run: func(ctx context.Context) (string, error) {
+			deactivated, err := service.DeactivateExpired(ctx)
+			if err != nil {
+				return "", err
+			}
+
+			expiring, err := service.GetExpiring(ctx, expirationWarningDays)
+			if err != nil {
+				return "", err
+			}
+			for _, comp := range expiring {
+				logger.Warn("Competence expiring soon",
+					zap.String("competence_id", comp.ID),
+					zap.String("nom", comp.Nom),
+					zap.Int("agents", comp.NombreAgents),
+					zap.Timep("date_expiration", comp.DateExpiration),
+				)
+			}
+
+			return fmt.Sprintf("%d compétences désactivées, %d expirent sous %d jours",
+				deactivated, len(expiring), expirationWarningDays), nil
+		},
+	}
+}
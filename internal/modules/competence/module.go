@@ -19,6 +19,10 @@ var Module = fx.Module("competence",
 			fx.As(new(interfaces.Controller)),
 			fx.ResultTags(`group:"controllers"`),
 		),
+		fx.Annotate(
+			NewExpirationJob,
+			fx.ResultTags(`group:"jobs"`),
+		),
 	),
 )
 
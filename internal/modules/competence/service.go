@@ -6,6 +6,8 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -16,13 +18,14 @@ type Service interface {
 	Create(ctx context.Context, req *CreateCompetenceRequest) (*CompetenceResponse, error)
 	GetByID(ctx context.Context, id string) (*CompetenceResponse, error)
 	GetByNom(ctx context.Context, nom string) (*CompetenceResponse, error)
-	List(ctx context.Context, filters *ListCompetencesFilters) ([]CompetenceResponse, error)
+	List(ctx context.Context, filters *ListCompetencesFilters) ([]CompetenceResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, req *UpdateCompetenceRequest) (*CompetenceResponse, error)
 	Delete(ctx context.Context, id string) error
 	AssignToAgent(ctx context.Context, competenceID string, req *AssignCompetenceRequest) error
 	RemoveFromAgent(ctx context.Context, competenceID, agentID string) error
 	GetByAgent(ctx context.Context, agentID string) ([]CompetenceResponse, error)
 	GetExpiring(ctx context.Context, daysAhead int) ([]CompetenceResponse, error)
+	DeactivateExpired(ctx context.Context) (int, error)
 }
 
 type service struct {
@@ -40,6 +43,9 @@ func NewService(repo repository.CompetenceRepository, logger *zap.Logger) Servic
 
 // Create creates a new competence
 func (s *service) Create(ctx context.Context, req *CreateCompetenceRequest) (*CompetenceResponse, error) {
+	ctx, span := tracing.Start(ctx, "competence.Create")
+	defer span.End()
+
 	input := &repository.CreateCompetenceInput{
 		ID:             uuid.New().String(),
 		Nom:            req.Nom,
@@ -60,6 +66,9 @@ func (s *service) Create(ctx context.Context, req *CreateCompetenceRequest) (*Co
 
 // GetByID gets a competence by ID
 func (s *service) GetByID(ctx context.Context, id string) (*CompetenceResponse, error) {
+	ctx, span := tracing.Start(ctx, "competence.GetByID")
+	defer span.End()
+
 	competence, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -70,6 +79,9 @@ func (s *service) GetByID(ctx context.Context, id string) (*CompetenceResponse,
 
 // GetByNom gets a competence by nom
 func (s *service) GetByNom(ctx context.Context, nom string) (*CompetenceResponse, error) {
+	ctx, span := tracing.Start(ctx, "competence.GetByNom")
+	defer span.End()
+
 	competence, err := s.repo.GetByNom(ctx, nom)
 	if err != nil {
 		return nil, err
@@ -79,7 +91,10 @@ func (s *service) GetByNom(ctx context.Context, nom string) (*CompetenceResponse
 }
 
 // List lists competences with filters
-func (s *service) List(ctx context.Context, filters *ListCompetencesFilters) ([]CompetenceResponse, error) {
+func (s *service) List(ctx context.Context, filters *ListCompetencesFilters) ([]CompetenceResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "competence.List")
+	defer span.End()
+
 	repoFilters := &repository.CompetenceFilters{}
 
 	if filters != nil {
@@ -96,11 +111,17 @@ func (s *service) List(ctx context.Context, filters *ListCompetencesFilters) ([]
 		if filters.Organisme != "" {
 			repoFilters.Organisme = &filters.Organisme
 		}
+		repoFilters.Page = filters.Page
 	}
 
 	competences, err := s.repo.List(ctx, repoFilters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	competences, page, err := pagination.Trim(repoFilters.Page, competences)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	responses := make([]CompetenceResponse, len(competences))
@@ -108,11 +129,14 @@ func (s *service) List(ctx context.Context, filters *ListCompetencesFilters) ([]
 		responses[i] = *s.toResponse(comp)
 	}
 
-	return responses, nil
+	return responses, page, nil
 }
 
 // Update updates a competence
 func (s *service) Update(ctx context.Context, id string, req *UpdateCompetenceRequest) (*CompetenceResponse, error) {
+	ctx, span := tracing.Start(ctx, "competence.Update")
+	defer span.End()
+
 	input := &repository.UpdateCompetenceInput{
 		Nom:            req.Nom,
 		Description:    req.Description,
@@ -131,21 +155,33 @@ func (s *service) Update(ctx context.Context, id string, req *UpdateCompetenceRe
 
 // Delete deletes a competence
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "competence.Delete")
+	defer span.End()
+
 	return s.repo.Delete(ctx, id)
 }
 
 // AssignToAgent assigns a competence to an agent
 func (s *service) AssignToAgent(ctx context.Context, competenceID string, req *AssignCompetenceRequest) error {
+	ctx, span := tracing.Start(ctx, "competence.AssignToAgent")
+	defer span.End()
+
 	return s.repo.AssignToAgent(ctx, competenceID, req.AgentID)
 }
 
 // RemoveFromAgent removes a competence from an agent
 func (s *service) RemoveFromAgent(ctx context.Context, competenceID, agentID string) error {
+	ctx, span := tracing.Start(ctx, "competence.RemoveFromAgent")
+	defer span.End()
+
 	return s.repo.RemoveFromAgent(ctx, competenceID, agentID)
 }
 
 // GetByAgent gets competences for an agent
 func (s *service) GetByAgent(ctx context.Context, agentID string) ([]CompetenceResponse, error) {
+	ctx, span := tracing.Start(ctx, "competence.GetByAgent")
+	defer span.End()
+
 	competences, err := s.repo.GetByAgent(ctx, agentID)
 	if err != nil {
 		return nil, err
@@ -161,6 +197,9 @@ func (s *service) GetByAgent(ctx context.Context, agentID string) ([]CompetenceR
 
 // GetExpiring gets competences expiring within the specified number of days
 func (s *service) GetExpiring(ctx context.Context, daysAhead int) ([]CompetenceResponse, error) {
+	ctx, span := tracing.Start(ctx, "competence.GetExpiring")
+	defer span.End()
+
 	competences, err := s.repo.GetExpiring(ctx, daysAhead)
 	if err != nil {
 		return nil, err
@@ -174,6 +213,23 @@ func (s *service) GetExpiring(ctx context.Context, daysAhead int) ([]CompetenceR
 	return responses, nil
 }
 
+// DeactivateExpired deactivates competences whose expiration date has passed
+func (s *service) DeactivateExpired(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "competence.DeactivateExpired")
+	defer span.End()
+
+	count, err := s.repo.DeactivateExpired(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	if count > 0 {
+		s.logger.Info("Deactivated expired competences", zap.Int("count", count))
+	}
+
+	return count, nil
+}
+
 // toResponse converts ent.Competence to CompetenceResponse
 func (s *service) toResponse(comp *ent.Competence) *CompetenceResponse {
 	resp := &CompetenceResponse{
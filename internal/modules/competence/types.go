@@ -1,6 +1,11 @@
 package competence
 
-import "time"
+import (
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+)
 
 // CompetenceResponse represents a competence in API responses
 type CompetenceResponse struct {
@@ -58,4 +63,25 @@ type ListCompetencesFilters struct {
 	Active    string `query:"active"`
 	Search    string `query:"search"`
 	Organisme string `query:"organisme"`
+	Page      *pagination.Params // Set by the controller
+}
+
+// listSpec declares the sorts and fields accepted by GET /competences
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"nom":       "nom",
+		"type":      "type",
+		"createdAt": "created_at",
+	},
+	DefaultSort: "nom",
+	Fields:      pagination.JSONFields(CompetenceResponse{}),
+	Filters: filter.Fields{
+		"nom":            {Column: "nom", Type: filter.String},
+		"type":           {Column: "type", Type: filter.String},
+		"organisme":      {Column: "organisme", Type: filter.String},
+		"active":         {Column: "active", Type: filter.Bool},
+		"dateObtention":  {Column: "date_obtention", Type: filter.Time},
+		"dateExpiration": {Column: "date_expiration", Type: filter.Time},
+		"createdAt":      {Column: "created_at", Type: filter.Time},
+	},
 }
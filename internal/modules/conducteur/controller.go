@@ -4,6 +4,8 @@ import (
 	"strconv"
 
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
@@ -23,23 +25,23 @@ func NewController(service Service) interfaces.Controller {
 
 // RegisterRoutes registers conducteur routes
 func (c *Controller) RegisterRoutes(g *echo.Group) {
-	group := g.Group("/conducteurs")
+	group := rbac.Guard(g).Group("/conducteurs")
 
 	// Public endpoints
-	group.GET("", c.ListConducteurs)
-	group.GET("/:id", c.GetConducteur)
-	group.GET("/search", c.SearchConducteurs)
+	group.GET("", c.ListConducteurs, rbac.PermReadConducteurs)
+	group.GET("/:id", c.GetConducteur, rbac.PermReadConducteurs)
+	group.GET("/search", c.SearchConducteurs, rbac.PermReadConducteurs)
 
 	// Protected endpoints
-	group.POST("", c.CreateConducteur)
-	group.PUT("/:id", c.UpdateConducteur)
-	group.DELETE("/:id", c.DeleteConducteur)
+	group.POST("", c.CreateConducteur, rbac.PermCreateConducteurs)
+	group.PUT("/:id", c.UpdateConducteur, rbac.PermUpdateConducteurs)
+	group.DELETE("/:id", c.DeleteConducteur, rbac.PermDeleteConducteurs)
 
 	// Additional endpoints
-	group.GET("/permis/:numeroPermis", c.GetByNumeroPermis)
-	group.GET("/email/:email", c.GetByEmail)
-	group.GET("/nom/:nom/prenom/:prenom", c.GetByNomPrenom)
-	group.GET("/:id/statistics", c.GetStatistics)
+	group.GET("/permis/:numeroPermis", c.GetByNumeroPermis, rbac.PermReadConducteurs)
+	group.GET("/email/:email", c.GetByEmail, rbac.PermReadConducteurs)
+	group.GET("/nom/:nom/prenom/:prenom", c.GetByNomPrenom, rbac.PermReadConducteurs)
+	group.GET("/:id/statistics", c.GetStatistics, rbac.PermReadConducteurs)
 }
 
 // ListConducteurs lists conducteurs with filters
@@ -69,27 +71,19 @@ func (c *Controller) ListConducteurs(ctx echo.Context) error {
 		}
 	}
 
-	// Pagination
-	if limit := ctx.QueryParam("limit"); limit != "" {
-		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
-			request.Limit = l
-		}
-	} else {
-		request.Limit = 50
-	}
-
-	if offset := ctx.QueryParam("offset"); offset != "" {
-		if o, err := strconv.Atoi(offset); err == nil && o >= 0 {
-			request.Offset = o
-		}
+	// Pagination, tri et champs
+	page, err := pagination.Parse(ctx, listSpec)
+	if err != nil {
+		return responses.BadRequest(ctx, err.Error())
 	}
+	request.Page = page
 
-	result, err := c.service.List(ctx.Request().Context(), request)
+	conducteurs, result, err := c.service.List(ctx.Request().Context(), request)
 	if err != nil {
 		return responses.InternalServerError(ctx, "Failed to list conducteurs")
 	}
 
-	return responses.Success(ctx, result)
+	return responses.Paginated(ctx, conducteurs, result)
 }
 
 // GetConducteur gets a conducteur by ID
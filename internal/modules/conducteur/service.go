@@ -9,6 +9,8 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -20,7 +22,7 @@ type Service interface {
 	GetByID(ctx context.Context, id string) (*ConducteurResponse, error)
 	GetByNumeroPermis(ctx context.Context, numeroPermis string) (*ConducteurResponse, error)
 	GetByEmail(ctx context.Context, email string) (*ConducteurResponse, error)
-	List(ctx context.Context, filters *ListConducteursRequest) (*ListConducteursResponse, error)
+	List(ctx context.Context, filters *ListConducteursRequest) ([]*ConducteurResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, input *UpdateConducteurRequest) (*ConducteurResponse, error)
 	Delete(ctx context.Context, id string) error
 	Search(ctx context.Context, query string) (*SearchConducteursResponse, error)
@@ -44,6 +46,9 @@ func NewService(repo repository.ConducteurRepository, logger *zap.Logger) Servic
 
 // Create creates a new conducteur
 func (s *service) Create(ctx context.Context, input *CreateConducteurRequest) (*ConducteurResponse, error) {
+	ctx, span := tracing.Start(ctx, "conducteur.Create")
+	defer span.End()
+
 	// Validation métier
 	if err := s.validateCreateInput(input); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
@@ -97,6 +102,9 @@ func (s *service) Create(ctx context.Context, input *CreateConducteurRequest) (*
 
 // GetByID gets conducteur by ID
 func (s *service) GetByID(ctx context.Context, id string) (*ConducteurResponse, error) {
+	ctx, span := tracing.Start(ctx, "conducteur.GetByID")
+	defer span.End()
+
 	conducteurEnt, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -107,6 +115,9 @@ func (s *service) GetByID(ctx context.Context, id string) (*ConducteurResponse,
 
 // GetByNumeroPermis gets conducteur by numero permis
 func (s *service) GetByNumeroPermis(ctx context.Context, numeroPermis string) (*ConducteurResponse, error) {
+	ctx, span := tracing.Start(ctx, "conducteur.GetByNumeroPermis")
+	defer span.End()
+
 	conducteurEnt, err := s.repo.GetByNumeroPermis(ctx, numeroPermis)
 	if err != nil {
 		return nil, err
@@ -117,6 +128,9 @@ func (s *service) GetByNumeroPermis(ctx context.Context, numeroPermis string) (*
 
 // GetByEmail gets conducteur by email
 func (s *service) GetByEmail(ctx context.Context, email string) (*ConducteurResponse, error) {
+	ctx, span := tracing.Start(ctx, "conducteur.GetByEmail")
+	defer span.End()
+
 	normalizedEmail := s.normalizeEmail(email)
 	conducteurEnt, err := s.repo.GetByEmail(ctx, normalizedEmail)
 	if err != nil {
@@ -127,7 +141,10 @@ func (s *service) GetByEmail(ctx context.Context, email string) (*ConducteurResp
 }
 
 // List gets conducteurs with filters
-func (s *service) List(ctx context.Context, input *ListConducteursRequest) (*ListConducteursResponse, error) {
+func (s *service) List(ctx context.Context, input *ListConducteursRequest) ([]*ConducteurResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "conducteur.List")
+	defer span.End()
+
 	filters := &repository.ConducteurFilters{
 		Nom:         input.Nom,
 		Prenom:      input.Prenom,
@@ -136,11 +153,17 @@ func (s *service) List(ctx context.Context, input *ListConducteursRequest) (*Lis
 		Active:      input.Active,
 		Limit:       input.Limit,
 		Offset:      input.Offset,
+		Page:        input.Page,
 	}
 
 	conducteursEnt, err := s.repo.List(ctx, filters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	conducteursEnt, page, err := pagination.Trim(input.Page, conducteursEnt)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	conducteurs := make([]*ConducteurResponse, len(conducteursEnt))
@@ -148,14 +171,14 @@ func (s *service) List(ctx context.Context, input *ListConducteursRequest) (*Lis
 		conducteurs[i] = s.entityToResponse(c)
 	}
 
-	return &ListConducteursResponse{
-		Conducteurs: conducteurs,
-		Total:       len(conducteurs),
-	}, nil
+	return conducteurs, page, nil
 }
 
 // Update updates conducteur
 func (s *service) Update(ctx context.Context, id string, input *UpdateConducteurRequest) (*ConducteurResponse, error) {
+	ctx, span := tracing.Start(ctx, "conducteur.Update")
+	defer span.End()
+
 	// Validation métier
 	if err := s.validateUpdateInput(input); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
@@ -196,11 +219,17 @@ func (s *service) Update(ctx context.Context, id string, input *UpdateConducteur
 
 // Delete deletes conducteur
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "conducteur.Delete")
+	defer span.End()
+
 	return s.repo.Delete(ctx, id)
 }
 
 // Search searches conducteurs
 func (s *service) Search(ctx context.Context, query string) (*SearchConducteursResponse, error) {
+	ctx, span := tracing.Start(ctx, "conducteur.Search")
+	defer span.End()
+
 	conducteursEnt, err := s.repo.Search(ctx, query)
 	if err != nil {
 		return nil, err
@@ -220,6 +249,9 @@ func (s *service) Search(ctx context.Context, query string) (*SearchConducteursR
 
 // GetByNomPrenom gets conducteurs by nom and prenom
 func (s *service) GetByNomPrenom(ctx context.Context, nom, prenom string) (*ListConducteursResponse, error) {
+	ctx, span := tracing.Start(ctx, "conducteur.GetByNomPrenom")
+	defer span.End()
+
 	conducteursEnt, err := s.repo.GetByNomPrenom(ctx, nom, prenom)
 	if err != nil {
 		return nil, err
@@ -238,6 +270,9 @@ func (s *service) GetByNomPrenom(ctx context.Context, nom, prenom string) (*List
 
 // GetStatistics gets statistics for a conducteur
 func (s *service) GetStatistics(ctx context.Context, conducteurID string) (*ConducteurStatisticsResponse, error) {
+	ctx, span := tracing.Start(ctx, "conducteur.GetStatistics")
+	defer span.End()
+
 	// Récupérer le conducteur avec ses relations
 	conducteurEnt, err := s.repo.GetByID(ctx, conducteurID)
 	if err != nil {
@@ -2,6 +2,9 @@ package conducteur
 
 import (
 	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 )
 
 // Request types
@@ -54,6 +57,31 @@ type ListConducteursRequest struct {
 	Active      *bool   `json:"active,omitempty"`
 	Limit       int     `json:"limit,omitempty"`
 	Offset      int     `json:"offset,omitempty"`
+	Page        *pagination.Params `json:"-"`
+}
+
+// listSpec declares the sorts and fields accepted by GET /conducteurs
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"created_at": "created_at",
+		"nom":        "nom",
+		"prenom":     "prenom",
+	},
+	DefaultSort: "-created_at",
+	Fields:      pagination.JSONFields(ConducteurResponse{}),
+	Filters: filter.Fields{
+		"nom":                 {Column: "nom", Type: filter.String},
+		"prenom":              {Column: "prenom", Type: filter.String},
+		"ville":               {Column: "ville", Type: filter.String},
+		"nationalite":         {Column: "nationalite", Type: filter.String},
+		"numero_permis":       {Column: "numero_permis", Type: filter.String},
+		"numero_cni":          {Column: "numero_cni", Type: filter.String},
+		"points_permis":       {Column: "points_permis", Type: filter.Int},
+		"permis_valide_jusqu": {Column: "permis_valide_jusqu", Type: filter.Time},
+		"date_naissance":      {Column: "date_naissance", Type: filter.Time},
+		"active":              {Column: "active", Type: filter.Bool},
+		"created_at":          {Column: "created_at", Type: filter.Time},
+	},
 }
 
 // Response types
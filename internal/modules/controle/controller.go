@@ -1,11 +1,12 @@
 package controle
 
 import (
-	"strconv"
 	"time"
 
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
 	"police-trafic-api-frontend-aligned/internal/modules/verification"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
@@ -27,32 +28,32 @@ func NewController(service Service, verificationService verification.Service) in
 
 // RegisterRoutes registers controle routes
 func (c *Controller) RegisterRoutes(g *echo.Group) {
-	group := g.Group("/controles")
+	group := rbac.Guard(g).Group("/controles")
 
 	// List all controles
-	group.GET("", c.ListControles)
+	group.GET("", c.ListControles, rbac.PermReadControles)
 
 	// Additional endpoints with fixed paths (must be before /:id)
-	group.GET("/agent/:agentId", c.GetControlesByAgent)
-	group.GET("/vehicule/:vehiculeId", c.GetControlesByVehicule)
-	group.GET("/conducteur/:conducteurId", c.GetControlesByConducteur)
-	group.GET("/statistics", c.GetStatistics)
+	group.GET("/agent/:agentId", c.GetControlesByAgent, rbac.PermReadControles)
+	group.GET("/vehicule/:vehiculeId", c.GetControlesByVehicule, rbac.PermReadControles)
+	group.GET("/conducteur/:conducteurId", c.GetControlesByConducteur, rbac.PermReadControles)
+	group.GET("/statistics", c.GetStatistics, rbac.PermReadControles)
 
 	// Nested routes under /:id (must be before single /:id GET)
-	group.GET("/:id/verifications", c.GetVerifications)
-	group.POST("/:id/verifications", c.SaveVerifications)
-	group.POST("/:id/pv", c.GeneratePV)
-	group.PATCH("/:id/statut", c.ChangerStatut)
-	group.POST("/:id/archive", c.Archive)
-	group.POST("/:id/unarchive", c.Unarchive)
+	group.GET("/:id/verifications", c.GetVerifications, rbac.PermReadControles)
+	group.POST("/:id/verifications", c.SaveVerifications, rbac.PermUpdateControles)
+	group.POST("/:id/pv", c.GeneratePV, rbac.PermCreatePV)
+	group.PATCH("/:id/statut", c.ChangerStatut, rbac.PermUpdateControles)
+	group.POST("/:id/archive", c.Archive, rbac.PermUpdateControles)
+	group.POST("/:id/unarchive", c.Unarchive, rbac.PermUpdateControles)
 
 	// Single controle by ID (should be last among GET routes with :id)
-	group.GET("/:id", c.GetControle)
+	group.GET("/:id", c.GetControle, rbac.PermReadControles)
 
 	// Create/Update/Delete
-	group.POST("", c.CreateControle)
-	group.PUT("/:id", c.UpdateControle)
-	group.DELETE("/:id", c.DeleteControle)
+	group.POST("", c.CreateControle, rbac.PermCreateControles)
+	group.PUT("/:id", c.UpdateControle, rbac.PermUpdateControles)
+	group.DELETE("/:id", c.DeleteControle, rbac.PermDeleteControles)
 }
 
 // ListControles lists controles with filters
@@ -100,27 +101,19 @@ func (c *Controller) ListControles(ctx echo.Context) error {
 		request.IsArchived = &archived
 	}
 
-	// Pagination
-	if limit := ctx.QueryParam("limit"); limit != "" {
-		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
-			request.Limit = l
-		}
-	} else {
-		request.Limit = 50 // Default limit
-	}
-	
-	if offset := ctx.QueryParam("offset"); offset != "" {
-		if o, err := strconv.Atoi(offset); err == nil && o >= 0 {
-			request.Offset = o
-		}
+	// Pagination, tri et champs
+	page, err := pagination.Parse(ctx, listSpec)
+	if err != nil {
+		return responses.BadRequest(ctx, err.Error())
 	}
+	request.Page = page
 	
-	result, err := c.service.List(ctx.Request().Context(), request)
+	controles, result, err := c.service.List(ctx.Request().Context(), request)
 	if err != nil {
 		return responses.InternalServerError(ctx, "Failed to list controles: " + err.Error())
 	}
 	
-	return responses.Success(ctx, result)
+	return responses.Paginated(ctx, controles, result)
 }
 
 // GetControle gets a controle by ID
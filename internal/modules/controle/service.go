@@ -6,7 +6,10 @@ import (
 	"time"
 
 	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/metrics"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -16,7 +19,7 @@ import (
 type Service interface {
 	Create(ctx context.Context, input *CreateControleRequest) (*ControleResponse, error)
 	GetByID(ctx context.Context, id string) (*ControleResponse, error)
-	List(ctx context.Context, filters *ListControlesRequest) (*ListControlesResponse, error)
+	List(ctx context.Context, filters *ListControlesRequest) ([]*ControleResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, input *UpdateControleRequest) (*ControleResponse, error)
 	Delete(ctx context.Context, id string) error
 	GetByAgent(ctx context.Context, agentID string, filters *ListControlesRequest) (*ListControlesResponse, error)
@@ -58,6 +61,9 @@ func NewService(
 
 // Create creates a new controle
 func (s *service) Create(ctx context.Context, input *CreateControleRequest) (*ControleResponse, error) {
+	ctx, span := tracing.Start(ctx, "controle.Create")
+	defer span.End()
+
 	// Validation
 	if err := s.validateCreateInput(input); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
@@ -162,6 +168,9 @@ func (s *service) Create(ctx context.Context, input *CreateControleRequest) (*Co
 
 // GetByID gets controle by ID
 func (s *service) GetByID(ctx context.Context, id string) (*ControleResponse, error) {
+	ctx, span := tracing.Start(ctx, "controle.GetByID")
+	defer span.End()
+
 	controleEnt, err := s.controleRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -172,7 +181,10 @@ func (s *service) GetByID(ctx context.Context, id string) (*ControleResponse, er
 }
 
 // List gets controles with filters
-func (s *service) List(ctx context.Context, input *ListControlesRequest) (*ListControlesResponse, error) {
+func (s *service) List(ctx context.Context, input *ListControlesRequest) ([]*ControleResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "controle.List")
+	defer span.End()
+
 	filters := &repository.ControleFilters{
 		AgentID:                 input.AgentID,
 		VehiculeID:              input.VehiculeID,
@@ -185,18 +197,21 @@ func (s *service) List(ctx context.Context, input *ListControlesRequest) (*ListC
 		DateDebut:               input.DateDebut,
 		DateFin:                 input.DateFin,
 		IsArchived:              input.IsArchived,
-		Limit:                   input.Limit,
-		Offset:                  input.Offset,
+		Page:                    input.Page,
 	}
 
 	controlesEnt, err := s.controleRepo.List(ctx, filters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	total, err := s.controleRepo.Count(ctx, filters)
+	controlesEnt, page, err := pagination.Trim(input.Page, controlesEnt)
 	if err != nil {
-		total = len(controlesEnt)
+		return nil, nil, err
+	}
+
+	if total, err := s.controleRepo.Count(ctx, filters); err == nil {
+		page.SetTotal(total)
 	}
 
 	controles := make([]*ControleResponse, len(controlesEnt))
@@ -204,14 +219,14 @@ func (s *service) List(ctx context.Context, input *ListControlesRequest) (*ListC
 		controles[i] = s.entityToResponse(c)
 	}
 
-	return &ListControlesResponse{
-		Controles: controles,
-		Total:     total,
-	}, nil
+	return controles, page, nil
 }
 
 // Update updates controle
 func (s *service) Update(ctx context.Context, id string, input *UpdateControleRequest) (*ControleResponse, error) {
+	ctx, span := tracing.Start(ctx, "controle.Update")
+	defer span.End()
+
 	repoInput := &repository.UpdateControleInput{
 		DateControle:        input.DateControle,
 		LieuControle:        input.LieuControle,
@@ -242,11 +257,17 @@ func (s *service) Update(ctx context.Context, id string, input *UpdateControleRe
 
 // Delete deletes controle
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "controle.Delete")
+	defer span.End()
+
 	return s.controleRepo.Delete(ctx, id)
 }
 
 // GetByAgent gets controles by agent
 func (s *service) GetByAgent(ctx context.Context, agentID string, input *ListControlesRequest) (*ListControlesResponse, error) {
+	ctx, span := tracing.Start(ctx, "controle.GetByAgent")
+	defer span.End()
+
 	filters := s.buildFilters(input)
 	controlesEnt, err := s.controleRepo.GetByAgent(ctx, agentID, filters)
 	if err != nil {
@@ -266,6 +287,9 @@ func (s *service) GetByAgent(ctx context.Context, agentID string, input *ListCon
 
 // GetByVehicule gets controles by vehicule
 func (s *service) GetByVehicule(ctx context.Context, vehiculeID string) (*ListControlesResponse, error) {
+	ctx, span := tracing.Start(ctx, "controle.GetByVehicule")
+	defer span.End()
+
 	controlesEnt, err := s.controleRepo.GetByVehicule(ctx, vehiculeID)
 	if err != nil {
 		return nil, err
@@ -284,6 +308,9 @@ func (s *service) GetByVehicule(ctx context.Context, vehiculeID string) (*ListCo
 
 // GetByConducteur gets controles by conducteur
 func (s *service) GetByConducteur(ctx context.Context, conducteurID string) (*ListControlesResponse, error) {
+	ctx, span := tracing.Start(ctx, "controle.GetByConducteur")
+	defer span.End()
+
 	controlesEnt, err := s.controleRepo.GetByConducteur(ctx, conducteurID)
 	if err != nil {
 		return nil, err
@@ -302,6 +329,9 @@ func (s *service) GetByConducteur(ctx context.Context, conducteurID string) (*Li
 
 // GetByDateRange gets controles by date range
 func (s *service) GetByDateRange(ctx context.Context, start, end time.Time) (*ListControlesResponse, error) {
+	ctx, span := tracing.Start(ctx, "controle.GetByDateRange")
+	defer span.End()
+
 	controlesEnt, err := s.controleRepo.GetByDateRange(ctx, start, end)
 	if err != nil {
 		return nil, err
@@ -320,6 +350,9 @@ func (s *service) GetByDateRange(ctx context.Context, start, end time.Time) (*Li
 
 // GetStatistics gets statistics for controles
 func (s *service) GetStatistics(ctx context.Context, filters *StatisticsFilters) (*ControleStatisticsResponse, error) {
+	ctx, span := tracing.Start(ctx, "controle.GetStatistics")
+	defer span.End()
+
 	var repoFilters *repository.ControleStatsFilters
 	var agentID *string
 	var dateDebut, dateFin *time.Time
@@ -359,6 +392,9 @@ func (s *service) GetStatistics(ctx context.Context, filters *StatisticsFilters)
 
 // ChangerStatut changes the status of a controle
 func (s *service) ChangerStatut(ctx context.Context, controleID string, input *ChangerStatutRequest) (*ControleResponse, error) {
+	ctx, span := tracing.Start(ctx, "controle.ChangerStatut")
+	defer span.End()
+
 	s.logger.Info("Changing controle status",
 		zap.String("controle_id", controleID),
 		zap.String("new_status", input.Statut))
@@ -388,6 +424,9 @@ func (s *service) ChangerStatut(ctx context.Context, controleID string, input *C
 
 // GeneratePV generates a PV from a controle with specified infractions
 func (s *service) GeneratePV(ctx context.Context, controleID string, input *GeneratePVRequest) (*GeneratePVResponse, error) {
+	ctx, span := tracing.Start(ctx, "controle.GeneratePV")
+	defer span.End()
+
 	s.logger.Info("Generating PV for controle", zap.String("controle_id", controleID), zap.Int("nb_infractions", len(input.Infractions)))
 
 	// Verify controle exists
@@ -452,6 +491,7 @@ func (s *service) GeneratePV(ctx context.Context, controleID string, input *Gene
 		s.logger.Error("Failed to create PV", zap.Error(err))
 		return nil, fmt.Errorf("failed to create PV: %w", err)
 	}
+	metrics.PVEmitted.Inc("controle")
 
 	return &GeneratePVResponse{
 		ID:                 pvEnt.ID.String(),
@@ -467,6 +507,9 @@ func (s *service) GeneratePV(ctx context.Context, controleID string, input *Gene
 
 // Archive archives a controle
 func (s *service) Archive(ctx context.Context, id string) (*ControleResponse, error) {
+	ctx, span := tracing.Start(ctx, "controle.Archive")
+	defer span.End()
+
 	s.logger.Info("Archiving controle", zap.String("id", id))
 
 	controleEnt, err := s.controleRepo.Archive(ctx, id)
@@ -486,6 +529,9 @@ func (s *service) Archive(ctx context.Context, id string) (*ControleResponse, er
 
 // Unarchive unarchives a controle
 func (s *service) Unarchive(ctx context.Context, id string) (*ControleResponse, error) {
+	ctx, span := tracing.Start(ctx, "controle.Unarchive")
+	defer span.End()
+
 	s.logger.Info("Unarchiving controle", zap.String("id", id))
 
 	controleEnt, err := s.controleRepo.Unarchive(ctx, id)
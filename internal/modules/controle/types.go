@@ -2,6 +2,9 @@ package controle
 
 import (
 	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 )
 
 // Request types
@@ -85,6 +88,34 @@ type ListControlesRequest struct {
 	IsArchived              *bool      `json:"is_archived,omitempty"`
 	Limit                   int        `json:"limit,omitempty"`
 	Offset                  int        `json:"offset,omitempty"`
+	Page                    *pagination.Params `json:"-"`
+}
+
+// listSpec declares the sorts and fields accepted by GET /controles
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"date_controle":         "date_controle",
+		"created_at":            "created_at",
+		"statut":                "statut",
+		"type_controle":         "type_controle",
+		"montant_total_amendes": "montant_total_amendes",
+	},
+	DefaultSort: "-date_controle",
+	Fields:      pagination.JSONFields(ControleResponse{}),
+	Filters: filter.Fields{
+		"statut":                   {Column: "statut", Type: filter.String},
+		"type_controle":            {Column: "type_controle", Type: filter.String},
+		"date_controle":            {Column: "date_controle", Type: filter.Time},
+		"lieu_controle":            {Column: "lieu_controle", Type: filter.String},
+		"montant_total_amendes":    {Column: "montant_total_amendes", Type: filter.Int},
+		"verifications_echec":      {Column: "verifications_echec", Type: filter.Int},
+		"vehicule_immatriculation": {Column: "vehicule_immatriculation", Type: filter.String},
+		"vehicule_type":            {Column: "vehicule_type", Type: filter.String},
+		"conducteur_numero_permis": {Column: "conducteur_numero_permis", Type: filter.String},
+		"conducteur_nom":           {Column: "conducteur_nom", Type: filter.String},
+		"is_archived":              {Column: "is_archived", Type: filter.Bool},
+		"created_at":               {Column: "created_at", Type: filter.Time},
+	},
 }
 
 // Response types
@@ -2,10 +2,11 @@ package convocations
 
 import (
 	"net/http"
-	"strconv"
 	"time"
 
 	"police-trafic-api-frontend-aligned/internal/core/middleware"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
@@ -34,23 +35,23 @@ func (ctrl *Controller) RegisterRoutes(e *echo.Group) {
 	
 	// Le groupe est déjà sous /api/v1 avec auth middleware appliqué
 	// On crée juste le sous-groupe /convocations
-	convocations := e.Group("/convocations")
+	convocations := rbac.Guard(e).Group("/convocations")
 
 	// Routes principales
-	convocations.POST("", ctrl.Create)
-	convocations.GET("", ctrl.List)
+	convocations.POST("", ctrl.Create, rbac.PermCreateConvocations)
+	convocations.GET("", ctrl.List, rbac.PermReadConvocations)
 	
 	// Routes fixes (AVANT /:id pour éviter les conflits)
-	convocations.GET("/statistiques", ctrl.GetStatistiques)
-	convocations.GET("/dashboard", ctrl.GetDashboard)
+	convocations.GET("/statistiques", ctrl.GetStatistiques, rbac.PermReadConvocations)
+	convocations.GET("/dashboard", ctrl.GetDashboard, rbac.PermReadConvocations)
 	
 	// Route avec paramètre :id (APRÈS les routes fixes)
-	convocations.GET("/:id", ctrl.GetByID)
-	convocations.PATCH("/:id/statut", ctrl.UpdateStatut)
-	convocations.PATCH("/:id/reporter", ctrl.ReporterRdv)
-	convocations.POST("/:id/notifier", ctrl.Notifier)
-	convocations.POST("/:id/notes", ctrl.AjouterNote)
-	convocations.GET("/:id/pdf", ctrl.DownloadPDF)
+	convocations.GET("/:id", ctrl.GetByID, rbac.PermReadConvocations)
+	convocations.PATCH("/:id/statut", ctrl.UpdateStatut, rbac.PermUpdateConvocations)
+	convocations.PATCH("/:id/reporter", ctrl.ReporterRdv, rbac.PermUpdateConvocations)
+	convocations.POST("/:id/notifier", ctrl.Notifier, rbac.PermUpdateConvocations)
+	convocations.POST("/:id/notes", ctrl.AjouterNote, rbac.PermUpdateConvocations)
+	convocations.GET("/:id/pdf", ctrl.DownloadPDF, rbac.PermReadConvocations)
 	
 	ctrl.logger.Info("Convocations routes registered successfully",
 		zap.String("base_path", "/api/v1/convocations"),
@@ -125,22 +126,14 @@ func (ctrl *Controller) GetByID(c echo.Context) error {
 // List handles GET /convocations
 func (ctrl *Controller) List(c echo.Context) error {
 	// Parse filters
-	filters := &FilterConvocationsRequest{
-		Page:  1,
-		Limit: 5,
-	}
-
-	if page := c.QueryParam("page"); page != "" {
-		if p, err := strconv.Atoi(page); err == nil {
-			filters.Page = p
-		}
-	}
+	filters := &FilterConvocationsRequest{}
 
-	if limit := c.QueryParam("limit"); limit != "" {
-		if l, err := strconv.Atoi(limit); err == nil {
-			filters.Limit = l
-		}
+	// Pagination, tri et champs
+	page, err := pagination.Parse(c, listSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
 	}
+	filters.Page = page
 
 	if statut := c.QueryParam("statut"); statut != "" {
 		filters.Statut = &statut
@@ -180,20 +173,13 @@ func (ctrl *Controller) List(c echo.Context) error {
 	userID := getUserIDFromContext(c)
 	commissariatID := getCommissariatIDFromContext(c)
 
-	result, err := ctrl.service.List(c.Request().Context(), filters, role, userID, commissariatID)
+	convocations, result, err := ctrl.service.List(c.Request().Context(), filters, role, userID, commissariatID)
 	if err != nil {
 		ctrl.logger.Error("Failed to list convocations", zap.Error(err))
 		return responses.InternalServerError(c, err.Error())
 	}
 
-	// Return with data and pagination wrapper
-	return c.JSON(http.StatusOK, map[string]interface{}{
-		"success": true,
-		"data": map[string]interface{}{
-			"convocations": result.Convocations,
-			"pagination":   result.Pagination,
-		},
-	})
+	return responses.Paginated(c, convocations, result)
 }
 
 // UpdateStatut handles PATCH /convocations/:id/statut
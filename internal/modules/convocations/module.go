@@ -5,6 +5,8 @@ import (
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
 	"police-trafic-api-frontend-aligned/internal/core/middleware"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/notification"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
 
 	"go.uber.org/fx"
@@ -26,6 +28,8 @@ var Module = fx.Module("convocations",
 // NewConvocationsService creates a new convocations service for DI
 func NewConvocationsService(
 	client *ent.Client,
+	notificationService notification.Service,
+	numberingService numbering.Service,
 	cfg *config.Config,
 	logger *zap.Logger,
 ) Service {
@@ -34,7 +38,7 @@ func NewConvocationsService(
 	commissariatRepo := repository.NewCommissariatRepository(client, logger)
 	userRepo := repository.NewUserRepository(client, logger)
 	
-	return NewService(convocationRepo, commissariatRepo, userRepo, cfg, logger)
+	return NewService(convocationRepo, commissariatRepo, userRepo, notificationService, numberingService, cfg, logger)
 }
 
 // NewConvocationsController creates a new convocations controller for DI
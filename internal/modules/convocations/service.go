@@ -3,14 +3,18 @@ package convocations
 import (
 	"context"
 	"fmt"
-	"strconv"
 	"strings"
 	"time"
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/convocation"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/audittrail"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/notification"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -20,7 +24,7 @@ import (
 type Service interface {
 	Create(ctx context.Context, req *CreateConvocationRequest, agentID, commissariatID string) (*ConvocationResponse, error)
 	GetByID(ctx context.Context, id string) (*ConvocationResponse, error)
-	List(ctx context.Context, filters *FilterConvocationsRequest, role, userID, commissariatID string) (*ListConvocationsResponse, error)
+	List(ctx context.Context, filters *FilterConvocationsRequest, role, userID, commissariatID string) ([]ConvocationResponse, *pagination.Page, error)
 	UpdateStatut(ctx context.Context, id string, req *UpdateStatutConvocationRequest, agentID string) (*ConvocationResponse, error)
 	ReporterRdv(ctx context.Context, id string, req *ReporterRdvRequest, agentID string) (*ConvocationResponse, error)
 	Notifier(ctx context.Context, id string, req *NotifierRequest, agentID string) (*ConvocationResponse, error)
@@ -30,13 +34,18 @@ type Service interface {
 	GetDashboard(ctx context.Context, commissariatID *string, dateDebut, dateFin, periode *string) (*DashboardConvocationsResponse, error)
 }
 
+// auditResourceType identifies convocations in the audit log
+const auditResourceType = "Convocation"
+
 // service implements Service interface
 type service struct {
-	convocationRepo  repository.ConvocationRepository
-	commissariatRepo repository.CommissariatRepository
-	userRepo         repository.UserRepository
-	config           *config.Config
-	logger           *zap.Logger
+	convocationRepo     repository.ConvocationRepository
+	commissariatRepo    repository.CommissariatRepository
+	userRepo            repository.UserRepository
+	notificationService notification.Service
+	numberingService    numbering.Service
+	config              *config.Config
+	logger              *zap.Logger
 }
 
 // NewService creates a new convocations service
@@ -44,79 +53,27 @@ func NewService(
 	convocationRepo repository.ConvocationRepository,
 	commissariatRepo repository.CommissariatRepository,
 	userRepo repository.UserRepository,
+	notificationService notification.Service,
+	numberingService numbering.Service,
 	cfg *config.Config,
 	logger *zap.Logger,
 ) Service {
 	return &service{
-		convocationRepo:  convocationRepo,
-		commissariatRepo: commissariatRepo,
-		userRepo:         userRepo,
-		config:           cfg,
-		logger:           logger,
-	}
-}
-
-// generateNumero génère un numéro unique pour la convocation
-func (s *service) generateNumero(ctx context.Context, commissariatID string) (string, error) {
-	// Récupérer le commissariat pour obtenir la ville
-	commissariat, err := s.commissariatRepo.GetByID(ctx, commissariatID)
-	if err != nil {
-		return "", fmt.Errorf("commissariat not found")
-	}
-
-	year := time.Now().Year()
-
-	// Extraire les 3 premières lettres de la ville en majuscules
-	ville := strings.ToUpper(commissariat.Ville)
-	villePrefix := ville
-	if len(ville) > 3 {
-		villePrefix = ville[:3]
-	} else if len(ville) < 3 {
-		// Si la ville a moins de 3 lettres, compléter avec des X
-		villePrefix = ville + strings.Repeat("X", 3-len(ville))
-	}
-
-	// Chercher le dernier numéro de convocation pour cette année
-	filters := &repository.ConvocationFilters{
-		CommissariatID: &commissariatID,
-		Limit:          1000,
-		Offset:         0,
-	}
-
-	convocations, err := s.convocationRepo.List(ctx, filters)
-
-	nextNumber := 1
-
-	if err == nil && len(convocations) > 0 {
-		maxNum := 0
-		for _, conv := range convocations {
-			// Format: CONV-VILLE-COM-YYYY-NNNN
-			parts := strings.Split(conv.Numero, "-")
-			if len(parts) == 5 {
-				if num, err := strconv.Atoi(parts[4]); err == nil && num > maxNum {
-					maxNum = num
-				}
-			}
-		}
-		if maxNum > 0 {
-			nextNumber = maxNum + 1
-		}
+		convocationRepo:     convocationRepo,
+		commissariatRepo:    commissariatRepo,
+		userRepo:            userRepo,
+		notificationService: notificationService,
+		numberingService:    numberingService,
+		config:              cfg,
+		logger:              logger,
 	}
-
-	maxRetries := 10
-	for retry := 0; retry < maxRetries; retry++ {
-		numero := fmt.Sprintf("CONV-%s-COM-%d-%04d", villePrefix, year, nextNumber+retry)
-		_, err := s.convocationRepo.GetByNumero(ctx, numero)
-		if err != nil {
-			return numero, nil
-		}
-	}
-
-	return "", fmt.Errorf("failed to generate unique numero after %d retries", maxRetries)
 }
 
 // Create creates a new convocation with ALL 74 fields
 func (s *service) Create(ctx context.Context, req *CreateConvocationRequest, agentID, commissariatID string) (*ConvocationResponse, error) {
+	ctx, span := tracing.Start(ctx, "convocations.Create")
+	defer span.End()
+
 	// Validation basique des champs obligatoires
 	if req.Nom == "" || req.Prenom == "" || req.Telephone1 == "" {
 		return nil, fmt.Errorf("nom, prenom et telephone1 sont obligatoires")
@@ -137,12 +94,6 @@ func (s *service) Create(ctx context.Context, req *CreateConvocationRequest, age
 		return nil, fmt.Errorf("motif est obligatoire")
 	}
 
-	// Générer le numéro unique
-	numero, err := s.generateNumero(ctx, commissariatID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate numero: %w", err)
-	}
-
 	// Parser la date de création
 	dateCreation, err := time.Parse("2006-01-02", req.DateCreation)
 	if err != nil {
@@ -159,9 +110,19 @@ func (s *service) Create(ctx context.Context, req *CreateConvocationRequest, age
 		dateRdv = &parsed
 	}
 
+	// Réserver le numéro dans la séquence du commissariat
+	resv, err := s.numberingService.Reserve(ctx, &numbering.Request{
+		Type:           numbering.TypeConvocation,
+		CommissariatID: commissariatID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve numero: %w", err)
+	}
+	defer resv.Rollback()
+
 	// Créer le builder avec TOUS les champs
-	createBuilder := s.convocationRepo.Client().Convocation.Create().
-		SetNumero(numero).
+	createBuilder := resv.Client().Convocation.Create().
+		SetNumero(resv.Numero).
 		SetCommissariatID(uuid.MustParse(commissariatID)).
 		SetAgentID(uuid.MustParse(agentID))
 
@@ -470,12 +431,17 @@ func (s *service) Create(ctx context.Context, req *CreateConvocationRequest, age
 	createBuilder.SetHistorique(historiqueInitial)
 
 	// Créer la convocation
-	created, err := createBuilder.Save(ctx)
+	created, err := createBuilder.Save(resv.Context())
 	if err != nil {
 		s.logger.Error("failed to create convocation", zap.Error(err))
 		return nil, fmt.Errorf("failed to create convocation: %w", err)
 	}
 
+	if err := resv.Commit(); err != nil {
+		s.logger.Error("failed to create convocation", zap.Error(err))
+		return nil, fmt.Errorf("failed to create convocation: %w", err)
+	}
+
 	s.logger.Info("convocation created successfully",
 		zap.String("id", created.ID.String()),
 		zap.String("numero", created.Numero),
@@ -483,11 +449,19 @@ func (s *service) Create(ctx context.Context, req *CreateConvocationRequest, age
 	)
 
 	// Récupérer la convocation avec les relations
-	return s.GetByID(ctx, created.ID.String())
+	response, err := s.GetByID(ctx, created.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	audittrail.Snapshot(ctx, auditResourceType, response.ID, nil, response)
+	return response, nil
 }
 
 // GetByID retrieves a convocation by ID
 func (s *service) GetByID(ctx context.Context, id string) (*ConvocationResponse, error) {
+	ctx, span := tracing.Start(ctx, "convocations.GetByID")
+	defer span.End()
+
 	convocID, err := uuid.Parse(id)
 	if err != nil {
 		return nil, fmt.Errorf("invalid convocation ID: %w", err)
@@ -502,7 +476,10 @@ func (s *service) GetByID(ctx context.Context, id string) (*ConvocationResponse,
 }
 
 // List retrieves convocations with filters
-func (s *service) List(ctx context.Context, filters *FilterConvocationsRequest, role, userID, commissariatID string) (*ListConvocationsResponse, error) {
+func (s *service) List(ctx context.Context, filters *FilterConvocationsRequest, role, userID, commissariatID string) ([]ConvocationResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "convocations.List")
+	defer span.End()
+
 	repoFilters := &repository.ConvocationFilters{
 		Statut:          filters.Statut,
 		TypeConvocation: filters.TypeConvocation,
@@ -510,7 +487,6 @@ func (s *service) List(ctx context.Context, filters *FilterConvocationsRequest,
 		DateFin:         filters.DateFin,
 		Search:          filters.Search,
 		Page:            filters.Page,
-		Limit:           filters.Limit,
 	}
 
 	if role != "ADMIN" {
@@ -521,52 +497,41 @@ func (s *service) List(ctx context.Context, filters *FilterConvocationsRequest,
 
 	convocations, err := s.convocationRepo.List(ctx, repoFilters)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list convocations: %w", err)
+		return nil, nil, fmt.Errorf("failed to list convocations: %w", err)
+	}
+
+	convocations, page, err := pagination.Trim(filters.Page, convocations)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	total, err := s.convocationRepo.Count(ctx, repoFilters)
 	if err != nil {
-		return nil, fmt.Errorf("failed to count convocations: %w", err)
+		return nil, nil, fmt.Errorf("failed to count convocations: %w", err)
 	}
+	page.SetTotal(int(total))
 
 	responses := make([]ConvocationResponse, len(convocations))
 	for i, conv := range convocations {
 		responses[i] = *s.toResponse(conv)
 	}
 
-	page := filters.Page
-	if page < 1 {
-		page = 1
-	}
-	limit := filters.Limit
-	if limit < 1 {
-		limit = 10
-	}
-	totalPages := int(total) / limit
-	if int(total)%limit > 0 {
-		totalPages++
-	}
-
-	return &ListConvocationsResponse{
-		Convocations: responses,
-		Pagination: PaginationInfo{
-			Page:       page,
-			Limit:      limit,
-			Total:      total,
-			TotalPages: totalPages,
-		},
-	}, nil
+	return responses, page, nil
 }
 
 // UpdateStatut updates convocation status
 func (s *service) UpdateStatut(ctx context.Context, id string, req *UpdateStatutConvocationRequest, agentID string) (*ConvocationResponse, error) {
+	ctx, span := tracing.Start(ctx, "convocations.UpdateStatut")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	convocID, err := uuid.Parse(id)
 	if err != nil {
 		return nil, fmt.Errorf("invalid convocation ID: %w", err)
 	}
 
-	conv, err := s.convocationRepo.GetByID(ctx, convocID.String())
-	if err != nil {
+	if _, err := s.convocationRepo.GetByID(ctx, convocID.String()); err != nil {
 		return nil, fmt.Errorf("convocation not found: %w", err)
 	}
 
@@ -598,11 +563,6 @@ func (s *service) UpdateStatut(ctx context.Context, id string, req *UpdateStatut
 		updateBuilder.SetObservations(*req.Observations)
 	}
 
-	var historique []map[string]interface{}
-	if len(conv.Historique) > 0 {
-		historique = conv.Historique
-	}
-
 	nouvelleEntree := map[string]interface{}{
 		"date":    time.Now().Format("02/01/2006 15:04"),
 		"dateISO": time.Now().Format(time.RFC3339),
@@ -617,19 +577,27 @@ func (s *service) UpdateStatut(ctx context.Context, id string, req *UpdateStatut
 		nouvelleEntree["details"] = *req.Observations
 	}
 
-	historique = append(historique, nouvelleEntree)
-	updateBuilder.SetHistorique(historique)
+	// Ajout dans la requête SQL : une entrée écrite entre-temps n'est pas écrasée
+	updateBuilder.AppendHistorique([]map[string]interface{}{nouvelleEntree})
 
 	_, err = updateBuilder.Save(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update convocation: %w", err)
 	}
 
-	return s.GetByID(ctx, id)
+	response, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	audittrail.Snapshot(ctx, auditResourceType, id, before, response)
+	return response, nil
 }
 
 // GetStatistiques retrieves convocations statistics
 func (s *service) GetStatistiques(ctx context.Context, commissariatID *string, dateDebut, dateFin, periode *string) (*StatistiquesConvocationsResponse, error) {
+	ctx, span := tracing.Start(ctx, "convocations.GetStatistiques")
+	defer span.End()
+
 	// Calculer les plages de dates
 	debut, fin := s.calculateDateRange(dateDebut, dateFin, periode)
 
@@ -731,6 +699,9 @@ func (s *service) GetStatistiques(ctx context.Context, commissariatID *string, d
 
 // GetDashboard retrieves dashboard data
 func (s *service) GetDashboard(ctx context.Context, commissariatID *string, dateDebut, dateFin, periode *string) (*DashboardConvocationsResponse, error) {
+	ctx, span := tracing.Start(ctx, "convocations.GetDashboard")
+	defer span.End()
+
 	// Calculer les plages de dates
 	debut, fin := s.calculateDateRange(dateDebut, dateFin, periode)
 
@@ -1069,6 +1040,16 @@ func parseDateTime(dateStr string) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("invalid date format: %s", dateStr)
 }
 
+// snapshot returns the state of a convocation before a mutation, for the
+// audit log; nil when it cannot be read, the mutation then reports the error
+func (s *service) snapshot(ctx context.Context, id string) *ConvocationResponse {
+	before, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return before
+}
+
 // toResponse converts ent.Convocation to ConvocationResponse with ALL 74 fields
 func (s *service) toResponse(conv *ent.Convocation) *ConvocationResponse {
 	// Helper function
@@ -1246,6 +1227,11 @@ func getStringFromMap(m map[string]interface{}, key string) string {
 
 // ReporterRdv reports a convocation to a new date
 func (s *service) ReporterRdv(ctx context.Context, id string, req *ReporterRdvRequest, agentID string) (*ConvocationResponse, error) {
+	ctx, span := tracing.Start(ctx, "convocations.ReporterRdv")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	convocID, err := uuid.Parse(id)
 	if err != nil {
 		return nil, fmt.Errorf("invalid convocation ID: %w", err)
@@ -1289,12 +1275,6 @@ func (s *service) ReporterRdv(ctx context.Context, id string, req *ReporterRdvRe
 		SetDateRdv(nouvelleDate).
 		SetHeureRdv(req.NouvelleHeure)
 
-	// Ajouter à l'historique
-	var historique []map[string]interface{}
-	if len(conv.Historique) > 0 {
-		historique = conv.Historique
-	}
-
 	// Formater la nouvelle date pour l'affichage
 	nouvelleDateFormatee := nouvelleDate.Format("02/01/2006")
 
@@ -1307,19 +1287,29 @@ func (s *service) ReporterRdv(ctx context.Context, id string, req *ReporterRdvRe
 			ancienneDateStr, ancienneHeureStr, nouvelleDateFormatee, req.NouvelleHeure, req.Motif),
 	}
 
-	historique = append(historique, nouvelleEntree)
-	updateBuilder.SetHistorique(historique)
+	// Ajouter à l'historique
+	updateBuilder.AppendHistorique([]map[string]interface{}{nouvelleEntree})
 
 	_, err = updateBuilder.Save(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update convocation: %w", err)
 	}
 
-	return s.GetByID(ctx, id)
+	response, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	audittrail.Snapshot(ctx, auditResourceType, id, before, response)
+	return response, nil
 }
 
 // Notifier sends notifications to the convoqué
 func (s *service) Notifier(ctx context.Context, id string, req *NotifierRequest, agentID string) (*ConvocationResponse, error) {
+	ctx, span := tracing.Start(ctx, "convocations.Notifier")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	convocID, err := uuid.Parse(id)
 	if err != nil {
 		return nil, fmt.Errorf("invalid convocation ID: %w", err)
@@ -1351,12 +1341,6 @@ func (s *service) Notifier(ctx context.Context, id string, req *NotifierRequest,
 		updateBuilder.SetDateEnvoi(DateEnvoi)
 	}
 
-	// Ajouter à l'historique
-	var historique []map[string]interface{}
-	if len(conv.Historique) > 0 {
-		historique = conv.Historique
-	}
-
 	moyensStr := strings.Join(req.Moyens, ", ")
 	messageDetails := fmt.Sprintf("Notification envoyée par: %s", moyensStr)
 	if req.Message != nil && *req.Message != "" {
@@ -1371,25 +1355,83 @@ func (s *service) Notifier(ctx context.Context, id string, req *NotifierRequest,
 		"details": messageDetails,
 	}
 
-	historique = append(historique, nouvelleEntree)
-	updateBuilder.SetHistorique(historique)
+	// Ajouter à l'historique
+	updateBuilder.AppendHistorique([]map[string]interface{}{nouvelleEntree})
 
 	_, err = updateBuilder.Save(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update convocation: %w", err)
 	}
 
-	// TODO: Implémenter l'envoi réel des notifications (SMS, Email, etc.)
-	s.logger.Info("Notification sent",
-		zap.String("convocation_id", id),
-		zap.Strings("moyens", req.Moyens),
-	)
+	s.envoyerNotifications(ctx, conv, req)
 
-	return s.GetByID(ctx, id)
+	response, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	audittrail.Snapshot(ctx, auditResourceType, id, before, response)
+	return response, nil
+}
+
+// envoyerNotifications dispatches the convocation through each requested channel.
+// Delivery is asynchronous: failures are retried by the notification service.
+func (s *service) envoyerNotifications(ctx context.Context, conv *ent.Convocation, req *NotifierRequest) {
+	data := map[string]interface{}{
+		"Numero": conv.Numero,
+		"Nom":    strings.TrimSpace(conv.ConvoquePrenom + " " + conv.ConvoqueNom),
+		"Lieu":   conv.LieuRdv,
+	}
+	if conv.DateRdv != nil {
+		data["Date"] = conv.DateRdv.Format("02/01/2006")
+	}
+	if conv.HeureRdv != nil {
+		data["Heure"] = *conv.HeureRdv
+	}
+	if req.Message != nil {
+		data["Message"] = *req.Message
+	}
+
+	for _, moyen := range req.Moyens {
+		var channel, recipient string
+		switch strings.ToUpper(moyen) {
+		case "SMS":
+			channel, recipient = notification.ChannelSMS, conv.ConvoqueTelephone
+		case "EMAIL", "MAIL", "COURRIEL":
+			channel = notification.ChannelEmail
+			if conv.ConvoqueEmail != nil {
+				recipient = *conv.ConvoqueEmail
+			}
+		default:
+			// Remise en main propre, appel téléphonique...: rien à envoyer
+			s.logger.Debug("No notification channel for moyen", zap.String("moyen", moyen))
+			continue
+		}
+
+		_, err := s.notificationService.Send(ctx, &notification.SendRequest{
+			Channel:      channel,
+			Recipient:    recipient,
+			Template:     notification.TemplateConvocation,
+			Data:         data,
+			ResourceType: "Convocation",
+			ResourceID:   conv.ID.String(),
+		})
+		if err != nil {
+			s.logger.Warn("Failed to send convocation notification",
+				zap.String("convocation_id", conv.ID.String()),
+				zap.String("moyen", moyen),
+				zap.Error(err),
+			)
+		}
+	}
 }
 
 // AjouterNote adds a note to a convocation
 func (s *service) AjouterNote(ctx context.Context, id string, req *AjouterNoteRequest, agentID string) (*ConvocationResponse, error) {
+	ctx, span := tracing.Start(ctx, "convocations.AjouterNote")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	convocID, err := uuid.Parse(id)
 	if err != nil {
 		return nil, fmt.Errorf("invalid convocation ID: %w", err)
@@ -1424,12 +1466,6 @@ func (s *service) AjouterNote(ctx context.Context, id string, req *AjouterNoteRe
 		UpdateOneID(convocID).
 		SetObservations(newObservations)
 
-	// Ajouter à l'historique
-	var historique []map[string]interface{}
-	if len(conv.Historique) > 0 {
-		historique = conv.Historique
-	}
-
 	nouvelleEntree := map[string]interface{}{
 		"date":    time.Now().Format("02/01/2006 15:04"),
 		"dateISO": time.Now().Format(time.RFC3339),
@@ -1438,19 +1474,27 @@ func (s *service) AjouterNote(ctx context.Context, id string, req *AjouterNoteRe
 		"details": req.Note,
 	}
 
-	historique = append(historique, nouvelleEntree)
-	updateBuilder.SetHistorique(historique)
+	// Ajouter à l'historique
+	updateBuilder.AppendHistorique([]map[string]interface{}{nouvelleEntree})
 
 	_, err = updateBuilder.Save(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to add note: %w", err)
 	}
 
-	return s.GetByID(ctx, id)
+	response, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	audittrail.Snapshot(ctx, auditResourceType, id, before, response)
+	return response, nil
 }
 
 // GeneratePDF generates a PDF document for a convocation
 func (s *service) GeneratePDF(ctx context.Context, id string) ([]byte, error) {
+	ctx, span := tracing.Start(ctx, "convocations.GeneratePDF")
+	defer span.End()
+
 	conv, err := s.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("convocation not found: %w", err)
@@ -2,6 +2,9 @@ package convocations
 
 import (
 	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 )
 
 // StatutConvocation représente le statut de la convocation
@@ -317,22 +320,32 @@ type FilterConvocationsRequest struct {
 	DateDebut       *time.Time `json:"dateDebut,omitempty"`
 	DateFin         *time.Time `json:"dateFin,omitempty"`
 	Search          *string    `json:"search,omitempty"`
-	Page            int        `json:"page,omitempty" validate:"omitempty,min=1"`
-	Limit           int        `json:"limit,omitempty" validate:"omitempty,min=1"`
-}
-
-// ListConvocationsResponse représente la réponse de liste avec pagination
-type ListConvocationsResponse struct {
-	Convocations []ConvocationResponse `json:"convocations"`
-	Pagination   PaginationInfo        `json:"pagination"`
+	Page            *pagination.Params `json:"-"`
 }
 
-// PaginationInfo représente les informations de pagination
-type PaginationInfo struct {
-	Page       int   `json:"page"`
-	Limit      int   `json:"limit"`
-	Total      int64 `json:"total"`
-	TotalPages int   `json:"totalPages"`
+// listSpec declares the sorts and fields accepted by GET /convocations
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"dateCreation": "date_creation",
+		"createdAt":    "created_at",
+		"numero":       "numero",
+		"statut":       "statut",
+	},
+	DefaultSort: "-dateCreation",
+	Fields:      pagination.JSONFields(ConvocationResponse{}),
+	Filters: filter.Fields{
+		"numero":          {Column: "numero", Type: filter.String},
+		"typeConvocation": {Column: "type_convocation", Type: filter.String},
+		"statut":          {Column: "statut", Type: filter.String},
+		"qualiteConvoque": {Column: "qualite_convoque", Type: filter.String},
+		"modeEnvoi":       {Column: "mode_envoi", Type: filter.String},
+		"convoqueNom":     {Column: "convoque_nom", Type: filter.String},
+		"dateCreation":    {Column: "date_creation", Type: filter.Time},
+		"dateRdv":         {Column: "date_rdv", Type: filter.Time},
+		"dateEnvoi":       {Column: "date_envoi", Type: filter.Time},
+		"dateHonoration":  {Column: "date_honoration", Type: filter.Time},
+		"createdAt":       {Column: "created_at", Type: filter.Time},
+	},
 }
 
 // StatistiquesConvocationsResponse représente les statistiques des convocations
@@ -1,10 +1,17 @@
 package document
 
 import (
+	"errors"
+	"fmt"
+	"net/http"
 	"strconv"
 	"time"
 
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/media"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/storage"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
@@ -24,27 +31,33 @@ func NewDocumentController(service Service) interfaces.Controller {
 
 // RegisterRoutes registers document routes
 func (c *Controller) RegisterRoutes(g *echo.Group) {
-	group := g.Group("/documents")
+	group := rbac.Guard(g).Group("/documents")
 
 	// CRUD endpoints
-	group.GET("", c.ListDocuments)
-	group.GET("/:id", c.GetDocument)
-	group.POST("", c.UploadDocument)
-	group.PUT("/:id", c.UpdateDocument)
-	group.DELETE("/:id", c.DeleteDocument)
+	group.GET("", c.ListDocuments, rbac.PermReadDocuments)
+	group.GET("/:id", c.GetDocument, rbac.PermReadDocuments)
+	group.POST("", c.UploadDocument, rbac.PermCreateDocuments)
+	group.PUT("/:id", c.UpdateDocument, rbac.PermUpdateDocuments)
+	group.DELETE("/:id", c.DeleteDocument, rbac.PermDeleteDocuments)
 
-	// Download endpoint
-	group.GET("/:id/download", c.DownloadDocument)
+	// Download endpoints
+	group.GET("/:id/download", c.DownloadDocument, rbac.PermReadDocuments)
+	group.GET("/:id/thumbnail", c.GetThumbnail, rbac.PermReadDocuments)
+
+	// Signed download links, usable without token
+	group.POST("/:id/signed-url", c.SignURL, rbac.PermReadDocuments)
+	group.GET("/:id/downloads", c.ListDownloads, rbac.PermReadDocuments)
+	g.GET("/files/documents/:id", c.DownloadSigned)
 
 	// Related documents
-	group.GET("/controle/:controleId", c.GetByControle)
-	group.GET("/infraction/:infractionId", c.GetByInfraction)
-	group.GET("/pv/:pvId", c.GetByProcesVerbal)
-	group.GET("/recours/:recoursId", c.GetByRecours)
-	group.GET("/user/:userId", c.GetByUploader)
+	group.GET("/controle/:controleId", c.GetByControle, rbac.PermReadDocuments)
+	group.GET("/infraction/:infractionId", c.GetByInfraction, rbac.PermReadDocuments)
+	group.GET("/pv/:pvId", c.GetByProcesVerbal, rbac.PermReadDocuments)
+	group.GET("/recours/:recoursId", c.GetByRecours, rbac.PermReadDocuments)
+	group.GET("/user/:userId", c.GetByUploader, rbac.PermReadDocuments)
 
 	// Statistics
-	group.GET("/statistics", c.GetStatistics)
+	group.GET("/statistics", c.GetStatistics, rbac.PermReadDocuments)
 }
 
 // ListDocuments lists documents with filters
@@ -86,27 +99,19 @@ func (c *Controller) ListDocuments(ctx echo.Context) error {
 		request.ProcesVerbalID = &pvID
 	}
 
-	// Pagination
-	if limit := ctx.QueryParam("limit"); limit != "" {
-		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
-			request.Limit = l
-		}
-	} else {
-		request.Limit = 50 // Default limit
-	}
-
-	if offset := ctx.QueryParam("offset"); offset != "" {
-		if o, err := strconv.Atoi(offset); err == nil && o >= 0 {
-			request.Offset = o
-		}
+	// Pagination, tri et champs
+	page, err := pagination.Parse(ctx, listSpec)
+	if err != nil {
+		return responses.BadRequest(ctx, err.Error())
 	}
+	request.Page = page
 
-	result, err := c.service.List(ctx.Request().Context(), request)
+	documents, result, err := c.service.List(ctx.Request().Context(), request)
 	if err != nil {
 		return responses.InternalServerError(ctx, "Failed to list documents: "+err.Error())
 	}
 
-	return responses.Success(ctx, result)
+	return responses.Paginated(ctx, documents, result)
 }
 
 // GetDocument gets a document by ID
@@ -135,11 +140,6 @@ func (c *Controller) UploadDocument(ctx echo.Context) error {
 		return responses.BadRequest(ctx, "File is required")
 	}
 
-	// Vérifier la taille (max 10MB)
-	if file.Size > 10*1024*1024 {
-		return responses.BadRequest(ctx, "File too large (max 10MB)")
-	}
-
 	// Récupérer les paramètres
 	request := &UploadDocumentRequest{
 		TypeDocument: ctx.FormValue("type_document"),
@@ -174,6 +174,10 @@ func (c *Controller) UploadDocument(ctx echo.Context) error {
 
 	document, err := c.service.Upload(ctx.Request().Context(), file, request, userID)
 	if err != nil {
+		// Taille, type détecté ou image illisible : refus du fichier
+		if errors.Is(err, media.ErrTooLarge) || errors.Is(err, media.ErrTypeNotAllowed) || errors.Is(err, media.ErrInvalidImage) {
+			return responses.BadRequest(ctx, err.Error())
+		}
 		return responses.InternalServerError(ctx, "Failed to upload document: "+err.Error())
 	}
 
@@ -228,23 +232,111 @@ func (c *Controller) DownloadDocument(ctx echo.Context) error {
 		return responses.BadRequest(ctx, "ID is required")
 	}
 
-	// Récupérer les infos du document
-	document, err := c.service.GetByID(ctx.Request().Context(), id)
+	download, err := c.service.Download(ctx.Request().Context(), id)
 	if err != nil {
 		if err.Error() == "document not found" {
 			return responses.NotFound(ctx, "Document not found")
 		}
-		return responses.InternalServerError(ctx, "Failed to get document")
+		if errors.Is(err, storage.ErrNotFound) {
+			return responses.NotFound(ctx, "Document file not found")
+		}
+		return responses.InternalServerError(ctx, "Failed to get document file")
 	}
 
-	// Récupérer le chemin du fichier
-	filePath, err := c.service.GetFilePath(ctx.Request().Context(), id)
+	return serveFile(ctx, download, "attachment")
+}
+
+// GetThumbnail gets the thumbnail of an image document
+func (c *Controller) GetThumbnail(ctx echo.Context) error {
+	id := ctx.Param("id")
+	if id == "" {
+		return responses.BadRequest(ctx, "ID is required")
+	}
+
+	download, err := c.service.Thumbnail(ctx.Request().Context(), id)
 	if err != nil {
-		return responses.InternalServerError(ctx, "Failed to get file path")
+		switch {
+		case err.Error() == "document not found", errors.Is(err, ErrNoThumbnail):
+			return responses.NotFound(ctx, "Thumbnail not found")
+		case errors.Is(err, storage.ErrNotFound):
+			return responses.NotFound(ctx, "Document file not found")
+		}
+		return responses.InternalServerError(ctx, "Failed to get thumbnail")
 	}
 
-	// Renvoyer le fichier
-	return ctx.Attachment(filePath, document.NomOriginal)
+	ctx.Response().Header().Set("Cache-Control", "private, max-age=3600")
+	return serveFile(ctx, download, "inline")
+}
+
+// SignURL issues a signed download link
+func (c *Controller) SignURL(ctx echo.Context) error {
+	id := ctx.Param("id")
+	if id == "" {
+		return responses.BadRequest(ctx, "ID is required")
+	}
+
+	var request SignURLRequest
+	if err := ctx.Bind(&request); err != nil {
+		return responses.BadRequest(ctx, "Invalid request")
+	}
+
+	link, err := c.service.SignURL(ctx.Request().Context(), id, &request)
+	if err != nil {
+		if err.Error() == "document not found" {
+			return responses.NotFound(ctx, "Document not found")
+		}
+		if errors.Is(err, ErrInvalidLinkRequest) {
+			return responses.BadRequest(ctx, err.Error())
+		}
+		return responses.InternalServerError(ctx, "Failed to sign download link")
+	}
+
+	return responses.Created(ctx, link)
+}
+
+// DownloadSigned downloads a document through a signed link, without token
+func (c *Controller) DownloadSigned(ctx echo.Context) error {
+	client := &DownloadClient{
+		IPAddress: ctx.RealIP(),
+		UserAgent: ctx.Request().UserAgent(),
+	}
+	download, err := c.service.DownloadSigned(ctx.Request().Context(), ctx.Param("id"), ctx.QueryParams(), client)
+	if err != nil {
+		switch {
+		case errors.Is(err, ErrInvalidLink), errors.Is(err, ErrLinkExpired), errors.Is(err, ErrLinkUsed):
+			return responses.Forbidden(ctx, err.Error())
+		case err.Error() == "document not found", errors.Is(err, storage.ErrNotFound), errors.Is(err, ErrNoThumbnail):
+			return responses.NotFound(ctx, "Document not found")
+		}
+		return responses.InternalServerError(ctx, "Failed to get document file")
+	}
+
+	// Les liens publics peuvent être mis en cache jusqu'à leur expiration, jamais les autres
+	if download.Public {
+		maxAge := int(time.Until(download.ExpiresAt).Seconds())
+		ctx.Response().Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+	} else {
+		ctx.Response().Header().Set("Cache-Control", "private, no-store")
+	}
+	return serveFile(ctx, download, "inline")
+}
+
+// ListDownloads lists the downloads of a document through signed links
+func (c *Controller) ListDownloads(ctx echo.Context) error {
+	id := ctx.Param("id")
+	if id == "" {
+		return responses.BadRequest(ctx, "ID is required")
+	}
+
+	downloads, err := c.service.ListDownloads(ctx.Request().Context(), id)
+	if err != nil {
+		if err.Error() == "document not found" {
+			return responses.NotFound(ctx, "Document not found")
+		}
+		return responses.InternalServerError(ctx, "Failed to list downloads")
+	}
+
+	return responses.Success(ctx, downloads)
 }
 
 // GetByControle gets documents by controle ID
@@ -332,6 +424,27 @@ func (c *Controller) GetStatistics(ctx echo.Context) error {
 	return responses.Success(ctx, stats)
 }
 
+// serveFile redirects to the storage backend, or streams the file through
+// the API, with the given disposition (attachment or inline)
+func serveFile(ctx echo.Context, download *FileDownload, disposition string) error {
+	// Stockage S3 : téléchargement direct depuis le bucket
+	if download.RedirectURL != "" {
+		return ctx.Redirect(http.StatusFound, download.RedirectURL)
+	}
+
+	// Sinon le fichier est relayé par l'API, sans interprétation par le navigateur
+	defer download.Content.Close()
+	ctx.Response().Header().Set(echo.HeaderXContentTypeOptions, "nosniff")
+	ctx.Response().Header().Set(echo.HeaderContentSecurityPolicy, "sandbox")
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("%s; filename=%q", disposition, download.NomOriginal))
+	ctx.Response().Header().Set(echo.HeaderContentLength, strconv.FormatInt(download.Taille, 10))
+	contentType := download.TypeMime
+	if contentType == "" {
+		contentType = echo.MIMEOctetStream
+	}
+	return ctx.Stream(http.StatusOK, contentType, download.Content)
+}
+
 // getUserIDFromContext extracts user ID from the Echo context
 func getUserIDFromContext(ctx echo.Context) string {
 	// D'abord essayer le contexte JWT standard
@@ -2,7 +2,9 @@ package document
 
 import (
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/storage"
 
 	"go.uber.org/fx"
 	"go.uber.org/zap"
@@ -23,9 +25,11 @@ var Module = fx.Module("document",
 // NewDocumentServiceProvider creates a new document service for DI
 func NewDocumentServiceProvider(
 	documentRepo repository.DocumentRepository,
+	backend storage.Backend,
+	cfg *config.Config,
 	logger *zap.Logger,
 ) Service {
-	return NewDocumentService(documentRepo, logger)
+	return NewDocumentService(documentRepo, backend, cfg, logger)
 }
 
 // NewDocumentControllerProvider creates a new document controller for DI
@@ -1,18 +1,28 @@
 package document
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/url"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/media"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/storage"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -22,7 +32,7 @@ import (
 type Service interface {
 	Upload(ctx context.Context, file *multipart.FileHeader, input *UploadDocumentRequest, userID string) (*DocumentResponse, error)
 	GetByID(ctx context.Context, id string) (*DocumentResponse, error)
-	List(ctx context.Context, filters *ListDocumentsRequest) (*ListDocumentsResponse, error)
+	List(ctx context.Context, filters *ListDocumentsRequest) ([]*DocumentResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, input *UpdateDocumentRequest) (*DocumentResponse, error)
 	Delete(ctx context.Context, id string) error
 	GetByControle(ctx context.Context, controleID string) (*ListDocumentsResponse, error)
@@ -31,47 +41,73 @@ type Service interface {
 	GetByRecours(ctx context.Context, recoursID string) (*ListDocumentsResponse, error)
 	GetByUploader(ctx context.Context, userID string) (*ListDocumentsResponse, error)
 	GetStatistics(ctx context.Context) (*DocumentStatisticsResponse, error)
-	GetFilePath(ctx context.Context, id string) (string, error)
+	Download(ctx context.Context, id string) (*FileDownload, error)
+	// Thumbnail gets the thumbnail of an image document
+	Thumbnail(ctx context.Context, id string) (*FileDownload, error)
+	// SignURL issues a link downloading the document without token
+	SignURL(ctx context.Context, id string, input *SignURLRequest) (*SignedURLResponse, error)
+	// DownloadSigned checks a signed link and records the download
+	DownloadSigned(ctx context.Context, id string, query url.Values, client *DownloadClient) (*FileDownload, error)
+	ListDownloads(ctx context.Context, id string) ([]*DownloadResponse, error)
 }
 
+var (
+	// ErrInvalidLinkRequest is returned for a signed link lifetime out of bounds
+	ErrInvalidLinkRequest = errors.New("invalid download link request")
+	// ErrNoThumbnail is returned for the thumbnail of a document that is not an image
+	ErrNoThumbnail = errors.New("document has no thumbnail")
+)
+
+// Copies of the image documents, stored next to the original which is kept
+// as uploaded (evidence)
+const (
+	variantPublic    = "public"    // Re-encoded without metadata, served through public links
+	variantThumbnail = "thumbnail" // JPEG, for the lists of the frontend
+)
+
 // service implements Service interface
 type service struct {
-	documentRepo repository.DocumentRepository
-	logger       *zap.Logger
-	uploadDir    string
-	baseURL      string
+	documentRepo  repository.DocumentRepository
+	storage       storage.Backend
+	presignExpiry time.Duration
+	links         config.SignedURLConfig
+	signer        *linkSigner
+	policy        *media.Policy
+	uploads       config.UploadConfig
+	logger        *zap.Logger
+	baseURL       string
 }
 
 // NewDocumentService creates a new document service
 func NewDocumentService(
 	documentRepo repository.DocumentRepository,
+	backend storage.Backend,
+	cfg *config.Config,
 	logger *zap.Logger,
 ) Service {
-	uploadDir := os.Getenv("UPLOAD_DIR")
-	if uploadDir == "" {
-		uploadDir = "./uploads"
-	}
-
 	baseURL := os.Getenv("BASE_URL")
 	if baseURL == "" {
 		baseURL = "http://localhost:8080"
 	}
 
-	// Créer le répertoire d'upload s'il n'existe pas
-	if err := os.MkdirAll(uploadDir, 0755); err != nil {
-		logger.Error("Failed to create upload directory", zap.Error(err))
-	}
-
 	return &service{
-		documentRepo: documentRepo,
-		logger:       logger,
-		uploadDir:    uploadDir,
-		baseURL:      baseURL,
+		documentRepo:  documentRepo,
+		storage:       backend,
+		presignExpiry: cfg.Storage.PresignExpiry,
+		links:         cfg.Storage.SignedURLs,
+		signer:        newLinkSigner(cfg.Storage.SignedURLs, cfg.JWT.Secret),
+		policy:        media.NewPolicy(cfg.Uploads),
+		uploads:       cfg.Uploads,
+		logger:        logger,
+		baseURL:       baseURL,
 	}
 }
 
 // Upload uploads a new document
 func (s *service) Upload(ctx context.Context, file *multipart.FileHeader, input *UploadDocumentRequest, userID string) (*DocumentResponse, error) {
+	ctx, span := tracing.Start(ctx, "document.Upload")
+	defer span.End()
+
 	if err := s.validateUploadInput(input); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
@@ -83,37 +119,50 @@ func (s *service) Upload(ctx context.Context, file *multipart.FileHeader, input
 	}
 	defer src.Close()
 
-	// Générer un nom de fichier unique
-	ext := filepath.Ext(file.Filename)
-	nomFichier := fmt.Sprintf("%s%s", uuid.New().String(), ext)
+	// Type détecté depuis le contenu : l'en-tête et l'extension du client ne sont pas fiables
+	head := make([]byte, media.SniffLen)
+	n, err := io.ReadFull(src, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+	head = head[:n]
+	typeMime := media.Detect(head)
+	if err := s.policy.Check(input.TypeDocument, file.Size, typeMime); err != nil {
+		return nil, fmt.Errorf("validation error: %w", err)
+	}
 
-	// Créer le sous-répertoire basé sur la date
-	subDir := time.Now().Format("2006/01")
-	fullDir := filepath.Join(s.uploadDir, subDir)
-	if err := os.MkdirAll(fullDir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create directory: %w", err)
+	// Images : copies sans métadonnées préparées avant tout stockage, une image illisible est refusée
+	content := io.MultiReader(bytes.NewReader(head), src)
+	var processed *media.Processed
+	if media.IsImage(typeMime) {
+		data, err := io.ReadAll(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file: %w", err)
+		}
+		processed, err = media.Process(data, typeMime, s.uploads.ThumbnailSize, s.maxPixels())
+		if err != nil {
+			return nil, fmt.Errorf("validation error: %w", err)
+		}
+		content = bytes.NewReader(data)
 	}
 
-	// Chemin complet du fichier
-	cheminStockage := filepath.Join(subDir, nomFichier)
-	fullPath := filepath.Join(s.uploadDir, cheminStockage)
+	// Générer un nom de fichier unique
+	nomFichier := fmt.Sprintf("%s%s", uuid.New().String(), media.Extension(typeMime))
 
-	// Créer le fichier destination
-	dst, err := os.Create(fullPath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create destination file: %w", err)
-	}
-	defer dst.Close()
+	// Clé de stockage rangée par mois
+	cheminStockage := path.Join(time.Now().Format("2006/01"), nomFichier)
 
-	// Calculer le hash et copier en même temps
+	// Original conservé tel quel, avec son hash calculé pendant l'envoi au stockage
 	hash := sha256.New()
-	writer := io.MultiWriter(dst, hash)
-
-	if _, err := io.Copy(writer, src); err != nil {
-		// Supprimer le fichier en cas d'erreur
-		os.Remove(fullPath)
+	if err := s.storage.Put(ctx, cheminStockage, io.TeeReader(content, hash), file.Size, typeMime); err != nil {
 		return nil, fmt.Errorf("failed to save file: %w", err)
 	}
+	if processed != nil {
+		if err := s.storeVariants(ctx, cheminStockage, typeMime, processed); err != nil {
+			s.deleteFiles(ctx, cheminStockage, typeMime)
+			return nil, err
+		}
+	}
 
 	hashFichier := hex.EncodeToString(hash.Sum(nil))
 
@@ -122,7 +171,7 @@ func (s *service) Upload(ctx context.Context, file *multipart.FileHeader, input
 		ID:             uuid.New().String(),
 		NomFichier:     nomFichier,
 		NomOriginal:    file.Filename,
-		TypeMime:       file.Header.Get("Content-Type"),
+		TypeMime:       typeMime,
 		Taille:         file.Size,
 		CheminStockage: cheminStockage,
 		TypeDocument:   input.TypeDocument,
@@ -138,8 +187,8 @@ func (s *service) Upload(ctx context.Context, file *multipart.FileHeader, input
 
 	documentEnt, err := s.documentRepo.Create(ctx, repoInput)
 	if err != nil {
-		// Supprimer le fichier en cas d'erreur
-		os.Remove(fullPath)
+		// Supprimer les fichiers en cas d'erreur
+		s.deleteFiles(ctx, cheminStockage, typeMime)
 		s.logger.Error("Failed to create document", zap.Error(err))
 		return nil, fmt.Errorf("failed to create document: %w", err)
 	}
@@ -156,6 +205,9 @@ func (s *service) Upload(ctx context.Context, file *multipart.FileHeader, input
 
 // GetByID gets document by ID
 func (s *service) GetByID(ctx context.Context, id string) (*DocumentResponse, error) {
+	ctx, span := tracing.Start(ctx, "document.GetByID")
+	defer span.End()
+
 	documentEnt, err := s.documentRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -165,32 +217,41 @@ func (s *service) GetByID(ctx context.Context, id string) (*DocumentResponse, er
 }
 
 // List gets documents with filters
-func (s *service) List(ctx context.Context, input *ListDocumentsRequest) (*ListDocumentsResponse, error) {
+func (s *service) List(ctx context.Context, input *ListDocumentsRequest) ([]*DocumentResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "document.List")
+	defer span.End()
+
 	filters := s.buildFilters(input)
 
 	documentsEnt, err := s.documentRepo.List(ctx, filters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	documentsEnt, page, err := pagination.Trim(input.Page, documentsEnt)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	total, err := s.documentRepo.Count(ctx, filters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	page.SetTotal(total)
 
 	documents := make([]*DocumentResponse, len(documentsEnt))
 	for i, d := range documentsEnt {
 		documents[i] = s.entityToResponse(d)
 	}
 
-	return &ListDocumentsResponse{
-		Documents: documents,
-		Total:     total,
-	}, nil
+	return documents, page, nil
 }
 
 // Update updates document
 func (s *service) Update(ctx context.Context, id string, input *UpdateDocumentRequest) (*DocumentResponse, error) {
+	ctx, span := tracing.Start(ctx, "document.Update")
+	defer span.End()
+
 	if err := s.validateUpdateInput(input); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
@@ -218,17 +279,17 @@ func (s *service) Update(ctx context.Context, id string, input *UpdateDocumentRe
 
 // Delete deletes document
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "document.Delete")
+	defer span.End()
+
 	// Récupérer le document pour avoir le chemin
 	doc, err := s.documentRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	// Supprimer le fichier physique
-	fullPath := filepath.Join(s.uploadDir, doc.CheminStockage)
-	if err := os.Remove(fullPath); err != nil && !os.IsNotExist(err) {
-		s.logger.Warn("Failed to delete physical file", zap.String("path", fullPath), zap.Error(err))
-	}
+	// Supprimer le fichier stocké et ses copies
+	s.deleteFiles(ctx, doc.CheminStockage, doc.TypeMime)
 
 	// Supprimer l'entrée en base
 	return s.documentRepo.Delete(ctx, id)
@@ -236,6 +297,9 @@ func (s *service) Delete(ctx context.Context, id string) error {
 
 // GetByControle gets documents by controle ID
 func (s *service) GetByControle(ctx context.Context, controleID string) (*ListDocumentsResponse, error) {
+	ctx, span := tracing.Start(ctx, "document.GetByControle")
+	defer span.End()
+
 	documentsEnt, err := s.documentRepo.GetByControle(ctx, controleID)
 	if err != nil {
 		return nil, err
@@ -254,6 +318,9 @@ func (s *service) GetByControle(ctx context.Context, controleID string) (*ListDo
 
 // GetByInfraction gets documents by infraction ID
 func (s *service) GetByInfraction(ctx context.Context, infractionID string) (*ListDocumentsResponse, error) {
+	ctx, span := tracing.Start(ctx, "document.GetByInfraction")
+	defer span.End()
+
 	documentsEnt, err := s.documentRepo.GetByInfraction(ctx, infractionID)
 	if err != nil {
 		return nil, err
@@ -272,6 +339,9 @@ func (s *service) GetByInfraction(ctx context.Context, infractionID string) (*Li
 
 // GetByProcesVerbal gets documents by proces verbal ID
 func (s *service) GetByProcesVerbal(ctx context.Context, pvID string) (*ListDocumentsResponse, error) {
+	ctx, span := tracing.Start(ctx, "document.GetByProcesVerbal")
+	defer span.End()
+
 	documentsEnt, err := s.documentRepo.GetByProcesVerbal(ctx, pvID)
 	if err != nil {
 		return nil, err
@@ -290,6 +360,9 @@ func (s *service) GetByProcesVerbal(ctx context.Context, pvID string) (*ListDocu
 
 // GetByRecours gets documents by recours ID
 func (s *service) GetByRecours(ctx context.Context, recoursID string) (*ListDocumentsResponse, error) {
+	ctx, span := tracing.Start(ctx, "document.GetByRecours")
+	defer span.End()
+
 	documentsEnt, err := s.documentRepo.GetByRecours(ctx, recoursID)
 	if err != nil {
 		return nil, err
@@ -308,6 +381,9 @@ func (s *service) GetByRecours(ctx context.Context, recoursID string) (*ListDocu
 
 // GetByUploader gets documents by uploader ID
 func (s *service) GetByUploader(ctx context.Context, userID string) (*ListDocumentsResponse, error) {
+	ctx, span := tracing.Start(ctx, "document.GetByUploader")
+	defer span.End()
+
 	documentsEnt, err := s.documentRepo.GetByUploader(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -326,6 +402,9 @@ func (s *service) GetByUploader(ctx context.Context, userID string) (*ListDocume
 
 // GetStatistics gets statistics for documents
 func (s *service) GetStatistics(ctx context.Context) (*DocumentStatisticsResponse, error) {
+	ctx, span := tracing.Start(ctx, "document.GetStatistics")
+	defer span.End()
+
 	documentsEnt, err := s.documentRepo.List(ctx, nil)
 	if err != nil {
 		return nil, err
@@ -354,18 +433,304 @@ func (s *service) GetStatistics(ctx context.Context) (*DocumentStatisticsRespons
 	return stats, nil
 }
 
-// GetFilePath gets the file path for a document
-func (s *service) GetFilePath(ctx context.Context, id string) (string, error) {
+// Download gets the content of a document: a redirection to the storage
+// backend when it hands out presigned URLs, the file to stream otherwise
+func (s *service) Download(ctx context.Context, id string) (*FileDownload, error) {
+	ctx, span := tracing.Start(ctx, "document.Download")
+	defer span.End()
+
+	doc, err := s.documentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.content(ctx, doc, "", s.presignExpiry, doc.NomOriginal)
+}
+
+// Thumbnail gets the thumbnail of an image document
+func (s *service) Thumbnail(ctx context.Context, id string) (*FileDownload, error) {
+	ctx, span := tracing.Start(ctx, "document.Thumbnail")
+	defer span.End()
+
+	doc, err := s.documentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !media.IsImage(doc.TypeMime) {
+		return nil, ErrNoThumbnail
+	}
+	return s.content(ctx, doc, variantThumbnail, s.presignExpiry, "")
+}
+
+// SignURL issues a link downloading the document without token. Links to
+// private documents are short-lived and may be single-use; links to public
+// documents may last up to the public lifetime.
+func (s *service) SignURL(ctx context.Context, id string, input *SignURLRequest) (*SignedURLResponse, error) {
+	ctx, span := tracing.Start(ctx, "document.SignURL")
+	defer span.End()
+
+	// Lecture dans le périmètre de l'appelant : pas de lien vers un document qu'il ne voit pas
+	doc, err := s.documentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl, maxTTL := s.links.TTL, s.links.MaxTTL
+	if doc.Public {
+		ttl, maxTTL = s.links.PublicTTL, s.links.PublicTTL
+	}
+	if input.ExpiresIn != 0 {
+		ttl = time.Duration(input.ExpiresIn) * time.Second
+	}
+	if ttl <= 0 || ttl > maxTTL {
+		return nil, fmt.Errorf("%w: expires_in must be between 1 and %d seconds", ErrInvalidLinkRequest, int(maxTTL.Seconds()))
+	}
+	if input.Thumbnail && !media.IsImage(doc.TypeMime) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLinkRequest, ErrNoThumbnail)
+	}
+
+	link := &signedLink{
+		DocumentID: doc.ID.String(),
+		Expires:    time.Now().Add(ttl).Truncate(time.Second),
+		Nonce:      newNonce(),
+		SingleUse:  input.SingleUse,
+		Public:     doc.Public,
+		Thumbnail:  input.Thumbnail,
+	}
+	return &SignedURLResponse{
+		URL:       s.linkURL(link),
+		ExpiresAt: link.Expires,
+		SingleUse: link.SingleUse,
+	}, nil
+}
+
+// DownloadSigned checks a signed link and records the download before
+// handing out the file; the record is removed when the file cannot be served
+func (s *service) DownloadSigned(ctx context.Context, id string, query url.Values, client *DownloadClient) (*FileDownload, error) {
+	ctx, span := tracing.Start(ctx, "document.DownloadSigned")
+	defer span.End()
+
+	link, err := s.signer.verify(id, query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := s.documentRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if link.Public && !doc.Public {
+		// Document repassé en privé : ses liens publics ne valent plus
+		return nil, ErrInvalidLink
+	}
+
+	// Les liens publics ne servent jamais l'original d'une image, avec ses métadonnées
+	variant := ""
+	switch {
+	case link.Thumbnail && !media.IsImage(doc.TypeMime):
+		return nil, ErrNoThumbnail
+	case link.Thumbnail:
+		variant = variantThumbnail
+	case link.Public && media.IsImage(doc.TypeMime):
+		variant = variantPublic
+	}
+
+	recorded, err := s.documentRepo.RecordDownload(ctx, &repository.DocumentDownloadInput{
+		DocumentID:    doc.ID,
+		Nonce:         link.Nonce,
+		SingleUse:     link.SingleUse,
+		Public:        link.Public,
+		LinkExpiresAt: link.Expires,
+		IPAddress:     client.IPAddress,
+		UserAgent:     client.UserAgent,
+	})
+	if errors.Is(err, repository.ErrDownloadLinkUsed) {
+		return nil, ErrLinkUsed
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Redirection vers le stockage limitée à la durée restante du lien
+	expiry := s.presignExpiry
+	if remaining := time.Until(link.Expires); remaining < expiry {
+		expiry = remaining
+	}
+	download, err := s.content(ctx, doc, variant, expiry, "")
+	if err != nil {
+		// Fichier non servi : le lien à usage unique reste utilisable
+		if delErr := s.documentRepo.DeleteDownload(ctx, recorded.ID); delErr != nil {
+			s.logger.Error("Failed to release download link", zap.String("documentID", id), zap.Error(delErr))
+		}
+		return nil, err
+	}
+	download.Public = link.Public
+	download.ExpiresAt = link.Expires
+	return download, nil
+}
+
+// ListDownloads lists the downloads of a document through signed links
+func (s *service) ListDownloads(ctx context.Context, id string) ([]*DownloadResponse, error) {
+	ctx, span := tracing.Start(ctx, "document.ListDownloads")
+	defer span.End()
+
 	doc, err := s.documentRepo.GetByID(ctx, id)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	downloadsEnt, err := s.documentRepo.ListDownloads(ctx, doc.ID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	downloads := make([]*DownloadResponse, len(downloadsEnt))
+	for i, d := range downloadsEnt {
+		downloads[i] = &DownloadResponse{
+			ID:            d.ID.String(),
+			SingleUse:     d.SingleUse,
+			Public:        d.Public,
+			LinkExpiresAt: d.LinkExpiresAt,
+			IPAddress:     d.IPAddress,
+			UserAgent:     d.UserAgent,
+			CreatedAt:     d.CreatedAt,
+		}
 	}
 
-	return filepath.Join(s.uploadDir, doc.CheminStockage), nil
+	return downloads, nil
 }
 
 // Private helper methods
 
+// content gets the redirection to the storage backend when it hands out
+// presigned URLs, the file to stream otherwise. The variant selects a copy of
+// an image, the original when empty. With a filename, the file is downloaded
+// as an attachment.
+func (s *service) content(ctx context.Context, doc *ent.Document, variant string, expiry time.Duration, filename string) (*FileDownload, error) {
+	key, typeMime := variantKey(doc.CheminStockage, doc.TypeMime, variant)
+	download := &FileDownload{
+		NomOriginal: doc.NomOriginal,
+		TypeMime:    typeMime,
+		Taille:      doc.Taille,
+	}
+	if variant != "" {
+		if err := s.ensureVariants(ctx, doc); err != nil {
+			return nil, err
+		}
+		download.NomOriginal = strings.TrimSuffix(doc.NomOriginal, filepath.Ext(doc.NomOriginal)) + media.Extension(typeMime)
+	}
+
+	presigned, err := s.storage.Presign(ctx, key, expiry, filename)
+	if err == nil {
+		download.RedirectURL = presigned
+		return download, nil
+	}
+	if !errors.Is(err, storage.ErrPresignUnsupported) {
+		return nil, fmt.Errorf("failed to presign file: %w", err)
+	}
+
+	content, object, err := s.storage.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	download.Content = content
+	download.Taille = object.Size
+	return download, nil
+}
+
+// variantKey returns the storage key and the type of a copy of an image, of
+// the original when the variant is empty
+func variantKey(key, typeMime, variant string) (string, string) {
+	switch variant {
+	case variantPublic:
+		publicType := media.PublicType(typeMime)
+		return key + ".public" + media.Extension(publicType), publicType
+	case variantThumbnail:
+		return key + ".thumb.jpg", media.TypeJPEG
+	}
+	return key, typeMime
+}
+
+// storeVariants stores the public copy and the thumbnail of an image
+func (s *service) storeVariants(ctx context.Context, key, typeMime string, processed *media.Processed) error {
+	publicKey, publicType := variantKey(key, typeMime, variantPublic)
+	if err := s.storage.Put(ctx, publicKey, bytes.NewReader(processed.Public), int64(len(processed.Public)), publicType); err != nil {
+		return fmt.Errorf("failed to save public copy: %w", err)
+	}
+	thumbnailKey, thumbnailType := variantKey(key, typeMime, variantThumbnail)
+	if err := s.storage.Put(ctx, thumbnailKey, bytes.NewReader(processed.Thumbnail), int64(len(processed.Thumbnail)), thumbnailType); err != nil {
+		return fmt.Errorf("failed to save thumbnail: %w", err)
+	}
+	return nil
+}
+
+// ensureVariants creates the copies of an image uploaded before they were
+// made at upload time. The thumbnail is stored last: once it exists, both
+// copies do.
+func (s *service) ensureVariants(ctx context.Context, doc *ent.Document) error {
+	thumbnailKey, _ := variantKey(doc.CheminStockage, doc.TypeMime, variantThumbnail)
+	_, err := s.storage.Stat(ctx, thumbnailKey)
+	if !errors.Is(err, storage.ErrNotFound) {
+		return err
+	}
+
+	original, _, err := s.storage.Get(ctx, doc.CheminStockage)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer original.Close()
+	data, err := io.ReadAll(original)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+	processed, err := media.Process(data, doc.TypeMime, s.uploads.ThumbnailSize, s.maxPixels())
+	if err != nil {
+		return fmt.Errorf("failed to process image: %w", err)
+	}
+	return s.storeVariants(ctx, doc.CheminStockage, doc.TypeMime, processed)
+}
+
+// deleteFiles deletes the stored file of a document and the copies of an image
+func (s *service) deleteFiles(ctx context.Context, key, typeMime string) {
+	keys := []string{key}
+	if media.IsImage(typeMime) {
+		publicKey, _ := variantKey(key, typeMime, variantPublic)
+		thumbnailKey, _ := variantKey(key, typeMime, variantThumbnail)
+		keys = append(keys, publicKey, thumbnailKey)
+	}
+	for _, k := range keys {
+		if err := s.storage.Delete(ctx, k); err != nil {
+			s.logger.Warn("Failed to delete stored file", zap.String("key", k), zap.Error(err))
+		}
+	}
+}
+
+// maxPixels returns the size limit of the decoded images
+func (s *service) maxPixels() int {
+	return s.uploads.MaxMegapixels * 1_000_000
+}
+
+// linkURL returns the URL of a signed link
+func (s *service) linkURL(link *signedLink) string {
+	return fmt.Sprintf("%s/api/v1/files/documents/%s?%s", s.baseURL, link.DocumentID, s.signer.query(link).Encode())
+}
+
+// publicLink returns the link, or the thumbnail link, included in the
+// responses of a public document. Its expiry moves by steps of a quarter of
+// its lifetime, so that the URL stays the same, and cacheable, in between.
+func (s *service) publicLink(doc *ent.Document, thumbnail bool) *signedLink {
+	ttl := s.links.PublicTTL
+	step := ttl / 4
+	if step < time.Second {
+		step = time.Second
+	}
+	return &signedLink{
+		DocumentID: doc.ID.String(),
+		Expires:    time.Now().Truncate(step).Add(ttl),
+		Public:     true,
+		Thumbnail:  thumbnail,
+	}
+}
+
 func (s *service) validateUploadInput(input *UploadDocumentRequest) error {
 	if input.TypeDocument == "" {
 		return fmt.Errorf("type_document is required")
@@ -422,6 +787,7 @@ func (s *service) buildFilters(input *ListDocumentsRequest) *repository.Document
 		DateFin:        input.DateFin,
 		Limit:          input.Limit,
 		Offset:         input.Offset,
+		Page:           input.Page,
 	}
 }
 
@@ -441,6 +807,18 @@ func (s *service) entityToResponse(documentEnt *ent.Document) *DocumentResponse
 		UpdatedAt:      documentEnt.UpdatedAt,
 	}
 
+	if media.IsImage(documentEnt.TypeMime) {
+		response.ThumbnailURL = fmt.Sprintf("%s/api/v1/documents/%s/thumbnail", s.baseURL, documentEnt.ID)
+	}
+
+	// Document public : liens utilisables sans jeton (balises <img>, visionneuse PDF)
+	if documentEnt.Public {
+		response.SignedURL = s.linkURL(s.publicLink(documentEnt, false))
+		if response.ThumbnailURL != "" {
+			response.SignedThumbnailURL = s.linkURL(s.publicLink(documentEnt, true))
+		}
+	}
+
 	// Ajouter les relations
 	if documentEnt.Edges.UploadedBy != nil {
 		u := documentEnt.Edges.UploadedBy
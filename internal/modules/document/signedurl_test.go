@@ -0,0 +1,67 @@
+package document
+
+import (
+	"testing"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLinkSigner(t *testing.T) {
+	signer := newLinkSigner(config.SignedURLConfig{}, "jwt-secret")
+	now := time.Now()
+	link := &signedLink{
+		DocumentID: "0b6f7c1e-8d4a-4c47-9a3e-2f1d5b6c7a80",
+		Expires:    now.Add(5 * time.Minute).Truncate(time.Second),
+		Nonce:      newNonce(),
+		SingleUse:  true,
+	}
+	query := signer.query(link)
+
+	verified, err := signer.verify(link.DocumentID, query, now)
+	require.NoError(t, err)
+	assert.Equal(t, link, verified)
+
+	// Lien d'un autre document
+	_, err = signer.verify("5d3c2b1a-0000-4c47-9a3e-2f1d5b6c7a80", query, now)
+	assert.ErrorIs(t, err, ErrInvalidLink)
+
+	// Usage unique retiré, ou lien présenté comme celui d'un document public
+	altered := signer.query(link)
+	altered.Del("single_use")
+	_, err = signer.verify(link.DocumentID, altered, now)
+	assert.ErrorIs(t, err, ErrInvalidLink)
+	altered = signer.query(link)
+	altered.Set("public", "1")
+	_, err = signer.verify(link.DocumentID, altered, now)
+	assert.ErrorIs(t, err, ErrInvalidLink)
+
+	// Lien de miniature présenté comme lien de l'image, et inversement
+	altered = signer.query(link)
+	altered.Set("thumbnail", "1")
+	_, err = signer.verify(link.DocumentID, altered, now)
+	assert.ErrorIs(t, err, ErrInvalidLink)
+	thumbnail := *link
+	thumbnail.Thumbnail = true
+	altered = signer.query(&thumbnail)
+	altered.Del("thumbnail")
+	_, err = signer.verify(link.DocumentID, altered, now)
+	assert.ErrorIs(t, err, ErrInvalidLink)
+
+	// Expiration repoussée
+	altered = signer.query(link)
+	altered.Set("expires", "99999999999")
+	_, err = signer.verify(link.DocumentID, altered, now)
+	assert.ErrorIs(t, err, ErrInvalidLink)
+
+	_, err = signer.verify(link.DocumentID, query, link.Expires)
+	assert.ErrorIs(t, err, ErrLinkExpired)
+
+	// Clé différente : signature refusée
+	other := newLinkSigner(config.SignedURLConfig{}, "another-secret")
+	_, err = other.verify(link.DocumentID, query, now)
+	assert.ErrorIs(t, err, ErrInvalidLink)
+}
@@ -1,7 +1,11 @@
 package document
 
 import (
+	"io"
 	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 )
 
 // UploadDocumentRequest represents request to upload a document
@@ -36,27 +40,50 @@ type ListDocumentsRequest struct {
 	DateFin        *time.Time `json:"date_fin,omitempty"`
 	Limit          int        `json:"limit,omitempty"`
 	Offset         int        `json:"offset,omitempty"`
+	Page           *pagination.Params `json:"-"`
+}
+
+// listSpec declares the sorts and fields accepted by GET /documents
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"created_at":    "created_at",
+		"nom_original":  "nom_original",
+		"taille":        "taille",
+		"type_document": "type_document",
+	},
+	DefaultSort: "-created_at",
+	Fields:      pagination.JSONFields(DocumentResponse{}),
+	Filters: filter.Fields{
+		"type_document": {Column: "type_document", Type: filter.String},
+		"type_mime":     {Column: "type_mime", Type: filter.String},
+		"nom_original":  {Column: "nom_original", Type: filter.String},
+		"public":        {Column: "public", Type: filter.Bool},
+		"created_at":    {Column: "created_at", Type: filter.Time},
+	},
 }
 
 // DocumentResponse represents a document in responses
 type DocumentResponse struct {
-	ID             string           `json:"id"`
-	NomFichier     string           `json:"nom_fichier"`
-	NomOriginal    string           `json:"nom_original"`
-	TypeMime       string           `json:"type_mime"`
-	Taille         int64            `json:"taille"`
-	TailleFormatee string           `json:"taille_formatee"`
-	TypeDocument   string           `json:"type_document"`
-	Description    string           `json:"description,omitempty"`
-	Public         bool             `json:"public"`
-	URL            string           `json:"url,omitempty"`
-	UploadedBy     *UploaderSummary `json:"uploaded_by,omitempty"`
-	ControleID     *string          `json:"controle_id,omitempty"`
-	InfractionID   *string          `json:"infraction_id,omitempty"`
-	ProcesVerbalID *string          `json:"proces_verbal_id,omitempty"`
-	RecoursID      *string          `json:"recours_id,omitempty"`
-	CreatedAt      time.Time        `json:"created_at"`
-	UpdatedAt      time.Time        `json:"updated_at"`
+	ID                 string           `json:"id"`
+	NomFichier         string           `json:"nom_fichier"`
+	NomOriginal        string           `json:"nom_original"`
+	TypeMime           string           `json:"type_mime"`
+	Taille             int64            `json:"taille"`
+	TailleFormatee     string           `json:"taille_formatee"`
+	TypeDocument       string           `json:"type_document"`
+	Description        string           `json:"description,omitempty"`
+	Public             bool             `json:"public"`
+	URL                string           `json:"url,omitempty"`
+	SignedURL          string           `json:"signed_url,omitempty"`           // Public documents only: download without token
+	ThumbnailURL       string           `json:"thumbnail_url,omitempty"`        // Image documents only
+	SignedThumbnailURL string           `json:"signed_thumbnail_url,omitempty"` // Public image documents only: thumbnail without token
+	UploadedBy         *UploaderSummary `json:"uploaded_by,omitempty"`
+	ControleID         *string          `json:"controle_id,omitempty"`
+	InfractionID       *string          `json:"infraction_id,omitempty"`
+	ProcesVerbalID     *string          `json:"proces_verbal_id,omitempty"`
+	RecoursID          *string          `json:"recours_id,omitempty"`
+	CreatedAt          time.Time        `json:"created_at"`
+	UpdatedAt          time.Time        `json:"updated_at"`
 }
 
 // UploaderSummary represents uploader summary for document response
@@ -83,3 +110,46 @@ type DocumentStatisticsResponse struct {
 	DocumentsPublics  int                `json:"documents_publics"`
 	DocumentsPrives   int                `json:"documents_prives"`
 }
+
+// FileDownload is the content of a document: either a URL of the storage
+// backend to redirect to, or the file to stream
+type FileDownload struct {
+	NomOriginal string
+	TypeMime    string
+	Taille      int64
+	RedirectURL string
+	Content     io.ReadCloser
+	Public      bool      // Served through the link of a public document
+	ExpiresAt   time.Time // Expiry of the signed link
+}
+
+// SignURLRequest represents request for a signed download link
+type SignURLRequest struct {
+	ExpiresIn int  `json:"expires_in,omitempty"` // Seconds; default from the configuration
+	SingleUse bool `json:"single_use"`
+	Thumbnail bool `json:"thumbnail"` // Link to the thumbnail of an image document
+}
+
+// SignedURLResponse represents a signed download link
+type SignedURLResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+	SingleUse bool      `json:"single_use"`
+}
+
+// DownloadClient identifies the client of a download through a signed link
+type DownloadClient struct {
+	IPAddress string
+	UserAgent string
+}
+
+// DownloadResponse represents a download through a signed link
+type DownloadResponse struct {
+	ID            string    `json:"id"`
+	SingleUse     bool      `json:"single_use"`
+	Public        bool      `json:"public"`
+	LinkExpiresAt time.Time `json:"link_expires_at"`
+	IPAddress     string    `json:"ip_address,omitempty"`
+	UserAgent     string    `json:"user_agent,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
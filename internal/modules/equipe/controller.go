@@ -4,6 +4,9 @@ import (
 	"net/http"
 
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
@@ -82,6 +85,7 @@ func (c *Controller) GetByID(ctx echo.Context) error {
 // @Param commissariatId query string false "Commissariat ID"
 // @Param active query string false "Active status (true/false)"
 // @Param search query string false "Search term"
+// @Param filter query string false "Filter expression, e.g. zone ~ nord and active = true"
 // @Success 200 {array} EquipeResponse
 // @Router /api/equipes [get]
 func (c *Controller) List(ctx echo.Context) error {
@@ -90,13 +94,19 @@ func (c *Controller) List(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid query parameters")
 	}
 
-	equipes, err := c.service.List(ctx.Request().Context(), &filters)
+	page, err := pagination.Parse(ctx, listSpec)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	filters.Page = page
+
+	equipes, result, err := c.service.List(ctx.Request().Context(), &filters)
 	if err != nil {
 		c.logger.Error("Failed to list equipes", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusOK, equipes)
+	return responses.Paginated(ctx, equipes, result)
 }
 
 // Update updates an equipe
@@ -240,13 +250,13 @@ func (c *Controller) SetChefEquipe(ctx echo.Context) error {
 
 // RegisterRoutes registers equipe routes
 func (c *Controller) RegisterRoutes(g *echo.Group) {
-	equipes := g.Group("/equipes")
-	equipes.POST("", c.Create)
-	equipes.GET("", c.List)
-	equipes.GET("/:id", c.GetByID)
-	equipes.PUT("/:id", c.Update)
-	equipes.DELETE("/:id", c.Delete)
-	equipes.POST("/:id/membres", c.AddMembre)
-	equipes.DELETE("/:id/membres/:userId", c.RemoveMembre)
-	equipes.PUT("/:id/chef", c.SetChefEquipe)
+	equipes := rbac.Guard(g).Group("/equipes")
+	equipes.POST("", c.Create, rbac.PermManageEquipes)
+	equipes.GET("", c.List, rbac.PermReadEquipes)
+	equipes.GET("/:id", c.GetByID, rbac.PermReadEquipes)
+	equipes.PUT("/:id", c.Update, rbac.PermManageEquipes)
+	equipes.DELETE("/:id", c.Delete, rbac.PermManageEquipes)
+	equipes.POST("/:id/membres", c.AddMembre, rbac.PermManageEquipes)
+	equipes.DELETE("/:id/membres/:userId", c.RemoveMembre, rbac.PermManageEquipes)
+	equipes.PUT("/:id/chef", c.SetChefEquipe, rbac.PermManageEquipes)
 }
@@ -5,6 +5,8 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -15,7 +17,7 @@ type Service interface {
 	Create(ctx context.Context, req *CreateEquipeRequest) (*EquipeResponse, error)
 	GetByID(ctx context.Context, id string) (*EquipeResponse, error)
 	GetByCode(ctx context.Context, code string) (*EquipeResponse, error)
-	List(ctx context.Context, filters *ListEquipesFilters) ([]EquipeResponse, error)
+	List(ctx context.Context, filters *ListEquipesFilters) ([]EquipeResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, req *UpdateEquipeRequest) (*EquipeResponse, error)
 	Delete(ctx context.Context, id string) error
 	AddMembre(ctx context.Context, equipeID string, req *AddMembreRequest) error
@@ -38,6 +40,9 @@ func NewService(repo repository.EquipeRepository, logger *zap.Logger) Service {
 
 // Create creates a new equipe
 func (s *service) Create(ctx context.Context, req *CreateEquipeRequest) (*EquipeResponse, error) {
+	ctx, span := tracing.Start(ctx, "equipe.Create")
+	defer span.End()
+
 	input := &repository.CreateEquipeInput{
 		ID:             uuid.New().String(),
 		Nom:            req.Nom,
@@ -57,6 +62,9 @@ func (s *service) Create(ctx context.Context, req *CreateEquipeRequest) (*Equipe
 
 // GetByID gets an equipe by ID
 func (s *service) GetByID(ctx context.Context, id string) (*EquipeResponse, error) {
+	ctx, span := tracing.Start(ctx, "equipe.GetByID")
+	defer span.End()
+
 	equipe, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -67,6 +75,9 @@ func (s *service) GetByID(ctx context.Context, id string) (*EquipeResponse, erro
 
 // GetByCode gets an equipe by code
 func (s *service) GetByCode(ctx context.Context, code string) (*EquipeResponse, error) {
+	ctx, span := tracing.Start(ctx, "equipe.GetByCode")
+	defer span.End()
+
 	equipe, err := s.repo.GetByCode(ctx, code)
 	if err != nil {
 		return nil, err
@@ -76,7 +87,10 @@ func (s *service) GetByCode(ctx context.Context, code string) (*EquipeResponse,
 }
 
 // List lists equipes with filters
-func (s *service) List(ctx context.Context, filters *ListEquipesFilters) ([]EquipeResponse, error) {
+func (s *service) List(ctx context.Context, filters *ListEquipesFilters) ([]EquipeResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "equipe.List")
+	defer span.End()
+
 	repoFilters := &repository.EquipeFilters{}
 
 	if filters != nil {
@@ -90,11 +104,17 @@ func (s *service) List(ctx context.Context, filters *ListEquipesFilters) ([]Equi
 		if filters.Search != "" {
 			repoFilters.Search = &filters.Search
 		}
+		repoFilters.Page = filters.Page
 	}
 
 	equipes, err := s.repo.List(ctx, repoFilters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	equipes, page, err := pagination.Trim(repoFilters.Page, equipes)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	responses := make([]EquipeResponse, len(equipes))
@@ -102,11 +122,14 @@ func (s *service) List(ctx context.Context, filters *ListEquipesFilters) ([]Equi
 		responses[i] = *s.toResponse(eq)
 	}
 
-	return responses, nil
+	return responses, page, nil
 }
 
 // Update updates an equipe
 func (s *service) Update(ctx context.Context, id string, req *UpdateEquipeRequest) (*EquipeResponse, error) {
+	ctx, span := tracing.Start(ctx, "equipe.Update")
+	defer span.End()
+
 	input := &repository.UpdateEquipeInput{
 		Nom:         req.Nom,
 		Zone:        req.Zone,
@@ -124,21 +147,33 @@ func (s *service) Update(ctx context.Context, id string, req *UpdateEquipeReques
 
 // Delete deletes an equipe
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "equipe.Delete")
+	defer span.End()
+
 	return s.repo.Delete(ctx, id)
 }
 
 // AddMembre adds a member to the equipe
 func (s *service) AddMembre(ctx context.Context, equipeID string, req *AddMembreRequest) error {
+	ctx, span := tracing.Start(ctx, "equipe.AddMembre")
+	defer span.End()
+
 	return s.repo.AddMembre(ctx, equipeID, req.UserID)
 }
 
 // RemoveMembre removes a member from the equipe
 func (s *service) RemoveMembre(ctx context.Context, equipeID, userID string) error {
+	ctx, span := tracing.Start(ctx, "equipe.RemoveMembre")
+	defer span.End()
+
 	return s.repo.RemoveMembre(ctx, equipeID, userID)
 }
 
 // SetChefEquipe sets the team leader
 func (s *service) SetChefEquipe(ctx context.Context, equipeID string, req *SetChefEquipeRequest) error {
+	ctx, span := tracing.Start(ctx, "equipe.SetChefEquipe")
+	defer span.End()
+
 	return s.repo.SetChefEquipe(ctx, equipeID, req.UserID)
 }
 
@@ -1,6 +1,11 @@
 package equipe
 
-import "time"
+import (
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+)
 
 // EquipeResponse represents an equipe in API responses
 type EquipeResponse struct {
@@ -78,4 +83,21 @@ type ListEquipesFilters struct {
 	CommissariatID string `query:"commissariatId"`
 	Active         string `query:"active"`
 	Search         string `query:"search"`
+	Page           *pagination.Params // Set by the controller
+}
+
+// listSpec declares the sorts and fields accepted by GET /equipes
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"nom":       "nom",
+		"createdAt": "created_at",
+	},
+	DefaultSort: "nom",
+	Fields:      pagination.JSONFields(EquipeResponse{}),
+	Filters: filter.Fields{
+		"nom":       {Column: "nom", Type: filter.String},
+		"zone":      {Column: "zone", Type: filter.String},
+		"active":    {Column: "active", Type: filter.Bool},
+		"createdAt": {Column: "created_at", Type: filter.Time},
+	},
 }
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
@@ -24,33 +26,33 @@ func NewController(service Service) interfaces.Controller {
 
 // RegisterRoutes registers infraction routes
 func (c *Controller) RegisterRoutes(g *echo.Group) {
-	group := g.Group("/infractions")
+	group := rbac.Guard(g).Group("/infractions")
 
 	// Public endpoints
-	group.GET("", c.ListInfractions)
-	group.GET("/dashboard", c.GetDashboard)
-	group.GET("/:id", c.GetInfraction)
-	group.GET("/types", c.GetTypesInfractions)
-	group.GET("/categories", c.GetCategories)
-	group.GET("/stats", c.GetStatistics)
+	group.GET("", c.ListInfractions, rbac.PermReadInfractions)
+	group.GET("/dashboard", c.GetDashboard, rbac.PermReadInfractions)
+	group.GET("/:id", c.GetInfraction, rbac.PermReadInfractions)
+	group.GET("/types", c.GetTypesInfractions, rbac.PermReadInfractions)
+	group.GET("/categories", c.GetCategories, rbac.PermReadInfractions)
+	group.GET("/stats", c.GetStatistics, rbac.PermReadInfractions)
 	
 	// Protected endpoints
-	group.POST("", c.CreateInfraction)
-	group.PUT("/:id", c.UpdateInfraction)
-	group.DELETE("/:id", c.DeleteInfraction)
+	group.POST("", c.CreateInfraction, rbac.PermCreateInfractions)
+	group.PUT("/:id", c.UpdateInfraction, rbac.PermUpdateInfractions)
+	group.DELETE("/:id", c.DeleteInfraction, rbac.PermDeleteInfractions)
 	
 	// Additional endpoints
-	group.GET("/pv/:numeroPv", c.GetByNumeroPV)
-	group.GET("/controle/:controleId", c.GetByControle)
-	group.GET("/vehicule/:vehiculeId", c.GetByVehicule)
-	group.GET("/conducteur/:conducteurId", c.GetByConducteur)
-	group.GET("/statut/:statut", c.GetByStatut)
-	group.POST("/:id/generate-pv", c.GeneratePV)
-	group.POST("/:id/validate", c.ValidateInfraction)
-	group.POST("/:id/archive", c.ArchiveInfraction)
-	group.POST("/:id/unarchive", c.UnarchiveInfraction)
-	group.POST("/:id/payment", c.RecordPayment)
-	group.GET("/group-by-type", c.GroupByType)
+	group.GET("/pv/:numeroPv", c.GetByNumeroPV, rbac.PermReadInfractions)
+	group.GET("/controle/:controleId", c.GetByControle, rbac.PermReadInfractions)
+	group.GET("/vehicule/:vehiculeId", c.GetByVehicule, rbac.PermReadInfractions)
+	group.GET("/conducteur/:conducteurId", c.GetByConducteur, rbac.PermReadInfractions)
+	group.GET("/statut/:statut", c.GetByStatut, rbac.PermReadInfractions)
+	group.POST("/:id/generate-pv", c.GeneratePV, rbac.PermCreatePV)
+	group.POST("/:id/validate", c.ValidateInfraction, rbac.PermUpdateInfractions)
+	group.POST("/:id/archive", c.ArchiveInfraction, rbac.PermUpdateInfractions)
+	group.POST("/:id/unarchive", c.UnarchiveInfraction, rbac.PermUpdateInfractions)
+	group.POST("/:id/payment", c.RecordPayment, rbac.PermCreatePaiements)
+	group.GET("/group-by-type", c.GroupByType, rbac.PermReadInfractions)
 }
 
 // ListInfractions lists infractions with filters
@@ -108,27 +110,19 @@ func (c *Controller) ListInfractions(ctx echo.Context) error {
 		}
 	}
 	
-	// Pagination
-	if limit := ctx.QueryParam("limit"); limit != "" {
-		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
-			request.Limit = l
-		}
-	} else {
-		request.Limit = 50
-	}
-	
-	if offset := ctx.QueryParam("offset"); offset != "" {
-		if o, err := strconv.Atoi(offset); err == nil && o >= 0 {
-			request.Offset = o
-		}
+	// Pagination, tri et champs
+	page, err := pagination.Parse(ctx, listSpec)
+	if err != nil {
+		return responses.BadRequest(ctx, err.Error())
 	}
+	request.Page = page
 	
-	result, err := c.service.List(ctx.Request().Context(), request)
+	infractions, result, err := c.service.List(ctx.Request().Context(), request)
 	if err != nil {
 		return responses.InternalServerError(ctx, "Failed to list infractions")
 	}
 	
-	return responses.Success(ctx, result)
+	return responses.Paginated(ctx, infractions, result)
 }
 
 // GetInfraction gets an infraction by ID
@@ -2,6 +2,7 @@ package infraction
 
 import (
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
 
 	"go.uber.org/fx"
@@ -28,9 +29,10 @@ func NewInfractionService(
 	vehiculeRepo repository.VehiculeRepository,
 	conducteurRepo repository.ConducteurRepository,
 	pvRepo repository.PVRepository,
+	numberingService numbering.Service,
 	logger *zap.Logger,
 ) Service {
-	return NewService(infractionRepo, infractionTypeRepo, controleRepo, vehiculeRepo, conducteurRepo, pvRepo, logger)
+	return NewService(infractionRepo, infractionTypeRepo, controleRepo, vehiculeRepo, conducteurRepo, pvRepo, numberingService, logger)
 }
 
 // NewInfractionController creates a new infraction controller for DI
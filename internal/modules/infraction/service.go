@@ -6,7 +6,11 @@ import (
 	"time"
 
 	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/metrics"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -17,7 +21,7 @@ type Service interface {
 	Create(ctx context.Context, input *CreateInfractionRequest) (*InfractionResponse, error)
 	GetByID(ctx context.Context, id string) (*InfractionResponse, error)
 	GetByNumeroPV(ctx context.Context, numeroPV string) (*InfractionResponse, error)
-	List(ctx context.Context, filters *ListInfractionsRequest) (*ListInfractionsResponse, error)
+	List(ctx context.Context, filters *ListInfractionsRequest) ([]*InfractionResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, input *UpdateInfractionRequest) (*InfractionResponse, error)
 	Delete(ctx context.Context, id string) error
 	GetByControle(ctx context.Context, controleID string) (*ListInfractionsResponse, error)
@@ -44,6 +48,7 @@ type service struct {
 	vehiculeRepo       repository.VehiculeRepository
 	conducteurRepo     repository.ConducteurRepository
 	pvRepo             repository.PVRepository
+	numberingService   numbering.Service
 	logger             *zap.Logger
 }
 
@@ -55,6 +60,7 @@ func NewService(
 	vehiculeRepo repository.VehiculeRepository,
 	conducteurRepo repository.ConducteurRepository,
 	pvRepo repository.PVRepository,
+	numberingService numbering.Service,
 	logger *zap.Logger,
 ) Service {
 	return &service{
@@ -64,12 +70,16 @@ func NewService(
 		vehiculeRepo:       vehiculeRepo,
 		conducteurRepo:     conducteurRepo,
 		pvRepo:             pvRepo,
+		numberingService:   numberingService,
 		logger:             logger,
 	}
 }
 
 // Create creates a new infraction
 func (s *service) Create(ctx context.Context, input *CreateInfractionRequest) (*InfractionResponse, error) {
+	ctx, span := tracing.Start(ctx, "infraction.Create")
+	defer span.End()
+
 	// Validation métier
 	if err := s.validateCreateInput(input); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
@@ -132,6 +142,9 @@ func (s *service) Create(ctx context.Context, input *CreateInfractionRequest) (*
 
 // GetByID gets infraction by ID
 func (s *service) GetByID(ctx context.Context, id string) (*InfractionResponse, error) {
+	ctx, span := tracing.Start(ctx, "infraction.GetByID")
+	defer span.End()
+
 	infractionEnt, err := s.infractionRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -142,6 +155,9 @@ func (s *service) GetByID(ctx context.Context, id string) (*InfractionResponse,
 
 // GetByNumeroPV gets infraction by numero PV
 func (s *service) GetByNumeroPV(ctx context.Context, numeroPV string) (*InfractionResponse, error) {
+	ctx, span := tracing.Start(ctx, "infraction.GetByNumeroPV")
+	defer span.End()
+
 	infractionEnt, err := s.infractionRepo.GetByNumeroPV(ctx, numeroPV)
 	if err != nil {
 		return nil, err
@@ -151,12 +167,20 @@ func (s *service) GetByNumeroPV(ctx context.Context, numeroPV string) (*Infracti
 }
 
 // List gets infractions with filters
-func (s *service) List(ctx context.Context, input *ListInfractionsRequest) (*ListInfractionsResponse, error) {
+func (s *service) List(ctx context.Context, input *ListInfractionsRequest) ([]*InfractionResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "infraction.List")
+	defer span.End()
+
 	filters := s.buildRepositoryFilters(input)
 
 	infractionsEnt, err := s.infractionRepo.List(ctx, filters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	infractionsEnt, page, err := pagination.Trim(input.Page, infractionsEnt)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	infractions := make([]*InfractionResponse, len(infractionsEnt))
@@ -164,14 +188,14 @@ func (s *service) List(ctx context.Context, input *ListInfractionsRequest) (*Lis
 		infractions[i] = s.entityToResponse(inf)
 	}
 
-	return &ListInfractionsResponse{
-		Infractions: infractions,
-		Total:       len(infractions),
-	}, nil
+	return infractions, page, nil
 }
 
 // Update updates infraction
 func (s *service) Update(ctx context.Context, id string, input *UpdateInfractionRequest) (*InfractionResponse, error) {
+	ctx, span := tracing.Start(ctx, "infraction.Update")
+	defer span.End()
+
 	// Validation métier
 	if err := s.validateUpdateInput(input); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
@@ -238,11 +262,17 @@ func (s *service) Update(ctx context.Context, id string, input *UpdateInfraction
 
 // Delete deletes infraction
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "infraction.Delete")
+	defer span.End()
+
 	return s.infractionRepo.Delete(ctx, id)
 }
 
 // GetByControle gets infractions by controle
 func (s *service) GetByControle(ctx context.Context, controleID string) (*ListInfractionsResponse, error) {
+	ctx, span := tracing.Start(ctx, "infraction.GetByControle")
+	defer span.End()
+
 	infractionsEnt, err := s.infractionRepo.GetByControle(ctx, controleID)
 	if err != nil {
 		return nil, err
@@ -261,6 +291,9 @@ func (s *service) GetByControle(ctx context.Context, controleID string) (*ListIn
 
 // GetByVehicule gets infractions by vehicule
 func (s *service) GetByVehicule(ctx context.Context, vehiculeID string) (*ListInfractionsResponse, error) {
+	ctx, span := tracing.Start(ctx, "infraction.GetByVehicule")
+	defer span.End()
+
 	infractionsEnt, err := s.infractionRepo.GetByVehicule(ctx, vehiculeID)
 	if err != nil {
 		return nil, err
@@ -279,6 +312,9 @@ func (s *service) GetByVehicule(ctx context.Context, vehiculeID string) (*ListIn
 
 // GetByConducteur gets infractions by conducteur
 func (s *service) GetByConducteur(ctx context.Context, conducteurID string) (*ListInfractionsResponse, error) {
+	ctx, span := tracing.Start(ctx, "infraction.GetByConducteur")
+	defer span.End()
+
 	infractionsEnt, err := s.infractionRepo.GetByConducteur(ctx, conducteurID)
 	if err != nil {
 		return nil, err
@@ -297,6 +333,9 @@ func (s *service) GetByConducteur(ctx context.Context, conducteurID string) (*Li
 
 // GetByStatut gets infractions by statut
 func (s *service) GetByStatut(ctx context.Context, statut string) (*ListInfractionsResponse, error) {
+	ctx, span := tracing.Start(ctx, "infraction.GetByStatut")
+	defer span.End()
+
 	infractionsEnt, err := s.infractionRepo.GetByStatut(ctx, statut)
 	if err != nil {
 		return nil, err
@@ -315,6 +354,9 @@ func (s *service) GetByStatut(ctx context.Context, statut string) (*ListInfracti
 
 // GetStatistics gets statistics for infractions
 func (s *service) GetStatistics(ctx context.Context, input *ListInfractionsRequest) (*InfractionStatisticsResponse, error) {
+	ctx, span := tracing.Start(ctx, "infraction.GetStatistics")
+	defer span.End()
+
 	repoFilters := &repository.InfractionStatsFilters{
 		DateDebut: input.DateDebut,
 		DateFin:   input.DateFin,
@@ -353,6 +395,9 @@ func (s *service) GetStatistics(ctx context.Context, input *ListInfractionsReque
 
 // GeneratePV generates a PV for an infraction
 func (s *service) GeneratePV(ctx context.Context, infractionID string) (*PVGenerationResponse, error) {
+	ctx, span := tracing.Start(ctx, "infraction.GeneratePV")
+	defer span.End()
+
 	// Récupérer l'infraction
 	infraction, err := s.GetByID(ctx, infractionID)
 	if err != nil {
@@ -369,8 +414,17 @@ func (s *service) GeneratePV(ctx context.Context, infractionID string) (*PVGener
 		}, nil
 	}
 
-	// Générer numéro PV unique
-	numeroPV := s.generateNumeroPV()
+	// Réserver le numéro PV dans la séquence du commissariat
+	resv, err := s.numberingService.Reserve(ctx, &numbering.Request{
+		Type:          numbering.TypePV,
+		InfractionIDs: []string{infractionID},
+	})
+	if err != nil {
+		s.logger.Error("Failed to reserve PV number", zap.Error(err))
+		return nil, fmt.Errorf("failed to reserve PV number: %w", err)
+	}
+	defer resv.Rollback()
+	numeroPV := resv.Numero
 
 	// Calculer la date limite de paiement (45 jours)
 	dateLimite := time.Now().AddDate(0, 0, 45)
@@ -386,12 +440,18 @@ func (s *service) GeneratePV(ctx context.Context, infractionID string) (*PVGener
 		InfractionIDs:      []string{infractionID},
 	}
 
-	pvEnt, err := s.pvRepo.Create(ctx, pvInput)
+	pvEnt, err := s.pvRepo.Create(resv.Context(), pvInput)
 	if err != nil {
 		s.logger.Error("Failed to create PV", zap.Error(err))
 		return nil, fmt.Errorf("failed to create PV: %w", err)
 	}
 
+	if err := resv.Commit(); err != nil {
+		s.logger.Error("Failed to create PV", zap.Error(err))
+		return nil, fmt.Errorf("failed to create PV: %w", err)
+	}
+	metrics.PVEmitted.Inc("infraction")
+
 	// Mettre à jour l'infraction avec le numéro PV et changer le statut
 	updateInput := &UpdateInfractionRequest{
 		NumeroPV: &numeroPV,
@@ -416,6 +476,9 @@ func (s *service) GeneratePV(ctx context.Context, infractionID string) (*PVGener
 
 // ValidateInfraction validates an infraction
 func (s *service) ValidateInfraction(ctx context.Context, infractionID string) (*InfractionValidationResponse, error) {
+	ctx, span := tracing.Start(ctx, "infraction.ValidateInfraction")
+	defer span.End()
+
 	// Récupérer l'infraction
 	infraction, err := s.GetByID(ctx, infractionID)
 	if err != nil {
@@ -457,6 +520,9 @@ func (s *service) ValidateInfraction(ctx context.Context, infractionID string) (
 
 // ArchiveInfraction archives an infraction (only PAYEE or ANNULEE can be archived)
 func (s *service) ArchiveInfraction(ctx context.Context, infractionID string) (*InfractionArchiveResponse, error) {
+	ctx, span := tracing.Start(ctx, "infraction.ArchiveInfraction")
+	defer span.End()
+
 	// Récupérer l'infraction
 	infraction, err := s.GetByID(ctx, infractionID)
 	if err != nil {
@@ -508,6 +574,9 @@ func (s *service) ArchiveInfraction(ctx context.Context, infractionID string) (*
 
 // UnarchiveInfraction unarchives an infraction (changes status back to PAYEE)
 func (s *service) UnarchiveInfraction(ctx context.Context, infractionID string) (*InfractionArchiveResponse, error) {
+	ctx, span := tracing.Start(ctx, "infraction.UnarchiveInfraction")
+	defer span.End()
+
 	// Récupérer l'infraction
 	infraction, err := s.GetByID(ctx, infractionID)
 	if err != nil {
@@ -548,6 +617,9 @@ func (s *service) UnarchiveInfraction(ctx context.Context, infractionID string)
 
 // RecordPayment records a payment for an infraction
 func (s *service) RecordPayment(ctx context.Context, infractionID string, input *PaymentRequest) (*PaymentResponse, error) {
+	ctx, span := tracing.Start(ctx, "infraction.RecordPayment")
+	defer span.End()
+
 	// Récupérer l'infraction
 	infraction, err := s.GetByID(ctx, infractionID)
 	if err != nil {
@@ -625,8 +697,11 @@ func (s *service) RecordPayment(ctx context.Context, infractionID string, input
 
 // GroupByType groups infractions by type
 func (s *service) GroupByType(ctx context.Context, input *ListInfractionsRequest) ([]*InfractionsByTypeResponse, error) {
+	ctx, span := tracing.Start(ctx, "infraction.GroupByType")
+	defer span.End()
+
 	// Récupérer toutes les infractions
-	infractions, err := s.List(ctx, input)
+	infractions, _, err := s.List(ctx, input)
 	if err != nil {
 		return nil, err
 	}
@@ -634,7 +709,7 @@ func (s *service) GroupByType(ctx context.Context, input *ListInfractionsRequest
 	// Grouper par type
 	typeMap := make(map[string]*InfractionsByTypeResponse)
 
-	for _, inf := range infractions.Infractions {
+	for _, inf := range infractions {
 		if inf.TypeInfraction == nil {
 			continue
 		}
@@ -750,12 +825,6 @@ func (s *service) calculateSpeedingPenalty(exces, baseAmende float64, basePoints
 	}
 }
 
-func (s *service) generateNumeroPV() string {
-	// Générer un numéro PV unique (format simplifié)
-	timestamp := time.Now().Unix()
-	return fmt.Sprintf("PV%d", timestamp)
-}
-
 func (s *service) buildRepositoryFilters(input *ListInfractionsRequest) *repository.InfractionFilters {
 	if input == nil {
 		return nil
@@ -774,6 +843,7 @@ func (s *service) buildRepositoryFilters(input *ListInfractionsRequest) *reposit
 		Accident:         input.Accident,
 		Limit:            input.Limit,
 		Offset:           input.Offset,
+		Page:             input.Page,
 	}
 }
 
@@ -868,6 +938,9 @@ func (s *service) entityToResponse(infractionEnt *ent.Infraction) *InfractionRes
 
 // GetTypesInfractions returns all infraction types from the database
 func (s *service) GetTypesInfractions(ctx context.Context) ([]*TypeInfractionSummary, error) {
+	ctx, span := tracing.Start(ctx, "infraction.GetTypesInfractions")
+	defer span.End()
+
 	// Récupérer les types actifs depuis la base de données
 	typesEnt, err := s.infractionTypeRepo.GetActive(ctx)
 	if err != nil {
@@ -894,6 +967,9 @@ func (s *service) GetTypesInfractions(ctx context.Context) ([]*TypeInfractionSum
 
 // GetCategories returns all infraction categories with their type counts
 func (s *service) GetCategories(ctx context.Context) ([]*CategorieResponse, error) {
+	ctx, span := tracing.Start(ctx, "infraction.GetCategories")
+	defer span.End()
+
 	// Récupérer les types actifs pour compter par catégorie
 	typesEnt, err := s.infractionTypeRepo.GetActive(ctx)
 	if err != nil {
@@ -943,6 +1019,9 @@ func getCategorieLibelle(code string) string {
 
 // GetDashboard returns dashboard data for the frontend
 func (s *service) GetDashboard(ctx context.Context, input *DashboardRequest) (*DashboardResponse, error) {
+	ctx, span := tracing.Start(ctx, "infraction.GetDashboard")
+	defer span.End()
+
 	// Calculer les dates selon la période
 	var dateDebut, dateFin time.Time
 	now := time.Now()
@@ -980,13 +1059,11 @@ func (s *service) GetDashboard(ctx context.Context, input *DashboardRequest) (*D
 		Limit:     10000,
 	}
 
-	infractionsResp, err := s.List(ctx, listRequest)
+	infractions, _, err := s.List(ctx, listRequest)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get infractions: %w", err)
 	}
 
-	infractions := infractionsResp.Infractions
-
 	// Requête séparée pour les infractions des dernières 24h (indépendant du filtre)
 	yesterday := now.AddDate(0, 0, -1)
 	last24hRequest := &ListInfractionsRequest{
@@ -994,10 +1071,10 @@ func (s *service) GetDashboard(ctx context.Context, input *DashboardRequest) (*D
 		DateFin:   &now,
 		Limit:     10000,
 	}
-	last24hResp, err := s.List(ctx, last24hRequest)
+	last24h, _, err := s.List(ctx, last24hRequest)
 	infractions24h := 0
-	if err == nil && last24hResp != nil {
-		infractions24h = last24hResp.Total
+	if err == nil {
+		infractions24h = len(last24h)
 	}
 
 	// Calculer les stats
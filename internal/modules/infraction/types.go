@@ -2,6 +2,9 @@ package infraction
 
 import (
 	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 )
 
 // Request types
@@ -54,6 +57,31 @@ type ListInfractionsRequest struct {
 	Accident         *bool      `json:"accident,omitempty"`
 	Limit            int        `json:"limit,omitempty"`
 	Offset           int        `json:"offset,omitempty"`
+	Page             *pagination.Params `json:"-"`
+}
+
+// listSpec declares the sorts and fields accepted by GET /infractions
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"date_infraction": "date_infraction",
+		"created_at":      "created_at",
+		"statut":          "statut",
+		"montant_amende":  "montant_amende",
+	},
+	DefaultSort: "-date_infraction",
+	Fields:      pagination.JSONFields(InfractionResponse{}),
+	Filters: filter.Fields{
+		"numero_pv":       {Column: "numero_pv", Type: filter.String},
+		"statut":          {Column: "statut", Type: filter.String},
+		"date_infraction": {Column: "date_infraction", Type: filter.Time},
+		"lieu_infraction": {Column: "lieu_infraction", Type: filter.String},
+		"montant_amende":  {Column: "montant_amende", Type: filter.Float},
+		"points_retires":  {Column: "points_retires", Type: filter.Int},
+		"vitesse_retenue": {Column: "vitesse_retenue", Type: filter.Float},
+		"flagrant_delit":  {Column: "flagrant_delit", Type: filter.Bool},
+		"accident":        {Column: "accident", Type: filter.Bool},
+		"created_at":      {Column: "created_at", Type: filter.Time},
+	},
 }
 
 // GeneratePVRequest represents the request to generate a PV
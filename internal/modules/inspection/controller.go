@@ -4,7 +4,10 @@ import (
 	"net/http"
 	"time"
 
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
 	"police-trafic-api-frontend-aligned/internal/modules/verification"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
 )
@@ -25,28 +28,28 @@ func NewController(service Service, verificationService verification.Service) *C
 
 // RegisterRoutes registers inspection routes
 func (c *Controller) RegisterRoutes(g *echo.Group) {
-	inspections := g.Group("/inspections")
+	inspections := rbac.Guard(g).Group("/inspections")
 
 	// List all inspections
-	inspections.GET("", c.List)
-	inspections.POST("", c.Create)
+	inspections.GET("", c.List, rbac.PermReadInspections)
+	inspections.POST("", c.Create, rbac.PermCreateInspections)
 
 	// Fixed path endpoints (must be before /:id)
-	inspections.GET("/statistics", c.GetStatistics)
-	inspections.GET("/numero/:numero", c.GetByNumero)
-	inspections.GET("/vehicule/:vehicule_id", c.GetByVehicule)
+	inspections.GET("/statistics", c.GetStatistics, rbac.PermReadInspections)
+	inspections.GET("/numero/:numero", c.GetByNumero, rbac.PermReadInspections)
+	inspections.GET("/vehicule/:vehicule_id", c.GetByVehicule, rbac.PermReadInspections)
 
 	// Nested routes under /:id (must be before single /:id GET)
-	inspections.GET("/:id/verifications", c.GetVerifications)
-	inspections.POST("/:id/verifications", c.SaveVerifications)
-	inspections.PATCH("/:id/statut", c.ChangerStatut)
+	inspections.GET("/:id/verifications", c.GetVerifications, rbac.PermReadInspections)
+	inspections.POST("/:id/verifications", c.SaveVerifications, rbac.PermUpdateInspections)
+	inspections.PATCH("/:id/statut", c.ChangerStatut, rbac.PermUpdateInspections)
 
 	// Single inspection by ID (should be last among GET routes with :id)
-	inspections.GET("/:id", c.GetByID)
+	inspections.GET("/:id", c.GetByID, rbac.PermReadInspections)
 
 	// Update/Delete
-	inspections.PUT("/:id", c.Update)
-	inspections.DELETE("/:id", c.Delete)
+	inspections.PUT("/:id", c.Update, rbac.PermUpdateInspections)
+	inspections.DELETE("/:id", c.Delete, rbac.PermDeleteInspections)
 }
 
 // Create creates a new inspection
@@ -148,12 +151,19 @@ func (c *Controller) List(ctx echo.Context) error {
 		req.InspecteurID = &inspecteurID
 	}
 
-	result, err := c.service.List(ctx.Request().Context(), req)
+	// Pagination, tri et champs
+	page, err := pagination.Parse(ctx, listSpec)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	req.Page = page
+
+	inspections, result, err := c.service.List(ctx.Request().Context(), req)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	return ctx.JSON(http.StatusOK, result)
+	return responses.Paginated(ctx, inspections, result)
 }
 
 // Update updates an inspection
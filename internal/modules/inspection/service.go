@@ -7,8 +7,11 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/inspection"
+	"police-trafic-api-frontend-aligned/ent/predicate"
 	"police-trafic-api-frontend-aligned/ent/user"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -19,7 +22,7 @@ type Service interface {
 	Create(ctx context.Context, req CreateInspectionRequest) (*InspectionResponse, error)
 	GetByID(ctx context.Context, id string) (*InspectionResponse, error)
 	GetByNumero(ctx context.Context, numero string) (*InspectionResponse, error)
-	List(ctx context.Context, req ListInspectionsRequest) (*ListInspectionsResponse, error)
+	List(ctx context.Context, req ListInspectionsRequest) ([]*InspectionResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, req UpdateInspectionRequest) (*InspectionResponse, error)
 	Delete(ctx context.Context, id string) error
 	ChangerStatut(ctx context.Context, id string, req ChangerStatutRequest) (*InspectionResponse, error)
@@ -44,6 +47,9 @@ func NewService(client *ent.Client, verificationRepo repository.VerificationRepo
 }
 
 func (s *service) Create(ctx context.Context, req CreateInspectionRequest) (*InspectionResponse, error) {
+	ctx, span := tracing.Start(ctx, "inspection.Create")
+	defer span.End()
+
 	s.logger.Info("Creating new inspection", zap.String("vehicule_immatriculation", req.VehiculeImmatriculation))
 
 	// Generate unique ID and numero
@@ -187,6 +193,9 @@ func (s *service) Create(ctx context.Context, req CreateInspectionRequest) (*Ins
 }
 
 func (s *service) GetByID(ctx context.Context, id string) (*InspectionResponse, error) {
+	ctx, span := tracing.Start(ctx, "inspection.GetByID")
+	defer span.End()
+
 	uid, _ := uuid.Parse(id)
 	ins, err := s.client.Inspection.Query().
 		Where(inspection.ID(uid)).
@@ -206,6 +215,9 @@ func (s *service) GetByID(ctx context.Context, id string) (*InspectionResponse,
 }
 
 func (s *service) GetByNumero(ctx context.Context, numero string) (*InspectionResponse, error) {
+	ctx, span := tracing.Start(ctx, "inspection.GetByNumero")
+	defer span.End()
+
 	ins, err := s.client.Inspection.Query().
 		Where(inspection.Numero(numero)).
 		WithVehicule().
@@ -223,7 +235,10 @@ func (s *service) GetByNumero(ctx context.Context, numero string) (*InspectionRe
 	return s.toResponse(ctx, ins)
 }
 
-func (s *service) List(ctx context.Context, req ListInspectionsRequest) (*ListInspectionsResponse, error) {
+func (s *service) List(ctx context.Context, req ListInspectionsRequest) ([]*InspectionResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "inspection.List")
+	defer span.End()
+
 	query := s.client.Inspection.Query()
 
 	// Apply filters
@@ -259,49 +274,64 @@ func (s *service) List(ctx context.Context, req ListInspectionsRequest) (*ListIn
 		)
 	}
 
-	// Get total count
-	total, err := query.Count(ctx)
+	// Get total count, filter= included
+	total, err := query.Clone().Where(predicate.Inspection(req.Page.Filter())).Count(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to count inspections: %w", err)
+		return nil, nil, fmt.Errorf("failed to count inspections: %w", err)
 	}
 
 	// Apply pagination
-	if req.Limit > 0 {
-		query = query.Limit(req.Limit)
+	order := ent.Desc(inspection.FieldDateInspection)
+	if page := req.Page; page != nil {
+		// Les pages suivantes partent de la dernière inspection lue
+		query = query.Where(predicate.Inspection(page.Where())).
+			Offset(page.Offset()).
+			Limit(page.FetchLimit())
+		order = page.OrderBy()
 	} else {
-		query = query.Limit(20)
-	}
-	if req.Offset > 0 {
-		query = query.Offset(req.Offset)
+		if req.Limit > 0 {
+			query = query.Limit(req.Limit)
+		} else {
+			query = query.Limit(20)
+		}
+		if req.Offset > 0 {
+			query = query.Offset(req.Offset)
+		}
 	}
 
-	// Order by date inspection descending
-	query = query.Order(ent.Desc(inspection.FieldDateInspection))
+	// Order by date inspection descending, unless sorted otherwise
+	query = query.Order(order)
 
 	// Load with edges
 	query = query.WithVehicule().WithInspecteur().WithCommissariat().WithProcesVerbal()
 
 	inspections, err := query.All(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list inspections: %w", err)
+		return nil, nil, fmt.Errorf("failed to list inspections: %w", err)
 	}
 
+	inspections, page, err := pagination.Trim(req.Page, inspections)
+	if err != nil {
+		return nil, nil, err
+	}
+	page.SetTotal(total)
+
 	responses := make([]*InspectionResponse, len(inspections))
 	for i, ins := range inspections {
 		resp, err := s.toResponse(ctx, ins)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		responses[i] = resp
 	}
 
-	return &ListInspectionsResponse{
-		Inspections: responses,
-		Total:       total,
-	}, nil
+	return responses, page, nil
 }
 
 func (s *service) Update(ctx context.Context, id string, req UpdateInspectionRequest) (*InspectionResponse, error) {
+	ctx, span := tracing.Start(ctx, "inspection.Update")
+	defer span.End()
+
 	uid, _ := uuid.Parse(id)
 	update := s.client.Inspection.UpdateOneID(uid)
 
@@ -365,6 +395,9 @@ func (s *service) Update(ctx context.Context, id string, req UpdateInspectionReq
 }
 
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "inspection.Delete")
+	defer span.End()
+
 	uid, _ := uuid.Parse(id)
 	err := s.client.Inspection.DeleteOneID(uid).Exec(ctx)
 	if err != nil {
@@ -377,6 +410,9 @@ func (s *service) Delete(ctx context.Context, id string) error {
 }
 
 func (s *service) ChangerStatut(ctx context.Context, id string, req ChangerStatutRequest) (*InspectionResponse, error) {
+	ctx, span := tracing.Start(ctx, "inspection.ChangerStatut")
+	defer span.End()
+
 	uid, _ := uuid.Parse(id)
 	update := s.client.Inspection.UpdateOneID(uid).
 		SetStatut(inspection.Statut(req.Statut))
@@ -412,6 +448,9 @@ func (s *service) ChangerStatut(ctx context.Context, id string, req ChangerStatu
 }
 
 func (s *service) GetStatistics(ctx context.Context) (*InspectionStatisticsResponse, error) {
+	ctx, span := tracing.Start(ctx, "inspection.GetStatistics")
+	defer span.End()
+
 	// Get all inspections for statistics
 	inspections, err := s.client.Inspection.Query().All(ctx)
 	if err != nil {
@@ -465,6 +504,9 @@ func (s *service) GetStatistics(ctx context.Context) (*InspectionStatisticsRespo
 }
 
 func (s *service) GetStatisticsWithFilters(ctx context.Context, dateDebut, dateFin *time.Time) (*InspectionStatisticsResponse, error) {
+	ctx, span := tracing.Start(ctx, "inspection.GetStatisticsWithFilters")
+	defer span.End()
+
 	// Build query with date filters
 	query := s.client.Inspection.Query()
 
@@ -527,6 +569,9 @@ func (s *service) GetStatisticsWithFilters(ctx context.Context, dateDebut, dateF
 }
 
 func (s *service) GetByVehicule(ctx context.Context, vehiculeID string) ([]*InspectionResponse, error) {
+	ctx, span := tracing.Start(ctx, "inspection.GetByVehicule")
+	defer span.End()
+
 	inspections, err := s.client.Inspection.Query().
 		Where(inspection.HasVehiculeWith()).
 		WithVehicule().
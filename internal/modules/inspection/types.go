@@ -2,6 +2,9 @@ package inspection
 
 import (
 	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 )
 
 // Request types
@@ -82,17 +85,44 @@ type UpdateInspectionRequest struct {
 
 // ListInspectionsRequest represents the request to list inspections
 type ListInspectionsRequest struct {
-	VehiculeID              *string    `json:"vehicule_id,omitempty"`
-	InspecteurID            *string    `json:"inspecteur_id,omitempty"`
-	CommissariatID          *string    `json:"commissariat_id,omitempty"`
-	Statut                  *string    `json:"statut,omitempty"`
-	AssuranceStatut         *string    `json:"assurance_statut,omitempty"`
-	VehiculeImmatriculation *string    `json:"vehicule_immatriculation,omitempty"`
-	DateDebut               *time.Time `json:"date_debut,omitempty"`
-	DateFin                 *time.Time `json:"date_fin,omitempty"`
-	Search                  *string    `json:"search,omitempty"`
-	Limit                   int        `json:"limit,omitempty"`
-	Offset                  int        `json:"offset,omitempty"`
+	VehiculeID              *string            `json:"vehicule_id,omitempty"`
+	InspecteurID            *string            `json:"inspecteur_id,omitempty"`
+	CommissariatID          *string            `json:"commissariat_id,omitempty"`
+	Statut                  *string            `json:"statut,omitempty"`
+	AssuranceStatut         *string            `json:"assurance_statut,omitempty"`
+	VehiculeImmatriculation *string            `json:"vehicule_immatriculation,omitempty"`
+	DateDebut               *time.Time         `json:"date_debut,omitempty"`
+	DateFin                 *time.Time         `json:"date_fin,omitempty"`
+	Search                  *string            `json:"search,omitempty"`
+	Limit                   int                `json:"limit,omitempty"`
+	Offset                  int                `json:"offset,omitempty"`
+	Page                    *pagination.Params `json:"-"`
+}
+
+// listSpec declares the sorts and fields accepted by GET /inspections
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"date_inspection": "date_inspection",
+		"created_at":      "created_at",
+		"numero":          "numero",
+		"statut":          "statut",
+	},
+	DefaultSort: "-date_inspection",
+	Fields:      pagination.JSONFields(InspectionResponse{}),
+	Filters: filter.Fields{
+		"numero":                    {Column: "numero", Type: filter.String},
+		"statut":                    {Column: "statut", Type: filter.String},
+		"lieu_inspection":           {Column: "lieu_inspection", Type: filter.String},
+		"montant_total_amendes":     {Column: "montant_total_amendes", Type: filter.Int},
+		"verifications_echec":       {Column: "verifications_echec", Type: filter.Int},
+		"vehicule_immatriculation":  {Column: "vehicule_immatriculation", Type: filter.String},
+		"vehicule_type":             {Column: "vehicule_type", Type: filter.String},
+		"conducteur_numero_permis":  {Column: "conducteur_numero_permis", Type: filter.String},
+		"assurance_statut":          {Column: "assurance_statut", Type: filter.String},
+		"assurance_date_expiration": {Column: "assurance_date_expiration", Type: filter.Time},
+		"date_inspection":           {Column: "date_inspection", Type: filter.Time},
+		"created_at":                {Column: "created_at", Type: filter.Time},
+	},
 }
 
 // Response types
@@ -178,12 +208,6 @@ type PVSummary struct {
 	Statut       string    `json:"statut"`
 }
 
-// ListInspectionsResponse represents the response for listing inspections
-type ListInspectionsResponse struct {
-	Inspections []*InspectionResponse `json:"inspections"`
-	Total       int                   `json:"total"`
-}
-
 // ChangerStatutRequest represents request to change inspection status
 type ChangerStatutRequest struct {
 	Statut       string  `json:"statut" validate:"required,oneof=EN_ATTENTE EN_COURS TERMINE CONFORME NON_CONFORME"`
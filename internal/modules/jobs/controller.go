@@ -0,0 +1,150 @@
+package jobs
+
+import (
+	"errors"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/scheduler"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+	"police-trafic-api-frontend-aligned/internal/shared/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Controller handles background job administration requests
+type Controller struct {
+	scheduler scheduler.Scheduler
+}
+
+// NewController creates a new jobs controller
+func NewController(s scheduler.Scheduler) *Controller {
+	return &Controller{
+		scheduler: s,
+	}
+}
+
+// RegisterRoutes registers job administration routes
+func (ctrl *Controller) RegisterRoutes(e *echo.Group) {
+	jobs := rbac.Guard(e).Group("/admin/jobs")
+
+	jobs.GET("", ctrl.List, rbac.PermManageSystem)
+	jobs.GET("/:name/runs", ctrl.Runs, rbac.PermManageSystem)
+	jobs.POST("/:name/trigger", ctrl.Trigger, rbac.PermManageSystem)
+	jobs.POST("/:name/pause", ctrl.Pause, rbac.PermManageSystem)
+	jobs.POST("/:name/resume", ctrl.Resume, rbac.PermManageSystem)
+}
+
+// List handles GET /admin/jobs
+func (ctrl *Controller) List(c echo.Context) error {
+	page, err := pagination.Parse(c, listSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
+	infos, err := ctrl.scheduler.Jobs(c.Request().Context())
+	if err != nil {
+		return responses.InternalServerError(c, err.Error())
+	}
+
+	result := make([]*JobResponse, len(infos))
+	for i, info := range infos {
+		result[i] = &JobResponse{
+			Name:        info.Name,
+			Description: info.Description,
+			Schedule:    info.Schedule,
+			Paused:      info.Paused,
+			Running:     info.Running,
+			LockedBy:    info.LockedBy,
+			NextRunAt:   info.NextRunAt,
+			LastRunAt:   info.LastRunAt,
+			LastStatus:  info.LastStatus,
+		}
+	}
+
+	jobs, listPage := pagination.Slice(page, result)
+	return responses.Paginated(c, jobs, listPage)
+}
+
+// Runs handles GET /admin/jobs/:name/runs
+func (ctrl *Controller) Runs(c echo.Context) error {
+	page, err := pagination.Parse(c, runSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
+	runs, total, err := ctrl.scheduler.Runs(c.Request().Context(), c.Param("name"), page)
+	if err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	runs, runPage, err := pagination.Trim(page, runs)
+	if err != nil {
+		return responses.InternalServerError(c, err.Error())
+	}
+	runPage.SetTotal(total)
+
+	result := make([]*JobRunResponse, len(runs))
+	for i, run := range runs {
+		result[i] = toRunResponse(run)
+	}
+
+	return responses.Paginated(c, result, runPage)
+}
+
+// Trigger handles POST /admin/jobs/:name/trigger
+func (ctrl *Controller) Trigger(c echo.Context) error {
+	triggeredBy, _ := c.Get("matricule").(string)
+
+	run, err := ctrl.scheduler.Trigger(c.Request().Context(), c.Param("name"), triggeredBy)
+	if err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	return responses.SuccessWithMessage(c, "Job started", toRunResponse(run))
+}
+
+// Pause handles POST /admin/jobs/:name/pause
+func (ctrl *Controller) Pause(c echo.Context) error {
+	if err := ctrl.scheduler.Pause(c.Request().Context(), c.Param("name")); err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	return responses.SuccessWithMessage(c, "Job paused", nil)
+}
+
+// Resume handles POST /admin/jobs/:name/resume
+func (ctrl *Controller) Resume(c echo.Context) error {
+	if err := ctrl.scheduler.Resume(c.Request().Context(), c.Param("name")); err != nil {
+		return ctrl.handleError(c, err)
+	}
+
+	return responses.SuccessWithMessage(c, "Job resumed", nil)
+}
+
+func (ctrl *Controller) handleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, scheduler.ErrJobNotFound):
+		return responses.NotFound(c, "Job not found")
+	case errors.Is(err, scheduler.ErrJobRunning):
+		return responses.Conflict(c, "Job is already running")
+	default:
+		return responses.InternalServerError(c, err.Error())
+	}
+}
+
+func toRunResponse(run *ent.JobRun) *JobRunResponse {
+	return &JobRunResponse{
+		ID:           run.ID.String(),
+		JobName:      run.JobName,
+		Trigger:      run.Trigger,
+		TriggeredBy:  run.TriggeredBy,
+		InstanceID:   run.InstanceID,
+		Status:       run.Status,
+		StartedAt:    run.StartedAt,
+		FinishedAt:   run.FinishedAt,
+		DurationMs:   run.DurationMs,
+		Result:       run.Result,
+		ErrorMessage: run.ErrorMessage,
+	}
+}
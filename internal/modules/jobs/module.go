@@ -0,0 +1,18 @@
+package jobs
+
+import (
+	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+
+	"go.uber.org/fx"
+)
+
+// Module provides background job administration endpoints
+var Module = fx.Module("jobs",
+	fx.Provide(
+		fx.Annotate(
+			NewController,
+			fx.As(new(interfaces.Controller)),
+			fx.ResultTags(`group:"controllers"`),
+		),
+	),
+)
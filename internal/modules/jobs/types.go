@@ -0,0 +1,66 @@
+package jobs
+
+import (
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+)
+
+// listSpec declares the sorts and fields accepted by GET /admin/jobs
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"name":      "name",
+		"nextRunAt": "nextRunAt",
+		"lastRunAt": "lastRunAt",
+	},
+	DefaultSort: "name",
+	Fields:      pagination.JSONFields(JobResponse{}),
+}
+
+// runSpec declares the sorts and fields accepted by GET /admin/jobs/:name/runs
+var runSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"startedAt": "started_at",
+		"status":    "status",
+	},
+	DefaultSort: "-startedAt",
+	Fields:      pagination.JSONFields(JobRunResponse{}),
+	Filters: filter.Fields{
+		"status":      {Column: "status", Type: filter.String},
+		"trigger":     {Column: "trigger", Type: filter.String},
+		"triggeredBy": {Column: "triggered_by", Type: filter.String},
+		"instanceId":  {Column: "instance_id", Type: filter.String},
+		"durationMs":  {Column: "duration_ms", Type: filter.Int},
+		"startedAt":   {Column: "started_at", Type: filter.Time},
+		"finishedAt":  {Column: "finished_at", Type: filter.Time},
+	},
+}
+
+// JobResponse represents a scheduled job and its current state
+type JobResponse struct {
+	Name        string     `json:"name"`
+	Description string     `json:"description"`
+	Schedule    string     `json:"schedule"`
+	Paused      bool       `json:"paused"`
+	Running     bool       `json:"running"`
+	LockedBy    string     `json:"lockedBy,omitempty"`
+	NextRunAt   *time.Time `json:"nextRunAt,omitempty"`
+	LastRunAt   *time.Time `json:"lastRunAt,omitempty"`
+	LastStatus  string     `json:"lastStatus,omitempty"`
+}
+
+// JobRunResponse represents one execution of a job
+type JobRunResponse struct {
+	ID           string     `json:"id"`
+	JobName      string     `json:"jobName"`
+	Trigger      string     `json:"trigger"`
+	TriggeredBy  string     `json:"triggeredBy,omitempty"`
+	InstanceID   string     `json:"instanceId,omitempty"`
+	Status       string     `json:"status"`
+	StartedAt    time.Time  `json:"startedAt"`
+	FinishedAt   *time.Time `json:"finishedAt,omitempty"`
+	DurationMs   int64      `json:"durationMs,omitempty"`
+	Result       string     `json:"result,omitempty"`
+	ErrorMessage string     `json:"errorMessage,omitempty"`
+}
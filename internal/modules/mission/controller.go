@@ -5,6 +5,9 @@ import (
 	"strconv"
 
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
@@ -87,6 +90,7 @@ func (c *Controller) GetByID(ctx echo.Context) error {
 // @Param type query string false "Type"
 // @Param dateDebut query string false "Date debut (YYYY-MM-DD)"
 // @Param dateFin query string false "Date fin (YYYY-MM-DD)"
+// @Param filter query string false "Filter expression, e.g. statut in (EN_COURS,PLANIFIEE) and dateDebut >= 2026-01-01"
 // @Success 200 {array} MissionResponse
 // @Router /api/missions [get]
 func (c *Controller) List(ctx echo.Context) error {
@@ -95,13 +99,19 @@ func (c *Controller) List(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid query parameters")
 	}
 
-	missions, err := c.service.List(ctx.Request().Context(), &filters)
+	page, err := pagination.Parse(ctx, listSpec)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	filters.Page = page
+
+	missions, result, err := c.service.List(ctx.Request().Context(), &filters)
 	if err != nil {
 		c.logger.Error("Failed to list missions", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusOK, map[string]interface{}{"data": missions})
+	return responses.Paginated(ctx, missions, result)
 }
 
 // Update updates a mission
@@ -375,19 +385,21 @@ func (c *Controller) RemoveAgent(ctx echo.Context) error {
 
 // RegisterRoutes registers mission routes
 func (c *Controller) RegisterRoutes(g *echo.Group) {
-	missions := g.Group("/missions")
-	missions.POST("", c.Create)
-	missions.GET("", c.List)
-	missions.GET("/:id", c.GetByID)
-	missions.PUT("/:id", c.Update)
-	missions.DELETE("/:id", c.Delete)
-	missions.POST("/:id/start", c.StartMission)
-	missions.POST("/:id/end", c.EndMission)
-	missions.POST("/:id/cancel", c.CancelMission)
-	missions.POST("/:id/agents", c.AddAgents)
-	missions.DELETE("/:id/agents/:agentId", c.RemoveAgent)
+	routes := rbac.Guard(g)
+
+	missions := routes.Group("/missions")
+	missions.POST("", c.Create, rbac.PermCreateMissions)
+	missions.GET("", c.List, rbac.PermReadMissions)
+	missions.GET("/:id", c.GetByID, rbac.PermReadMissions)
+	missions.PUT("/:id", c.Update, rbac.PermUpdateMissions)
+	missions.DELETE("/:id", c.Delete, rbac.PermDeleteMissions)
+	missions.POST("/:id/start", c.StartMission, rbac.PermUpdateMissions)
+	missions.POST("/:id/end", c.EndMission, rbac.PermUpdateMissions)
+	missions.POST("/:id/cancel", c.CancelMission, rbac.PermUpdateMissions)
+	missions.POST("/:id/agents", c.AddAgents, rbac.PermUpdateMissions)
+	missions.DELETE("/:id/agents/:agentId", c.RemoveAgent, rbac.PermUpdateMissions)
 
 	// Nested routes
-	g.GET("/agents/:agentId/missions", c.GetByAgent)
-	g.GET("/equipes/:equipeId/missions", c.GetByEquipe)
+	routes.GET("/agents/:agentId/missions", c.GetByAgent, rbac.PermReadMissions)
+	routes.GET("/equipes/:equipeId/missions", c.GetByEquipe, rbac.PermReadMissions)
 }
@@ -6,6 +6,8 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -15,7 +17,7 @@ import (
 type Service interface {
 	Create(ctx context.Context, req *CreateMissionRequest) (*MissionResponse, error)
 	GetByID(ctx context.Context, id string) (*MissionResponse, error)
-	List(ctx context.Context, filters *ListMissionsFilters) ([]MissionResponse, error)
+	List(ctx context.Context, filters *ListMissionsFilters) ([]MissionResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, req *UpdateMissionRequest) (*MissionResponse, error)
 	Delete(ctx context.Context, id string) error
 	GetByAgent(ctx context.Context, agentID string, limit int) ([]MissionResponse, error)
@@ -42,6 +44,9 @@ func NewService(repo repository.MissionRepository, logger *zap.Logger) Service {
 
 // Create creates a new mission
 func (s *service) Create(ctx context.Context, req *CreateMissionRequest) (*MissionResponse, error) {
+	ctx, span := tracing.Start(ctx, "mission.Create")
+	defer span.End()
+
 	input := &repository.CreateMissionInput{
 		ID:             uuid.New().String(),
 		Type:           req.Type,
@@ -65,6 +70,9 @@ func (s *service) Create(ctx context.Context, req *CreateMissionRequest) (*Missi
 
 // GetByID gets a mission by ID
 func (s *service) GetByID(ctx context.Context, id string) (*MissionResponse, error) {
+	ctx, span := tracing.Start(ctx, "mission.GetByID")
+	defer span.End()
+
 	mission, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -74,7 +82,10 @@ func (s *service) GetByID(ctx context.Context, id string) (*MissionResponse, err
 }
 
 // List lists missions with filters
-func (s *service) List(ctx context.Context, filters *ListMissionsFilters) ([]MissionResponse, error) {
+func (s *service) List(ctx context.Context, filters *ListMissionsFilters) ([]MissionResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "mission.List")
+	defer span.End()
+
 	repoFilters := &repository.MissionFilters{}
 
 	if filters != nil {
@@ -103,11 +114,17 @@ func (s *service) List(ctx context.Context, filters *ListMissionsFilters) ([]Mis
 				repoFilters.DateFin = &t
 			}
 		}
+		repoFilters.Page = filters.Page
 	}
 
 	missions, err := s.repo.List(ctx, repoFilters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	missions, page, err := pagination.Trim(repoFilters.Page, missions)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	responses := make([]MissionResponse, len(missions))
@@ -115,11 +132,14 @@ func (s *service) List(ctx context.Context, filters *ListMissionsFilters) ([]Mis
 		responses[i] = *s.toResponse(m)
 	}
 
-	return responses, nil
+	return responses, page, nil
 }
 
 // Update updates a mission
 func (s *service) Update(ctx context.Context, id string, req *UpdateMissionRequest) (*MissionResponse, error) {
+	ctx, span := tracing.Start(ctx, "mission.Update")
+	defer span.End()
+
 	input := &repository.UpdateMissionInput{
 		Titre:   req.Titre,
 		Zone:    req.Zone,
@@ -139,11 +159,17 @@ func (s *service) Update(ctx context.Context, id string, req *UpdateMissionReque
 
 // Delete deletes a mission
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "mission.Delete")
+	defer span.End()
+
 	return s.repo.Delete(ctx, id)
 }
 
 // GetByAgent gets missions for an agent
 func (s *service) GetByAgent(ctx context.Context, agentID string, limit int) ([]MissionResponse, error) {
+	ctx, span := tracing.Start(ctx, "mission.GetByAgent")
+	defer span.End()
+
 	missions, err := s.repo.GetByAgent(ctx, agentID, limit)
 	if err != nil {
 		return nil, err
@@ -159,6 +185,9 @@ func (s *service) GetByAgent(ctx context.Context, agentID string, limit int) ([]
 
 // GetByEquipe gets missions for an equipe
 func (s *service) GetByEquipe(ctx context.Context, equipeID string) ([]MissionResponse, error) {
+	ctx, span := tracing.Start(ctx, "mission.GetByEquipe")
+	defer span.End()
+
 	missions, err := s.repo.GetByEquipe(ctx, equipeID)
 	if err != nil {
 		return nil, err
@@ -174,6 +203,9 @@ func (s *service) GetByEquipe(ctx context.Context, equipeID string) ([]MissionRe
 
 // StartMission starts a mission
 func (s *service) StartMission(ctx context.Context, id string) (*MissionResponse, error) {
+	ctx, span := tracing.Start(ctx, "mission.StartMission")
+	defer span.End()
+
 	mission, err := s.repo.StartMission(ctx, id)
 	if err != nil {
 		return nil, err
@@ -184,6 +216,9 @@ func (s *service) StartMission(ctx context.Context, id string) (*MissionResponse
 
 // EndMission ends a mission with a rapport
 func (s *service) EndMission(ctx context.Context, id string, req *EndMissionRequest) (*MissionResponse, error) {
+	ctx, span := tracing.Start(ctx, "mission.EndMission")
+	defer span.End()
+
 	mission, err := s.repo.EndMission(ctx, id, req.Rapport)
 	if err != nil {
 		return nil, err
@@ -194,6 +229,9 @@ func (s *service) EndMission(ctx context.Context, id string, req *EndMissionRequ
 
 // CancelMission cancels a mission
 func (s *service) CancelMission(ctx context.Context, id string, req *CancelMissionRequest) (*MissionResponse, error) {
+	ctx, span := tracing.Start(ctx, "mission.CancelMission")
+	defer span.End()
+
 	raison := ""
 	if req != nil {
 		raison = req.Raison
@@ -208,6 +246,9 @@ func (s *service) CancelMission(ctx context.Context, id string, req *CancelMissi
 
 // AddAgents adds agents to a mission
 func (s *service) AddAgents(ctx context.Context, missionID string, req *AddAgentsRequest) (*MissionResponse, error) {
+	ctx, span := tracing.Start(ctx, "mission.AddAgents")
+	defer span.End()
+
 	mission, err := s.repo.AddAgents(ctx, missionID, req.AgentIDs)
 	if err != nil {
 		return nil, err
@@ -218,6 +259,9 @@ func (s *service) AddAgents(ctx context.Context, missionID string, req *AddAgent
 
 // RemoveAgent removes an agent from a mission
 func (s *service) RemoveAgent(ctx context.Context, missionID string, req *RemoveAgentRequest) (*MissionResponse, error) {
+	ctx, span := tracing.Start(ctx, "mission.RemoveAgent")
+	defer span.End()
+
 	mission, err := s.repo.RemoveAgent(ctx, missionID, req.AgentID)
 	if err != nil {
 		return nil, err
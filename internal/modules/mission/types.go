@@ -1,6 +1,11 @@
 package mission
 
-import "time"
+import (
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+)
 
 // MissionResponse represents a mission in API responses
 type MissionResponse struct {
@@ -88,11 +93,33 @@ type EndMissionRequest struct {
 
 // ListMissionsFilters represents query filters for listing missions
 type ListMissionsFilters struct {
-	AgentID        string `query:"agentId"`
-	EquipeID       string `query:"equipeId"`
-	CommissariatID string `query:"commissariatId"`
-	Statut         string `query:"statut"`
-	Type           string `query:"type"`
-	DateDebut      string `query:"dateDebut"`
-	DateFin        string `query:"dateFin"`
+	AgentID        string             `query:"agentId"`
+	EquipeID       string             `query:"equipeId"`
+	CommissariatID string             `query:"commissariatId"`
+	Statut         string             `query:"statut"`
+	Type           string             `query:"type"`
+	DateDebut      string             `query:"dateDebut"`
+	DateFin        string             `query:"dateFin"`
+	Page           *pagination.Params // Set by the controller
+}
+
+// listSpec declares the sorts and fields accepted by GET /missions
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"dateDebut": "date_debut",
+		"createdAt": "created_at",
+		"statut":    "statut",
+		"type":      "type",
+	},
+	DefaultSort: "-dateDebut",
+	Fields:      pagination.JSONFields(MissionResponse{}),
+	Filters: filter.Fields{
+		"type":      {Column: "type", Type: filter.String},
+		"titre":     {Column: "titre", Type: filter.String},
+		"zone":      {Column: "zone", Type: filter.String},
+		"statut":    {Column: "statut", Type: filter.String},
+		"dateDebut": {Column: "date_debut", Type: filter.Time},
+		"dateFin":   {Column: "date_fin", Type: filter.Time},
+		"createdAt": {Column: "created_at", Type: filter.Time},
+	},
 }
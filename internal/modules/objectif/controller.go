@@ -4,6 +4,9 @@ import (
 	"net/http"
 
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
@@ -84,6 +87,7 @@ func (c *Controller) GetByID(ctx echo.Context) error {
 // @Param statut query string false "Statut"
 // @Param dateDebut query string false "Date debut (YYYY-MM-DD)"
 // @Param dateFin query string false "Date fin (YYYY-MM-DD)"
+// @Param filter query string false "Filter expression, e.g. statut = EN_COURS and progression < 50"
 // @Success 200 {array} ObjectifResponse
 // @Router /api/objectifs [get]
 func (c *Controller) List(ctx echo.Context) error {
@@ -92,13 +96,19 @@ func (c *Controller) List(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid query parameters")
 	}
 
-	objectifs, err := c.service.List(ctx.Request().Context(), &filters)
+	page, err := pagination.Parse(ctx, listSpec)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	filters.Page = page
+
+	objectifs, result, err := c.service.List(ctx.Request().Context(), &filters)
 	if err != nil {
 		c.logger.Error("Failed to list objectifs", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusOK, objectifs)
+	return responses.Paginated(ctx, objectifs, result)
 }
 
 // Update updates an objectif
@@ -216,14 +226,16 @@ func (c *Controller) UpdateProgression(ctx echo.Context) error {
 
 // RegisterRoutes registers objectif routes
 func (c *Controller) RegisterRoutes(g *echo.Group) {
-	objectifs := g.Group("/objectifs")
-	objectifs.POST("", c.Create)
-	objectifs.GET("", c.List)
-	objectifs.GET("/:id", c.GetByID)
-	objectifs.PUT("/:id", c.Update)
-	objectifs.DELETE("/:id", c.Delete)
-	objectifs.PUT("/:id/progression", c.UpdateProgression)
+	routes := rbac.Guard(g)
+
+	objectifs := routes.Group("/objectifs")
+	objectifs.POST("", c.Create, rbac.PermManageObjectifs)
+	objectifs.GET("", c.List, rbac.PermReadObjectifs)
+	objectifs.GET("/:id", c.GetByID, rbac.PermReadObjectifs)
+	objectifs.PUT("/:id", c.Update, rbac.PermManageObjectifs)
+	objectifs.DELETE("/:id", c.Delete, rbac.PermManageObjectifs)
+	objectifs.PUT("/:id/progression", c.UpdateProgression, rbac.PermManageObjectifs)
 
 	// Nested routes
-	g.GET("/agents/:agentId/objectifs", c.GetByAgent)
+	routes.GET("/agents/:agentId/objectifs", c.GetByAgent, rbac.PermReadObjectifs)
 }
@@ -6,6 +6,8 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -15,7 +17,7 @@ import (
 type Service interface {
 	Create(ctx context.Context, req *CreateObjectifRequest) (*ObjectifResponse, error)
 	GetByID(ctx context.Context, id string) (*ObjectifResponse, error)
-	List(ctx context.Context, filters *ListObjectifsFilters) ([]ObjectifResponse, error)
+	List(ctx context.Context, filters *ListObjectifsFilters) ([]ObjectifResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, req *UpdateObjectifRequest) (*ObjectifResponse, error)
 	Delete(ctx context.Context, id string) error
 	GetByAgent(ctx context.Context, agentID string) ([]ObjectifResponse, error)
@@ -37,6 +39,9 @@ func NewService(repo repository.ObjectifRepository, logger *zap.Logger) Service
 
 // Create creates a new objectif
 func (s *service) Create(ctx context.Context, req *CreateObjectifRequest) (*ObjectifResponse, error) {
+	ctx, span := tracing.Start(ctx, "objectif.Create")
+	defer span.End()
+
 	input := &repository.CreateObjectifInput{
 		ID:          uuid.New().String(),
 		Titre:       req.Titre,
@@ -58,6 +63,9 @@ func (s *service) Create(ctx context.Context, req *CreateObjectifRequest) (*Obje
 
 // GetByID gets an objectif by ID
 func (s *service) GetByID(ctx context.Context, id string) (*ObjectifResponse, error) {
+	ctx, span := tracing.Start(ctx, "objectif.GetByID")
+	defer span.End()
+
 	objectif, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -67,7 +75,10 @@ func (s *service) GetByID(ctx context.Context, id string) (*ObjectifResponse, er
 }
 
 // List lists objectifs with filters
-func (s *service) List(ctx context.Context, filters *ListObjectifsFilters) ([]ObjectifResponse, error) {
+func (s *service) List(ctx context.Context, filters *ListObjectifsFilters) ([]ObjectifResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "objectif.List")
+	defer span.End()
+
 	repoFilters := &repository.ObjectifFilters{}
 
 	if filters != nil {
@@ -90,11 +101,17 @@ func (s *service) List(ctx context.Context, filters *ListObjectifsFilters) ([]Ob
 				repoFilters.DateFin = &t
 			}
 		}
+		repoFilters.Page = filters.Page
 	}
 
 	objectifs, err := s.repo.List(ctx, repoFilters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	objectifs, page, err := pagination.Trim(repoFilters.Page, objectifs)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	responses := make([]ObjectifResponse, len(objectifs))
@@ -102,11 +119,14 @@ func (s *service) List(ctx context.Context, filters *ListObjectifsFilters) ([]Ob
 		responses[i] = *s.toResponse(obj)
 	}
 
-	return responses, nil
+	return responses, page, nil
 }
 
 // Update updates an objectif
 func (s *service) Update(ctx context.Context, id string, req *UpdateObjectifRequest) (*ObjectifResponse, error) {
+	ctx, span := tracing.Start(ctx, "objectif.Update")
+	defer span.End()
+
 	input := &repository.UpdateObjectifInput{
 		Titre:          req.Titre,
 		Description:    req.Description,
@@ -126,11 +146,17 @@ func (s *service) Update(ctx context.Context, id string, req *UpdateObjectifRequ
 
 // Delete deletes an objectif
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "objectif.Delete")
+	defer span.End()
+
 	return s.repo.Delete(ctx, id)
 }
 
 // GetByAgent gets objectifs for an agent
 func (s *service) GetByAgent(ctx context.Context, agentID string) ([]ObjectifResponse, error) {
+	ctx, span := tracing.Start(ctx, "objectif.GetByAgent")
+	defer span.End()
+
 	objectifs, err := s.repo.GetByAgent(ctx, agentID)
 	if err != nil {
 		return nil, err
@@ -146,6 +172,9 @@ func (s *service) GetByAgent(ctx context.Context, agentID string) ([]ObjectifRes
 
 // UpdateProgression updates the progression of an objectif
 func (s *service) UpdateProgression(ctx context.Context, id string, req *UpdateProgressionRequest) (*ObjectifResponse, error) {
+	ctx, span := tracing.Start(ctx, "objectif.UpdateProgression")
+	defer span.End()
+
 	objectif, err := s.repo.UpdateProgression(ctx, id, req.ValeurActuelle)
 	if err != nil {
 		return nil, err
@@ -1,6 +1,11 @@
 package objectif
 
-import "time"
+import (
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+)
 
 // ObjectifResponse represents an objectif in API responses
 type ObjectifResponse struct {
@@ -55,9 +60,33 @@ type UpdateProgressionRequest struct {
 
 // ListObjectifsFilters represents query filters for listing objectifs
 type ListObjectifsFilters struct {
-	AgentID   string `query:"agentId"`
-	Periode   string `query:"periode"`
-	Statut    string `query:"statut"`
-	DateDebut string `query:"dateDebut"`
-	DateFin   string `query:"dateFin"`
+	AgentID   string             `query:"agentId"`
+	Periode   string             `query:"periode"`
+	Statut    string             `query:"statut"`
+	DateDebut string             `query:"dateDebut"`
+	DateFin   string             `query:"dateFin"`
+	Page      *pagination.Params // Set by the controller
+}
+
+// listSpec declares the sorts and fields accepted by GET /objectifs
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"createdAt":   "created_at",
+		"dateDebut":   "date_debut",
+		"statut":      "statut",
+		"progression": "progression",
+	},
+	DefaultSort: "-createdAt",
+	Fields:      pagination.JSONFields(ObjectifResponse{}),
+	Filters: filter.Fields{
+		"titre":          {Column: "titre", Type: filter.String},
+		"periode":        {Column: "periode", Type: filter.String},
+		"statut":         {Column: "statut", Type: filter.String},
+		"valeurCible":    {Column: "valeur_cible", Type: filter.Int},
+		"valeurActuelle": {Column: "valeur_actuelle", Type: filter.Int},
+		"progression":    {Column: "progression", Type: filter.Float},
+		"dateDebut":      {Column: "date_debut", Type: filter.Time},
+		"dateFin":        {Column: "date_fin", Type: filter.Time},
+		"createdAt":      {Column: "created_at", Type: filter.Time},
+	},
 }
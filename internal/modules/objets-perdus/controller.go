@@ -3,10 +3,11 @@ package objetsperdus
 import (
 	"fmt"
 	"net/http"
-	"strconv"
 	"time"
 
 	"police-trafic-api-frontend-aligned/internal/core/middleware"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/google/uuid"
@@ -34,27 +35,24 @@ func NewController(service Service, authMiddleware *middleware.AuthMiddleware, l
 func (ctrl *Controller) RegisterRoutes(e *echo.Group) {
 	ctrl.logger.Info("Registering objets-perdus routes")
 	
-	objetsPerdus := e.Group("/objets-perdus")
-
-	// Appliquer le middleware d'authentification à toutes les routes
-	objetsPerdus.Use(ctrl.authMiddleware.RequireAuth())
+	objetsPerdus := rbac.Guard(e).Group("/objets-perdus")
 
 	// Routes principales
-	objetsPerdus.POST("", ctrl.Create)
-	objetsPerdus.GET("", ctrl.List)
+	objetsPerdus.POST("", ctrl.Create, rbac.PermCreateObjetsPerdus)
+	objetsPerdus.GET("", ctrl.List, rbac.PermReadObjetsPerdus)
 	
 	// Routes fixes (AVANT /:id pour éviter les conflits)
 	// IMPORTANT: Ces routes doivent être définies AVANT /:id pour être matchées correctement
-	objetsPerdus.POST("/check-matches", ctrl.CheckMatches)
-	objetsPerdus.GET("/statistiques", ctrl.GetStatistiques)
-	objetsPerdus.GET("/dashboard", ctrl.GetDashboard)
+	objetsPerdus.POST("/check-matches", ctrl.CheckMatches, rbac.PermReadObjetsPerdus)
+	objetsPerdus.GET("/statistiques", ctrl.GetStatistiques, rbac.PermReadObjetsPerdus)
+	objetsPerdus.GET("/dashboard", ctrl.GetDashboard, rbac.PermReadObjetsPerdus)
 	
 	// Route avec paramètre :id (APRÈS les routes fixes)
 	// Cette route sera matchée en dernier pour éviter d'intercepter les routes fixes
-	objetsPerdus.GET("/:id", ctrl.GetByID)
-	objetsPerdus.PATCH("/:id", ctrl.Update)
-	objetsPerdus.PATCH("/:id/statut", ctrl.UpdateStatut)
-	objetsPerdus.DELETE("/:id", ctrl.Delete)
+	objetsPerdus.GET("/:id", ctrl.GetByID, rbac.PermReadObjetsPerdus)
+	objetsPerdus.PATCH("/:id", ctrl.Update, rbac.PermUpdateObjetsPerdus)
+	objetsPerdus.PATCH("/:id/statut", ctrl.UpdateStatut, rbac.PermUpdateObjetsPerdus)
+	objetsPerdus.DELETE("/:id", ctrl.Delete, rbac.PermDeleteObjetsPerdus)
 	
 	ctrl.logger.Info("Objets-perdus routes registered successfully",
 		zap.String("base_path", "/api/objets-perdus"),
@@ -262,17 +260,12 @@ func (ctrl *Controller) List(c echo.Context) error {
 		}
 	}
 
-	// Parse pagination
-	if page := c.QueryParam("page"); page != "" {
-		if p, err := strconv.Atoi(page); err == nil && p > 0 {
-			filters.Page = p
-		}
-	}
-	if limit := c.QueryParam("limit"); limit != "" {
-		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
-			filters.Limit = l
-		}
+	// Pagination, tri et champs
+	page, err := pagination.Parse(c, listSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
 	}
+	filters.Page = page
 
 	// Get user context for filtering
 	userID := getUserIDFromContext(c)
@@ -284,12 +277,12 @@ func (ctrl *Controller) List(c echo.Context) error {
 		roleStr = role.(string)
 	}
 
-	result, err := ctrl.service.List(c.Request().Context(), filters, roleStr, userID, commissariatFromContext)
+	objets, result, err := ctrl.service.List(c.Request().Context(), filters, roleStr, userID, commissariatFromContext)
 	if err != nil {
 		return responses.InternalServerError(c, err.Error())
 	}
 
-	return responses.Success(c, result)
+	return responses.Paginated(c, objets, result)
 }
 
 // Update handles PATCH /objets-perdus/:id
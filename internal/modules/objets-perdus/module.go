@@ -4,6 +4,7 @@ import (
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
 	"police-trafic-api-frontend-aligned/internal/core/middleware"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
 
 	"go.uber.org/fx"
@@ -28,10 +29,11 @@ func NewServiceProvider(
 	objetRetrouveRepo repository.ObjetRetrouveRepository,
 	commissariatRepo repository.CommissariatRepository,
 	userRepo repository.UserRepository,
+	numberingService numbering.Service,
 	cfg *config.Config,
 	logger *zap.Logger,
 ) Service {
-	return NewService(objetPerduRepo, objetRetrouveRepo, commissariatRepo, userRepo, cfg, logger)
+	return NewService(objetPerduRepo, objetRetrouveRepo, commissariatRepo, userRepo, numberingService, cfg, logger)
 }
 
 // NewControllerProvider creates a new objets perdus controller for DI
@@ -4,13 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"strconv"
-	"strings"
 	"time"
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -20,7 +21,7 @@ import (
 type Service interface {
 	Create(ctx context.Context, req *CreateObjetPerduRequest, agentID, commissariatID string) (*ObjetPerduResponse, error)
 	GetByID(ctx context.Context, id string) (*ObjetPerduResponse, error)
-	List(ctx context.Context, filters *FilterObjetsPerdusRequest, role, userID, commissariatID string) (*ListObjetsPerdusResponse, error)
+	List(ctx context.Context, filters *FilterObjetsPerdusRequest, role, userID, commissariatID string) ([]ObjetPerduResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, req *UpdateObjetPerduRequest) (*ObjetPerduResponse, error)
 	UpdateStatut(ctx context.Context, id string, req *UpdateStatutRequest, agentID string) (*ObjetPerduResponse, error)
 	Delete(ctx context.Context, id string) error
@@ -35,6 +36,7 @@ type service struct {
 	objetRetrouveRepo repository.ObjetRetrouveRepository
 	commissariatRepo repository.CommissariatRepository
 	userRepo         repository.UserRepository
+	numberingService numbering.Service
 	config           *config.Config
 	logger           *zap.Logger
 }
@@ -45,6 +47,7 @@ func NewService(
 	objetRetrouveRepo repository.ObjetRetrouveRepository,
 	commissariatRepo repository.CommissariatRepository,
 	userRepo repository.UserRepository,
+	numberingService numbering.Service,
 	cfg *config.Config,
 	logger *zap.Logger,
 ) Service {
@@ -53,83 +56,27 @@ func NewService(
 		objetRetrouveRepo: objetRetrouveRepo,
 		commissariatRepo:  commissariatRepo,
 		userRepo:          userRepo,
+		numberingService:  numberingService,
 		config:            cfg,
 		logger:            logger,
 	}
 }
 
-// generateNumero génère un numéro unique pour l'objet perdu
-func (s *service) generateNumero(ctx context.Context, commissariatID string) (string, error) {
-	// Récupérer le commissariat pour obtenir la ville
-	commissariat, err := s.commissariatRepo.GetByID(ctx, commissariatID)
-	if err != nil {
-		return "", fmt.Errorf("commissariat not found")
-	}
-
-	year := time.Now().Year()
-
-	// Extraire les 3 premières lettres de la ville en majuscules
-	ville := strings.ToUpper(commissariat.Ville)
-	villePrefix := ville
-	if len(ville) > 3 {
-		villePrefix = ville[:3]
-	} else if len(ville) < 3 {
-		// Si la ville a moins de 3 lettres, compléter avec des X
-		villePrefix = ville + strings.Repeat("X", 3-len(ville))
-	}
-
-	// Chercher le dernier objet perdu du commissariat pour cette année
-	filters := &repository.ObjetPerduFilters{
-		CommissariatID: &commissariatID,
-		Limit:          1000, // Récupérer beaucoup pour trouver le max
-		Offset:         0,
-	}
-
-	objets, err := s.objetPerduRepo.List(ctx, filters)
-
-	nextNumber := 1
-
-	// Si des objets existent, extraire le dernier numéro
-	if err == nil && len(objets) > 0 {
-		// Trouver le numéro max en parcourant tous les objets de l'année
-		maxNum := 0
-		for _, objet := range objets {
-			// Format: OBP-VILLE-COM-YYYY-NNNN
-			parts := strings.Split(objet.Numero, "-")
-			if len(parts) == 5 {
-				if num, err := strconv.Atoi(parts[4]); err == nil && num > maxNum {
-					maxNum = num
-				}
-			}
-		}
-		if maxNum > 0 {
-			nextNumber = maxNum + 1
-		}
-	}
-
-	// Générer le numéro avec retry pour éviter les collisions
-	maxRetries := 10
-	for retry := 0; retry < maxRetries; retry++ {
-		numero := fmt.Sprintf("OBP-%s-COM-%d-%04d", villePrefix, year, nextNumber+retry)
-
-		// Vérifier si le numéro existe déjà
-		_, err := s.objetPerduRepo.GetByNumero(ctx, numero)
-		if err != nil {
-			// Le numéro n'existe pas, on peut l'utiliser
-			return numero, nil
-		}
-	}
-
-	return "", fmt.Errorf("failed to generate unique numero after %d retries", maxRetries)
-}
-
 // Create creates a new objet perdu
 func (s *service) Create(ctx context.Context, req *CreateObjetPerduRequest, agentID, commissariatID string) (*ObjetPerduResponse, error) {
-	// Générer le numéro unique
-	numero, err := s.generateNumero(ctx, commissariatID)
+	ctx, span := tracing.Start(ctx, "objetsperdus.Create")
+	defer span.End()
+
+	// Réserver le numéro dans la séquence du commissariat
+	resv, err := s.numberingService.Reserve(ctx, &numbering.Request{
+		Type:           numbering.TypeObjetPerdu,
+		CommissariatID: commissariatID,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate numero: %w", err)
+		return nil, fmt.Errorf("failed to reserve numero: %w", err)
 	}
+	defer resv.Rollback()
+	numero := resv.Numero
 
 	// Parser la date de perte
 	datePerte, err := time.Parse("2006-01-02", req.DatePerte)
@@ -223,12 +170,17 @@ func (s *service) Create(ctx context.Context, req *CreateObjetPerduRequest, agen
 		zap.Bool("is_container", isContainer),
 	)
 
-	objetEnt, err := s.objetPerduRepo.Create(ctx, repoInput)
+	objetEnt, err := s.objetPerduRepo.Create(resv.Context(), repoInput)
 	if err != nil {
 		s.logger.Error("Failed to create objet perdu", zap.Error(err))
 		return nil, fmt.Errorf("failed to create objet perdu: %w", err)
 	}
 
+	if err := resv.Commit(); err != nil {
+		s.logger.Error("Failed to create objet perdu", zap.Error(err))
+		return nil, fmt.Errorf("failed to create objet perdu: %w", err)
+	}
+
 	s.logger.Info("Objet perdu created successfully",
 		zap.String("id", objetEnt.ID.String()),
 		zap.String("numero", objetEnt.Numero),
@@ -239,6 +191,9 @@ func (s *service) Create(ctx context.Context, req *CreateObjetPerduRequest, agen
 
 // GetByID gets an objet perdu by ID
 func (s *service) GetByID(ctx context.Context, id string) (*ObjetPerduResponse, error) {
+	ctx, span := tracing.Start(ctx, "objetsperdus.GetByID")
+	defer span.End()
+
 	objet, err := s.objetPerduRepo.GetByID(ctx, id)
 	if err != nil {
 		if err.Error() == "objet perdu not found" {
@@ -251,7 +206,10 @@ func (s *service) GetByID(ctx context.Context, id string) (*ObjetPerduResponse,
 }
 
 // List lists objets perdus with filters
-func (s *service) List(ctx context.Context, filters *FilterObjetsPerdusRequest, role, userID, commissariatID string) (*ListObjetsPerdusResponse, error) {
+func (s *service) List(ctx context.Context, filters *FilterObjetsPerdusRequest, role, userID, commissariatID string) ([]ObjetPerduResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "objetsperdus.List")
+	defer span.End()
+
 	repoFilters := &repository.ObjetPerduFilters{}
 
 	if filters.Statut != nil {
@@ -279,43 +237,37 @@ func (s *service) List(ctx context.Context, filters *FilterObjetsPerdusRequest,
 		repoFilters.Search = filters.Search
 	}
 
-	// Pagination
-	page := 1
-	limit := 50
-	if filters.Page > 0 {
-		page = filters.Page
-	}
-	if filters.Limit > 0 {
-		limit = filters.Limit
-	}
-	repoFilters.Offset = (page - 1) * limit
-	repoFilters.Limit = limit
+	repoFilters.Page = filters.Page
 
 	objets, err := s.objetPerduRepo.List(ctx, repoFilters)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list objets perdus: %w", err)
+		return nil, nil, fmt.Errorf("failed to list objets perdus: %w", err)
+	}
+
+	objets, page, err := pagination.Trim(filters.Page, objets)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	total, err := s.objetPerduRepo.Count(ctx, repoFilters)
 	if err != nil {
-		return nil, fmt.Errorf("failed to count objets perdus: %w", err)
+		return nil, nil, fmt.Errorf("failed to count objets perdus: %w", err)
 	}
+	page.SetTotal(total)
 
 	responses := make([]ObjetPerduResponse, len(objets))
 	for i, objet := range objets {
 		responses[i] = *s.formatObjetPerdu(objet)
 	}
 
-	return &ListObjetsPerdusResponse{
-		Objets: responses,
-		Total:  int64(total),
-		Page:   page,
-		Limit:  limit,
-	}, nil
+	return responses, page, nil
 }
 
 // Update updates an objet perdu
 func (s *service) Update(ctx context.Context, id string, req *UpdateObjetPerduRequest) (*ObjetPerduResponse, error) {
+	ctx, span := tracing.Start(ctx, "objetsperdus.Update")
+	defer span.End()
+
 	repoInput := &repository.UpdateObjetPerduInput{}
 
 	if req.TypeObjet != nil {
@@ -412,6 +364,9 @@ func (s *service) Update(ctx context.Context, id string, req *UpdateObjetPerduRe
 
 // UpdateStatut updates the statut of an objet perdu
 func (s *service) UpdateStatut(ctx context.Context, id string, req *UpdateStatutRequest, agentID string) (*ObjetPerduResponse, error) {
+	ctx, span := tracing.Start(ctx, "objetsperdus.UpdateStatut")
+	defer span.End()
+
 	repoInput := &repository.UpdateObjetPerduInput{
 		Statut: &req.Statut,
 	}
@@ -433,6 +388,9 @@ func (s *service) UpdateStatut(ctx context.Context, id string, req *UpdateStatut
 
 // Delete deletes an objet perdu
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "objetsperdus.Delete")
+	defer span.End()
+
 	err := s.objetPerduRepo.Delete(ctx, id)
 	if err != nil {
 		if err.Error() == "objet perdu not found" {
@@ -600,6 +558,9 @@ func (s *service) formatObjetPerdu(objet *ent.ObjetPerdu) *ObjetPerduResponse {
 
 // GetStatistiques calcule les statistiques des objets perdus
 func (s *service) GetStatistiques(ctx context.Context, commissariatID *string, dateDebut, dateFin, periode *string) (*StatistiquesObjetsPerdusResponse, error) {
+	ctx, span := tracing.Start(ctx, "objetsperdus.GetStatistiques")
+	defer span.End()
+
 	s.logger.Info("GetStatistiques called",
 		zap.Stringp("commissariatID", commissariatID),
 		zap.Stringp("dateDebut", dateDebut),
@@ -733,6 +694,9 @@ func (s *service) GetStatistiques(ctx context.Context, commissariatID *string, d
 
 // GetDashboard gets dashboard data for objets perdus
 func (s *service) GetDashboard(ctx context.Context, commissariatID *string, dateDebut, dateFin, periode *string) (*DashboardResponse, error) {
+	ctx, span := tracing.Start(ctx, "objetsperdus.GetDashboard")
+	defer span.End()
+
 	var debut, fin *time.Time
 
 	if dateDebut != nil {
@@ -1362,6 +1326,9 @@ func parseDateTime(dateStr string) (time.Time, error) {
 
 // CheckMatches vérifie si des objets retrouvés correspondent aux identifiants ultra-uniques fournis
 func (s *service) CheckMatches(ctx context.Context, req *CheckMatchesRequest) ([]MatchedObjetRetrouve, error) {
+	ctx, span := tracing.Start(ctx, "objetsperdus.CheckMatches")
+	defer span.End()
+
 	s.logger.Info("🔍 CheckMatches appelé",
 		zap.String("typeObjet", req.TypeObjet),
 		zap.Any("identifiers", req.Identifiers),
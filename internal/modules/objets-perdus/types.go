@@ -2,6 +2,9 @@ package objetsperdus
 
 import (
 	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 )
 
 // StatutObjetPerdu représente le statut de l'objet perdu
@@ -100,8 +103,28 @@ type FilterObjetsPerdusRequest struct {
 	DateDebut      *time.Time `json:"dateDebut,omitempty"`
 	DateFin        *time.Time `json:"dateFin,omitempty"`
 	Search         *string    `json:"search,omitempty"`
-	Page           int        `json:"page,omitempty" validate:"omitempty,min=1"`
-	Limit          int        `json:"limit,omitempty" validate:"omitempty,min=1"`
+	Page           *pagination.Params `json:"-"`
+}
+
+// listSpec declares the sorts and fields accepted by GET /objets-perdus
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"dateDeclaration": "date_declaration",
+		"createdAt":       "created_at",
+		"numero":          "numero",
+		"statut":          "statut",
+	},
+	DefaultSort: "-dateDeclaration",
+	Fields:      pagination.JSONFields(ObjetPerduResponse{}),
+	Filters: filter.Fields{
+		"numero":          {Column: "numero", Type: filter.String},
+		"statut":          {Column: "statut", Type: filter.String},
+		"typeObjet":       {Column: "type_objet", Type: filter.String},
+		"description":     {Column: "description", Type: filter.String},
+		"isContainer":     {Column: "is_container", Type: filter.Bool},
+		"dateDeclaration": {Column: "date_declaration", Type: filter.Time},
+		"createdAt":       {Column: "created_at", Type: filter.Time},
+	},
 }
 
 // UpdateStatutRequest représente la requête de mise à jour du statut
@@ -168,14 +191,6 @@ type HistoriqueEntry struct {
 	Details *string `json:"details,omitempty"`
 }
 
-// ListObjetsPerdusResponse représente la réponse de liste avec pagination
-type ListObjetsPerdusResponse struct {
-	Objets []ObjetPerduResponse `json:"objets"`
-	Total  int64                `json:"total"`
-	Page   int                  `json:"page"`
-	Limit  int                  `json:"limit"`
-}
-
 // StatistiquesObjetsPerdusResponse représente les statistiques des objets perdus
 type StatistiquesObjetsPerdusResponse struct {
 	Total                 int64   `json:"total"`
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"police-trafic-api-frontend-aligned/internal/core/middleware"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/google/uuid"
@@ -34,24 +36,21 @@ func NewController(service Service, authMiddleware *middleware.AuthMiddleware, l
 func (ctrl *Controller) RegisterRoutes(e *echo.Group) {
 	ctrl.logger.Info("Registering objets-retrouves routes")
 
-	objetsRetrouves := e.Group("/objets-retrouves")
-
-	// Appliquer le middleware d'authentification à toutes les routes
-	objetsRetrouves.Use(ctrl.authMiddleware.RequireAuth())
+	objetsRetrouves := rbac.Guard(e).Group("/objets-retrouves")
 
 	// Routes principales
-	objetsRetrouves.POST("", ctrl.Create)
-	objetsRetrouves.GET("", ctrl.List)
+	objetsRetrouves.POST("", ctrl.Create, rbac.PermCreateObjetsRetrouves)
+	objetsRetrouves.GET("", ctrl.List, rbac.PermReadObjetsRetrouves)
 
 	// Routes fixes (AVANT /:id pour éviter les conflits)
-	objetsRetrouves.GET("/statistiques", ctrl.GetStatistiques)
-	objetsRetrouves.GET("/dashboard", ctrl.GetDashboard)
+	objetsRetrouves.GET("/statistiques", ctrl.GetStatistiques, rbac.PermReadObjetsRetrouves)
+	objetsRetrouves.GET("/dashboard", ctrl.GetDashboard, rbac.PermReadObjetsRetrouves)
 
 	// Route avec paramètre :id (APRÈS les routes fixes)
-	objetsRetrouves.GET("/:id", ctrl.GetByID)
-	objetsRetrouves.PATCH("/:id", ctrl.Update)
-	objetsRetrouves.PATCH("/:id/statut", ctrl.UpdateStatut)
-	objetsRetrouves.DELETE("/:id", ctrl.Delete)
+	objetsRetrouves.GET("/:id", ctrl.GetByID, rbac.PermReadObjetsRetrouves)
+	objetsRetrouves.PATCH("/:id", ctrl.Update, rbac.PermUpdateObjetsRetrouves)
+	objetsRetrouves.PATCH("/:id/statut", ctrl.UpdateStatut, rbac.PermUpdateObjetsRetrouves)
+	objetsRetrouves.DELETE("/:id", ctrl.Delete, rbac.PermDeleteObjetsRetrouves)
 
 	ctrl.logger.Info("Objets-retrouves routes registered successfully",
 		zap.String("base_path", "/api/objets-retrouves"),
@@ -238,17 +237,12 @@ func (ctrl *Controller) List(c echo.Context) error {
 		}
 	}
 
-	// Parse pagination
-	if page := c.QueryParam("page"); page != "" {
-		if p, err := strconv.Atoi(page); err == nil && p > 0 {
-			filters.Page = p
-		}
-	}
-	if limit := c.QueryParam("limit"); limit != "" {
-		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
-			filters.Limit = l
-		}
+	// Pagination, tri et champs
+	page, err := pagination.Parse(c, listSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
 	}
+	filters.Page = page
 
 	// Get user context for filtering
 	userID := getUserIDFromContext(c)
@@ -269,22 +263,22 @@ func (ctrl *Controller) List(c echo.Context) error {
 		zap.Stringp("CommissariatID", filters.CommissariatID),
 		zap.Stringp("Search", filters.Search),
 		zap.Any("IsContainer", filters.IsContainer),
-		zap.Int("Page", filters.Page),
-		zap.Int("Limit", filters.Limit),
+		zap.String("Sort", page.Sort),
+		zap.Int("Limit", page.Limit),
 	)
 
-	result, err := ctrl.service.List(c.Request().Context(), filters, roleStr, userID, commissariatFromContext)
+	objets, result, err := ctrl.service.List(c.Request().Context(), filters, roleStr, userID, commissariatFromContext)
 	if err != nil {
 		ctrl.logger.Error("Failed to list objets retrouves", zap.Error(err))
 		return responses.InternalServerError(c, err.Error())
 	}
 
 	ctrl.logger.Info("Controller List - Results",
-		zap.Int("count", len(result.Objets)),
-		zap.Int64("total", result.Total),
+		zap.Int("count", len(objets)),
+		zap.Any("total", result.Total),
 	)
 
-	return responses.Success(c, result)
+	return responses.Paginated(c, objets, result)
 }
 
 // Update handles PATCH /objets-retrouves/:id
@@ -3,13 +3,14 @@ package objetsretrouves
 import (
 	"context"
 	"fmt"
-	"strconv"
-	"strings"
 	"time"
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -19,7 +20,7 @@ import (
 type Service interface {
 	Create(ctx context.Context, req *CreateObjetRetrouveRequest, agentID, commissariatID string) (*ObjetRetrouveResponse, error)
 	GetByID(ctx context.Context, id string) (*ObjetRetrouveResponse, error)
-	List(ctx context.Context, filters *FilterObjetsRetrouvesRequest, role, userID, commissariatID string) (*ListObjetsRetrouvesResponse, error)
+	List(ctx context.Context, filters *FilterObjetsRetrouvesRequest, role, userID, commissariatID string) ([]ObjetRetrouveResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, req *UpdateObjetRetrouveRequest) (*ObjetRetrouveResponse, error)
 	UpdateStatut(ctx context.Context, id string, req *UpdateStatutRequest, agentID string) (*ObjetRetrouveResponse, error)
 	Delete(ctx context.Context, id string) error
@@ -32,6 +33,7 @@ type service struct {
 	objetRetrouveRepo repository.ObjetRetrouveRepository
 	commissariatRepo  repository.CommissariatRepository
 	userRepo          repository.UserRepository
+	numberingService  numbering.Service
 	config            *config.Config
 	logger            *zap.Logger
 }
@@ -41,6 +43,7 @@ func NewService(
 	objetRetrouveRepo repository.ObjetRetrouveRepository,
 	commissariatRepo repository.CommissariatRepository,
 	userRepo repository.UserRepository,
+	numberingService numbering.Service,
 	cfg *config.Config,
 	logger *zap.Logger,
 ) Service {
@@ -48,83 +51,27 @@ func NewService(
 		objetRetrouveRepo: objetRetrouveRepo,
 		commissariatRepo:  commissariatRepo,
 		userRepo:          userRepo,
+		numberingService:  numberingService,
 		config:            cfg,
 		logger:            logger,
 	}
 }
 
-// generateNumero génère un numéro unique pour l'objet retrouvé
-func (s *service) generateNumero(ctx context.Context, commissariatID string) (string, error) {
-	// Récupérer le commissariat pour obtenir la ville
-	commissariat, err := s.commissariatRepo.GetByID(ctx, commissariatID)
-	if err != nil {
-		return "", fmt.Errorf("commissariat not found")
-	}
-
-	year := time.Now().Year()
-
-	// Extraire les 3 premières lettres de la ville en majuscules
-	ville := strings.ToUpper(commissariat.Ville)
-	villePrefix := ville
-	if len(ville) > 3 {
-		villePrefix = ville[:3]
-	} else if len(ville) < 3 {
-		// Si la ville a moins de 3 lettres, compléter avec des X
-		villePrefix = ville + strings.Repeat("X", 3-len(ville))
-	}
-
-	// Chercher le dernier objet retrouvé du commissariat pour cette année
-	filters := &repository.ObjetRetrouveFilters{
-		CommissariatID: &commissariatID,
-		Limit:          1000, // Récupérer beaucoup pour trouver le max
-		Offset:         0,
-	}
-
-	objets, err := s.objetRetrouveRepo.List(ctx, filters)
-
-	nextNumber := 1
-
-	// Si des objets existent, extraire le dernier numéro
-	if err == nil && len(objets) > 0 {
-		// Trouver le numéro max en parcourant tous les objets de l'année
-		maxNum := 0
-		for _, objet := range objets {
-			// Format: OBR-VILLE-COM-YYYY-NNNN
-			parts := strings.Split(objet.Numero, "-")
-			if len(parts) == 5 {
-				if num, err := strconv.Atoi(parts[4]); err == nil && num > maxNum {
-					maxNum = num
-				}
-			}
-		}
-		if maxNum > 0 {
-			nextNumber = maxNum + 1
-		}
-	}
-
-	// Générer le numéro avec retry pour éviter les collisions
-	maxRetries := 10
-	for retry := 0; retry < maxRetries; retry++ {
-		numero := fmt.Sprintf("OBR-%s-COM-%d-%04d", villePrefix, year, nextNumber+retry)
-
-		// Vérifier si le numéro existe déjà
-		_, err := s.objetRetrouveRepo.GetByNumero(ctx, numero)
-		if err != nil {
-			// Le numéro n'existe pas, on peut l'utiliser
-			return numero, nil
-		}
-	}
-
-	return "", fmt.Errorf("failed to generate unique numero after %d retries", maxRetries)
-}
-
 // Create creates a new objet retrouve
 func (s *service) Create(ctx context.Context, req *CreateObjetRetrouveRequest, agentID, commissariatID string) (*ObjetRetrouveResponse, error) {
-	// Générer le numéro unique
-	numero, err := s.generateNumero(ctx, commissariatID)
+	ctx, span := tracing.Start(ctx, "objetsretrouves.Create")
+	defer span.End()
+
+	// Réserver le numéro dans la séquence du commissariat
+	resv, err := s.numberingService.Reserve(ctx, &numbering.Request{
+		Type:           numbering.TypeObjetRetrouve,
+		CommissariatID: commissariatID,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate numero: %w", err)
+		return nil, fmt.Errorf("failed to reserve numero: %w", err)
 	}
+	defer resv.Rollback()
+	numero := resv.Numero
 
 	// Parser la date de trouvaille
 	dateTrouvaille, err := time.Parse("2006-01-02", req.DateTrouvaille)
@@ -216,12 +163,17 @@ func (s *service) Create(ctx context.Context, req *CreateObjetRetrouveRequest, a
 		zap.String("commissariat_id", commissariatID),
 	)
 
-	objetEnt, err := s.objetRetrouveRepo.Create(ctx, repoInput)
+	objetEnt, err := s.objetRetrouveRepo.Create(resv.Context(), repoInput)
 	if err != nil {
 		s.logger.Error("Failed to create objet retrouve", zap.Error(err))
 		return nil, fmt.Errorf("failed to create objet retrouve: %w", err)
 	}
 
+	if err := resv.Commit(); err != nil {
+		s.logger.Error("Failed to create objet retrouve", zap.Error(err))
+		return nil, fmt.Errorf("failed to create objet retrouve: %w", err)
+	}
+
 	s.logger.Info("Objet retrouve created successfully",
 		zap.String("id", objetEnt.ID.String()),
 		zap.String("numero", objetEnt.Numero),
@@ -232,6 +184,9 @@ func (s *service) Create(ctx context.Context, req *CreateObjetRetrouveRequest, a
 
 // GetByID gets an objet retrouve by ID
 func (s *service) GetByID(ctx context.Context, id string) (*ObjetRetrouveResponse, error) {
+	ctx, span := tracing.Start(ctx, "objetsretrouves.GetByID")
+	defer span.End()
+
 	objet, err := s.objetRetrouveRepo.GetByID(ctx, id)
 	if err != nil {
 		if err.Error() == "objet retrouve not found" {
@@ -244,7 +199,10 @@ func (s *service) GetByID(ctx context.Context, id string) (*ObjetRetrouveRespons
 }
 
 // List lists objets retrouves with filters
-func (s *service) List(ctx context.Context, filters *FilterObjetsRetrouvesRequest, role, userID, commissariatID string) (*ListObjetsRetrouvesResponse, error) {
+func (s *service) List(ctx context.Context, filters *FilterObjetsRetrouvesRequest, role, userID, commissariatID string) ([]ObjetRetrouveResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "objetsretrouves.List")
+	defer span.End()
+
 	repoFilters := &repository.ObjetRetrouveFilters{}
 
 	// CORRECTION: Ajouter tous les filtres
@@ -281,35 +239,29 @@ func (s *service) List(ctx context.Context, filters *FilterObjetsRetrouvesReques
 		s.logger.Info("Service - Filter Search", zap.Stringp("search", filters.Search))
 	}
 
-	// Pagination
-	page := 1
-	limit := 50
-	if filters.Page > 0 {
-		page = filters.Page
-	}
-	if filters.Limit > 0 {
-		limit = filters.Limit
-	}
-	repoFilters.Offset = (page - 1) * limit
-	repoFilters.Limit = limit
+	repoFilters.Page = filters.Page
 
 	s.logger.Info("Service List - Calling repository with filters",
 		zap.Any("repoFilters", repoFilters),
-		zap.Int("page", page),
-		zap.Int("limit", limit),
 	)
 
 	objets, err := s.objetRetrouveRepo.List(ctx, repoFilters)
 	if err != nil {
 		s.logger.Error("Repository List failed", zap.Error(err))
-		return nil, fmt.Errorf("failed to list objets retrouves: %w", err)
+		return nil, nil, fmt.Errorf("failed to list objets retrouves: %w", err)
+	}
+
+	objets, page, err := pagination.Trim(filters.Page, objets)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	total, err := s.objetRetrouveRepo.Count(ctx, repoFilters)
 	if err != nil {
 		s.logger.Error("Repository Count failed", zap.Error(err))
-		return nil, fmt.Errorf("failed to count objets retrouves: %w", err)
+		return nil, nil, fmt.Errorf("failed to count objets retrouves: %w", err)
 	}
+	page.SetTotal(total)
 
 	s.logger.Info("Service List - Results from repository",
 		zap.Int("objets_count", len(objets)),
@@ -321,16 +273,14 @@ func (s *service) List(ctx context.Context, filters *FilterObjetsRetrouvesReques
 		responses[i] = *s.formatObjetRetrouve(objet)
 	}
 
-	return &ListObjetsRetrouvesResponse{
-		Objets: responses,
-		Total:  int64(total),
-		Page:   page,
-		Limit:  limit,
-	}, nil
+	return responses, page, nil
 }
 
 // Update updates an objet retrouve
 func (s *service) Update(ctx context.Context, id string, req *UpdateObjetRetrouveRequest) (*ObjetRetrouveResponse, error) {
+	ctx, span := tracing.Start(ctx, "objetsretrouves.Update")
+	defer span.End()
+
 	repoInput := &repository.UpdateObjetRetrouveInput{}
 
 	if req.TypeObjet != nil {
@@ -421,6 +371,9 @@ func (s *service) Update(ctx context.Context, id string, req *UpdateObjetRetrouv
 
 // UpdateStatut updates the statut of an objet retrouve
 func (s *service) UpdateStatut(ctx context.Context, id string, req *UpdateStatutRequest, agentID string) (*ObjetRetrouveResponse, error) {
+	ctx, span := tracing.Start(ctx, "objetsretrouves.UpdateStatut")
+	defer span.End()
+
 	var dateRestitution *time.Time
 	var proprietaire map[string]interface{}
 
@@ -457,6 +410,9 @@ func (s *service) UpdateStatut(ctx context.Context, id string, req *UpdateStatut
 
 // Delete deletes an objet retrouve
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "objetsretrouves.Delete")
+	defer span.End()
+
 	err := s.objetRetrouveRepo.Delete(ctx, id)
 	if err != nil {
 		if err.Error() == "objet retrouve not found" {
@@ -615,6 +571,9 @@ func (s *service) formatObjetRetrouve(objet *ent.ObjetRetrouve) *ObjetRetrouveRe
 
 // GetStatistiques calcule les statistiques des objets retrouvés
 func (s *service) GetStatistiques(ctx context.Context, commissariatID *string, dateDebut, dateFin, periode *string) (*StatistiquesObjetsRetrouvesResponse, error) {
+	ctx, span := tracing.Start(ctx, "objetsretrouves.GetStatistiques")
+	defer span.End()
+
 	s.logger.Info("GetStatistiques called",
 		zap.Stringp("commissariatID", commissariatID),
 		zap.Stringp("dateDebut", dateDebut),
@@ -749,6 +708,9 @@ func (s *service) GetStatistiques(ctx context.Context, commissariatID *string, d
 
 // GetDashboard gets dashboard data for objets retrouves
 func (s *service) GetDashboard(ctx context.Context, commissariatID *string, dateDebut, dateFin, periode *string) (*DashboardResponse, error) {
+	ctx, span := tracing.Start(ctx, "objetsretrouves.GetDashboard")
+	defer span.End()
+
 	var debut, fin *time.Time
 
 	if dateDebut != nil {
@@ -2,6 +2,9 @@ package objetsretrouves
 
 import (
 	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 )
 
 // StatutObjetRetrouve représente le statut de l'objet retrouvé
@@ -100,8 +103,31 @@ type FilterObjetsRetrouvesRequest struct {
 	DateDebut      *time.Time `json:"dateDebut,omitempty"`
 	DateFin        *time.Time `json:"dateFin,omitempty"`
 	Search         *string    `json:"search,omitempty"`
-	Page           int        `json:"page,omitempty" validate:"omitempty,min=1"`
-	Limit          int        `json:"limit,omitempty" validate:"omitempty,min=1"`
+	Page           *pagination.Params `json:"-"`
+}
+
+// listSpec declares the sorts and fields accepted by GET /objets-retrouves
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"dateDepot":      "date_depot",
+		"dateTrouvaille": "date_trouvaille",
+		"createdAt":      "created_at",
+		"numero":         "numero",
+		"statut":         "statut",
+	},
+	DefaultSort: "-dateDepot",
+	Fields:      pagination.JSONFields(ObjetRetrouveResponse{}),
+	Filters: filter.Fields{
+		"numero":         {Column: "numero", Type: filter.String},
+		"statut":         {Column: "statut", Type: filter.String},
+		"typeObjet":      {Column: "type_objet", Type: filter.String},
+		"description":    {Column: "description", Type: filter.String},
+		"lieuTrouvaille": {Column: "lieu_trouvaille", Type: filter.String},
+		"isContainer":    {Column: "is_container", Type: filter.Bool},
+		"dateTrouvaille": {Column: "date_trouvaille", Type: filter.Time},
+		"dateDepot":      {Column: "date_depot", Type: filter.Time},
+		"createdAt":      {Column: "created_at", Type: filter.Time},
+	},
 }
 
 // UpdateStatutRequest représente la requête de mise à jour du statut
@@ -180,14 +206,6 @@ type HistoriqueEntry struct {
 	Details *string `json:"details,omitempty"`
 }
 
-// ListObjetsRetrouvesResponse représente la réponse de liste avec pagination
-type ListObjetsRetrouvesResponse struct {
-	Objets []ObjetRetrouveResponse `json:"objets"`
-	Total  int64                   `json:"total"`
-	Page   int                     `json:"page"`
-	Limit  int                     `json:"limit"`
-}
-
 // StatistiquesObjetsRetrouvesResponse représente les statistiques des objets retrouvés
 type StatistiquesObjetsRetrouvesResponse struct {
 	Total                int64   `json:"total"`
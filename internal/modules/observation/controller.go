@@ -4,6 +4,9 @@ import (
 	"net/http"
 
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
@@ -84,6 +87,7 @@ func (c *Controller) GetByID(ctx echo.Context) error {
 // @Param type query string false "Type"
 // @Param categorie query string false "Categorie"
 // @Param visibleAgent query string false "Visible to agent (true/false)"
+// @Param filter query string false "Filter expression, e.g. categorie = DISCIPLINE and dateObservation >= 2026-01-01"
 // @Success 200 {array} ObservationResponse
 // @Router /api/observations [get]
 func (c *Controller) List(ctx echo.Context) error {
@@ -92,13 +96,19 @@ func (c *Controller) List(ctx echo.Context) error {
 		return echo.NewHTTPError(http.StatusBadRequest, "Invalid query parameters")
 	}
 
-	observations, err := c.service.List(ctx.Request().Context(), &filters)
+	page, err := pagination.Parse(ctx, listSpec)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+	filters.Page = page
+
+	observations, result, err := c.service.List(ctx.Request().Context(), &filters)
 	if err != nil {
 		c.logger.Error("Failed to list observations", zap.Error(err))
 		return echo.NewHTTPError(http.StatusInternalServerError, err.Error())
 	}
 
-	return ctx.JSON(http.StatusOK, observations)
+	return responses.Paginated(ctx, observations, result)
 }
 
 // Update updates an observation
@@ -205,14 +215,16 @@ func (c *Controller) GetByAuteur(ctx echo.Context) error {
 
 // RegisterRoutes registers observation routes
 func (c *Controller) RegisterRoutes(g *echo.Group) {
-	observations := g.Group("/observations")
-	observations.POST("", c.Create)
-	observations.GET("", c.List)
-	observations.GET("/:id", c.GetByID)
-	observations.PUT("/:id", c.Update)
-	observations.DELETE("/:id", c.Delete)
+	routes := rbac.Guard(g)
+
+	observations := routes.Group("/observations")
+	observations.POST("", c.Create, rbac.PermCreateObservations)
+	observations.GET("", c.List, rbac.PermReadObservations)
+	observations.GET("/:id", c.GetByID, rbac.PermReadObservations)
+	observations.PUT("/:id", c.Update, rbac.PermManageObservations)
+	observations.DELETE("/:id", c.Delete, rbac.PermManageObservations)
 
 	// Nested routes
-	g.GET("/agents/:agentId/observations", c.GetByAgent)
-	g.GET("/auteurs/:auteurId/observations", c.GetByAuteur)
+	routes.GET("/agents/:agentId/observations", c.GetByAgent, rbac.PermReadObservations)
+	routes.GET("/auteurs/:auteurId/observations", c.GetByAuteur, rbac.PermReadObservations)
 }
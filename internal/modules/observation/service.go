@@ -5,6 +5,8 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -14,7 +16,7 @@ import (
 type Service interface {
 	Create(ctx context.Context, req *CreateObservationRequest) (*ObservationResponse, error)
 	GetByID(ctx context.Context, id string) (*ObservationResponse, error)
-	List(ctx context.Context, filters *ListObservationsFilters) ([]ObservationResponse, error)
+	List(ctx context.Context, filters *ListObservationsFilters) ([]ObservationResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, req *UpdateObservationRequest) (*ObservationResponse, error)
 	Delete(ctx context.Context, id string) error
 	GetByAgent(ctx context.Context, agentID string, visibleOnly bool) ([]ObservationResponse, error)
@@ -36,6 +38,9 @@ func NewService(repo repository.ObservationRepository, logger *zap.Logger) Servi
 
 // Create creates a new observation
 func (s *service) Create(ctx context.Context, req *CreateObservationRequest) (*ObservationResponse, error) {
+	ctx, span := tracing.Start(ctx, "observation.Create")
+	defer span.End()
+
 	input := &repository.CreateObservationInput{
 		ID:           uuid.New().String(),
 		Contenu:      req.Contenu,
@@ -56,6 +61,9 @@ func (s *service) Create(ctx context.Context, req *CreateObservationRequest) (*O
 
 // GetByID gets an observation by ID
 func (s *service) GetByID(ctx context.Context, id string) (*ObservationResponse, error) {
+	ctx, span := tracing.Start(ctx, "observation.GetByID")
+	defer span.End()
+
 	observation, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -65,7 +73,10 @@ func (s *service) GetByID(ctx context.Context, id string) (*ObservationResponse,
 }
 
 // List lists observations with filters
-func (s *service) List(ctx context.Context, filters *ListObservationsFilters) ([]ObservationResponse, error) {
+func (s *service) List(ctx context.Context, filters *ListObservationsFilters) ([]ObservationResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "observation.List")
+	defer span.End()
+
 	repoFilters := &repository.ObservationFilters{}
 
 	if filters != nil {
@@ -85,11 +96,17 @@ func (s *service) List(ctx context.Context, filters *ListObservationsFilters) ([
 			visible := filters.VisibleAgent == "true"
 			repoFilters.VisibleAgent = &visible
 		}
+		repoFilters.Page = filters.Page
 	}
 
 	observations, err := s.repo.List(ctx, repoFilters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	observations, page, err := pagination.Trim(repoFilters.Page, observations)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	responses := make([]ObservationResponse, len(observations))
@@ -97,11 +114,14 @@ func (s *service) List(ctx context.Context, filters *ListObservationsFilters) ([
 		responses[i] = *s.toResponse(obs)
 	}
 
-	return responses, nil
+	return responses, page, nil
 }
 
 // Update updates an observation
 func (s *service) Update(ctx context.Context, id string, req *UpdateObservationRequest) (*ObservationResponse, error) {
+	ctx, span := tracing.Start(ctx, "observation.Update")
+	defer span.End()
+
 	input := &repository.UpdateObservationInput{
 		Contenu:      req.Contenu,
 		Type:         req.Type,
@@ -119,11 +139,17 @@ func (s *service) Update(ctx context.Context, id string, req *UpdateObservationR
 
 // Delete deletes an observation
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "observation.Delete")
+	defer span.End()
+
 	return s.repo.Delete(ctx, id)
 }
 
 // GetByAgent gets observations for an agent
 func (s *service) GetByAgent(ctx context.Context, agentID string, visibleOnly bool) ([]ObservationResponse, error) {
+	ctx, span := tracing.Start(ctx, "observation.GetByAgent")
+	defer span.End()
+
 	observations, err := s.repo.GetByAgent(ctx, agentID, visibleOnly)
 	if err != nil {
 		return nil, err
@@ -139,6 +165,9 @@ func (s *service) GetByAgent(ctx context.Context, agentID string, visibleOnly bo
 
 // GetByAuteur gets observations created by an auteur
 func (s *service) GetByAuteur(ctx context.Context, auteurID string) ([]ObservationResponse, error) {
+	ctx, span := tracing.Start(ctx, "observation.GetByAuteur")
+	defer span.End()
+
 	observations, err := s.repo.GetByAuteur(ctx, auteurID)
 	if err != nil {
 		return nil, err
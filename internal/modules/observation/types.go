@@ -1,6 +1,11 @@
 package observation
 
-import "time"
+import (
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+)
 
 // ObservationResponse represents an observation in API responses
 type ObservationResponse struct {
@@ -44,9 +49,28 @@ type UpdateObservationRequest struct {
 
 // ListObservationsFilters represents query filters for listing observations
 type ListObservationsFilters struct {
-	AgentID      string `query:"agentId"`
-	AuteurID     string `query:"auteurId"`
-	Type         string `query:"type"`
-	Categorie    string `query:"categorie"`
-	VisibleAgent string `query:"visibleAgent"`
+	AgentID      string             `query:"agentId"`
+	AuteurID     string             `query:"auteurId"`
+	Type         string             `query:"type"`
+	Categorie    string             `query:"categorie"`
+	VisibleAgent string             `query:"visibleAgent"`
+	Page         *pagination.Params // Set by the controller
+}
+
+// listSpec declares the sorts and fields accepted by GET /observations
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"createdAt": "created_at",
+		"type":      "type",
+	},
+	DefaultSort: "-createdAt",
+	Fields:      pagination.JSONFields(ObservationResponse{}),
+	Filters: filter.Fields{
+		"type":            {Column: "type", Type: filter.String},
+		"categorie":       {Column: "categorie", Type: filter.String},
+		"periode":         {Column: "periode", Type: filter.String},
+		"visibleAgent":    {Column: "visible_agent", Type: filter.Bool},
+		"dateObservation": {Column: "date_observation", Type: filter.Time},
+		"createdAt":       {Column: "created_at", Type: filter.Time},
+	},
 }
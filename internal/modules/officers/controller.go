@@ -1,6 +1,7 @@
 package officers
 
 import (
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
 	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
@@ -20,10 +21,10 @@ func NewController(service Service) *Controller {
 
 // RegisterRoutes registers officers routes on the group
 func (ctrl *Controller) RegisterRoutes(e *echo.Group) {
-	officers := e.Group("/officers")
+	officers := rbac.Guard(e).Group("/officers")
 
-	officers.GET("/:id/dashboard", ctrl.GetOfficerDashboard)
-	officers.GET("/:id/statistics", ctrl.GetOfficerStatistics)
+	officers.GET("/:id/dashboard", ctrl.GetOfficerDashboard, rbac.PermReadUsers)
+	officers.GET("/:id/statistics", ctrl.GetOfficerStatistics, rbac.PermReadUsers)
 }
 
 // GetOfficerDashboard handles GET /officers/:id/dashboard
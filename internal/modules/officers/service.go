@@ -10,6 +10,7 @@ import (
 	"police-trafic-api-frontend-aligned/ent/procesverbal"
 	"police-trafic-api-frontend-aligned/ent/user"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -45,6 +46,9 @@ func NewService(
 
 // GetOfficerDashboard returns dashboard statistics for a specific officer
 func (s *service) GetOfficerDashboard(ctx context.Context, officerID string, period string) (*OfficerDashboardResponse, error) {
+	ctx, span := tracing.Start(ctx, "officers.GetOfficerDashboard")
+	defer span.End()
+
 	s.logger.Info("Getting officer dashboard", zap.String("officerID", officerID), zap.String("period", period))
 
 	// Verify officer exists
@@ -144,6 +148,9 @@ func (s *service) GetOfficerDashboard(ctx context.Context, officerID string, per
 
 // GetOfficerStatistics returns simple statistics for a specific officer
 func (s *service) GetOfficerStatistics(ctx context.Context, officerID string) (*OfficerStatisticsResponse, error) {
+	ctx, span := tracing.Start(ctx, "officers.GetOfficerStatistics")
+	defer span.End()
+
 	s.logger.Info("Getting officer statistics", zap.String("officerID", officerID))
 
 	// Verify officer exists
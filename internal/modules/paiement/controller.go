@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
@@ -24,32 +26,32 @@ func NewPaiementController(service Service) interfaces.Controller {
 
 // RegisterRoutes registers paiement routes
 func (c *Controller) RegisterRoutes(g *echo.Group) {
-	group := g.Group("/paiements")
+	group := rbac.Guard(g).Group("/paiements")
 
 	// CRUD endpoints
-	group.GET("", c.ListPaiements)
-	group.GET("/:id", c.GetPaiement)
-	group.POST("", c.CreatePaiement)
-	group.PUT("/:id", c.UpdatePaiement)
-	group.DELETE("/:id", c.DeletePaiement)
+	group.GET("", c.ListPaiements, rbac.PermReadPaiements)
+	group.GET("/:id", c.GetPaiement, rbac.PermReadPaiements)
+	group.POST("", c.CreatePaiement, rbac.PermCreatePaiements)
+	group.PUT("/:id", c.UpdatePaiement, rbac.PermUpdatePaiements)
+	group.DELETE("/:id", c.DeletePaiement, rbac.PermDeletePaiements)
 
 	// Transaction endpoint
-	group.GET("/transaction/:numero", c.GetByTransaction)
+	group.GET("/transaction/:numero", c.GetByTransaction, rbac.PermReadPaiements)
 
 	// Process verbal payments
-	group.GET("/pv/:pvId", c.GetByProcesVerbal)
+	group.GET("/pv/:pvId", c.GetByProcesVerbal, rbac.PermReadPaiements)
 
 	// Actions
-	group.POST("/:id/validate", c.ValidatePaiement)
-	group.POST("/:id/refuse", c.RefusePaiement)
-	group.POST("/:id/refund", c.RemboursementPaiement)
+	group.POST("/:id/validate", c.ValidatePaiement, rbac.PermValidatePaiements)
+	group.POST("/:id/refuse", c.RefusePaiement, rbac.PermValidatePaiements)
+	group.POST("/:id/refund", c.RemboursementPaiement, rbac.PermValidatePaiements)
 
 	// Reçu Trésor Public
-	group.POST("/:id/recu-tresor", c.GenerateRecuTresor)
-	group.GET("/:id/recu-tresor", c.GetRecuTresor)
+	group.POST("/:id/recu-tresor", c.GenerateRecuTresor, rbac.PermCreatePaiements)
+	group.GET("/:id/recu-tresor", c.GetRecuTresor, rbac.PermReadPaiements)
 
 	// Statistics
-	group.GET("/statistics", c.GetStatistics)
+	group.GET("/statistics", c.GetStatistics, rbac.PermReadPaiements)
 }
 
 // ListPaiements lists paiements with filters
@@ -95,27 +97,19 @@ func (c *Controller) ListPaiements(ctx echo.Context) error {
 		}
 	}
 
-	// Pagination
-	if limit := ctx.QueryParam("limit"); limit != "" {
-		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
-			request.Limit = l
-		}
-	} else {
-		request.Limit = 50 // Default limit
-	}
-
-	if offset := ctx.QueryParam("offset"); offset != "" {
-		if o, err := strconv.Atoi(offset); err == nil && o >= 0 {
-			request.Offset = o
-		}
+	// Pagination, tri et champs
+	page, err := pagination.Parse(ctx, listSpec)
+	if err != nil {
+		return responses.BadRequest(ctx, err.Error())
 	}
+	request.Page = page
 
-	result, err := c.service.List(ctx.Request().Context(), request)
+	paiements, result, err := c.service.List(ctx.Request().Context(), request)
 	if err != nil {
 		return responses.InternalServerError(ctx, "Failed to list paiements: "+err.Error())
 	}
 
-	return responses.Success(ctx, result)
+	return responses.Paginated(ctx, paiements, result)
 }
 
 // GetPaiement gets a paiement by ID
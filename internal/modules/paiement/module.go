@@ -2,6 +2,7 @@ package paiement
 
 import (
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
 
 	"go.uber.org/fx"
@@ -23,9 +24,10 @@ var Module = fx.Module("paiement",
 // NewPaiementServiceProvider creates a new paiement service for DI
 func NewPaiementServiceProvider(
 	paiementRepo repository.PaiementRepository,
+	numberingService numbering.Service,
 	logger *zap.Logger,
 ) Service {
-	return NewPaiementService(paiementRepo, logger)
+	return NewPaiementService(paiementRepo, numberingService, logger)
 }
 
 // NewPaiementControllerProvider creates a new paiement controller for DI
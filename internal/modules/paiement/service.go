@@ -6,18 +6,26 @@ import (
 	"time"
 
 	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/audittrail"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/metrics"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// auditResourceType identifies payments in the audit log
+const auditResourceType = "Paiement"
+
 // Service defines paiement service interface
 type Service interface {
 	Create(ctx context.Context, input *CreatePaiementRequest) (*PaiementResponse, error)
 	GetByID(ctx context.Context, id string) (*PaiementResponse, error)
 	GetByNumeroTransaction(ctx context.Context, numero string) (*PaiementResponse, error)
-	List(ctx context.Context, filters *ListPaiementsRequest) (*ListPaiementsResponse, error)
+	List(ctx context.Context, filters *ListPaiementsRequest) ([]*PaiementResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, input *UpdatePaiementRequest) (*PaiementResponse, error)
 	Delete(ctx context.Context, id string) error
 	GetByProcesVerbal(ctx context.Context, pvID string) (*ListPaiementsResponse, error)
@@ -32,23 +40,29 @@ type Service interface {
 
 // service implements Service interface
 type service struct {
-	paiementRepo repository.PaiementRepository
-	logger       *zap.Logger
+	paiementRepo     repository.PaiementRepository
+	numberingService numbering.Service
+	logger           *zap.Logger
 }
 
 // NewPaiementService creates a new paiement service
 func NewPaiementService(
 	paiementRepo repository.PaiementRepository,
+	numberingService numbering.Service,
 	logger *zap.Logger,
 ) Service {
 	return &service{
-		paiementRepo: paiementRepo,
-		logger:       logger,
+		paiementRepo:     paiementRepo,
+		numberingService: numberingService,
+		logger:           logger,
 	}
 }
 
 // Create creates a new paiement
 func (s *service) Create(ctx context.Context, input *CreatePaiementRequest) (*PaiementResponse, error) {
+	ctx, span := tracing.Start(ctx, "paiement.Create")
+	defer span.End()
+
 	if err := s.validateCreateInput(input); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
@@ -82,11 +96,17 @@ func (s *service) Create(ctx context.Context, input *CreatePaiementRequest) (*Pa
 		return nil, fmt.Errorf("failed to reload paiement: %w", err)
 	}
 
-	return s.entityToResponse(paiementEnt), nil
+	response := s.entityToResponse(paiementEnt)
+	audittrail.Snapshot(ctx, auditResourceType, response.ID, nil, response)
+
+	return response, nil
 }
 
 // GetByID gets paiement by ID
 func (s *service) GetByID(ctx context.Context, id string) (*PaiementResponse, error) {
+	ctx, span := tracing.Start(ctx, "paiement.GetByID")
+	defer span.End()
+
 	paiementEnt, err := s.paiementRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -97,6 +117,9 @@ func (s *service) GetByID(ctx context.Context, id string) (*PaiementResponse, er
 
 // GetByNumeroTransaction gets paiement by transaction number
 func (s *service) GetByNumeroTransaction(ctx context.Context, numero string) (*PaiementResponse, error) {
+	ctx, span := tracing.Start(ctx, "paiement.GetByNumeroTransaction")
+	defer span.End()
+
 	paiementEnt, err := s.paiementRepo.GetByNumeroTransaction(ctx, numero)
 	if err != nil {
 		return nil, err
@@ -106,36 +129,50 @@ func (s *service) GetByNumeroTransaction(ctx context.Context, numero string) (*P
 }
 
 // List gets paiements with filters
-func (s *service) List(ctx context.Context, input *ListPaiementsRequest) (*ListPaiementsResponse, error) {
+func (s *service) List(ctx context.Context, input *ListPaiementsRequest) ([]*PaiementResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "paiement.List")
+	defer span.End()
+
 	filters := s.buildFilters(input)
 
 	paiementsEnt, err := s.paiementRepo.List(ctx, filters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	paiementsEnt, page, err := pagination.Trim(input.Page, paiementsEnt)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	total, err := s.paiementRepo.Count(ctx, filters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	page.SetTotal(total)
 
 	paiements := make([]*PaiementResponse, len(paiementsEnt))
 	for i, p := range paiementsEnt {
 		paiements[i] = s.entityToResponse(p)
 	}
 
-	return &ListPaiementsResponse{
-		Paiements: paiements,
-		Total:     total,
-	}, nil
+	return paiements, page, nil
 }
 
 // Update updates paiement
 func (s *service) Update(ctx context.Context, id string, input *UpdatePaiementRequest) (*PaiementResponse, error) {
+	ctx, span := tracing.Start(ctx, "paiement.Update")
+	defer span.End()
+
 	if err := s.validateUpdateInput(input); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
 
+	paiement, err := s.paiementRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
 	repoInput := &repository.UpdatePaiementInput{
 		Statut:           input.Statut,
 		ReferenceExterne: input.ReferenceExterne,
@@ -154,11 +191,17 @@ func (s *service) Update(ctx context.Context, id string, input *UpdatePaiementRe
 		return nil, err
 	}
 
-	return s.entityToResponse(paiementEnt), nil
+	response := s.entityToResponse(paiementEnt)
+	audittrail.Snapshot(ctx, auditResourceType, id, s.entityToResponse(paiement), response)
+
+	return response, nil
 }
 
 // Delete deletes paiement
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "paiement.Delete")
+	defer span.End()
+
 	// Vérifier que le paiement existe et n'est pas validé
 	paiement, err := s.paiementRepo.GetByID(ctx, id)
 	if err != nil {
@@ -169,11 +212,19 @@ func (s *service) Delete(ctx context.Context, id string) error {
 		return fmt.Errorf("cannot delete a validated payment")
 	}
 
-	return s.paiementRepo.Delete(ctx, id)
+	if err := s.paiementRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	audittrail.Snapshot(ctx, auditResourceType, id, s.entityToResponse(paiement), nil)
+	return nil
 }
 
 // GetByProcesVerbal gets paiements by proces verbal ID
 func (s *service) GetByProcesVerbal(ctx context.Context, pvID string) (*ListPaiementsResponse, error) {
+	ctx, span := tracing.Start(ctx, "paiement.GetByProcesVerbal")
+	defer span.End()
+
 	paiementsEnt, err := s.paiementRepo.GetByProcesVerbal(ctx, pvID)
 	if err != nil {
 		return nil, err
@@ -192,6 +243,9 @@ func (s *service) GetByProcesVerbal(ctx context.Context, pvID string) (*ListPaie
 
 // Validate validates a paiement
 func (s *service) Validate(ctx context.Context, id string, input *ValidatePaiementRequest) (*PaiementResponse, error) {
+	ctx, span := tracing.Start(ctx, "paiement.Validate")
+	defer span.End()
+
 	// Vérifier que le paiement existe et est en cours
 	paiement, err := s.paiementRepo.GetByID(ctx, id)
 	if err != nil {
@@ -214,6 +268,7 @@ func (s *service) Validate(ctx context.Context, id string, input *ValidatePaieme
 	if err != nil {
 		return nil, err
 	}
+	metrics.PaiementsValidated.Inc(paiementEnt.MoyenPaiement)
 
 	// Recharger avec les relations
 	paiementEnt, err = s.paiementRepo.GetByID(ctx, paiementEnt.ID.String())
@@ -221,11 +276,17 @@ func (s *service) Validate(ctx context.Context, id string, input *ValidatePaieme
 		return nil, err
 	}
 
-	return s.entityToResponse(paiementEnt), nil
+	response := s.entityToResponse(paiementEnt)
+	audittrail.Snapshot(ctx, auditResourceType, id, s.entityToResponse(paiement), response)
+
+	return response, nil
 }
 
 // Refuse refuses a paiement
 func (s *service) Refuse(ctx context.Context, id string, input *RefusePaiementRequest) (*PaiementResponse, error) {
+	ctx, span := tracing.Start(ctx, "paiement.Refuse")
+	defer span.End()
+
 	// Vérifier que le paiement existe et est en cours
 	paiement, err := s.paiementRepo.GetByID(ctx, id)
 	if err != nil {
@@ -253,11 +314,17 @@ func (s *service) Refuse(ctx context.Context, id string, input *RefusePaiementRe
 		return nil, err
 	}
 
-	return s.entityToResponse(paiementEnt), nil
+	response := s.entityToResponse(paiementEnt)
+	audittrail.Snapshot(ctx, auditResourceType, id, s.entityToResponse(paiement), response)
+
+	return response, nil
 }
 
 // Rembourser processes a refund
 func (s *service) Rembourser(ctx context.Context, id string, input *RemboursementRequest) (*PaiementResponse, error) {
+	ctx, span := tracing.Start(ctx, "paiement.Rembourser")
+	defer span.End()
+
 	// Vérifier que le paiement existe et est validé
 	paiement, err := s.paiementRepo.GetByID(ctx, id)
 	if err != nil {
@@ -295,11 +362,17 @@ func (s *service) Rembourser(ctx context.Context, id string, input *Remboursemen
 		return nil, err
 	}
 
-	return s.entityToResponse(paiementEnt), nil
+	response := s.entityToResponse(paiementEnt)
+	audittrail.Snapshot(ctx, auditResourceType, id, s.entityToResponse(paiement), response)
+
+	return response, nil
 }
 
 // GetStatistics gets statistics for paiements
 func (s *service) GetStatistics(ctx context.Context, input *ListPaiementsRequest) (*PaiementStatisticsResponse, error) {
+	ctx, span := tracing.Start(ctx, "paiement.GetStatistics")
+	defer span.End()
+
 	filters := s.buildFilters(input)
 
 	stats, err := s.paiementRepo.GetStatistics(ctx, filters)
@@ -386,6 +459,7 @@ func (s *service) buildFilters(input *ListPaiementsRequest) *repository.Paiement
 		MontantMax:     input.MontantMax,
 		Limit:          input.Limit,
 		Offset:         input.Offset,
+		Page:           input.Page,
 	}
 }
 
@@ -433,6 +507,9 @@ func generateNumeroTransaction() string {
 
 // GenerateRecuTresor generates a treasury receipt for a TRESOR_PUBLIC payment
 func (s *service) GenerateRecuTresor(ctx context.Context, input *RecuTresorRequest) (*RecuTresorResponse, error) {
+	ctx, span := tracing.Start(ctx, "paiement.GenerateRecuTresor")
+	defer span.End()
+
 	// Récupérer le paiement
 	paiement, err := s.paiementRepo.GetByID(ctx, input.PaiementID)
 	if err != nil {
@@ -444,8 +521,17 @@ func (s *service) GenerateRecuTresor(ctx context.Context, input *RecuTresorReque
 		return nil, fmt.Errorf("payment must be TRESOR_PUBLIC type")
 	}
 
-	// Générer le numéro de reçu
-	numeroRecu := generateNumeroRecuTresor()
+	// Réserver le numéro de reçu dans la séquence du commissariat du PV
+	numberingReq := &numbering.Request{Type: numbering.TypeRecuTresor}
+	if paiement.Edges.ProcesVerbal != nil {
+		numberingReq.ProcesVerbalID = paiement.Edges.ProcesVerbal.ID.String()
+	}
+	resv, err := s.numberingService.Reserve(ctx, numberingReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reserve receipt number: %w", err)
+	}
+	defer resv.Rollback()
+	numeroRecu := resv.Numero
 
 	// Construire les données QR Code (pour vérification)
 	qrData := fmt.Sprintf("TRESOR|%s|%s|%.2f|%s",
@@ -483,11 +569,15 @@ func (s *service) GenerateRecuTresor(ctx context.Context, input *RecuTresorReque
 		CodeAutorisation: &numeroRecu,
 	}
 
-	_, err = s.paiementRepo.Update(ctx, input.PaiementID, updateInput)
+	_, err = s.paiementRepo.Update(resv.Context(), input.PaiementID, updateInput)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update payment: %w", err)
 	}
 
+	if err := resv.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to update payment: %w", err)
+	}
+
 	return &RecuTresorResponse{
 		NumeroRecu:       numeroRecu,
 		DateEmission:     now,
@@ -506,6 +596,9 @@ func (s *service) GenerateRecuTresor(ctx context.Context, input *RecuTresorReque
 
 // GetRecuTresor retrieves an existing treasury receipt for a payment
 func (s *service) GetRecuTresor(ctx context.Context, paiementID string) (*RecuTresorResponse, error) {
+	ctx, span := tracing.Start(ctx, "paiement.GetRecuTresor")
+	defer span.End()
+
 	paiement, err := s.paiementRepo.GetByID(ctx, paiementID)
 	if err != nil {
 		return nil, err
@@ -561,12 +654,6 @@ func (s *service) GetRecuTresor(ctx context.Context, paiementID string) (*RecuTr
 	}, nil
 }
 
-// generateNumeroRecuTresor generates a unique treasury receipt number
-func generateNumeroRecuTresor() string {
-	now := time.Now()
-	return fmt.Sprintf("RCU-TR-%s-%06d", now.Format("20060102"), now.Nanosecond()/1000)
-}
-
 // montantEnLettres converts amount to words (simplified French version)
 func montantEnLettres(montant float64) string {
 	// Simplified version - in production, use a proper library
@@ -2,6 +2,9 @@ package paiement
 
 import (
 	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 )
 
 // CreatePaiementRequest represents request to create a payment
@@ -53,6 +56,28 @@ type ListPaiementsRequest struct {
 	MontantMax     *float64   `json:"montant_max,omitempty"`
 	Limit          int        `json:"limit,omitempty"`
 	Offset         int        `json:"offset,omitempty"`
+	Page           *pagination.Params `json:"-"`
+}
+
+// listSpec declares the sorts and fields accepted by GET /paiements
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"date_paiement": "date_paiement",
+		"created_at":    "created_at",
+		"montant":       "montant",
+		"statut":        "statut",
+	},
+	DefaultSort: "-date_paiement",
+	Fields:      pagination.JSONFields(PaiementResponse{}),
+	Filters: filter.Fields{
+		"numero_transaction": {Column: "numero_transaction", Type: filter.String},
+		"statut":             {Column: "statut", Type: filter.String},
+		"moyen_paiement":     {Column: "moyen_paiement", Type: filter.String},
+		"montant":            {Column: "montant", Type: filter.Float},
+		"date_paiement":      {Column: "date_paiement", Type: filter.Time},
+		"date_validation":    {Column: "date_validation", Type: filter.Time},
+		"created_at":         {Column: "created_at", Type: filter.Time},
+	},
 }
 
 // PaiementResponse represents a payment in responses
@@ -3,6 +3,10 @@ package plainte
 import (
 	"net/http"
 
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+	"police-trafic-api-frontend-aligned/internal/shared/responses"
+
 	"github.com/labstack/echo/v4"
 )
 
@@ -18,30 +22,30 @@ func NewController(service Service) *Controller {
 
 // RegisterRoutes registers plainte routes
 func (c *Controller) RegisterRoutes(g *echo.Group) {
-	plaintes := g.Group("/plaintes")
-	plaintes.GET("", c.List)
-	plaintes.POST("", c.Create)
-	plaintes.GET("/statistics", c.GetStatistics)
-	plaintes.GET("/alertes", c.GetAlertes)
-	plaintes.GET("/top-agents", c.GetTopAgents)
-	plaintes.GET("/:id", c.GetByID)
-	plaintes.GET("/numero/:numero", c.GetByNumero)
-	plaintes.GET("/:id/preuves", c.GetPreuves)
-	plaintes.POST("/:id/preuves", c.AddPreuve)
-	plaintes.GET("/:id/actes-enquete", c.GetActesEnquete)
-	plaintes.POST("/:id/actes-enquete", c.AddActeEnquete)
-	plaintes.GET("/:id/timeline", c.GetTimeline)
-	plaintes.POST("/:id/timeline", c.AddTimelineEvent)
-	plaintes.GET("/:id/enquetes", c.GetEnquetes)
-	plaintes.POST("/:id/enquetes", c.AddEnquete)
-	plaintes.GET("/:id/decisions", c.GetDecisions)
-	plaintes.POST("/:id/decisions", c.AddDecision)
-	plaintes.GET("/:id/historique", c.GetHistorique)
-	plaintes.PUT("/:id", c.Update)
-	plaintes.DELETE("/:id", c.Delete)
-	plaintes.PATCH("/:id/etape", c.ChangerEtape)
-	plaintes.PATCH("/:id/statut", c.ChangerStatut)
-	plaintes.PATCH("/:id/assigner", c.AssignerAgent)
+	plaintes := rbac.Guard(g).Group("/plaintes")
+	plaintes.GET("", c.List, rbac.PermReadPlaintes)
+	plaintes.POST("", c.Create, rbac.PermCreatePlaintes)
+	plaintes.GET("/statistics", c.GetStatistics, rbac.PermReadPlaintes)
+	plaintes.GET("/alertes", c.GetAlertes, rbac.PermReadPlaintes)
+	plaintes.GET("/top-agents", c.GetTopAgents, rbac.PermReadPlaintes)
+	plaintes.GET("/:id", c.GetByID, rbac.PermReadPlaintes)
+	plaintes.GET("/numero/:numero", c.GetByNumero, rbac.PermReadPlaintes)
+	plaintes.GET("/:id/preuves", c.GetPreuves, rbac.PermReadPlaintes)
+	plaintes.POST("/:id/preuves", c.AddPreuve, rbac.PermUpdatePlaintes)
+	plaintes.GET("/:id/actes-enquete", c.GetActesEnquete, rbac.PermReadPlaintes)
+	plaintes.POST("/:id/actes-enquete", c.AddActeEnquete, rbac.PermUpdatePlaintes)
+	plaintes.GET("/:id/timeline", c.GetTimeline, rbac.PermReadPlaintes)
+	plaintes.POST("/:id/timeline", c.AddTimelineEvent, rbac.PermUpdatePlaintes)
+	plaintes.GET("/:id/enquetes", c.GetEnquetes, rbac.PermReadPlaintes)
+	plaintes.POST("/:id/enquetes", c.AddEnquete, rbac.PermUpdatePlaintes)
+	plaintes.GET("/:id/decisions", c.GetDecisions, rbac.PermReadPlaintes)
+	plaintes.POST("/:id/decisions", c.AddDecision, rbac.PermUpdatePlaintes)
+	plaintes.GET("/:id/historique", c.GetHistorique, rbac.PermReadPlaintes)
+	plaintes.PUT("/:id", c.Update, rbac.PermUpdatePlaintes)
+	plaintes.DELETE("/:id", c.Delete, rbac.PermDeletePlaintes)
+	plaintes.PATCH("/:id/etape", c.ChangerEtape, rbac.PermUpdatePlaintes)
+	plaintes.PATCH("/:id/statut", c.ChangerStatut, rbac.PermUpdatePlaintes)
+	plaintes.PATCH("/:id/assigner", c.AssignerAgent, rbac.PermUpdatePlaintes)
 }
 
 // Create creates a new plainte
@@ -119,12 +123,19 @@ func (c *Controller) List(ctx echo.Context) error {
 		req.Search = &search
 	}
 
-	result, err := c.service.List(ctx.Request().Context(), req)
+	// Pagination, tri et champs
+	page, err := pagination.Parse(ctx, listSpec)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	req.Page = page
+
+	plaintes, result, err := c.service.List(ctx.Request().Context(), req)
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
 
-	return ctx.JSON(http.StatusOK, result)
+	return responses.Paginated(ctx, plaintes, result)
 }
 
 // Update updates a plainte
@@ -0,0 +1,24 @@
+package plainte
+
+import (
+	"context"
+	"fmt"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/scheduler"
+)
+
+// NewSLAJob flags open plaintes whose SLA has been exceeded
+func NewSLAJob(service Service) scheduler.Job {
+	return scheduler.Job{
+		Name:        "plainte-sla",
+		Description: "Signale les plaintes en cours dont le délai SLA est dépassé",
+		Schedule:    "*/30 * * * *",
+		Run: func(ctx context.Context) (string, error) {
+			count, err := service.MarquerSLADepasse(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d plaintes en dépassement de SLA", count), nil
+		},
+	}
+}
@@ -3,6 +3,7 @@ package plainte
 import (
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
 
 	"go.uber.org/fx"
 	"go.uber.org/zap"
@@ -17,15 +18,20 @@ var Module = fx.Module("plainte",
 			fx.As(new(interfaces.Controller)),
 			fx.ResultTags(`group:"controllers"`),
 		),
+		fx.Annotate(
+			NewSLAJob,
+			fx.ResultTags(`group:"jobs"`),
+		),
 	),
 )
 
 // NewPlainteService creates a new plainte service for DI
 func NewPlainteService(
 	client *ent.Client,
+	numberingService numbering.Service,
 	logger *zap.Logger,
 ) Service {
-	return NewService(client, logger)
+	return NewService(client, numberingService, logger)
 }
 
 // NewPlainteController creates a new plainte controller for DI
@@ -3,11 +3,18 @@ package plainte
 import (
 	"context"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/plainte"
+	"police-trafic-api-frontend-aligned/ent/predicate"
 	"police-trafic-api-frontend-aligned/ent/user"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/audittrail"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -18,7 +25,7 @@ type Service interface {
 	Create(ctx context.Context, req CreatePlainteRequest) (*PlainteResponse, error)
 	GetByID(ctx context.Context, id string) (*PlainteResponse, error)
 	GetByNumero(ctx context.Context, numero string) (*PlainteResponse, error)
-	List(ctx context.Context, req ListPlaintesRequest) (*ListPlaintesResponse, error)
+	List(ctx context.Context, req ListPlaintesRequest) ([]*PlainteResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, req UpdatePlainteRequest) (*PlainteResponse, error)
 	Delete(ctx context.Context, id string) error
 	ChangerEtape(ctx context.Context, id string, req ChangerEtapeRequest) (*PlainteResponse, error)
@@ -38,32 +45,50 @@ type Service interface {
 	GetDecisions(ctx context.Context, plainteID string) ([]DecisionResponse, error)
 	AddDecision(ctx context.Context, plainteID string, req AddDecisionRequest) (*DecisionResponse, error)
 	GetHistorique(ctx context.Context, plainteID string) ([]HistoriqueResponse, error)
+	MarquerSLADepasse(ctx context.Context) (int, error)
 }
 
+// auditResourceType identifies plaintes in the audit log
+const auditResourceType = "Plainte"
+
 type service struct {
-	client *ent.Client
-	logger *zap.Logger
+	client           *ent.Client
+	numberingService numbering.Service
+	logger           *zap.Logger
 }
 
 // NewService creates a new plainte service
-func NewService(client *ent.Client, logger *zap.Logger) Service {
+func NewService(client *ent.Client, numberingService numbering.Service, logger *zap.Logger) Service {
 	return &service{
-		client: client,
-		logger: logger,
+		client:           client,
+		numberingService: numberingService,
+		logger:           logger,
 	}
 }
 
 func (s *service) Create(ctx context.Context, req CreatePlainteRequest) (*PlainteResponse, error) {
+	ctx, span := tracing.Start(ctx, "plainte.Create")
+	defer span.End()
+
 	s.logger.Info("Creating new plainte", zap.String("type", req.TypePlainte))
 
-	// Generate unique ID and numero
-	id := uuid.New()
-	numero := fmt.Sprintf("PLT-%d-%s", time.Now().Year(), uuid.New().String()[:8])
+	// Reserve the numero in the commissariat sequence
+	numberingReq := &numbering.Request{Type: numbering.TypePlainte}
+	if req.CommissariatID != nil {
+		numberingReq.CommissariatID = *req.CommissariatID
+	}
+	resv, err := s.numberingService.Reserve(ctx, numberingReq)
+	if err != nil {
+		s.logger.Error("Failed to reserve plainte numero", zap.Error(err))
+		return nil, fmt.Errorf("failed to reserve plainte numero: %w", err)
+	}
+	defer resv.Rollback()
 
 	// Build create query
-	create := s.client.Plainte.Create().
+	id := uuid.New()
+	create := resv.Client().Plainte.Create().
 		SetID(id).
-		SetNumero(numero).
+		SetNumero(resv.Numero).
 		SetTypePlainte(req.TypePlainte).
 		SetPlaignantNom(req.PlaignantNom).
 		SetPlaignantPrenom(req.PlaignantPrenom).
@@ -132,16 +157,29 @@ func (s *service) Create(ctx context.Context, req CreatePlainteRequest) (*Plaint
 		create.SetTemoins(temoinsData)
 	}
 
-	p, err := create.Save(ctx)
+	p, err := create.Save(resv.Context())
 	if err != nil {
 		s.logger.Error("Failed to create plainte", zap.Error(err))
 		return nil, fmt.Errorf("failed to create plainte: %w", err)
 	}
 
-	return s.toResponse(ctx, p)
+	if err := resv.Commit(); err != nil {
+		s.logger.Error("Failed to create plainte", zap.Error(err))
+		return nil, fmt.Errorf("failed to create plainte: %w", err)
+	}
+
+	response, err := s.toResponse(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	audittrail.Snapshot(ctx, auditResourceType, response.ID, nil, response)
+	return response, nil
 }
 
 func (s *service) GetByID(ctx context.Context, id string) (*PlainteResponse, error) {
+	ctx, span := tracing.Start(ctx, "plainte.GetByID")
+	defer span.End()
+
 	uid, _ := uuid.Parse(id)
 	p, err := s.client.Plainte.Query().
 		Where(plainte.ID(uid)).
@@ -159,6 +197,9 @@ func (s *service) GetByID(ctx context.Context, id string) (*PlainteResponse, err
 }
 
 func (s *service) GetByNumero(ctx context.Context, numero string) (*PlainteResponse, error) {
+	ctx, span := tracing.Start(ctx, "plainte.GetByNumero")
+	defer span.End()
+
 	p, err := s.client.Plainte.Query().
 		Where(plainte.Numero(numero)).
 		WithCommissariat().
@@ -174,7 +215,10 @@ func (s *service) GetByNumero(ctx context.Context, numero string) (*PlainteRespo
 	return s.toResponse(ctx, p)
 }
 
-func (s *service) List(ctx context.Context, req ListPlaintesRequest) (*ListPlaintesResponse, error) {
+func (s *service) List(ctx context.Context, req ListPlaintesRequest) ([]*PlainteResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "plainte.List")
+	defer span.End()
+
 	query := s.client.Plainte.Query()
 
 	// Apply filters
@@ -213,49 +257,66 @@ func (s *service) List(ctx context.Context, req ListPlaintesRequest) (*ListPlain
 		)
 	}
 
-	// Get total count
-	total, err := query.Count(ctx)
+	// Get total count, filter= included
+	total, err := query.Clone().Where(predicate.Plainte(req.Page.Filter())).Count(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to count plaintes: %w", err)
+		return nil, nil, fmt.Errorf("failed to count plaintes: %w", err)
 	}
 
 	// Apply pagination
-	if req.Limit > 0 {
-		query = query.Limit(req.Limit)
+	order := ent.Desc(plainte.FieldDateDepot)
+	if page := req.Page; page != nil {
+		// Les pages suivantes partent de la dernière plainte lue
+		query = query.Where(predicate.Plainte(page.Where())).
+			Offset(page.Offset()).
+			Limit(page.FetchLimit())
+		order = page.OrderBy()
 	} else {
-		query = query.Limit(20)
-	}
-	if req.Offset > 0 {
-		query = query.Offset(req.Offset)
+		if req.Limit > 0 {
+			query = query.Limit(req.Limit)
+		} else {
+			query = query.Limit(20)
+		}
+		if req.Offset > 0 {
+			query = query.Offset(req.Offset)
+		}
 	}
 
-	// Order by date depot descending
-	query = query.Order(ent.Desc(plainte.FieldDateDepot))
+	// Order by date depot descending, unless sorted otherwise
+	query = query.Order(order)
 
 	// Load with edges
 	query = query.WithCommissariat().WithAgentAssigne()
 
 	plaintes, err := query.All(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list plaintes: %w", err)
+		return nil, nil, fmt.Errorf("failed to list plaintes: %w", err)
 	}
 
+	plaintes, page, err := pagination.Trim(req.Page, plaintes)
+	if err != nil {
+		return nil, nil, err
+	}
+	page.SetTotal(total)
+
 	responses := make([]*PlainteResponse, len(plaintes))
 	for i, p := range plaintes {
 		resp, err := s.toResponse(ctx, p)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		responses[i] = resp
 	}
 
-	return &ListPlaintesResponse{
-		Plaintes: responses,
-		Total:    total,
-	}, nil
+	return responses, page, nil
 }
 
 func (s *service) Update(ctx context.Context, id string, req UpdatePlainteRequest) (*PlainteResponse, error) {
+	ctx, span := tracing.Start(ctx, "plainte.Update")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	uid, _ := uuid.Parse(id)
 	update := s.client.Plainte.UpdateOneID(uid)
 
@@ -318,10 +379,20 @@ func (s *service) Update(ctx context.Context, id string, req UpdatePlainteReques
 		return nil, fmt.Errorf("failed to update plainte: %w", err)
 	}
 
-	return s.toResponse(ctx, p)
+	response, err := s.toResponse(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	audittrail.Snapshot(ctx, auditResourceType, id, before, response)
+	return response, nil
 }
 
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "plainte.Delete")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	uid, _ := uuid.Parse(id)
 	err := s.client.Plainte.DeleteOneID(uid).Exec(ctx)
 	if err != nil {
@@ -330,10 +401,16 @@ func (s *service) Delete(ctx context.Context, id string) error {
 		}
 		return fmt.Errorf("failed to delete plainte: %w", err)
 	}
+	audittrail.Snapshot(ctx, auditResourceType, id, before, nil)
 	return nil
 }
 
 func (s *service) ChangerEtape(ctx context.Context, id string, req ChangerEtapeRequest) (*PlainteResponse, error) {
+	ctx, span := tracing.Start(ctx, "plainte.ChangerEtape")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	uid, _ := uuid.Parse(id)
 	update := s.client.Plainte.UpdateOneID(uid).
 		SetEtapeActuelle(plainte.EtapeActuelle(req.Etape))
@@ -355,10 +432,20 @@ func (s *service) ChangerEtape(ctx context.Context, id string, req ChangerEtapeR
 		return nil, fmt.Errorf("failed to change etape: %w", err)
 	}
 
-	return s.toResponse(ctx, p)
+	response, err := s.toResponse(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	audittrail.Snapshot(ctx, auditResourceType, id, before, response)
+	return response, nil
 }
 
 func (s *service) ChangerStatut(ctx context.Context, id string, req ChangerStatutRequest) (*PlainteResponse, error) {
+	ctx, span := tracing.Start(ctx, "plainte.ChangerStatut")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	uid, _ := uuid.Parse(id)
 	update := s.client.Plainte.UpdateOneID(uid).
 		SetStatut(plainte.Statut(req.Statut))
@@ -380,10 +467,20 @@ func (s *service) ChangerStatut(ctx context.Context, id string, req ChangerStatu
 		return nil, fmt.Errorf("failed to change statut: %w", err)
 	}
 
-	return s.toResponse(ctx, p)
+	response, err := s.toResponse(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	audittrail.Snapshot(ctx, auditResourceType, id, before, response)
+	return response, nil
 }
 
 func (s *service) AssignerAgent(ctx context.Context, id string, req AssignerAgentRequest) (*PlainteResponse, error) {
+	ctx, span := tracing.Start(ctx, "plainte.AssignerAgent")
+	defer span.End()
+
+	before := s.snapshot(ctx, id)
+
 	uid, _ := uuid.Parse(id)
 	agentID, _ := uuid.Parse(req.AgentID)
 	p, err := s.client.Plainte.UpdateOneID(uid).
@@ -396,10 +493,18 @@ func (s *service) AssignerAgent(ctx context.Context, id string, req AssignerAgen
 		return nil, fmt.Errorf("failed to assign agent: %w", err)
 	}
 
-	return s.toResponse(ctx, p)
+	response, err := s.toResponse(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	audittrail.Snapshot(ctx, auditResourceType, id, before, response)
+	return response, nil
 }
 
 func (s *service) GetStatistics(ctx context.Context, req StatisticsRequest) (*PlainteStatisticsResponse, error) {
+	ctx, span := tracing.Start(ctx, "plainte.GetStatistics")
+	defer span.End()
+
 	// Build query with filters
 	query := s.client.Plainte.Query()
 
@@ -471,6 +576,74 @@ func (s *service) GetStatistics(ctx context.Context, req StatisticsRequest) (*Pl
 	return stats, nil
 }
 
+// MarquerSLADepasse flags open plaintes whose processing delay has exceeded their SLA
+func (s *service) MarquerSLADepasse(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "plainte.MarquerSLADepasse")
+	defer span.End()
+
+	plaintes, err := s.client.Plainte.Query().
+		Where(
+			plainte.StatutEQ(plainte.StatutEN_COURS),
+			plainte.SLADepasse(false),
+		).
+		All(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query plaintes: %w", err)
+	}
+
+	now := time.Now()
+	var ids []uuid.UUID
+	for _, p := range plaintes {
+		if now.After(p.DateDepot.Add(delaiSLA(p.DelaiSLA))) {
+			ids = append(ids, p.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	count, err := s.client.Plainte.Update().
+		Where(plainte.IDIn(ids...)).
+		SetSLADepasse(true).
+		Save(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to flag SLA exceeded: %w", err)
+	}
+
+	s.logger.Info("Flagged plaintes with SLA exceeded", zap.Int("count", count))
+	return count, nil
+}
+
+// delaiSLA parses the SLA stored on a plainte ("10", "10 jours", "48h"),
+// falling back to the 7 days used by the alertes
+func delaiSLA(value string) time.Duration {
+	const defaultSLA = 7 * 24 * time.Hour
+
+	value = strings.TrimSpace(strings.ToLower(value))
+	if value == "" {
+		return defaultSLA
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return d
+	}
+	if fields := strings.Fields(value); len(fields) > 0 {
+		if days, err := strconv.Atoi(fields[0]); err == nil && days > 0 {
+			return time.Duration(days) * 24 * time.Hour
+		}
+	}
+	return defaultSLA
+}
+
+// snapshot returns the state of a plainte before a mutation, for the audit
+// log; nil when it cannot be read, the mutation then reports the error
+func (s *service) snapshot(ctx context.Context, id string) *PlainteResponse {
+	before, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil
+	}
+	return before
+}
+
 func (s *service) toResponse(ctx context.Context, p *ent.Plainte) (*PlainteResponse, error) {
 	resp := &PlainteResponse{
 		ID:                 p.ID.String(),
@@ -13,6 +13,7 @@ import (
 	"police-trafic-api-frontend-aligned/ent/plainte"
 	"police-trafic-api-frontend-aligned/ent/preuve"
 	"police-trafic-api-frontend-aligned/ent/timelineevent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/audittrail"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -150,6 +151,7 @@ func (s *service) AddPreuve(ctx context.Context, plainteID string, req AddPreuve
 		zap.String("id", preuveResult.ID.String()),
 		zap.String("numero_piece", preuveResult.NumeroPiece))
 
+	audittrail.Snapshot(ctx, "Preuve", response.ID, nil, response)
 	return response, nil
 }
 
@@ -290,6 +292,7 @@ func (s *service) AddActeEnquete(ctx context.Context, plainteID string, req AddA
 		zap.String("id", acteResult.ID.String()),
 		zap.String("type", string(acteResult.Type)))
 
+	audittrail.Snapshot(ctx, "ActeEnquete", response.ID, nil, response)
 	return response, nil
 }
 
@@ -413,6 +416,7 @@ func (s *service) AddTimelineEvent(ctx context.Context, plainteID string, req Ad
 		zap.String("id", eventResult.ID.String()),
 		zap.String("type", string(eventResult.Type)))
 
+	audittrail.Snapshot(ctx, "TimelineEvent", response.ID, nil, response)
 	return response, nil
 }
 
@@ -725,6 +729,7 @@ func (s *service) AddEnquete(ctx context.Context, plainteID string, req AddEnque
 		zap.String("id", enqueteResult.ID.String()),
 		zap.String("type", string(enqueteResult.Type)))
 
+	audittrail.Snapshot(ctx, "Enquete", response.ID, nil, response)
 	return response, nil
 }
 
@@ -847,6 +852,7 @@ func (s *service) AddDecision(ctx context.Context, plainteID string, req AddDeci
 		zap.String("id", decisionResult.ID.String()),
 		zap.String("type", string(decisionResult.Type)))
 
+	audittrail.Snapshot(ctx, "Decision", response.ID, nil, response)
 	return response, nil
 }
 
@@ -2,6 +2,9 @@ package plainte
 
 import (
 	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 )
 
 // Request types
@@ -74,6 +77,34 @@ type ListPlaintesRequest struct {
 	Search         *string    `json:"search,omitempty"`
 	Limit          int        `json:"limit,omitempty"`
 	Offset         int        `json:"offset,omitempty"`
+	Page           *pagination.Params `json:"-"`
+}
+
+// listSpec declares the sorts and fields accepted by GET /plaintes
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"date_depot": "date_depot",
+		"created_at": "created_at",
+		"numero":     "numero",
+		"statut":     "statut",
+		"priorite":   "priorite",
+	},
+	DefaultSort: "-date_depot",
+	Fields:      pagination.JSONFields(PlainteResponse{}),
+	Filters: filter.Fields{
+		"numero":          {Column: "numero", Type: filter.String},
+		"type_plainte":    {Column: "type_plainte", Type: filter.String},
+		"statut":          {Column: "statut", Type: filter.String},
+		"priorite":        {Column: "priorite", Type: filter.String},
+		"etape_actuelle":  {Column: "etape_actuelle", Type: filter.String},
+		"plaignant_nom":   {Column: "plaignant_nom", Type: filter.String},
+		"lieu_faits":      {Column: "lieu_faits", Type: filter.String},
+		"sla_depasse":     {Column: "sla_depasse", Type: filter.Bool},
+		"date_depot":      {Column: "date_depot", Type: filter.Time},
+		"date_faits":      {Column: "date_faits", Type: filter.Time},
+		"date_resolution": {Column: "date_resolution", Type: filter.Time},
+		"created_at":      {Column: "created_at", Type: filter.Time},
+	},
 }
 
 // Response types
@@ -141,12 +172,6 @@ type AgentSummary struct {
 	Prenom    string `json:"prenom"`
 }
 
-// ListPlaintesResponse represents the response for listing plaintes
-type ListPlaintesResponse struct {
-	Plaintes []*PlainteResponse `json:"plaintes"`
-	Total    int                `json:"total"`
-}
-
 // ChangerEtapeRequest represents request to change plainte workflow step
 type ChangerEtapeRequest struct {
 	Etape        string  `json:"etape" validate:"required,oneof=DEPOT ENQUETE RESOLUTION CLOTURE"`
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
@@ -24,35 +26,35 @@ func NewPVController(service Service) interfaces.Controller {
 
 // RegisterRoutes registers PV routes
 func (c *Controller) RegisterRoutes(g *echo.Group) {
-	group := g.Group("/pv")
+	group := rbac.Guard(g).Group("/pv")
 
 	// CRUD endpoints
-	group.GET("", c.ListPVs)
-	group.GET("/:id", c.GetPV)
-	group.POST("", c.CreatePV)
-	group.PUT("/:id", c.UpdatePV)
-	group.DELETE("/:id", c.DeletePV)
+	group.GET("", c.ListPVs, rbac.PermReadPV)
+	group.GET("/:id", c.GetPV, rbac.PermReadPV)
+	group.POST("", c.CreatePV, rbac.PermCreatePV)
+	group.PUT("/:id", c.UpdatePV, rbac.PermUpdatePV)
+	group.DELETE("/:id", c.DeletePV, rbac.PermDeletePV)
 
 	// By numero
-	group.GET("/numero/:numero", c.GetByNumeroPV)
+	group.GET("/numero/:numero", c.GetByNumeroPV, rbac.PermReadPV)
 
 	// By infraction
-	group.GET("/infraction/:infractionId", c.GetByInfraction)
+	group.GET("/infraction/:infractionId", c.GetByInfraction, rbac.PermReadPV)
 
 	// Actions
-	group.POST("/:id/payer", c.PayerPV)
-	group.POST("/:id/contester", c.ContesterPV)
-	group.POST("/:id/decision", c.DeciderContestation)
-	group.POST("/:id/majorer", c.MajorerPV)
-	group.POST("/:id/annuler", c.AnnulerPV)
+	group.POST("/:id/payer", c.PayerPV, rbac.PermCreatePaiements)
+	group.POST("/:id/contester", c.ContesterPV, rbac.PermCreateRecours)
+	group.POST("/:id/decision", c.DeciderContestation, rbac.PermApprovePV)
+	group.POST("/:id/majorer", c.MajorerPV, rbac.PermUpdatePV)
+	group.POST("/:id/annuler", c.AnnulerPV, rbac.PermApprovePV)
 
 	// Special queries
-	group.GET("/expired", c.GetExpiredPVs)
-	group.GET("/statistics", c.GetStatistics)
+	group.GET("/expired", c.GetExpiredPVs, rbac.PermReadPV)
+	group.GET("/statistics", c.GetStatistics, rbac.PermReadPV)
 
 	// Rappels et retards
-	group.POST("/:id/envoyer-rappel", c.EnvoyerRappel)
-	group.PATCH("/:id/marquer-en-retard", c.MarquerEnRetard)
+	group.POST("/:id/envoyer-rappel", c.EnvoyerRappel, rbac.PermUpdatePV)
+	group.PATCH("/:id/marquer-en-retard", c.MarquerEnRetard, rbac.PermUpdatePV)
 }
 
 // ListPVs lists PVs with filters
@@ -99,27 +101,19 @@ func (c *Controller) ListPVs(ctx echo.Context) error {
 		request.Expired = &expired
 	}
 
-	// Pagination
-	if limit := ctx.QueryParam("limit"); limit != "" {
-		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
-			request.Limit = l
-		}
-	} else {
-		request.Limit = 50
-	}
-
-	if offset := ctx.QueryParam("offset"); offset != "" {
-		if o, err := strconv.Atoi(offset); err == nil && o >= 0 {
-			request.Offset = o
-		}
+	// Pagination, tri et champs
+	page, err := pagination.Parse(ctx, listSpec)
+	if err != nil {
+		return responses.BadRequest(ctx, err.Error())
 	}
+	request.Page = page
 
-	result, err := c.service.List(ctx.Request().Context(), request)
+	pvs, result, err := c.service.List(ctx.Request().Context(), request)
 	if err != nil {
 		return responses.InternalServerError(ctx, "Failed to list PVs: "+err.Error())
 	}
 
-	return responses.Success(ctx, result)
+	return responses.Paginated(ctx, pvs, result)
 }
 
 // GetPV gets a PV by ID
@@ -0,0 +1,55 @@
+package pv
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/scheduler"
+)
+
+// NewMarquerEnRetardJob marks unpaid PVs past their payment deadline as late
+func NewMarquerEnRetardJob(service Service) scheduler.Job {
+	return scheduler.Job{
+		Name:        "pv-marquer-en-retard",
+		Description: "Marque en retard les PV émis dont la date limite de paiement est dépassée",
+		Schedule:    "0 1 * * *",
+		Run: func(ctx context.Context) (string, error) {
+			count, err := service.MarquerPVsEnRetard(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d PV marqués en retard", count), nil
+		},
+	}
+}
+
+// NewMajorationJob applies the late payment penalty to the PVs overdue for
+// more than pv.majoration_delay
+func NewMajorationJob(service Service, cfg *config.Config) scheduler.Job {
+	return scheduler.Job{
+		Name:        "pv-majoration",
+		Description: "Applique la majoration aux PV en retard depuis plus de " + formatDelay(cfg.PV.MajorationDelay),
+		Schedule:    "30 1 * * *",
+		Run: func(ctx context.Context) (string, error) {
+			count, err := service.MajorerPVsEnRetard(ctx)
+			if err != nil {
+				return "", err
+			}
+			return fmt.Sprintf("%d PV majorés", count), nil
+		},
+	}
+}
+
+// formatDelay writes a delay in days when it is a whole number of days
+func formatDelay(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d == day:
+		return "1 jour"
+	case d > 0 && d%day == 0:
+		return fmt.Sprintf("%d jours", d/day)
+	}
+	return d.String()
+}
@@ -2,6 +2,9 @@ package pv
 
 import (
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/notification"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
 
 	"go.uber.org/fx"
@@ -17,15 +20,26 @@ var Module = fx.Module("pv",
 			fx.As(new(interfaces.Controller)),
 			fx.ResultTags(`group:"controllers"`),
 		),
+		fx.Annotate(
+			NewMarquerEnRetardJob,
+			fx.ResultTags(`group:"jobs"`),
+		),
+		fx.Annotate(
+			NewMajorationJob,
+			fx.ResultTags(`group:"jobs"`),
+		),
 	),
 )
 
 // NewPVServiceProvider creates a new PV service for DI
 func NewPVServiceProvider(
 	pvRepo repository.PVRepository,
+	notificationService notification.Service,
+	numberingService numbering.Service,
+	cfg *config.Config,
 	logger *zap.Logger,
 ) Service {
-	return NewPVService(pvRepo, logger)
+	return NewPVService(pvRepo, notificationService, numberingService, cfg.PV, logger)
 }
 
 // NewPVControllerProvider creates a new PV controller for DI
@@ -3,21 +3,32 @@ package pv
 import (
 	"context"
 	"fmt"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"strings"
 	"time"
 
 	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/audittrail"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/metrics"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/notification"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
+// auditResourceType identifies PVs in the audit log and the notification history
+const auditResourceType = "ProcesVerbal"
+
 // Service defines PV service interface
 type Service interface {
 	Create(ctx context.Context, input *CreatePVRequest) (*PVResponse, error)
 	GetByID(ctx context.Context, id string) (*PVResponse, error)
 	GetByNumeroPV(ctx context.Context, numero string) (*PVResponse, error)
-	List(ctx context.Context, filters *ListPVRequest) (*ListPVResponse, error)
+	List(ctx context.Context, filters *ListPVRequest) ([]*PVResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, input *UpdatePVRequest) (*PVResponse, error)
 	Delete(ctx context.Context, id string) error
 	GetByInfraction(ctx context.Context, infractionID string) (*PVResponse, error)
@@ -30,33 +41,57 @@ type Service interface {
 	GetStatistics(ctx context.Context, filters *ListPVRequest) (*PVStatisticsResponse, error)
 	EnvoyerRappel(ctx context.Context, id string) (*RappelResponse, error)
 	MarquerEnRetard(ctx context.Context, id string) (*PVResponse, error)
+	MarquerPVsEnRetard(ctx context.Context) (int, error)
+	MajorerPVsEnRetard(ctx context.Context) (int, error)
 }
 
 // service implements Service interface
 type service struct {
-	pvRepo repository.PVRepository
-	logger *zap.Logger
+	pvRepo              repository.PVRepository
+	notificationService notification.Service
+	numberingService    numbering.Service
+	cfg                 config.PVConfig
+	logger              *zap.Logger
 }
 
 // NewPVService creates a new PV service
 func NewPVService(
 	pvRepo repository.PVRepository,
+	notificationService notification.Service,
+	numberingService numbering.Service,
+	cfg config.PVConfig,
 	logger *zap.Logger,
 ) Service {
 	return &service{
-		pvRepo: pvRepo,
-		logger: logger,
+		pvRepo:              pvRepo,
+		notificationService: notificationService,
+		numberingService:    numberingService,
+		cfg:                 cfg,
+		logger:              logger,
 	}
 }
 
 // Create creates a new PV
 func (s *service) Create(ctx context.Context, input *CreatePVRequest) (*PVResponse, error) {
+	ctx, span := tracing.Start(ctx, "pv.Create")
+	defer span.End()
+
 	if err := s.validateCreateInput(input); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
 
-	// Générer un numéro de PV unique
-	numeroPV := generateNumeroPV()
+	// Réserver le numéro de PV dans la séquence du commissariat
+	resv, err := s.numberingService.Reserve(ctx, &numbering.Request{
+		Type:          numbering.TypePV,
+		ControleID:    input.ControleID,
+		InspectionID:  input.InspectionID,
+		InfractionIDs: input.InfractionIDs,
+	})
+	if err != nil {
+		s.logger.Error("Failed to reserve PV number", zap.Error(err))
+		return nil, fmt.Errorf("failed to reserve PV number: %w", err)
+	}
+	defer resv.Rollback()
 
 	// Calculer la date limite de paiement (45 jours par défaut)
 	var dateLimite *time.Time
@@ -69,7 +104,7 @@ func (s *service) Create(ctx context.Context, input *CreatePVRequest) (*PVRespon
 
 	repoInput := &repository.CreatePVInput{
 		ID:                 uuid.New().String(),
-		NumeroPV:           numeroPV,
+		NumeroPV:           resv.Numero,
 		DateEmission:       time.Now(),
 		MontantTotal:       input.MontantTotal,
 		DateLimitePaiement: dateLimite,
@@ -80,12 +115,18 @@ func (s *service) Create(ctx context.Context, input *CreatePVRequest) (*PVRespon
 		InspectionID:       input.InspectionID,
 	}
 
-	pvEnt, err := s.pvRepo.Create(ctx, repoInput)
+	pvEnt, err := s.pvRepo.Create(resv.Context(), repoInput)
 	if err != nil {
 		s.logger.Error("Failed to create PV", zap.Error(err))
 		return nil, fmt.Errorf("failed to create PV: %w", err)
 	}
 
+	if err := resv.Commit(); err != nil {
+		s.logger.Error("Failed to create PV", zap.Error(err))
+		return nil, fmt.Errorf("failed to create PV: %w", err)
+	}
+	metrics.PVEmitted.Inc("pv")
+
 	// Recharger avec les relations
 	pvEnt, err = s.pvRepo.GetByID(ctx, pvEnt.ID.String())
 	if err != nil {
@@ -93,11 +134,17 @@ func (s *service) Create(ctx context.Context, input *CreatePVRequest) (*PVRespon
 		return nil, fmt.Errorf("failed to reload PV: %w", err)
 	}
 
-	return s.entityToResponse(pvEnt), nil
+	response := s.entityToResponse(pvEnt)
+	audittrail.Snapshot(ctx, auditResourceType, response.ID, nil, response)
+
+	return response, nil
 }
 
 // GetByID gets PV by ID
 func (s *service) GetByID(ctx context.Context, id string) (*PVResponse, error) {
+	ctx, span := tracing.Start(ctx, "pv.GetByID")
+	defer span.End()
+
 	pvEnt, err := s.pvRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -108,6 +155,9 @@ func (s *service) GetByID(ctx context.Context, id string) (*PVResponse, error) {
 
 // GetByNumeroPV gets PV by numero
 func (s *service) GetByNumeroPV(ctx context.Context, numero string) (*PVResponse, error) {
+	ctx, span := tracing.Start(ctx, "pv.GetByNumeroPV")
+	defer span.End()
+
 	pvEnt, err := s.pvRepo.GetByNumeroPV(ctx, numero)
 	if err != nil {
 		return nil, err
@@ -117,34 +167,43 @@ func (s *service) GetByNumeroPV(ctx context.Context, numero string) (*PVResponse
 }
 
 // List gets PVs with filters
-func (s *service) List(ctx context.Context, input *ListPVRequest) (*ListPVResponse, error) {
+func (s *service) List(ctx context.Context, input *ListPVRequest) ([]*PVResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "pv.List")
+	defer span.End()
+
 	filters := s.buildFilters(input)
 
 	pvsEnt, err := s.pvRepo.List(ctx, filters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	pvsEnt, page, err := pagination.Trim(input.Page, pvsEnt)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	total, err := s.pvRepo.Count(ctx, filters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	page.SetTotal(total)
 
 	pvs := make([]*PVResponse, len(pvsEnt))
 	for i, pv := range pvsEnt {
 		pvs[i] = s.entityToResponse(pv)
 	}
 
-	return &ListPVResponse{
-		PVs:   pvs,
-		Total: total,
-	}, nil
+	return pvs, page, nil
 }
 
 // Update updates PV
 func (s *service) Update(ctx context.Context, id string, input *UpdatePVRequest) (*PVResponse, error) {
+	ctx, span := tracing.Start(ctx, "pv.Update")
+	defer span.End()
+
 	// Vérifier que le PV existe
-	_, err := s.pvRepo.GetByID(ctx, id)
+	pv, err := s.pvRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
@@ -166,11 +225,17 @@ func (s *service) Update(ctx context.Context, id string, input *UpdatePVRequest)
 		return nil, err
 	}
 
-	return s.entityToResponse(pvEnt), nil
+	response := s.entityToResponse(pvEnt)
+	audittrail.Snapshot(ctx, auditResourceType, id, s.entityToResponse(pv), response)
+
+	return response, nil
 }
 
 // Delete deletes PV
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "pv.Delete")
+	defer span.End()
+
 	// Vérifier que le PV peut être supprimé
 	pv, err := s.pvRepo.GetByID(ctx, id)
 	if err != nil {
@@ -181,11 +246,19 @@ func (s *service) Delete(ctx context.Context, id string) error {
 		return fmt.Errorf("cannot delete a paid PV")
 	}
 
-	return s.pvRepo.Delete(ctx, id)
+	if err := s.pvRepo.Delete(ctx, id); err != nil {
+		return err
+	}
+
+	audittrail.Snapshot(ctx, auditResourceType, id, s.entityToResponse(pv), nil)
+	return nil
 }
 
 // GetByInfraction gets PV by infraction ID
 func (s *service) GetByInfraction(ctx context.Context, infractionID string) (*PVResponse, error) {
+	ctx, span := tracing.Start(ctx, "pv.GetByInfraction")
+	defer span.End()
+
 	pvEnt, err := s.pvRepo.GetByInfraction(ctx, infractionID)
 	if err != nil {
 		return nil, err
@@ -196,6 +269,9 @@ func (s *service) GetByInfraction(ctx context.Context, infractionID string) (*PV
 
 // Payer enregistre un paiement sur le PV
 func (s *service) Payer(ctx context.Context, id string, input *PayerPVRequest) (*PVResponse, error) {
+	ctx, span := tracing.Start(ctx, "pv.Payer")
+	defer span.End()
+
 	// Vérifier que le PV existe et peut être payé
 	pv, err := s.pvRepo.GetByID(ctx, id)
 	if err != nil {
@@ -242,11 +318,17 @@ func (s *service) Payer(ctx context.Context, id string, input *PayerPVRequest) (
 		return nil, err
 	}
 
-	return s.entityToResponse(pvEnt), nil
+	response := s.entityToResponse(pvEnt)
+	audittrail.Snapshot(ctx, auditResourceType, id, s.entityToResponse(pv), response)
+
+	return response, nil
 }
 
 // Contester enregistre une contestation sur le PV
 func (s *service) Contester(ctx context.Context, id string, input *ContesterPVRequest) (*PVResponse, error) {
+	ctx, span := tracing.Start(ctx, "pv.Contester")
+	defer span.End()
+
 	// Vérifier que le PV existe et peut être contesté
 	pv, err := s.pvRepo.GetByID(ctx, id)
 	if err != nil {
@@ -283,11 +365,17 @@ func (s *service) Contester(ctx context.Context, id string, input *ContesterPVRe
 		return nil, err
 	}
 
-	return s.entityToResponse(pvEnt), nil
+	response := s.entityToResponse(pvEnt)
+	audittrail.Snapshot(ctx, auditResourceType, id, s.entityToResponse(pv), response)
+
+	return response, nil
 }
 
 // DeciderContestation enregistre la décision sur une contestation
 func (s *service) DeciderContestation(ctx context.Context, id string, input *DecisionContestationRequest) (*PVResponse, error) {
+	ctx, span := tracing.Start(ctx, "pv.DeciderContestation")
+	defer span.End()
+
 	// Vérifier que le PV existe et est contesté
 	pv, err := s.pvRepo.GetByID(ctx, id)
 	if err != nil {
@@ -332,11 +420,17 @@ func (s *service) DeciderContestation(ctx context.Context, id string, input *Dec
 		return nil, err
 	}
 
-	return s.entityToResponse(pvEnt), nil
+	response := s.entityToResponse(pvEnt)
+	audittrail.Snapshot(ctx, auditResourceType, id, s.entityToResponse(pv), response)
+
+	return response, nil
 }
 
 // Majorer applique une majoration sur le PV
 func (s *service) Majorer(ctx context.Context, id string, input *MajorerPVRequest) (*PVResponse, error) {
+	ctx, span := tracing.Start(ctx, "pv.Majorer")
+	defer span.End()
+
 	// Vérifier que le PV existe et peut être majoré
 	pv, err := s.pvRepo.GetByID(ctx, id)
 	if err != nil {
@@ -368,11 +462,17 @@ func (s *service) Majorer(ctx context.Context, id string, input *MajorerPVReques
 		return nil, err
 	}
 
-	return s.entityToResponse(pvEnt), nil
+	response := s.entityToResponse(pvEnt)
+	audittrail.Snapshot(ctx, auditResourceType, id, s.entityToResponse(pv), response)
+
+	return response, nil
 }
 
 // Annuler annule un PV
 func (s *service) Annuler(ctx context.Context, id string, input *AnnulerPVRequest) (*PVResponse, error) {
+	ctx, span := tracing.Start(ctx, "pv.Annuler")
+	defer span.End()
+
 	// Vérifier que le PV existe et peut être annulé
 	pv, err := s.pvRepo.GetByID(ctx, id)
 	if err != nil {
@@ -403,11 +503,17 @@ func (s *service) Annuler(ctx context.Context, id string, input *AnnulerPVReques
 		return nil, err
 	}
 
-	return s.entityToResponse(pvEnt), nil
+	response := s.entityToResponse(pvEnt)
+	audittrail.Snapshot(ctx, auditResourceType, id, s.entityToResponse(pv), response)
+
+	return response, nil
 }
 
 // GetExpired gets expired PVs
 func (s *service) GetExpired(ctx context.Context) (*ListPVResponse, error) {
+	ctx, span := tracing.Start(ctx, "pv.GetExpired")
+	defer span.End()
+
 	pvsEnt, err := s.pvRepo.GetExpired(ctx)
 	if err != nil {
 		return nil, err
@@ -426,6 +532,9 @@ func (s *service) GetExpired(ctx context.Context) (*ListPVResponse, error) {
 
 // GetStatistics gets statistics for PVs
 func (s *service) GetStatistics(ctx context.Context, input *ListPVRequest) (*PVStatisticsResponse, error) {
+	ctx, span := tracing.Start(ctx, "pv.GetStatistics")
+	defer span.End()
+
 	filters := s.buildFilters(input)
 
 	stats, err := s.pvRepo.GetStatistics(ctx, filters)
@@ -472,6 +581,7 @@ func (s *service) buildFilters(input *ListPVRequest) *repository.PVFilters {
 		Expired:      input.Expired,
 		Limit:        input.Limit,
 		Offset:       input.Offset,
+		Page:         input.Page,
 	}
 }
 
@@ -550,14 +660,11 @@ func (s *service) entityToResponse(pvEnt *ent.ProcesVerbal) *PVResponse {
 	return response
 }
 
-// generateNumeroPV generates a unique PV number
-func generateNumeroPV() string {
-	now := time.Now()
-	return fmt.Sprintf("PV%s%06d", now.Format("20060102"), now.Nanosecond()/1000)
-}
-
 // EnvoyerRappel envoie un rappel de paiement pour un PV
 func (s *service) EnvoyerRappel(ctx context.Context, id string) (*RappelResponse, error) {
+	ctx, span := tracing.Start(ctx, "pv.EnvoyerRappel")
+	defer span.End()
+
 	// Vérifier que le PV existe
 	pv, err := s.pvRepo.GetByID(ctx, id)
 	if err != nil {
@@ -588,17 +695,68 @@ func (s *service) EnvoyerRappel(ctx context.Context, id string) (*RappelResponse
 		montantDu = pv.MontantMajore - pv.MontantPaye
 	}
 
-	// Log l'envoi du rappel (en production, envoyer SMS/email)
+	numeroRappel, err := s.prochainNumeroRappel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	nom, telephone, email := contactContrevenant(pv)
+	data := map[string]interface{}{
+		"Nom":          nom,
+		"Numero":       pv.NumeroPv,
+		"NumeroRappel": numeroRappel,
+		"Montant":      fmt.Sprintf("%.0f", montantDu),
+	}
+	if !pv.DateLimitePaiement.IsZero() {
+		data["DateLimite"] = pv.DateLimitePaiement.Format("02/01/2006")
+	}
+
+	envoyes := 0
+	for channel, recipient := range map[string]string{
+		notification.ChannelSMS:   telephone,
+		notification.ChannelEmail: email,
+	} {
+		if recipient == "" {
+			continue
+		}
+		_, err := s.notificationService.Send(ctx, &notification.SendRequest{
+			Channel:      channel,
+			Recipient:    recipient,
+			Template:     notification.TemplatePVRappel,
+			Data:         data,
+			ResourceType: auditResourceType,
+			ResourceID:   id,
+		})
+		if err != nil {
+			s.logger.Warn("Failed to send PV reminder",
+				zap.String("pv_id", id),
+				zap.String("channel", channel),
+				zap.Error(err))
+			continue
+		}
+		envoyes++
+	}
+
+	if envoyes == 0 {
+		return &RappelResponse{
+			PVID:     id,
+			NumeroPV: pv.NumeroPv,
+			Success:  false,
+			Message:  "Aucun contact du contrevenant disponible pour le rappel",
+		}, nil
+	}
+
 	s.logger.Info("Envoi rappel PV",
 		zap.String("pv_id", id),
 		zap.String("numero_pv", pv.NumeroPv),
+		zap.Int("numero_rappel", numeroRappel),
 		zap.Float64("montant_du", montantDu))
 
 	return &RappelResponse{
 		PVID:         id,
 		NumeroPV:     pv.NumeroPv,
 		DateRappel:   time.Now(),
-		NumeroRappel: 1, // À implémenter: compteur de rappels
+		NumeroRappel: numeroRappel,
 		MontantDu:    montantDu,
 		DateLimite:   pv.DateLimitePaiement,
 		Success:      true,
@@ -606,8 +764,55 @@ func (s *service) EnvoyerRappel(ctx context.Context, id string) (*RappelResponse
 	}, nil
 }
 
+// prochainNumeroRappel counts the reminders already sent for the PV. A reminder
+// sends one message per channel, so the busiest channel gives the count.
+func (s *service) prochainNumeroRappel(ctx context.Context, id string) (int, error) {
+	notifications, err := s.notificationService.ListByResource(ctx, auditResourceType, id)
+	if err != nil {
+		return 0, err
+	}
+
+	parCanal := make(map[string]int)
+	precedents := 0
+	for _, n := range notifications {
+		if n.Template != notification.TemplatePVRappel {
+			continue
+		}
+		parCanal[n.Channel]++
+		if parCanal[n.Channel] > precedents {
+			precedents = parCanal[n.Channel]
+		}
+	}
+
+	return precedents + 1, nil
+}
+
+// contactContrevenant returns the name, phone and email of the offender, from
+// the control (and its driver record) or the inspection at the origin of the PV
+func contactContrevenant(pv *ent.ProcesVerbal) (nom, telephone, email string) {
+	if c := pv.Edges.Controle; c != nil {
+		nom = strings.TrimSpace(c.ConducteurPrenom + " " + c.ConducteurNom)
+		telephone = c.ConducteurTelephone
+		if cond := c.Edges.Conducteur; cond != nil {
+			if telephone == "" {
+				telephone = cond.Telephone
+			}
+			email = cond.Email
+		}
+		return nom, telephone, email
+	}
+	if i := pv.Edges.Inspection; i != nil {
+		nom = strings.TrimSpace(i.ConducteurPrenom + " " + i.ConducteurNom)
+		telephone = i.ConducteurTelephone
+	}
+	return nom, telephone, email
+}
+
 // MarquerEnRetard marque un PV comme étant en retard de paiement
 func (s *service) MarquerEnRetard(ctx context.Context, id string) (*PVResponse, error) {
+	ctx, span := tracing.Start(ctx, "pv.MarquerEnRetard")
+	defer span.End()
+
 	// Vérifier que le PV existe
 	pv, err := s.pvRepo.GetByID(ctx, id)
 	if err != nil {
@@ -642,5 +847,75 @@ func (s *service) MarquerEnRetard(ctx context.Context, id string) (*PVResponse,
 		return nil, err
 	}
 
-	return s.entityToResponse(pvEnt), nil
+	response := s.entityToResponse(pvEnt)
+	audittrail.Snapshot(ctx, auditResourceType, id, s.entityToResponse(pv), response)
+
+	return response, nil
+}
+
+// MarquerPVsEnRetard marque en retard tous les PV émis dont la date limite de paiement est dépassée
+func (s *service) MarquerPVsEnRetard(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "pv.MarquerPVsEnRetard")
+	defer span.End()
+
+	pvs, err := s.pvRepo.GetExpired(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, pv := range pvs {
+		// Les PV contestés ou déjà en retard/majorés ne sont pas concernés
+		if pv.Statut != "EMIS" {
+			continue
+		}
+		if ctx.Err() != nil {
+			return count, ctx.Err()
+		}
+		if _, err := s.MarquerEnRetard(ctx, pv.ID.String()); err != nil {
+			s.logger.Error("Failed to mark PV as late",
+				zap.String("pv_id", pv.ID.String()),
+				zap.Error(err))
+			continue
+		}
+		count++
+	}
+
+	return count, nil
+}
+
+// MajorerPVsEnRetard applique la majoration aux PV en retard depuis plus de pv.majoration_delay
+func (s *service) MajorerPVsEnRetard(ctx context.Context) (int, error) {
+	ctx, span := tracing.Start(ctx, "pv.MajorerPVsEnRetard")
+	defer span.End()
+
+	pvs, err := s.pvRepo.GetExpired(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	now := time.Now()
+	count := 0
+	for _, pv := range pvs {
+		if pv.Statut != "EN_RETARD" || now.Before(pv.DateLimitePaiement.Add(s.cfg.MajorationDelay)) {
+			continue
+		}
+		if ctx.Err() != nil {
+			return count, ctx.Err()
+		}
+
+		input := &MajorerPVRequest{
+			MontantMajore:  pv.MontantTotal * (1 + s.cfg.MajorationRate),
+			DateMajoration: now,
+		}
+		if _, err := s.Majorer(ctx, pv.ID.String(), input); err != nil {
+			s.logger.Error("Failed to apply PV penalty",
+				zap.String("pv_id", pv.ID.String()),
+				zap.Error(err))
+			continue
+		}
+		count++
+	}
+
+	return count, nil
 }
@@ -2,6 +2,9 @@ package pv
 
 import (
 	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 )
 
 // CreatePVRequest represents request to create a PV
@@ -63,6 +66,33 @@ type ListPVRequest struct {
 	Expired      *bool      `json:"expired,omitempty"`
 	Limit        int        `json:"limit,omitempty"`
 	Offset       int        `json:"offset,omitempty"`
+	Page         *pagination.Params `json:"-"`
+}
+
+// listSpec declares the sorts and fields accepted by GET /pvs
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"date_emission": "date_emission",
+		"created_at":    "created_at",
+		"numero_pv":     "numero_pv",
+		"montant_total": "montant_total",
+		"statut":        "statut",
+	},
+	DefaultSort: "-date_emission",
+	Fields:      pagination.JSONFields(PVResponse{}),
+	Filters: filter.Fields{
+		"numero_pv":            {Column: "numero_pv", Type: filter.String},
+		"statut":               {Column: "statut", Type: filter.String},
+		"montant_total":        {Column: "montant_total", Type: filter.Float},
+		"montant_majore":       {Column: "montant_majore", Type: filter.Float},
+		"montant_paye":         {Column: "montant_paye", Type: filter.Float},
+		"moyen_paiement":       {Column: "moyen_paiement", Type: filter.String},
+		"tribunal_competent":   {Column: "tribunal_competent", Type: filter.String},
+		"date_emission":        {Column: "date_emission", Type: filter.Time},
+		"date_limite_paiement": {Column: "date_limite_paiement", Type: filter.Time},
+		"date_paiement":        {Column: "date_paiement", Type: filter.Time},
+		"created_at":           {Column: "created_at", Type: filter.Time},
+	},
 }
 
 // PVResponse represents a PV in responses
@@ -2,9 +2,10 @@ package recours
 
 import (
 	"net/http"
-	"strconv"
 	"time"
 
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
@@ -24,21 +25,21 @@ func NewRecoursController(service Service) *Controller {
 
 // RegisterRoutes registers recours routes
 func (c *Controller) RegisterRoutes(g *echo.Group) {
-	recours := g.Group("/recours")
-
-	recours.POST("", c.Create)
-	recours.GET("", c.List)
-	recours.GET("/en-cours", c.GetEnCours)
-	recours.GET("/statistics", c.GetStatistics)
-	recours.GET("/:id", c.GetByID)
-	recours.GET("/numero/:numero", c.GetByNumero)
-	recours.GET("/pv/:pvId", c.GetByProcesVerbal)
-	recours.PUT("/:id", c.Update)
-	recours.DELETE("/:id", c.Delete)
-	recours.POST("/:id/traiter", c.Traiter)
-	recours.POST("/:id/assigner", c.Assigner)
-	recours.POST("/:id/abandonner", c.Abandonner)
-	recours.GET("/:id/etapes", c.GetEtapes)
+	recours := rbac.Guard(g).Group("/recours")
+
+	recours.POST("", c.Create, rbac.PermCreateRecours)
+	recours.GET("", c.List, rbac.PermReadRecours)
+	recours.GET("/en-cours", c.GetEnCours, rbac.PermReadRecours)
+	recours.GET("/statistics", c.GetStatistics, rbac.PermReadRecours)
+	recours.GET("/:id", c.GetByID, rbac.PermReadRecours)
+	recours.GET("/numero/:numero", c.GetByNumero, rbac.PermReadRecours)
+	recours.GET("/pv/:pvId", c.GetByProcesVerbal, rbac.PermReadRecours)
+	recours.PUT("/:id", c.Update, rbac.PermUpdateRecours)
+	recours.DELETE("/:id", c.Delete, rbac.PermDeleteRecours)
+	recours.POST("/:id/traiter", c.Traiter, rbac.PermDecideRecours)
+	recours.POST("/:id/assigner", c.Assigner, rbac.PermUpdateRecours)
+	recours.POST("/:id/abandonner", c.Abandonner, rbac.PermUpdateRecours)
+	recours.GET("/:id/etapes", c.GetEtapes, rbac.PermReadRecours)
 }
 
 // Create handles POST /recours
@@ -133,16 +134,19 @@ func (c *Controller) List(ctx echo.Context) error {
 		}
 	}
 
-	// Parse pagination
-	filters.Limit = getIntParam(ctx, "limit", 20)
-	filters.Offset = getIntParam(ctx, "offset", 0)
+	// Pagination, tri et champs
+	page, err := pagination.Parse(ctx, listSpec)
+	if err != nil {
+		return responses.BadRequest(ctx, err.Error())
+	}
+	filters.Page = page
 
-	result, err := c.service.List(ctx.Request().Context(), &filters)
+	recours, result, err := c.service.List(ctx.Request().Context(), &filters)
 	if err != nil {
 		return responses.InternalServerError(ctx, err.Error())
 	}
 
-	return responses.Success(ctx, result)
+	return responses.Paginated(ctx, recours, result)
 }
 
 // Update handles PUT /recours/:id
@@ -343,18 +347,6 @@ func getStringPtr(s string) *string {
 	return &s
 }
 
-func getIntParam(ctx echo.Context, name string, defaultVal int) int {
-	val := ctx.QueryParam(name)
-	if val == "" {
-		return defaultVal
-	}
-	result, err := strconv.Atoi(val)
-	if err != nil {
-		return defaultVal
-	}
-	return result
-}
-
 func parseTime(s string) (time.Time, error) {
 	return time.Parse("2006-01-02", s)
 }
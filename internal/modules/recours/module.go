@@ -2,6 +2,7 @@ package recours
 
 import (
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
 
 	"go.uber.org/fx"
@@ -23,9 +24,10 @@ var Module = fx.Module("recours",
 // NewRecoursServiceProvider creates a new Recours service for DI
 func NewRecoursServiceProvider(
 	recoursRepo repository.RecoursRepository,
+	numberingService numbering.Service,
 	logger *zap.Logger,
 ) Service {
-	return NewRecoursService(recoursRepo, logger)
+	return NewRecoursService(recoursRepo, numberingService, logger)
 }
 
 // NewRecoursControllerProvider creates a new Recours controller for DI
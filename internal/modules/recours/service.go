@@ -6,7 +6,10 @@ import (
 	"time"
 
 	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/numbering"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -17,7 +20,7 @@ type Service interface {
 	Create(ctx context.Context, input *CreateRecoursRequest) (*RecoursResponse, error)
 	GetByID(ctx context.Context, id string) (*RecoursResponse, error)
 	GetByNumeroRecours(ctx context.Context, numero string) (*RecoursResponse, error)
-	List(ctx context.Context, filters *ListRecoursRequest) (*ListRecoursResponse, error)
+	List(ctx context.Context, filters *ListRecoursRequest) ([]*RecoursResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, input *UpdateRecoursRequest) (*RecoursResponse, error)
 	Delete(ctx context.Context, id string) error
 	GetByProcesVerbal(ctx context.Context, pvID string) (*ListRecoursResponse, error)
@@ -31,33 +34,47 @@ type Service interface {
 
 // service implements Service interface
 type service struct {
-	recoursRepo repository.RecoursRepository
-	logger      *zap.Logger
+	recoursRepo      repository.RecoursRepository
+	numberingService numbering.Service
+	logger           *zap.Logger
 }
 
 // NewRecoursService creates a new recours service
 func NewRecoursService(
 	recoursRepo repository.RecoursRepository,
+	numberingService numbering.Service,
 	logger *zap.Logger,
 ) Service {
 	return &service{
-		recoursRepo: recoursRepo,
-		logger:      logger,
+		recoursRepo:      recoursRepo,
+		numberingService: numberingService,
+		logger:           logger,
 	}
 }
 
 // Create creates a new recours
 func (s *service) Create(ctx context.Context, input *CreateRecoursRequest) (*RecoursResponse, error) {
+	ctx, span := tracing.Start(ctx, "recours.Create")
+	defer span.End()
+
 	if err := s.validateCreateInput(input); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
 	}
 
-	// Générer un numéro de recours unique
-	numeroRecours := generateNumeroRecours()
+	// Réserver le numéro de recours dans la séquence du commissariat du PV
+	resv, err := s.numberingService.Reserve(ctx, &numbering.Request{
+		Type:           numbering.TypeRecours,
+		ProcesVerbalID: input.ProcesVerbalID,
+	})
+	if err != nil {
+		s.logger.Error("Failed to reserve recours number", zap.Error(err))
+		return nil, fmt.Errorf("failed to reserve recours number: %w", err)
+	}
+	defer resv.Rollback()
 
 	repoInput := &repository.CreateRecoursInput{
 		ID:                 uuid.New().String(),
-		NumeroRecours:      numeroRecours,
+		NumeroRecours:      resv.Numero,
 		DateRecours:        time.Now(),
 		TypeRecours:        input.TypeRecours,
 		Motif:              input.Motif,
@@ -69,12 +86,17 @@ func (s *service) Create(ctx context.Context, input *CreateRecoursRequest) (*Rec
 		ProcesVerbalID:     input.ProcesVerbalID,
 	}
 
-	recoursEnt, err := s.recoursRepo.Create(ctx, repoInput)
+	recoursEnt, err := s.recoursRepo.Create(resv.Context(), repoInput)
 	if err != nil {
 		s.logger.Error("Failed to create recours", zap.Error(err))
 		return nil, fmt.Errorf("failed to create recours: %w", err)
 	}
 
+	if err := resv.Commit(); err != nil {
+		s.logger.Error("Failed to create recours", zap.Error(err))
+		return nil, fmt.Errorf("failed to create recours: %w", err)
+	}
+
 	// Recharger avec les relations
 	recoursEnt, err = s.recoursRepo.GetByID(ctx, recoursEnt.ID.String())
 	if err != nil {
@@ -87,6 +109,9 @@ func (s *service) Create(ctx context.Context, input *CreateRecoursRequest) (*Rec
 
 // GetByID gets recours by ID
 func (s *service) GetByID(ctx context.Context, id string) (*RecoursResponse, error) {
+	ctx, span := tracing.Start(ctx, "recours.GetByID")
+	defer span.End()
+
 	recoursEnt, err := s.recoursRepo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -97,6 +122,9 @@ func (s *service) GetByID(ctx context.Context, id string) (*RecoursResponse, err
 
 // GetByNumeroRecours gets recours by numero
 func (s *service) GetByNumeroRecours(ctx context.Context, numero string) (*RecoursResponse, error) {
+	ctx, span := tracing.Start(ctx, "recours.GetByNumeroRecours")
+	defer span.End()
+
 	recoursEnt, err := s.recoursRepo.GetByNumeroRecours(ctx, numero)
 	if err != nil {
 		return nil, err
@@ -106,32 +134,41 @@ func (s *service) GetByNumeroRecours(ctx context.Context, numero string) (*Recou
 }
 
 // List gets recours with filters
-func (s *service) List(ctx context.Context, input *ListRecoursRequest) (*ListRecoursResponse, error) {
+func (s *service) List(ctx context.Context, input *ListRecoursRequest) ([]*RecoursResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "recours.List")
+	defer span.End()
+
 	filters := s.buildFilters(input)
 
 	recoursEnt, err := s.recoursRepo.List(ctx, filters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	recoursEnt, page, err := pagination.Trim(input.Page, recoursEnt)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	total, err := s.recoursRepo.Count(ctx, filters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	page.SetTotal(total)
 
 	recoursList := make([]*RecoursResponse, len(recoursEnt))
 	for i, r := range recoursEnt {
 		recoursList[i] = s.entityToResponse(r)
 	}
 
-	return &ListRecoursResponse{
-		Recours: recoursList,
-		Total:   total,
-	}, nil
+	return recoursList, page, nil
 }
 
 // Update updates recours
 func (s *service) Update(ctx context.Context, id string, input *UpdateRecoursRequest) (*RecoursResponse, error) {
+	ctx, span := tracing.Start(ctx, "recours.Update")
+	defer span.End()
+
 	// Vérifier que le recours existe et peut être modifié
 	rec, err := s.recoursRepo.GetByID(ctx, id)
 	if err != nil {
@@ -166,6 +203,9 @@ func (s *service) Update(ctx context.Context, id string, input *UpdateRecoursReq
 
 // Delete deletes recours
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "recours.Delete")
+	defer span.End()
+
 	// Vérifier que le recours peut être supprimé
 	rec, err := s.recoursRepo.GetByID(ctx, id)
 	if err != nil {
@@ -181,6 +221,9 @@ func (s *service) Delete(ctx context.Context, id string) error {
 
 // GetByProcesVerbal gets recours by PV ID
 func (s *service) GetByProcesVerbal(ctx context.Context, pvID string) (*ListRecoursResponse, error) {
+	ctx, span := tracing.Start(ctx, "recours.GetByProcesVerbal")
+	defer span.End()
+
 	recoursEnt, err := s.recoursRepo.GetByProcesVerbal(ctx, pvID)
 	if err != nil {
 		return nil, err
@@ -199,6 +242,9 @@ func (s *service) GetByProcesVerbal(ctx context.Context, pvID string) (*ListReco
 
 // Traiter processes a recours
 func (s *service) Traiter(ctx context.Context, id string, input *TraiterRecoursRequest, userID string) (*RecoursResponse, error) {
+	ctx, span := tracing.Start(ctx, "recours.Traiter")
+	defer span.End()
+
 	// Vérifier que le recours existe et peut être traité
 	rec, err := s.recoursRepo.GetByID(ctx, id)
 	if err != nil {
@@ -253,6 +299,9 @@ func (s *service) Traiter(ctx context.Context, id string, input *TraiterRecoursR
 
 // Assigner assigns a recours to an agent
 func (s *service) Assigner(ctx context.Context, id string, input *AssignerRecoursRequest) (*RecoursResponse, error) {
+	ctx, span := tracing.Start(ctx, "recours.Assigner")
+	defer span.End()
+
 	// Vérifier que le recours existe
 	rec, err := s.recoursRepo.GetByID(ctx, id)
 	if err != nil {
@@ -285,6 +334,9 @@ func (s *service) Assigner(ctx context.Context, id string, input *AssignerRecour
 
 // Abandonner abandons a recours
 func (s *service) Abandonner(ctx context.Context, id string, input *AbandonnerRecoursRequest) (*RecoursResponse, error) {
+	ctx, span := tracing.Start(ctx, "recours.Abandonner")
+	defer span.End()
+
 	// Vérifier que le recours existe
 	rec, err := s.recoursRepo.GetByID(ctx, id)
 	if err != nil {
@@ -319,6 +371,9 @@ func (s *service) Abandonner(ctx context.Context, id string, input *AbandonnerRe
 
 // GetEnCours gets recours in progress
 func (s *service) GetEnCours(ctx context.Context) (*ListRecoursResponse, error) {
+	ctx, span := tracing.Start(ctx, "recours.GetEnCours")
+	defer span.End()
+
 	statut := "EN_COURS"
 	filters := &repository.RecoursFilters{
 		Statut: &statut,
@@ -355,6 +410,9 @@ func (s *service) GetEnCours(ctx context.Context) (*ListRecoursResponse, error)
 
 // GetStatistics gets statistics for recours
 func (s *service) GetStatistics(ctx context.Context, input *ListRecoursRequest) (*RecoursStatisticsResponse, error) {
+	ctx, span := tracing.Start(ctx, "recours.GetStatistics")
+	defer span.End()
+
 	filters := s.buildFilters(input)
 
 	stats, err := s.recoursRepo.GetStatistics(ctx, filters)
@@ -414,6 +472,7 @@ func (s *service) buildFilters(input *ListRecoursRequest) *repository.RecoursFil
 		DateFin:        input.DateFin,
 		Limit:          input.Limit,
 		Offset:         input.Offset,
+		Page:           input.Page,
 	}
 }
 
@@ -479,6 +538,9 @@ func (s *service) entityToResponse(recoursEnt *ent.Recours) *RecoursResponse {
 
 // GetEtapes returns the workflow steps for a recours
 func (s *service) GetEtapes(ctx context.Context, id string) ([]*EtapeRecoursResponse, error) {
+	ctx, span := tracing.Start(ctx, "recours.GetEtapes")
+	defer span.End()
+
 	// Vérifier que le recours existe
 	rec, err := s.recoursRepo.GetByID(ctx, id)
 	if err != nil {
@@ -606,9 +668,3 @@ func (s *service) buildEtapesForRecours(rec *ent.Recours) []*EtapeRecoursRespons
 
 	return etapes
 }
-
-// generateNumeroRecours generates a unique recours number
-func generateNumeroRecours() string {
-	now := time.Now()
-	return fmt.Sprintf("REC%s%06d", now.Format("20060102"), now.Nanosecond()/1000)
-}
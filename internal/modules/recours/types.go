@@ -2,6 +2,9 @@ package recours
 
 import (
 	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 )
 
 // CreateRecoursRequest represents request to create a recours
@@ -53,6 +56,32 @@ type ListRecoursRequest struct {
 	DateFin        *time.Time `json:"date_fin,omitempty"`
 	Limit          int        `json:"limit,omitempty"`
 	Offset         int        `json:"offset,omitempty"`
+	Page           *pagination.Params `json:"-"`
+}
+
+// listSpec declares the sorts and fields accepted by GET /recours
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"date_recours":   "date_recours",
+		"created_at":     "created_at",
+		"numero_recours": "numero_recours",
+		"statut":         "statut",
+	},
+	DefaultSort: "-date_recours",
+	Fields:      pagination.JSONFields(RecoursResponse{}),
+	Filters: filter.Fields{
+		"numero_recours":      {Column: "numero_recours", Type: filter.String},
+		"type_recours":        {Column: "type_recours", Type: filter.String},
+		"statut":              {Column: "statut", Type: filter.String},
+		"decision":            {Column: "decision", Type: filter.String},
+		"autorite_competente": {Column: "autorite_competente", Type: filter.String},
+		"nouveau_montant":     {Column: "nouveau_montant", Type: filter.Float},
+		"recours_possible":    {Column: "recours_possible", Type: filter.Bool},
+		"date_recours":        {Column: "date_recours", Type: filter.Time},
+		"date_traitement":     {Column: "date_traitement", Type: filter.Time},
+		"date_limite_recours": {Column: "date_limite_recours", Type: filter.Time},
+		"created_at":          {Column: "created_at", Type: filter.Time},
+	},
 }
 
 // RecoursResponse represents a recours in responses
@@ -0,0 +1,138 @@
+package roles
+
+import (
+	"errors"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+	"police-trafic-api-frontend-aligned/internal/shared/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Controller handles role administration requests
+type Controller struct {
+	service Service
+}
+
+// NewController creates a new roles controller
+func NewController(service Service) *Controller {
+	return &Controller{
+		service: service,
+	}
+}
+
+// RegisterRoutes registers role administration routes
+func (ctrl *Controller) RegisterRoutes(e *echo.Group) {
+	admin := rbac.Guard(e).Group("/admin")
+
+	admin.GET("/roles", ctrl.List, rbac.PermReadRoles)
+	admin.GET("/roles/:name", ctrl.GetByName, rbac.PermReadRoles)
+	admin.POST("/roles", ctrl.Create, rbac.PermManageRoles)
+	admin.PUT("/roles/:name", ctrl.Update, rbac.PermManageRoles)
+	admin.DELETE("/roles/:name", ctrl.Delete, rbac.PermManageRoles)
+	admin.GET("/permissions", ctrl.ListPermissions, rbac.PermReadRoles)
+}
+
+// List handles GET /admin/roles
+func (ctrl *Controller) List(c echo.Context) error {
+	page, err := pagination.Parse(c, listSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
+	roles, err := ctrl.service.List(c.Request().Context())
+	if err != nil {
+		return responses.InternalServerError(c, err.Error())
+	}
+
+	// Peu de rôles : la page est découpée en mémoire
+	roles, result := pagination.Slice(page, roles)
+	return responses.Paginated(c, roles, result)
+}
+
+// GetByName handles GET /admin/roles/:name
+func (ctrl *Controller) GetByName(c echo.Context) error {
+	role, err := ctrl.service.GetByName(c.Request().Context(), c.Param("name"))
+	if err != nil {
+		return roleError(c, err)
+	}
+
+	return responses.Success(c, role)
+}
+
+// Create handles POST /admin/roles
+func (ctrl *Controller) Create(c echo.Context) error {
+	var req CreateRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return responses.BadRequest(c, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
+	role, err := ctrl.service.Create(c.Request().Context(), &req)
+	if err != nil {
+		return roleError(c, err)
+	}
+
+	return responses.Created(c, role)
+}
+
+// Update handles PUT /admin/roles/:name
+func (ctrl *Controller) Update(c echo.Context) error {
+	var req UpdateRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return responses.BadRequest(c, "Invalid request body")
+	}
+
+	role, err := ctrl.service.Update(c.Request().Context(), c.Param("name"), &req)
+	if err != nil {
+		return roleError(c, err)
+	}
+
+	return responses.Success(c, role)
+}
+
+// Delete handles DELETE /admin/roles/:name
+func (ctrl *Controller) Delete(c echo.Context) error {
+	if err := ctrl.service.Delete(c.Request().Context(), c.Param("name")); err != nil {
+		return roleError(c, err)
+	}
+
+	return responses.SuccessWithMessage(c, "Role deleted successfully", nil)
+}
+
+// ListPermissions handles GET /admin/permissions
+func (ctrl *Controller) ListPermissions(c echo.Context) error {
+	page, err := pagination.Parse(c, permissionSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
+	permissions, err := ctrl.service.ListPermissions(c.Request().Context())
+	if err != nil {
+		return responses.InternalServerError(c, err.Error())
+	}
+
+	permissions, result := pagination.Slice(page, permissions)
+	return responses.Paginated(c, permissions, result)
+}
+
+// roleError maps the role repository errors to HTTP responses
+func roleError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrRoleNotFound):
+		return responses.NotFound(c, "Role not found")
+	case errors.Is(err, repository.ErrRoleExists):
+		return responses.Conflict(c, "Role already exists")
+	case errors.Is(err, repository.ErrSystemRole), errors.Is(err, repository.ErrRoleInUse):
+		return responses.Conflict(c, err.Error())
+	case errors.Is(err, repository.ErrUnknownPermission), errors.Is(err, ErrInvalidRoleName):
+		return responses.BadRequest(c, err.Error())
+	default:
+		return responses.InternalServerError(c, err.Error())
+	}
+}
@@ -0,0 +1,19 @@
+package roles
+
+import (
+	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+
+	"go.uber.org/fx"
+)
+
+// Module provides role and permission administration endpoints
+var Module = fx.Module("roles",
+	fx.Provide(
+		NewService,
+		fx.Annotate(
+			NewController,
+			fx.As(new(interfaces.Controller)),
+			fx.ResultTags(`group:"controllers"`),
+		),
+	),
+)
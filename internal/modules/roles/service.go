@@ -0,0 +1,180 @@
+package roles
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+
+	"go.uber.org/zap"
+)
+
+// roleNamePattern keeps role names usable as identifiers in tokens and logs
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+// ErrInvalidRoleName is returned by Create for a malformed role name
+var ErrInvalidRoleName = errors.New("role name must start with a lowercase letter and contain only lowercase letters, digits, '-' or '_'")
+
+// Service defines role administration service interface
+type Service interface {
+	List(ctx context.Context) ([]*RoleResponse, error)
+	GetByName(ctx context.Context, name string) (*RoleResponse, error)
+	Create(ctx context.Context, req *CreateRoleRequest) (*RoleResponse, error)
+	Update(ctx context.Context, name string, req *UpdateRoleRequest) (*RoleResponse, error)
+	Delete(ctx context.Context, name string) error
+	ListPermissions(ctx context.Context) ([]*PermissionResponse, error)
+}
+
+type service struct {
+	roleRepo    repository.RoleRepository
+	rbacService rbac.Service
+	logger      *zap.Logger
+}
+
+// NewService creates a new role administration service
+func NewService(roleRepo repository.RoleRepository, rbacService rbac.Service, logger *zap.Logger) Service {
+	return &service{
+		roleRepo:    roleRepo,
+		rbacService: rbacService,
+		logger:      logger,
+	}
+}
+
+// List returns every role with its permissions
+func (s *service) List(ctx context.Context) ([]*RoleResponse, error) {
+	ctx, span := tracing.Start(ctx, "roles.List")
+	defer span.End()
+
+	roles, err := s.roleRepo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*RoleResponse, len(roles))
+	for i, r := range roles {
+		result[i] = toResponse(r)
+	}
+
+	return result, nil
+}
+
+// GetByName returns a role
+func (s *service) GetByName(ctx context.Context, name string) (*RoleResponse, error) {
+	ctx, span := tracing.Start(ctx, "roles.GetByName")
+	defer span.End()
+
+	r, err := s.roleRepo.GetByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	return toResponse(r), nil
+}
+
+// Create creates a custom role
+func (s *service) Create(ctx context.Context, req *CreateRoleRequest) (*RoleResponse, error) {
+	ctx, span := tracing.Start(ctx, "roles.Create")
+	defer span.End()
+
+	if !roleNamePattern.MatchString(req.Name) {
+		return nil, ErrInvalidRoleName
+	}
+
+	r, err := s.roleRepo.Create(ctx, &repository.CreateRoleInput{
+		Name:        req.Name,
+		Libelle:     req.Libelle,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.reload(ctx)
+	s.logger.Info("Role created", zap.String("name", r.Name), zap.Int("permissions", len(req.Permissions)))
+	return toResponse(r), nil
+}
+
+// Update updates a role, built-in ones included
+func (s *service) Update(ctx context.Context, name string, req *UpdateRoleRequest) (*RoleResponse, error) {
+	ctx, span := tracing.Start(ctx, "roles.Update")
+	defer span.End()
+
+	r, err := s.roleRepo.Update(ctx, name, &repository.UpdateRoleInput{
+		Libelle:     req.Libelle,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.reload(ctx)
+	s.logger.Info("Role updated", zap.String("name", name))
+	return toResponse(r), nil
+}
+
+// Delete deletes a custom role
+func (s *service) Delete(ctx context.Context, name string) error {
+	ctx, span := tracing.Start(ctx, "roles.Delete")
+	defer span.End()
+
+	if err := s.roleRepo.Delete(ctx, name); err != nil {
+		return err
+	}
+
+	s.reload(ctx)
+	s.logger.Info("Role deleted", zap.String("name", name))
+	return nil
+}
+
+// ListPermissions returns the permission catalog
+func (s *service) ListPermissions(ctx context.Context) ([]*PermissionResponse, error) {
+	ctx, span := tracing.Start(ctx, "roles.ListPermissions")
+	defer span.End()
+
+	permissions, err := s.roleRepo.ListPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*PermissionResponse, len(permissions))
+	for i, p := range permissions {
+		result[i] = &PermissionResponse{
+			Code:     p.Code,
+			Resource: p.Resource,
+		}
+	}
+
+	return result, nil
+}
+
+// reload applies the change on this instance right away; the others pick it
+// up at their next periodic reload
+func (s *service) reload(ctx context.Context) {
+	if err := s.rbacService.Reload(ctx); err != nil {
+		s.logger.Warn("Failed to reload RBAC policy", zap.Error(err))
+	}
+}
+
+func toResponse(r *ent.Role) *RoleResponse {
+	permissions := make([]string, len(r.Edges.Permissions))
+	for i, p := range r.Edges.Permissions {
+		permissions[i] = p.Code
+	}
+
+	return &RoleResponse{
+		ID:          r.ID.String(),
+		Name:        r.Name,
+		Libelle:     r.Libelle,
+		Description: r.Description,
+		System:      r.System,
+		Permissions: permissions,
+		CreatedAt:   r.CreatedAt,
+		UpdatedAt:   r.UpdatedAt,
+	}
+}
@@ -0,0 +1,61 @@
+package roles
+
+import (
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+)
+
+// CreateRoleRequest represents the body of POST /admin/roles
+type CreateRoleRequest struct {
+	Name        string   `json:"name" validate:"required,max=50"`
+	Libelle     string   `json:"libelle,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Permissions []string `json:"permissions"`
+}
+
+// UpdateRoleRequest represents the body of PUT /admin/roles/:name.
+// Permissions, when present, replace the role's permissions.
+type UpdateRoleRequest struct {
+	Libelle     *string  `json:"libelle,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+}
+
+// RoleResponse represents a role and its permissions
+type RoleResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Libelle     string    `json:"libelle,omitempty"`
+	Description string    `json:"description,omitempty"`
+	System      bool      `json:"system"`
+	Permissions []string  `json:"permissions"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// PermissionResponse represents an entry of the permission catalog
+type PermissionResponse struct {
+	Code     string `json:"code"`
+	Resource string `json:"resource"`
+}
+
+// listSpec declares the sorts and fields accepted by GET /admin/roles
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"name":      "name",
+		"createdAt": "createdAt",
+	},
+	DefaultSort: "name",
+	Fields:      pagination.JSONFields(RoleResponse{}),
+}
+
+// permissionSpec declares the sorts and fields accepted by GET /admin/permissions
+var permissionSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"code":     "code",
+		"resource": "resource",
+	},
+	DefaultSort: "code",
+	Fields:      pagination.JSONFields(PermissionResponse{}),
+}
@@ -0,0 +1,123 @@
+package search
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"police-trafic-api-frontend-aligned/internal/core/middleware"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/fulltext"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/responses"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+// Limits of the results per type
+const (
+	DefaultLimit   = 5
+	MaxLimit       = 20
+	MaxQueryLength = 100
+)
+
+// readPermissions is the permission needed to see the results of each type,
+// in the order types are searched by default
+var readPermissions = []struct {
+	t    Type
+	perm rbac.Permission
+}{
+	{TypeVehicule, rbac.PermReadVehicules},
+	{TypeConducteur, rbac.PermReadConducteurs},
+	{TypePV, rbac.PermReadPV},
+	{TypePlainte, rbac.PermReadPlaintes},
+	{TypeConvocation, rbac.PermReadConvocations},
+	{TypeAlerte, rbac.PermReadAlertes},
+	{TypeObjetPerdu, rbac.PermReadObjetsPerdus},
+	{TypeObjetRetrouve, rbac.PermReadObjetsRetrouves},
+}
+
+func knownTypes() string {
+	names := make([]string, len(readPermissions))
+	for i, rp := range readPermissions {
+		names[i] = string(rp.t)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Controller handles global search HTTP requests
+type Controller struct {
+	service Service
+	logger  *zap.Logger
+}
+
+// NewController creates a new search controller
+func NewController(service Service, logger *zap.Logger) *Controller {
+	return &Controller{
+		service: service,
+		logger:  logger,
+	}
+}
+
+// RegisterRoutes registers search routes on the group
+func (ctrl *Controller) RegisterRoutes(e *echo.Group) {
+	rbac.Guard(e).GET("/search", ctrl.Search, rbac.PermSearch)
+}
+
+// Search handles GET /search?q=AB-123-CD&types=vehicule,conducteur&limit=5
+func (ctrl *Controller) Search(c echo.Context) error {
+	query := strings.TrimSpace(c.QueryParam("q"))
+	if len(fulltext.Compact(query)) < fulltext.MinLength {
+		return responses.BadRequest(c, "q must contain at least "+strconv.Itoa(fulltext.MinLength)+" letters or digits")
+	}
+	if utf8.RuneCountInString(query) > MaxQueryLength {
+		return responses.BadRequest(c, "q must not exceed "+strconv.Itoa(MaxQueryLength)+" characters")
+	}
+
+	limit := DefaultLimit
+	if value := c.QueryParam("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			return responses.BadRequest(c, "limit must be a positive integer")
+		}
+		limit = min(n, MaxLimit)
+	}
+
+	user, err := middleware.GetUserFromContext(c)
+	if err != nil {
+		return responses.Unauthorized(c, "Authentication required")
+	}
+
+	// Seuls les types lisibles par l'appelant sont cherchés
+	requested := make(map[Type]bool)
+	for _, name := range strings.Split(c.QueryParam("types"), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			requested[Type(name)] = true
+		}
+	}
+	var types []Type
+	for _, rp := range readPermissions {
+		if len(requested) > 0 && !requested[rp.t] {
+			continue
+		}
+		delete(requested, rp.t)
+		if user.HasPermission(rp.perm) {
+			types = append(types, rp.t)
+		}
+	}
+	if len(requested) > 0 {
+		return responses.BadRequest(c, "types must be among: "+knownTypes())
+	}
+
+	result, err := ctrl.service.Search(c.Request().Context(), &SearchRequest{
+		Query: query,
+		Types: types,
+		Limit: limit,
+	})
+	if err != nil {
+		ctrl.logger.Error("Failed to search", zap.String("query", query), zap.Error(err))
+		return responses.InternalServerError(c, "Failed to search")
+	}
+
+	return responses.Success(c, result)
+}
@@ -0,0 +1,31 @@
+package search
+
+import (
+	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Module provides the global search module dependencies
+var Module = fx.Module("search",
+	fx.Provide(
+		NewServiceProvider,
+		fx.Annotate(
+			NewControllerProvider,
+			fx.As(new(interfaces.Controller)),
+			fx.ResultTags(`group:"controllers"`),
+		),
+	),
+)
+
+// NewServiceProvider creates a new search service for DI
+func NewServiceProvider(searchRepo repository.SearchRepository, logger *zap.Logger) Service {
+	return NewService(searchRepo, logger)
+}
+
+// NewControllerProvider creates a new search controller for DI
+func NewControllerProvider(service Service, logger *zap.Logger) interfaces.Controller {
+	return NewController(service, logger)
+}
@@ -0,0 +1,301 @@
+package search
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/fulltext"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+
+	"go.uber.org/zap"
+)
+
+// Service defines the global search service interface
+type Service interface {
+	Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error)
+}
+
+type service struct {
+	searchRepo repository.SearchRepository
+	logger     *zap.Logger
+}
+
+// NewService creates a new search service
+func NewService(searchRepo repository.SearchRepository, logger *zap.Logger) Service {
+	return &service{
+		searchRepo: searchRepo,
+		logger:     logger,
+	}
+}
+
+// field is a searched value of a result, scored against the query
+type field struct {
+	name  string
+	value string
+}
+
+// searcher returns the results of one type
+type searcher func(ctx context.Context, q *fulltext.Query) ([]Result, error)
+
+// Search searches the requested types and merges their results, ranked on
+// their best matching field
+func (s *service) Search(ctx context.Context, req *SearchRequest) (*SearchResponse, error) {
+	ctx, span := tracing.Start(ctx, "search.Search")
+	defer span.End()
+
+	q := fulltext.NewQuery(req.Query, req.Limit)
+	searchers := map[Type]searcher{
+		TypeVehicule:      s.vehicules,
+		TypeConducteur:    s.conducteurs,
+		TypePV:            s.procesVerbaux,
+		TypePlainte:       s.plaintes,
+		TypeConvocation:   s.convocations,
+		TypeAlerte:        s.alertes,
+		TypeObjetPerdu:    s.objetsPerdus,
+		TypeObjetRetrouve: s.objetsRetrouves,
+	}
+
+	response := &SearchResponse{
+		Query:   req.Query,
+		Results: []Result{},
+		Counts:  make(map[Type]int, len(req.Types)),
+	}
+	for _, t := range req.Types {
+		results, err := searchers[t](ctx, q)
+		if err != nil {
+			return nil, err
+		}
+		for i := range results {
+			results[i].Type = t
+			results[i].Score, results[i].Matched = score(req.Query, results[i].fields)
+		}
+		response.Results = append(response.Results, results...)
+		response.Counts[t] = len(results)
+	}
+
+	// Classement commun : le score ne dépend que du texte, pas de la table
+	sort.SliceStable(response.Results, func(i, j int) bool {
+		return response.Results[i].Score > response.Results[j].Score
+	})
+
+	s.logger.Debug("Global search",
+		zap.String("query", q.Text),
+		zap.Int("results", len(response.Results)),
+	)
+	return response, nil
+}
+
+// score returns the best score of the fields of a result and the name of the
+// field reaching it
+func score(query string, fields []field) (float64, string) {
+	best, matched := 0.0, ""
+	for _, f := range fields {
+		if sc := fulltext.Score(query, f.value); sc > best {
+			best, matched = sc, f.name
+		}
+	}
+	return float64(int(best*1000+0.5)) / 1000, matched
+}
+
+// join joins the non empty parts of a title
+func join(sep string, parts ...string) string {
+	kept := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			kept = append(kept, part)
+		}
+	}
+	return strings.Join(kept, sep)
+}
+
+// name joins a first name and a last name
+func name(first, last string) string {
+	return join(" ", first, last)
+}
+
+func (s *service) vehicules(ctx context.Context, q *fulltext.Query) ([]Result, error) {
+	rows, err := s.searchRepo.Vehicules(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Result, len(rows))
+	for i, v := range rows {
+		results[i] = Result{
+			ID:       v.ID.String(),
+			Title:    v.Immatriculation,
+			Subtitle: join(" - ", join(" ", v.Marque, v.Modele), name(v.ProprietairePrenom, v.ProprietaireNom)),
+			Link:     "/vehicules/" + v.ID.String(),
+			fields: []field{
+				{"immatriculation", v.Immatriculation},
+				{"numero_chassis", v.NumeroChassis},
+				{"proprietaire", name(v.ProprietairePrenom, v.ProprietaireNom)},
+				{"proprietaire_nom", v.ProprietaireNom},
+				{"proprietaire_prenom", v.ProprietairePrenom},
+				{"marque", join(" ", v.Marque, v.Modele)},
+			},
+		}
+	}
+	return results, nil
+}
+
+func (s *service) conducteurs(ctx context.Context, q *fulltext.Query) ([]Result, error) {
+	rows, err := s.searchRepo.Conducteurs(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Result, len(rows))
+	for i, c := range rows {
+		results[i] = Result{
+			ID:       c.ID.String(),
+			Title:    name(c.Prenom, c.Nom),
+			Subtitle: c.NumeroPermis,
+			Link:     "/conducteurs/" + c.ID.String(),
+			fields: []field{
+				{"nom", name(c.Prenom, c.Nom)},
+				{"nom", name(c.Nom, c.Prenom)},
+				{"nom", c.Nom},
+				{"prenom", c.Prenom},
+				{"numero_permis", c.NumeroPermis},
+				{"telephone", c.Telephone},
+			},
+		}
+	}
+	return results, nil
+}
+
+func (s *service) procesVerbaux(ctx context.Context, q *fulltext.Query) ([]Result, error) {
+	rows, err := s.searchRepo.ProcesVerbaux(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Result, len(rows))
+	for i, pv := range rows {
+		results[i] = Result{
+			ID:       pv.ID.String(),
+			Title:    pv.NumeroPv,
+			Subtitle: string(pv.Statut),
+			Link:     "/pv/" + pv.ID.String(),
+			fields:   []field{{"numero_pv", pv.NumeroPv}},
+		}
+	}
+	return results, nil
+}
+
+func (s *service) plaintes(ctx context.Context, q *fulltext.Query) ([]Result, error) {
+	rows, err := s.searchRepo.Plaintes(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Result, len(rows))
+	for i, p := range rows {
+		results[i] = Result{
+			ID:       p.ID.String(),
+			Title:    p.Numero,
+			Subtitle: join(" - ", p.TypePlainte, name(p.PlaignantPrenom, p.PlaignantNom)),
+			Link:     "/plaintes/" + p.ID.String(),
+			fields: []field{
+				{"numero", p.Numero},
+				{"plaignant", name(p.PlaignantPrenom, p.PlaignantNom)},
+				{"plaignant", name(p.PlaignantNom, p.PlaignantPrenom)},
+				{"plaignant_nom", p.PlaignantNom},
+				{"plaignant_prenom", p.PlaignantPrenom},
+				{"plaignant_telephone", p.PlaignantTelephone},
+			},
+		}
+	}
+	return results, nil
+}
+
+func (s *service) convocations(ctx context.Context, q *fulltext.Query) ([]Result, error) {
+	rows, err := s.searchRepo.Convocations(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Result, len(rows))
+	for i, c := range rows {
+		results[i] = Result{
+			ID:       c.ID.String(),
+			Title:    c.Numero,
+			Subtitle: join(" - ", c.TypeConvocation, name(c.ConvoquePrenom, c.ConvoqueNom)),
+			Link:     "/convocations/" + c.ID.String(),
+			fields: []field{
+				{"numero", c.Numero},
+				{"convoque", name(c.ConvoquePrenom, c.ConvoqueNom)},
+				{"convoque", name(c.ConvoqueNom, c.ConvoquePrenom)},
+				{"convoque_nom", c.ConvoqueNom},
+				{"convoque_prenom", c.ConvoquePrenom},
+				{"convoque_telephone", c.ConvoqueTelephone},
+			},
+		}
+	}
+	return results, nil
+}
+
+func (s *service) alertes(ctx context.Context, q *fulltext.Query) ([]Result, error) {
+	rows, err := s.searchRepo.Alertes(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Result, len(rows))
+	for i, a := range rows {
+		results[i] = Result{
+			ID:       a.ID.String(),
+			Title:    a.Titre,
+			Subtitle: join(" - ", a.Numero, string(a.Niveau)),
+			Link:     "/alertes/" + a.ID.String(),
+			fields: []field{
+				{"numero", a.Numero},
+				{"titre", a.Titre},
+				{"description", a.Description},
+			},
+		}
+	}
+	return results, nil
+}
+
+func (s *service) objetsPerdus(ctx context.Context, q *fulltext.Query) ([]Result, error) {
+	rows, err := s.searchRepo.ObjetsPerdus(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Result, len(rows))
+	for i, o := range rows {
+		results[i] = Result{
+			ID:       o.ID.String(),
+			Title:    join(" - ", o.TypeObjet, o.Numero),
+			Subtitle: o.Description,
+			Link:     "/objets-perdus/" + o.ID.String(),
+			fields: []field{
+				{"numero", o.Numero},
+				{"type_objet", o.TypeObjet},
+				{"description", o.Description},
+			},
+		}
+	}
+	return results, nil
+}
+
+func (s *service) objetsRetrouves(ctx context.Context, q *fulltext.Query) ([]Result, error) {
+	rows, err := s.searchRepo.ObjetsRetrouves(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Result, len(rows))
+	for i, o := range rows {
+		results[i] = Result{
+			ID:       o.ID.String(),
+			Title:    join(" - ", o.TypeObjet, o.Numero),
+			Subtitle: join(" - ", o.Description, o.LieuTrouvaille),
+			Link:     "/objets-retrouves/" + o.ID.String(),
+			fields: []field{
+				{"numero", o.Numero},
+				{"type_objet", o.TypeObjet},
+				{"description", o.Description},
+				{"lieu_trouvaille", o.LieuTrouvaille},
+			},
+		}
+	}
+	return results, nil
+}
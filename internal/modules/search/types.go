@@ -0,0 +1,43 @@
+package search
+
+// Type is the type of a search result
+type Type string
+
+// Searched types
+const (
+	TypeVehicule      Type = "vehicule"
+	TypeConducteur    Type = "conducteur"
+	TypePV            Type = "pv"
+	TypePlainte       Type = "plainte"
+	TypeConvocation   Type = "convocation"
+	TypeAlerte        Type = "alerte"
+	TypeObjetPerdu    Type = "objet_perdu"
+	TypeObjetRetrouve Type = "objet_retrouve"
+)
+
+// SearchRequest represents a global search
+type SearchRequest struct {
+	Query string
+	Types []Type // types the caller asked for and can read
+	Limit int    // results per type
+}
+
+// Result represents a search result
+type Result struct {
+	Type     Type    `json:"type"`
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	Subtitle string  `json:"subtitle,omitempty"`
+	Matched  string  `json:"matched,omitempty"` // field matching the query best
+	Score    float64 `json:"score"`
+	Link     string  `json:"link"`
+
+	fields []field
+}
+
+// SearchResponse represents the results of a global search, best first
+type SearchResponse struct {
+	Query   string       `json:"query"`
+	Results []Result     `json:"results"`
+	Counts  map[Type]int `json:"counts"`
+}
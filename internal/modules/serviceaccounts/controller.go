@@ -0,0 +1,197 @@
+package serviceaccounts
+
+import (
+	"errors"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+	"police-trafic-api-frontend-aligned/internal/shared/responses"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Controller handles service account administration requests
+type Controller struct {
+	service Service
+}
+
+// NewController creates a new service accounts controller
+func NewController(service Service) *Controller {
+	return &Controller{
+		service: service,
+	}
+}
+
+// RegisterRoutes registers service account administration routes
+func (ctrl *Controller) RegisterRoutes(e *echo.Group) {
+	accounts := rbac.Guard(e).Group("/admin/service-accounts")
+
+	accounts.GET("", ctrl.List, rbac.PermManageSystem)
+	accounts.POST("", ctrl.Create, rbac.PermManageSystem)
+	accounts.GET("/:id", ctrl.GetByID, rbac.PermManageSystem)
+	accounts.PUT("/:id", ctrl.Update, rbac.PermManageSystem)
+	accounts.DELETE("/:id", ctrl.Delete, rbac.PermManageSystem)
+	accounts.GET("/:id/keys", ctrl.ListKeys, rbac.PermManageSystem)
+	accounts.POST("/:id/keys", ctrl.CreateKey, rbac.PermManageSystem)
+	accounts.POST("/:id/keys/rotate", ctrl.RotateKeys, rbac.PermManageSystem)
+	accounts.DELETE("/:id/keys/:keyId", ctrl.RevokeKey, rbac.PermManageSystem)
+	accounts.GET("/:id/usage", ctrl.ListUsage, rbac.PermManageSystem)
+}
+
+// List handles GET /admin/service-accounts
+func (ctrl *Controller) List(c echo.Context) error {
+	page, err := pagination.Parse(c, listSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
+	accounts, err := ctrl.service.List(c.Request().Context())
+	if err != nil {
+		return responses.InternalServerError(c, err.Error())
+	}
+
+	// Peu de comptes de service : la page est découpée en mémoire
+	accounts, result := pagination.Slice(page, accounts)
+	return responses.Paginated(c, accounts, result)
+}
+
+// GetByID handles GET /admin/service-accounts/:id
+func (ctrl *Controller) GetByID(c echo.Context) error {
+	account, err := ctrl.service.GetByID(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return serviceAccountError(c, err)
+	}
+
+	return responses.Success(c, account)
+}
+
+// Create handles POST /admin/service-accounts
+func (ctrl *Controller) Create(c echo.Context) error {
+	var req CreateServiceAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return responses.BadRequest(c, "Invalid request body")
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
+	createdBy, _ := c.Get("matricule").(string)
+	account, err := ctrl.service.Create(c.Request().Context(), &req, createdBy)
+	if err != nil {
+		return serviceAccountError(c, err)
+	}
+
+	return responses.Created(c, account)
+}
+
+// Update handles PUT /admin/service-accounts/:id
+func (ctrl *Controller) Update(c echo.Context) error {
+	var req UpdateServiceAccountRequest
+	if err := c.Bind(&req); err != nil {
+		return responses.BadRequest(c, "Invalid request body")
+	}
+
+	account, err := ctrl.service.Update(c.Request().Context(), c.Param("id"), &req)
+	if err != nil {
+		return serviceAccountError(c, err)
+	}
+
+	return responses.Success(c, account)
+}
+
+// Delete handles DELETE /admin/service-accounts/:id
+func (ctrl *Controller) Delete(c echo.Context) error {
+	if err := ctrl.service.Delete(c.Request().Context(), c.Param("id")); err != nil {
+		return serviceAccountError(c, err)
+	}
+
+	return responses.SuccessWithMessage(c, "Service account deleted successfully", nil)
+}
+
+// ListKeys handles GET /admin/service-accounts/:id/keys
+func (ctrl *Controller) ListKeys(c echo.Context) error {
+	page, err := pagination.Parse(c, keySpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
+	keys, err := ctrl.service.ListKeys(c.Request().Context(), c.Param("id"))
+	if err != nil {
+		return serviceAccountError(c, err)
+	}
+
+	keys, result := pagination.Slice(page, keys)
+	return responses.Paginated(c, keys, result)
+}
+
+// CreateKey handles POST /admin/service-accounts/:id/keys.
+// The key is returned in clear only in this response.
+func (ctrl *Controller) CreateKey(c echo.Context) error {
+	var req CreateKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return responses.BadRequest(c, "Invalid request body")
+	}
+
+	createdBy, _ := c.Get("matricule").(string)
+	key, err := ctrl.service.CreateKey(c.Request().Context(), c.Param("id"), &req, createdBy)
+	if err != nil {
+		return serviceAccountError(c, err)
+	}
+
+	return responses.Created(c, key)
+}
+
+// RotateKeys handles POST /admin/service-accounts/:id/keys/rotate
+func (ctrl *Controller) RotateKeys(c echo.Context) error {
+	createdBy, _ := c.Get("matricule").(string)
+	key, err := ctrl.service.RotateKeys(c.Request().Context(), c.Param("id"), createdBy)
+	if err != nil {
+		return serviceAccountError(c, err)
+	}
+
+	return responses.Created(c, key)
+}
+
+// RevokeKey handles DELETE /admin/service-accounts/:id/keys/:keyId
+func (ctrl *Controller) RevokeKey(c echo.Context) error {
+	if err := ctrl.service.RevokeKey(c.Request().Context(), c.Param("id"), c.Param("keyId")); err != nil {
+		return serviceAccountError(c, err)
+	}
+
+	return responses.SuccessWithMessage(c, "API key revoked successfully", nil)
+}
+
+// ListUsage handles GET /admin/service-accounts/:id/usage
+func (ctrl *Controller) ListUsage(c echo.Context) error {
+	page, err := pagination.Parse(c, usageSpec)
+	if err != nil {
+		return responses.BadRequest(c, err.Error())
+	}
+
+	usage, result, err := ctrl.service.ListUsage(c.Request().Context(), c.Param("id"), page)
+	if err != nil {
+		return serviceAccountError(c, err)
+	}
+
+	return responses.Paginated(c, usage, result)
+}
+
+// serviceAccountError maps the service account errors to HTTP responses
+func serviceAccountError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, repository.ErrServiceAccountNotFound):
+		return responses.NotFound(c, "Service account not found")
+	case errors.Is(err, repository.ErrAPIKeyNotFound):
+		return responses.NotFound(c, "API key not found")
+	case errors.Is(err, repository.ErrServiceAccountExists):
+		return responses.Conflict(c, "Service account already exists")
+	case errors.Is(err, repository.ErrUnknownPermission),
+		errors.Is(err, ErrInvalidName),
+		errors.Is(err, ErrInvalidRequest):
+		return responses.BadRequest(c, err.Error())
+	default:
+		return responses.InternalServerError(c, err.Error())
+	}
+}
@@ -0,0 +1,19 @@
+package serviceaccounts
+
+import (
+	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+
+	"go.uber.org/fx"
+)
+
+// Module provides service account and API key administration endpoints
+var Module = fx.Module("serviceaccounts",
+	fx.Provide(
+		NewService,
+		fx.Annotate(
+			NewController,
+			fx.As(new(interfaces.Controller)),
+			fx.ResultTags(`group:"controllers"`),
+		),
+	),
+)
@@ -0,0 +1,432 @@
+package serviceaccounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"police-trafic-api-frontend-aligned/ent"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/apikeys"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Statuts d'une clé d'API
+const (
+	KeyStatusActive  = "ACTIVE"
+	KeyStatusExpired = "EXPIRED"
+	KeyStatusRevoked = "REVOKED"
+)
+
+// namePattern keeps service account names usable in logs and audit entries
+var namePattern = regexp.MustCompile(`^[a-z][a-z0-9_-]*$`)
+
+var (
+	// ErrInvalidName is returned by Create for a malformed name
+	ErrInvalidName = errors.New("service account name must start with a lowercase letter and contain only lowercase letters, digits, '-' or '_'")
+	// ErrInvalidRequest is returned for invalid permissions, addresses or dates
+	ErrInvalidRequest = errors.New("invalid service account request")
+)
+
+// Service defines service account administration service interface
+type Service interface {
+	List(ctx context.Context) ([]*ServiceAccountResponse, error)
+	GetByID(ctx context.Context, id string) (*ServiceAccountResponse, error)
+	Create(ctx context.Context, req *CreateServiceAccountRequest, createdBy string) (*ServiceAccountResponse, error)
+	Update(ctx context.Context, id string, req *UpdateServiceAccountRequest) (*ServiceAccountResponse, error)
+	Delete(ctx context.Context, id string) error
+
+	ListKeys(ctx context.Context, id string) ([]*APIKeyResponse, error)
+	CreateKey(ctx context.Context, id string, req *CreateKeyRequest, createdBy string) (*APIKeyResponse, error)
+	// RotateKeys creates a new key; the previous ones expire after the grace period
+	RotateKeys(ctx context.Context, id, createdBy string) (*APIKeyResponse, error)
+	RevokeKey(ctx context.Context, id, keyID string) error
+
+	ListUsage(ctx context.Context, id string, page *pagination.Params) ([]*UsageResponse, *pagination.Page, error)
+}
+
+type service struct {
+	repo          repository.ServiceAccountRepository
+	apiKeyService apikeys.Service
+	logger        *zap.Logger
+}
+
+// NewService creates a new service account administration service
+func NewService(repo repository.ServiceAccountRepository, apiKeyService apikeys.Service, logger *zap.Logger) Service {
+	return &service{
+		repo:          repo,
+		apiKeyService: apiKeyService,
+		logger:        logger,
+	}
+}
+
+// List returns every service account
+func (s *service) List(ctx context.Context) ([]*ServiceAccountResponse, error) {
+	ctx, span := tracing.Start(ctx, "serviceaccounts.List")
+	defer span.End()
+
+	accounts, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*ServiceAccountResponse, len(accounts))
+	for i, sa := range accounts {
+		result[i] = toResponse(sa)
+	}
+
+	return result, nil
+}
+
+// GetByID returns a service account
+func (s *service) GetByID(ctx context.Context, id string) (*ServiceAccountResponse, error) {
+	ctx, span := tracing.Start(ctx, "serviceaccounts.GetByID")
+	defer span.End()
+
+	accountID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	sa, err := s.repo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toResponse(sa), nil
+}
+
+// Create creates a service account, without key
+func (s *service) Create(ctx context.Context, req *CreateServiceAccountRequest, createdBy string) (*ServiceAccountResponse, error) {
+	ctx, span := tracing.Start(ctx, "serviceaccounts.Create")
+	defer span.End()
+
+	if !namePattern.MatchString(req.Name) {
+		return nil, ErrInvalidName
+	}
+	if err := validatePermissions(req.Permissions); err != nil {
+		return nil, err
+	}
+	if err := validateAllowedIPs(req.AllowedIPs); err != nil {
+		return nil, err
+	}
+	commissariatID, err := parseOptionalID(req.CommissariatID)
+	if err != nil {
+		return nil, err
+	}
+
+	input := &repository.CreateServiceAccountInput{
+		Name:           req.Name,
+		Description:    req.Description,
+		Permissions:    nonNil(req.Permissions),
+		AllowedIPs:     nonNil(req.AllowedIPs),
+		CommissariatID: commissariatID,
+		CreatedBy:      createdBy,
+	}
+
+	sa, err := s.repo.Create(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Service account created",
+		zap.String("name", sa.Name),
+		zap.Strings("permissions", sa.Permissions),
+		zap.String("created_by", createdBy),
+	)
+	return toResponse(sa), nil
+}
+
+// Update updates a service account. Its keys are kept.
+func (s *service) Update(ctx context.Context, id string, req *UpdateServiceAccountRequest) (*ServiceAccountResponse, error) {
+	ctx, span := tracing.Start(ctx, "serviceaccounts.Update")
+	defer span.End()
+
+	accountID, err := parseID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validatePermissions(req.Permissions); err != nil {
+		return nil, err
+	}
+	if err := validateAllowedIPs(req.AllowedIPs); err != nil {
+		return nil, err
+	}
+
+	input := &repository.UpdateServiceAccountInput{
+		Description: req.Description,
+		Permissions: req.Permissions,
+		AllowedIPs:  req.AllowedIPs,
+		Active:      req.Active,
+	}
+	if req.CommissariatID != nil {
+		if *req.CommissariatID == "" {
+			input.ClearScope = true
+		} else if input.CommissariatID, err = parseOptionalID(req.CommissariatID); err != nil {
+			return nil, err
+		}
+	}
+
+	sa, err := s.repo.Update(ctx, accountID, input)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logger.Info("Service account updated", zap.String("name", sa.Name))
+	return toResponse(sa), nil
+}
+
+// Delete deletes a service account and its keys
+func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "serviceaccounts.Delete")
+	defer span.End()
+
+	accountID, err := parseID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(ctx, accountID); err != nil {
+		return err
+	}
+
+	s.logger.Info("Service account deleted", zap.String("id", id))
+	return nil
+}
+
+// ListKeys returns the keys of a service account, without their secret
+func (s *service) ListKeys(ctx context.Context, id string) ([]*APIKeyResponse, error) {
+	ctx, span := tracing.Start(ctx, "serviceaccounts.ListKeys")
+	defer span.End()
+
+	accountID, err := s.existing(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	keys, err := s.repo.ListKeys(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*APIKeyResponse, len(keys))
+	for i, k := range keys {
+		result[i] = toKeyResponse(k)
+	}
+
+	return result, nil
+}
+
+// CreateKey issues a new key; the previous ones are left untouched
+func (s *service) CreateKey(ctx context.Context, id string, req *CreateKeyRequest, createdBy string) (*APIKeyResponse, error) {
+	ctx, span := tracing.Start(ctx, "serviceaccounts.CreateKey")
+	defer span.End()
+
+	accountID, err := s.existing(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidRequest)
+	}
+
+	issued, err := s.apiKeyService.Issue(ctx, accountID, req.ExpiresAt, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toKeyResponse(issued.APIKey)
+	response.Key = issued.Key
+	return response, nil
+}
+
+// RotateKeys issues a new key and schedules the expiry of the previous ones
+func (s *service) RotateKeys(ctx context.Context, id, createdBy string) (*APIKeyResponse, error) {
+	ctx, span := tracing.Start(ctx, "serviceaccounts.RotateKeys")
+	defer span.End()
+
+	accountID, err := s.existing(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	issued, err := s.apiKeyService.Rotate(ctx, accountID, createdBy)
+	if err != nil {
+		return nil, err
+	}
+
+	response := toKeyResponse(issued.APIKey)
+	response.Key = issued.Key
+	return response, nil
+}
+
+// RevokeKey revokes a key immediately
+func (s *service) RevokeKey(ctx context.Context, id, keyID string) error {
+	ctx, span := tracing.Start(ctx, "serviceaccounts.RevokeKey")
+	defer span.End()
+
+	accountID, err := parseID(id)
+	if err != nil {
+		return err
+	}
+	kid, err := uuid.Parse(keyID)
+	if err != nil {
+		return repository.ErrAPIKeyNotFound
+	}
+
+	return s.apiKeyService.Revoke(ctx, accountID, kid)
+}
+
+// ListUsage returns the requests made with the keys of a service account, newest first
+func (s *service) ListUsage(ctx context.Context, id string, page *pagination.Params) ([]*UsageResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "serviceaccounts.ListUsage")
+	defer span.End()
+
+	accountID, err := s.existing(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	usage, total, err := s.repo.ListUsage(ctx, accountID, page)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	usage, usagePage, err := pagination.Trim(page, usage)
+	if err != nil {
+		return nil, nil, err
+	}
+	usagePage.SetTotal(total)
+
+	result := make([]*UsageResponse, len(usage))
+	for i, u := range usage {
+		result[i] = &UsageResponse{
+			ID:         u.ID.String(),
+			KeyPrefix:  u.KeyPrefix,
+			Method:     u.Method,
+			Path:       u.Path,
+			Route:      u.Route,
+			StatusCode: u.StatusCode,
+			IPAddress:  u.IPAddress,
+			DurationMs: u.DurationMs,
+			CreatedAt:  u.CreatedAt,
+		}
+	}
+
+	return result, usagePage, nil
+}
+
+// existing parses the ID and checks that the service account exists
+func (s *service) existing(ctx context.Context, id string) (uuid.UUID, error) {
+	accountID, err := parseID(id)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if _, err := s.repo.GetByID(ctx, accountID); err != nil {
+		return uuid.Nil, err
+	}
+
+	return accountID, nil
+}
+
+func parseID(id string) (uuid.UUID, error) {
+	accountID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, repository.ErrServiceAccountNotFound
+	}
+	return accountID, nil
+}
+
+func parseOptionalID(id *string) (*uuid.UUID, error) {
+	if id == nil || *id == "" {
+		return nil, nil
+	}
+
+	parsed, err := uuid.Parse(*id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid commissariat_id", ErrInvalidRequest)
+	}
+	return &parsed, nil
+}
+
+// validatePermissions refuses the codes missing from the permission catalog
+func validatePermissions(permissions []string) error {
+	known := make(map[string]bool, len(rbac.AllPermissions))
+	for _, p := range rbac.AllPermissions {
+		known[string(p)] = true
+	}
+
+	for _, p := range permissions {
+		if !known[p] {
+			return fmt.Errorf("%w: %s", repository.ErrUnknownPermission, p)
+		}
+	}
+	return nil
+}
+
+func validateAllowedIPs(entries []string) error {
+	if err := apikeys.ValidateAllowedIPs(entries); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidRequest, err)
+	}
+	return nil
+}
+
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func keyStatus(k *ent.APIKey) string {
+	switch {
+	case k.RevokedAt != nil:
+		return KeyStatusRevoked
+	case k.ExpiresAt != nil && time.Now().After(*k.ExpiresAt):
+		return KeyStatusExpired
+	default:
+		return KeyStatusActive
+	}
+}
+
+func toResponse(sa *ent.ServiceAccount) *ServiceAccountResponse {
+	response := &ServiceAccountResponse{
+		ID:          sa.ID.String(),
+		Name:        sa.Name,
+		Description: sa.Description,
+		Permissions: nonNil(sa.Permissions),
+		AllowedIPs:  nonNil(sa.AllowedIps),
+		Active:      sa.Active,
+		CreatedBy:   sa.CreatedBy,
+		LastUsedAt:  sa.LastUsedAt,
+		CreatedAt:   sa.CreatedAt,
+		UpdatedAt:   sa.UpdatedAt,
+	}
+	if sa.CommissariatID != nil {
+		commissariatID := sa.CommissariatID.String()
+		response.CommissariatID = &commissariatID
+	}
+
+	return response
+}
+
+func toKeyResponse(k *ent.APIKey) *APIKeyResponse {
+	return &APIKeyResponse{
+		ID:         k.ID.String(),
+		Prefix:     apikeys.KeyPrefix + k.Prefix,
+		Status:     keyStatus(k),
+		ExpiresAt:  k.ExpiresAt,
+		RevokedAt:  k.RevokedAt,
+		LastUsedAt: k.LastUsedAt,
+		LastUsedIP: k.LastUsedIP,
+		CreatedBy:  k.CreatedBy,
+		CreatedAt:  k.CreatedAt,
+	}
+}
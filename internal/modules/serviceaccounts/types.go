@@ -0,0 +1,116 @@
+package serviceaccounts
+
+import (
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
+)
+
+// CreateServiceAccountRequest represents the body of POST /admin/service-accounts
+type CreateServiceAccountRequest struct {
+	Name           string   `json:"name" validate:"required,max=100"`
+	Description    string   `json:"description,omitempty"`
+	Permissions    []string `json:"permissions"`
+	AllowedIPs     []string `json:"allowed_ips,omitempty"`     // Addresses or CIDR ranges; empty allows every address
+	CommissariatID *string  `json:"commissariat_id,omitempty"` // Restricts the data to one commissariat
+}
+
+// UpdateServiceAccountRequest represents the body of PUT /admin/service-accounts/:id.
+// Lists, when present, replace the current ones.
+type UpdateServiceAccountRequest struct {
+	Description    *string  `json:"description,omitempty"`
+	Permissions    []string `json:"permissions,omitempty"`
+	AllowedIPs     []string `json:"allowed_ips,omitempty"`
+	CommissariatID *string  `json:"commissariat_id,omitempty"` // "" removes the restriction
+	Active         *bool    `json:"active,omitempty"`
+}
+
+// CreateKeyRequest represents the body of POST /admin/service-accounts/:id/keys
+type CreateKeyRequest struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // Defaults to the configured lifetime
+}
+
+// ServiceAccountResponse represents a service account
+type ServiceAccountResponse struct {
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	Description    string     `json:"description,omitempty"`
+	Permissions    []string   `json:"permissions"`
+	AllowedIPs     []string   `json:"allowed_ips"`
+	CommissariatID *string    `json:"commissariat_id,omitempty"`
+	Active         bool       `json:"active"`
+	CreatedBy      string     `json:"created_by,omitempty"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	UpdatedAt      time.Time  `json:"updatedAt"`
+}
+
+// APIKeyResponse represents an API key. The key itself is only returned at
+// its creation.
+type APIKeyResponse struct {
+	ID         string     `json:"id"`
+	Prefix     string     `json:"prefix"`
+	Key        string     `json:"key,omitempty"`
+	Status     string     `json:"status"` // ACTIVE, EXPIRED or REVOKED
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP string     `json:"last_used_ip,omitempty"`
+	CreatedBy  string     `json:"created_by,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// UsageResponse represents one request made with an API key
+type UsageResponse struct {
+	ID         string    `json:"id"`
+	KeyPrefix  string    `json:"key_prefix"`
+	Method     string    `json:"method"`
+	Path       string    `json:"path"`
+	Route      string    `json:"route,omitempty"`
+	StatusCode int       `json:"status_code"`
+	IPAddress  string    `json:"ip_address,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// listSpec declares the sorts and fields accepted by GET /admin/service-accounts
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"name":      "name",
+		"createdAt": "createdAt",
+	},
+	DefaultSort: "name",
+	Fields:      pagination.JSONFields(ServiceAccountResponse{}),
+}
+
+// keySpec declares the sorts and fields accepted by GET /admin/service-accounts/:id/keys
+var keySpec = pagination.Spec{
+	Sorts: map[string]string{
+		"createdAt": "createdAt",
+		"status":    "status",
+	},
+	DefaultSort: "-createdAt",
+	Fields:      pagination.JSONFields(APIKeyResponse{}),
+}
+
+// usageSpec declares the sorts and fields accepted by GET /admin/service-accounts/:id/usage
+var usageSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"createdAt":   "created_at",
+		"status_code": "status_code",
+		"duration_ms": "duration_ms",
+	},
+	DefaultSort: "-createdAt",
+	Fields:      pagination.JSONFields(UsageResponse{}),
+	Filters: filter.Fields{
+		"key_prefix":  {Column: "key_prefix", Type: filter.String},
+		"method":      {Column: "method", Type: filter.String},
+		"path":        {Column: "path", Type: filter.String},
+		"route":       {Column: "route", Type: filter.String},
+		"status_code": {Column: "status_code", Type: filter.Int},
+		"duration_ms": {Column: "duration_ms", Type: filter.Int},
+		"ip_address":  {Column: "ip_address", Type: filter.String},
+		"createdAt":   {Column: "createdAt", Type: filter.Time},
+	},
+}
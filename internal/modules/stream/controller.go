@@ -0,0 +1,169 @@
+package stream
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/infrastructure/config"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/realtime"
+	"police-trafic-api-frontend-aligned/internal/shared/responses"
+
+	"github.com/labstack/echo/v4"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultHeartbeat = 25 * time.Second
+	// retryDelay is the reconnection delay suggested to EventSource clients
+	retryDelay = 3 * time.Second
+)
+
+// Controller serves the real-time event stream (Server-Sent Events)
+type Controller struct {
+	hub       realtime.Hub
+	heartbeat time.Duration
+	logger    *zap.Logger
+}
+
+// NewController creates a new stream controller
+func NewController(hub realtime.Hub, cfg *config.Config, logger *zap.Logger) *Controller {
+	heartbeat := cfg.Stream.Heartbeat
+	if heartbeat <= 0 {
+		heartbeat = defaultHeartbeat
+	}
+
+	return &Controller{
+		hub:       hub,
+		heartbeat: heartbeat,
+		logger:    logger,
+	}
+}
+
+// RegisterRoutes registers stream routes. Authentication is done by the /api/v1
+// group middleware; the token may also be given as ?access_token= (EventSource).
+func (ctrl *Controller) RegisterRoutes(e *echo.Group) {
+	rbac.Guard(e).GET("/stream", ctrl.Stream, rbac.PermReadAlertes)
+}
+
+// Stream handles GET /stream
+//
+// Events are sent as "id: <id>\nevent: <type>\ndata: <json>". After a reconnect
+// the client sends the Last-Event-ID header (or ?lastEventId=) and receives the
+// events it missed; a "resync" event means too many were missed and the state
+// must be reloaded through the REST API.
+func (ctrl *Controller) Stream(c echo.Context) error {
+	userID, _ := c.Get("user_id").(string)
+	if userID == "" {
+		return responses.Unauthorized(c, "User ID not found in context")
+	}
+	role, _ := c.Get("user_role").(string)
+	commissariatID, _ := c.Get("commissariat_id").(string)
+
+	sub := &realtime.Subscriber{
+		UserID:         userID,
+		CommissariatID: commissariatID,
+		SeeAll:         role == string(rbac.RoleAdmin),
+	}
+	if types := c.QueryParam("types"); types != "" {
+		sub.Types = make(map[string]bool)
+		for _, t := range strings.Split(types, ",") {
+			if t = strings.TrimSpace(t); t != "" {
+				sub.Types[t] = true
+			}
+		}
+	}
+
+	lastEventID := c.Request().Header.Get("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.QueryParam("lastEventId")
+	}
+	lastID := 0
+	if lastEventID != "" {
+		id, err := strconv.Atoi(lastEventID)
+		if err != nil || id < 0 {
+			return responses.BadRequest(c, "Invalid Last-Event-ID")
+		}
+		lastID = id
+	}
+
+	// S'abonner avant le rejeu pour ne rien perdre entre les deux
+	subscription := ctrl.hub.Subscribe(sub)
+	defer subscription.Close()
+
+	ctx := c.Request().Context()
+	var replay []*realtime.Event
+	truncated := false
+	if lastID > 0 {
+		var err error
+		replay, truncated, err = ctrl.hub.Replay(ctx, sub, lastID)
+		if err != nil {
+			ctrl.logger.Error("Failed to replay stream events", zap.String("user_id", userID), zap.Error(err))
+			return responses.InternalServerError(c, "Failed to replay events")
+		}
+	}
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	if _, err := fmt.Fprintf(res, "retry: %d\n\n", retryDelay.Milliseconds()); err != nil {
+		return nil
+	}
+	replayed := make(map[int]bool, len(replay))
+	for _, e := range replay {
+		if err := writeEvent(res, e); err != nil {
+			return nil
+		}
+		replayed[e.ID] = true
+	}
+	if truncated {
+		if _, err := fmt.Fprint(res, "event: resync\ndata: {}\n\n"); err != nil {
+			return nil
+		}
+	}
+	res.Flush()
+
+	ctrl.logger.Debug("Stream client connected",
+		zap.String("user_id", userID),
+		zap.String("commissariat_id", commissariatID),
+		zap.Int("replayed", len(replay)))
+
+	heartbeat := time.NewTicker(ctrl.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-subscription.Done:
+			// Client trop lent ou arrêt du serveur: il se reconnectera avec Last-Event-ID
+			return nil
+		case e := <-subscription.Events:
+			if replayed[e.ID] {
+				// Déjà envoyé lors du rejeu
+				continue
+			}
+			if err := writeEvent(res, e); err != nil {
+				return nil
+			}
+			res.Flush()
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+func writeEvent(res *echo.Response, e *realtime.Event) error {
+	_, err := fmt.Fprintf(res, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, e.Data)
+	return err
+}
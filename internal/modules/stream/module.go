@@ -0,0 +1,18 @@
+package stream
+
+import (
+	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+
+	"go.uber.org/fx"
+)
+
+// Module provides the real-time stream endpoint
+var Module = fx.Module("stream",
+	fx.Provide(
+		fx.Annotate(
+			NewController,
+			fx.As(new(interfaces.Controller)),
+			fx.ResultTags(`group:"controllers"`),
+		),
+	),
+)
@@ -4,6 +4,8 @@ import (
 	"strconv"
 
 	"police-trafic-api-frontend-aligned/internal/core/interfaces"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/rbac"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 	"police-trafic-api-frontend-aligned/internal/shared/responses"
 
 	"github.com/labstack/echo/v4"
@@ -23,22 +25,22 @@ func NewController(service Service) interfaces.Controller {
 
 // RegisterRoutes registers vehicule routes
 func (c *Controller) RegisterRoutes(g *echo.Group) {
-	group := g.Group("/vehicules")
+	group := rbac.Guard(g).Group("/vehicules")
 
 	// Public endpoints
-	group.GET("", c.ListVehicules)
-	group.GET("/:id", c.GetVehicule)
-	group.GET("/immatriculation/:immat", c.GetByImmatriculation)
-	group.GET("/search", c.SearchVehicules)
+	group.GET("", c.ListVehicules, rbac.PermReadVehicules)
+	group.GET("/:id", c.GetVehicule, rbac.PermReadVehicules)
+	group.GET("/immatriculation/:immat", c.GetByImmatriculation, rbac.PermReadVehicules)
+	group.GET("/search", c.SearchVehicules, rbac.PermReadVehicules)
 
 	// Protected endpoints
-	group.POST("", c.CreateVehicule)
-	group.PUT("/:id", c.UpdateVehicule)
-	group.DELETE("/:id", c.DeleteVehicule)
+	group.POST("", c.CreateVehicule, rbac.PermCreateVehicules)
+	group.PUT("/:id", c.UpdateVehicule, rbac.PermUpdateVehicules)
+	group.DELETE("/:id", c.DeleteVehicule, rbac.PermDeleteVehicules)
 
 	// Additional endpoints
-	group.GET("/marque/:marque", c.GetByMarque)
-	group.GET("/type/:type", c.GetByType)
+	group.GET("/marque/:marque", c.GetByMarque, rbac.PermReadVehicules)
+	group.GET("/type/:type", c.GetByType, rbac.PermReadVehicules)
 }
 
 // ListVehicules lists vehicules with filters
@@ -68,27 +70,19 @@ func (c *Controller) ListVehicules(ctx echo.Context) error {
 		}
 	}
 
-	// Pagination
-	if limit := ctx.QueryParam("limit"); limit != "" {
-		if l, err := strconv.Atoi(limit); err == nil && l > 0 {
-			request.Limit = l
-		}
-	} else {
-		request.Limit = 50
-	}
-
-	if offset := ctx.QueryParam("offset"); offset != "" {
-		if o, err := strconv.Atoi(offset); err == nil && o >= 0 {
-			request.Offset = o
-		}
+	// Pagination, tri et champs
+	page, err := pagination.Parse(ctx, listSpec)
+	if err != nil {
+		return responses.BadRequest(ctx, err.Error())
 	}
+	request.Page = page
 
-	result, err := c.service.List(ctx.Request().Context(), request)
+	vehicules, result, err := c.service.List(ctx.Request().Context(), request)
 	if err != nil {
 		return responses.InternalServerError(ctx, "Failed to list vehicules")
 	}
 
-	return responses.Success(ctx, result)
+	return responses.Paginated(ctx, vehicules, result)
 }
 
 // GetVehicule gets a vehicule by ID
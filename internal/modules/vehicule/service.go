@@ -7,6 +7,8 @@ import (
 
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -17,7 +19,7 @@ type Service interface {
 	Create(ctx context.Context, input *CreateVehiculeRequest) (*VehiculeResponse, error)
 	GetByID(ctx context.Context, id string) (*VehiculeResponse, error)
 	GetByImmatriculation(ctx context.Context, immatriculation string) (*VehiculeResponse, error)
-	List(ctx context.Context, filters *ListVehiculesRequest) (*ListVehiculesResponse, error)
+	List(ctx context.Context, filters *ListVehiculesRequest) ([]*VehiculeResponse, *pagination.Page, error)
 	Update(ctx context.Context, id string, input *UpdateVehiculeRequest) (*VehiculeResponse, error)
 	Delete(ctx context.Context, id string) error
 	Search(ctx context.Context, query string) (*SearchVehiculesResponse, error)
@@ -42,6 +44,9 @@ func NewService(repo repository.VehiculeRepository, logger *zap.Logger) Service
 
 // Create creates a new vehicule
 func (s *service) Create(ctx context.Context, input *CreateVehiculeRequest) (*VehiculeResponse, error) {
+	ctx, span := tracing.Start(ctx, "vehicule.Create")
+	defer span.End()
+
 	// Validation métier
 	if err := s.validateCreateInput(input); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
@@ -82,6 +87,9 @@ func (s *service) Create(ctx context.Context, input *CreateVehiculeRequest) (*Ve
 
 // GetByID gets vehicule by ID
 func (s *service) GetByID(ctx context.Context, id string) (*VehiculeResponse, error) {
+	ctx, span := tracing.Start(ctx, "vehicule.GetByID")
+	defer span.End()
+
 	vehiculeEnt, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -92,6 +100,9 @@ func (s *service) GetByID(ctx context.Context, id string) (*VehiculeResponse, er
 
 // GetByImmatriculation gets vehicule by immatriculation
 func (s *service) GetByImmatriculation(ctx context.Context, immatriculation string) (*VehiculeResponse, error) {
+	ctx, span := tracing.Start(ctx, "vehicule.GetByImmatriculation")
+	defer span.End()
+
 	normalizedImmat := s.normalizeImmatriculation(immatriculation)
 	vehiculeEnt, err := s.repo.GetByImmatriculation(ctx, normalizedImmat)
 	if err != nil {
@@ -102,7 +113,10 @@ func (s *service) GetByImmatriculation(ctx context.Context, immatriculation stri
 }
 
 // List gets vehicules with filters
-func (s *service) List(ctx context.Context, input *ListVehiculesRequest) (*ListVehiculesResponse, error) {
+func (s *service) List(ctx context.Context, input *ListVehiculesRequest) ([]*VehiculeResponse, *pagination.Page, error) {
+	ctx, span := tracing.Start(ctx, "vehicule.List")
+	defer span.End()
+
 	filters := &repository.VehiculeFilters{
 		Marque:          input.Marque,
 		Modele:          input.Modele,
@@ -111,11 +125,17 @@ func (s *service) List(ctx context.Context, input *ListVehiculesRequest) (*ListV
 		ProprietaireNom: input.ProprietaireNom,
 		Limit:           input.Limit,
 		Offset:          input.Offset,
+		Page:            input.Page,
 	}
 
 	vehiculesEnt, err := s.repo.List(ctx, filters)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	vehiculesEnt, page, err := pagination.Trim(input.Page, vehiculesEnt)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	vehicules := make([]*VehiculeResponse, len(vehiculesEnt))
@@ -123,14 +143,14 @@ func (s *service) List(ctx context.Context, input *ListVehiculesRequest) (*ListV
 		vehicules[i] = s.entityToResponse(v)
 	}
 
-	return &ListVehiculesResponse{
-		Vehicules: vehicules,
-		Total:     len(vehicules),
-	}, nil
+	return vehicules, page, nil
 }
 
 // Update updates vehicule
 func (s *service) Update(ctx context.Context, id string, input *UpdateVehiculeRequest) (*VehiculeResponse, error) {
+	ctx, span := tracing.Start(ctx, "vehicule.Update")
+	defer span.End()
+
 	// Validation métier
 	if err := s.validateUpdateInput(input); err != nil {
 		return nil, fmt.Errorf("validation error: %w", err)
@@ -161,11 +181,17 @@ func (s *service) Update(ctx context.Context, id string, input *UpdateVehiculeRe
 
 // Delete deletes vehicule
 func (s *service) Delete(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "vehicule.Delete")
+	defer span.End()
+
 	return s.repo.Delete(ctx, id)
 }
 
 // Search searches vehicules
 func (s *service) Search(ctx context.Context, query string) (*SearchVehiculesResponse, error) {
+	ctx, span := tracing.Start(ctx, "vehicule.Search")
+	defer span.End()
+
 	vehiculesEnt, err := s.repo.Search(ctx, query)
 	if err != nil {
 		return nil, err
@@ -185,6 +211,9 @@ func (s *service) Search(ctx context.Context, query string) (*SearchVehiculesRes
 
 // GetByProprietaire gets vehicules by proprietaire
 func (s *service) GetByProprietaire(ctx context.Context, nom, prenom string) (*ListVehiculesResponse, error) {
+	ctx, span := tracing.Start(ctx, "vehicule.GetByProprietaire")
+	defer span.End()
+
 	vehiculesEnt, err := s.repo.GetByProprietaire(ctx, nom, prenom)
 	if err != nil {
 		return nil, err
@@ -203,6 +232,9 @@ func (s *service) GetByProprietaire(ctx context.Context, nom, prenom string) (*L
 
 // GetByMarque gets vehicules by marque
 func (s *service) GetByMarque(ctx context.Context, marque string) (*ListVehiculesResponse, error) {
+	ctx, span := tracing.Start(ctx, "vehicule.GetByMarque")
+	defer span.End()
+
 	filters := &repository.VehiculeFilters{
 		Marque: &marque,
 	}
@@ -225,6 +257,9 @@ func (s *service) GetByMarque(ctx context.Context, marque string) (*ListVehicule
 
 // GetByType gets vehicules by type
 func (s *service) GetByType(ctx context.Context, typeVehicule string) (*ListVehiculesResponse, error) {
+	ctx, span := tracing.Start(ctx, "vehicule.GetByType")
+	defer span.End()
+
 	filters := &repository.VehiculeFilters{
 		TypeVehicule: &typeVehicule,
 	}
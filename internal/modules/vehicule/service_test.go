@@ -2,11 +2,15 @@ package vehicule
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"police-trafic-api-frontend-aligned/ent/enttest"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
+	"github.com/labstack/echo/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
@@ -205,32 +209,69 @@ func TestVehiculeService_List_WithFilters(t *testing.T) {
 	}
 
 	// Test sans filtres
-	results, err := service.List(context.Background(), &ListVehiculesRequest{})
+	results, _, err := service.List(context.Background(), &ListVehiculesRequest{})
 	require.NoError(t, err)
-	assert.Len(t, results.Vehicules, 4)
-	assert.Equal(t, 4, results.Total)
+	assert.Len(t, results, 4)
 
 	// Test filtre par marque
-	results, err = service.List(context.Background(), &ListVehiculesRequest{
+	results, _, err = service.List(context.Background(), &ListVehiculesRequest{
 		Marque: stringPtr("Peugeot"),
 	})
 	require.NoError(t, err)
-	assert.Len(t, results.Vehicules, 2)
+	assert.Len(t, results, 2)
 
 	// Test filtre par type
-	results, err = service.List(context.Background(), &ListVehiculesRequest{
+	results, _, err = service.List(context.Background(), &ListVehiculesRequest{
 		TypeVehicule: stringPtr("PL"),
 	})
 	require.NoError(t, err)
-	assert.Len(t, results.Vehicules, 1)
-	assert.Equal(t, "Mercedes", results.Vehicules[0].Marque)
+	assert.Len(t, results, 1)
+	assert.Equal(t, "Mercedes", results[0].Marque)
 
 	// Test avec limite
-	results, err = service.List(context.Background(), &ListVehiculesRequest{
+	results, _, err = service.List(context.Background(), &ListVehiculesRequest{
 		Limit: 2,
 	})
 	require.NoError(t, err)
-	assert.Len(t, results.Vehicules, 2)
+	assert.Len(t, results, 2)
+}
+
+func TestVehiculeService_List_Cursor(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:ent?mode=memory&cache=shared&_fk=1")
+	defer client.Close()
+
+	logger := zap.NewNop()
+	repo := repository.NewVehiculeRepository(client, logger)
+	service := NewService(repo, logger)
+
+	for _, immat := range []string{"CURSOR03", "CURSOR01", "CURSOR02"} {
+		_, err := service.Create(context.Background(), &CreateVehiculeRequest{
+			Immatriculation: immat, Marque: "Renault", Modele: "Clio", TypeVehicule: "VP",
+		})
+		require.NoError(t, err)
+	}
+
+	page, err := parsePage("limit=2&sort=immatriculation")
+	require.NoError(t, err)
+	first, result, err := service.List(context.Background(), &ListVehiculesRequest{Page: page})
+	require.NoError(t, err)
+	require.Len(t, first, 2)
+	assert.Equal(t, "CURSOR01", first[0].Immatriculation)
+	require.NotEmpty(t, result.NextCursor)
+
+	// La page suivante reprend après le dernier véhicule lu
+	page, err = parsePage("limit=2&sort=immatriculation&cursor=" + result.NextCursor)
+	require.NoError(t, err)
+	second, result, err := service.List(context.Background(), &ListVehiculesRequest{Page: page})
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	assert.Equal(t, "CURSOR03", second[0].Immatriculation)
+	assert.Empty(t, result.NextCursor)
+}
+
+func parsePage(query string) (*pagination.Params, error) {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/vehicules?"+query, nil)
+	return pagination.Parse(echo.New().NewContext(req, httptest.NewRecorder()), listSpec)
 }
 
 // Helper function
@@ -2,6 +2,9 @@ package vehicule
 
 import (
 	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 )
 
 // Request types
@@ -47,6 +50,31 @@ type ListVehiculesRequest struct {
 	ProprietaireNom *string `json:"proprietaire_nom,omitempty"`
 	Limit           int     `json:"limit,omitempty"`
 	Offset          int     `json:"offset,omitempty"`
+	Page            *pagination.Params `json:"-"`
+}
+
+// listSpec declares the sorts and fields accepted by GET /vehicules
+var listSpec = pagination.Spec{
+	Sorts: map[string]string{
+		"created_at":      "created_at",
+		"immatriculation": "immatriculation",
+		"marque":          "marque",
+	},
+	DefaultSort: "-created_at",
+	Fields:      pagination.JSONFields(VehiculeResponse{}),
+	Filters: filter.Fields{
+		"immatriculation":             {Column: "immatriculation", Type: filter.String},
+		"marque":                      {Column: "marque", Type: filter.String},
+		"modele":                      {Column: "modele", Type: filter.String},
+		"couleur":                     {Column: "couleur", Type: filter.String},
+		"annee":                       {Column: "annee", Type: filter.Int},
+		"type_vehicule":               {Column: "type_vehicule", Type: filter.String},
+		"energie":                     {Column: "energie", Type: filter.String},
+		"assurance_validite":          {Column: "assurance_validite", Type: filter.Time},
+		"controle_technique_validite": {Column: "controle_technique_validite", Type: filter.Time},
+		"active":                      {Column: "active", Type: filter.Bool},
+		"created_at":                  {Column: "created_at", Type: filter.Time},
+	},
 }
 
 // Response types
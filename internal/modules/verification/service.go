@@ -9,6 +9,7 @@ import (
 	"police-trafic-api-frontend-aligned/ent"
 	"police-trafic-api-frontend-aligned/ent/checkoption"
 	"police-trafic-api-frontend-aligned/internal/infrastructure/repository"
+	"police-trafic-api-frontend-aligned/internal/infrastructure/tracing"
 
 	"go.uber.org/zap"
 )
@@ -41,6 +42,9 @@ func NewService(repo repository.VerificationRepository, logger *zap.Logger) Serv
 
 // ListCheckItems lists check items from the catalogue
 func (s *service) ListCheckItems(ctx context.Context, applicableTo string, category string) (*ListCheckItemsResponse, error) {
+	ctx, span := tracing.Start(ctx, "verification.ListCheckItems")
+	defer span.End()
+
 	items, err := s.repo.ListCheckItems(ctx, applicableTo, category, true)
 	if err != nil {
 		s.logger.Error("Failed to list check items", zap.Error(err))
@@ -61,6 +65,9 @@ func (s *service) ListCheckItems(ctx context.Context, applicableTo string, categ
 
 // GetCheckItemByID gets a check item by ID
 func (s *service) GetCheckItemByID(ctx context.Context, id string) (*CheckItemResponse, error) {
+	ctx, span := tracing.Start(ctx, "verification.GetCheckItemByID")
+	defer span.End()
+
 	item, err := s.repo.GetCheckItemByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -70,6 +77,9 @@ func (s *service) GetCheckItemByID(ctx context.Context, id string) (*CheckItemRe
 
 // GetVerifications gets all verifications for a source
 func (s *service) GetVerifications(ctx context.Context, sourceType string, sourceID string) (*ListVerificationsResponse, error) {
+	ctx, span := tracing.Start(ctx, "verification.GetVerifications")
+	defer span.End()
+
 	options, err := s.repo.GetVerificationsBySource(ctx, sourceType, sourceID)
 	if err != nil {
 		s.logger.Error("Failed to get verifications",
@@ -106,6 +116,9 @@ func (s *service) GetVerifications(ctx context.Context, sourceType string, sourc
 
 // SaveVerification saves a single verification
 func (s *service) SaveVerification(ctx context.Context, sourceType string, sourceID string, req *CreateCheckOptionRequest) (*CheckOptionResponse, error) {
+	ctx, span := tracing.Start(ctx, "verification.SaveVerification")
+	defer span.End()
+
 	// Get the check item
 	checkItem, err := s.repo.GetCheckItemByID(ctx, req.CheckItemID)
 	if err != nil {
@@ -152,6 +165,9 @@ func (s *service) SaveVerification(ctx context.Context, sourceType string, sourc
 
 // SaveBatchVerifications saves multiple verifications at once
 func (s *service) SaveBatchVerifications(ctx context.Context, sourceType string, sourceID string, req *BatchCheckOptionsRequest) (*ListVerificationsResponse, error) {
+	ctx, span := tracing.Start(ctx, "verification.SaveBatchVerifications")
+	defer span.End()
+
 	// Delete existing verifications for this source
 	if err := s.repo.DeleteVerificationsBySource(ctx, sourceType, sourceID); err != nil {
 		s.logger.Warn("Failed to delete existing verifications", zap.Error(err))
@@ -174,6 +190,9 @@ func (s *service) SaveBatchVerifications(ctx context.Context, sourceType string,
 
 // DeleteVerification deletes a verification
 func (s *service) DeleteVerification(ctx context.Context, id string) error {
+	ctx, span := tracing.Start(ctx, "verification.DeleteVerification")
+	defer span.End()
+
 	return s.repo.DeleteVerification(ctx, id)
 }
 
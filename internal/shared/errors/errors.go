@@ -11,6 +11,8 @@ var (
 	ErrTokenExpired      = errors.New("token expired")
 	ErrInvalidToken      = errors.New("invalid token")
 	ErrUnauthorized      = errors.New("unauthorized")
+	ErrAccountLocked     = errors.New("account temporarily locked")
+	ErrTooManyAttempts   = errors.New("too many login attempts")
 
 	// Validation errors
 	ErrInvalidInput     = errors.New("invalid input")
@@ -69,6 +71,10 @@ func MapErrorToHTTP(err error) *HTTPError {
 		return NewHTTPError(http.StatusUnauthorized, "Invalid token", err)
 	case errors.Is(err, ErrUnauthorized):
 		return NewHTTPError(http.StatusUnauthorized, "Unauthorized", err)
+	case errors.Is(err, ErrAccountLocked):
+		return NewHTTPError(http.StatusLocked, "Account temporarily locked", err)
+	case errors.Is(err, ErrTooManyAttempts):
+		return NewHTTPError(http.StatusTooManyRequests, "Too many login attempts, retry later", err)
 	case errors.Is(err, ErrInvalidInput):
 		return NewHTTPError(http.StatusBadRequest, "Invalid input", err)
 	case errors.Is(err, ErrMissingField):
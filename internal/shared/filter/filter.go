@@ -0,0 +1,225 @@
+// Package filter implements the filter= expressions of the list endpoints:
+//
+//	statut in (EMIS,MAJORE) and montant_total > 50000 and date_emission >= 2026-01-01
+//
+// Conditions compare a field with a value (=, !=, <, <=, >, >=), a list of
+// values (in, not in), a substring (~, case insensitive) or null (is null,
+// is not null). They are combined with and, or, not and parentheses. Each
+// resource declares the fields that can be filtered on; an expression is
+// parsed once and translated to an ent predicate on their columns, values
+// being passed as query arguments.
+package filter
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+)
+
+// Limits of an expression, so that a filter cannot produce an arbitrary query
+const (
+	MaxLength     = 1000
+	MaxConditions = 20
+	MaxDepth      = 5
+)
+
+// Type is the type of a filterable field, used to parse its values
+type Type int
+
+// Field types
+const (
+	String Type = iota
+	Int
+	Float
+	Time
+	Bool
+	UUID
+)
+
+func (t Type) String() string {
+	switch t {
+	case Int:
+		return "integer"
+	case Float:
+		return "number"
+	case Time:
+		return "date"
+	case Bool:
+		return "boolean"
+	case UUID:
+		return "uuid"
+	}
+	return "string"
+}
+
+// Field declares a filterable field
+type Field struct {
+	Column string
+	Type   Type
+}
+
+// Fields maps the names used in expressions to the fields of a resource
+type Fields map[string]Field
+
+// Error is returned for an invalid expression
+type Error struct {
+	Pos int // position in the expression, from 1
+	Msg string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("invalid filter at position %d: %s", e.Pos, e.Msg)
+}
+
+// Expr is a parsed filter expression
+type Expr struct {
+	root node
+}
+
+// Parse parses an expression against the fields of a resource
+func Parse(input string, fields Fields) (*Expr, error) {
+	if len(input) > MaxLength {
+		return nil, &Error{Pos: MaxLength, Msg: fmt.Sprintf("expression longer than %d characters", MaxLength)}
+	}
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, fields: fields}
+	root, err := p.parseOr(0)
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, p.errorf(tok, "unexpected %q", tok.text)
+	}
+	return &Expr{root: root}, nil
+}
+
+// Predicate returns the ent predicate of the expression, to convert to the
+// predicate type of the query: predicate.ProcesVerbal(expr.Predicate())
+func (e *Expr) Predicate() func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		s.Where(e.root.predicate(s))
+	}
+}
+
+// node is a node of a parsed expression
+type node interface {
+	predicate(s *sql.Selector) *sql.Predicate
+}
+
+type andNode []node
+
+func (n andNode) predicate(s *sql.Selector) *sql.Predicate {
+	preds := make([]*sql.Predicate, len(n))
+	for i, child := range n {
+		preds[i] = child.predicate(s)
+	}
+	return sql.And(preds...)
+}
+
+type orNode []node
+
+func (n orNode) predicate(s *sql.Selector) *sql.Predicate {
+	preds := make([]*sql.Predicate, len(n))
+	for i, child := range n {
+		preds[i] = child.predicate(s)
+	}
+	return sql.Or(preds...)
+}
+
+type notNode struct {
+	node
+}
+
+func (n notNode) predicate(s *sql.Selector) *sql.Predicate {
+	return sql.Not(n.node.predicate(s))
+}
+
+// condition compares a column with its values
+type condition struct {
+	column string
+	op     string
+	values []interface{}
+}
+
+func (c condition) predicate(s *sql.Selector) *sql.Predicate {
+	column := s.C(c.column)
+	switch c.op {
+	case "=":
+		return sql.EQ(column, c.values[0])
+	case "!=":
+		return sql.NEQ(column, c.values[0])
+	case "<":
+		return sql.LT(column, c.values[0])
+	case "<=":
+		return sql.LTE(column, c.values[0])
+	case ">":
+		return sql.GT(column, c.values[0])
+	case ">=":
+		return sql.GTE(column, c.values[0])
+	case "~":
+		return sql.ContainsFold(column, c.values[0].(string))
+	case "in":
+		return sql.In(column, c.values...)
+	case "not in":
+		return sql.NotIn(column, c.values...)
+	case "is null":
+		return sql.IsNull(column)
+	default: // "is not null"
+		return sql.NotNull(column)
+	}
+}
+
+// dateFormats are the accepted formats of date values, as in the date query
+// parameters
+var dateFormats = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"}
+
+// value converts a literal to the type of a field
+func value(field Field, text string) (interface{}, error) {
+	switch field.Type {
+	case Int:
+		return strconv.ParseInt(text, 10, 64)
+	case Float:
+		return strconv.ParseFloat(text, 64)
+	case Bool:
+		return strconv.ParseBool(text)
+	case UUID:
+		return uuid.Parse(text)
+	case Time:
+		for _, format := range dateFormats {
+			if t, err := time.Parse(format, text); err == nil {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("invalid date %q", text)
+	}
+	return text, nil
+}
+
+// allowed tells whether an operator applies to a type of field
+func allowed(op string, t Type) bool {
+	switch op {
+	case "<", "<=", ">", ">=":
+		return t == Int || t == Float || t == Time || t == String
+	case "~":
+		return t == String
+	}
+	return true
+}
+
+func fieldNames(fields Fields) string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
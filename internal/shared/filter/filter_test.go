@@ -0,0 +1,71 @@
+package filter
+
+import (
+	"testing"
+	"time"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var pvFields = Fields{
+	"statut":        {Column: "statut", Type: String},
+	"montant_total": {Column: "montant_total", Type: Float},
+	"date_emission": {Column: "date_emission", Type: Time},
+	"numero_pv":     {Column: "numero_pv", Type: String},
+	"majore":        {Column: "majore", Type: Bool},
+}
+
+// query returns the SQL and arguments of a filter on proces_verbals
+func query(t *testing.T, input string) (string, []interface{}) {
+	t.Helper()
+	expr, err := Parse(input, pvFields)
+	require.NoError(t, err)
+
+	s := sql.Dialect(dialect.Postgres).Select("*").From(sql.Table("proces_verbals"))
+	expr.Predicate()(s)
+	return s.Query()
+}
+
+func TestParse_Predicate(t *testing.T) {
+	q, args := query(t, "statut in (EMIS,MAJORE) and montant_total > 50000 and date_emission >= 2026-01-01")
+	assert.Equal(t, `SELECT * FROM "proces_verbals" WHERE "proces_verbals"."statut" IN ($1, $2) AND "proces_verbals"."montant_total" > $3 AND "proces_verbals"."date_emission" >= $4`, q)
+	assert.Equal(t, []interface{}{"EMIS", "MAJORE", 50000.0, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)}, args)
+
+	q, args = query(t, "(statut = 'CONTESTE' OR numero_pv ~ \"pv-2026\") and not majore = true")
+	assert.Contains(t, q, `"proces_verbals"."statut" = $1 OR "proces_verbals"."numero_pv" ILIKE $2`)
+	assert.Contains(t, q, `NOT ("proces_verbals"."majore")`)
+	assert.Equal(t, []interface{}{"CONTESTE", "%pv-2026%"}, args)
+
+	q, _ = query(t, "date_emission is not null and statut not in ('ANNULE')")
+	assert.Contains(t, q, `"proces_verbals"."date_emission" IS NOT NULL`)
+	assert.Contains(t, q, `"proces_verbals"."statut" NOT IN ($1)`)
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		input string
+		msg   string
+	}{
+		{"password = x", `position 1: unknown field "password"`},
+		{"statut like x", `position 8: expected an operator after "statut", got "like"`},
+		{"montant_total ~ 5", `operator "~" does not apply to number field "montant_total"`},
+		{"majore > true", `operator ">" does not apply to boolean field "majore"`},
+		{"montant_total > beaucoup", `invalid number value "beaucoup"`},
+		{"date_emission >= 31/01/2026", `invalid date value "31/01/2026"`},
+		{"statut in (EMIS", `expected "," or ")"`},
+		{"statut = 'EMIS", "unterminated string"},
+		{"statut = EMIS or", `expected a field, got "end of filter"`},
+		{"statut = EMIS statut", `unexpected "statut"`},
+		{"((((((statut = EMIS))))))", "nested parentheses"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.input, pvFields)
+		var ferr *Error
+		if assert.ErrorAs(t, err, &ferr, tt.input) {
+			assert.Contains(t, err.Error(), tt.msg, tt.input)
+		}
+	}
+}
@@ -0,0 +1,289 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokWord
+	tokString // quoted value
+	tokOp
+	tokLParen
+	tokRParen
+	tokComma
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// lex splits an expression into tokens. Words are names, keywords and bare
+// values (EMIS, 50000, 2026-01-01); values with spaces are quoted with ' or ",
+// the quote being doubled inside.
+func lex(input string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(input); {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", pos: i + 1})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", pos: i + 1})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokComma, text: ",", pos: i + 1})
+			i++
+		case c == '\'' || c == '"':
+			start := i
+			var b strings.Builder
+			for i++; ; i++ {
+				if i >= len(input) {
+					return nil, &Error{Pos: start + 1, Msg: "unterminated string"}
+				}
+				if input[i] == c {
+					if i+1 < len(input) && input[i+1] == c {
+						b.WriteByte(c)
+						i++
+						continue
+					}
+					i++
+					break
+				}
+				b.WriteByte(input[i])
+			}
+			tokens = append(tokens, token{kind: tokString, text: b.String(), pos: start + 1})
+		case strings.IndexByte("=!<>~", c) >= 0:
+			op := string(c)
+			if i+1 < len(input) {
+				switch next := input[i+1]; {
+				case next == '=' && c != '=' && c != '~', c == '<' && next == '>':
+					op += string(next)
+				}
+			}
+			if op == "!" {
+				return nil, &Error{Pos: i + 1, Msg: `unknown operator "!"`}
+			}
+			tokens = append(tokens, token{kind: tokOp, text: op, pos: i + 1})
+			i += len(op)
+		default:
+			start := i
+			for i < len(input) && strings.IndexByte(" \t\n\r(),'\"=!<>~", input[i]) < 0 {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokWord, text: input[start:i], pos: start + 1})
+		}
+	}
+	return append(tokens, token{kind: tokEOF, text: "end of filter", pos: len(input) + 1}), nil
+}
+
+// parser is a recursive descent parser:
+//
+//	or        = and { "or" and }
+//	and       = unary { "and" unary }
+//	unary     = "not" unary | "(" or ")" | condition
+//	condition = field op value | field [ "not" ] "in" "(" value { "," value } ")"
+//	          | field "is" [ "not" ] "null"
+type parser struct {
+	tokens     []token
+	pos        int
+	fields     Fields
+	conditions int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// keyword consumes the next token if it is the given keyword
+func (p *parser) keyword(kw string) bool {
+	if tok := p.peek(); tok.kind == tokWord && strings.EqualFold(tok.text, kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) errorf(tok token, format string, args ...interface{}) error {
+	return &Error{Pos: tok.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) parseOr(depth int) (node, error) {
+	first, err := p.parseAnd(depth)
+	if err != nil {
+		return nil, err
+	}
+	nodes := orNode{first}
+	for p.keyword("or") {
+		n, err := p.parseAnd(depth)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	if len(nodes) == 1 {
+		return first, nil
+	}
+	return nodes, nil
+}
+
+func (p *parser) parseAnd(depth int) (node, error) {
+	first, err := p.parseUnary(depth)
+	if err != nil {
+		return nil, err
+	}
+	nodes := andNode{first}
+	for p.keyword("and") {
+		n, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		nodes = append(nodes, n)
+	}
+	if len(nodes) == 1 {
+		return first, nil
+	}
+	return nodes, nil
+}
+
+func (p *parser) parseUnary(depth int) (node, error) {
+	if p.keyword("not") {
+		n, err := p.parseUnary(depth)
+		if err != nil {
+			return nil, err
+		}
+		return notNode{n}, nil
+	}
+
+	if tok := p.peek(); tok.kind == tokLParen {
+		if depth >= MaxDepth {
+			return nil, p.errorf(tok, "more than %d nested parentheses", MaxDepth)
+		}
+		p.next()
+		n, err := p.parseOr(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		if tok := p.next(); tok.kind != tokRParen {
+			return nil, p.errorf(tok, "expected \")\", got %q", tok.text)
+		}
+		return n, nil
+	}
+
+	return p.parseCondition()
+}
+
+func (p *parser) parseCondition() (node, error) {
+	tok := p.next()
+	if tok.kind != tokWord {
+		return nil, p.errorf(tok, "expected a field, got %q", tok.text)
+	}
+	field, ok := p.fields[tok.text]
+	if !ok {
+		return nil, p.errorf(tok, "unknown field %q, expected one of: %s", tok.text, fieldNames(p.fields))
+	}
+
+	p.conditions++
+	if p.conditions > MaxConditions {
+		return nil, p.errorf(tok, "more than %d conditions", MaxConditions)
+	}
+
+	cond := condition{column: field.Column}
+	opTok := p.peek()
+	switch {
+	case opTok.kind == tokOp:
+		p.next()
+		cond.op = opTok.text
+		if cond.op == "<>" {
+			cond.op = "!="
+		}
+	case p.keyword("in"):
+		cond.op = "in"
+	case p.keyword("not"):
+		if !p.keyword("in") {
+			return nil, p.errorf(p.peek(), "expected \"in\" after \"not\"")
+		}
+		cond.op = "not in"
+	case p.keyword("is"):
+		cond.op = "is null"
+		if p.keyword("not") {
+			cond.op = "is not null"
+		}
+		if !p.keyword("null") {
+			return nil, p.errorf(p.peek(), "expected \"null\"")
+		}
+		return cond, nil
+	default:
+		return nil, p.errorf(opTok, "expected an operator after %q, got %q", tok.text, opTok.text)
+	}
+
+	if !allowed(cond.op, field.Type) {
+		return nil, p.errorf(opTok, "operator %q does not apply to %s field %q", cond.op, field.Type, tok.text)
+	}
+
+	if cond.op == "in" || cond.op == "not in" {
+		values, err := p.parseList(tok.text, field)
+		if err != nil {
+			return nil, err
+		}
+		cond.values = values
+		return cond, nil
+	}
+
+	v, err := p.parseValue(tok.text, field)
+	if err != nil {
+		return nil, err
+	}
+	cond.values = []interface{}{v}
+	return cond, nil
+}
+
+func (p *parser) parseList(name string, field Field) ([]interface{}, error) {
+	if tok := p.next(); tok.kind != tokLParen {
+		return nil, p.errorf(tok, "expected \"(\", got %q", tok.text)
+	}
+	var values []interface{}
+	for {
+		v, err := p.parseValue(name, field)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+
+		tok := p.next()
+		if tok.kind == tokRParen {
+			return values, nil
+		}
+		if tok.kind != tokComma {
+			return nil, p.errorf(tok, "expected \",\" or \")\", got %q", tok.text)
+		}
+	}
+}
+
+func (p *parser) parseValue(name string, field Field) (interface{}, error) {
+	tok := p.next()
+	if tok.kind != tokWord && tok.kind != tokString {
+		return nil, p.errorf(tok, "expected a value for %q, got %q", name, tok.text)
+	}
+	v, err := value(field, tok.text)
+	if err != nil {
+		return nil, p.errorf(tok, "invalid %s value %q for %q", field.Type, tok.text, name)
+	}
+	return v, nil
+}
@@ -0,0 +1,211 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// cursorKey signs the cursors: a client cannot forge the position, nor the
+// values compared with the sort column
+var cursorKey = randomKey()
+
+// SetCursorKey derives the key signing the cursors from a secret shared by
+// every instance, so that a cursor issued by one is accepted by the others.
+// It is called once at startup; without it the key is random, for the
+// process only.
+func SetCursorKey(secret string) {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("pagination-cursors"))
+	cursorKey = mac.Sum(nil)
+}
+
+func randomKey() []byte {
+	key := make([]byte, 32)
+	rand.Read(key)
+	return key
+}
+
+func cursorSignature(payload string) string {
+	mac := hmac.New(sha256.New, cursorKey)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// cursor is the content of the opaque cursor tokens
+type cursor struct {
+	Sort   string        `json:"s"`
+	Offset int           `json:"o,omitempty"` // lists paged in memory
+	After  []interface{} `json:"-"`           // sort value and id of the last row
+	Values []cursorValue `json:"a,omitempty"`
+}
+
+// cursorValue keeps the Go type of a key through JSON: the database compares
+// it with a column of that type. Null is set for a NULL sort value.
+type cursorValue struct {
+	Null   bool       `json:"n,omitempty"`
+	Time   *time.Time `json:"t,omitempty"`
+	String *string    `json:"s,omitempty"`
+	Int    *int64     `json:"i,omitempty"`
+	Float  *float64   `json:"f,omitempty"`
+	UUID   *uuid.UUID `json:"u,omitempty"`
+	Bool   *bool      `json:"b,omitempty"`
+}
+
+func encodeCursor(cur cursor) (string, error) {
+	for _, v := range cur.After {
+		value, err := newCursorValue(v)
+		if err != nil {
+			return "", err
+		}
+		cur.Values = append(cur.Values, value)
+	}
+
+	data, err := json.Marshal(cur)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	return payload + "." + cursorSignature(payload), nil
+}
+
+func decodeCursor(token string) (*cursor, error) {
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(cursorSignature(payload))) {
+		return nil, ErrInvalidCursor
+	}
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cur cursor
+	if err := json.Unmarshal(data, &cur); err != nil {
+		return nil, ErrInvalidCursor
+	}
+	if cur.Offset < 0 || (len(cur.Values) != 0 && len(cur.Values) != 2) {
+		return nil, ErrInvalidCursor
+	}
+
+	for i, value := range cur.Values {
+		v, ok := value.get()
+		// Seule la valeur de tri peut être NULL, jamais l'id
+		if !ok || (v == nil && i > 0) {
+			return nil, ErrInvalidCursor
+		}
+		cur.After = append(cur.After, v)
+	}
+	return &cur, nil
+}
+
+func newCursorValue(v interface{}) (cursorValue, error) {
+	switch v := v.(type) {
+	case nil:
+		return cursorValue{Null: true}, nil
+	case time.Time:
+		return cursorValue{Time: &v}, nil
+	case string:
+		return cursorValue{String: &v}, nil
+	case int64:
+		return cursorValue{Int: &v}, nil
+	case float64:
+		return cursorValue{Float: &v}, nil
+	case uuid.UUID:
+		return cursorValue{UUID: &v}, nil
+	case bool:
+		return cursorValue{Bool: &v}, nil
+	}
+	return cursorValue{}, fmt.Errorf("unsupported cursor value %T", v)
+}
+
+func (c cursorValue) get() (interface{}, bool) {
+	switch {
+	case c.Null:
+		return nil, true
+	case c.Time != nil:
+		return *c.Time, true
+	case c.String != nil:
+		return *c.String, true
+	case c.Int != nil:
+		return *c.Int, true
+	case c.Float != nil:
+		return *c.Float, true
+	case c.UUID != nil:
+		return *c.UUID, true
+	case c.Bool != nil:
+		return *c.Bool, true
+	}
+	return nil, false
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	uuidType = reflect.TypeOf(uuid.UUID{})
+)
+
+// fieldByJSON returns the field of a struct, or pointer to struct, whose json
+// name is name: ent entities name their fields after the columns
+func fieldByJSON(v reflect.Value, name string) (reflect.Value, bool) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return reflect.Value{}, false
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return reflect.Value{}, false
+	}
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.IsExported() && field.Tag.Get("json") == "" {
+			if inner, ok := fieldByJSON(v.Field(i), name); ok {
+				return inner, true
+			}
+			continue
+		}
+		if jsonName(field) == name {
+			return v.Field(i), true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// keyValue converts a field to one of the cursorValue types, nil for a NULL
+// value of a nillable field
+func keyValue(v reflect.Value) (interface{}, error) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+
+	switch {
+	case v.Type() == timeType:
+		return v.Interface().(time.Time), nil
+	case v.Type() == uuidType:
+		return v.Interface().(uuid.UUID), nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil // enums compris
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.Bool:
+		return v.Bool(), nil
+	}
+	return nil, fmt.Errorf("unsupported sort value %s", v.Type())
+}
@@ -0,0 +1,74 @@
+package pagination
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// JSONFields returns the json field names of a response struct, for Spec.Fields
+func JSONFields(v interface{}) []string {
+	t := reflect.TypeOf(v)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.IsExported() && field.Tag.Get("json") == "" {
+			fields = append(fields, JSONFields(reflect.Zero(field.Type).Interface())...)
+			continue
+		}
+		if name := jsonName(field); name != "" {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// Select keeps the given fields of each item of data, a slice of objects. The
+// id is always kept so that clients can address the items.
+func Select(data interface{}, fields []string) (interface{}, error) {
+	if len(fields) == 0 {
+		return data, nil
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select fields: %w", err)
+	}
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &items); err != nil {
+		// Pas une liste d'objets : rien à filtrer
+		return data, nil
+	}
+
+	keep := map[string]bool{"id": true}
+	for _, field := range fields {
+		keep[field] = true
+	}
+	for _, item := range items {
+		for key := range item {
+			if !keep[key] {
+				delete(item, key)
+			}
+		}
+	}
+	return items, nil
+}
+
+func jsonName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
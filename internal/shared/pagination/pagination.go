@@ -0,0 +1,192 @@
+// Package pagination implements the paging, sorting and field selection shared
+// by the list endpoints:
+//
+//	?limit=50&sort=-date_controle&fields=id,numero,statut&cursor=<next_cursor>
+//	?filter=statut in (EMIS,MAJORE) and montant_total > 50000
+//
+// Pages are read with opaque cursors (keyset pagination: the next page starts
+// after the last row of the previous one, however deep), or with the former
+// page= and offset= parameters. Rows are ordered by the sort column, NULL
+// values last, then by id, so the order is stable between pages. Cursors are
+// signed (SetCursorKey).
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// DefaultLimit is the page size when limit is not given
+	DefaultLimit = 50
+	// MaxLimit is the largest page size accepted
+	MaxLimit = 200
+)
+
+// ErrInvalidCursor is returned for a cursor that was not issued for this
+// list, or not signed by the API
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Spec declares what a list endpoint accepts
+type Spec struct {
+	// Sorts maps the sort= values to the columns they order by (for lists
+	// read from the database) or to the json fields of the items (lists
+	// paged in memory). Sort columns are NOT NULL, or nillable in the ent
+	// schema: a NULL read into a zero value could not be told from it.
+	Sorts map[string]string
+	// DefaultSort is used without sort=, "-" first for descending order
+	DefaultSort string
+	// Fields lists the fields accepted by fields=, see JSONFields
+	Fields []string
+	// Filters lists the fields accepted in filter= expressions, none when
+	// the list cannot be filtered
+	Filters filter.Fields
+}
+
+// Params are the paging parameters of a list request
+type Params struct {
+	Limit  int
+	Sort   string // sort= value, "-" first for descending order
+	Column string // column or json field ordered by
+	Desc   bool
+	Fields []string // fields to return, all when empty
+
+	offset int
+	page   int
+	after  []interface{} // sort value and id of the last row of the previous page
+	filter *filter.Expr
+}
+
+// Parse reads limit, sort, fields, filter, cursor and the former page and
+// offset query parameters of a list request
+func Parse(c echo.Context, spec Spec) (*Params, error) {
+	p := &Params{Limit: DefaultLimit}
+
+	if limit := c.QueryParam("limit"); limit != "" {
+		l, err := strconv.Atoi(limit)
+		if err != nil || l < 1 {
+			return nil, fmt.Errorf("invalid limit %q", limit)
+		}
+		p.Limit = min(l, MaxLimit)
+	}
+
+	p.Sort = spec.DefaultSort
+	if s := c.QueryParam("sort"); s != "" {
+		p.Sort = s
+	}
+	key := strings.TrimPrefix(p.Sort, "-")
+	column, ok := spec.Sorts[key]
+	if !ok {
+		return nil, fmt.Errorf("invalid sort %q, expected one of: %s", key, strings.Join(sortKeys(spec), ", "))
+	}
+	p.Column = column
+	p.Desc = strings.HasPrefix(p.Sort, "-")
+
+	if fields := c.QueryParam("fields"); fields != "" {
+		for _, field := range strings.Split(fields, ",") {
+			field = strings.TrimSpace(field)
+			if field == "" {
+				continue
+			}
+			if len(spec.Fields) > 0 && !contains(spec.Fields, field) {
+				return nil, fmt.Errorf("unknown field %q", field)
+			}
+			p.Fields = append(p.Fields, field)
+		}
+	}
+
+	if expr := c.QueryParam("filter"); expr != "" {
+		if len(spec.Filters) == 0 {
+			return nil, fmt.Errorf("this list cannot be filtered")
+		}
+		f, err := filter.Parse(expr, spec.Filters)
+		if err != nil {
+			return nil, err
+		}
+		p.filter = f
+	}
+
+	if token := c.QueryParam("cursor"); token != "" {
+		cur, err := decodeCursor(token)
+		if err != nil {
+			return nil, err
+		}
+		if cur.Sort != p.Sort {
+			return nil, fmt.Errorf("%w: issued for sort %q", ErrInvalidCursor, cur.Sort)
+		}
+		p.offset = cur.Offset
+		p.after = cur.After
+		return p, nil
+	}
+
+	if page := c.QueryParam("page"); page != "" {
+		n, err := strconv.Atoi(page)
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid page %q", page)
+		}
+		p.page = n
+		p.offset = (n - 1) * p.Limit
+	} else if offset := c.QueryParam("offset"); offset != "" {
+		o, err := strconv.Atoi(offset)
+		if err != nil || o < 0 {
+			return nil, fmt.Errorf("invalid offset %q", offset)
+		}
+		p.offset = o
+	}
+
+	return p, nil
+}
+
+// Offset returns the number of rows to skip, 0 when reading after a cursor
+func (p *Params) Offset() int {
+	return p.offset
+}
+
+// FetchLimit returns the number of rows to read: one more than the page
+// size, to know whether another page follows
+func (p *Params) FetchLimit() int {
+	return p.Limit + 1
+}
+
+// Page describes the page of a list response
+type Page struct {
+	Limit      int
+	Number     int // page number, when requested with page=
+	NextCursor string
+	Total      *int
+	Fields     []string
+}
+
+// SetTotal records the total number of items of the list
+func (pg *Page) SetTotal(total int) {
+	pg.Total = &total
+}
+
+func (p *Params) newPage() *Page {
+	return &Page{Limit: p.Limit, Number: p.page, Fields: p.Fields}
+}
+
+func sortKeys(spec Spec) []string {
+	keys := make([]string, 0, len(spec.Sorts))
+	for key := range spec.Sorts {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,179 @@
+package pagination
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"police-trafic-api-frontend-aligned/internal/shared/filter"
+
+	"entgo.io/ent/dialect/sql"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testSpec = Spec{
+	Sorts:       map[string]string{"date": "date_controle", "statut": "statut", "cloture": "date_cloture"},
+	DefaultSort: "-date",
+	Fields:      []string{"id", "statut", "reference"},
+	Filters:     filter.Fields{"statut": {Column: "statut", Type: filter.String}},
+}
+
+// row mimics an ent entity: fields named after the columns
+type row struct {
+	ID           uuid.UUID  `json:"id,omitempty"`
+	DateControle time.Time  `json:"date_controle,omitempty"`
+	DateCloture  *time.Time `json:"date_cloture,omitempty"`
+	Statut       string     `json:"statut,omitempty"`
+}
+
+func parse(t *testing.T, query string) (*Params, error) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/controles?"+query, nil)
+	return Parse(echo.New().NewContext(req, httptest.NewRecorder()), testSpec)
+}
+
+func TestParse(t *testing.T) {
+	p, err := parse(t, "")
+	require.NoError(t, err)
+	assert.Equal(t, DefaultLimit, p.Limit)
+	assert.Equal(t, "date_controle", p.Column)
+	assert.True(t, p.Desc)
+
+	p, err = parse(t, "limit=1000&sort=statut&fields=statut,reference&page=3")
+	require.NoError(t, err)
+	assert.Equal(t, MaxLimit, p.Limit)
+	assert.False(t, p.Desc)
+	assert.Equal(t, []string{"statut", "reference"}, p.Fields)
+	assert.Equal(t, 2*MaxLimit, p.Offset())
+
+	_, err = parse(t, "sort=password")
+	assert.ErrorContains(t, err, "cloture, date, statut")
+	_, err = parse(t, "fields=password")
+	assert.Error(t, err)
+	_, err = parse(t, "limit=0")
+	assert.Error(t, err)
+	_, err = parse(t, "cursor=not-a-cursor")
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	p, err = parse(t, "filter="+url.QueryEscape("statut in (EN_COURS, TERMINE)"))
+	require.NoError(t, err)
+	assert.NotNil(t, p.filter)
+	_, err = parse(t, "filter="+url.QueryEscape("password = x"))
+	var ferr *filter.Error
+	assert.ErrorAs(t, err, &ferr)
+}
+
+func TestTrim_CursorRoundTrip(t *testing.T) {
+	p, err := parse(t, "limit=2")
+	require.NoError(t, err)
+
+	date := time.Date(2024, 5, 2, 10, 30, 0, 123000, time.UTC)
+	rows := []*row{
+		{ID: uuid.New(), DateControle: date.Add(time.Hour)},
+		{ID: uuid.New(), DateControle: date},
+		{ID: uuid.New(), DateControle: date.Add(-time.Hour)},
+	}
+
+	kept, page, err := Trim(p, rows)
+	require.NoError(t, err)
+	assert.Len(t, kept, 2)
+	require.NotEmpty(t, page.NextCursor)
+
+	next, err := parse(t, "limit=2&cursor="+page.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{date, rows[1].ID}, next.after)
+	assert.Zero(t, next.Offset())
+
+	// Le curseur est lié au tri qui l'a produit
+	_, err = parse(t, "sort=statut&cursor="+page.NextCursor)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+
+	// Dernière page : pas de curseur
+	_, page, err = Trim(next, rows[2:])
+	require.NoError(t, err)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestCursor_Signed(t *testing.T) {
+	token, err := encodeCursor(cursor{Sort: "-date", Offset: 50})
+	require.NoError(t, err)
+	_, err = decodeCursor(token)
+	require.NoError(t, err)
+
+	// Un client ne peut pas déplacer le curseur ni changer les valeurs comparées
+	payload, _, _ := strings.Cut(token, ".")
+	forged, err := encodeCursor(cursor{Sort: "-date", Offset: 5000})
+	require.NoError(t, err)
+	_, forgedSignature, _ := strings.Cut(forged, ".")
+	_, err = decodeCursor(payload + "." + forgedSignature)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+	_, err = decodeCursor(payload)
+	assert.ErrorIs(t, err, ErrInvalidCursor)
+}
+
+func TestTrim_NullSortValue(t *testing.T) {
+	p, err := parse(t, "limit=1&sort=-cloture")
+	require.NoError(t, err)
+
+	rows := []*row{{ID: uuid.New()}, {ID: uuid.New()}}
+	_, page, err := Trim(p, rows)
+	require.NoError(t, err, "a NULL sort value must not fail the list")
+
+	next, err := parse(t, "limit=1&sort=-cloture&cursor="+page.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{nil, rows[0].ID}, next.after)
+
+	// Après une ligne NULL, seules les lignes NULL d'id inférieur restent
+	s := sql.Select("*").From(sql.Table("controles"))
+	next.Where()(s)
+	query, _ := s.Query()
+	assert.Contains(t, query, "`controles`.`date_cloture` IS NULL AND `controles`.`id` < ?")
+
+	// Avant, les lignes NULL suivent toutes les valeurs
+	date := time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)
+	_, page, err = Trim(p, []*row{{ID: uuid.New(), DateCloture: &date}, {ID: uuid.New()}})
+	require.NoError(t, err)
+	next, err = parse(t, "limit=1&sort=-cloture&cursor="+page.NextCursor)
+	require.NoError(t, err)
+	s = sql.Select("*").From(sql.Table("controles"))
+	next.Where()(s)
+	query, _ = s.Query()
+	assert.Contains(t, query, "OR `controles`.`date_cloture` IS NULL")
+}
+
+func TestSlice(t *testing.T) {
+	p, err := parse(t, "limit=2&sort=statut")
+	require.NoError(t, err)
+
+	items := []row{{Statut: "c"}, {Statut: "a"}, {Statut: "b"}}
+	first, page := Slice(p, items)
+	assert.Equal(t, []row{{Statut: "a"}, {Statut: "b"}}, first)
+	assert.Equal(t, 3, *page.Total)
+
+	next, err := parse(t, "limit=2&sort=statut&cursor="+page.NextCursor)
+	require.NoError(t, err)
+	second, page := Slice(next, items)
+	assert.Equal(t, []row{{Statut: "c"}}, second)
+	assert.Empty(t, page.NextCursor)
+}
+
+func TestSelect(t *testing.T) {
+	type item struct {
+		ID        string `json:"id"`
+		Statut    string `json:"statut"`
+		Reference string `json:"reference"`
+	}
+	assert.Equal(t, []string{"id", "statut", "reference"}, JSONFields(item{}))
+
+	data, err := Select([]item{{ID: "1", Statut: "EN_COURS", Reference: "CTR-1"}}, []string{"statut"})
+	require.NoError(t, err)
+	assert.Len(t, data, 1)
+	assert.Equal(t, 2, len(data.([]map[string]json.RawMessage)[0]))
+}
@@ -0,0 +1,98 @@
+package pagination
+
+import (
+	"fmt"
+	"reflect"
+
+	"entgo.io/ent/dialect/sql"
+)
+
+// Where returns the ent predicate keeping the rows matching the filter and
+// after the cursor, to convert to the predicate type of the query:
+// predicate.Controle(p.Where())
+func (p *Params) Where() func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		if p.filter != nil {
+			p.filter.Predicate()(s)
+		}
+		if len(p.after) != 2 {
+			return
+		}
+		column, id := s.C(p.Column), s.C("id")
+		// Les NULL viennent en dernier, triés par id : après une ligne NULL,
+		// seules les lignes NULL suivantes restent
+		if p.after[0] == nil {
+			if p.Desc {
+				s.Where(sql.And(sql.IsNull(column), sql.LT(id, p.after[1])))
+			} else {
+				s.Where(sql.And(sql.IsNull(column), sql.GT(id, p.after[1])))
+			}
+			return
+		}
+		// (colonne, id) < (valeur, id) : l'index sur la colonne de tri est utilisé
+		columns := []string{column, id}
+		if p.Desc {
+			s.Where(sql.Or(sql.CompositeLT(columns, p.after...), sql.IsNull(column)))
+		} else {
+			s.Where(sql.Or(sql.CompositeGT(columns, p.after...), sql.IsNull(column)))
+		}
+	}
+}
+
+// Filter returns the ent predicate of the filter alone, for the count of the
+// list. It matches every row when p is nil.
+func (p *Params) Filter() func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		if p != nil && p.filter != nil {
+			p.filter.Predicate()(s)
+		}
+	}
+}
+
+// OrderBy returns the ent order option of the sort, the id breaking ties.
+// NULL values come last in both directions, as Where expects.
+func (p *Params) OrderBy() func(*sql.Selector) {
+	return func(s *sql.Selector) {
+		if p.Desc {
+			s.OrderBy(sql.Desc(s.C(p.Column))+" NULLS LAST", sql.Desc(s.C("id")))
+		} else {
+			s.OrderBy(sql.Asc(s.C(p.Column))+" NULLS LAST", sql.Asc(s.C("id")))
+		}
+	}
+}
+
+// Trim keeps the first Limit rows of the ent entities read with Where,
+// OrderBy, Offset and FetchLimit, and returns the page with the cursor of the
+// next one. Without params (internal callers listing everything) the rows are
+// returned as they are.
+func Trim[T any](p *Params, rows []T) ([]T, *Page, error) {
+	if p == nil {
+		return rows, &Page{Limit: len(rows)}, nil
+	}
+	page := p.newPage()
+	if len(rows) <= p.Limit {
+		return rows, page, nil
+	}
+	rows = rows[:p.Limit]
+
+	last := reflect.ValueOf(rows[len(rows)-1])
+	after := make([]interface{}, 0, 2)
+	for _, name := range []string{p.Column, "id"} {
+		field, ok := fieldByJSON(last, name)
+		if !ok {
+			return nil, nil, fmt.Errorf("failed to build cursor: no field %q", name)
+		}
+		value, err := keyValue(field)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to build cursor on %q: %w", name, err)
+		}
+		after = append(after, value)
+	}
+
+	token, err := encodeCursor(cursor{Sort: p.Sort, After: after})
+	if err != nil {
+		return nil, nil, err
+	}
+	page.NextCursor = token
+	return rows, page, nil
+}
@@ -0,0 +1,69 @@
+package pagination
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Slice pages a list already in memory (roles, permissions...), sorted on the
+// json field of the sort. Its cursors carry an offset.
+func Slice[T any](p *Params, items []T) ([]T, *Page) {
+	sorted := make([]T, len(items))
+	copy(sorted, items)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, _ := fieldByJSON(reflect.ValueOf(sorted[i]), p.Column)
+		b, _ := fieldByJSON(reflect.ValueOf(sorted[j]), p.Column)
+		if p.Desc {
+			return less(b, a)
+		}
+		return less(a, b)
+	})
+
+	page := p.newPage()
+	page.SetTotal(len(sorted))
+
+	start := min(p.offset, len(sorted))
+	end := min(start+p.Limit, len(sorted))
+	if end < len(sorted) {
+		// Un curseur d'offset ne peut pas échouer à l'encodage
+		page.NextCursor, _ = encodeCursor(cursor{Sort: p.Sort, Offset: end})
+	}
+	return sorted[start:end], page
+}
+
+// less compares two values of the same field; missing and null values first
+func less(a, b reflect.Value) bool {
+	a, b = deref(a), deref(b)
+	if !a.IsValid() || !b.IsValid() {
+		return !a.IsValid() && b.IsValid()
+	}
+
+	if a.Type() == timeType {
+		return a.Interface().(time.Time).Before(b.Interface().(time.Time))
+	}
+	switch a.Kind() {
+	case reflect.String:
+		return strings.ToLower(a.String()) < strings.ToLower(b.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.Bool:
+		return !a.Bool() && b.Bool()
+	}
+	return false
+}
+
+func deref(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
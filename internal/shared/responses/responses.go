@@ -3,6 +3,7 @@ package responses
 import (
 	"net/http"
 	"police-trafic-api-frontend-aligned/internal/shared/errors"
+	"police-trafic-api-frontend-aligned/internal/shared/pagination"
 
 	"github.com/labstack/echo/v4"
 	"go.uber.org/zap"
@@ -14,13 +15,15 @@ type SuccessResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
-// PaginatedResponse represents a paginated response
+// PaginatedResponse represents a paginated response, the envelope of every list
 type PaginatedResponse struct {
 	Data       interface{} `json:"data"`
-	Total      int         `json:"total"`
-	Page       int         `json:"page"`
+	Total      *int        `json:"total,omitempty"`
+	Page       int         `json:"page,omitempty"`
 	PageSize   int         `json:"page_size"`
-	TotalPages int         `json:"total_pages"`
+	TotalPages int         `json:"total_pages,omitempty"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+	HasMore    bool        `json:"has_more"`
 }
 
 // Success sends a successful JSON response
@@ -41,16 +44,25 @@ func Created(c echo.Context, data interface{}) error {
 	return c.JSON(http.StatusCreated, SuccessResponse{Data: data})
 }
 
-// Paginated sends a paginated JSON response
-func Paginated(c echo.Context, data interface{}, total, page, pageSize int) error {
-	totalPages := (total + pageSize - 1) / pageSize
-	return c.JSON(http.StatusOK, PaginatedResponse{
+// Paginated sends a page of a list, with the fields selected by the request
+func Paginated(c echo.Context, data interface{}, page *pagination.Page) error {
+	data, err := pagination.Select(data, page.Fields)
+	if err != nil {
+		return InternalServerError(c, err.Error())
+	}
+
+	resp := PaginatedResponse{
 		Data:       data,
-		Total:      total,
-		Page:       page,
-		PageSize:   pageSize,
-		TotalPages: totalPages,
-	})
+		Total:      page.Total,
+		Page:       page.Number,
+		PageSize:   page.Limit,
+		NextCursor: page.NextCursor,
+		HasMore:    page.NextCursor != "",
+	}
+	if page.Total != nil && page.Limit > 0 {
+		resp.TotalPages = (*page.Total + page.Limit - 1) / page.Limit
+	}
+	return c.JSON(http.StatusOK, resp)
 }
 
 // Error sends an error JSON response
@@ -116,6 +128,33 @@ func Conflict(c echo.Context, message string) error {
 	})
 }
 
+// UnprocessableEntity sends an unprocessable entity error response
+func UnprocessableEntity(c echo.Context, message string) error {
+	return c.JSON(http.StatusUnprocessableEntity, errors.ErrorResponse{
+		Error:   "unprocessable_entity",
+		Message: message,
+		Code:    http.StatusUnprocessableEntity,
+	})
+}
+
+// PreconditionFailed sends a precondition failed error response
+func PreconditionFailed(c echo.Context, message string) error {
+	return c.JSON(http.StatusPreconditionFailed, errors.ErrorResponse{
+		Error:   "precondition_failed",
+		Message: message,
+		Code:    http.StatusPreconditionFailed,
+	})
+}
+
+// PreconditionRequired sends a precondition required error response
+func PreconditionRequired(c echo.Context, message string) error {
+	return c.JSON(http.StatusPreconditionRequired, errors.ErrorResponse{
+		Error:   "precondition_required",
+		Message: message,
+		Code:    http.StatusPreconditionRequired,
+	})
+}
+
 // InternalServerError sends an internal server error response
 func InternalServerError(c echo.Context, message string) error {
 	return c.JSON(http.StatusInternalServerError, errors.ErrorResponse{
@@ -0,0 +1,490 @@
+-- reverse: modify "user_competences" table
+ALTER TABLE "user_competences" DROP CONSTRAINT "user_competences_competence_id", DROP CONSTRAINT "user_competences_user_id";
+-- reverse: modify "mission_agents" table
+ALTER TABLE "mission_agents" DROP CONSTRAINT "mission_agents_user_id", DROP CONSTRAINT "mission_agents_mission_id";
+-- reverse: modify "user_sessions" table
+ALTER TABLE "user_sessions" DROP CONSTRAINT "user_sessions_users_sessions";
+-- reverse: modify "users" table
+ALTER TABLE "users" DROP CONSTRAINT "users_equipes_membres", DROP CONSTRAINT "users_commissariats_agents";
+-- reverse: modify "timeline_events" table
+ALTER TABLE "timeline_events" DROP CONSTRAINT "timeline_events_plaintes_timeline";
+-- reverse: modify "recours" table
+ALTER TABLE "recours" DROP CONSTRAINT "recours_users_recours_traites", DROP CONSTRAINT "recours_proces_verbals_recours";
+-- reverse: modify "preuves" table
+ALTER TABLE "preuves" DROP CONSTRAINT "preuves_plaintes_preuves";
+-- reverse: modify "plainte_historiques" table
+ALTER TABLE "plainte_historiques" DROP CONSTRAINT "plainte_historiques_plaintes_historiques";
+-- reverse: modify "plaintes" table
+ALTER TABLE "plaintes" DROP CONSTRAINT "plaintes_users_plaintes_assignees", DROP CONSTRAINT "plaintes_commissariats_plaintes";
+-- reverse: modify "paiements" table
+ALTER TABLE "paiements" DROP CONSTRAINT "paiements_proces_verbals_paiements";
+-- reverse: modify "observations" table
+ALTER TABLE "observations" DROP CONSTRAINT "observations_users_observations", DROP CONSTRAINT "observations_users_auteur";
+-- reverse: modify "objet_retrouves" table
+ALTER TABLE "objet_retrouves" DROP CONSTRAINT "objet_retrouves_users_objets_retrouves", DROP CONSTRAINT "objet_retrouves_commissariats_objets_retrouves";
+-- reverse: modify "objet_perdus" table
+ALTER TABLE "objet_perdus" DROP CONSTRAINT "objet_perdus_users_objets_perdus", DROP CONSTRAINT "objet_perdus_commissariats_objets_perdus";
+-- reverse: modify "objectifs" table
+ALTER TABLE "objectifs" DROP CONSTRAINT "objectifs_users_objectifs", DROP CONSTRAINT "objectifs_users_assigne_par";
+-- reverse: modify "missions" table
+ALTER TABLE "missions" DROP CONSTRAINT "missions_equipes_missions", DROP CONSTRAINT "missions_commissariats_missions";
+-- reverse: modify "inspections" table
+ALTER TABLE "inspections" DROP CONSTRAINT "inspections_vehicules_inspections", DROP CONSTRAINT "inspections_users_inspections_realisees", DROP CONSTRAINT "inspections_proces_verbals_inspection", DROP CONSTRAINT "inspections_commissariats_inspections";
+-- reverse: modify "infractions" table
+ALTER TABLE "infractions" DROP CONSTRAINT "infractions_vehicules_infractions", DROP CONSTRAINT "infractions_proces_verbals_infractions", DROP CONSTRAINT "infractions_infraction_types_infractions", DROP CONSTRAINT "infractions_controles_infractions", DROP CONSTRAINT "infractions_conducteurs_infractions", DROP CONSTRAINT "infractions_check_options_infraction";
+-- reverse: modify "equipes" table
+ALTER TABLE "equipes" DROP CONSTRAINT "equipes_users_chef_equipe", DROP CONSTRAINT "equipes_commissariats_equipes";
+-- reverse: modify "enquetes" table
+ALTER TABLE "enquetes" DROP CONSTRAINT "enquetes_plaintes_enquetes";
+-- reverse: modify "documents" table
+ALTER TABLE "documents" DROP CONSTRAINT "documents_users_documents", DROP CONSTRAINT "documents_recours_documents", DROP CONSTRAINT "documents_proces_verbals_documents", DROP CONSTRAINT "documents_infractions_documents", DROP CONSTRAINT "documents_controles_documents";
+-- reverse: modify "decisions" table
+ALTER TABLE "decisions" DROP CONSTRAINT "decisions_plaintes_decisions";
+-- reverse: modify "convocations" table
+ALTER TABLE "convocations" DROP CONSTRAINT "convocations_users_convocations_creees", DROP CONSTRAINT "convocations_plaintes_convocations", DROP CONSTRAINT "convocations_commissariats_convocations";
+-- reverse: modify "controles" table
+ALTER TABLE "controles" DROP CONSTRAINT "controles_vehicules_controles", DROP CONSTRAINT "controles_users_controles", DROP CONSTRAINT "controles_proces_verbals_controle", DROP CONSTRAINT "controles_conducteurs_controles", DROP CONSTRAINT "controles_commissariats_controles";
+-- reverse: modify "check_options" table
+ALTER TABLE "check_options" DROP CONSTRAINT "check_options_documents_check_options", DROP CONSTRAINT "check_options_check_items_check_options";
+-- reverse: modify "check_items" table
+ALTER TABLE "check_items" DROP CONSTRAINT "check_items_infraction_types_check_items";
+-- reverse: modify "audit_logs" table
+ALTER TABLE "audit_logs" DROP CONSTRAINT "audit_logs_users_audit_logs";
+-- reverse: modify "alerte_securitaires" table
+ALTER TABLE "alerte_securitaires" DROP CONSTRAINT "alerte_securitaires_users_alertes", DROP CONSTRAINT "alerte_securitaires_commissariats_alertes";
+-- reverse: modify "acte_enquetes" table
+ALTER TABLE "acte_enquetes" DROP CONSTRAINT "acte_enquetes_plaintes_actes_enquete";
+-- reverse: create "user_competences" table
+DROP TABLE "user_competences";
+-- reverse: create "mission_agents" table
+DROP TABLE "mission_agents";
+-- reverse: create index "vehicule_proprietaire_nom_proprietaire_prenom" to table: "vehicules"
+DROP INDEX "vehicule_proprietaire_nom_proprietaire_prenom";
+-- reverse: create index "vehicule_marque_modele" to table: "vehicules"
+DROP INDEX "vehicule_marque_modele";
+-- reverse: create index "vehicule_immatriculation" to table: "vehicules"
+DROP INDEX "vehicule_immatriculation";
+-- reverse: create index "vehicule_code" to table: "vehicules"
+DROP INDEX "vehicule_code";
+-- reverse: create index "vehicules_immatriculation_key" to table: "vehicules"
+DROP INDEX "vehicules_immatriculation_key";
+-- reverse: create index "vehicules_code_key" to table: "vehicules"
+DROP INDEX "vehicules_code_key";
+-- reverse: create "vehicules" table
+DROP TABLE "vehicules";
+-- reverse: create index "usersession_last_activity_at" to table: "user_sessions"
+DROP INDEX "usersession_last_activity_at";
+-- reverse: create index "usersession_is_active_is_revoked" to table: "user_sessions"
+DROP INDEX "usersession_is_active_is_revoked";
+-- reverse: create index "usersession_device_id" to table: "user_sessions"
+DROP INDEX "usersession_device_id";
+-- reverse: create index "user_sessions_refresh_token_hash_key" to table: "user_sessions"
+DROP INDEX "user_sessions_refresh_token_hash_key";
+-- reverse: create "user_sessions" table
+DROP TABLE "user_sessions";
+-- reverse: create index "user_statut_service" to table: "users"
+DROP INDEX "user_statut_service";
+-- reverse: create index "user_active" to table: "users"
+DROP INDEX "user_active";
+-- reverse: create index "user_role" to table: "users"
+DROP INDEX "user_role";
+-- reverse: create index "user_email" to table: "users"
+DROP INDEX "user_email";
+-- reverse: create index "user_matricule" to table: "users"
+DROP INDEX "user_matricule";
+-- reverse: create index "user_code" to table: "users"
+DROP INDEX "user_code";
+-- reverse: create index "users_email_key" to table: "users"
+DROP INDEX "users_email_key";
+-- reverse: create index "users_matricule_key" to table: "users"
+DROP INDEX "users_matricule_key";
+-- reverse: create index "users_code_key" to table: "users"
+DROP INDEX "users_code_key";
+-- reverse: create "users" table
+DROP TABLE "users";
+-- reverse: create index "timelineevent_date" to table: "timeline_events"
+DROP INDEX "timelineevent_date";
+-- reverse: create index "timelineevent_plainte_id" to table: "timeline_events"
+DROP INDEX "timelineevent_plainte_id";
+-- reverse: create "timeline_events" table
+DROP TABLE "timeline_events";
+-- reverse: create index "recours_date_traitement" to table: "recours"
+DROP INDEX "recours_date_traitement";
+-- reverse: create index "recours_type_recours" to table: "recours"
+DROP INDEX "recours_type_recours";
+-- reverse: create index "recours_statut" to table: "recours"
+DROP INDEX "recours_statut";
+-- reverse: create index "recours_date_recours" to table: "recours"
+DROP INDEX "recours_date_recours";
+-- reverse: create index "recours_numero_recours" to table: "recours"
+DROP INDEX "recours_numero_recours";
+-- reverse: create index "recours_code" to table: "recours"
+DROP INDEX "recours_code";
+-- reverse: create index "recours_numero_recours_key" to table: "recours"
+DROP INDEX "recours_numero_recours_key";
+-- reverse: create index "recours_code_key" to table: "recours"
+DROP INDEX "recours_code_key";
+-- reverse: create "recours" table
+DROP TABLE "recours";
+-- reverse: create index "procesverbal_date_paiement" to table: "proces_verbals"
+DROP INDEX "procesverbal_date_paiement";
+-- reverse: create index "procesverbal_date_limite_paiement" to table: "proces_verbals"
+DROP INDEX "procesverbal_date_limite_paiement";
+-- reverse: create index "procesverbal_statut" to table: "proces_verbals"
+DROP INDEX "procesverbal_statut";
+-- reverse: create index "procesverbal_date_emission" to table: "proces_verbals"
+DROP INDEX "procesverbal_date_emission";
+-- reverse: create index "procesverbal_numero_pv" to table: "proces_verbals"
+DROP INDEX "procesverbal_numero_pv";
+-- reverse: create index "procesverbal_code" to table: "proces_verbals"
+DROP INDEX "procesverbal_code";
+-- reverse: create index "proces_verbals_numero_pv_key" to table: "proces_verbals"
+DROP INDEX "proces_verbals_numero_pv_key";
+-- reverse: create index "proces_verbals_code_key" to table: "proces_verbals"
+DROP INDEX "proces_verbals_code_key";
+-- reverse: create "proces_verbals" table
+DROP TABLE "proces_verbals";
+-- reverse: create index "preuve_numero_piece" to table: "preuves"
+DROP INDEX "preuve_numero_piece";
+-- reverse: create index "preuve_plainte_id" to table: "preuves"
+DROP INDEX "preuve_plainte_id";
+-- reverse: create "preuves" table
+DROP TABLE "preuves";
+-- reverse: create index "plaintehistorique_created_at" to table: "plainte_historiques"
+DROP INDEX "plaintehistorique_created_at";
+-- reverse: create index "plaintehistorique_plainte_id" to table: "plainte_historiques"
+DROP INDEX "plaintehistorique_plainte_id";
+-- reverse: create "plainte_historiques" table
+DROP TABLE "plainte_historiques";
+-- reverse: create index "plainte_date_depot" to table: "plaintes"
+DROP INDEX "plainte_date_depot";
+-- reverse: create index "plainte_etape_actuelle" to table: "plaintes"
+DROP INDEX "plainte_etape_actuelle";
+-- reverse: create index "plainte_priorite" to table: "plaintes"
+DROP INDEX "plainte_priorite";
+-- reverse: create index "plainte_statut" to table: "plaintes"
+DROP INDEX "plainte_statut";
+-- reverse: create index "plainte_type_plainte" to table: "plaintes"
+DROP INDEX "plainte_type_plainte";
+-- reverse: create index "plainte_numero" to table: "plaintes"
+DROP INDEX "plainte_numero";
+-- reverse: create index "plainte_code" to table: "plaintes"
+DROP INDEX "plainte_code";
+-- reverse: create index "plaintes_numero_key" to table: "plaintes"
+DROP INDEX "plaintes_numero_key";
+-- reverse: create index "plaintes_code_key" to table: "plaintes"
+DROP INDEX "plaintes_code_key";
+-- reverse: create "plaintes" table
+DROP TABLE "plaintes";
+-- reverse: create index "paiement_reference_externe" to table: "paiements"
+DROP INDEX "paiement_reference_externe";
+-- reverse: create index "paiement_moyen_paiement" to table: "paiements"
+DROP INDEX "paiement_moyen_paiement";
+-- reverse: create index "paiement_statut" to table: "paiements"
+DROP INDEX "paiement_statut";
+-- reverse: create index "paiement_date_paiement" to table: "paiements"
+DROP INDEX "paiement_date_paiement";
+-- reverse: create index "paiement_numero_transaction" to table: "paiements"
+DROP INDEX "paiement_numero_transaction";
+-- reverse: create index "paiement_code" to table: "paiements"
+DROP INDEX "paiement_code";
+-- reverse: create index "paiements_numero_transaction_key" to table: "paiements"
+DROP INDEX "paiements_numero_transaction_key";
+-- reverse: create index "paiements_code_key" to table: "paiements"
+DROP INDEX "paiements_code_key";
+-- reverse: create "paiements" table
+DROP TABLE "paiements";
+-- reverse: create index "observation_date_observation" to table: "observations"
+DROP INDEX "observation_date_observation";
+-- reverse: create index "observation_type" to table: "observations"
+DROP INDEX "observation_type";
+-- reverse: create index "observation_code" to table: "observations"
+DROP INDEX "observation_code";
+-- reverse: create index "observations_code_key" to table: "observations"
+DROP INDEX "observations_code_key";
+-- reverse: create "observations" table
+DROP TABLE "observations";
+-- reverse: create index "objetretrouve_date_depot" to table: "objet_retrouves"
+DROP INDEX "objetretrouve_date_depot";
+-- reverse: create index "objetretrouve_statut" to table: "objet_retrouves"
+DROP INDEX "objetretrouve_statut";
+-- reverse: create index "objetretrouve_type_objet" to table: "objet_retrouves"
+DROP INDEX "objetretrouve_type_objet";
+-- reverse: create index "objetretrouve_numero" to table: "objet_retrouves"
+DROP INDEX "objetretrouve_numero";
+-- reverse: create index "objet_retrouves_numero_key" to table: "objet_retrouves"
+DROP INDEX "objet_retrouves_numero_key";
+-- reverse: create "objet_retrouves" table
+DROP TABLE "objet_retrouves";
+-- reverse: create index "objetperdu_date_declaration" to table: "objet_perdus"
+DROP INDEX "objetperdu_date_declaration";
+-- reverse: create index "objetperdu_statut" to table: "objet_perdus"
+DROP INDEX "objetperdu_statut";
+-- reverse: create index "objetperdu_type_objet" to table: "objet_perdus"
+DROP INDEX "objetperdu_type_objet";
+-- reverse: create index "objetperdu_numero" to table: "objet_perdus"
+DROP INDEX "objetperdu_numero";
+-- reverse: create index "objet_perdus_numero_key" to table: "objet_perdus"
+DROP INDEX "objet_perdus_numero_key";
+-- reverse: create "objet_perdus" table
+DROP TABLE "objet_perdus";
+-- reverse: create index "objectif_date_debut" to table: "objectifs"
+DROP INDEX "objectif_date_debut";
+-- reverse: create index "objectif_statut" to table: "objectifs"
+DROP INDEX "objectif_statut";
+-- reverse: create index "objectif_periode" to table: "objectifs"
+DROP INDEX "objectif_periode";
+-- reverse: create index "objectif_code" to table: "objectifs"
+DROP INDEX "objectif_code";
+-- reverse: create index "objectifs_code_key" to table: "objectifs"
+DROP INDEX "objectifs_code_key";
+-- reverse: create "objectifs" table
+DROP TABLE "objectifs";
+-- reverse: create index "mission_date_debut" to table: "missions"
+DROP INDEX "mission_date_debut";
+-- reverse: create index "mission_statut" to table: "missions"
+DROP INDEX "mission_statut";
+-- reverse: create index "mission_type" to table: "missions"
+DROP INDEX "mission_type";
+-- reverse: create index "mission_code" to table: "missions"
+DROP INDEX "mission_code";
+-- reverse: create index "missions_code_key" to table: "missions"
+DROP INDEX "missions_code_key";
+-- reverse: create "missions" table
+DROP TABLE "missions";
+-- reverse: create index "inspection_assurance_statut" to table: "inspections"
+DROP INDEX "inspection_assurance_statut";
+-- reverse: create index "inspection_conducteur_numero_permis" to table: "inspections"
+DROP INDEX "inspection_conducteur_numero_permis";
+-- reverse: create index "inspection_vehicule_immatriculation" to table: "inspections"
+DROP INDEX "inspection_vehicule_immatriculation";
+-- reverse: create index "inspection_date_inspection" to table: "inspections"
+DROP INDEX "inspection_date_inspection";
+-- reverse: create index "inspection_statut" to table: "inspections"
+DROP INDEX "inspection_statut";
+-- reverse: create index "inspection_numero" to table: "inspections"
+DROP INDEX "inspection_numero";
+-- reverse: create index "inspection_code" to table: "inspections"
+DROP INDEX "inspection_code";
+-- reverse: create index "inspections_proces_verbal_inspection_key" to table: "inspections"
+DROP INDEX "inspections_proces_verbal_inspection_key";
+-- reverse: create index "inspections_numero_key" to table: "inspections"
+DROP INDEX "inspections_numero_key";
+-- reverse: create index "inspections_code_key" to table: "inspections"
+DROP INDEX "inspections_code_key";
+-- reverse: create "inspections" table
+DROP TABLE "inspections";
+-- reverse: create index "infractiontype_active" to table: "infraction_types"
+DROP INDEX "infractiontype_active";
+-- reverse: create index "infractiontype_categorie" to table: "infraction_types"
+DROP INDEX "infractiontype_categorie";
+-- reverse: create index "infractiontype_code" to table: "infraction_types"
+DROP INDEX "infractiontype_code";
+-- reverse: create index "infraction_types_code_key" to table: "infraction_types"
+DROP INDEX "infraction_types_code_key";
+-- reverse: create "infraction_types" table
+DROP TABLE "infraction_types";
+-- reverse: create index "infraction_lieu_infraction" to table: "infractions"
+DROP INDEX "infraction_lieu_infraction";
+-- reverse: create index "infraction_statut" to table: "infractions"
+DROP INDEX "infraction_statut";
+-- reverse: create index "infraction_date_infraction" to table: "infractions"
+DROP INDEX "infraction_date_infraction";
+-- reverse: create index "infraction_numero_pv" to table: "infractions"
+DROP INDEX "infraction_numero_pv";
+-- reverse: create index "infraction_code" to table: "infractions"
+DROP INDEX "infraction_code";
+-- reverse: create index "infractions_check_option_infraction_key" to table: "infractions"
+DROP INDEX "infractions_check_option_infraction_key";
+-- reverse: create index "infractions_numero_pv_key" to table: "infractions"
+DROP INDEX "infractions_numero_pv_key";
+-- reverse: create index "infractions_code_key" to table: "infractions"
+DROP INDEX "infractions_code_key";
+-- reverse: create "infractions" table
+DROP TABLE "infractions";
+-- reverse: create index "equipe_active" to table: "equipes"
+DROP INDEX "equipe_active";
+-- reverse: create index "equipe_code" to table: "equipes"
+DROP INDEX "equipe_code";
+-- reverse: create index "equipes_code_key" to table: "equipes"
+DROP INDEX "equipes_code_key";
+-- reverse: create "equipes" table
+DROP TABLE "equipes";
+-- reverse: create index "enquete_statut" to table: "enquetes"
+DROP INDEX "enquete_statut";
+-- reverse: create index "enquete_plainte_id" to table: "enquetes"
+DROP INDEX "enquete_plainte_id";
+-- reverse: create "enquetes" table
+DROP TABLE "enquetes";
+-- reverse: create index "document_hash_fichier" to table: "documents"
+DROP INDEX "document_hash_fichier";
+-- reverse: create index "document_created_at" to table: "documents"
+DROP INDEX "document_created_at";
+-- reverse: create index "document_nom_fichier" to table: "documents"
+DROP INDEX "document_nom_fichier";
+-- reverse: create index "document_type_document" to table: "documents"
+DROP INDEX "document_type_document";
+-- reverse: create index "document_code" to table: "documents"
+DROP INDEX "document_code";
+-- reverse: create index "documents_code_key" to table: "documents"
+DROP INDEX "documents_code_key";
+-- reverse: create "documents" table
+DROP TABLE "documents";
+-- reverse: create index "decision_date_decision" to table: "decisions"
+DROP INDEX "decision_date_decision";
+-- reverse: create index "decision_plainte_id" to table: "decisions"
+DROP INDEX "decision_plainte_id";
+-- reverse: create "decisions" table
+DROP TABLE "decisions";
+-- reverse: create index "convocation_date_creation" to table: "convocations"
+DROP INDEX "convocation_date_creation";
+-- reverse: create index "convocation_date_rdv" to table: "convocations"
+DROP INDEX "convocation_date_rdv";
+-- reverse: create index "convocation_statut" to table: "convocations"
+DROP INDEX "convocation_statut";
+-- reverse: create index "convocation_type_convocation" to table: "convocations"
+DROP INDEX "convocation_type_convocation";
+-- reverse: create index "convocation_numero" to table: "convocations"
+DROP INDEX "convocation_numero";
+-- reverse: create index "convocation_code" to table: "convocations"
+DROP INDEX "convocation_code";
+-- reverse: create index "convocations_numero_key" to table: "convocations"
+DROP INDEX "convocations_numero_key";
+-- reverse: create index "convocations_code_key" to table: "convocations"
+DROP INDEX "convocations_code_key";
+-- reverse: create "convocations" table
+DROP TABLE "convocations";
+-- reverse: create index "controle_is_archived" to table: "controles"
+DROP INDEX "controle_is_archived";
+-- reverse: create index "controle_conducteur_numero_permis" to table: "controles"
+DROP INDEX "controle_conducteur_numero_permis";
+-- reverse: create index "controle_vehicule_immatriculation" to table: "controles"
+DROP INDEX "controle_vehicule_immatriculation";
+-- reverse: create index "controle_type_controle" to table: "controles"
+DROP INDEX "controle_type_controle";
+-- reverse: create index "controle_statut" to table: "controles"
+DROP INDEX "controle_statut";
+-- reverse: create index "controle_lieu_controle" to table: "controles"
+DROP INDEX "controle_lieu_controle";
+-- reverse: create index "controle_date_controle" to table: "controles"
+DROP INDEX "controle_date_controle";
+-- reverse: create index "controle_reference" to table: "controles"
+DROP INDEX "controle_reference";
+-- reverse: create index "controle_code" to table: "controles"
+DROP INDEX "controle_code";
+-- reverse: create index "controles_proces_verbal_controle_key" to table: "controles"
+DROP INDEX "controles_proces_verbal_controle_key";
+-- reverse: create index "controles_reference_key" to table: "controles"
+DROP INDEX "controles_reference_key";
+-- reverse: create index "controles_code_key" to table: "controles"
+DROP INDEX "controles_code_key";
+-- reverse: create "controles" table
+DROP TABLE "controles";
+-- reverse: create index "conducteur_email" to table: "conducteurs"
+DROP INDEX "conducteur_email";
+-- reverse: create index "conducteur_numero_permis" to table: "conducteurs"
+DROP INDEX "conducteur_numero_permis";
+-- reverse: create index "conducteur_date_naissance" to table: "conducteurs"
+DROP INDEX "conducteur_date_naissance";
+-- reverse: create index "conducteur_nom_prenom" to table: "conducteurs"
+DROP INDEX "conducteur_nom_prenom";
+-- reverse: create index "conducteur_code" to table: "conducteurs"
+DROP INDEX "conducteur_code";
+-- reverse: create index "conducteurs_code_key" to table: "conducteurs"
+DROP INDEX "conducteurs_code_key";
+-- reverse: create "conducteurs" table
+DROP TABLE "conducteurs";
+-- reverse: create index "competence_active" to table: "competences"
+DROP INDEX "competence_active";
+-- reverse: create index "competence_nom" to table: "competences"
+DROP INDEX "competence_nom";
+-- reverse: create index "competence_type" to table: "competences"
+DROP INDEX "competence_type";
+-- reverse: create index "competence_code" to table: "competences"
+DROP INDEX "competence_code";
+-- reverse: create index "competences_code_key" to table: "competences"
+DROP INDEX "competences_code_key";
+-- reverse: create "competences" table
+DROP TABLE "competences";
+-- reverse: create index "commissariat_actif" to table: "commissariats"
+DROP INDEX "commissariat_actif";
+-- reverse: create index "commissariat_region" to table: "commissariats"
+DROP INDEX "commissariat_region";
+-- reverse: create index "commissariat_ville" to table: "commissariats"
+DROP INDEX "commissariat_ville";
+-- reverse: create index "commissariat_code" to table: "commissariats"
+DROP INDEX "commissariat_code";
+-- reverse: create index "commissariats_code_key" to table: "commissariats"
+DROP INDEX "commissariats_code_key";
+-- reverse: create "commissariats" table
+DROP TABLE "commissariats";
+-- reverse: create index "checkoption_result_status" to table: "check_options"
+DROP INDEX "checkoption_result_status";
+-- reverse: create index "checkoption_source_type_source_id" to table: "check_options"
+DROP INDEX "checkoption_source_type_source_id";
+-- reverse: create index "checkoption_code" to table: "check_options"
+DROP INDEX "checkoption_code";
+-- reverse: create index "check_options_code_key" to table: "check_options"
+DROP INDEX "check_options_code_key";
+-- reverse: create "check_options" table
+DROP TABLE "check_options";
+-- reverse: create index "checkitem_display_order" to table: "check_items"
+DROP INDEX "checkitem_display_order";
+-- reverse: create index "checkitem_is_active" to table: "check_items"
+DROP INDEX "checkitem_is_active";
+-- reverse: create index "checkitem_applicable_to" to table: "check_items"
+DROP INDEX "checkitem_applicable_to";
+-- reverse: create index "checkitem_item_category" to table: "check_items"
+DROP INDEX "checkitem_item_category";
+-- reverse: create index "checkitem_item_code" to table: "check_items"
+DROP INDEX "checkitem_item_code";
+-- reverse: create index "checkitem_code" to table: "check_items"
+DROP INDEX "checkitem_code";
+-- reverse: create index "check_items_item_code_key" to table: "check_items"
+DROP INDEX "check_items_item_code_key";
+-- reverse: create index "check_items_code_key" to table: "check_items"
+DROP INDEX "check_items_code_key";
+-- reverse: create "check_items" table
+DROP TABLE "check_items";
+-- reverse: create index "auditlog_ip_address" to table: "audit_logs"
+DROP INDEX "auditlog_ip_address";
+-- reverse: create index "auditlog_session_id" to table: "audit_logs"
+DROP INDEX "auditlog_session_id";
+-- reverse: create index "auditlog_status" to table: "audit_logs"
+DROP INDEX "auditlog_status";
+-- reverse: create index "auditlog_resource_id" to table: "audit_logs"
+DROP INDEX "auditlog_resource_id";
+-- reverse: create index "auditlog_resource_type" to table: "audit_logs"
+DROP INDEX "auditlog_resource_type";
+-- reverse: create index "auditlog_action" to table: "audit_logs"
+DROP INDEX "auditlog_action";
+-- reverse: create index "auditlog_timestamp" to table: "audit_logs"
+DROP INDEX "auditlog_timestamp";
+-- reverse: create index "auditlog_code" to table: "audit_logs"
+DROP INDEX "auditlog_code";
+-- reverse: create index "audit_logs_code_key" to table: "audit_logs"
+DROP INDEX "audit_logs_code_key";
+-- reverse: create "audit_logs" table
+DROP TABLE "audit_logs";
+-- reverse: create index "alertesecuritaire_date_alerte" to table: "alerte_securitaires"
+DROP INDEX "alertesecuritaire_date_alerte";
+-- reverse: create index "alertesecuritaire_type_alerte" to table: "alerte_securitaires"
+DROP INDEX "alertesecuritaire_type_alerte";
+-- reverse: create index "alertesecuritaire_statut" to table: "alerte_securitaires"
+DROP INDEX "alertesecuritaire_statut";
+-- reverse: create index "alertesecuritaire_niveau" to table: "alerte_securitaires"
+DROP INDEX "alertesecuritaire_niveau";
+-- reverse: create index "alertesecuritaire_code" to table: "alerte_securitaires"
+DROP INDEX "alertesecuritaire_code";
+-- reverse: create index "alerte_securitaires_numero_key" to table: "alerte_securitaires"
+DROP INDEX "alerte_securitaires_numero_key";
+-- reverse: create index "alerte_securitaires_code_key" to table: "alerte_securitaires"
+DROP INDEX "alerte_securitaires_code_key";
+-- reverse: create "alerte_securitaires" table
+DROP TABLE "alerte_securitaires";
+-- reverse: create index "acteenquete_date" to table: "acte_enquetes"
+DROP INDEX "acteenquete_date";
+-- reverse: create index "acteenquete_plainte_id" to table: "acte_enquetes"
+DROP INDEX "acteenquete_plainte_id";
+-- reverse: create "acte_enquetes" table
+DROP TABLE "acte_enquetes";
@@ -0,0 +1,490 @@
+-- create "acte_enquetes" table
+CREATE TABLE "acte_enquetes" ("id" uuid NOT NULL, "type" character varying NOT NULL, "date" timestamptz NOT NULL, "heure" character varying NULL, "duree" character varying NULL, "lieu" character varying NULL, "officier_charge" character varying NOT NULL, "description" text NOT NULL, "pv_numero" character varying NULL, "mandat_numero" character varying NULL, "personnes_presentes" jsonb NULL, "objets_saisis" jsonb NULL, "conclusions" text NULL, "documents_joints" jsonb NULL, "created_at" timestamptz NOT NULL, "plainte_id" uuid NOT NULL, PRIMARY KEY ("id"));
+-- create index "acteenquete_plainte_id" to table: "acte_enquetes"
+CREATE INDEX "acteenquete_plainte_id" ON "acte_enquetes" ("plainte_id");
+-- create index "acteenquete_date" to table: "acte_enquetes"
+CREATE INDEX "acteenquete_date" ON "acte_enquetes" ("date");
+-- create "alerte_securitaires" table
+CREATE TABLE "alerte_securitaires" ("id" uuid NOT NULL, "code" character varying NULL, "numero" character varying NOT NULL, "titre" character varying NOT NULL, "description" character varying NULL, "contexte" text NULL, "niveau" character varying NOT NULL DEFAULT 'MOYEN', "statut" character varying NOT NULL DEFAULT 'ACTIVE', "type_alerte" character varying NOT NULL, "localisation" character varying NULL, "lieu" character varying NULL, "precision_localisation" character varying NULL, "latitude" double precision NULL, "longitude" double precision NULL, "date_alerte" timestamptz NOT NULL, "date_resolution" timestamptz NULL, "date_cloture" timestamptz NULL, "risques" jsonb NULL, "personne_concernee" jsonb NULL, "vehicule" jsonb NULL, "suspect" jsonb NULL, "intervention" jsonb NULL, "evaluation" jsonb NULL, "actions" jsonb NULL, "rapport" jsonb NULL, "temoins" jsonb NULL, "documents" jsonb NULL, "suivis" jsonb NULL, "photos" jsonb NULL, "observations" text NULL, "diffusee" boolean NOT NULL DEFAULT false, "date_diffusion" timestamptz NULL, "diffusion_destinataires" jsonb NULL, "assignation_destinataires" jsonb NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "commissariat_alertes" uuid NULL, "user_alertes" uuid NULL, PRIMARY KEY ("id"));
+-- create index "alerte_securitaires_code_key" to table: "alerte_securitaires"
+CREATE UNIQUE INDEX "alerte_securitaires_code_key" ON "alerte_securitaires" ("code");
+-- create index "alerte_securitaires_numero_key" to table: "alerte_securitaires"
+CREATE UNIQUE INDEX "alerte_securitaires_numero_key" ON "alerte_securitaires" ("numero");
+-- create index "alertesecuritaire_code" to table: "alerte_securitaires"
+CREATE INDEX "alertesecuritaire_code" ON "alerte_securitaires" ("code");
+-- create index "alertesecuritaire_niveau" to table: "alerte_securitaires"
+CREATE INDEX "alertesecuritaire_niveau" ON "alerte_securitaires" ("niveau");
+-- create index "alertesecuritaire_statut" to table: "alerte_securitaires"
+CREATE INDEX "alertesecuritaire_statut" ON "alerte_securitaires" ("statut");
+-- create index "alertesecuritaire_type_alerte" to table: "alerte_securitaires"
+CREATE INDEX "alertesecuritaire_type_alerte" ON "alerte_securitaires" ("type_alerte");
+-- create index "alertesecuritaire_date_alerte" to table: "alerte_securitaires"
+CREATE INDEX "alertesecuritaire_date_alerte" ON "alerte_securitaires" ("date_alerte");
+-- create "audit_logs" table
+CREATE TABLE "audit_logs" ("id" uuid NOT NULL, "code" character varying NULL, "timestamp" timestamptz NOT NULL, "action" character varying NOT NULL, "resource_type" character varying NOT NULL, "resource_id" character varying NULL, "user_agent" character varying NULL, "ip_address" character varying NULL, "details" text NULL, "old_values" text NULL, "new_values" text NULL, "session_id" character varying NULL, "status" character varying NOT NULL DEFAULT 'SUCCESS', "error_message" character varying NULL, "user_audit_logs" uuid NULL, PRIMARY KEY ("id"));
+-- create index "audit_logs_code_key" to table: "audit_logs"
+CREATE UNIQUE INDEX "audit_logs_code_key" ON "audit_logs" ("code");
+-- create index "auditlog_code" to table: "audit_logs"
+CREATE INDEX "auditlog_code" ON "audit_logs" ("code");
+-- create index "auditlog_timestamp" to table: "audit_logs"
+CREATE INDEX "auditlog_timestamp" ON "audit_logs" ("timestamp");
+-- create index "auditlog_action" to table: "audit_logs"
+CREATE INDEX "auditlog_action" ON "audit_logs" ("action");
+-- create index "auditlog_resource_type" to table: "audit_logs"
+CREATE INDEX "auditlog_resource_type" ON "audit_logs" ("resource_type");
+-- create index "auditlog_resource_id" to table: "audit_logs"
+CREATE INDEX "auditlog_resource_id" ON "audit_logs" ("resource_id");
+-- create index "auditlog_status" to table: "audit_logs"
+CREATE INDEX "auditlog_status" ON "audit_logs" ("status");
+-- create index "auditlog_session_id" to table: "audit_logs"
+CREATE INDEX "auditlog_session_id" ON "audit_logs" ("session_id");
+-- create index "auditlog_ip_address" to table: "audit_logs"
+CREATE INDEX "auditlog_ip_address" ON "audit_logs" ("ip_address");
+-- create "check_items" table
+CREATE TABLE "check_items" ("id" uuid NOT NULL, "code" character varying NULL, "item_name" character varying NOT NULL, "item_code" character varying NOT NULL, "item_category" character varying NOT NULL, "applicable_to" character varying NOT NULL DEFAULT 'BOTH', "description" character varying NULL, "icon" character varying NOT NULL DEFAULT 'check_circle', "is_mandatory" boolean NOT NULL DEFAULT false, "is_active" boolean NOT NULL DEFAULT true, "display_order" bigint NOT NULL DEFAULT 0, "fine_amount" bigint NOT NULL DEFAULT 0, "points_retrait" bigint NOT NULL DEFAULT 0, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "infraction_type_check_items" uuid NULL, PRIMARY KEY ("id"));
+-- create index "check_items_code_key" to table: "check_items"
+CREATE UNIQUE INDEX "check_items_code_key" ON "check_items" ("code");
+-- create index "check_items_item_code_key" to table: "check_items"
+CREATE UNIQUE INDEX "check_items_item_code_key" ON "check_items" ("item_code");
+-- create index "checkitem_code" to table: "check_items"
+CREATE INDEX "checkitem_code" ON "check_items" ("code");
+-- create index "checkitem_item_code" to table: "check_items"
+CREATE INDEX "checkitem_item_code" ON "check_items" ("item_code");
+-- create index "checkitem_item_category" to table: "check_items"
+CREATE INDEX "checkitem_item_category" ON "check_items" ("item_category");
+-- create index "checkitem_applicable_to" to table: "check_items"
+CREATE INDEX "checkitem_applicable_to" ON "check_items" ("applicable_to");
+-- create index "checkitem_is_active" to table: "check_items"
+CREATE INDEX "checkitem_is_active" ON "check_items" ("is_active");
+-- create index "checkitem_display_order" to table: "check_items"
+CREATE INDEX "checkitem_display_order" ON "check_items" ("display_order");
+-- create "check_options" table
+CREATE TABLE "check_options" ("id" uuid NOT NULL, "code" character varying NULL, "source_type" character varying NOT NULL, "source_id" character varying NOT NULL, "result_status" character varying NOT NULL DEFAULT 'NOT_CHECKED', "notes" character varying NULL, "fine_amount" bigint NOT NULL DEFAULT 0, "checked_at" timestamptz NOT NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "check_item_check_options" uuid NOT NULL, "document_check_options" uuid NULL, PRIMARY KEY ("id"));
+-- create index "check_options_code_key" to table: "check_options"
+CREATE UNIQUE INDEX "check_options_code_key" ON "check_options" ("code");
+-- create index "checkoption_code" to table: "check_options"
+CREATE INDEX "checkoption_code" ON "check_options" ("code");
+-- create index "checkoption_source_type_source_id" to table: "check_options"
+CREATE INDEX "checkoption_source_type_source_id" ON "check_options" ("source_type", "source_id");
+-- create index "checkoption_result_status" to table: "check_options"
+CREATE INDEX "checkoption_result_status" ON "check_options" ("result_status");
+-- create "commissariats" table
+CREATE TABLE "commissariats" ("id" uuid NOT NULL, "nom" character varying NOT NULL, "code" character varying NOT NULL, "adresse" character varying NOT NULL, "ville" character varying NOT NULL, "region" character varying NOT NULL, "telephone" character varying NOT NULL, "email" character varying NULL, "latitude" double precision NULL, "longitude" double precision NULL, "actif" boolean NOT NULL DEFAULT true, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "commissariats_code_key" to table: "commissariats"
+CREATE UNIQUE INDEX "commissariats_code_key" ON "commissariats" ("code");
+-- create index "commissariat_code" to table: "commissariats"
+CREATE INDEX "commissariat_code" ON "commissariats" ("code");
+-- create index "commissariat_ville" to table: "commissariats"
+CREATE INDEX "commissariat_ville" ON "commissariats" ("ville");
+-- create index "commissariat_region" to table: "commissariats"
+CREATE INDEX "commissariat_region" ON "commissariats" ("region");
+-- create index "commissariat_actif" to table: "commissariats"
+CREATE INDEX "commissariat_actif" ON "commissariats" ("actif");
+-- create "competences" table
+CREATE TABLE "competences" ("id" uuid NOT NULL, "code" character varying NULL, "nom" character varying NOT NULL, "type" character varying NOT NULL DEFAULT 'SPECIALITE', "description" character varying NULL, "organisme" character varying NULL, "date_obtention" timestamptz NULL, "date_expiration" timestamptz NULL, "active" boolean NOT NULL DEFAULT true, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "competences_code_key" to table: "competences"
+CREATE UNIQUE INDEX "competences_code_key" ON "competences" ("code");
+-- create index "competence_code" to table: "competences"
+CREATE INDEX "competence_code" ON "competences" ("code");
+-- create index "competence_type" to table: "competences"
+CREATE INDEX "competence_type" ON "competences" ("type");
+-- create index "competence_nom" to table: "competences"
+CREATE INDEX "competence_nom" ON "competences" ("nom");
+-- create index "competence_active" to table: "competences"
+CREATE INDEX "competence_active" ON "competences" ("active");
+-- create "conducteurs" table
+CREATE TABLE "conducteurs" ("id" uuid NOT NULL, "code" character varying NULL, "nom" character varying NOT NULL, "prenom" character varying NOT NULL, "date_naissance" timestamptz NOT NULL, "lieu_naissance" character varying NULL, "adresse" character varying NULL, "code_postal" character varying NULL, "ville" character varying NULL, "telephone" character varying NULL, "email" character varying NULL, "numero_cni" character varying NULL, "numero_permis" character varying NULL, "permis_delivre_le" timestamptz NULL, "permis_valide_jusqu" timestamptz NULL, "categories_permis" character varying NULL, "points_permis" bigint NOT NULL DEFAULT 12, "nationalite" character varying NOT NULL DEFAULT 'FR', "active" boolean NOT NULL DEFAULT true, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "conducteurs_code_key" to table: "conducteurs"
+CREATE UNIQUE INDEX "conducteurs_code_key" ON "conducteurs" ("code");
+-- create index "conducteur_code" to table: "conducteurs"
+CREATE INDEX "conducteur_code" ON "conducteurs" ("code");
+-- create index "conducteur_nom_prenom" to table: "conducteurs"
+CREATE INDEX "conducteur_nom_prenom" ON "conducteurs" ("nom", "prenom");
+-- create index "conducteur_date_naissance" to table: "conducteurs"
+CREATE INDEX "conducteur_date_naissance" ON "conducteurs" ("date_naissance");
+-- create index "conducteur_numero_permis" to table: "conducteurs"
+CREATE INDEX "conducteur_numero_permis" ON "conducteurs" ("numero_permis");
+-- create index "conducteur_email" to table: "conducteurs"
+CREATE INDEX "conducteur_email" ON "conducteurs" ("email");
+-- create "controles" table
+CREATE TABLE "controles" ("id" uuid NOT NULL, "code" character varying NULL, "reference" character varying NULL, "type_controle" character varying NOT NULL DEFAULT 'GENERAL', "date_controle" timestamptz NOT NULL, "lieu_controle" character varying NOT NULL, "latitude" double precision NULL, "longitude" double precision NULL, "statut" character varying NOT NULL DEFAULT 'EN_COURS', "observations" text NULL, "total_verifications" bigint NOT NULL DEFAULT 0, "verifications_ok" bigint NOT NULL DEFAULT 0, "verifications_echec" bigint NOT NULL DEFAULT 0, "montant_total_amendes" bigint NOT NULL DEFAULT 0, "vehicule_immatriculation" character varying NOT NULL, "vehicule_marque" character varying NOT NULL, "vehicule_modele" character varying NOT NULL, "vehicule_annee" bigint NULL, "vehicule_couleur" character varying NULL, "vehicule_numero_chassis" character varying NULL, "vehicule_type" character varying NOT NULL DEFAULT 'VOITURE', "conducteur_numero_permis" character varying NOT NULL, "conducteur_nom" character varying NOT NULL, "conducteur_prenom" character varying NOT NULL, "conducteur_telephone" character varying NULL, "conducteur_adresse" character varying NULL, "is_archived" boolean NOT NULL DEFAULT false, "archived_at" timestamptz NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "commissariat_controles" uuid NULL, "conducteur_controles" uuid NULL, "proces_verbal_controle" uuid NULL, "user_controles" uuid NOT NULL, "vehicule_controles" uuid NULL, PRIMARY KEY ("id"));
+-- create index "controles_code_key" to table: "controles"
+CREATE UNIQUE INDEX "controles_code_key" ON "controles" ("code");
+-- create index "controles_reference_key" to table: "controles"
+CREATE UNIQUE INDEX "controles_reference_key" ON "controles" ("reference");
+-- create index "controles_proces_verbal_controle_key" to table: "controles"
+CREATE UNIQUE INDEX "controles_proces_verbal_controle_key" ON "controles" ("proces_verbal_controle");
+-- create index "controle_code" to table: "controles"
+CREATE INDEX "controle_code" ON "controles" ("code");
+-- create index "controle_reference" to table: "controles"
+CREATE INDEX "controle_reference" ON "controles" ("reference");
+-- create index "controle_date_controle" to table: "controles"
+CREATE INDEX "controle_date_controle" ON "controles" ("date_controle");
+-- create index "controle_lieu_controle" to table: "controles"
+CREATE INDEX "controle_lieu_controle" ON "controles" ("lieu_controle");
+-- create index "controle_statut" to table: "controles"
+CREATE INDEX "controle_statut" ON "controles" ("statut");
+-- create index "controle_type_controle" to table: "controles"
+CREATE INDEX "controle_type_controle" ON "controles" ("type_controle");
+-- create index "controle_vehicule_immatriculation" to table: "controles"
+CREATE INDEX "controle_vehicule_immatriculation" ON "controles" ("vehicule_immatriculation");
+-- create index "controle_conducteur_numero_permis" to table: "controles"
+CREATE INDEX "controle_conducteur_numero_permis" ON "controles" ("conducteur_numero_permis");
+-- create index "controle_is_archived" to table: "controles"
+CREATE INDEX "controle_is_archived" ON "controles" ("is_archived");
+-- create "convocations" table
+CREATE TABLE "convocations" ("id" uuid NOT NULL, "code" character varying NULL, "numero" character varying NOT NULL, "reference" character varying NULL, "type_convocation" character varying NOT NULL, "sous_type" character varying NULL, "urgence" character varying NOT NULL DEFAULT 'NORMALE', "priorite" character varying NOT NULL DEFAULT 'MOYENNE', "confidentialite" character varying NOT NULL DEFAULT 'STANDARD', "affaire_id" character varying NULL, "affaire_type" character varying NULL, "affaire_numero" character varying NULL, "affaire_titre" character varying NULL, "affaire_liee" character varying NULL, "section_judiciaire" character varying NULL, "infraction" character varying NULL, "qualification_legale" character varying NULL, "statut_personne" character varying NOT NULL, "qualite_convoque" character varying NULL, "convoque_nom" character varying NOT NULL, "convoque_prenom" character varying NOT NULL, "date_naissance" character varying NULL, "lieu_naissance" character varying NULL, "nationalite" character varying NULL, "type_piece" character varying NOT NULL, "numero_piece" character varying NOT NULL, "date_delivrance_piece" character varying NULL, "lieu_delivrance_piece" character varying NULL, "date_expiration_piece" character varying NULL, "convoque_telephone" character varying NULL, "convoque_telephone2" character varying NULL, "convoque_email" character varying NULL, "convoque_adresse" character varying NULL, "adresse_residence" character varying NULL, "adresse_professionnelle" character varying NULL, "dernier_lieu_connu" character varying NULL, "profession" character varying NULL, "situation_familiale" character varying NULL, "nombre_enfants" character varying NULL, "sexe" character varying NULL, "taille" character varying NULL, "poids" character varying NULL, "signes_particuliers" text NULL, "photo_identite" boolean NOT NULL DEFAULT false, "empreintes" boolean NOT NULL DEFAULT false, "date_creation" timestamptz NOT NULL, "heure_convocation" character varying NULL, "date_rdv" timestamptz NULL, "heure_rdv" character varying NULL, "duree_estimee" bigint NULL, "type_audience" character varying NULL, "date_envoi" timestamptz NULL, "date_honoration" timestamptz NULL, "statut" character varying NOT NULL DEFAULT 'CRÉATION', "lieu_rdv" character varying NULL, "bureau" character varying NULL, "salle_audience" character varying NULL, "point_rencontre" character varying NULL, "acces_specifique" text NULL, "convocateur_nom" character varying NULL, "convocateur_prenom" character varying NULL, "convocateur_matricule" character varying NULL, "convocateur_fonction" character varying NULL, "agents_presents" text NULL, "representant_parquet" boolean NOT NULL DEFAULT false, "nom_parquetier" character varying NULL, "expert_present" boolean NOT NULL DEFAULT false, "type_expert" character varying NULL, "interprete_necessaire" boolean NOT NULL DEFAULT false, "langue_interpretation" character varying NULL, "avocat_present" boolean NOT NULL DEFAULT false, "nom_avocat" character varying NULL, "barreau_avocat" character varying NULL, "motif" text NULL, "objet_precis" text NULL, "questions_preparatoires" text NULL, "pieces_a_apporter" text NULL, "documents_demandes" text NULL, "observations" text NULL, "resultat_audition" text NULL, "mode_envoi" character varying NOT NULL DEFAULT 'MANUEL', "reference_envoi" character varying NULL, "donnees_completes" jsonb NULL, "historique" jsonb NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "commissariat_convocations" uuid NULL, "plainte_convocations" uuid NULL, "user_convocations_creees" uuid NULL, PRIMARY KEY ("id"));
+-- create index "convocations_code_key" to table: "convocations"
+CREATE UNIQUE INDEX "convocations_code_key" ON "convocations" ("code");
+-- create index "convocations_numero_key" to table: "convocations"
+CREATE UNIQUE INDEX "convocations_numero_key" ON "convocations" ("numero");
+-- create index "convocation_code" to table: "convocations"
+CREATE INDEX "convocation_code" ON "convocations" ("code");
+-- create index "convocation_numero" to table: "convocations"
+CREATE INDEX "convocation_numero" ON "convocations" ("numero");
+-- create index "convocation_type_convocation" to table: "convocations"
+CREATE INDEX "convocation_type_convocation" ON "convocations" ("type_convocation");
+-- create index "convocation_statut" to table: "convocations"
+CREATE INDEX "convocation_statut" ON "convocations" ("statut");
+-- create index "convocation_date_rdv" to table: "convocations"
+CREATE INDEX "convocation_date_rdv" ON "convocations" ("date_rdv");
+-- create index "convocation_date_creation" to table: "convocations"
+CREATE INDEX "convocation_date_creation" ON "convocations" ("date_creation");
+-- create "decisions" table
+CREATE TABLE "decisions" ("id" uuid NOT NULL, "type" character varying NOT NULL, "date_decision" timestamptz NOT NULL, "autorite" character varying NOT NULL, "description" text NOT NULL, "motivation" text NULL, "dispositions" jsonb NULL, "suites" text NULL, "document_reference" character varying NULL, "notifiee" boolean NOT NULL DEFAULT false, "date_notification" timestamptz NULL, "created_at" timestamptz NOT NULL, "plainte_id" uuid NOT NULL, PRIMARY KEY ("id"));
+-- create index "decision_plainte_id" to table: "decisions"
+CREATE INDEX "decision_plainte_id" ON "decisions" ("plainte_id");
+-- create index "decision_date_decision" to table: "decisions"
+CREATE INDEX "decision_date_decision" ON "decisions" ("date_decision");
+-- create "documents" table
+CREATE TABLE "documents" ("id" uuid NOT NULL, "code" character varying NULL, "nom_fichier" character varying NOT NULL, "nom_original" character varying NOT NULL, "type_mime" character varying NOT NULL, "taille" bigint NOT NULL, "chemin_stockage" character varying NOT NULL, "type_document" character varying NOT NULL, "description" character varying NULL, "hash_fichier" character varying NULL, "public" boolean NOT NULL DEFAULT false, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "controle_documents" uuid NULL, "infraction_documents" uuid NULL, "proces_verbal_documents" uuid NULL, "recours_documents" uuid NULL, "user_documents" uuid NOT NULL, PRIMARY KEY ("id"));
+-- create index "documents_code_key" to table: "documents"
+CREATE UNIQUE INDEX "documents_code_key" ON "documents" ("code");
+-- create index "document_code" to table: "documents"
+CREATE INDEX "document_code" ON "documents" ("code");
+-- create index "document_type_document" to table: "documents"
+CREATE INDEX "document_type_document" ON "documents" ("type_document");
+-- create index "document_nom_fichier" to table: "documents"
+CREATE INDEX "document_nom_fichier" ON "documents" ("nom_fichier");
+-- create index "document_created_at" to table: "documents"
+CREATE INDEX "document_created_at" ON "documents" ("created_at");
+-- create index "document_hash_fichier" to table: "documents"
+CREATE INDEX "document_hash_fichier" ON "documents" ("hash_fichier");
+-- create "enquetes" table
+CREATE TABLE "enquetes" ("id" uuid NOT NULL, "type" character varying NOT NULL, "officier_charge" character varying NOT NULL, "date_debut" timestamptz NOT NULL, "date_fin" timestamptz NULL, "lieu" character varying NULL, "description" text NOT NULL, "resultats" text NULL, "personnes_interrogees" jsonb NULL, "preuves_collectees" jsonb NULL, "conclusions" text NULL, "statut" character varying NOT NULL DEFAULT 'EN_COURS', "documents" jsonb NULL, "created_at" timestamptz NOT NULL, "plainte_id" uuid NOT NULL, PRIMARY KEY ("id"));
+-- create index "enquete_plainte_id" to table: "enquetes"
+CREATE INDEX "enquete_plainte_id" ON "enquetes" ("plainte_id");
+-- create index "enquete_statut" to table: "enquetes"
+CREATE INDEX "enquete_statut" ON "enquetes" ("statut");
+-- create "equipes" table
+CREATE TABLE "equipes" ("id" uuid NOT NULL, "code" character varying NULL, "nom" character varying NOT NULL, "zone" character varying NULL, "description" character varying NULL, "active" boolean NOT NULL DEFAULT true, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "commissariat_equipes" uuid NULL, "equipe_chef_equipe" uuid NULL, PRIMARY KEY ("id"));
+-- create index "equipes_code_key" to table: "equipes"
+CREATE UNIQUE INDEX "equipes_code_key" ON "equipes" ("code");
+-- create index "equipe_code" to table: "equipes"
+CREATE INDEX "equipe_code" ON "equipes" ("code");
+-- create index "equipe_active" to table: "equipes"
+CREATE INDEX "equipe_active" ON "equipes" ("active");
+-- create "infractions" table
+CREATE TABLE "infractions" ("id" uuid NOT NULL, "code" character varying NULL, "numero_pv" character varying NULL, "date_infraction" timestamptz NOT NULL, "lieu_infraction" character varying NOT NULL, "circonstances" character varying NULL, "vitesse_retenue" double precision NULL, "vitesse_limitee" double precision NULL, "appareil_mesure" character varying NULL, "montant_amende" double precision NOT NULL DEFAULT 0, "points_retires" bigint NOT NULL DEFAULT 0, "statut" character varying NOT NULL DEFAULT 'CONSTATEE', "observations" text NULL, "flagrant_delit" boolean NOT NULL DEFAULT false, "accident" boolean NOT NULL DEFAULT false, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "check_option_infraction" uuid NULL, "conducteur_infractions" uuid NOT NULL, "controle_infractions" uuid NULL, "infraction_type_infractions" uuid NOT NULL, "proces_verbal_infractions" uuid NULL, "vehicule_infractions" uuid NOT NULL, PRIMARY KEY ("id"));
+-- create index "infractions_code_key" to table: "infractions"
+CREATE UNIQUE INDEX "infractions_code_key" ON "infractions" ("code");
+-- create index "infractions_numero_pv_key" to table: "infractions"
+CREATE UNIQUE INDEX "infractions_numero_pv_key" ON "infractions" ("numero_pv");
+-- create index "infractions_check_option_infraction_key" to table: "infractions"
+CREATE UNIQUE INDEX "infractions_check_option_infraction_key" ON "infractions" ("check_option_infraction");
+-- create index "infraction_code" to table: "infractions"
+CREATE INDEX "infraction_code" ON "infractions" ("code");
+-- create index "infraction_numero_pv" to table: "infractions"
+CREATE INDEX "infraction_numero_pv" ON "infractions" ("numero_pv");
+-- create index "infraction_date_infraction" to table: "infractions"
+CREATE INDEX "infraction_date_infraction" ON "infractions" ("date_infraction");
+-- create index "infraction_statut" to table: "infractions"
+CREATE INDEX "infraction_statut" ON "infractions" ("statut");
+-- create index "infraction_lieu_infraction" to table: "infractions"
+CREATE INDEX "infraction_lieu_infraction" ON "infractions" ("lieu_infraction");
+-- create "infraction_types" table
+CREATE TABLE "infraction_types" ("id" uuid NOT NULL, "code" character varying NOT NULL, "libelle" character varying NOT NULL, "description" character varying NULL, "amende" double precision NOT NULL, "points" bigint NOT NULL DEFAULT 0, "categorie" character varying NOT NULL, "active" boolean NOT NULL DEFAULT true, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "infraction_types_code_key" to table: "infraction_types"
+CREATE UNIQUE INDEX "infraction_types_code_key" ON "infraction_types" ("code");
+-- create index "infractiontype_code" to table: "infraction_types"
+CREATE INDEX "infractiontype_code" ON "infraction_types" ("code");
+-- create index "infractiontype_categorie" to table: "infraction_types"
+CREATE INDEX "infractiontype_categorie" ON "infraction_types" ("categorie");
+-- create index "infractiontype_active" to table: "infraction_types"
+CREATE INDEX "infractiontype_active" ON "infraction_types" ("active");
+-- create "inspections" table
+CREATE TABLE "inspections" ("id" uuid NOT NULL, "code" character varying NULL, "numero" character varying NOT NULL, "statut" character varying NOT NULL DEFAULT 'EN_ATTENTE', "observations" text NULL, "date_inspection" timestamptz NOT NULL, "total_verifications" bigint NOT NULL DEFAULT 0, "verifications_ok" bigint NOT NULL DEFAULT 0, "verifications_attention" bigint NOT NULL DEFAULT 0, "verifications_echec" bigint NOT NULL DEFAULT 0, "montant_total_amendes" bigint NOT NULL DEFAULT 0, "vehicule_immatriculation" character varying NOT NULL, "vehicule_marque" character varying NOT NULL, "vehicule_modele" character varying NOT NULL, "vehicule_annee" bigint NULL, "vehicule_couleur" character varying NULL, "vehicule_numero_chassis" character varying NULL, "vehicule_type" character varying NOT NULL DEFAULT 'VOITURE', "conducteur_numero_permis" character varying NOT NULL, "conducteur_prenom" character varying NOT NULL, "conducteur_nom" character varying NOT NULL, "conducteur_telephone" character varying NULL, "conducteur_adresse" character varying NULL, "conducteur_type_piece" character varying NULL, "conducteur_numero_piece" character varying NULL, "assurance_compagnie" character varying NULL, "assurance_numero_police" character varying NULL, "assurance_date_expiration" timestamptz NULL, "assurance_statut" character varying NOT NULL DEFAULT 'INCONNU', "lieu_inspection" character varying NULL, "latitude" double precision NULL, "longitude" double precision NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "commissariat_inspections" uuid NULL, "proces_verbal_inspection" uuid NULL, "user_inspections_realisees" uuid NOT NULL, "vehicule_inspections" uuid NULL, PRIMARY KEY ("id"));
+-- create index "inspections_code_key" to table: "inspections"
+CREATE UNIQUE INDEX "inspections_code_key" ON "inspections" ("code");
+-- create index "inspections_numero_key" to table: "inspections"
+CREATE UNIQUE INDEX "inspections_numero_key" ON "inspections" ("numero");
+-- create index "inspections_proces_verbal_inspection_key" to table: "inspections"
+CREATE UNIQUE INDEX "inspections_proces_verbal_inspection_key" ON "inspections" ("proces_verbal_inspection");
+-- create index "inspection_code" to table: "inspections"
+CREATE INDEX "inspection_code" ON "inspections" ("code");
+-- create index "inspection_numero" to table: "inspections"
+CREATE INDEX "inspection_numero" ON "inspections" ("numero");
+-- create index "inspection_statut" to table: "inspections"
+CREATE INDEX "inspection_statut" ON "inspections" ("statut");
+-- create index "inspection_date_inspection" to table: "inspections"
+CREATE INDEX "inspection_date_inspection" ON "inspections" ("date_inspection");
+-- create index "inspection_vehicule_immatriculation" to table: "inspections"
+CREATE INDEX "inspection_vehicule_immatriculation" ON "inspections" ("vehicule_immatriculation");
+-- create index "inspection_conducteur_numero_permis" to table: "inspections"
+CREATE INDEX "inspection_conducteur_numero_permis" ON "inspections" ("conducteur_numero_permis");
+-- create index "inspection_assurance_statut" to table: "inspections"
+CREATE INDEX "inspection_assurance_statut" ON "inspections" ("assurance_statut");
+-- create "missions" table
+CREATE TABLE "missions" ("id" uuid NOT NULL, "code" character varying NULL, "type" character varying NOT NULL, "titre" character varying NULL, "description" character varying NULL, "date_debut" timestamptz NOT NULL, "date_fin" timestamptz NULL, "duree" character varying NULL, "zone" character varying NULL, "statut" character varying NOT NULL DEFAULT 'PLANIFIEE', "rapport" character varying NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "commissariat_missions" uuid NULL, "equipe_missions" uuid NULL, PRIMARY KEY ("id"));
+-- create index "missions_code_key" to table: "missions"
+CREATE UNIQUE INDEX "missions_code_key" ON "missions" ("code");
+-- create index "mission_code" to table: "missions"
+CREATE INDEX "mission_code" ON "missions" ("code");
+-- create index "mission_type" to table: "missions"
+CREATE INDEX "mission_type" ON "missions" ("type");
+-- create index "mission_statut" to table: "missions"
+CREATE INDEX "mission_statut" ON "missions" ("statut");
+-- create index "mission_date_debut" to table: "missions"
+CREATE INDEX "mission_date_debut" ON "missions" ("date_debut");
+-- create "objectifs" table
+CREATE TABLE "objectifs" ("id" uuid NOT NULL, "code" character varying NULL, "titre" character varying NOT NULL, "description" character varying NULL, "periode" character varying NOT NULL DEFAULT 'mois', "date_debut" timestamptz NOT NULL, "date_fin" timestamptz NULL, "statut" character varying NOT NULL DEFAULT 'EN_COURS', "valeur_cible" bigint NULL, "valeur_actuelle" bigint NOT NULL DEFAULT 0, "progression" double precision NOT NULL DEFAULT 0, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "objectif_assigne_par" uuid NULL, "user_objectifs" uuid NOT NULL, PRIMARY KEY ("id"));
+-- create index "objectifs_code_key" to table: "objectifs"
+CREATE UNIQUE INDEX "objectifs_code_key" ON "objectifs" ("code");
+-- create index "objectif_code" to table: "objectifs"
+CREATE INDEX "objectif_code" ON "objectifs" ("code");
+-- create index "objectif_periode" to table: "objectifs"
+CREATE INDEX "objectif_periode" ON "objectifs" ("periode");
+-- create index "objectif_statut" to table: "objectifs"
+CREATE INDEX "objectif_statut" ON "objectifs" ("statut");
+-- create index "objectif_date_debut" to table: "objectifs"
+CREATE INDEX "objectif_date_debut" ON "objectifs" ("date_debut");
+-- create "objet_perdus" table
+CREATE TABLE "objet_perdus" ("id" uuid NOT NULL, "numero" character varying NOT NULL, "type_objet" character varying NOT NULL, "description" text NOT NULL, "valeur_estimee" character varying NULL, "couleur" character varying NULL, "details_specifiques" jsonb NULL, "is_container" boolean NOT NULL DEFAULT false, "container_details" jsonb NULL, "declarant" jsonb NOT NULL, "lieu_perte" character varying NOT NULL, "adresse_lieu" character varying NULL, "date_perte" timestamptz NOT NULL, "heure_perte" character varying NULL, "statut" character varying NOT NULL DEFAULT 'EN_RECHERCHE', "date_declaration" timestamptz NOT NULL, "date_retrouve" timestamptz NULL, "observations" text NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "commissariat_objets_perdus" uuid NULL, "user_objets_perdus" uuid NULL, PRIMARY KEY ("id"));
+-- create index "objet_perdus_numero_key" to table: "objet_perdus"
+CREATE UNIQUE INDEX "objet_perdus_numero_key" ON "objet_perdus" ("numero");
+-- create index "objetperdu_numero" to table: "objet_perdus"
+CREATE INDEX "objetperdu_numero" ON "objet_perdus" ("numero");
+-- create index "objetperdu_type_objet" to table: "objet_perdus"
+CREATE INDEX "objetperdu_type_objet" ON "objet_perdus" ("type_objet");
+-- create index "objetperdu_statut" to table: "objet_perdus"
+CREATE INDEX "objetperdu_statut" ON "objet_perdus" ("statut");
+-- create index "objetperdu_date_declaration" to table: "objet_perdus"
+CREATE INDEX "objetperdu_date_declaration" ON "objet_perdus" ("date_declaration");
+-- create "objet_retrouves" table
+CREATE TABLE "objet_retrouves" ("id" uuid NOT NULL, "numero" character varying NOT NULL, "type_objet" character varying NOT NULL, "description" text NOT NULL, "valeur_estimee" character varying NULL, "couleur" character varying NULL, "details_specifiques" jsonb NULL, "is_container" boolean NOT NULL DEFAULT false, "container_details" jsonb NULL, "deposant" jsonb NOT NULL, "lieu_trouvaille" character varying NOT NULL, "adresse_lieu" character varying NULL, "date_trouvaille" timestamptz NOT NULL, "heure_trouvaille" character varying NULL, "statut" character varying NOT NULL DEFAULT 'DISPONIBLE', "date_depot" timestamptz NOT NULL, "date_restitution" timestamptz NULL, "proprietaire" jsonb NULL, "observations" text NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "commissariat_objets_retrouves" uuid NULL, "user_objets_retrouves" uuid NULL, PRIMARY KEY ("id"));
+-- create index "objet_retrouves_numero_key" to table: "objet_retrouves"
+CREATE UNIQUE INDEX "objet_retrouves_numero_key" ON "objet_retrouves" ("numero");
+-- create index "objetretrouve_numero" to table: "objet_retrouves"
+CREATE INDEX "objetretrouve_numero" ON "objet_retrouves" ("numero");
+-- create index "objetretrouve_type_objet" to table: "objet_retrouves"
+CREATE INDEX "objetretrouve_type_objet" ON "objet_retrouves" ("type_objet");
+-- create index "objetretrouve_statut" to table: "objet_retrouves"
+CREATE INDEX "objetretrouve_statut" ON "objet_retrouves" ("statut");
+-- create index "objetretrouve_date_depot" to table: "objet_retrouves"
+CREATE INDEX "objetretrouve_date_depot" ON "objet_retrouves" ("date_depot");
+-- create "observations" table
+CREATE TABLE "observations" ("id" uuid NOT NULL, "code" character varying NULL, "contenu" character varying NOT NULL, "type" character varying NOT NULL DEFAULT 'NEUTRE', "categorie" character varying NULL, "periode" character varying NULL, "date_observation" timestamptz NOT NULL, "visible_agent" boolean NOT NULL DEFAULT true, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "observation_auteur" uuid NOT NULL, "user_observations" uuid NOT NULL, PRIMARY KEY ("id"));
+-- create index "observations_code_key" to table: "observations"
+CREATE UNIQUE INDEX "observations_code_key" ON "observations" ("code");
+-- create index "observation_code" to table: "observations"
+CREATE INDEX "observation_code" ON "observations" ("code");
+-- create index "observation_type" to table: "observations"
+CREATE INDEX "observation_type" ON "observations" ("type");
+-- create index "observation_date_observation" to table: "observations"
+CREATE INDEX "observation_date_observation" ON "observations" ("date_observation");
+-- create "paiements" table
+CREATE TABLE "paiements" ("id" uuid NOT NULL, "code" character varying NULL, "numero_transaction" character varying NOT NULL, "date_paiement" timestamptz NOT NULL, "montant" double precision NOT NULL, "moyen_paiement" character varying NOT NULL, "reference_externe" character varying NULL, "statut" character varying NOT NULL DEFAULT 'EN_COURS', "code_autorisation" character varying NULL, "details_paiement" text NULL, "date_validation" timestamptz NULL, "motif_refus" character varying NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "proces_verbal_paiements" uuid NOT NULL, PRIMARY KEY ("id"));
+-- create index "paiements_code_key" to table: "paiements"
+CREATE UNIQUE INDEX "paiements_code_key" ON "paiements" ("code");
+-- create index "paiements_numero_transaction_key" to table: "paiements"
+CREATE UNIQUE INDEX "paiements_numero_transaction_key" ON "paiements" ("numero_transaction");
+-- create index "paiement_code" to table: "paiements"
+CREATE INDEX "paiement_code" ON "paiements" ("code");
+-- create index "paiement_numero_transaction" to table: "paiements"
+CREATE INDEX "paiement_numero_transaction" ON "paiements" ("numero_transaction");
+-- create index "paiement_date_paiement" to table: "paiements"
+CREATE INDEX "paiement_date_paiement" ON "paiements" ("date_paiement");
+-- create index "paiement_statut" to table: "paiements"
+CREATE INDEX "paiement_statut" ON "paiements" ("statut");
+-- create index "paiement_moyen_paiement" to table: "paiements"
+CREATE INDEX "paiement_moyen_paiement" ON "paiements" ("moyen_paiement");
+-- create index "paiement_reference_externe" to table: "paiements"
+CREATE INDEX "paiement_reference_externe" ON "paiements" ("reference_externe");
+-- create "plaintes" table
+CREATE TABLE "plaintes" ("id" uuid NOT NULL, "code" character varying NULL, "numero" character varying NOT NULL, "type_plainte" character varying NOT NULL, "description" character varying NULL, "plaignant_nom" character varying NOT NULL, "plaignant_prenom" character varying NOT NULL, "plaignant_telephone" character varying NULL, "plaignant_adresse" character varying NULL, "plaignant_email" character varying NULL, "date_depot" timestamptz NOT NULL, "date_resolution" timestamptz NULL, "etape_actuelle" character varying NOT NULL DEFAULT 'DEPOT', "priorite" character varying NOT NULL DEFAULT 'NORMALE', "statut" character varying NOT NULL DEFAULT 'EN_COURS', "delai_sla" character varying NULL, "sla_depasse" boolean NOT NULL DEFAULT false, "lieu_faits" character varying NULL, "date_faits" timestamptz NULL, "observations" character varying NULL, "decision_finale" character varying NULL, "suspects" jsonb NULL, "temoins" jsonb NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "commissariat_plaintes" uuid NULL, "user_plaintes_assignees" uuid NULL, PRIMARY KEY ("id"));
+-- create index "plaintes_code_key" to table: "plaintes"
+CREATE UNIQUE INDEX "plaintes_code_key" ON "plaintes" ("code");
+-- create index "plaintes_numero_key" to table: "plaintes"
+CREATE UNIQUE INDEX "plaintes_numero_key" ON "plaintes" ("numero");
+-- create index "plainte_code" to table: "plaintes"
+CREATE INDEX "plainte_code" ON "plaintes" ("code");
+-- create index "plainte_numero" to table: "plaintes"
+CREATE INDEX "plainte_numero" ON "plaintes" ("numero");
+-- create index "plainte_type_plainte" to table: "plaintes"
+CREATE INDEX "plainte_type_plainte" ON "plaintes" ("type_plainte");
+-- create index "plainte_statut" to table: "plaintes"
+CREATE INDEX "plainte_statut" ON "plaintes" ("statut");
+-- create index "plainte_priorite" to table: "plaintes"
+CREATE INDEX "plainte_priorite" ON "plaintes" ("priorite");
+-- create index "plainte_etape_actuelle" to table: "plaintes"
+CREATE INDEX "plainte_etape_actuelle" ON "plaintes" ("etape_actuelle");
+-- create index "plainte_date_depot" to table: "plaintes"
+CREATE INDEX "plainte_date_depot" ON "plaintes" ("date_depot");
+-- create "plainte_historiques" table
+CREATE TABLE "plainte_historiques" ("id" uuid NOT NULL, "user_id" uuid NULL, "type_changement" character varying NOT NULL, "champ_modifie" character varying NOT NULL, "ancienne_valeur" character varying NULL, "nouvelle_valeur" character varying NOT NULL, "commentaire" text NULL, "auteur_nom" character varying NULL, "created_at" timestamptz NOT NULL, "plainte_id" uuid NOT NULL, PRIMARY KEY ("id"));
+-- create index "plaintehistorique_plainte_id" to table: "plainte_historiques"
+CREATE INDEX "plaintehistorique_plainte_id" ON "plainte_historiques" ("plainte_id");
+-- create index "plaintehistorique_created_at" to table: "plainte_historiques"
+CREATE INDEX "plaintehistorique_created_at" ON "plainte_historiques" ("created_at");
+-- create "preuves" table
+CREATE TABLE "preuves" ("id" uuid NOT NULL, "numero_piece" character varying NOT NULL, "type" character varying NOT NULL, "description" text NOT NULL, "lieu_conservation" character varying NULL, "date_collecte" timestamptz NOT NULL, "collecte_par" character varying NULL, "photos" jsonb NULL, "hash_verification" character varying NULL, "expertise_demandee" boolean NOT NULL DEFAULT false, "expertise_type" character varying NULL, "expertise_resultat" text NULL, "statut" character varying NOT NULL DEFAULT 'COLLECTEE', "created_at" timestamptz NOT NULL, "plainte_id" uuid NOT NULL, PRIMARY KEY ("id"));
+-- create index "preuve_plainte_id" to table: "preuves"
+CREATE INDEX "preuve_plainte_id" ON "preuves" ("plainte_id");
+-- create index "preuve_numero_piece" to table: "preuves"
+CREATE INDEX "preuve_numero_piece" ON "preuves" ("numero_piece");
+-- create "proces_verbals" table
+CREATE TABLE "proces_verbals" ("id" uuid NOT NULL, "code" character varying NULL, "numero_pv" character varying NOT NULL, "date_emission" timestamptz NOT NULL, "montant_total" double precision NOT NULL, "montant_majore" double precision NULL, "date_limite_paiement" timestamptz NULL, "date_majoration" timestamptz NULL, "statut" character varying NOT NULL DEFAULT 'EMIS', "date_paiement" timestamptz NULL, "montant_paye" double precision NULL, "moyen_paiement" character varying NULL, "reference_paiement" character varying NULL, "date_contestation" timestamptz NULL, "motif_contestation" character varying NULL, "decision_contestation" text NULL, "tribunal_competent" character varying NULL, "observations" text NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "proces_verbals_code_key" to table: "proces_verbals"
+CREATE UNIQUE INDEX "proces_verbals_code_key" ON "proces_verbals" ("code");
+-- create index "proces_verbals_numero_pv_key" to table: "proces_verbals"
+CREATE UNIQUE INDEX "proces_verbals_numero_pv_key" ON "proces_verbals" ("numero_pv");
+-- create index "procesverbal_code" to table: "proces_verbals"
+CREATE INDEX "procesverbal_code" ON "proces_verbals" ("code");
+-- create index "procesverbal_numero_pv" to table: "proces_verbals"
+CREATE INDEX "procesverbal_numero_pv" ON "proces_verbals" ("numero_pv");
+-- create index "procesverbal_date_emission" to table: "proces_verbals"
+CREATE INDEX "procesverbal_date_emission" ON "proces_verbals" ("date_emission");
+-- create index "procesverbal_statut" to table: "proces_verbals"
+CREATE INDEX "procesverbal_statut" ON "proces_verbals" ("statut");
+-- create index "procesverbal_date_limite_paiement" to table: "proces_verbals"
+CREATE INDEX "procesverbal_date_limite_paiement" ON "proces_verbals" ("date_limite_paiement");
+-- create index "procesverbal_date_paiement" to table: "proces_verbals"
+CREATE INDEX "procesverbal_date_paiement" ON "proces_verbals" ("date_paiement");
+-- create "recours" table
+CREATE TABLE "recours" ("id" uuid NOT NULL, "code" character varying NULL, "numero_recours" character varying NOT NULL, "date_recours" timestamptz NOT NULL, "type_recours" character varying NOT NULL, "motif" character varying NOT NULL, "argumentaire" text NOT NULL, "statut" character varying NOT NULL DEFAULT 'DEPOSE', "date_traitement" timestamptz NULL, "decision" character varying NULL, "motif_decision" text NULL, "autorite_competente" character varying NULL, "reference_decision" character varying NULL, "nouveau_montant" double precision NULL, "date_limite_recours" timestamptz NULL, "recours_possible" boolean NOT NULL DEFAULT true, "observations" text NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "proces_verbal_recours" uuid NOT NULL, "user_recours_traites" uuid NULL, PRIMARY KEY ("id"));
+-- create index "recours_code_key" to table: "recours"
+CREATE UNIQUE INDEX "recours_code_key" ON "recours" ("code");
+-- create index "recours_numero_recours_key" to table: "recours"
+CREATE UNIQUE INDEX "recours_numero_recours_key" ON "recours" ("numero_recours");
+-- create index "recours_code" to table: "recours"
+CREATE INDEX "recours_code" ON "recours" ("code");
+-- create index "recours_numero_recours" to table: "recours"
+CREATE INDEX "recours_numero_recours" ON "recours" ("numero_recours");
+-- create index "recours_date_recours" to table: "recours"
+CREATE INDEX "recours_date_recours" ON "recours" ("date_recours");
+-- create index "recours_statut" to table: "recours"
+CREATE INDEX "recours_statut" ON "recours" ("statut");
+-- create index "recours_type_recours" to table: "recours"
+CREATE INDEX "recours_type_recours" ON "recours" ("type_recours");
+-- create index "recours_date_traitement" to table: "recours"
+CREATE INDEX "recours_date_traitement" ON "recours" ("date_traitement");
+-- create "timeline_events" table
+CREATE TABLE "timeline_events" ("id" uuid NOT NULL, "date" timestamptz NOT NULL, "heure" character varying NULL, "type" character varying NOT NULL, "titre" character varying NOT NULL, "description" text NOT NULL, "acteur" character varying NULL, "statut" character varying NULL, "documents" jsonb NULL, "created_at" timestamptz NOT NULL, "plainte_id" uuid NOT NULL, PRIMARY KEY ("id"));
+-- create index "timelineevent_plainte_id" to table: "timeline_events"
+CREATE INDEX "timelineevent_plainte_id" ON "timeline_events" ("plainte_id");
+-- create index "timelineevent_date" to table: "timeline_events"
+CREATE INDEX "timelineevent_date" ON "timeline_events" ("date");
+-- create "users" table
+CREATE TABLE "users" ("id" uuid NOT NULL, "code" character varying NULL, "matricule" character varying NOT NULL, "nom" character varying NOT NULL, "prenom" character varying NOT NULL, "email" character varying NOT NULL, "password" character varying NOT NULL, "role" character varying NOT NULL DEFAULT 'agent', "grade" character varying NULL, "telephone" character varying NULL, "date_naissance" timestamptz NULL, "cni" character varying NULL, "adresse" character varying NULL, "date_entree" timestamptz NULL, "statut_service" character varying NOT NULL DEFAULT 'HORS_SERVICE', "localisation" character varying NULL, "activite" character varying NULL, "derniere_activite" timestamptz NULL, "gps_precision" double precision NOT NULL DEFAULT 0, "temps_service" character varying NULL, "active" boolean NOT NULL DEFAULT true, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "commissariat_agents" uuid NULL, "equipe_membres" uuid NULL, "user_subordonnes" uuid NULL, PRIMARY KEY ("id"), CONSTRAINT "users_users_subordonnes" FOREIGN KEY ("user_subordonnes") REFERENCES "users" ("id") ON DELETE SET NULL);
+-- create index "users_code_key" to table: "users"
+CREATE UNIQUE INDEX "users_code_key" ON "users" ("code");
+-- create index "users_matricule_key" to table: "users"
+CREATE UNIQUE INDEX "users_matricule_key" ON "users" ("matricule");
+-- create index "users_email_key" to table: "users"
+CREATE UNIQUE INDEX "users_email_key" ON "users" ("email");
+-- create index "user_code" to table: "users"
+CREATE INDEX "user_code" ON "users" ("code");
+-- create index "user_matricule" to table: "users"
+CREATE INDEX "user_matricule" ON "users" ("matricule");
+-- create index "user_email" to table: "users"
+CREATE INDEX "user_email" ON "users" ("email");
+-- create index "user_role" to table: "users"
+CREATE INDEX "user_role" ON "users" ("role");
+-- create index "user_active" to table: "users"
+CREATE INDEX "user_active" ON "users" ("active");
+-- create index "user_statut_service" to table: "users"
+CREATE INDEX "user_statut_service" ON "users" ("statut_service");
+-- create "user_sessions" table
+CREATE TABLE "user_sessions" ("id" uuid NOT NULL, "device_id" character varying NOT NULL, "device_name" character varying NULL, "device_type" character varying NULL, "device_os" character varying NULL, "app_version" character varying NULL, "refresh_token_hash" character varying NOT NULL, "refresh_token_expires_at" timestamptz NOT NULL, "session_started_at" timestamptz NOT NULL, "last_activity_at" timestamptz NOT NULL, "last_ip_address" character varying NULL, "is_active" boolean NOT NULL DEFAULT true, "is_revoked" boolean NOT NULL DEFAULT false, "revoked_at" timestamptz NULL, "revoked_reason" character varying NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, "user_sessions" uuid NOT NULL, PRIMARY KEY ("id"));
+-- create index "user_sessions_refresh_token_hash_key" to table: "user_sessions"
+CREATE UNIQUE INDEX "user_sessions_refresh_token_hash_key" ON "user_sessions" ("refresh_token_hash");
+-- create index "usersession_device_id" to table: "user_sessions"
+CREATE INDEX "usersession_device_id" ON "user_sessions" ("device_id");
+-- create index "usersession_is_active_is_revoked" to table: "user_sessions"
+CREATE INDEX "usersession_is_active_is_revoked" ON "user_sessions" ("is_active", "is_revoked");
+-- create index "usersession_last_activity_at" to table: "user_sessions"
+CREATE INDEX "usersession_last_activity_at" ON "user_sessions" ("last_activity_at");
+-- create "vehicules" table
+CREATE TABLE "vehicules" ("id" uuid NOT NULL, "code" character varying NULL, "immatriculation" character varying NOT NULL, "marque" character varying NOT NULL, "modele" character varying NOT NULL, "couleur" character varying NULL, "annee" bigint NULL, "type_vehicule" character varying NOT NULL DEFAULT 'VP', "energie" character varying NULL, "date_premiere_mise_en_circulation" timestamptz NULL, "numero_chassis" character varying NULL, "proprietaire_nom" character varying NULL, "proprietaire_prenom" character varying NULL, "proprietaire_adresse" character varying NULL, "assurance_compagnie" character varying NULL, "assurance_numero" character varying NULL, "assurance_validite" timestamptz NULL, "controle_technique_validite" timestamptz NULL, "active" boolean NOT NULL DEFAULT true, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "vehicules_code_key" to table: "vehicules"
+CREATE UNIQUE INDEX "vehicules_code_key" ON "vehicules" ("code");
+-- create index "vehicules_immatriculation_key" to table: "vehicules"
+CREATE UNIQUE INDEX "vehicules_immatriculation_key" ON "vehicules" ("immatriculation");
+-- create index "vehicule_code" to table: "vehicules"
+CREATE INDEX "vehicule_code" ON "vehicules" ("code");
+-- create index "vehicule_immatriculation" to table: "vehicules"
+CREATE INDEX "vehicule_immatriculation" ON "vehicules" ("immatriculation");
+-- create index "vehicule_marque_modele" to table: "vehicules"
+CREATE INDEX "vehicule_marque_modele" ON "vehicules" ("marque", "modele");
+-- create index "vehicule_proprietaire_nom_proprietaire_prenom" to table: "vehicules"
+CREATE INDEX "vehicule_proprietaire_nom_proprietaire_prenom" ON "vehicules" ("proprietaire_nom", "proprietaire_prenom");
+-- create "mission_agents" table
+CREATE TABLE "mission_agents" ("mission_id" uuid NOT NULL, "user_id" uuid NOT NULL, PRIMARY KEY ("mission_id", "user_id"));
+-- create "user_competences" table
+CREATE TABLE "user_competences" ("user_id" uuid NOT NULL, "competence_id" uuid NOT NULL, PRIMARY KEY ("user_id", "competence_id"));
+-- modify "acte_enquetes" table
+ALTER TABLE "acte_enquetes" ADD CONSTRAINT "acte_enquetes_plaintes_actes_enquete" FOREIGN KEY ("plainte_id") REFERENCES "plaintes" ("id") ON DELETE NO ACTION;
+-- modify "alerte_securitaires" table
+ALTER TABLE "alerte_securitaires" ADD CONSTRAINT "alerte_securitaires_commissariats_alertes" FOREIGN KEY ("commissariat_alertes") REFERENCES "commissariats" ("id") ON DELETE SET NULL, ADD CONSTRAINT "alerte_securitaires_users_alertes" FOREIGN KEY ("user_alertes") REFERENCES "users" ("id") ON DELETE SET NULL;
+-- modify "audit_logs" table
+ALTER TABLE "audit_logs" ADD CONSTRAINT "audit_logs_users_audit_logs" FOREIGN KEY ("user_audit_logs") REFERENCES "users" ("id") ON DELETE SET NULL;
+-- modify "check_items" table
+ALTER TABLE "check_items" ADD CONSTRAINT "check_items_infraction_types_check_items" FOREIGN KEY ("infraction_type_check_items") REFERENCES "infraction_types" ("id") ON DELETE SET NULL;
+-- modify "check_options" table
+ALTER TABLE "check_options" ADD CONSTRAINT "check_options_check_items_check_options" FOREIGN KEY ("check_item_check_options") REFERENCES "check_items" ("id") ON DELETE NO ACTION, ADD CONSTRAINT "check_options_documents_check_options" FOREIGN KEY ("document_check_options") REFERENCES "documents" ("id") ON DELETE SET NULL;
+-- modify "controles" table
+ALTER TABLE "controles" ADD CONSTRAINT "controles_commissariats_controles" FOREIGN KEY ("commissariat_controles") REFERENCES "commissariats" ("id") ON DELETE SET NULL, ADD CONSTRAINT "controles_conducteurs_controles" FOREIGN KEY ("conducteur_controles") REFERENCES "conducteurs" ("id") ON DELETE SET NULL, ADD CONSTRAINT "controles_proces_verbals_controle" FOREIGN KEY ("proces_verbal_controle") REFERENCES "proces_verbals" ("id") ON DELETE SET NULL, ADD CONSTRAINT "controles_users_controles" FOREIGN KEY ("user_controles") REFERENCES "users" ("id") ON DELETE NO ACTION, ADD CONSTRAINT "controles_vehicules_controles" FOREIGN KEY ("vehicule_controles") REFERENCES "vehicules" ("id") ON DELETE SET NULL;
+-- modify "convocations" table
+ALTER TABLE "convocations" ADD CONSTRAINT "convocations_commissariats_convocations" FOREIGN KEY ("commissariat_convocations") REFERENCES "commissariats" ("id") ON DELETE SET NULL, ADD CONSTRAINT "convocations_plaintes_convocations" FOREIGN KEY ("plainte_convocations") REFERENCES "plaintes" ("id") ON DELETE SET NULL, ADD CONSTRAINT "convocations_users_convocations_creees" FOREIGN KEY ("user_convocations_creees") REFERENCES "users" ("id") ON DELETE SET NULL;
+-- modify "decisions" table
+ALTER TABLE "decisions" ADD CONSTRAINT "decisions_plaintes_decisions" FOREIGN KEY ("plainte_id") REFERENCES "plaintes" ("id") ON DELETE NO ACTION;
+-- modify "documents" table
+ALTER TABLE "documents" ADD CONSTRAINT "documents_controles_documents" FOREIGN KEY ("controle_documents") REFERENCES "controles" ("id") ON DELETE SET NULL, ADD CONSTRAINT "documents_infractions_documents" FOREIGN KEY ("infraction_documents") REFERENCES "infractions" ("id") ON DELETE SET NULL, ADD CONSTRAINT "documents_proces_verbals_documents" FOREIGN KEY ("proces_verbal_documents") REFERENCES "proces_verbals" ("id") ON DELETE SET NULL, ADD CONSTRAINT "documents_recours_documents" FOREIGN KEY ("recours_documents") REFERENCES "recours" ("id") ON DELETE SET NULL, ADD CONSTRAINT "documents_users_documents" FOREIGN KEY ("user_documents") REFERENCES "users" ("id") ON DELETE NO ACTION;
+-- modify "enquetes" table
+ALTER TABLE "enquetes" ADD CONSTRAINT "enquetes_plaintes_enquetes" FOREIGN KEY ("plainte_id") REFERENCES "plaintes" ("id") ON DELETE NO ACTION;
+-- modify "equipes" table
+ALTER TABLE "equipes" ADD CONSTRAINT "equipes_commissariats_equipes" FOREIGN KEY ("commissariat_equipes") REFERENCES "commissariats" ("id") ON DELETE SET NULL, ADD CONSTRAINT "equipes_users_chef_equipe" FOREIGN KEY ("equipe_chef_equipe") REFERENCES "users" ("id") ON DELETE SET NULL;
+-- modify "infractions" table
+ALTER TABLE "infractions" ADD CONSTRAINT "infractions_check_options_infraction" FOREIGN KEY ("check_option_infraction") REFERENCES "check_options" ("id") ON DELETE SET NULL, ADD CONSTRAINT "infractions_conducteurs_infractions" FOREIGN KEY ("conducteur_infractions") REFERENCES "conducteurs" ("id") ON DELETE NO ACTION, ADD CONSTRAINT "infractions_controles_infractions" FOREIGN KEY ("controle_infractions") REFERENCES "controles" ("id") ON DELETE SET NULL, ADD CONSTRAINT "infractions_infraction_types_infractions" FOREIGN KEY ("infraction_type_infractions") REFERENCES "infraction_types" ("id") ON DELETE NO ACTION, ADD CONSTRAINT "infractions_proces_verbals_infractions" FOREIGN KEY ("proces_verbal_infractions") REFERENCES "proces_verbals" ("id") ON DELETE SET NULL, ADD CONSTRAINT "infractions_vehicules_infractions" FOREIGN KEY ("vehicule_infractions") REFERENCES "vehicules" ("id") ON DELETE NO ACTION;
+-- modify "inspections" table
+ALTER TABLE "inspections" ADD CONSTRAINT "inspections_commissariats_inspections" FOREIGN KEY ("commissariat_inspections") REFERENCES "commissariats" ("id") ON DELETE SET NULL, ADD CONSTRAINT "inspections_proces_verbals_inspection" FOREIGN KEY ("proces_verbal_inspection") REFERENCES "proces_verbals" ("id") ON DELETE SET NULL, ADD CONSTRAINT "inspections_users_inspections_realisees" FOREIGN KEY ("user_inspections_realisees") REFERENCES "users" ("id") ON DELETE NO ACTION, ADD CONSTRAINT "inspections_vehicules_inspections" FOREIGN KEY ("vehicule_inspections") REFERENCES "vehicules" ("id") ON DELETE SET NULL;
+-- modify "missions" table
+ALTER TABLE "missions" ADD CONSTRAINT "missions_commissariats_missions" FOREIGN KEY ("commissariat_missions") REFERENCES "commissariats" ("id") ON DELETE SET NULL, ADD CONSTRAINT "missions_equipes_missions" FOREIGN KEY ("equipe_missions") REFERENCES "equipes" ("id") ON DELETE SET NULL;
+-- modify "objectifs" table
+ALTER TABLE "objectifs" ADD CONSTRAINT "objectifs_users_assigne_par" FOREIGN KEY ("objectif_assigne_par") REFERENCES "users" ("id") ON DELETE SET NULL, ADD CONSTRAINT "objectifs_users_objectifs" FOREIGN KEY ("user_objectifs") REFERENCES "users" ("id") ON DELETE NO ACTION;
+-- modify "objet_perdus" table
+ALTER TABLE "objet_perdus" ADD CONSTRAINT "objet_perdus_commissariats_objets_perdus" FOREIGN KEY ("commissariat_objets_perdus") REFERENCES "commissariats" ("id") ON DELETE SET NULL, ADD CONSTRAINT "objet_perdus_users_objets_perdus" FOREIGN KEY ("user_objets_perdus") REFERENCES "users" ("id") ON DELETE SET NULL;
+-- modify "objet_retrouves" table
+ALTER TABLE "objet_retrouves" ADD CONSTRAINT "objet_retrouves_commissariats_objets_retrouves" FOREIGN KEY ("commissariat_objets_retrouves") REFERENCES "commissariats" ("id") ON DELETE SET NULL, ADD CONSTRAINT "objet_retrouves_users_objets_retrouves" FOREIGN KEY ("user_objets_retrouves") REFERENCES "users" ("id") ON DELETE SET NULL;
+-- modify "observations" table
+ALTER TABLE "observations" ADD CONSTRAINT "observations_users_auteur" FOREIGN KEY ("observation_auteur") REFERENCES "users" ("id") ON DELETE NO ACTION, ADD CONSTRAINT "observations_users_observations" FOREIGN KEY ("user_observations") REFERENCES "users" ("id") ON DELETE NO ACTION;
+-- modify "paiements" table
+ALTER TABLE "paiements" ADD CONSTRAINT "paiements_proces_verbals_paiements" FOREIGN KEY ("proces_verbal_paiements") REFERENCES "proces_verbals" ("id") ON DELETE NO ACTION;
+-- modify "plaintes" table
+ALTER TABLE "plaintes" ADD CONSTRAINT "plaintes_commissariats_plaintes" FOREIGN KEY ("commissariat_plaintes") REFERENCES "commissariats" ("id") ON DELETE SET NULL, ADD CONSTRAINT "plaintes_users_plaintes_assignees" FOREIGN KEY ("user_plaintes_assignees") REFERENCES "users" ("id") ON DELETE SET NULL;
+-- modify "plainte_historiques" table
+ALTER TABLE "plainte_historiques" ADD CONSTRAINT "plainte_historiques_plaintes_historiques" FOREIGN KEY ("plainte_id") REFERENCES "plaintes" ("id") ON DELETE NO ACTION;
+-- modify "preuves" table
+ALTER TABLE "preuves" ADD CONSTRAINT "preuves_plaintes_preuves" FOREIGN KEY ("plainte_id") REFERENCES "plaintes" ("id") ON DELETE NO ACTION;
+-- modify "recours" table
+ALTER TABLE "recours" ADD CONSTRAINT "recours_proces_verbals_recours" FOREIGN KEY ("proces_verbal_recours") REFERENCES "proces_verbals" ("id") ON DELETE NO ACTION, ADD CONSTRAINT "recours_users_recours_traites" FOREIGN KEY ("user_recours_traites") REFERENCES "users" ("id") ON DELETE SET NULL;
+-- modify "timeline_events" table
+ALTER TABLE "timeline_events" ADD CONSTRAINT "timeline_events_plaintes_timeline" FOREIGN KEY ("plainte_id") REFERENCES "plaintes" ("id") ON DELETE NO ACTION;
+-- modify "users" table
+ALTER TABLE "users" ADD CONSTRAINT "users_commissariats_agents" FOREIGN KEY ("commissariat_agents") REFERENCES "commissariats" ("id") ON DELETE SET NULL, ADD CONSTRAINT "users_equipes_membres" FOREIGN KEY ("equipe_membres") REFERENCES "equipes" ("id") ON DELETE SET NULL;
+-- modify "user_sessions" table
+ALTER TABLE "user_sessions" ADD CONSTRAINT "user_sessions_users_sessions" FOREIGN KEY ("user_sessions") REFERENCES "users" ("id") ON DELETE NO ACTION;
+-- modify "mission_agents" table
+ALTER TABLE "mission_agents" ADD CONSTRAINT "mission_agents_mission_id" FOREIGN KEY ("mission_id") REFERENCES "missions" ("id") ON DELETE CASCADE, ADD CONSTRAINT "mission_agents_user_id" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+-- modify "user_competences" table
+ALTER TABLE "user_competences" ADD CONSTRAINT "user_competences_user_id" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE, ADD CONSTRAINT "user_competences_competence_id" FOREIGN KEY ("competence_id") REFERENCES "competences" ("id") ON DELETE CASCADE;
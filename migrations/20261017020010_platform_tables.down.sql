@@ -0,0 +1,114 @@
+-- reverse: create "role_permissions" table
+DROP TABLE "role_permissions";
+-- reverse: create index "permissions_code_key" to table: "permissions"
+DROP INDEX "permissions_code_key";
+-- reverse: create "permissions" table
+DROP TABLE "permissions";
+-- reverse: create index "roles_name_key" to table: "roles"
+DROP INDEX "roles_name_key";
+-- reverse: create "roles" table
+DROP TABLE "roles";
+-- reverse: create index "apikey_service_account_id" to table: "api_keys"
+DROP INDEX "apikey_service_account_id";
+-- reverse: create index "api_keys_prefix_key" to table: "api_keys"
+DROP INDEX "api_keys_prefix_key";
+-- reverse: create "api_keys" table
+DROP TABLE "api_keys";
+-- reverse: create index "signingkey_expires_at" to table: "signing_keys"
+DROP INDEX "signingkey_expires_at";
+-- reverse: create index "signingkey_kid" to table: "signing_keys"
+DROP INDEX "signingkey_kid";
+-- reverse: create "signing_keys" table
+DROP TABLE "signing_keys";
+-- reverse: create index "service_accounts_name_key" to table: "service_accounts"
+DROP INDEX "service_accounts_name_key";
+-- reverse: create "service_accounts" table
+DROP TABLE "service_accounts";
+-- reverse: create index "streamevent_created_at" to table: "stream_events"
+DROP INDEX "streamevent_created_at";
+-- reverse: create "stream_events" table
+DROP TABLE "stream_events";
+-- reverse: create index "resourceversion_resource_type_resource_id" to table: "resource_versions"
+DROP INDEX "resourceversion_resource_type_resource_id";
+-- reverse: create "resource_versions" table
+DROP TABLE "resource_versions";
+-- reverse: create index "recoverycode_user_id" to table: "recovery_codes"
+DROP INDEX "recoverycode_user_id";
+-- reverse: create "recovery_codes" table
+DROP TABLE "recovery_codes";
+-- reverse: create index "oidcloginstate_expires_at" to table: "oidc_login_states"
+DROP INDEX "oidcloginstate_expires_at";
+-- reverse: create index "oidcloginstate_state_hash" to table: "oidc_login_states"
+DROP INDEX "oidcloginstate_state_hash";
+-- reverse: create "oidc_login_states" table
+DROP TABLE "oidc_login_states";
+-- reverse: create index "useridentity_user_id" to table: "user_identities"
+DROP INDEX "useridentity_user_id";
+-- reverse: create index "useridentity_issuer_subject" to table: "user_identities"
+DROP INDEX "useridentity_issuer_subject";
+-- reverse: create "user_identities" table
+DROP TABLE "user_identities";
+-- reverse: create index "apikeyusage_created_at" to table: "api_key_usages"
+DROP INDEX "apikeyusage_created_at";
+-- reverse: create index "apikeyusage_service_account_id_created_at" to table: "api_key_usages"
+DROP INDEX "apikeyusage_service_account_id_created_at";
+-- reverse: create "api_key_usages" table
+DROP TABLE "api_key_usages";
+-- reverse: create index "user_mf_as_user_id_key" to table: "user_mf_as"
+DROP INDEX "user_mf_as_user_id_key";
+-- reverse: create "user_mf_as" table
+DROP TABLE "user_mf_as";
+-- reverse: create index "numbersequence_type_scope_year" to table: "number_sequences"
+DROP INDEX "numbersequence_type_scope_year";
+-- reverse: create "number_sequences" table
+DROP TABLE "number_sequences";
+-- reverse: create index "notification_recipient" to table: "notifications"
+DROP INDEX "notification_recipient";
+-- reverse: create index "notification_resource_type_resource_id" to table: "notifications"
+DROP INDEX "notification_resource_type_resource_id";
+-- reverse: create index "notification_status_next_attempt_at" to table: "notifications"
+DROP INDEX "notification_status_next_attempt_at";
+-- reverse: create "notifications" table
+DROP TABLE "notifications";
+-- reverse: create index "mfaverification_user_id" to table: "mfa_verifications"
+DROP INDEX "mfaverification_user_id";
+-- reverse: create index "mfa_verifications_session_id_key" to table: "mfa_verifications"
+DROP INDEX "mfa_verifications_session_id_key";
+-- reverse: create "mfa_verifications" table
+DROP TABLE "mfa_verifications";
+-- reverse: create index "loginthrottle_last_failure_at" to table: "login_throttles"
+DROP INDEX "loginthrottle_last_failure_at";
+-- reverse: create index "loginthrottle_kind_key" to table: "login_throttles"
+DROP INDEX "loginthrottle_kind_key";
+-- reverse: create "login_throttles" table
+DROP TABLE "login_throttles";
+-- reverse: create index "jobstate_next_run_at" to table: "job_states"
+DROP INDEX "jobstate_next_run_at";
+-- reverse: create index "job_states_name_key" to table: "job_states"
+DROP INDEX "job_states_name_key";
+-- reverse: create "job_states" table
+DROP TABLE "job_states";
+-- reverse: create index "jobrun_status" to table: "job_runs"
+DROP INDEX "jobrun_status";
+-- reverse: create index "jobrun_job_name_started_at" to table: "job_runs"
+DROP INDEX "jobrun_job_name_started_at";
+-- reverse: create "job_runs" table
+DROP TABLE "job_runs";
+-- reverse: create index "idempotencykey_expires_at" to table: "idempotency_keys"
+DROP INDEX "idempotencykey_expires_at";
+-- reverse: create index "idempotencykey_user_id_route_key" to table: "idempotency_keys"
+DROP INDEX "idempotencykey_user_id_route_key";
+-- reverse: create "idempotency_keys" table
+DROP TABLE "idempotency_keys";
+-- reverse: create index "passwordresettoken_expires_at" to table: "password_reset_tokens"
+DROP INDEX "passwordresettoken_expires_at";
+-- reverse: create index "passwordresettoken_user_id" to table: "password_reset_tokens"
+DROP INDEX "passwordresettoken_user_id";
+-- reverse: create index "passwordresettoken_token_hash" to table: "password_reset_tokens"
+DROP INDEX "passwordresettoken_token_hash";
+-- reverse: create "password_reset_tokens" table
+DROP TABLE "password_reset_tokens";
+-- reverse: create index "passwordhistory_user_id_created_at" to table: "password_histories"
+DROP INDEX "passwordhistory_user_id_created_at";
+-- reverse: create "password_histories" table
+DROP TABLE "password_histories";
@@ -0,0 +1,114 @@
+-- create "password_histories" table
+CREATE TABLE "password_histories" ("id" uuid NOT NULL, "user_id" uuid NOT NULL, "password_hash" character varying NOT NULL, "temporary" boolean NOT NULL DEFAULT false, "created_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "passwordhistory_user_id_created_at" to table: "password_histories"
+CREATE INDEX "passwordhistory_user_id_created_at" ON "password_histories" ("user_id", "created_at");
+-- create "password_reset_tokens" table
+CREATE TABLE "password_reset_tokens" ("id" uuid NOT NULL, "user_id" uuid NOT NULL, "token_hash" character varying NOT NULL, "expires_at" timestamptz NOT NULL, "used_at" timestamptz NULL, "ip_address" character varying NULL, "created_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "passwordresettoken_token_hash" to table: "password_reset_tokens"
+CREATE UNIQUE INDEX "passwordresettoken_token_hash" ON "password_reset_tokens" ("token_hash");
+-- create index "passwordresettoken_user_id" to table: "password_reset_tokens"
+CREATE INDEX "passwordresettoken_user_id" ON "password_reset_tokens" ("user_id");
+-- create index "passwordresettoken_expires_at" to table: "password_reset_tokens"
+CREATE INDEX "passwordresettoken_expires_at" ON "password_reset_tokens" ("expires_at");
+-- create "idempotency_keys" table
+CREATE TABLE "idempotency_keys" ("id" uuid NOT NULL, "key" character varying NOT NULL, "user_id" character varying NOT NULL, "route" character varying NOT NULL, "request_hash" character varying NOT NULL, "status_code" bigint NULL, "content_type" character varying NULL, "response_body" bytea NULL, "locked_until" timestamptz NULL, "expires_at" timestamptz NOT NULL, "created_at" timestamptz NOT NULL, "completed_at" timestamptz NULL, PRIMARY KEY ("id"));
+-- create index "idempotencykey_user_id_route_key" to table: "idempotency_keys"
+CREATE UNIQUE INDEX "idempotencykey_user_id_route_key" ON "idempotency_keys" ("user_id", "route", "key");
+-- create index "idempotencykey_expires_at" to table: "idempotency_keys"
+CREATE INDEX "idempotencykey_expires_at" ON "idempotency_keys" ("expires_at");
+-- create "job_runs" table
+CREATE TABLE "job_runs" ("id" uuid NOT NULL, "job_name" character varying NOT NULL, "trigger" character varying NOT NULL DEFAULT 'SCHEDULED', "triggered_by" character varying NULL, "instance_id" character varying NULL, "status" character varying NOT NULL DEFAULT 'RUNNING', "started_at" timestamptz NOT NULL, "finished_at" timestamptz NULL, "duration_ms" bigint NULL, "result" text NULL, "error_message" text NULL, PRIMARY KEY ("id"));
+-- create index "jobrun_job_name_started_at" to table: "job_runs"
+CREATE INDEX "jobrun_job_name_started_at" ON "job_runs" ("job_name", "started_at");
+-- create index "jobrun_status" to table: "job_runs"
+CREATE INDEX "jobrun_status" ON "job_runs" ("status");
+-- create "job_states" table
+CREATE TABLE "job_states" ("id" uuid NOT NULL, "name" character varying NOT NULL, "paused" boolean NOT NULL DEFAULT false, "next_run_at" timestamptz NULL, "locked_by" character varying NULL, "locked_until" timestamptz NULL, "last_run_at" timestamptz NULL, "last_status" character varying NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "job_states_name_key" to table: "job_states"
+CREATE UNIQUE INDEX "job_states_name_key" ON "job_states" ("name");
+-- create index "jobstate_next_run_at" to table: "job_states"
+CREATE INDEX "jobstate_next_run_at" ON "job_states" ("next_run_at");
+-- create "login_throttles" table
+CREATE TABLE "login_throttles" ("id" uuid NOT NULL, "kind" character varying NOT NULL, "key" character varying NOT NULL, "failures" bigint NOT NULL DEFAULT 0, "last_failure_at" timestamptz NOT NULL, "next_attempt_at" timestamptz NULL, "locked_until" timestamptz NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "loginthrottle_kind_key" to table: "login_throttles"
+CREATE UNIQUE INDEX "loginthrottle_kind_key" ON "login_throttles" ("kind", "key");
+-- create index "loginthrottle_last_failure_at" to table: "login_throttles"
+CREATE INDEX "loginthrottle_last_failure_at" ON "login_throttles" ("last_failure_at");
+-- create "mfa_verifications" table
+CREATE TABLE "mfa_verifications" ("id" uuid NOT NULL, "session_id" uuid NOT NULL, "user_id" uuid NOT NULL, "method" character varying NOT NULL, "verified_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "mfa_verifications_session_id_key" to table: "mfa_verifications"
+CREATE UNIQUE INDEX "mfa_verifications_session_id_key" ON "mfa_verifications" ("session_id");
+-- create index "mfaverification_user_id" to table: "mfa_verifications"
+CREATE INDEX "mfaverification_user_id" ON "mfa_verifications" ("user_id");
+-- create "notifications" table
+CREATE TABLE "notifications" ("id" uuid NOT NULL, "channel" character varying NOT NULL, "recipient" character varying NOT NULL, "template" character varying NULL, "subject" character varying NULL, "body" text NOT NULL, "status" character varying NOT NULL DEFAULT 'PENDING', "attempts" bigint NOT NULL DEFAULT 0, "max_attempts" bigint NOT NULL DEFAULT 5, "next_attempt_at" timestamptz NOT NULL, "last_error" text NULL, "provider" character varying NULL, "sent_at" timestamptz NULL, "resource_type" character varying NULL, "resource_id" character varying NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "notification_status_next_attempt_at" to table: "notifications"
+CREATE INDEX "notification_status_next_attempt_at" ON "notifications" ("status", "next_attempt_at");
+-- create index "notification_resource_type_resource_id" to table: "notifications"
+CREATE INDEX "notification_resource_type_resource_id" ON "notifications" ("resource_type", "resource_id");
+-- create index "notification_recipient" to table: "notifications"
+CREATE INDEX "notification_recipient" ON "notifications" ("recipient");
+-- create "number_sequences" table
+CREATE TABLE "number_sequences" ("id" uuid NOT NULL, "type" character varying NOT NULL, "scope" character varying NOT NULL DEFAULT '', "year" bigint NOT NULL, "last_value" bigint NOT NULL DEFAULT 0, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "numbersequence_type_scope_year" to table: "number_sequences"
+CREATE UNIQUE INDEX "numbersequence_type_scope_year" ON "number_sequences" ("type", "scope", "year");
+-- create "user_mf_as" table
+CREATE TABLE "user_mf_as" ("id" uuid NOT NULL, "user_id" uuid NOT NULL, "secret" character varying NOT NULL, "confirmed_at" timestamptz NULL, "last_used_step" bigint NOT NULL DEFAULT 0, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "user_mf_as_user_id_key" to table: "user_mf_as"
+CREATE UNIQUE INDEX "user_mf_as_user_id_key" ON "user_mf_as" ("user_id");
+-- create "api_key_usages" table
+CREATE TABLE "api_key_usages" ("id" uuid NOT NULL, "service_account_id" uuid NOT NULL, "api_key_id" uuid NOT NULL, "key_prefix" character varying NOT NULL, "method" character varying NOT NULL, "path" character varying NOT NULL, "route" character varying NULL, "status_code" bigint NOT NULL, "ip_address" character varying NULL, "duration_ms" bigint NOT NULL DEFAULT 0, "created_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "apikeyusage_service_account_id_created_at" to table: "api_key_usages"
+CREATE INDEX "apikeyusage_service_account_id_created_at" ON "api_key_usages" ("service_account_id", "created_at");
+-- create index "apikeyusage_created_at" to table: "api_key_usages"
+CREATE INDEX "apikeyusage_created_at" ON "api_key_usages" ("created_at");
+-- create "user_identities" table
+CREATE TABLE "user_identities" ("id" uuid NOT NULL, "user_id" uuid NOT NULL, "issuer" character varying NOT NULL, "subject" character varying NOT NULL, "email" character varying NULL, "created_at" timestamptz NOT NULL, "last_login_at" timestamptz NULL, PRIMARY KEY ("id"));
+-- create index "useridentity_issuer_subject" to table: "user_identities"
+CREATE UNIQUE INDEX "useridentity_issuer_subject" ON "user_identities" ("issuer", "subject");
+-- create index "useridentity_user_id" to table: "user_identities"
+CREATE INDEX "useridentity_user_id" ON "user_identities" ("user_id");
+-- create "oidc_login_states" table
+CREATE TABLE "oidc_login_states" ("id" uuid NOT NULL, "state_hash" character varying NOT NULL, "code_verifier" character varying NOT NULL, "nonce" character varying NOT NULL, "ip_address" character varying NULL, "expires_at" timestamptz NOT NULL, "created_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "oidcloginstate_state_hash" to table: "oidc_login_states"
+CREATE UNIQUE INDEX "oidcloginstate_state_hash" ON "oidc_login_states" ("state_hash");
+-- create index "oidcloginstate_expires_at" to table: "oidc_login_states"
+CREATE INDEX "oidcloginstate_expires_at" ON "oidc_login_states" ("expires_at");
+-- create "recovery_codes" table
+CREATE TABLE "recovery_codes" ("id" uuid NOT NULL, "user_id" uuid NOT NULL, "code_hash" character varying NOT NULL, "used_at" timestamptz NULL, "created_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "recoverycode_user_id" to table: "recovery_codes"
+CREATE INDEX "recoverycode_user_id" ON "recovery_codes" ("user_id");
+-- create "resource_versions" table
+CREATE TABLE "resource_versions" ("id" uuid NOT NULL, "resource_type" character varying NOT NULL, "resource_id" character varying NOT NULL, "version" bigint NOT NULL DEFAULT 1, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "resourceversion_resource_type_resource_id" to table: "resource_versions"
+CREATE UNIQUE INDEX "resourceversion_resource_type_resource_id" ON "resource_versions" ("resource_type", "resource_id");
+-- create "stream_events" table
+CREATE TABLE "stream_events" ("id" bigint NOT NULL GENERATED BY DEFAULT AS IDENTITY, "type" character varying NOT NULL, "resource_id" character varying NULL, "payload" text NULL, "broadcast" boolean NOT NULL DEFAULT false, "commissariat_ids" jsonb NULL, "agent_ids" jsonb NULL, "instance_id" character varying NULL, "created_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "streamevent_created_at" to table: "stream_events"
+CREATE INDEX "streamevent_created_at" ON "stream_events" ("created_at");
+-- create "service_accounts" table
+CREATE TABLE "service_accounts" ("id" uuid NOT NULL, "name" character varying NOT NULL, "description" text NULL, "permissions" jsonb NULL, "allowed_ips" jsonb NULL, "commissariat_id" uuid NULL, "active" boolean NOT NULL DEFAULT true, "created_by" character varying NULL, "last_used_at" timestamptz NULL, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "service_accounts_name_key" to table: "service_accounts"
+CREATE UNIQUE INDEX "service_accounts_name_key" ON "service_accounts" ("name");
+-- create "signing_keys" table
+CREATE TABLE "signing_keys" ("id" uuid NOT NULL, "kid" character varying NOT NULL, "algorithm" character varying NOT NULL, "private_key" text NOT NULL, "created_at" timestamptz NOT NULL, "retired_at" timestamptz NULL, "expires_at" timestamptz NULL, PRIMARY KEY ("id"));
+-- create index "signingkey_kid" to table: "signing_keys"
+CREATE UNIQUE INDEX "signingkey_kid" ON "signing_keys" ("kid");
+-- create index "signingkey_expires_at" to table: "signing_keys"
+CREATE INDEX "signingkey_expires_at" ON "signing_keys" ("expires_at");
+-- create "api_keys" table
+CREATE TABLE "api_keys" ("id" uuid NOT NULL, "prefix" character varying NOT NULL, "key_hash" character varying NOT NULL, "expires_at" timestamptz NULL, "revoked_at" timestamptz NULL, "last_used_at" timestamptz NULL, "last_used_ip" character varying NULL, "created_by" character varying NULL, "created_at" timestamptz NOT NULL, "service_account_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "api_keys_service_accounts_keys" FOREIGN KEY ("service_account_id") REFERENCES "service_accounts" ("id") ON DELETE NO ACTION);
+-- create index "api_keys_prefix_key" to table: "api_keys"
+CREATE UNIQUE INDEX "api_keys_prefix_key" ON "api_keys" ("prefix");
+-- create index "apikey_service_account_id" to table: "api_keys"
+CREATE INDEX "apikey_service_account_id" ON "api_keys" ("service_account_id");
+-- create "roles" table
+CREATE TABLE "roles" ("id" uuid NOT NULL, "name" character varying NOT NULL, "libelle" character varying NULL, "description" text NULL, "system" boolean NOT NULL DEFAULT false, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "roles_name_key" to table: "roles"
+CREATE UNIQUE INDEX "roles_name_key" ON "roles" ("name");
+-- create "permissions" table
+CREATE TABLE "permissions" ("id" uuid NOT NULL, "code" character varying NOT NULL, "resource" character varying NOT NULL, "created_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+-- create index "permissions_code_key" to table: "permissions"
+CREATE UNIQUE INDEX "permissions_code_key" ON "permissions" ("code");
+-- create "role_permissions" table
+CREATE TABLE "role_permissions" ("role_id" uuid NOT NULL, "permission_id" uuid NOT NULL, PRIMARY KEY ("role_id", "permission_id"), CONSTRAINT "role_permissions_role_id" FOREIGN KEY ("role_id") REFERENCES "roles" ("id") ON DELETE CASCADE, CONSTRAINT "role_permissions_permission_id" FOREIGN KEY ("permission_id") REFERENCES "permissions" ("id") ON DELETE CASCADE);
@@ -0,0 +1,65 @@
+-- reverse: create index "objet_retrouves_fts_idx" to table: "objet_retrouves"
+DROP INDEX IF EXISTS objet_retrouves_fts_idx;
+-- reverse: create index "objet_retrouves_type_objet_trgm_idx" to table: "objet_retrouves"
+DROP INDEX IF EXISTS objet_retrouves_type_objet_trgm_idx;
+-- reverse: create index "objet_retrouves_numero_search_idx" to table: "objet_retrouves"
+DROP INDEX IF EXISTS objet_retrouves_numero_search_idx;
+-- reverse: create index "objet_perdus_fts_idx" to table: "objet_perdus"
+DROP INDEX IF EXISTS objet_perdus_fts_idx;
+-- reverse: create index "objet_perdus_type_objet_trgm_idx" to table: "objet_perdus"
+DROP INDEX IF EXISTS objet_perdus_type_objet_trgm_idx;
+-- reverse: create index "objet_perdus_numero_search_idx" to table: "objet_perdus"
+DROP INDEX IF EXISTS objet_perdus_numero_search_idx;
+-- reverse: create index "alerte_securitaires_fts_idx" to table: "alerte_securitaires"
+DROP INDEX IF EXISTS alerte_securitaires_fts_idx;
+-- reverse: create index "alerte_securitaires_titre_trgm_idx" to table: "alerte_securitaires"
+DROP INDEX IF EXISTS alerte_securitaires_titre_trgm_idx;
+-- reverse: create index "alerte_securitaires_numero_search_idx" to table: "alerte_securitaires"
+DROP INDEX IF EXISTS alerte_securitaires_numero_search_idx;
+-- reverse: create index "convocations_fts_idx" to table: "convocations"
+DROP INDEX IF EXISTS convocations_fts_idx;
+-- reverse: create index "convocations_convoque_prenom_trgm_idx" to table: "convocations"
+DROP INDEX IF EXISTS convocations_convoque_prenom_trgm_idx;
+-- reverse: create index "convocations_convoque_nom_trgm_idx" to table: "convocations"
+DROP INDEX IF EXISTS convocations_convoque_nom_trgm_idx;
+-- reverse: create index "convocations_convoque_telephone_search_idx" to table: "convocations"
+DROP INDEX IF EXISTS convocations_convoque_telephone_search_idx;
+-- reverse: create index "convocations_numero_search_idx" to table: "convocations"
+DROP INDEX IF EXISTS convocations_numero_search_idx;
+-- reverse: create index "plaintes_fts_idx" to table: "plaintes"
+DROP INDEX IF EXISTS plaintes_fts_idx;
+-- reverse: create index "plaintes_plaignant_prenom_trgm_idx" to table: "plaintes"
+DROP INDEX IF EXISTS plaintes_plaignant_prenom_trgm_idx;
+-- reverse: create index "plaintes_plaignant_nom_trgm_idx" to table: "plaintes"
+DROP INDEX IF EXISTS plaintes_plaignant_nom_trgm_idx;
+-- reverse: create index "plaintes_plaignant_telephone_search_idx" to table: "plaintes"
+DROP INDEX IF EXISTS plaintes_plaignant_telephone_search_idx;
+-- reverse: create index "plaintes_numero_search_idx" to table: "plaintes"
+DROP INDEX IF EXISTS plaintes_numero_search_idx;
+-- reverse: create index "proces_verbals_numero_pv_search_idx" to table: "proces_verbals"
+DROP INDEX IF EXISTS proces_verbals_numero_pv_search_idx;
+-- reverse: create index "conducteurs_fts_idx" to table: "conducteurs"
+DROP INDEX IF EXISTS conducteurs_fts_idx;
+-- reverse: create index "conducteurs_prenom_trgm_idx" to table: "conducteurs"
+DROP INDEX IF EXISTS conducteurs_prenom_trgm_idx;
+-- reverse: create index "conducteurs_nom_trgm_idx" to table: "conducteurs"
+DROP INDEX IF EXISTS conducteurs_nom_trgm_idx;
+-- reverse: create index "conducteurs_telephone_search_idx" to table: "conducteurs"
+DROP INDEX IF EXISTS conducteurs_telephone_search_idx;
+-- reverse: create index "conducteurs_numero_permis_search_idx" to table: "conducteurs"
+DROP INDEX IF EXISTS conducteurs_numero_permis_search_idx;
+-- reverse: create index "vehicules_fts_idx" to table: "vehicules"
+DROP INDEX IF EXISTS vehicules_fts_idx;
+-- reverse: create index "vehicules_proprietaire_prenom_trgm_idx" to table: "vehicules"
+DROP INDEX IF EXISTS vehicules_proprietaire_prenom_trgm_idx;
+-- reverse: create index "vehicules_proprietaire_nom_trgm_idx" to table: "vehicules"
+DROP INDEX IF EXISTS vehicules_proprietaire_nom_trgm_idx;
+-- reverse: create index "vehicules_numero_chassis_search_idx" to table: "vehicules"
+DROP INDEX IF EXISTS vehicules_numero_chassis_search_idx;
+-- reverse: create index "vehicules_immatriculation_search_idx" to table: "vehicules"
+DROP INDEX IF EXISTS vehicules_immatriculation_search_idx;
+-- reverse: immutable unaccent wrapper
+DROP FUNCTION IF EXISTS f_unaccent(text);
+-- reverse: extensions of the global search
+DROP EXTENSION IF EXISTS unaccent;
+DROP EXTENSION IF EXISTS pg_trgm;
@@ -0,0 +1,67 @@
+-- extensions of the global search
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE EXTENSION IF NOT EXISTS unaccent;
+-- immutable unaccent wrapper, usable in the indexes
+CREATE OR REPLACE FUNCTION f_unaccent(text) RETURNS text AS
+$$ SELECT public.unaccent('public.unaccent', $1) $$
+LANGUAGE sql IMMUTABLE PARALLEL SAFE STRICT;
+-- create index "vehicules_immatriculation_search_idx" to table: "vehicules"
+CREATE INDEX IF NOT EXISTS vehicules_immatriculation_search_idx ON vehicules USING gin (regexp_replace(f_unaccent(lower(immatriculation)), '[^a-z0-9]+', '', 'g') gin_trgm_ops);
+-- create index "vehicules_numero_chassis_search_idx" to table: "vehicules"
+CREATE INDEX IF NOT EXISTS vehicules_numero_chassis_search_idx ON vehicules USING gin (regexp_replace(f_unaccent(lower(numero_chassis)), '[^a-z0-9]+', '', 'g') gin_trgm_ops);
+-- create index "vehicules_proprietaire_nom_trgm_idx" to table: "vehicules"
+CREATE INDEX IF NOT EXISTS vehicules_proprietaire_nom_trgm_idx ON vehicules USING gin (f_unaccent(lower(proprietaire_nom)) gin_trgm_ops);
+-- create index "vehicules_proprietaire_prenom_trgm_idx" to table: "vehicules"
+CREATE INDEX IF NOT EXISTS vehicules_proprietaire_prenom_trgm_idx ON vehicules USING gin (f_unaccent(lower(proprietaire_prenom)) gin_trgm_ops);
+-- create index "vehicules_fts_idx" to table: "vehicules"
+CREATE INDEX IF NOT EXISTS vehicules_fts_idx ON vehicules USING gin (to_tsvector('simple', f_unaccent(coalesce(proprietaire_nom, '') || ' ' || coalesce(proprietaire_prenom, '') || ' ' || coalesce(marque, '') || ' ' || coalesce(modele, ''))));
+-- create index "conducteurs_numero_permis_search_idx" to table: "conducteurs"
+CREATE INDEX IF NOT EXISTS conducteurs_numero_permis_search_idx ON conducteurs USING gin (regexp_replace(f_unaccent(lower(numero_permis)), '[^a-z0-9]+', '', 'g') gin_trgm_ops);
+-- create index "conducteurs_telephone_search_idx" to table: "conducteurs"
+CREATE INDEX IF NOT EXISTS conducteurs_telephone_search_idx ON conducteurs USING gin (regexp_replace(f_unaccent(lower(telephone)), '[^a-z0-9]+', '', 'g') gin_trgm_ops);
+-- create index "conducteurs_nom_trgm_idx" to table: "conducteurs"
+CREATE INDEX IF NOT EXISTS conducteurs_nom_trgm_idx ON conducteurs USING gin (f_unaccent(lower(nom)) gin_trgm_ops);
+-- create index "conducteurs_prenom_trgm_idx" to table: "conducteurs"
+CREATE INDEX IF NOT EXISTS conducteurs_prenom_trgm_idx ON conducteurs USING gin (f_unaccent(lower(prenom)) gin_trgm_ops);
+-- create index "conducteurs_fts_idx" to table: "conducteurs"
+CREATE INDEX IF NOT EXISTS conducteurs_fts_idx ON conducteurs USING gin (to_tsvector('simple', f_unaccent(coalesce(nom, '') || ' ' || coalesce(prenom, ''))));
+-- create index "proces_verbals_numero_pv_search_idx" to table: "proces_verbals"
+CREATE INDEX IF NOT EXISTS proces_verbals_numero_pv_search_idx ON proces_verbals USING gin (regexp_replace(f_unaccent(lower(numero_pv)), '[^a-z0-9]+', '', 'g') gin_trgm_ops);
+-- create index "plaintes_numero_search_idx" to table: "plaintes"
+CREATE INDEX IF NOT EXISTS plaintes_numero_search_idx ON plaintes USING gin (regexp_replace(f_unaccent(lower(numero)), '[^a-z0-9]+', '', 'g') gin_trgm_ops);
+-- create index "plaintes_plaignant_telephone_search_idx" to table: "plaintes"
+CREATE INDEX IF NOT EXISTS plaintes_plaignant_telephone_search_idx ON plaintes USING gin (regexp_replace(f_unaccent(lower(plaignant_telephone)), '[^a-z0-9]+', '', 'g') gin_trgm_ops);
+-- create index "plaintes_plaignant_nom_trgm_idx" to table: "plaintes"
+CREATE INDEX IF NOT EXISTS plaintes_plaignant_nom_trgm_idx ON plaintes USING gin (f_unaccent(lower(plaignant_nom)) gin_trgm_ops);
+-- create index "plaintes_plaignant_prenom_trgm_idx" to table: "plaintes"
+CREATE INDEX IF NOT EXISTS plaintes_plaignant_prenom_trgm_idx ON plaintes USING gin (f_unaccent(lower(plaignant_prenom)) gin_trgm_ops);
+-- create index "plaintes_fts_idx" to table: "plaintes"
+CREATE INDEX IF NOT EXISTS plaintes_fts_idx ON plaintes USING gin (to_tsvector('simple', f_unaccent(coalesce(plaignant_nom, '') || ' ' || coalesce(plaignant_prenom, ''))));
+-- create index "convocations_numero_search_idx" to table: "convocations"
+CREATE INDEX IF NOT EXISTS convocations_numero_search_idx ON convocations USING gin (regexp_replace(f_unaccent(lower(numero)), '[^a-z0-9]+', '', 'g') gin_trgm_ops);
+-- create index "convocations_convoque_telephone_search_idx" to table: "convocations"
+CREATE INDEX IF NOT EXISTS convocations_convoque_telephone_search_idx ON convocations USING gin (regexp_replace(f_unaccent(lower(convoque_telephone)), '[^a-z0-9]+', '', 'g') gin_trgm_ops);
+-- create index "convocations_convoque_nom_trgm_idx" to table: "convocations"
+CREATE INDEX IF NOT EXISTS convocations_convoque_nom_trgm_idx ON convocations USING gin (f_unaccent(lower(convoque_nom)) gin_trgm_ops);
+-- create index "convocations_convoque_prenom_trgm_idx" to table: "convocations"
+CREATE INDEX IF NOT EXISTS convocations_convoque_prenom_trgm_idx ON convocations USING gin (f_unaccent(lower(convoque_prenom)) gin_trgm_ops);
+-- create index "convocations_fts_idx" to table: "convocations"
+CREATE INDEX IF NOT EXISTS convocations_fts_idx ON convocations USING gin (to_tsvector('simple', f_unaccent(coalesce(convoque_nom, '') || ' ' || coalesce(convoque_prenom, ''))));
+-- create index "alerte_securitaires_numero_search_idx" to table: "alerte_securitaires"
+CREATE INDEX IF NOT EXISTS alerte_securitaires_numero_search_idx ON alerte_securitaires USING gin (regexp_replace(f_unaccent(lower(numero)), '[^a-z0-9]+', '', 'g') gin_trgm_ops);
+-- create index "alerte_securitaires_titre_trgm_idx" to table: "alerte_securitaires"
+CREATE INDEX IF NOT EXISTS alerte_securitaires_titre_trgm_idx ON alerte_securitaires USING gin (f_unaccent(lower(titre)) gin_trgm_ops);
+-- create index "alerte_securitaires_fts_idx" to table: "alerte_securitaires"
+CREATE INDEX IF NOT EXISTS alerte_securitaires_fts_idx ON alerte_securitaires USING gin (to_tsvector('simple', f_unaccent(coalesce(titre, '') || ' ' || coalesce(description, ''))));
+-- create index "objet_perdus_numero_search_idx" to table: "objet_perdus"
+CREATE INDEX IF NOT EXISTS objet_perdus_numero_search_idx ON objet_perdus USING gin (regexp_replace(f_unaccent(lower(numero)), '[^a-z0-9]+', '', 'g') gin_trgm_ops);
+-- create index "objet_perdus_type_objet_trgm_idx" to table: "objet_perdus"
+CREATE INDEX IF NOT EXISTS objet_perdus_type_objet_trgm_idx ON objet_perdus USING gin (f_unaccent(lower(type_objet)) gin_trgm_ops);
+-- create index "objet_perdus_fts_idx" to table: "objet_perdus"
+CREATE INDEX IF NOT EXISTS objet_perdus_fts_idx ON objet_perdus USING gin (to_tsvector('simple', f_unaccent(coalesce(type_objet, '') || ' ' || coalesce(description, ''))));
+-- create index "objet_retrouves_numero_search_idx" to table: "objet_retrouves"
+CREATE INDEX IF NOT EXISTS objet_retrouves_numero_search_idx ON objet_retrouves USING gin (regexp_replace(f_unaccent(lower(numero)), '[^a-z0-9]+', '', 'g') gin_trgm_ops);
+-- create index "objet_retrouves_type_objet_trgm_idx" to table: "objet_retrouves"
+CREATE INDEX IF NOT EXISTS objet_retrouves_type_objet_trgm_idx ON objet_retrouves USING gin (f_unaccent(lower(type_objet)) gin_trgm_ops);
+-- create index "objet_retrouves_fts_idx" to table: "objet_retrouves"
+CREATE INDEX IF NOT EXISTS objet_retrouves_fts_idx ON objet_retrouves USING gin (to_tsvector('simple', f_unaccent(coalesce(type_objet, '') || ' ' || coalesce(description, '') || ' ' || coalesce(lieu_trouvaille, ''))));
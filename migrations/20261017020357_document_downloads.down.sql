@@ -0,0 +1,6 @@
+-- reverse: create index "documentdownload_nonce" to table: "document_downloads"
+DROP INDEX "documentdownload_nonce";
+-- reverse: create index "documentdownload_document_id_created_at" to table: "document_downloads"
+DROP INDEX "documentdownload_document_id_created_at";
+-- reverse: create "document_downloads" table
+DROP TABLE "document_downloads";
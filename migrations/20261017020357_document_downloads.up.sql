@@ -0,0 +1,6 @@
+-- create "document_downloads" table
+CREATE TABLE "document_downloads" ("id" uuid NOT NULL, "nonce" character varying NULL, "single_use" boolean NOT NULL DEFAULT false, "public" boolean NOT NULL DEFAULT false, "link_expires_at" timestamptz NOT NULL, "ip_address" character varying NULL, "user_agent" character varying NULL, "created_at" timestamptz NOT NULL, "document_id" uuid NOT NULL, PRIMARY KEY ("id"), CONSTRAINT "document_downloads_documents_downloads" FOREIGN KEY ("document_id") REFERENCES "documents" ("id") ON DELETE CASCADE);
+-- create index "documentdownload_document_id_created_at" to table: "document_downloads"
+CREATE INDEX "documentdownload_document_id_created_at" ON "document_downloads" ("document_id", "created_at");
+-- create index "documentdownload_nonce" to table: "document_downloads"
+CREATE UNIQUE INDEX "documentdownload_nonce" ON "document_downloads" ("nonce") WHERE single_use;
@@ -0,0 +1,13 @@
+-- reverse: drop "resource_versions" table
+CREATE TABLE "resource_versions" ("id" uuid NOT NULL, "resource_type" character varying NOT NULL, "resource_id" character varying NOT NULL, "version" bigint NOT NULL DEFAULT 1, "created_at" timestamptz NOT NULL, "updated_at" timestamptz NOT NULL, PRIMARY KEY ("id"));
+CREATE UNIQUE INDEX "resourceversion_resource_type_resource_id" ON "resource_versions" ("resource_type", "resource_id");
+-- reverse: copy the versions taken so far
+INSERT INTO "resource_versions" ("id", "resource_type", "resource_id", "version", "created_at", "updated_at") SELECT gen_random_uuid(), 'plaintes', "id"::text, "version", now(), now() FROM "plaintes" WHERE "version" > 1;
+INSERT INTO "resource_versions" ("id", "resource_type", "resource_id", "version", "created_at", "updated_at") SELECT gen_random_uuid(), 'alertes', "id"::text, "version", now(), now() FROM "alerte_securitaires" WHERE "version" > 1;
+INSERT INTO "resource_versions" ("id", "resource_type", "resource_id", "version", "created_at", "updated_at") SELECT gen_random_uuid(), 'convocations', "id"::text, "version", now(), now() FROM "convocations" WHERE "version" > 1;
+-- reverse: modify "convocations" table
+ALTER TABLE "convocations" DROP COLUMN "version";
+-- reverse: modify "alerte_securitaires" table
+ALTER TABLE "alerte_securitaires" DROP COLUMN "version";
+-- reverse: modify "plaintes" table
+ALTER TABLE "plaintes" DROP COLUMN "version";
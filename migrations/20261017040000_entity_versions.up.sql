@@ -0,0 +1,12 @@
+-- modify "plaintes" table
+ALTER TABLE "plaintes" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+-- modify "alerte_securitaires" table
+ALTER TABLE "alerte_securitaires" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+-- modify "convocations" table
+ALTER TABLE "convocations" ADD COLUMN "version" bigint NOT NULL DEFAULT 1;
+-- copy the versions taken so far, the ETags already sent stay valid
+UPDATE "plaintes" SET "version" = v."version" FROM "resource_versions" v WHERE v."resource_type" = 'plaintes' AND v."resource_id" = "plaintes"."id"::text;
+UPDATE "alerte_securitaires" SET "version" = v."version" FROM "resource_versions" v WHERE v."resource_type" = 'alertes' AND v."resource_id" = "alerte_securitaires"."id"::text;
+UPDATE "convocations" SET "version" = v."version" FROM "resource_versions" v WHERE v."resource_type" = 'convocations' AND v."resource_id" = "convocations"."id"::text;
+-- drop "resource_versions" table
+DROP TABLE "resource_versions";
@@ -0,0 +1 @@
+-- reverse: rename the legacy roles, the users who had them are not known anymore
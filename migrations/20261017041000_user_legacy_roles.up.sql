@@ -0,0 +1,3 @@
+-- rename the legacy roles to the built-in roles of the RBAC policy
+UPDATE "users" SET "role" = 'admin' WHERE "role" = 'commissaire';
+UPDATE "users" SET "role" = 'supervisor' WHERE "role" = 'superviseur';
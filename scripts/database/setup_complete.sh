@@ -58,7 +58,7 @@ echo "✅ Base de données prête"
 # Étape 3: Exécuter les migrations
 echo ""
 echo "3️⃣  Exécution des migrations Ent..."
-if ! go run ./cmd/migrate; then
+if ! go run ./cmd/migrate up; then
     echo "❌ Erreur lors des migrations"
     exit 1
 fi